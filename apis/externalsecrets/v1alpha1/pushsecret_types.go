@@ -20,11 +20,13 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	esmeta "github.com/external-secrets/external-secrets/apis/meta/v1"
 )
 
 const (
-	ReasonSynced  = "Synced"
-	ReasonErrored = "Errored"
+	ReasonSynced          = "Synced"
+	ReasonErrored         = "Errored"
+	ReasonPolicyViolation = "PolicyViolation"
 )
 
 type PushSecretStoreRef struct {
@@ -96,6 +98,11 @@ type PushSecretSpec struct {
 	// Template defines a blueprint for the created Secret resource.
 	// +optional
 	Template *esv1beta1.ExternalSecretTemplate `json:"template,omitempty"`
+
+	// Policy defines the length and complexity rules every pushed value must comply with.
+	// Nothing is pushed if a value does not comply.
+	// +optional
+	Policy *esmeta.SecretValuePolicy `json:"policy,omitempty"`
 }
 
 type PushSecretSecret struct {
@@ -34,6 +34,12 @@ var (
 	// SchemeBuilder is used to add go types to the GroupVersionKind scheme.
 	SchemeBuilder = &scheme.Builder{GroupVersion: SchemeGroupVersion}
 	AddToScheme   = SchemeBuilder.AddToScheme
+
+	// AlphaOnlySchemeBuilder only adds the kinds that have no other version,
+	// i.e. PushSecret and RemoteSecretDeletion. It is used if the v1alpha1 versions
+	// of ExternalSecret, SecretStore and ClusterSecretStore are disabled.
+	AlphaOnlySchemeBuilder = &scheme.Builder{GroupVersion: SchemeGroupVersion}
+	AddAlphaOnlyToScheme   = AlphaOnlySchemeBuilder.AddToScheme
 )
 
 // ExternalSecret type metadata.
@@ -67,9 +73,20 @@ var (
 	PushSecretGroupVersionKind = SchemeGroupVersion.WithKind(PushSecretKind)
 )
 
+var (
+	RemoteSecretDeletionKind             = reflect.TypeOf(RemoteSecretDeletion{}).Name()
+	RemoteSecretDeletionGroupKind        = schema.GroupKind{Group: Group, Kind: RemoteSecretDeletionKind}.String()
+	RemoteSecretDeletionKindAPIVersion   = RemoteSecretDeletionKind + "." + SchemeGroupVersion.String()
+	RemoteSecretDeletionGroupVersionKind = SchemeGroupVersion.WithKind(RemoteSecretDeletionKind)
+)
+
 func init() {
 	SchemeBuilder.Register(&ExternalSecret{}, &ExternalSecretList{})
 	SchemeBuilder.Register(&SecretStore{}, &SecretStoreList{})
 	SchemeBuilder.Register(&ClusterSecretStore{}, &ClusterSecretStoreList{})
 	SchemeBuilder.Register(&PushSecret{}, &PushSecretList{})
+	SchemeBuilder.Register(&RemoteSecretDeletion{}, &RemoteSecretDeletionList{})
+
+	AlphaOnlySchemeBuilder.Register(&PushSecret{}, &PushSecretList{})
+	AlphaOnlySchemeBuilder.Register(&RemoteSecretDeletion{}, &RemoteSecretDeletionList{})
 }
@@ -0,0 +1,34 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestAddAlphaOnlyToScheme(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, AddAlphaOnlyToScheme(scheme))
+
+	assert.True(t, scheme.Recognizes(PushSecretGroupVersionKind))
+	assert.True(t, scheme.Recognizes(RemoteSecretDeletionGroupVersionKind))
+	assert.False(t, scheme.Recognizes(ExtSecretGroupVersionKind))
+	assert.False(t, scheme.Recognizes(SecretStoreGroupVersionKind))
+	assert.False(t, scheme.Recognizes(ClusterSecretStoreGroupVersionKind))
+}
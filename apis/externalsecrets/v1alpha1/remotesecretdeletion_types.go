@@ -0,0 +1,156 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+)
+
+const (
+	ReasonDeleted = "Deleted"
+	ReasonDryRun  = "DryRun"
+)
+
+// RemoteSecretDeletionConfirmation confirms that the matching secrets are deleted.
+// +kubebuilder:validation:Enum=DeleteManagedSecrets
+type RemoteSecretDeletionConfirmation string
+
+const (
+	RemoteSecretDeletionConfirmed RemoteSecretDeletionConfirmation = "DeleteManagedSecrets"
+)
+
+// RemoteSecretDeletionFind selects the remote secrets to delete.
+// Name and Tags must both match if both are set.
+type RemoteSecretDeletionFind struct {
+	// A root path to start the find operations.
+	// +optional
+	Path *string `json:"path,omitempty"`
+
+	// Finds secrets based on the name.
+	// +optional
+	Name *esv1beta1.FindName `json:"name,omitempty"`
+
+	// Find secrets based on tags.
+	// +optional
+	Tags map[string]string `json:"tags,omitempty"`
+}
+
+// RemoteSecretDeletionSpec configures which secrets are deleted from which stores.
+type RemoteSecretDeletionSpec struct {
+	// SecretStoreRefs are the stores to delete the secrets from.
+	// +kubebuilder:validation:MinItems=1
+	SecretStoreRefs []esv1beta1.SecretStoreRef `json:"secretStoreRefs"`
+
+	// Find selects the secrets to delete, name or tags must be set.
+	// Only secrets that carry the managed-by marker of external-secrets are deleted.
+	Find RemoteSecretDeletionFind `json:"find"`
+
+	// DryRun only lists the secrets that would be deleted in the status.
+	// +optional
+	DryRun bool `json:"dryRun,omitempty"`
+
+	// Confirmation must be set to DeleteManagedSecrets,
+	// it protects against deleting secrets by accident.
+	Confirmation RemoteSecretDeletionConfirmation `json:"confirmation"`
+}
+
+// RemoteSecretDeletionStoreStatus is the result of the deletion in one store.
+type RemoteSecretDeletionStoreStatus struct {
+	// Name of the SecretStore resource
+	Name string `json:"name"`
+
+	// Kind of the SecretStore resource (SecretStore or ClusterSecretStore)
+	Kind string `json:"kind"`
+
+	// Secrets are the keys of the deleted secrets,
+	// or of the secrets that would be deleted with dryRun.
+	// +optional
+	Secrets []string `json:"secrets,omitempty"`
+
+	// Error is set if the secrets of this store could not be deleted.
+	// +optional
+	Error string `json:"error,omitempty"`
+}
+
+// RemoteSecretDeletionConditionType indicates the condition of the RemoteSecretDeletion.
+type RemoteSecretDeletionConditionType string
+
+const (
+	RemoteSecretDeletionReady RemoteSecretDeletionConditionType = "Ready"
+)
+
+// RemoteSecretDeletionStatusCondition indicates the status of the RemoteSecretDeletion.
+type RemoteSecretDeletionStatusCondition struct {
+	Type   RemoteSecretDeletionConditionType `json:"type"`
+	Status corev1.ConditionStatus            `json:"status"`
+
+	// +optional
+	Reason string `json:"reason,omitempty"`
+
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// +optional
+	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
+}
+
+// RemoteSecretDeletionStatus records the result of the last deletion.
+type RemoteSecretDeletionStatus struct {
+	// ObservedGeneration is the generation that was processed,
+	// the secrets are deleted once per generation.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// DryRun is true if the stores list the secrets that would be deleted.
+	// +optional
+	DryRun bool `json:"dryRun,omitempty"`
+
+	// +optional
+	Stores []RemoteSecretDeletionStoreStatus `json:"stores,omitempty"`
+
+	// +optional
+	Conditions []RemoteSecretDeletionStatusCondition `json:"conditions,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:storageversion
+// RemoteSecretDeletion deletes all secrets managed by external-secrets that match a filter
+// from the given stores, e.g. for teardown automation.
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:printcolumn:name="DryRun",type=boolean,JSONPath=`.spec.dryRun`
+// +kubebuilder:printcolumn:name="Status",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].reason`
+// +kubebuilder:subresource:status
+// +kubebuilder:metadata:labels="external-secrets.io/component=controller"
+// +kubebuilder:resource:scope=Namespaced,categories={external-secrets}
+
+type RemoteSecretDeletion struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   RemoteSecretDeletionSpec   `json:"spec,omitempty"`
+	Status RemoteSecretDeletionStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// RemoteSecretDeletionList contains a list of RemoteSecretDeletion resources.
+type RemoteSecretDeletionList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []RemoteSecretDeletion `json:"items"`
+}
@@ -1258,6 +1258,11 @@ func (in *PushSecretSpec) DeepCopyInto(out *PushSecretSpec) {
 		*out = new(v1beta1.ExternalSecretTemplate)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Policy != nil {
+		in, out := &in.Policy, &out.Policy
+		*out = new(metav1.SecretValuePolicy)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PushSecretSpec.
@@ -1347,6 +1352,183 @@ func (in *PushSecretStoreRef) DeepCopy() *PushSecretStoreRef {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemoteSecretDeletion) DeepCopyInto(out *RemoteSecretDeletion) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemoteSecretDeletion.
+func (in *RemoteSecretDeletion) DeepCopy() *RemoteSecretDeletion {
+	if in == nil {
+		return nil
+	}
+	out := new(RemoteSecretDeletion)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RemoteSecretDeletion) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemoteSecretDeletionFind) DeepCopyInto(out *RemoteSecretDeletionFind) {
+	*out = *in
+	if in.Path != nil {
+		in, out := &in.Path, &out.Path
+		*out = new(string)
+		**out = **in
+	}
+	if in.Name != nil {
+		in, out := &in.Name, &out.Name
+		*out = new(v1beta1.FindName)
+		**out = **in
+	}
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemoteSecretDeletionFind.
+func (in *RemoteSecretDeletionFind) DeepCopy() *RemoteSecretDeletionFind {
+	if in == nil {
+		return nil
+	}
+	out := new(RemoteSecretDeletionFind)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemoteSecretDeletionList) DeepCopyInto(out *RemoteSecretDeletionList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]RemoteSecretDeletion, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemoteSecretDeletionList.
+func (in *RemoteSecretDeletionList) DeepCopy() *RemoteSecretDeletionList {
+	if in == nil {
+		return nil
+	}
+	out := new(RemoteSecretDeletionList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RemoteSecretDeletionList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemoteSecretDeletionSpec) DeepCopyInto(out *RemoteSecretDeletionSpec) {
+	*out = *in
+	if in.SecretStoreRefs != nil {
+		in, out := &in.SecretStoreRefs, &out.SecretStoreRefs
+		*out = make([]v1beta1.SecretStoreRef, len(*in))
+		copy(*out, *in)
+	}
+	in.Find.DeepCopyInto(&out.Find)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemoteSecretDeletionSpec.
+func (in *RemoteSecretDeletionSpec) DeepCopy() *RemoteSecretDeletionSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RemoteSecretDeletionSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemoteSecretDeletionStatus) DeepCopyInto(out *RemoteSecretDeletionStatus) {
+	*out = *in
+	if in.Stores != nil {
+		in, out := &in.Stores, &out.Stores
+		*out = make([]RemoteSecretDeletionStoreStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]RemoteSecretDeletionStatusCondition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemoteSecretDeletionStatus.
+func (in *RemoteSecretDeletionStatus) DeepCopy() *RemoteSecretDeletionStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RemoteSecretDeletionStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemoteSecretDeletionStatusCondition) DeepCopyInto(out *RemoteSecretDeletionStatusCondition) {
+	*out = *in
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemoteSecretDeletionStatusCondition.
+func (in *RemoteSecretDeletionStatusCondition) DeepCopy() *RemoteSecretDeletionStatusCondition {
+	if in == nil {
+		return nil
+	}
+	out := new(RemoteSecretDeletionStatusCondition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemoteSecretDeletionStoreStatus) DeepCopyInto(out *RemoteSecretDeletionStoreStatus) {
+	*out = *in
+	if in.Secrets != nil {
+		in, out := &in.Secrets, &out.Secrets
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemoteSecretDeletionStoreStatus.
+func (in *RemoteSecretDeletionStoreStatus) DeepCopy() *RemoteSecretDeletionStoreStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RemoteSecretDeletionStoreStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SecretStore) DeepCopyInto(out *SecretStore) {
 	*out = *in
@@ -67,7 +67,12 @@ type ExternalSecretMetadata struct {
 
 type ClusterExternalSecretConditionType string
 
-const ClusterExternalSecretReady ClusterExternalSecretConditionType = "Ready"
+const (
+	// ClusterExternalSecretReady indicates that the ExternalSecrets were applied to all target namespaces.
+	ClusterExternalSecretReady ClusterExternalSecretConditionType = "Ready"
+	// ClusterExternalSecretSynced indicates that the ExternalSecrets of all target namespaces are Ready.
+	ClusterExternalSecretSynced ClusterExternalSecretConditionType = "Synced"
+)
 
 type ClusterExternalSecretStatusCondition struct {
 	Type   ClusterExternalSecretConditionType `json:"type"`
@@ -101,10 +106,31 @@ type ClusterExternalSecretStatus struct {
 	// +optional
 	ProvisionedNamespaces []string `json:"provisionedNamespaces,omitempty"`
 
+	// Summary counts the target namespaces by the sync state of their ExternalSecret.
+	// +optional
+	Summary *ClusterExternalSecretSummary `json:"summary,omitempty"`
+
 	// +optional
 	Conditions []ClusterExternalSecretStatusCondition `json:"conditions,omitempty"`
 }
 
+// ClusterExternalSecretSummary counts the target namespaces of a ClusterExternalSecret.
+type ClusterExternalSecretSummary struct {
+	// Total is the number of target namespaces.
+	Total int `json:"total"`
+
+	// Synced is the number of namespaces whose ExternalSecret is Ready.
+	Synced int `json:"synced"`
+
+	// Failed is the number of namespaces where the ExternalSecret could not be applied
+	// or failed to sync.
+	Failed int `json:"failed"`
+
+	// Pending is the number of namespaces whose ExternalSecret has not been synced yet
+	// or waits for a generator, dependency or a terminating namespace.
+	Pending int `json:"pending"`
+}
+
 // +kubebuilder:object:root=true
 // +kubebuilder:storageversion
 // +kubebuilder:resource:scope=Cluster,categories={external-secrets},shortName=ces
@@ -113,6 +139,7 @@ type ClusterExternalSecretStatus struct {
 // +kubebuilder:printcolumn:name="Store",type=string,JSONPath=`.spec.externalSecretSpec.secretStoreRef.name`
 // +kubebuilder:printcolumn:name="Refresh Interval",type=string,JSONPath=`.spec.refreshTime`
 // +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+// +kubebuilder:printcolumn:name="Synced",type=string,JSONPath=`.status.conditions[?(@.type=="Synced")].status`
 // ClusterExternalSecret is the Schema for the clusterexternalsecrets API.
 type ClusterExternalSecret struct {
 	metav1.TypeMeta   `json:",inline"`
@@ -0,0 +1,168 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// SecretPreviewMode defines when the validating webhook returns a preview of the target secret.
+type SecretPreviewMode string
+
+const (
+	SecretPreviewNever SecretPreviewMode = "Never"
+	// SecretPreviewDryRun only returns the preview for dry-run requests, e.g. `kubectl apply --dry-run=server`.
+	SecretPreviewDryRun SecretPreviewMode = "DryRun"
+	SecretPreviewAlways SecretPreviewMode = "Always"
+)
+
+const (
+	previewPrefix         = "preview: "
+	previewResolvedAtSync = "resolved at sync"
+)
+
+// PreviewSecret describes the target secret of the ExternalSecret as admission warnings.
+// Only the parts of the spec that can be resolved without a provider are included,
+// e.g. the type and metadata of the template and the static keys. Values are never included,
+// keys that depend on a provider, a generator or a referenced template are listed as resolved at sync.
+func PreviewSecret(es *ExternalSecret) admission.Warnings {
+	target := es.Spec.Target
+	name := target.Name
+	if name == "" {
+		name = es.Name
+	}
+	if target.CreationPolicy == CreatePolicyNone {
+		return admission.Warnings{previewPrefix + fmt.Sprintf("secret %s is not written (creationPolicy=None)", name)}
+	}
+
+	var warnings admission.Warnings
+	add := func(format string, args ...any) {
+		warnings = append(warnings, previewPrefix+fmt.Sprintf(format, args...))
+	}
+
+	tpl := target.Template
+	secretType := corev1.SecretTypeOpaque
+	if tpl != nil && tpl.Type != "" {
+		secretType = tpl.Type
+	}
+	add("secret %s of type %s", name, secretType)
+	if target.CreationPolicy == CreatePolicyMerge {
+		add("the existing keys of secret %s are kept (creationPolicy=Merge)", name)
+	}
+	if ref := target.TemplateRef; ref != nil {
+		add("template of ConfigMap %s is %s", ref.Name, previewResolvedAtSync)
+	}
+
+	labels, annotations := es.Labels, es.Annotations
+	if tpl != nil {
+		labels, annotations = tpl.Metadata.Labels, tpl.Metadata.Annotations
+	}
+	if len(labels) > 0 {
+		add("labels %s", previewLabels(labels))
+	}
+	if len(annotations) > 0 {
+		add("annotations %s", strings.Join(slices.Sorted(maps.Keys(annotations)), ", "))
+	}
+
+	// the fetched data is written unless a data template replaces it
+	writesData := tpl == nil || tpl.MergePolicy == MergePolicyMerge || (len(tpl.Data) == 0 && len(tpl.TemplateFrom) == 0)
+	if tpl != nil && tpl.EnabledKey != "" {
+		add("the template is only applied if key %s is true, otherwise the fetched keys are written", tpl.EnabledKey)
+		writesData = true
+	}
+	keys := make(map[string]struct{})
+	if writesData {
+		for _, data := range es.Spec.Data {
+			for _, key := range previewDataKeys(data) {
+				keys[key] = struct{}{}
+			}
+		}
+	}
+	if tpl != nil {
+		for key := range tpl.Data {
+			keys[key] = struct{}{}
+		}
+	}
+	if len(keys) > 0 {
+		add("keys %s", strings.Join(slices.Sorted(maps.Keys(keys)), ", "))
+	}
+	if writesData {
+		for i, ref := range es.Spec.DataFrom {
+			add("keys of dataFrom[%d] (%s) are %s", i, previewDataFrom(ref), previewResolvedAtSync)
+		}
+	}
+	if tpl != nil {
+		for i, from := range tpl.TemplateFrom {
+			add("%s of templateFrom[%d] are %s", previewTemplateTarget(from.Target), i, previewResolvedAtSync)
+		}
+	}
+	return warnings
+}
+
+// previewDataKeys returns the keys written by an entry of spec.data.
+func previewDataKeys(data ExternalSecretData) []string {
+	if len(data.RemoteRef.Properties) > 0 {
+		return slices.Sorted(maps.Keys(data.RemoteRef.Properties))
+	}
+	if data.RemoteRef.Split != "" {
+		// an invalid expression is reported by validateSplit
+		_, keys, _ := CompileSplit(data.RemoteRef.Split)
+		return keys
+	}
+	return []string{data.SecretKey}
+}
+
+// previewLabels lists the labels, values that are templates are rendered at sync.
+func previewLabels(labels map[string]string) string {
+	parts := make([]string, 0, len(labels))
+	for _, key := range slices.Sorted(maps.Keys(labels)) {
+		value := labels[key]
+		if strings.Contains(value, "{{") {
+			value = "<" + previewResolvedAtSync + ">"
+		}
+		parts = append(parts, key+"="+value)
+	}
+	return strings.Join(parts, ", ")
+}
+
+func previewDataFrom(ref ExternalSecretDataFromRemoteRef) string {
+	switch {
+	case ref.Extract != nil:
+		return "extract key " + ref.Extract.Key
+	case ref.Find != nil:
+		return "find"
+	case ref.SourceRef != nil && ref.SourceRef.GeneratorRef != nil:
+		return fmt.Sprintf("generator %s/%s", ref.SourceRef.GeneratorRef.Kind, ref.SourceRef.GeneratorRef.Name)
+	default:
+		return "unknown source"
+	}
+}
+
+func previewTemplateTarget(target TemplateTarget) string {
+	switch target {
+	case TemplateTargetLabels:
+		return "labels"
+	case TemplateTargetAnnotations:
+		return "annotations"
+	default:
+		return "keys"
+	}
+}
@@ -0,0 +1,163 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	admissionv1 "k8s.io/api/admission/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+func TestPreviewSecret(t *testing.T) {
+	data := []ExternalSecretData{
+		{SecretKey: "password", RemoteRef: ExternalSecretDataRemoteRef{Key: "db"}},
+		{RemoteRef: ExternalSecretDataRemoteRef{Key: "db", Properties: map[string]string{"user": "u", "host": "h"}}},
+	}
+	dataFrom := []ExternalSecretDataFromRemoteRef{
+		{Extract: &ExternalSecretDataRemoteRef{Key: "shared"}},
+		{SourceRef: &StoreGeneratorSourceRef{GeneratorRef: &GeneratorRef{Kind: "Password", Name: "pw"}}},
+	}
+	tests := []struct {
+		name string
+		es   *ExternalSecret
+		want admission.Warnings
+	}{
+		{
+			name: "no template",
+			es: &ExternalSecret{
+				ObjectMeta: metav1.ObjectMeta{Name: "es", Labels: map[string]string{"team": "a"}},
+				Spec:       ExternalSecretSpec{Data: data, DataFrom: dataFrom},
+			},
+			want: admission.Warnings{
+				"preview: secret es of type Opaque",
+				"preview: labels team=a",
+				"preview: keys host, password, user",
+				"preview: keys of dataFrom[0] (extract key shared) are resolved at sync",
+				"preview: keys of dataFrom[1] (generator Password/pw) are resolved at sync",
+			},
+		},
+		{
+			name: "template replaces the data",
+			es: &ExternalSecret{
+				ObjectMeta: metav1.ObjectMeta{Name: "es", Labels: map[string]string{"team": "a"}},
+				Spec: ExternalSecretSpec{
+					Target: ExternalSecretTarget{
+						Name: "tls",
+						Template: &ExternalSecretTemplate{
+							Type: corev1.SecretTypeTLS,
+							Metadata: ExternalSecretTemplateMetadata{
+								Labels:      map[string]string{"env": "prod", "owner": "{{ .owner }}"},
+								Annotations: map[string]string{"note": "a long value that is not shown"},
+							},
+							Data:         map[string]string{"tls.crt": "{{ .cert }}", "tls.key": "{{ .key }}"},
+							TemplateFrom: []TemplateFrom{{Target: TemplateTargetAnnotations}},
+						},
+					},
+					Data:     data,
+					DataFrom: dataFrom,
+				},
+			},
+			want: admission.Warnings{
+				"preview: secret tls of type kubernetes.io/tls",
+				"preview: labels env=prod, owner=<resolved at sync>",
+				"preview: annotations note",
+				"preview: keys tls.crt, tls.key",
+				"preview: annotations of templateFrom[0] are resolved at sync",
+			},
+		},
+		{
+			name: "merged template",
+			es: &ExternalSecret{
+				ObjectMeta: metav1.ObjectMeta{Name: "es"},
+				Spec: ExternalSecretSpec{
+					Target: ExternalSecretTarget{
+						CreationPolicy: CreatePolicyMerge,
+						TemplateRef:    &ExternalSecretTemplateRef{Name: "base"},
+						Template: &ExternalSecretTemplate{
+							MergePolicy: MergePolicyMerge,
+							Data:        map[string]string{"dsn": "{{ .user }}@{{ .host }}"},
+						},
+					},
+					Data: data,
+				},
+			},
+			want: admission.Warnings{
+				"preview: secret es of type Opaque",
+				"preview: the existing keys of secret es are kept (creationPolicy=Merge)",
+				"preview: template of ConfigMap base is resolved at sync",
+				"preview: keys dsn, host, password, user",
+			},
+		},
+		{
+			name: "creationPolicy None",
+			es: &ExternalSecret{
+				ObjectMeta: metav1.ObjectMeta{Name: "es"},
+				Spec:       ExternalSecretSpec{Target: ExternalSecretTarget{CreationPolicy: CreatePolicyNone}, Data: data},
+			},
+			want: admission.Warnings{"preview: secret es is not written (creationPolicy=None)"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if diff := cmp.Diff(tt.want, PreviewSecret(tt.es)); diff != "" {
+				t.Errorf("unexpected preview (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestValidatorSecretPreview(t *testing.T) {
+	es := &ExternalSecret{
+		ObjectMeta: metav1.ObjectMeta{Name: "es"},
+		Spec: ExternalSecretSpec{
+			Data: []ExternalSecretData{{SecretKey: "password", RemoteRef: ExternalSecretDataRemoteRef{Key: "db"}}},
+		},
+	}
+	request := func(dryRun bool) context.Context {
+		return admission.NewContextWithRequest(context.Background(), admission.Request{
+			AdmissionRequest: admissionv1.AdmissionRequest{DryRun: ptr.To(dryRun)},
+		})
+	}
+	tests := []struct {
+		name  string
+		mode  SecretPreviewMode
+		ctx   context.Context
+		es    *ExternalSecret
+		count int
+	}{
+		{name: "disabled by default", ctx: request(true), es: es},
+		{name: "never", mode: SecretPreviewNever, ctx: request(true), es: es},
+		{name: "dry run", mode: SecretPreviewDryRun, ctx: request(true), es: es, count: 2},
+		{name: "not a dry run", mode: SecretPreviewDryRun, ctx: request(false), es: es},
+		{name: "no request", mode: SecretPreviewDryRun, ctx: context.Background(), es: es},
+		{name: "always", mode: SecretPreviewAlways, ctx: request(false), es: es, count: 2},
+		{name: "invalid object", mode: SecretPreviewAlways, ctx: request(false), es: &ExternalSecret{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			esv := &ExternalSecretValidator{SecretPreview: tt.mode}
+			warnings, _ := esv.ValidateCreate(tt.ctx, tt.es)
+			if len(warnings) != tt.count {
+				t.Errorf("ValidateCreate() returned %d warnings, want %d: %v", len(warnings), tt.count, warnings)
+			}
+		})
+	}
+}
@@ -0,0 +1,34 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"fmt"
+	"slices"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// CheckSecretTypeAllowed returns an error if secretType is not in allowed.
+// An empty type is treated as Opaque, every type is allowed if allowed is empty.
+func CheckSecretTypeAllowed(allowed []corev1.SecretType, secretType corev1.SecretType) error {
+	if secretType == "" {
+		secretType = corev1.SecretTypeOpaque
+	}
+	if len(allowed) == 0 || slices.Contains(allowed, secretType) {
+		return nil
+	}
+	return fmt.Errorf("secret type %s is not allowed, allowed types: %v", secretType, allowed)
+}
@@ -0,0 +1,42 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"errors"
+	"regexp"
+	"slices"
+)
+
+var errSplitNoGroups = errors.New("split must contain at least one named capture group")
+
+// CompileSplit compiles the regular expression of remoteRef.split and returns it
+// together with the names of its capture groups, which are the keys the value is split into.
+func CompileSplit(expr string) (*regexp.Regexp, []string, error) {
+	re, err := regexp.Compile(expr)
+	if err != nil {
+		return nil, nil, err
+	}
+	var keys []string
+	for _, name := range re.SubexpNames() {
+		if name != "" && !slices.Contains(keys, name) {
+			keys = append(keys, name)
+		}
+	}
+	if len(keys) == 0 {
+		return nil, nil, errSplitNoGroups
+	}
+	return re, keys, nil
+}
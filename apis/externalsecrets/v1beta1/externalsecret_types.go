@@ -15,8 +15,12 @@ limitations under the License.
 package v1beta1
 
 import (
+	"time"
+
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	esmeta "github.com/external-secrets/external-secrets/apis/meta/v1"
 )
 
 // SecretStoreRef defines which SecretStore to fetch the ExternalSecret data.
@@ -35,7 +39,7 @@ type SecretStoreRef struct {
 }
 
 // ExternalSecretCreationPolicy defines rules on how to create the resulting Secret.
-// +kubebuilder:validation:Enum=Owner;Orphan;Merge;None
+// +kubebuilder:validation:Enum=Owner;Orphan;Merge;StrictMerge;None
 type ExternalSecretCreationPolicy string
 
 const (
@@ -49,6 +53,11 @@ const (
 	// Merge does not create the Secret, but merges the data fields to the Secret.
 	CreatePolicyMerge ExternalSecretCreationPolicy = "Merge"
 
+	// StrictMerge does not create the Secret, but applies only the data fields of the ExternalSecret
+	// to the Secret with server-side apply. Fields owned by other managers are never taken over,
+	// a conflicting key fails the sync instead.
+	CreatePolicyStrictMerge ExternalSecretCreationPolicy = "StrictMerge"
+
 	// None does not create a Secret (future use with injector).
 	CreatePolicyNone ExternalSecretCreationPolicy = "None"
 )
@@ -76,6 +85,25 @@ const (
 	DeletionPolicyRetain ExternalSecretDeletionPolicy = "Retain"
 )
 
+// ExternalSecretOnErrorPolicy defines what happens to the resulting Secret
+// when the provider data can not be fetched for longer than a threshold.
+// +kubebuilder:validation:Enum=Retain;Clear;Delete
+type ExternalSecretOnErrorPolicy string
+
+const (
+	// OnErrorRetain keeps the Secret with its last known data.
+	OnErrorRetain ExternalSecretOnErrorPolicy = "Retain"
+
+	// OnErrorClear removes all data from the Secret, the Secret itself is kept.
+	OnErrorClear ExternalSecretOnErrorPolicy = "Clear"
+
+	// OnErrorDelete deletes the Secret.
+	OnErrorDelete ExternalSecretOnErrorPolicy = "Delete"
+)
+
+// DefaultOnErrorAfter is the time a sync must be failing before the onError policy is applied.
+const DefaultOnErrorAfter = time.Hour
+
 // ExternalSecretTemplateMetadata defines metadata fields for the Secret blueprint.
 type ExternalSecretTemplateMetadata struct {
 	// +optional
@@ -108,6 +136,12 @@ type ExternalSecretTemplate struct {
 
 	// +optional
 	TemplateFrom []TemplateFrom `json:"templateFrom,omitempty"`
+
+	// EnabledKey gates the template on a key of the fetched data, e.g. to roll out a new template.
+	// The template is only applied if the value of the key is true (as accepted by strconv.ParseBool),
+	// otherwise the fetched data is written as if no template was defined.
+	// +optional
+	EnabledKey string `json:"enabledKey,omitempty"`
 }
 
 // +kubebuilder:validation:Enum=Replace;Merge
@@ -177,6 +211,24 @@ type TemplateRefItem struct {
 	TemplateAs TemplateScope `json:"templateAs,omitempty"`
 }
 
+// ExternalSecretTemplateRef points to a base template stored as YAML in a ConfigMap
+// in the namespace of the ExternalSecret.
+type ExternalSecretTemplateRef struct {
+	// The name of the ConfigMap resource
+	// +kubebuilder:validation:MinLength:=1
+	// +kubebuilder:validation:MaxLength:=253
+	// +kubebuilder:validation:Pattern:=^[a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*$
+	Name string `json:"name"`
+
+	// The key in the ConfigMap holding the template
+	// +optional
+	// +kubebuilder:default="template"
+	// +kubebuilder:validation:MinLength:=1
+	// +kubebuilder:validation:MaxLength:=253
+	// +kubebuilder:validation:Pattern:=^[-._a-zA-Z0-9]+$
+	Key string `json:"key,omitempty"`
+}
+
 // ExternalSecretTarget defines the Kubernetes Secret to be created
 // There can be only one target per ExternalSecret.
 type ExternalSecretTarget struct {
@@ -200,22 +252,192 @@ type ExternalSecretTarget struct {
 	// +kubebuilder:default="Retain"
 	DeletionPolicy ExternalSecretDeletionPolicy `json:"deletionPolicy,omitempty"`
 
+	// OnError defines what happens to the Secret when the sync keeps failing for longer than OnErrorAfter.
+	// Defaults to "Retain"
+	// +optional
+	// +kubebuilder:default="Retain"
+	OnError ExternalSecretOnErrorPolicy `json:"onError,omitempty"`
+
+	// OnErrorAfter is the time a sync must be failing before the OnError policy is applied.
+	// Defaults to 1h
+	// +optional
+	OnErrorAfter *metav1.Duration `json:"onErrorAfter,omitempty"`
+
+	// OrphanGracePeriod is the time the previous Secret is kept after the target name changed,
+	// so consumers that still mount it can switch to the new name before it is deleted.
+	// Only used with CreationPolicy=Owner. Defaults to 0, the previous Secret is deleted immediately.
+	// +optional
+	OrphanGracePeriod *metav1.Duration `json:"orphanGracePeriod,omitempty"`
+
 	// Template defines a blueprint for the created Secret resource.
 	// +optional
 	Template *ExternalSecretTemplate `json:"template,omitempty"`
 
+	// TemplateRef points to a ConfigMap holding a base template that is shared by many ExternalSecrets.
+	// The inline template is merged on top of it, inline fields take precedence.
+	// +optional
+	TemplateRef *ExternalSecretTemplateRef `json:"templateRef,omitempty"`
+
 	// Immutable defines if the final secret will be immutable
 	// +optional
 	Immutable bool `json:"immutable,omitempty"`
+
+	// DisableOwnerReference prevents setting an owner reference on the resulting Secret
+	// when CreationPolicy is Owner. The Secret is still labeled as owned by this ExternalSecret
+	// and deleted through a finalizer when the ExternalSecret is deleted.
+	// +optional
+	DisableOwnerReference bool `json:"disableOwnerReference,omitempty"`
+
+	// Bundle combines multiple keys of the provider data into a single key of the Secret,
+	// e.g. to assemble one config file from many secrets.
+	// +optional
+	Bundle *ExternalSecretBundle `json:"bundle,omitempty"`
+
+	// KeyPrefix is prepended to every key fetched with data and dataFrom, after rewrite.
+	// Templates and bundles reference the prefixed keys.
+	// +optional
+	// +kubebuilder:validation:MaxLength:=253
+	// +kubebuilder:validation:Pattern:=^[-._a-zA-Z0-9]*$
+	KeyPrefix string `json:"keyPrefix,omitempty"`
+
+	// KeySuffix is appended to every key fetched with data and dataFrom, after rewrite.
+	// Templates and bundles reference the suffixed keys.
+	// +optional
+	// +kubebuilder:validation:MaxLength:=253
+	// +kubebuilder:validation:Pattern:=^[-._a-zA-Z0-9]*$
+	KeySuffix string `json:"keySuffix,omitempty"`
+
+	// SizeLimits limits the size of the values of the Secret, they are checked before the Secret is written.
+	// Without limits the data of the Secret must not exceed 1MiB, the size limit of Kubernetes.
+	// +optional
+	SizeLimits *ExternalSecretSizeLimits `json:"sizeLimits,omitempty"`
+
+	// Encoding is a hint for the values of the Secret. With Text, keys whose values are not valid UTF-8
+	// are listed in the reconcile.external-secrets.io/non-utf8-keys annotation of the Secret
+	// and reported with a warning event. The Secret is written in any case.
+	// Defaults to "Binary", which accepts any value.
+	// +optional
+	Encoding ExternalSecretTargetEncoding `json:"encoding,omitempty"`
+
+	// MetadataAnnotations selects metadata reported by the providers, e.g. the backend or the time of the read,
+	// that is written to the metadata.reconcile.external-secrets.io/<key> annotations of the Secret.
+	// Keys that are not reported by the providers are not written.
+	// Keys that look sensitive, e.g. containing "token" or "password", are rejected.
+	// +optional
+	// +kubebuilder:validation:items:Pattern:=^[a-zA-Z0-9]([-._a-zA-Z0-9]*[a-zA-Z0-9])?$
+	// +kubebuilder:validation:items:MaxLength:=63
+	MetadataAnnotations []string `json:"metadataAnnotations,omitempty"`
+
+	// CopyProviderTagsToLabels writes the tags of the secrets read by spec.data and spec.dataFrom[].extract,
+	// e.g. AWS tags, Azure tags or GCP labels, to the labels of the Secret.
+	// The tags are read with metadataPolicy Fetch, so the provider must support it.
+	// Tag keys and values are sanitized to valid label keys and values, tags that can not be sanitized are skipped.
+	// Labels of the template or the ExternalSecret take precedence.
+	// +optional
+	CopyProviderTagsToLabels bool `json:"copyProviderTagsToLabels,omitempty"`
+
+	// EncryptWith encrypts every value of the Secret for the given recipients before it is written,
+	// e.g. for clusters without encryption at rest. The values must be decrypted by the consumer,
+	// e.g. by a sidecar when the pod starts. Can only be used with creationPolicy Owner or Orphan.
+	// +optional
+	EncryptWith *ExternalSecretEncryption `json:"encryptWith,omitempty"`
+}
+
+// ExternalSecretTargetEncoding describes the expected encoding of the values of the Secret.
+// +kubebuilder:validation:Enum=Binary;Text
+type ExternalSecretTargetEncoding string
+
+const (
+	// TargetEncodingBinary accepts any value.
+	TargetEncodingBinary ExternalSecretTargetEncoding = "Binary"
+
+	// TargetEncodingText expects the values to be UTF-8 text, other values are flagged.
+	TargetEncodingText ExternalSecretTargetEncoding = "Text"
+)
+
+// ExternalSecretSizeLimits defines the maximum size of the values of the Secret.
+type ExternalSecretSizeLimits struct {
+	// MaxKeySize is the maximum size of a single value in bytes.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	MaxKeySize *int64 `json:"maxKeySize,omitempty"`
+
+	// MaxTotalSize is the maximum size of all values in bytes.
+	// Defaults to 1048576, the size limit of a Kubernetes Secret.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=1048576
+	MaxTotalSize *int64 `json:"maxTotalSize,omitempty"`
+
+	// TruncateKeys are keys whose values are truncated to maxKeySize instead of failing the sync.
+	// Requires maxKeySize.
+	// +optional
+	TruncateKeys []string `json:"truncateKeys,omitempty"`
+}
+
+// +kubebuilder:validation:Enum=Concat;YAMLMerge;JSONMerge
+type ExternalSecretBundleFormat string
+
+const (
+	// BundleFormatConcat joins the values with a separator.
+	BundleFormatConcat ExternalSecretBundleFormat = "Concat"
+	// BundleFormatYAMLMerge deep merges YAML mappings.
+	BundleFormatYAMLMerge ExternalSecretBundleFormat = "YAMLMerge"
+	// BundleFormatJSONMerge deep merges JSON objects.
+	BundleFormatJSONMerge ExternalSecretBundleFormat = "JSONMerge"
+)
+
+// +kubebuilder:validation:Enum=Override;Error
+type ExternalSecretBundleConflictPolicy string
+
+const (
+	// BundleConflictOverride lets later sources override values of earlier sources.
+	BundleConflictOverride ExternalSecretBundleConflictPolicy = "Override"
+	// BundleConflictError fails if sources set different values for the same key.
+	BundleConflictError ExternalSecretBundleConflictPolicy = "Error"
+)
+
+// ExternalSecretBundle defines how multiple keys are combined into a single key.
+type ExternalSecretBundle struct {
+	// Key of the Secret the bundle is written to.
+	// It overrides a key with the same name from data, dataFrom or the template.
+	// +kubebuilder:validation:MinLength:=1
+	// +kubebuilder:validation:MaxLength:=253
+	// +kubebuilder:validation:Pattern:=^[-._a-zA-Z0-9]+$
+	Key string `json:"key"`
+
+	// Sources are the keys of the provider data that are combined, in the given order.
+	// +kubebuilder:validation:MinItems:=1
+	Sources []string `json:"sources"`
+
+	// Format defines how the sources are combined.
+	// Defaults to "Concat"
+	// +optional
+	// +kubebuilder:default="Concat"
+	Format ExternalSecretBundleFormat `json:"format,omitempty"`
+
+	// Separator is written between the values if format is Concat.
+	// Defaults to a newline.
+	// +optional
+	Separator *string `json:"separator,omitempty"`
+
+	// ConflictPolicy defines what happens if merged sources set different values for the same key.
+	// Defaults to "Override"
+	// +optional
+	// +kubebuilder:default="Override"
+	ConflictPolicy ExternalSecretBundleConflictPolicy `json:"conflictPolicy,omitempty"`
 }
 
 // ExternalSecretData defines the connection between the Kubernetes Secret key (spec.data.<key>) and the Provider data.
+// +kubebuilder:validation:XValidation:rule="has(self.secretKey) || has(self.remoteRef.properties) || has(self.remoteRef.split)",message="secretKey must be set unless remoteRef.properties or remoteRef.split is used"
 type ExternalSecretData struct {
 	// The key in the Kubernetes Secret to store the value.
+	// Required, unless remoteRef.properties or remoteRef.split is used, then it must not be set.
+	// +optional
 	// +kubebuilder:validation:MinLength:=1
 	// +kubebuilder:validation:MaxLength:=253
 	// +kubebuilder:validation:Pattern:=^[-._a-zA-Z0-9]+$
-	SecretKey string `json:"secretKey"`
+	SecretKey string `json:"secretKey,omitempty"`
 
 	// RemoteRef points to the remote secret and defines
 	// which secret (version/property/..) to fetch.
@@ -224,13 +446,61 @@ type ExternalSecretData struct {
 	// SourceRef allows you to override the source
 	// from which the value will be pulled.
 	SourceRef *StoreSourceRef `json:"sourceRef,omitempty"`
+
+	// ContinueOnError keeps syncing the other keys if this entry can not be fetched.
+	// The entry is left out of the Secret, its error is recorded in status.sources
+	// and the ExternalSecret is marked as PartiallySynced.
+	// +optional
+	ContinueOnError bool `json:"continueOnError,omitempty"`
+
+	// Decoders are applied in order to the value after remoteRef.decodingStrategy,
+	// e.g. `[Base64, Gunzip]` for a value that was compressed and then encoded.
+	// +optional
+	// +kubebuilder:validation:MaxItems:=8
+	Decoders []ExternalSecretDecoder `json:"decoders,omitempty"`
+
+	// Sensitivity marks the keys of this entry for downstream tooling. The keys are listed in the
+	// reconcile.external-secrets.io/sensitive-keys annotation of the Secret, Encrypted keys also in
+	// reconcile.external-secrets.io/encrypted-keys. It does not change how the value is stored.
+	// +optional
+	Sensitivity ExternalSecretKeySensitivity `json:"sensitivity,omitempty"`
 }
 
+// +kubebuilder:validation:Enum=None;Sensitive;Encrypted
+type ExternalSecretKeySensitivity string
+
+const (
+	// KeySensitivityNone does not mark the keys, it is the default.
+	KeySensitivityNone ExternalSecretKeySensitivity = "None"
+	// KeySensitivitySensitive marks values that must not be logged.
+	KeySensitivitySensitive ExternalSecretKeySensitivity = "Sensitive"
+	// KeySensitivityEncrypted marks values that are already encrypted by the provider
+	// and must neither be logged nor encrypted again.
+	KeySensitivityEncrypted ExternalSecretKeySensitivity = "Encrypted"
+)
+
+// +kubebuilder:validation:Enum=Base64;Base64URL;Hex;Gunzip;Zlib
+type ExternalSecretDecoder string
+
+const (
+	ExternalSecretDecoderBase64    ExternalSecretDecoder = "Base64"
+	ExternalSecretDecoderBase64URL ExternalSecretDecoder = "Base64URL"
+	ExternalSecretDecoderHex       ExternalSecretDecoder = "Hex"
+	ExternalSecretDecoderGunzip    ExternalSecretDecoder = "Gunzip"
+	ExternalSecretDecoderZlib      ExternalSecretDecoder = "Zlib"
+)
+
 // ExternalSecretDataRemoteRef defines Provider data location.
 type ExternalSecretDataRemoteRef struct {
 	// Key is the key used in the Provider, mandatory
 	Key string `json:"key"`
 
+	// +optional
+	// KeyCandidates are tried in order if the secret does not exist under key,
+	// the first key that exists is used, e.g. during the migration to a new key.
+	// Only used in data.
+	KeyCandidates []string `json:"keyCandidates,omitempty"`
+
 	// +optional
 	// Policy for fetching tags/labels from provider secrets, possible options are Fetch, None. Defaults to None
 	// +kubebuilder:default="None"
@@ -241,7 +511,42 @@ type ExternalSecretDataRemoteRef struct {
 	Property string `json:"property,omitempty"`
 
 	// +optional
-	// Used to select a specific version of the Provider value, if supported
+	// Used to select a value of the Provider value with a RFC 6901 JSON Pointer, e.g. `/keys/0/value`.
+	// It is evaluated by the controller on the whole value and works with all providers.
+	// Can not be combined with property.
+	PropertyPointer string `json:"propertyPointer,omitempty"`
+
+	// +optional
+	// Maps keys of the Kubernetes Secret to properties of the Provider value, e.g. `{"username": "db.user"}`.
+	// The value is fetched once and every property is selected by the controller with a JSON path.
+	// Only used in data, can not be combined with secretKey, property or propertyPointer.
+	Properties map[string]string `json:"properties,omitempty"`
+
+	// +optional
+	// Split is a regular expression with named capture groups, e.g. `^(?P<user>[^:]+):(?P<pass>[^@]+)@(?P<host>[^:]+):(?P<port>[0-9]+)$`.
+	// The value is matched after decoding and every named group is written to the key of the same name.
+	// The sync fails if the value does not match.
+	// Only used in data, can not be combined with secretKey, properties, asBlob or expectedDigest.
+	Split string `json:"split,omitempty"`
+
+	// +optional
+	// AsBlob stores the whole Provider value as canonical JSON under secretKey,
+	// with sorted object keys and without whitespace, so the value is byte-stable.
+	// The sync fails if the value is not valid JSON.
+	// Only used in data, can not be combined with property, propertyPointer or properties.
+	AsBlob bool `json:"asBlob,omitempty"`
+
+	// +optional
+	// ExpectedDigest pins the value to its SHA-256 digest in the form `sha256:<hex>`.
+	// The digest is computed from the value that is written to the Secret. If it does not match,
+	// e.g. after an unexpected rotation, the sync fails with the DigestMismatch reason.
+	// Only used in data, can not be combined with properties.
+	// +kubebuilder:validation:Pattern=`^sha256:[a-f0-9]{64}$`
+	ExpectedDigest string `json:"expectedDigest,omitempty"`
+
+	// +optional
+	// Used to select a specific version of the Provider value, if supported.
+	// The aliases latest, current, previous and pending are mapped to the native versions of the provider.
 	Version string `json:"version,omitempty"`
 
 	// +optional
@@ -253,8 +558,46 @@ type ExternalSecretDataRemoteRef struct {
 	// Used to define a decoding Strategy
 	// +kubebuilder:default="None"
 	DecodingStrategy ExternalSecretDecodingStrategy `json:"decodingStrategy,omitempty"`
+
+	// +optional
+	// SourceEncoding is the character encoding of the Provider value, e.g. Latin1.
+	// The value is converted to UTF-8 after it has been decoded. Defaults to UTF8, which is not converted.
+	SourceEncoding ExternalSecretSourceEncoding `json:"sourceEncoding,omitempty"`
+
+	// +optional
+	// InvalidEncodingPolicy defines how byte sequences that are invalid in the sourceEncoding are handled.
+	// Fail fails the sync, Replace replaces them with the Unicode replacement character U+FFFD.
+	// +kubebuilder:default="Fail"
+	InvalidEncodingPolicy ExternalSecretInvalidEncodingPolicy `json:"invalidEncodingPolicy,omitempty"`
+
+	// +optional
+	// Used to define how the remote value is parsed into multiple keys.
+	// Only allowed in dataFrom.extract, it is rejected in data. Defaults to JSON, which is parsed by the provider.
+	Parser ExternalSecretParser `json:"parser,omitempty"`
+
+	// +optional
+	// DocumentKey is the dot-separated path of a field that names each document
+	// of the YAMLMultiDoc parser, e.g. metadata.name. The keys of a document are prefixed with its name.
+	// Without documentKey the keys are prefixed with doc0, doc1, ... in the order of the documents.
+	DocumentKey string `json:"documentKey,omitempty"`
 }
 
+// +kubebuilder:validation:Enum=JSON;DotEnv;YAML;YAMLMultiDoc;INI;PEMBundle
+type ExternalSecretParser string
+
+const (
+	ExternalSecretParserJSON   ExternalSecretParser = "JSON"
+	ExternalSecretParserDotEnv ExternalSecretParser = "DotEnv"
+	ExternalSecretParserYAML   ExternalSecretParser = "YAML"
+	// ExternalSecretParserYAMLMultiDoc splits `---` separated YAML documents
+	// and prefixes the keys of each document with its index or documentKey.
+	ExternalSecretParserYAMLMultiDoc ExternalSecretParser = "YAMLMultiDoc"
+	ExternalSecretParserINI          ExternalSecretParser = "INI"
+	// ExternalSecretParserPEMBundle splits a PEM bundle into tls.crt (leaf),
+	// ca.crt (intermediates and root) and tls.key (private key, if present).
+	ExternalSecretParserPEMBundle ExternalSecretParser = "PEMBundle"
+)
+
 // +kubebuilder:validation:Enum=None;Fetch
 type ExternalSecretMetadataPolicy string
 
@@ -281,6 +624,35 @@ const (
 	ExternalSecretDecodeNone      ExternalSecretDecodingStrategy = "None"
 )
 
+// +kubebuilder:validation:Enum=UTF8;Latin1;Latin9;Windows1250;Windows1251;Windows1252;ShiftJIS;EUCJP;GBK;Big5;EUCKR;UTF16LE;UTF16BE
+type ExternalSecretSourceEncoding string
+
+const (
+	ExternalSecretSourceEncodingUTF8 ExternalSecretSourceEncoding = "UTF8"
+	// ExternalSecretSourceEncodingLatin1 is ISO-8859-1.
+	ExternalSecretSourceEncodingLatin1 ExternalSecretSourceEncoding = "Latin1"
+	// ExternalSecretSourceEncodingLatin9 is ISO-8859-15.
+	ExternalSecretSourceEncodingLatin9      ExternalSecretSourceEncoding = "Latin9"
+	ExternalSecretSourceEncodingWindows1250 ExternalSecretSourceEncoding = "Windows1250"
+	ExternalSecretSourceEncodingWindows1251 ExternalSecretSourceEncoding = "Windows1251"
+	ExternalSecretSourceEncodingWindows1252 ExternalSecretSourceEncoding = "Windows1252"
+	ExternalSecretSourceEncodingShiftJIS    ExternalSecretSourceEncoding = "ShiftJIS"
+	ExternalSecretSourceEncodingEUCJP       ExternalSecretSourceEncoding = "EUCJP"
+	ExternalSecretSourceEncodingGBK         ExternalSecretSourceEncoding = "GBK"
+	ExternalSecretSourceEncodingBig5        ExternalSecretSourceEncoding = "Big5"
+	ExternalSecretSourceEncodingEUCKR       ExternalSecretSourceEncoding = "EUCKR"
+	ExternalSecretSourceEncodingUTF16LE     ExternalSecretSourceEncoding = "UTF16LE"
+	ExternalSecretSourceEncodingUTF16BE     ExternalSecretSourceEncoding = "UTF16BE"
+)
+
+// +kubebuilder:validation:Enum=Fail;Replace
+type ExternalSecretInvalidEncodingPolicy string
+
+const (
+	ExternalSecretInvalidEncodingFail    ExternalSecretInvalidEncodingPolicy = "Fail"
+	ExternalSecretInvalidEncodingReplace ExternalSecretInvalidEncodingPolicy = "Replace"
+)
+
 type ExternalSecretDataFromRemoteRef struct {
 	// Used to extract multiple key/value pairs from one secret
 	// Note: Extract does not support sourceRef.Generator or sourceRef.GeneratorRef.
@@ -303,8 +675,25 @@ type ExternalSecretDataFromRemoteRef struct {
 	// When sourceRef points to a generator Extract or Find is not supported.
 	// The generator returns a static map of values
 	SourceRef *StoreGeneratorSourceRef `json:"sourceRef,omitempty"`
+
+	// MergeStrategy defines how keys of this entry are merged with keys of the previous dataFrom entries.
+	// Overwrite (default) replaces existing keys.
+	// DeepMerge merges keys whose previous and new values are both JSON objects recursively,
+	// values of this entry win on conflicts. All other values are replaced.
+	// +optional
+	MergeStrategy ExternalSecretMergeStrategy `json:"mergeStrategy,omitempty"`
 }
 
+// +kubebuilder:validation:Enum=Overwrite;DeepMerge
+type ExternalSecretMergeStrategy string
+
+const (
+	// MergeStrategyOverwrite replaces keys set by previous dataFrom entries.
+	MergeStrategyOverwrite ExternalSecretMergeStrategy = "Overwrite"
+	// MergeStrategyDeepMerge recursively merges JSON objects set by previous dataFrom entries.
+	MergeStrategyDeepMerge ExternalSecretMergeStrategy = "DeepMerge"
+)
+
 type ExternalSecretRewrite struct {
 	// Used to rewrite with regular expressions.
 	// The resulting key will be the output of a regexp.ReplaceAll operation.
@@ -352,6 +741,17 @@ type ExternalSecretFind struct {
 	// Used to define a decoding Strategy
 	// +kubebuilder:default="None"
 	DecodingStrategy ExternalSecretDecodingStrategy `json:"decodingStrategy,omitempty"`
+
+	// Exclude removes secrets from the result, it takes precedence over the other find operators.
+	// It is applied to the keys returned by the provider, before they are rewritten.
+	// +optional
+	Exclude []FindExclude `json:"exclude,omitempty"`
+
+	// Limit caps the number of secrets in the result. The keys returned by the provider
+	// are sorted lexically after the exclusions, the first Limit keys are kept.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	Limit *int `json:"limit,omitempty"`
 }
 
 type FindName struct {
@@ -360,6 +760,17 @@ type FindName struct {
 	RegExp string `json:"regexp,omitempty"`
 }
 
+// FindExclude excludes secrets by name or regular expression.
+type FindExclude struct {
+	// Name excludes the secret with exactly this name.
+	// +optional
+	Name string `json:"name,omitempty"`
+
+	// RegExp excludes the secrets whose name matches the regular expression.
+	// +optional
+	RegExp string `json:"regexp,omitempty"`
+}
+
 // ExternalSecretSpec defines the desired state of ExternalSecret.
 type ExternalSecretSpec struct {
 	// +optional
@@ -377,6 +788,27 @@ type ExternalSecretSpec struct {
 	// +kubebuilder:default="1h"
 	RefreshInterval *metav1.Duration `json:"refreshInterval,omitempty"`
 
+	// ProviderTimeout limits the duration of each individual call to a provider,
+	// so a single slow key fails on its own instead of blocking the whole sync.
+	// Combined with continueOnError the other keys are still synced.
+	// Not set means the calls are only limited by the provider itself.
+	// +optional
+	ProviderTimeout *metav1.Duration `json:"providerTimeout,omitempty"`
+
+	// RefreshPolicy determines when the ExternalSecret is refreshed:
+	// - Periodic (default): refreshes every refreshInterval and when the ExternalSecret changes.
+	// - OnChange: refreshes only when the ExternalSecret changes, refreshInterval is ignored.
+	// - Manual: refreshes only when the value of the `force-sync` annotation changes, refreshInterval is ignored.
+	// The target Secret is restored in all cases if it is deleted or modified.
+	// +optional
+	RefreshPolicy ExternalSecretRefreshPolicy `json:"refreshPolicy,omitempty"`
+
+	// KeyNamePolicy defines how keys from dataFrom that are not valid Kubernetes Secret keys are handled:
+	// - Strict (default): the sync fails.
+	// - Sanitize: every invalid character is replaced with `_`. The sync fails if two keys are equal after sanitization.
+	// +optional
+	KeyNamePolicy ExternalSecretKeyNamePolicy `json:"keyNamePolicy,omitempty"`
+
 	// Data defines the connection between the Kubernetes Secret keys and the Provider data
 	// +optional
 	Data []ExternalSecretData `json:"data,omitempty"`
@@ -385,6 +817,174 @@ type ExternalSecretSpec struct {
 	// If multiple entries are specified, the Secret keys are merged in the specified order
 	// +optional
 	DataFrom []ExternalSecretDataFromRemoteRef `json:"dataFrom,omitempty"`
+
+	// MergePrecedence defines which entries win if data and dataFrom write the same key:
+	// - Data (default): keys of data override keys of dataFrom.
+	// - DataFrom: keys of dataFrom override keys of data, e.g. to layer overrides on top of defaults.
+	// Templates are applied on top of the merged data in both cases.
+	// +optional
+	MergePrecedence ExternalSecretMergePrecedence `json:"mergePrecedence,omitempty"`
+
+	// StatusPolicy defines how remote key names are exposed in events and conditions of the ExternalSecret.
+	// +optional
+	StatusPolicy *ExternalSecretStatusPolicy `json:"statusPolicy,omitempty"`
+
+	// ProviderOptions is passed to the provider with every request made for this ExternalSecret.
+	// Values support templating with the metadata of the ExternalSecret, e.g. `{{ .metadata.namespace }}`.
+	// Providers that support it translate the options into request headers, others ignore them.
+	// +optional
+	ProviderOptions map[string]string `json:"providerOptions,omitempty"`
+
+	// Decryption decrypts the secret values after they are fetched from the provider,
+	// before they are extracted, decoded and templated.
+	// +optional
+	Decryption *ExternalSecretDecryption `json:"decryption,omitempty"`
+
+	// DependsOn lists ExternalSecrets in the same namespace that must be Ready
+	// before this ExternalSecret is synced, e.g. a CA that must exist before the certificates it signs.
+	// The sync is retried until all dependencies are Ready. Dependency cycles are reported in the Ready condition.
+	// +optional
+	DependsOn []ExternalSecretDependency `json:"dependsOn,omitempty"`
+
+	// Shadow reads the data again from a candidate store after the target secret was synced
+	// and writes it to a separate shadow secret, to verify a new store configuration before switching to it.
+	// The ShadowMatch condition reports whether the data of both stores is equal.
+	// +optional
+	Shadow *ExternalSecretShadow `json:"shadow,omitempty"`
+
+	// ValidateOnly fetches every entry and records whether it can be read in status.sources,
+	// the Secret is never created, updated or deleted. An entry that can not be read does not stop
+	// the validation of the other entries. Set it to false to sync the Secret.
+	// +optional
+	ValidateOnly bool `json:"validateOnly,omitempty"`
+}
+
+// ExternalSecretShadow defines the candidate store and the secret its data is written to.
+type ExternalSecretShadow struct {
+	// StoreRef is the candidate store. It replaces the store of every data and dataFrom entry.
+	StoreRef SecretStoreRef `json:"storeRef"`
+
+	// SecretName is the name of the shadow secret in the namespace of the ExternalSecret.
+	// It must differ from the target secret, the shadow never replaces the target secret.
+	// +kubebuilder:validation:MinLength:=1
+	// +kubebuilder:validation:MaxLength:=253
+	// +kubebuilder:validation:Pattern:=^[a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*$
+	SecretName string `json:"secretName"`
+}
+
+// ExternalSecretDependency references an ExternalSecret in the same namespace.
+type ExternalSecretDependency struct {
+	// Name of the ExternalSecret.
+	// +kubebuilder:validation:MinLength:=1
+	// +kubebuilder:validation:MaxLength:=253
+	// +kubebuilder:validation:Pattern:=^[a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*$
+	Name string `json:"name"`
+}
+
+// +kubebuilder:validation:Enum=SOPS;Age
+type ExternalSecretDecryptionFormat string
+
+const (
+	// DecryptionFormatSOPS decrypts a SOPS document (YAML or JSON) into a JSON document.
+	DecryptionFormatSOPS ExternalSecretDecryptionFormat = "SOPS"
+	// DecryptionFormatAge decrypts an age encrypted value, armored or binary.
+	DecryptionFormatAge ExternalSecretDecryptionFormat = "Age"
+)
+
+// ExternalSecretDecryption defines how secret values are decrypted.
+type ExternalSecretDecryption struct {
+	// Format of the encrypted secret values.
+	Format ExternalSecretDecryptionFormat `json:"format"`
+
+	// AgeKeySecretRef references the age identities used for decryption,
+	// one `AGE-SECRET-KEY-1...` identity per line. The Secret must be in the
+	// namespace of the ExternalSecret, the namespace of the selector is ignored.
+	AgeKeySecretRef esmeta.SecretKeySelector `json:"ageKeySecretRef"`
+}
+
+// +kubebuilder:validation:Enum=Age;GPG
+type ExternalSecretEncryptionFormat string
+
+const (
+	// EncryptionFormatAge encrypts the values to armored age files for X25519 recipients.
+	EncryptionFormatAge ExternalSecretEncryptionFormat = "Age"
+	// EncryptionFormatGPG encrypts the values to armored OpenPGP messages.
+	EncryptionFormatGPG ExternalSecretEncryptionFormat = "GPG"
+)
+
+// ExternalSecretEncryption defines how the values of the Secret are encrypted.
+type ExternalSecretEncryption struct {
+	// Format of the encrypted values.
+	Format ExternalSecretEncryptionFormat `json:"format"`
+
+	// Recipients that can decrypt the values. With Age these are X25519 recipients (`age1...`),
+	// with GPG ASCII armored OpenPGP public keys.
+	// +kubebuilder:validation:MinItems:=1
+	Recipients []string `json:"recipients"`
+}
+
+// +kubebuilder:validation:Enum=OnChange;Periodic;Manual
+type ExternalSecretRefreshPolicy string
+
+const (
+	// RefreshPolicyOnChange refreshes only when the ExternalSecret changes.
+	RefreshPolicyOnChange ExternalSecretRefreshPolicy = "OnChange"
+	// RefreshPolicyPeriodic refreshes every refreshInterval and when the ExternalSecret changes.
+	RefreshPolicyPeriodic ExternalSecretRefreshPolicy = "Periodic"
+	// RefreshPolicyManual refreshes only when the AnnotationForceSync changes.
+	RefreshPolicyManual ExternalSecretRefreshPolicy = "Manual"
+)
+
+// +kubebuilder:validation:Enum=Strict;Sanitize
+type ExternalSecretKeyNamePolicy string
+
+const (
+	// KeyNamePolicyStrict rejects keys with invalid characters.
+	KeyNamePolicyStrict ExternalSecretKeyNamePolicy = "Strict"
+	// KeyNamePolicySanitize replaces invalid characters in keys with `_`.
+	KeyNamePolicySanitize ExternalSecretKeyNamePolicy = "Sanitize"
+)
+
+// ExternalSecretMergePrecedence defines whether data or dataFrom wins if both write the same key.
+// +kubebuilder:validation:Enum=Data;DataFrom
+type ExternalSecretMergePrecedence string
+
+const (
+	// MergePrecedenceData lets keys of data override keys of dataFrom.
+	MergePrecedenceData ExternalSecretMergePrecedence = "Data"
+	// MergePrecedenceDataFrom lets keys of dataFrom override keys of data.
+	MergePrecedenceDataFrom ExternalSecretMergePrecedence = "DataFrom"
+)
+
+// ExternalSecretKeyVisibility defines how remote key names are shown.
+// +kubebuilder:validation:Enum=Show;Hash;Omit
+type ExternalSecretKeyVisibility string
+
+const (
+	// KeyVisibilityShow shows remote key names as they are.
+	KeyVisibilityShow ExternalSecretKeyVisibility = "Show"
+	// KeyVisibilityHash replaces remote key names with a short hash,
+	// so the same key can still be correlated across messages.
+	KeyVisibilityHash ExternalSecretKeyVisibility = "Hash"
+	// KeyVisibilityOmit replaces remote key names with a placeholder.
+	KeyVisibilityOmit ExternalSecretKeyVisibility = "Omit"
+)
+
+// ExternalSecretStatusPolicy defines which information about remote secrets
+// is exposed in events and conditions.
+type ExternalSecretStatusPolicy struct {
+	// RemoteKeys defines how remote key names appear in events and condition messages.
+	// This is useful when key names are sensitive themselves, e.g. if paths encode customer IDs.
+	// Defaults to "Show"
+	// +optional
+	// +kubebuilder:default="Show"
+	RemoteKeys ExternalSecretKeyVisibility `json:"remoteKeys,omitempty"`
+
+	// ResolvedSources records every data and dataFrom entry in status.sources with the store,
+	// the remote reference and the secret keys it resolved to, without any values.
+	// This helps to debug ExternalSecrets using rewrites, store groups or many entries.
+	// +optional
+	ResolvedSources bool `json:"resolvedSources,omitempty"`
 }
 
 // StoreSourceRef allows you to override the SecretStore source
@@ -394,7 +994,13 @@ type ExternalSecretSpec struct {
 // +kubebuilder:validation:MinProperties=1
 type StoreSourceRef struct {
 	// +optional
-	SecretStoreRef SecretStoreRef `json:"storeRef,omitempty"`
+	SecretStoreRef *SecretStoreRef `json:"storeRef,omitempty"`
+
+	// StoreGroup is an ordered list of stores to read the secret from.
+	// The first store that returns the secret is used, if the secret is missing
+	// or the store is unavailable the next store is tried.
+	// +optional
+	StoreGroup []SecretStoreRef `json:"storeGroup,omitempty"`
 
 	// GeneratorRef points to a generator custom resource.
 	//
@@ -412,6 +1018,13 @@ type StoreGeneratorSourceRef struct {
 	// +optional
 	SecretStoreRef *SecretStoreRef `json:"storeRef,omitempty"`
 
+	// StoreGroup is an ordered list of stores to extract the secret from.
+	// The first store that returns the secret is used, if the secret is missing
+	// or the store is unavailable the next store is tried.
+	// Only supported with dataFrom.extract.
+	// +optional
+	StoreGroup []SecretStoreRef `json:"storeGroup,omitempty"`
+
 	// GeneratorRef points to a generator custom resource.
 	// +optional
 	GeneratorRef *GeneratorRef `json:"generatorRef,omitempty"`
@@ -424,7 +1037,7 @@ type GeneratorRef struct {
 	APIVersion string `json:"apiVersion,omitempty"`
 
 	// Specify the Kind of the generator resource
-	// +kubebuilder:validation:Enum=ACRAccessToken;ClusterGenerator;ECRAuthorizationToken;Fake;GCRAccessToken;GithubAccessToken;Password;STSSessionToken;UUID;VaultDynamicSecret;Webhook
+	// +kubebuilder:validation:Enum=ACRAccessToken;ClusterGenerator;DeterministicSecret;ECRAuthorizationToken;Fake;GCPKMSDecrypt;GCRAccessToken;GithubAccessToken;Password;ServiceAccountToken;STSSessionToken;UUID;VaultDynamicSecret;Webhook
 	Kind string `json:"kind"`
 
 	// Specify the name of the generator resource
@@ -439,6 +1052,12 @@ type ExternalSecretConditionType string
 const (
 	ExternalSecretReady   ExternalSecretConditionType = "Ready"
 	ExternalSecretDeleted ExternalSecretConditionType = "Deleted"
+	// ExternalSecretShadowMatch reports whether the candidate store of spec.shadow returned the same data.
+	ExternalSecretShadowMatch ExternalSecretConditionType = "ShadowMatch"
+	// ExternalSecretTemplateDeprecated reports that the template uses the deprecated v1 engine.
+	ExternalSecretTemplateDeprecated ExternalSecretConditionType = "TemplateDeprecated"
+	// ExternalSecretSyncDisabled reports that the target secret has the AnnotationDisableSync annotation.
+	ExternalSecretSyncDisabled ExternalSecretConditionType = "SyncDisabled"
 )
 
 type ExternalSecretStatusCondition struct {
@@ -464,13 +1083,53 @@ const (
 	ConditionReasonSecretDeleted = "SecretDeleted"
 	// ConditionReasonSecretMissing indicates that the secret is missing.
 	ConditionReasonSecretMissing = "SecretMissing"
+	// ConditionReasonSecretPartiallySynced indicates that the secret was synced without some entries with continueOnError.
+	ConditionReasonSecretPartiallySynced = "PartiallySynced"
+	// ConditionReasonStoreUnavailable indicates that the circuit breaker of a store is open.
+	ConditionReasonStoreUnavailable = "StoreUnavailable"
+	// ConditionReasonStoreDenied indicates that the store is expired by spec.expiresAt or its conditions exclude the namespace.
+	ConditionReasonStoreDenied = "StoreDenied"
+	// ConditionReasonGeneratorNotReady indicates that a generator does not report a Ready condition yet.
+	ConditionReasonGeneratorNotReady = "GeneratorNotReady"
+	// ConditionReasonNamespaceTerminating indicates that the secret is not written because its namespace is terminating.
+	ConditionReasonNamespaceTerminating = "NamespaceTerminating"
+	// ConditionReasonDependencyNotReady indicates that an ExternalSecret of spec.dependsOn is not Ready yet.
+	ConditionReasonDependencyNotReady = "DependencyNotReady"
+	// ConditionReasonDependencyCycle indicates that spec.dependsOn forms a cycle, so the secret is never synced.
+	ConditionReasonDependencyCycle = "DependencyCycle"
+	// ConditionReasonDigestMismatch indicates that a provider value does not match remoteRef.expectedDigest.
+	ConditionReasonDigestMismatch = "DigestMismatch"
+	// ConditionReasonQuotaExceeded indicates that the secret is not created because the controller manages --max-managed-secrets secrets.
+	ConditionReasonQuotaExceeded = "QuotaExceeded"
+	// ConditionReasonShadowMatch indicates that the candidate store returned the same data.
+	ConditionReasonShadowMatch = "ShadowMatch"
+	// ConditionReasonShadowMismatch indicates that the candidate store returned different data.
+	ConditionReasonShadowMismatch = "ShadowMismatch"
+	// ConditionReasonShadowError indicates that the data of the candidate store could not be read or written.
+	ConditionReasonShadowError = "ShadowError"
+	// ConditionReasonValidated indicates that every entry of an ExternalSecret with validateOnly can be read.
+	ConditionReasonValidated = "Validated"
+	// ConditionReasonValidationFailed indicates that some entries of an ExternalSecret with validateOnly can not be read.
+	ConditionReasonValidationFailed = "ValidationFailed"
+	// ConditionReasonTemplateEngineV1 indicates that the template uses the deprecated v1 engine.
+	ConditionReasonTemplateEngineV1 = "TemplateEngineV1"
+	// ConditionReasonSyncDisabled indicates that the target secret is not written while it has the AnnotationDisableSync annotation.
+	ConditionReasonSyncDisabled = "SyncDisabled"
 
 	ReasonUpdateFailed          = "UpdateFailed"
+	ReasonGeneratorNotReady     = "GeneratorNotReady"
+	ReasonNamespaceTerminating  = "NamespaceTerminating"
+	ReasonNonUTF8Data           = "NonUTF8Data"
+	ReasonProviderTagSkipped    = "ProviderTagSkipped"
 	ReasonDeprecated            = "ParameterDeprecated"
 	ReasonCreated               = "Created"
 	ReasonUpdated               = "Updated"
 	ReasonDeleted               = "Deleted"
+	ReasonOrphaned              = "Orphaned"
+	ReasonCleared               = "Cleared"
 	ReasonMissingProviderSecret = "MissingProviderSecret"
+	ReasonSyncDisabled          = "SyncDisabled"
+	ReasonSyncEnabled           = "SyncEnabled"
 )
 
 type ExternalSecretStatus struct {
@@ -487,6 +1146,113 @@ type ExternalSecretStatus struct {
 
 	// Binding represents a servicebinding.io Provisioned Service reference to the secret
 	Binding corev1.LocalObjectReference `json:"binding,omitempty"`
+
+	// Sources records which store of a sourceRef.storeGroup served an entry
+	// and the errors of entries with continueOnError.
+	// With statusPolicy.resolvedSources, validateOnly or creationPolicy=None every entry is recorded.
+	// +optional
+	Sources []ExternalSecretSourceStatus `json:"sources,omitempty"`
+
+	// LastError is the error of the last failed sync, in a structured form.
+	// It is updated together with the Ready condition and removed once the secret is synced again.
+	// +optional
+	LastError *ExternalSecretLastError `json:"lastError,omitempty"`
+
+	// OrphanedSecrets are the previous target Secrets that are kept until target.orphanGracePeriod has passed.
+	// +optional
+	OrphanedSecrets []ExternalSecretOrphanedSecret `json:"orphanedSecrets,omitempty"`
+
+	// GeneratorRefreshTime is the time the values of an expiring generator, e.g. a ServiceAccountToken,
+	// are generated again. It is set after 80% of the lifetime of the first expiring value.
+	// +optional
+	GeneratorRefreshTime *metav1.Time `json:"generatorRefreshTime,omitempty"`
+
+	// Encryption records the plaintext the values of the Secret were last encrypted for with target.encryptWith.
+	// +optional
+	Encryption *ExternalSecretEncryptionStatus `json:"encryption,omitempty"`
+}
+
+// ExternalSecretEncryptionStatus records the last encryption of the values of the Secret.
+// The hash of the plaintext is keyed with a key that is only known to the controller,
+// so readers of the ExternalSecret can not guess low-entropy values from it.
+type ExternalSecretEncryptionStatus struct {
+	// PlaintextHash is the HMAC of the format, the recipients and the plaintext data, keyed by the controller.
+	// It is empty if the controller has no key.
+	PlaintextHash string `json:"plaintextHash"`
+
+	// DataHash is the hash of the encrypted data written for the plaintext.
+	// The current ciphertext is only kept if the data of the Secret still matches it.
+	DataHash string `json:"dataHash"`
+}
+
+// ExternalSecretOrphanedSecret is a previous target Secret that is deleted once target.orphanGracePeriod has passed.
+type ExternalSecretOrphanedSecret struct {
+	// Name of the Secret.
+	Name string `json:"name"`
+
+	// OrphanedAt is the time the Secret was found to be no longer the target.
+	OrphanedAt metav1.Time `json:"orphanedAt"`
+}
+
+// ExternalSecretLastError describes the error of the last failed sync.
+type ExternalSecretLastError struct {
+	// Path of the failing entry in the spec, e.g. spec.data[0].
+	// It is empty if the error is not caused by a single entry.
+	// +optional
+	Path string `json:"path,omitempty"`
+
+	// Key is the remote key of the failing entry.
+	// It is redacted according to statusPolicy.remoteKeys.
+	// +optional
+	Key string `json:"key,omitempty"`
+
+	// StoreRef is the store the failing entry was read from.
+	// It is not set for generators and store groups.
+	// +optional
+	StoreRef *SecretStoreRef `json:"storeRef,omitempty"`
+
+	// Reason is the reason of the Ready condition.
+	// +optional
+	Reason string `json:"reason,omitempty"`
+
+	// Error is the error returned for the entry, without the context added by the controller.
+	// Remote keys are redacted according to statusPolicy.remoteKeys.
+	Error string `json:"error"`
+}
+
+// ExternalSecretSourceStatus is the status of a single data or dataFrom entry.
+type ExternalSecretSourceStatus struct {
+	// Path of the entry in the spec, e.g. spec.data[0].
+	Path string `json:"path"`
+
+	// StoreRef is the store of the sourceRef.storeGroup that served the entry.
+	// With statusPolicy.resolvedSources it is set for every entry read from a store.
+	// +optional
+	StoreRef *SecretStoreRef `json:"storeRef,omitempty"`
+
+	// Error is the reason the entry could not be fetched, if continueOnError or validateOnly is set.
+	// +optional
+	Error string `json:"error,omitempty"`
+
+	// Reason classifies the error of the provider, e.g. NotFound or PermissionDenied.
+	// It is empty if the provider does not classify its errors.
+	// +optional
+	Reason ProviderErrorReason `json:"reason,omitempty"`
+
+	// Key is the remote key the entry was read from, if remoteRef.keyCandidates is set.
+	// It is redacted according to statusPolicy.remoteKeys.
+	// +optional
+	Key string `json:"key,omitempty"`
+
+	// Summary describes the remote reference the entry resolved to, if statusPolicy.resolvedSources is set.
+	// Remote keys are redacted according to statusPolicy.remoteKeys.
+	// +optional
+	Summary string `json:"summary,omitempty"`
+
+	// Keys are the secret keys the entry produced after rewrites and target.keyPrefix/keySuffix,
+	// if statusPolicy.resolvedSources is set.
+	// +optional
+	Keys []string `json:"keys,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -511,12 +1277,45 @@ const (
 	// AnnotationDataHash all secrets managed by an ExternalSecret have this annotation with the hash of their data.
 	AnnotationDataHash = "reconcile.external-secrets.io/data-hash"
 
+	// AnnotationNonUTF8Keys lists the keys of a secret with values that are not valid UTF-8,
+	// set when target.encoding is Text.
+	AnnotationNonUTF8Keys = "reconcile.external-secrets.io/non-utf8-keys"
+
+	// AnnotationProviderTagLabels lists the labels of a secret written from provider tags with target.copyProviderTagsToLabels,
+	// so the labels of removed tags are deleted.
+	AnnotationProviderTagLabels = "reconcile.external-secrets.io/provider-tag-labels"
+
+	// AnnotationSensitiveKeys lists the keys of a secret marked as Sensitive or Encrypted in spec.data[].sensitivity.
+	AnnotationSensitiveKeys = "reconcile.external-secrets.io/sensitive-keys"
+
+	// AnnotationEncryptedKeys lists the keys of a secret marked as Encrypted in spec.data[].sensitivity.
+	AnnotationEncryptedKeys = "reconcile.external-secrets.io/encrypted-keys"
+
+	// AnnotationProviderMetadataPrefix is the prefix of the annotations with the provider metadata
+	// selected in target.metadataAnnotations.
+	AnnotationProviderMetadataPrefix = "metadata.reconcile.external-secrets.io/"
+
+	// AnnotationNamespaceHash is the hash of the namespace metadata a templated secret was rendered with.
+	AnnotationNamespaceHash = "reconcile.external-secrets.io/namespace-hash"
+
 	// LabelManaged all secrets managed by an ExternalSecret will have this label equal to "true".
 	LabelManaged      = "reconcile.external-secrets.io/managed"
 	LabelManagedValue = "true"
 
 	// LabelOwner points to the owning ExternalSecret resource when CreationPolicy=Owner.
 	LabelOwner = "reconcile.external-secrets.io/created-by"
+
+	// LabelControllerIdentity is the identity of the controller instance that manages a secret,
+	// set when the controller runs with --controller-identity.
+	LabelControllerIdentity = "reconcile.external-secrets.io/controller-identity"
+
+	// AnnotationForceSync triggers a refresh of an ExternalSecret when its value changes.
+	AnnotationForceSync = "force-sync"
+
+	// AnnotationDisableSync on a target secret stops the controller from writing to it while its value is "true",
+	// e.g. to debug a manual change of the secret.
+	AnnotationDisableSync      = "external-secrets.io/disable-sync"
+	AnnotationDisableSyncValue = "true"
 )
 
 // +kubebuilder:object:root=true
@@ -18,25 +18,81 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
 
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 )
 
-type ExternalSecretValidator struct{}
+type ExternalSecretValidator struct {
+	// AllowedSecretTypes restricts the type of the target secret set in target.template.type,
+	// every type is allowed if it is empty.
+	AllowedSecretTypes []corev1.SecretType
+	// SecretPreview defines when a preview of the target secret is returned as warnings.
+	// Defaults to Never.
+	SecretPreview SecretPreviewMode
+}
+
+const warnTemplateEngineV1 = "target.template.engineVersion v1 is deprecated and will be removed, migrate the template to v2"
 
-func (esv *ExternalSecretValidator) ValidateCreate(_ context.Context, obj runtime.Object) (admission.Warnings, error) {
-	return validateExternalSecret(obj)
+// secretKeyPattern matches the validation pattern of data[].secretKey.
+var secretKeyPattern = regexp.MustCompile(`^[-._a-zA-Z0-9]+$`)
+
+func (esv *ExternalSecretValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return esv.withPreview(ctx, obj)
 }
 
-func (esv *ExternalSecretValidator) ValidateUpdate(_ context.Context, _, newObj runtime.Object) (admission.Warnings, error) {
-	return validateExternalSecret(newObj)
+func (esv *ExternalSecretValidator) ValidateUpdate(ctx context.Context, _, newObj runtime.Object) (admission.Warnings, error) {
+	return esv.withPreview(ctx, newObj)
 }
 
 func (esv *ExternalSecretValidator) ValidateDelete(_ context.Context, _ runtime.Object) (admission.Warnings, error) {
 	return nil, nil
 }
 
+func (esv *ExternalSecretValidator) validate(obj runtime.Object) (admission.Warnings, error) {
+	warnings, err := validateExternalSecret(obj)
+	if es, ok := obj.(*ExternalSecret); ok {
+		var secretType corev1.SecretType
+		if es.Spec.Target.Template != nil {
+			secretType = es.Spec.Target.Template.Type
+		}
+		if typeErr := CheckSecretTypeAllowed(esv.AllowedSecretTypes, secretType); typeErr != nil {
+			err = errors.Join(err, typeErr)
+		}
+	}
+	return warnings, err
+}
+
+// withPreview validates the object and appends the preview of the target secret
+// to the warnings if it is valid and the preview is enabled for the request.
+func (esv *ExternalSecretValidator) withPreview(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	warnings, err := esv.validate(obj)
+	if err != nil || !esv.previewEnabled(ctx) {
+		return warnings, err
+	}
+	if es, ok := obj.(*ExternalSecret); ok {
+		warnings = append(warnings, PreviewSecret(es)...)
+	}
+	return warnings, nil
+}
+
+func (esv *ExternalSecretValidator) previewEnabled(ctx context.Context) bool {
+	switch esv.SecretPreview {
+	case SecretPreviewAlways:
+		return true
+	case SecretPreviewDryRun:
+		req, err := admission.RequestFromContext(ctx)
+		return err == nil && req.DryRun != nil && *req.DryRun
+	default:
+		return false
+	}
+}
+
 func validateExternalSecret(obj runtime.Object) (admission.Warnings, error) {
 	es, ok := obj.(*ExternalSecret)
 	if !ok {
@@ -64,15 +120,188 @@ func validateExternalSecret(obj runtime.Object) (admission.Warnings, error) {
 		if err := validateSourceRef(ref); err != nil {
 			errs = errors.Join(errs, err)
 		}
+
+		if ref.Extract != nil {
+			if err := validatePropertyPointer(*ref.Extract); err != nil {
+				errs = errors.Join(errs, err)
+			}
+			if len(ref.Extract.Properties) > 0 {
+				errs = errors.Join(errs, fmt.Errorf("properties can only be used in data (key: %s)", ref.Extract.Key))
+			}
+			if len(ref.Extract.KeyCandidates) > 0 {
+				errs = errors.Join(errs, fmt.Errorf("keyCandidates can only be used in data (key: %s)", ref.Extract.Key))
+			}
+			if ref.Extract.AsBlob {
+				errs = errors.Join(errs, fmt.Errorf("asBlob can only be used in data (key: %s)", ref.Extract.Key))
+			}
+			if ref.Extract.Split != "" {
+				errs = errors.Join(errs, fmt.Errorf("split can only be used in data (key: %s)", ref.Extract.Key))
+			}
+			if ref.Extract.ExpectedDigest != "" {
+				errs = errors.Join(errs, fmt.Errorf("expectedDigest can only be used in data (key: %s)", ref.Extract.Key))
+			}
+			if ref.Extract.DocumentKey != "" && ref.Extract.Parser != ExternalSecretParserYAMLMultiDoc {
+				errs = errors.Join(errs, fmt.Errorf("documentKey can only be used with the YAMLMultiDoc parser (key: %s)", ref.Extract.Key))
+			}
+		}
+	}
+
+	for _, data := range es.Spec.Data {
+		if err := validatePropertyPointer(data.RemoteRef); err != nil {
+			errs = errors.Join(errs, err)
+		}
+		if err := validateProperties(data); err != nil {
+			errs = errors.Join(errs, err)
+		}
+		if err := validateSplit(data); err != nil {
+			errs = errors.Join(errs, err)
+		}
+		if data.RemoteRef.AsBlob && (data.RemoteRef.Property != "" || data.RemoteRef.PropertyPointer != "" || len(data.RemoteRef.Properties) > 0) {
+			errs = errors.Join(errs, fmt.Errorf("asBlob cannot be combined with property, propertyPointer or properties (key: %s)", data.RemoteRef.Key))
+		}
+		if data.RemoteRef.ExpectedDigest != "" && len(data.RemoteRef.Properties) > 0 {
+			errs = errors.Join(errs, fmt.Errorf("expectedDigest cannot be combined with properties (key: %s)", data.RemoteRef.Key))
+		}
+		if data.RemoteRef.Parser != "" {
+			errs = errors.Join(errs, fmt.Errorf("parser can only be used in dataFrom.extract (key: %s)", data.RemoteRef.Key))
+		}
+		if data.RemoteRef.DocumentKey != "" {
+			errs = errors.Join(errs, fmt.Errorf("documentKey can only be used in dataFrom.extract (key: %s)", data.RemoteRef.Key))
+		}
+		if slices.Contains(data.RemoteRef.KeyCandidates, "") {
+			errs = errors.Join(errs, fmt.Errorf("keyCandidates must not be empty (key: %s)", data.RemoteRef.Key))
+		}
+	}
+
+	if ref := es.Spec.Target.TemplateRef; ref != nil && ref.Name == "" {
+		errs = errors.Join(errs, errors.New("templateRef.name must be set"))
+	}
+
+	for _, key := range es.Spec.Target.MetadataAnnotations {
+		if IsSensitiveMetadataKey(key) {
+			errs = errors.Join(errs, fmt.Errorf("metadataAnnotations must not contain sensitive keys: %s", key))
+		}
+	}
+
+	if timeout := es.Spec.ProviderTimeout; timeout != nil && timeout.Duration <= 0 {
+		errs = errors.Join(errs, fmt.Errorf("providerTimeout must be positive, got %s", timeout.Duration))
+	}
+
+	if limits := es.Spec.Target.SizeLimits; limits != nil && len(limits.TruncateKeys) > 0 && limits.MaxKeySize == nil {
+		errs = errors.Join(errs, errors.New("sizeLimits.truncateKeys requires sizeLimits.maxKeySize"))
+	}
+
+	if err := validateDependsOn(es); err != nil {
+		errs = errors.Join(errs, err)
+	}
+
+	if err := validateShadow(es); err != nil {
+		errs = errors.Join(errs, err)
 	}
 
 	errs = validateDuplicateKeys(es, errs)
-	return nil, errs
+
+	var warnings admission.Warnings
+	if tpl := es.Spec.Target.Template; tpl != nil && tpl.EngineVersion == TemplateEngineV1 {
+		warnings = append(warnings, warnTemplateEngineV1)
+	}
+	return warnings, errs
+}
+
+func validateDependsOn(es *ExternalSecret) error {
+	var errs error
+	seen := make(map[string]bool, len(es.Spec.DependsOn))
+	for _, dep := range es.Spec.DependsOn {
+		if dep.Name == es.Name {
+			errs = errors.Join(errs, errors.New("dependsOn must not reference the ExternalSecret itself"))
+		}
+		if seen[dep.Name] {
+			errs = errors.Join(errs, fmt.Errorf("duplicate dependsOn found: %s", dep.Name))
+		}
+		seen[dep.Name] = true
+	}
+	return errs
+}
+
+// validateShadow ensures the shadow secret never replaces the target secret.
+// Generators are not supported, as they return new values on every call.
+func validateShadow(es *ExternalSecret) error {
+	if es.Spec.Shadow == nil {
+		return nil
+	}
+	var errs error
+	targetName := es.Spec.Target.Name
+	if targetName == "" {
+		targetName = es.Name
+	}
+	if es.Spec.Shadow.SecretName == targetName {
+		errs = errors.Join(errs, errors.New("shadow.secretName must differ from the target secret name"))
+	}
+	for _, ref := range es.Spec.DataFrom {
+		if ref.SourceRef != nil && ref.SourceRef.GeneratorRef != nil {
+			errs = errors.Join(errs, errors.New("shadow can not be used with generatorRef"))
+			break
+		}
+	}
+	return errs
+}
+
+func validatePropertyPointer(ref ExternalSecretDataRemoteRef) error {
+	if ref.PropertyPointer == "" {
+		return nil
+	}
+	if ref.Property != "" {
+		return fmt.Errorf("property and propertyPointer cannot be set at the same time (key: %s)", ref.Key)
+	}
+	if !strings.HasPrefix(ref.PropertyPointer, "/") {
+		return fmt.Errorf("propertyPointer must start with / (key: %s)", ref.Key)
+	}
+	return nil
+}
+
+func validateProperties(data ExternalSecretData) error {
+	ref := data.RemoteRef
+	if len(ref.Properties) == 0 {
+		if data.SecretKey == "" && ref.Split == "" {
+			return fmt.Errorf("secretKey must be set (key: %s)", ref.Key)
+		}
+		return nil
+	}
+	if data.SecretKey != "" || ref.Property != "" || ref.PropertyPointer != "" {
+		return fmt.Errorf("properties cannot be combined with secretKey, property or propertyPointer (key: %s)", ref.Key)
+	}
+	var errs error
+	for secretKey, property := range ref.Properties {
+		if len(secretKey) > 253 || !secretKeyPattern.MatchString(secretKey) {
+			errs = errors.Join(errs, fmt.Errorf("invalid secret key %q in properties (key: %s)", secretKey, ref.Key))
+		}
+		if property == "" {
+			errs = errors.Join(errs, fmt.Errorf("property for secret key %q must not be empty (key: %s)", secretKey, ref.Key))
+		}
+	}
+	return errs
+}
+
+func validateSplit(data ExternalSecretData) error {
+	ref := data.RemoteRef
+	if ref.Split == "" {
+		return nil
+	}
+	if data.SecretKey != "" || len(ref.Properties) > 0 || ref.AsBlob || ref.ExpectedDigest != "" {
+		return fmt.Errorf("split cannot be combined with secretKey, properties, asBlob or expectedDigest (key: %s)", ref.Key)
+	}
+	if _, _, err := CompileSplit(ref.Split); err != nil {
+		return fmt.Errorf("invalid split (key: %s): %w", ref.Key, err)
+	}
+	return nil
 }
 
 func validateSourceRef(ref ExternalSecretDataFromRemoteRef) error {
-	if ref.SourceRef != nil && ref.SourceRef.GeneratorRef == nil && ref.SourceRef.SecretStoreRef == nil {
-		return errors.New("generatorRef or storeRef must be set when using sourceRef in dataFrom")
+	if ref.SourceRef != nil && ref.SourceRef.GeneratorRef == nil && ref.SourceRef.SecretStoreRef == nil && len(ref.SourceRef.StoreGroup) == 0 {
+		return errors.New("generatorRef, storeRef or storeGroup must be set when using sourceRef in dataFrom")
+	}
+	if ref.SourceRef != nil && len(ref.SourceRef.StoreGroup) > 0 && ref.Extract == nil {
+		return errors.New("storeGroup can only be used with extract in dataFrom")
 	}
 
 	return nil
@@ -98,6 +327,7 @@ func validateExtractFindGenerator(ref ExternalSecretDataFromRemoteRef) error {
 func validatePolicies(es *ExternalSecret) error {
 	var errs error
 	if (es.Spec.Target.DeletionPolicy == DeletionPolicyDelete && es.Spec.Target.CreationPolicy == CreatePolicyMerge) ||
+		(es.Spec.Target.DeletionPolicy == DeletionPolicyDelete && es.Spec.Target.CreationPolicy == CreatePolicyStrictMerge) ||
 		(es.Spec.Target.DeletionPolicy == DeletionPolicyDelete && es.Spec.Target.CreationPolicy == CreatePolicyNone) {
 		errs = errors.Join(errs, errors.New("deletionPolicy=Delete must not be used when the controller doesn't own the secret. Please set creationPolicy=Owner"))
 	}
@@ -106,6 +336,31 @@ func validatePolicies(es *ExternalSecret) error {
 		errs = errors.Join(errs, errors.New("deletionPolicy=Merge must not be used with creationPolicy=None. There is no Secret to merge with"))
 	}
 
+	onError := es.Spec.Target.OnError
+	creationPolicy := es.Spec.Target.CreationPolicy
+	if (onError == OnErrorClear || onError == OnErrorDelete) &&
+		(creationPolicy == CreatePolicyMerge || creationPolicy == CreatePolicyStrictMerge || creationPolicy == CreatePolicyNone) {
+		errs = errors.Join(errs, fmt.Errorf("onError=%s must not be used when the controller doesn't own the secret. Please set creationPolicy=Owner or creationPolicy=Orphan", onError))
+	}
+
+	if es.Spec.Target.EncryptWith != nil &&
+		(creationPolicy == CreatePolicyMerge || creationPolicy == CreatePolicyStrictMerge || creationPolicy == CreatePolicyNone) {
+		errs = errors.Join(errs, errors.New("encryptWith must not be used when the controller doesn't own the secret. Please set creationPolicy=Owner or creationPolicy=Orphan"))
+	}
+
+	if after := es.Spec.Target.OnErrorAfter; after != nil && after.Duration <= 0 {
+		errs = errors.Join(errs, errors.New("onErrorAfter must be greater than 0"))
+	}
+
+	if grace := es.Spec.Target.OrphanGracePeriod; grace != nil {
+		if grace.Duration < 0 {
+			errs = errors.Join(errs, errors.New("orphanGracePeriod must not be negative"))
+		}
+		if creationPolicy != "" && creationPolicy != CreatePolicyOwner {
+			errs = errors.Join(errs, errors.New("orphanGracePeriod must only be used with creationPolicy=Owner, other policies never delete the previous secret"))
+		}
+	}
+
 	return errs
 }
 
@@ -113,11 +368,20 @@ func validateDuplicateKeys(es *ExternalSecret, errs error) error {
 	if es.Spec.Target.DeletionPolicy == DeletionPolicyRetain {
 		seenKeys := make(map[string]struct{})
 		for _, data := range es.Spec.Data {
-			secretKey := data.SecretKey
-			if _, exists := seenKeys[secretKey]; exists {
-				errs = errors.Join(errs, fmt.Errorf("duplicate secretKey found: %s", secretKey))
+			secretKeys := []string{data.SecretKey}
+			if len(data.RemoteRef.Properties) > 0 {
+				secretKeys = slices.Sorted(maps.Keys(data.RemoteRef.Properties))
+			}
+			if data.RemoteRef.Split != "" {
+				// an invalid expression is reported by validateSplit
+				_, secretKeys, _ = CompileSplit(data.RemoteRef.Split)
+			}
+			for _, secretKey := range secretKeys {
+				if _, exists := seenKeys[secretKey]; exists {
+					errs = errors.Join(errs, fmt.Errorf("duplicate secretKey found: %s", secretKey))
+				}
+				seenKeys[secretKey] = struct{}{}
 			}
-			seenKeys[secretKey] = struct{}{}
 		}
 	}
 	return errs
@@ -15,8 +15,13 @@ limitations under the License.
 package v1beta1
 
 import (
+	"context"
+	"slices"
 	"testing"
+	"time"
 
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -44,7 +49,22 @@ func TestValidateExternalSecret(t *testing.T) {
 						CreationPolicy: CreatePolicyMerge,
 					},
 					Data: []ExternalSecretData{
-						{},
+						{SecretKey: "foo"},
+					},
+				},
+			},
+			expectedErr: "deletionPolicy=Delete must not be used when the controller doesn't own the secret. Please set creationPolicy=Owner",
+		},
+		{
+			name: "deletion policy delete with strict merge",
+			obj: &ExternalSecret{
+				Spec: ExternalSecretSpec{
+					Target: ExternalSecretTarget{
+						DeletionPolicy: DeletionPolicyDelete,
+						CreationPolicy: CreatePolicyStrictMerge,
+					},
+					Data: []ExternalSecretData{
+						{SecretKey: "foo"},
 					},
 				},
 			},
@@ -59,12 +79,273 @@ func TestValidateExternalSecret(t *testing.T) {
 						CreationPolicy: CreatePolicyNone,
 					},
 					Data: []ExternalSecretData{
-						{},
+						{SecretKey: "foo"},
 					},
 				},
 			},
 			expectedErr: "deletionPolicy=Merge must not be used with creationPolicy=None. There is no Secret to merge with",
 		},
+		{
+			name: "onError delete with creation policy merge",
+			obj: &ExternalSecret{
+				Spec: ExternalSecretSpec{
+					Target: ExternalSecretTarget{
+						OnError:        OnErrorDelete,
+						CreationPolicy: CreatePolicyMerge,
+					},
+					Data: []ExternalSecretData{
+						{SecretKey: "foo"},
+					},
+				},
+			},
+			expectedErr: "onError=Delete must not be used when the controller doesn't own the secret. Please set creationPolicy=Owner or creationPolicy=Orphan",
+		},
+		{
+			name: "onError clear with creation policy owner",
+			obj: &ExternalSecret{
+				Spec: ExternalSecretSpec{
+					Target: ExternalSecretTarget{
+						OnError:        OnErrorClear,
+						OnErrorAfter:   &metav1.Duration{Duration: time.Minute},
+						CreationPolicy: CreatePolicyOwner,
+					},
+					Data: []ExternalSecretData{
+						{SecretKey: "foo"},
+					},
+				},
+			},
+		},
+		{
+			name: "encryptWith with creation policy merge",
+			obj: &ExternalSecret{
+				Spec: ExternalSecretSpec{
+					Target: ExternalSecretTarget{
+						CreationPolicy: CreatePolicyMerge,
+						EncryptWith: &ExternalSecretEncryption{
+							Format:     EncryptionFormatAge,
+							Recipients: []string{"age1recipient"},
+						},
+					},
+					Data: []ExternalSecretData{
+						{SecretKey: "foo"},
+					},
+				},
+			},
+			expectedErr: "encryptWith must not be used when the controller doesn't own the secret. Please set creationPolicy=Owner or creationPolicy=Orphan",
+		},
+		{
+			name: "onErrorAfter not positive",
+			obj: &ExternalSecret{
+				Spec: ExternalSecretSpec{
+					Target: ExternalSecretTarget{
+						OnError:      OnErrorClear,
+						OnErrorAfter: &metav1.Duration{},
+					},
+					Data: []ExternalSecretData{
+						{SecretKey: "foo"},
+					},
+				},
+			},
+			expectedErr: "onErrorAfter must be greater than 0",
+		},
+		{
+			name: "orphanGracePeriod negative",
+			obj: &ExternalSecret{
+				Spec: ExternalSecretSpec{
+					Target: ExternalSecretTarget{
+						OrphanGracePeriod: &metav1.Duration{Duration: -time.Minute},
+					},
+					Data: []ExternalSecretData{
+						{SecretKey: "foo"},
+					},
+				},
+			},
+			expectedErr: "orphanGracePeriod must not be negative",
+		},
+		{
+			name: "orphanGracePeriod without creationPolicy=Owner",
+			obj: &ExternalSecret{
+				Spec: ExternalSecretSpec{
+					Target: ExternalSecretTarget{
+						CreationPolicy:    CreatePolicyOrphan,
+						OrphanGracePeriod: &metav1.Duration{Duration: time.Minute},
+					},
+					Data: []ExternalSecretData{
+						{SecretKey: "foo"},
+					},
+				},
+			},
+			expectedErr: "orphanGracePeriod must only be used with creationPolicy=Owner, other policies never delete the previous secret",
+		},
+		{
+			name: "asBlob with property",
+			obj: &ExternalSecret{
+				Spec: ExternalSecretSpec{
+					Data: []ExternalSecretData{
+						{SecretKey: "foo", RemoteRef: ExternalSecretDataRemoteRef{Key: "config", Property: "db", AsBlob: true}},
+					},
+				},
+			},
+			expectedErr: "asBlob cannot be combined with property, propertyPointer or properties (key: config)",
+		},
+		{
+			name: "asBlob in dataFrom",
+			obj: &ExternalSecret{
+				Spec: ExternalSecretSpec{
+					DataFrom: []ExternalSecretDataFromRemoteRef{
+						{Extract: &ExternalSecretDataRemoteRef{Key: "config", AsBlob: true}},
+					},
+				},
+			},
+			expectedErr: "asBlob can only be used in data (key: config)",
+		},
+		{
+			name: "expectedDigest with properties",
+			obj: &ExternalSecret{
+				Spec: ExternalSecretSpec{
+					Data: []ExternalSecretData{
+						{RemoteRef: ExternalSecretDataRemoteRef{Key: "db", Properties: map[string]string{"user": "username"}, ExpectedDigest: "sha256:00"}},
+					},
+				},
+			},
+			expectedErr: "expectedDigest cannot be combined with properties (key: db)",
+		},
+		{
+			name: "expectedDigest in dataFrom",
+			obj: &ExternalSecret{
+				Spec: ExternalSecretSpec{
+					DataFrom: []ExternalSecretDataFromRemoteRef{
+						{Extract: &ExternalSecretDataRemoteRef{Key: "config", ExpectedDigest: "sha256:00"}},
+					},
+				},
+			},
+			expectedErr: "expectedDigest can only be used in data (key: config)",
+		},
+		{
+			name: "documentKey without YAMLMultiDoc parser",
+			obj: &ExternalSecret{
+				Spec: ExternalSecretSpec{
+					DataFrom: []ExternalSecretDataFromRemoteRef{
+						{Extract: &ExternalSecretDataRemoteRef{Key: "config", Parser: ExternalSecretParserYAML, DocumentKey: "name"}},
+					},
+				},
+			},
+			expectedErr: "documentKey can only be used with the YAMLMultiDoc parser (key: config)",
+		},
+		{
+			name: "parser in data",
+			obj: &ExternalSecret{
+				Spec: ExternalSecretSpec{
+					Data: []ExternalSecretData{
+						{SecretKey: "config", RemoteRef: ExternalSecretDataRemoteRef{Key: "config", Parser: ExternalSecretParserDotEnv}},
+					},
+				},
+			},
+			expectedErr: "parser can only be used in dataFrom.extract (key: config)",
+		},
+		{
+			name: "documentKey in data",
+			obj: &ExternalSecret{
+				Spec: ExternalSecretSpec{
+					Data: []ExternalSecretData{
+						{SecretKey: "config", RemoteRef: ExternalSecretDataRemoteRef{Key: "config", DocumentKey: "name"}},
+					},
+				},
+			},
+			expectedErr: "documentKey can only be used in dataFrom.extract (key: config)",
+		},
+		{
+			name: "sensitive metadata annotation",
+			obj: &ExternalSecret{
+				Spec: ExternalSecretSpec{
+					Target: ExternalSecretTarget{
+						MetadataAnnotations: []string{"source", "session_token"},
+					},
+					Data: []ExternalSecretData{
+						{SecretKey: "foo"},
+					},
+				},
+			},
+			expectedErr: "metadataAnnotations must not contain sensitive keys: session_token",
+		},
+		{
+			name: "negative provider timeout",
+			obj: &ExternalSecret{
+				Spec: ExternalSecretSpec{
+					ProviderTimeout: &metav1.Duration{Duration: -time.Second},
+					Data: []ExternalSecretData{
+						{SecretKey: "foo"},
+					},
+				},
+			},
+			expectedErr: "providerTimeout must be positive, got -1s",
+		},
+		{
+			name: "truncate keys without max key size",
+			obj: &ExternalSecret{
+				Spec: ExternalSecretSpec{
+					Target: ExternalSecretTarget{
+						SizeLimits: &ExternalSecretSizeLimits{
+							TruncateKeys: []string{"foo"},
+						},
+					},
+					Data: []ExternalSecretData{
+						{SecretKey: "foo"},
+					},
+				},
+			},
+			expectedErr: "sizeLimits.truncateKeys requires sizeLimits.maxKeySize",
+		},
+		{
+			name: "dependsOn itself",
+			obj: &ExternalSecret{
+				ObjectMeta: metav1.ObjectMeta{Name: "leaf"},
+				Spec: ExternalSecretSpec{
+					Data:      []ExternalSecretData{{SecretKey: "foo"}},
+					DependsOn: []ExternalSecretDependency{{Name: "ca"}, {Name: "leaf"}, {Name: "ca"}},
+				},
+			},
+			expectedErr: "dependsOn must not reference the ExternalSecret itself\nduplicate dependsOn found: ca",
+		},
+		{
+			name: "shadow writes to the target secret",
+			obj: &ExternalSecret{
+				ObjectMeta: metav1.ObjectMeta{Name: "app"},
+				Spec: ExternalSecretSpec{
+					Data:   []ExternalSecretData{{SecretKey: "foo"}},
+					Shadow: &ExternalSecretShadow{SecretName: "app"},
+				},
+			},
+			expectedErr: "shadow.secretName must differ from the target secret name",
+		},
+		{
+			name: "shadow with generator",
+			obj: &ExternalSecret{
+				ObjectMeta: metav1.ObjectMeta{Name: "app"},
+				Spec: ExternalSecretSpec{
+					Target: ExternalSecretTarget{Name: "app-secret"},
+					DataFrom: []ExternalSecretDataFromRemoteRef{
+						{
+							SourceRef: &StoreGeneratorSourceRef{
+								GeneratorRef: &GeneratorRef{},
+							},
+						},
+					},
+					Shadow: &ExternalSecretShadow{SecretName: "app"},
+				},
+			},
+			expectedErr: "shadow can not be used with generatorRef",
+		},
+		{
+			name: "valid shadow",
+			obj: &ExternalSecret{
+				ObjectMeta: metav1.ObjectMeta{Name: "app"},
+				Spec: ExternalSecretSpec{
+					Data:   []ExternalSecretData{{SecretKey: "foo"}},
+					Shadow: &ExternalSecretShadow{SecretName: "app-shadow"},
+				},
+			},
+		},
 		{
 			name: "both data and data_from are empty",
 			obj: &ExternalSecret{
@@ -140,7 +421,38 @@ func TestValidateExternalSecret(t *testing.T) {
 					},
 				},
 			},
-			expectedErr: "generatorRef or storeRef must be set when using sourceRef in dataFrom",
+			expectedErr: "generatorRef, storeRef or storeGroup must be set when using sourceRef in dataFrom",
+		},
+		{
+			name: "storeGroup with find",
+			obj: &ExternalSecret{
+				Spec: ExternalSecretSpec{
+					DataFrom: []ExternalSecretDataFromRemoteRef{
+						{
+							Find: &ExternalSecretFind{},
+							SourceRef: &StoreGeneratorSourceRef{
+								StoreGroup: []SecretStoreRef{{Name: "primary"}, {Name: "secondary"}},
+							},
+						},
+					},
+				},
+			},
+			expectedErr: "storeGroup can only be used with extract in dataFrom",
+		},
+		{
+			name: "storeGroup with extract",
+			obj: &ExternalSecret{
+				Spec: ExternalSecretSpec{
+					DataFrom: []ExternalSecretDataFromRemoteRef{
+						{
+							Extract: &ExternalSecretDataRemoteRef{Key: "db"},
+							SourceRef: &StoreGeneratorSourceRef{
+								StoreGroup: []SecretStoreRef{{Name: "primary"}, {Name: "secondary"}},
+							},
+						},
+					},
+				},
+			},
 		},
 		{
 			name: "multiple errors",
@@ -169,6 +481,192 @@ either data or dataFrom should be specified`,
 				},
 			},
 		},
+		{
+			name: "templateRef without name",
+			obj: &ExternalSecret{
+				Spec: ExternalSecretSpec{
+					Target: ExternalSecretTarget{
+						TemplateRef: &ExternalSecretTemplateRef{Key: "template"},
+					},
+					Data: []ExternalSecretData{
+						{SecretKey: "api", RemoteRef: ExternalSecretDataRemoteRef{Key: "api"}},
+					},
+				},
+			},
+			expectedErr: "templateRef.name must be set",
+		},
+		{
+			name: "property and propertyPointer",
+			obj: &ExternalSecret{
+				Spec: ExternalSecretSpec{
+					Data: []ExternalSecretData{
+						{SecretKey: "api", RemoteRef: ExternalSecretDataRemoteRef{Key: "api", Property: "keys", PropertyPointer: "/keys/0"}},
+					},
+				},
+			},
+			expectedErr: "property and propertyPointer cannot be set at the same time (key: api)",
+		},
+		{
+			name: "propertyPointer without leading slash",
+			obj: &ExternalSecret{
+				Spec: ExternalSecretSpec{
+					DataFrom: []ExternalSecretDataFromRemoteRef{
+						{Extract: &ExternalSecretDataRemoteRef{Key: "api", PropertyPointer: "keys/0"}},
+					},
+				},
+			},
+			expectedErr: "propertyPointer must start with / (key: api)",
+		},
+		{
+			name: "missing secretKey",
+			obj: &ExternalSecret{
+				Spec: ExternalSecretSpec{
+					Data: []ExternalSecretData{
+						{RemoteRef: ExternalSecretDataRemoteRef{Key: "db"}},
+					},
+				},
+			},
+			expectedErr: "secretKey must be set (key: db)",
+		},
+		{
+			name: "properties with secretKey",
+			obj: &ExternalSecret{
+				Spec: ExternalSecretSpec{
+					Data: []ExternalSecretData{
+						{SecretKey: "db", RemoteRef: ExternalSecretDataRemoteRef{Key: "db", Properties: map[string]string{"user": "user"}}},
+					},
+				},
+			},
+			expectedErr: "properties cannot be combined with secretKey, property or propertyPointer (key: db)",
+		},
+		{
+			name: "properties with invalid secret key",
+			obj: &ExternalSecret{
+				Spec: ExternalSecretSpec{
+					Data: []ExternalSecretData{
+						{RemoteRef: ExternalSecretDataRemoteRef{Key: "db", Properties: map[string]string{"db/user": "user"}}},
+					},
+				},
+			},
+			expectedErr: `invalid secret key "db/user" in properties (key: db)`,
+		},
+		{
+			name: "properties in dataFrom",
+			obj: &ExternalSecret{
+				Spec: ExternalSecretSpec{
+					DataFrom: []ExternalSecretDataFromRemoteRef{
+						{Extract: &ExternalSecretDataRemoteRef{Key: "db", Properties: map[string]string{"user": "user"}}},
+					},
+				},
+			},
+			expectedErr: "properties can only be used in data (key: db)",
+		},
+		{
+			name: "valid split",
+			obj: &ExternalSecret{
+				Spec: ExternalSecretSpec{
+					Data: []ExternalSecretData{
+						{RemoteRef: ExternalSecretDataRemoteRef{Key: "db", Split: `^(?P<user>[^:]+):(?P<pass>[^@]+)@(?P<host>[^:]+):(?P<port>\d+)$`}},
+					},
+				},
+			},
+		},
+		{
+			name: "split with secretKey",
+			obj: &ExternalSecret{
+				Spec: ExternalSecretSpec{
+					Data: []ExternalSecretData{
+						{SecretKey: "db", RemoteRef: ExternalSecretDataRemoteRef{Key: "db", Split: `(?P<user>.*)`}},
+					},
+				},
+			},
+			expectedErr: "split cannot be combined with secretKey, properties, asBlob or expectedDigest (key: db)",
+		},
+		{
+			name: "split without named groups",
+			obj: &ExternalSecret{
+				Spec: ExternalSecretSpec{
+					Data: []ExternalSecretData{
+						{RemoteRef: ExternalSecretDataRemoteRef{Key: "db", Split: `^([^:]+):(.*)$`}},
+					},
+				},
+			},
+			expectedErr: "invalid split (key: db): split must contain at least one named capture group",
+		},
+		{
+			name: "invalid split",
+			obj: &ExternalSecret{
+				Spec: ExternalSecretSpec{
+					Data: []ExternalSecretData{
+						{RemoteRef: ExternalSecretDataRemoteRef{Key: "db", Split: `(?P<user>`}},
+					},
+				},
+			},
+			expectedErr: "invalid split (key: db): error parsing regexp: missing closing ): `(?P<user>`",
+		},
+		{
+			name: "split in dataFrom",
+			obj: &ExternalSecret{
+				Spec: ExternalSecretSpec{
+					DataFrom: []ExternalSecretDataFromRemoteRef{
+						{Extract: &ExternalSecretDataRemoteRef{Key: "db", Split: `(?P<user>.*)`}},
+					},
+				},
+			},
+			expectedErr: "split can only be used in data (key: db)",
+		},
+		{
+			name: "duplicate secretKey from split",
+			obj: &ExternalSecret{
+				Spec: ExternalSecretSpec{
+					Target: ExternalSecretTarget{
+						DeletionPolicy: DeletionPolicyRetain,
+					},
+					Data: []ExternalSecretData{
+						{SecretKey: "user", RemoteRef: ExternalSecretDataRemoteRef{Key: "user"}},
+						{RemoteRef: ExternalSecretDataRemoteRef{Key: "db", Split: `(?P<user>[^:]+):(?P<pass>.*)`}},
+					},
+				},
+			},
+			expectedErr: "duplicate secretKey found: user",
+		},
+		{
+			name: "keyCandidates in dataFrom",
+			obj: &ExternalSecret{
+				Spec: ExternalSecretSpec{
+					DataFrom: []ExternalSecretDataFromRemoteRef{
+						{Extract: &ExternalSecretDataRemoteRef{Key: "db", KeyCandidates: []string{"old/db"}}},
+					},
+				},
+			},
+			expectedErr: "keyCandidates can only be used in data (key: db)",
+		},
+		{
+			name: "empty keyCandidate",
+			obj: &ExternalSecret{
+				Spec: ExternalSecretSpec{
+					Data: []ExternalSecretData{
+						{SecretKey: "db", RemoteRef: ExternalSecretDataRemoteRef{Key: "db", KeyCandidates: []string{""}}},
+					},
+				},
+			},
+			expectedErr: "keyCandidates must not be empty (key: db)",
+		},
+		{
+			name: "duplicate secretKey from properties",
+			obj: &ExternalSecret{
+				Spec: ExternalSecretSpec{
+					Target: ExternalSecretTarget{
+						DeletionPolicy: DeletionPolicyRetain,
+					},
+					Data: []ExternalSecretData{
+						{SecretKey: "user", RemoteRef: ExternalSecretDataRemoteRef{Key: "user"}},
+						{RemoteRef: ExternalSecretDataRemoteRef{Key: "db", Properties: map[string]string{"user": "user", "password": "password"}}},
+					},
+				},
+			},
+			expectedErr: "duplicate secretKey found: user",
+		},
 		{
 			name: "duplicate secretKeys",
 			obj: &ExternalSecret{
@@ -222,3 +720,83 @@ either data or dataFrom should be specified`,
 		})
 	}
 }
+
+func TestValidateAllowedSecretTypes(t *testing.T) {
+	newES := func(tpl *ExternalSecretTemplate) *ExternalSecret {
+		return &ExternalSecret{
+			Spec: ExternalSecretSpec{
+				Target: ExternalSecretTarget{Template: tpl},
+				Data:   []ExternalSecretData{{SecretKey: "foo", RemoteRef: ExternalSecretDataRemoteRef{Key: "bar"}}},
+			},
+		}
+	}
+	tests := []struct {
+		name        string
+		allowed     []corev1.SecretType
+		es          *ExternalSecret
+		expectedErr string
+	}{
+		{
+			name: "all types are allowed by default",
+			es:   newES(&ExternalSecretTemplate{Type: corev1.SecretTypeServiceAccountToken}),
+		},
+		{
+			name:    "allowed type",
+			allowed: []corev1.SecretType{corev1.SecretTypeOpaque, corev1.SecretTypeTLS},
+			es:      newES(&ExternalSecretTemplate{Type: corev1.SecretTypeTLS}),
+		},
+		{
+			name:    "no template is Opaque",
+			allowed: []corev1.SecretType{corev1.SecretTypeOpaque},
+			es:      newES(nil),
+		},
+		{
+			name:        "disallowed type",
+			allowed:     []corev1.SecretType{corev1.SecretTypeOpaque, corev1.SecretTypeTLS},
+			es:          newES(&ExternalSecretTemplate{Type: corev1.SecretTypeServiceAccountToken}),
+			expectedErr: "secret type kubernetes.io/service-account-token is not allowed, allowed types: [Opaque kubernetes.io/tls]",
+		},
+		{
+			name:        "no template is rejected without Opaque",
+			allowed:     []corev1.SecretType{corev1.SecretTypeTLS},
+			es:          newES(&ExternalSecretTemplate{}),
+			expectedErr: "secret type Opaque is not allowed, allowed types: [kubernetes.io/tls]",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			esv := &ExternalSecretValidator{AllowedSecretTypes: tt.allowed}
+			_, err := esv.ValidateCreate(context.Background(), tt.es)
+			if tt.expectedErr == "" {
+				if err != nil {
+					t.Fatalf("ValidateCreate() returned an unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.expectedErr {
+				t.Fatalf("ValidateCreate() returned an unexpected error: got: %v, expected: %v", err, tt.expectedErr)
+			}
+		})
+	}
+}
+
+func TestValidateTemplateEngineWarning(t *testing.T) {
+	for _, version := range []TemplateEngineVersion{TemplateEngineV1, TemplateEngineV2} {
+		t.Run(string(version), func(t *testing.T) {
+			es := &ExternalSecret{
+				Spec: ExternalSecretSpec{
+					Target: ExternalSecretTarget{Template: &ExternalSecretTemplate{EngineVersion: version}},
+					Data:   []ExternalSecretData{{SecretKey: "foo", RemoteRef: ExternalSecretDataRemoteRef{Key: "bar"}}},
+				},
+			}
+			warnings, err := validateExternalSecret(es)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			wantWarning := version == TemplateEngineV1
+			if got := slices.Contains(warnings, warnTemplateEngineV1); got != wantWarning {
+				t.Errorf("unexpected warnings %v for engine %s", warnings, version)
+			}
+		})
+	}
+}
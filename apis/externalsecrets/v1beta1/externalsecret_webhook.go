@@ -18,9 +18,11 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 )
 
-func (r *ExternalSecret) SetupWebhookWithManager(mgr ctrl.Manager) error {
+// SetupWebhookWithManager registers the validating webhook with the given validator,
+// e.g. to restrict the types of target secrets or to enable the preview of the target secret.
+func (r *ExternalSecret) SetupWebhookWithManager(mgr ctrl.Manager, validator *ExternalSecretValidator) error {
 	return ctrl.NewWebhookManagedBy(mgr).
 		For(r).
-		WithValidator(&ExternalSecretValidator{}).
+		WithValidator(validator).
 		Complete()
 }
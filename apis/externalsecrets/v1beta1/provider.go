@@ -16,6 +16,9 @@ package v1beta1
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -88,6 +91,8 @@ type SecretsClient interface {
 	Validate() (ValidationResult, error)
 
 	// GetSecretMap returns multiple k/v pairs from the provider
+	// if the secret does not exist it must return an error with type NoSecretError
+	// as well, so the deletionPolicy applies to dataFrom.extract.
 	GetSecretMap(ctx context.Context, ref ExternalSecretDataRemoteRef) (map[string][]byte, error)
 
 	// GetAllSecrets returns multiple k/v pairs from the provider
@@ -96,6 +101,201 @@ type SecretsClient interface {
 	Close(ctx context.Context) error
 }
 
+// +kubebuilder:object:root=false
+// +kubebuilder:object:generate:false
+// +k8s:deepcopy-gen:interfaces=nil
+// +k8s:deepcopy-gen=nil
+
+// AtomicPushSecretClient is implemented by SecretsClients that can write
+// multiple properties of one remote secret in a single operation.
+// The PushSecret controller uses it instead of pushing the properties one by one,
+// so a failure never leaves the remote secret partially updated.
+type AtomicPushSecretClient interface {
+	// PushSecrets writes all data entries in a single operation. All entries
+	// share the same remote key and set a property. Either all entries are written or none.
+	PushSecrets(ctx context.Context, secret *corev1.Secret, data []PushSecretData) error
+}
+
+// +kubebuilder:object:root=false
+// +kubebuilder:object:generate:false
+// +k8s:deepcopy-gen:interfaces=nil
+// +k8s:deepcopy-gen=nil
+
+// SecretVersionLister is implemented by SecretsClients that can list
+// the versions of a remote secret, e.g. for rollback tooling.
+// It is not used to read secrets.
+type SecretVersionLister interface {
+	// ListSecretVersions returns the versions of the secret ref.Key.
+	// Only ref.Key is used, the versions are sorted from oldest to newest.
+	ListSecretVersions(ctx context.Context, ref ExternalSecretDataRemoteRef) ([]SecretVersion, error)
+}
+
+// +kubebuilder:object:root=false
+// +kubebuilder:object:generate:false
+// +k8s:deepcopy-gen:interfaces=nil
+// +k8s:deepcopy-gen=nil
+
+// SecretVersion is a version of a remote secret.
+type SecretVersion struct {
+	// Version identifies the version, it can be used as remoteRef.version.
+	Version string
+	// CreatedAt is the creation time of the version.
+	CreatedAt time.Time
+	// Attributes describe the state of the version,
+	// e.g. AWS version stages, the GCP version state or destroyed for Vault.
+	Attributes []string
+}
+
+// ErrListSecretVersionsNotSupported is returned by ListSecretVersions
+// if the SecretsClient does not implement SecretVersionLister.
+var ErrListSecretVersionsNotSupported = errors.New("provider does not support listing secret versions")
+
+// ListSecretVersions lists the versions of a remote secret
+// if the client implements SecretVersionLister.
+func ListSecretVersions(ctx context.Context, c SecretsClient, ref ExternalSecretDataRemoteRef) ([]SecretVersion, error) {
+	lister, ok := c.(SecretVersionLister)
+	if !ok {
+		return nil, ErrListSecretVersionsNotSupported
+	}
+	return lister.ListSecretVersions(ctx, ref)
+}
+
+// Version aliases of remoteRef.version, each provider maps them to its native versions.
+const (
+	// VersionAliasLatest is the newest version of the secret.
+	VersionAliasLatest = "latest"
+	// VersionAliasCurrent is the version that is served by default, e.g. AWSCURRENT.
+	VersionAliasCurrent = "current"
+	// VersionAliasPrevious is the version before the current one.
+	VersionAliasPrevious = "previous"
+	// VersionAliasPending is the version that is staged to become the current one, e.g. AWSPENDING.
+	VersionAliasPending = "pending"
+)
+
+// IsVersionAlias returns true if version is one of the version aliases.
+func IsVersionAlias(version string) bool {
+	switch version {
+	case VersionAliasLatest, VersionAliasCurrent, VersionAliasPrevious, VersionAliasPending:
+		return true
+	}
+	return false
+}
+
+// +kubebuilder:object:root=false
+// +kubebuilder:object:generate:false
+// +k8s:deepcopy-gen:interfaces=nil
+// +k8s:deepcopy-gen=nil
+
+// SecretVersionAliasResolver is implemented by SecretsClients that can map
+// the version aliases of remoteRef.version to the native versions of the backend.
+type SecretVersionAliasResolver interface {
+	// ResolveVersionAlias returns the native version of the secret ref.Key for the alias in ref.Version.
+	// Aliases the backend can not express return an error wrapping ErrVersionAliasNotSupported.
+	ResolveVersionAlias(ctx context.Context, ref ExternalSecretDataRemoteRef) (string, error)
+}
+
+// ErrVersionAliasNotSupported is returned by ResolveVersionAlias
+// if the SecretsClient does not implement SecretVersionAliasResolver or can not express the alias.
+var ErrVersionAliasNotSupported = errors.New("provider does not support the version alias")
+
+// ResolveVersionAlias returns the native version for the alias in ref.Version
+// if the client implements SecretVersionAliasResolver.
+func ResolveVersionAlias(ctx context.Context, c SecretsClient, ref ExternalSecretDataRemoteRef) (string, error) {
+	resolver, ok := c.(SecretVersionAliasResolver)
+	if !ok {
+		return "", fmt.Errorf("%w %s", ErrVersionAliasNotSupported, ref.Version)
+	}
+	return resolver.ResolveVersionAlias(ctx, ref)
+}
+
+// +kubebuilder:object:root=false
+// +kubebuilder:object:generate:false
+// +k8s:deepcopy-gen:interfaces=nil
+// +k8s:deepcopy-gen=nil
+
+// SecretBulkDeleter is implemented by SecretsClients that can enumerate
+// and delete remote secrets, e.g. for teardown automation.
+type SecretBulkDeleter interface {
+	// DeleteAllSecrets deletes all secrets matching ref and returns their keys in lexical order.
+	// ref.Name and ref.Tags must match if both are set.
+	// Only secrets that carry the managed-by marker of external-secrets are deleted,
+	// all other secrets are never touched. With dryRun the secrets are only listed.
+	// On error the keys deleted so far are returned.
+	DeleteAllSecrets(ctx context.Context, ref ExternalSecretFind, dryRun bool) ([]string, error)
+}
+
+// ErrDeleteAllSecretsNotSupported is returned by DeleteAllSecrets
+// if the SecretsClient does not implement SecretBulkDeleter.
+var ErrDeleteAllSecretsNotSupported = errors.New("provider does not support deleting all secrets")
+
+// DeleteAllSecrets deletes all managed secrets matching ref
+// if the client implements SecretBulkDeleter.
+func DeleteAllSecrets(ctx context.Context, c SecretsClient, ref ExternalSecretFind, dryRun bool) ([]string, error) {
+	deleter, ok := c.(SecretBulkDeleter)
+	if !ok {
+		return nil, ErrDeleteAllSecretsNotSupported
+	}
+	return deleter.DeleteAllSecrets(ctx, ref, dryRun)
+}
+
+// +kubebuilder:object:root=false
+// +kubebuilder:object:generate:false
+// +k8s:deepcopy-gen:interfaces=nil
+// +k8s:deepcopy-gen=nil
+
+// SecretRotator is implemented by SecretsClients of backends with a native
+// rotation trigger. Unlike PushSecret no value is written by the controller,
+// the backend generates and stores the new value itself.
+type SecretRotator interface {
+	// RotateSecret triggers the rotation of the secret remoteRef.GetRemoteKey().
+	// It returns once the rotation has been started, it may complete asynchronously.
+	RotateSecret(ctx context.Context, remoteRef PushSecretRemoteRef) error
+}
+
+// ErrRotateSecretNotSupported is returned by RotateSecret
+// if the SecretsClient does not implement SecretRotator.
+var ErrRotateSecretNotSupported = errors.New("provider does not support rotating secrets")
+
+// RotateSecret triggers the native rotation of a remote secret
+// if the client implements SecretRotator.
+func RotateSecret(ctx context.Context, c SecretsClient, remoteRef PushSecretRemoteRef) error {
+	rotator, ok := c.(SecretRotator)
+	if !ok {
+		return ErrRotateSecretNotSupported
+	}
+	return rotator.RotateSecret(ctx, remoteRef)
+}
+
+// +kubebuilder:object:root=false
+// +kubebuilder:object:generate:false
+// +k8s:deepcopy-gen:interfaces=nil
+// +k8s:deepcopy-gen=nil
+
+// SecretWatcher is implemented by Providers of backends that publish change notifications.
+// The ExternalSecrets of a store are refreshed as soon as one of their secrets changes,
+// stores of other providers are only polled on the refresh interval.
+type SecretWatcher interface {
+	// WatchSecrets subscribes to the change notifications of the store and calls notify
+	// with the remote key of every changed secret until ctx is done.
+	// An empty key refreshes all ExternalSecrets of the store.
+	// It returns ErrWatchSecretsNotSupported if change notifications are not configured for the store.
+	WatchSecrets(ctx context.Context, store GenericStore, kube client.Client, namespace string, notify func(key string)) error
+}
+
+// ErrWatchSecretsNotSupported is returned by WatchSecrets
+// if the Provider does not implement SecretWatcher or the store does not configure change notifications.
+var ErrWatchSecretsNotSupported = errors.New("provider does not support watching secrets")
+
+// WatchSecrets subscribes to the change notifications of a store
+// if the provider implements SecretWatcher.
+func WatchSecrets(ctx context.Context, p Provider, store GenericStore, kube client.Client, namespace string, notify func(key string)) error {
+	watcher, ok := p.(SecretWatcher)
+	if !ok {
+		return ErrWatchSecretsNotSupported
+	}
+	return watcher.WatchSecrets(ctx, store, kube, namespace, notify)
+}
+
 var NoSecretErr = NoSecretError{}
 
 // NoSecretError shall be returned when a GetSecret can not find the
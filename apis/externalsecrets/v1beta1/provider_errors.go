@@ -0,0 +1,123 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"errors"
+	"net/http"
+)
+
+// ProviderErrorReason classifies an error of a provider.
+// It is used as the reason of the Ready condition of an ExternalSecret.
+type ProviderErrorReason string
+
+const (
+	// ProviderErrorPermissionDenied indicates that the credentials are valid but not allowed to access the secret.
+	ProviderErrorPermissionDenied ProviderErrorReason = "PermissionDenied"
+	// ProviderErrorNotFound indicates that the secret or a parent resource does not exist.
+	ProviderErrorNotFound ProviderErrorReason = "NotFound"
+	// ProviderErrorRateLimited indicates that the provider throttled the request.
+	ProviderErrorRateLimited ProviderErrorReason = "RateLimited"
+	// ProviderErrorUnauthenticated indicates that the credentials are missing, invalid or expired.
+	ProviderErrorUnauthenticated ProviderErrorReason = "Unauthenticated"
+	// ProviderErrorUnavailable indicates that the provider is temporarily unavailable.
+	ProviderErrorUnavailable ProviderErrorReason = "Unavailable"
+	// ProviderErrorTLSPinMismatch indicates that the TLS certificate of the provider
+	// does not match any of the pinned keys of the store.
+	ProviderErrorTLSPinMismatch ProviderErrorReason = "TLSPinMismatch"
+)
+
+// ErrTLSPinMismatch is returned if the TLS certificate chain of a provider
+// does not contain any of the public keys pinned with tlsPinnedKeys.
+var ErrTLSPinMismatch = errors.New("TLS pin mismatch: the certificate of the server does not match any pinned key, the connection was refused")
+
+// +kubebuilder:object:root=false
+// +kubebuilder:object:generate:false
+// +k8s:deepcopy-gen:interfaces=nil
+// +k8s:deepcopy-gen=nil
+
+// ErrorClassifier is implemented by Providers that can classify
+// the errors of their SecretsClients, so they are reported with a common reason.
+type ErrorClassifier interface {
+	// ClassifyError returns the reason of err, or an empty reason if the error is not known.
+	ClassifyError(err error) ProviderErrorReason
+}
+
+// +kubebuilder:object:root=false
+// +kubebuilder:object:generate:false
+// +k8s:deepcopy-gen:interfaces=nil
+// +k8s:deepcopy-gen=nil
+
+// ProviderError is an error of a provider with its reason.
+// The message of the error is the message of the provider.
+type ProviderError struct {
+	Reason ProviderErrorReason
+	Err    error
+}
+
+func (e *ProviderError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *ProviderError) Unwrap() error {
+	return e.Err
+}
+
+// ClassifyError wraps err in a ProviderError if the provider implements ErrorClassifier
+// and knows the error. A NoSecretError is classified as NotFound.
+// An ErrTLSPinMismatch is classified as TLSPinMismatch for every provider.
+// Errors that are already classified and errors of other providers are returned as they are.
+func ClassifyError(provider Provider, err error) error {
+	if err == nil {
+		return err
+	}
+	var providerErr *ProviderError
+	if errors.As(err, &providerErr) {
+		return err
+	}
+	if errors.Is(err, ErrTLSPinMismatch) {
+		return &ProviderError{Reason: ProviderErrorTLSPinMismatch, Err: err}
+	}
+	classifier, ok := provider.(ErrorClassifier)
+	if !ok {
+		return err
+	}
+	reason := classifier.ClassifyError(err)
+	if reason == "" && errors.Is(err, NoSecretErr) {
+		reason = ProviderErrorNotFound
+	}
+	if reason == "" {
+		return err
+	}
+	return &ProviderError{Reason: reason, Err: err}
+}
+
+// ProviderErrorReasonForHTTPStatus returns the reason of an HTTP status code
+// or an empty reason, to be used by ErrorClassifiers of HTTP based providers.
+func ProviderErrorReasonForHTTPStatus(code int) ProviderErrorReason {
+	switch code {
+	case http.StatusUnauthorized:
+		return ProviderErrorUnauthenticated
+	case http.StatusForbidden:
+		return ProviderErrorPermissionDenied
+	case http.StatusNotFound:
+		return ProviderErrorNotFound
+	case http.StatusTooManyRequests:
+		return ProviderErrorRateLimited
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return ProviderErrorUnavailable
+	}
+	return ""
+}
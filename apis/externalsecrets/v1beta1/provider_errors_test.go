@@ -0,0 +1,100 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+type classifyingProvider struct {
+	PP
+}
+
+func (p *classifyingProvider) ClassifyError(err error) ProviderErrorReason {
+	if err.Error() == "denied" {
+		return ProviderErrorPermissionDenied
+	}
+	return ""
+}
+
+func TestClassifyError(t *testing.T) {
+	denied := errors.New("denied")
+	tests := []struct {
+		name       string
+		provider   Provider
+		err        error
+		wantReason ProviderErrorReason
+	}{
+		{
+			name:     "no error",
+			provider: &classifyingProvider{},
+		},
+		{
+			name:     "provider without classifier",
+			provider: &PP{},
+			err:      denied,
+		},
+		{
+			name:       "known error",
+			provider:   &classifyingProvider{},
+			err:        denied,
+			wantReason: ProviderErrorPermissionDenied,
+		},
+		{
+			name:     "unknown error",
+			provider: &classifyingProvider{},
+			err:      errors.New("boom"),
+		},
+		{
+			name:       "missing secret",
+			provider:   &classifyingProvider{},
+			err:        fmt.Errorf("key foo: %w", NoSecretErr),
+			wantReason: ProviderErrorNotFound,
+		},
+		{
+			name:       "TLS pin mismatch of a provider without classifier",
+			provider:   &PP{},
+			err:        fmt.Errorf("Get %q: %w", "https://vault.example.com", ErrTLSPinMismatch),
+			wantReason: ProviderErrorTLSPinMismatch,
+		},
+		{
+			name:       "already classified",
+			provider:   &classifyingProvider{},
+			err:        fmt.Errorf("wrapped: %w", &ProviderError{Reason: ProviderErrorRateLimited, Err: denied}),
+			wantReason: ProviderErrorRateLimited,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ClassifyError(tt.provider, tt.err)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("ClassifyError() = %v, does not wrap %v", err, tt.err)
+			}
+			var providerErr *ProviderError
+			var reason ProviderErrorReason
+			if errors.As(err, &providerErr) {
+				reason = providerErr.Reason
+			}
+			if reason != tt.wantReason {
+				t.Errorf("unexpected reason %q, want %q", reason, tt.wantReason)
+			}
+			if err != nil && err.Error() != tt.err.Error() {
+				t.Errorf("the message changed from %q to %q", tt.err, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,81 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"context"
+	"maps"
+	"strings"
+	"sync"
+)
+
+type providerMetadataKey struct{}
+
+// +kubebuilder:object:root=false
+// +kubebuilder:object:generate:false
+// +k8s:deepcopy-gen:interfaces=nil
+// +k8s:deepcopy-gen=nil
+
+// ProviderMetadata collects the metadata providers report about the secrets
+// they read for an ExternalSecret, e.g. the backend or the time of the read.
+type ProviderMetadata struct {
+	mu       sync.Mutex
+	metadata map[string]string
+}
+
+// ContextWithProviderMetadata returns a context that collects the metadata
+// reported by providers with ReportProviderMetadata.
+func ContextWithProviderMetadata(ctx context.Context) (context.Context, *ProviderMetadata) {
+	collector := &ProviderMetadata{metadata: make(map[string]string)}
+	return context.WithValue(ctx, providerMetadataKey{}, collector), collector
+}
+
+// ReportProviderMetadata is called by providers to report metadata about a secret they read.
+// It is a no-op if the metadata is not collected. If several reads report the same key, the last value wins.
+// NOTE: the metadata may be written to the annotations of the Secret, it must never contain secret data.
+func ReportProviderMetadata(ctx context.Context, metadata map[string]string) {
+	collector, ok := ctx.Value(providerMetadataKey{}).(*ProviderMetadata)
+	if !ok || collector == nil {
+		return
+	}
+	collector.mu.Lock()
+	defer collector.mu.Unlock()
+	maps.Copy(collector.metadata, metadata)
+}
+
+// Get returns a copy of the collected metadata.
+func (m *ProviderMetadata) Get() map[string]string {
+	if m == nil {
+		return nil
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return maps.Clone(m.metadata)
+}
+
+// sensitiveMetadataKeys are never written to the annotations of a Secret,
+// even if a provider reports them.
+var sensitiveMetadataKeys = []string{"password", "passwd", "secret", "token", "credential", "private", "apikey", "api_key", "api-key"}
+
+// IsSensitiveMetadataKey returns true if the name of the metadata key suggests it holds sensitive data.
+func IsSensitiveMetadataKey(key string) bool {
+	key = strings.ToLower(key)
+	for _, s := range sensitiveMetadataKeys {
+		if strings.Contains(key, s) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,36 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import "context"
+
+type providerOptionsKey struct{}
+
+// ContextWithProviderOptions returns a context carrying the rendered
+// spec.providerOptions of an ExternalSecret.
+func ContextWithProviderOptions(ctx context.Context, options map[string]string) context.Context {
+	if len(options) == 0 {
+		return ctx
+	}
+	return context.WithValue(ctx, providerOptionsKey{}, options)
+}
+
+// ProviderOptionsFromContext returns the provider options of the ExternalSecret
+// that is currently reconciled, or nil if there are none.
+// NOTE: values may contain sensitive data and must never be logged.
+func ProviderOptionsFromContext(ctx context.Context) map[string]string {
+	options, _ := ctx.Value(providerOptionsKey{}).(map[string]string)
+	return options
+}
@@ -81,6 +81,18 @@ type SecretsManager struct {
 	// see: https://docs.aws.amazon.com/secretsmanager/latest/apireference/API_DeleteSecret.html#SecretsManager-DeleteSecret-request-RecoveryWindowInDays
 	// +optional
 	RecoveryWindowInDays int64 `json:"recoveryWindowInDays,omitempty"`
+	// ChangeNotifications refreshes the ExternalSecrets of the store as soon as one of their secrets changes,
+	// instead of waiting for their refresh interval.
+	// +optional
+	ChangeNotifications *AWSChangeNotifications `json:"changeNotifications,omitempty"`
+}
+
+// AWSChangeNotifications configures the SQS queue that receives the change events of the secrets.
+type AWSChangeNotifications struct {
+	// QueueURL is the URL of an SQS queue that receives the Secrets Manager events of an EventBridge rule.
+	// The messages are deleted once they are processed, so the queue must not be shared with other consumers.
+	// +kubebuilder:validation:MinLength=1
+	QueueURL string `json:"queueURL"`
 }
 
 type Tag struct {
@@ -128,4 +140,9 @@ type AWSProvider struct {
 	// Prefix adds a prefix to all retrieved values.
 	// +optional
 	Prefix string `json:"prefix,omitempty"`
+
+	// Proxy routes the requests to AWS through a proxy,
+	// overriding the proxy environment variables of the controller.
+	// +optional
+	Proxy *ProxyConfig `json:"proxy,omitempty"`
 }
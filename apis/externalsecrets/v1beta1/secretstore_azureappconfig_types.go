@@ -0,0 +1,70 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import smmeta "github.com/external-secrets/external-secrets/apis/meta/v1"
+
+// AzureAppConfigProvider configures a store to sync key-values from Azure App Configuration.
+// It authenticates with Azure in the same way as the Azure Key Vault provider.
+type AzureAppConfigProvider struct {
+	// Endpoint of the App Configuration store, e.g. https://my-store.azconfig.io.
+	Endpoint string `json:"endpoint"`
+
+	// Label of the key-values to read, remoteRef.version overrides it for a single key.
+	// Key-values without a label are read if it is empty.
+	// +optional
+	Label string `json:"label,omitempty"`
+
+	// ResolveKeyVaultReferences returns the value of the Key Vault secret a key-value references,
+	// instead of the reference itself. The identity must be allowed to read the secrets of the vault.
+	// +optional
+	ResolveKeyVaultReferences bool `json:"resolveKeyVaultReferences,omitempty"`
+
+	// Auth type defines how to authenticate to Azure.
+	// Valid values are:
+	// - "ServicePrincipal" (default): Using a service principal (tenantId, clientId, clientSecret)
+	// - "ManagedIdentity": Using Managed Identity assigned to the pod (see aad-pod-identity)
+	// - "WorkloadIdentity": Using Workload Identity service accounts
+	// +optional
+	// +kubebuilder:default=ServicePrincipal
+	AuthType *AzureAuthType `json:"authType,omitempty"`
+
+	// TenantID configures the Azure Tenant to send requests to. Required for ServicePrincipal auth type. Optional for WorkloadIdentity.
+	// +optional
+	TenantID *string `json:"tenantId,omitempty"`
+
+	// EnvironmentType specifies the Azure cloud environment endpoints to use for
+	// authenticating with Azure. By default it points to the public cloud AAD endpoint.
+	// +kubebuilder:default=PublicCloud
+	EnvironmentType AzureEnvironmentType `json:"environmentType,omitempty"`
+
+	// Auth configures how the operator authenticates with Azure. Required for ServicePrincipal auth type. Optional for WorkloadIdentity.
+	// +optional
+	AuthSecretRef *AzureKVAuth `json:"authSecretRef,omitempty"`
+
+	// ServiceAccountRef specified the service account
+	// that should be used when authenticating with WorkloadIdentity.
+	// +optional
+	ServiceAccountRef *smmeta.ServiceAccountSelector `json:"serviceAccountRef,omitempty"`
+
+	// If multiple Managed Identity is assigned to the pod, you can select the one to be used
+	// +optional
+	IdentityID *string `json:"identityId,omitempty"`
+
+	// Proxy routes the requests to Azure through a proxy,
+	// overriding the proxy environment variables of the controller.
+	// +optional
+	Proxy *ProxyConfig `json:"proxy,omitempty"`
+}
@@ -84,6 +84,11 @@ type AzureKVProvider struct {
 	// If multiple Managed Identity is assigned to the pod, you can select the one to be used
 	// +optional
 	IdentityID *string `json:"identityId,omitempty"`
+
+	// Proxy routes the requests to Azure Key Vault through a proxy,
+	// overriding the proxy environment variables of the controller.
+	// +optional
+	Proxy *ProxyConfig `json:"proxy,omitempty"`
 }
 
 // Configuration used to authenticate with Azure.
@@ -0,0 +1,27 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+// EnvProvider configures a store that reads secrets from environment variables
+// of the controller process. It is intended for local development and testing only.
+// The controller must be started with --enable-env-provider and only reads
+// the variables listed in --env-provider-allowed-variables.
+type EnvProvider struct {
+	// Prefix of the environment variables that can be read by this store.
+	// A remoteRef.key of `DB_PASSWORD` with the prefix `ESO_` reads the variable `ESO_DB_PASSWORD`.
+	// The prefix prevents exposing unrelated environment variables of the controller.
+	// +kubebuilder:validation:MinLength:=1
+	Prefix string `json:"prefix"`
+}
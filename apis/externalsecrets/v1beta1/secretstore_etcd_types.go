@@ -0,0 +1,54 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	esmeta "github.com/external-secrets/external-secrets/apis/meta/v1"
+)
+
+// EtcdProvider configures a store to sync secrets from the keys of an etcd v3 cluster.
+type EtcdProvider struct {
+	// Endpoints of the etcd cluster, e.g. https://etcd-0.etcd:2379.
+	// +kubebuilder:validation:MinItems:=1
+	Endpoints []string `json:"endpoints"`
+
+	// CABundle is a base64-encoded CA certificate used to verify the etcd server.
+	// +optional
+	CABundle []byte `json:"caBundle,omitempty"`
+
+	// CAProvider points to a Secret or ConfigMap holding the CA certificate used to verify the etcd server.
+	// +optional
+	CAProvider *CAProvider `json:"caProvider,omitempty"`
+
+	// Auth configures how the controller authenticates with etcd.
+	// +optional
+	Auth *EtcdAuth `json:"auth,omitempty"`
+}
+
+// EtcdAuth configures the authentication with etcd.
+type EtcdAuth struct {
+	// ClientCert authenticates with a TLS client certificate.
+	// +optional
+	ClientCert *EtcdClientCertAuth `json:"clientCert,omitempty"`
+}
+
+// EtcdClientCertAuth references a PEM encoded TLS client certificate and its private key.
+type EtcdClientCertAuth struct {
+	// Certificate references the PEM encoded client certificate.
+	Certificate esmeta.SecretKeySelector `json:"certificate"`
+
+	// PrivateKey references the PEM encoded private key of the client certificate.
+	PrivateKey esmeta.SecretKeySelector `json:"privateKey"`
+}
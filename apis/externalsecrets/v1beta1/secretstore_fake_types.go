@@ -14,9 +14,47 @@ limitations under the License.
 
 package v1beta1
 
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
 // FakeProvider configures a fake provider that returns static values.
 type FakeProvider struct {
 	Data []FakeProviderData `json:"data"`
+
+	// Chaos makes the provider slow or flaky, to test retries, timeouts and circuit breakers.
+	// +optional
+	Chaos *FakeProviderChaos `json:"chaos,omitempty"`
+}
+
+// FakeProviderChaos injects latency and errors into the requests to a fake provider.
+// The random decisions are made with a generator per store that is seeded with Seed,
+// so the same sequence of requests always gets the same latencies and errors.
+type FakeProviderChaos struct {
+	// Latency is added to every request.
+	// +optional
+	Latency *metav1.Duration `json:"latency,omitempty"`
+
+	// LatencyJitter adds a random latency between zero and LatencyJitter to every request.
+	// +optional
+	LatencyJitter *metav1.Duration `json:"latencyJitter,omitempty"`
+
+	// ErrorRate is the percentage of requests that fail.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	ErrorRate int `json:"errorRate,omitempty"`
+
+	// ErrorReasons are the reasons of the injected errors, one is picked at random for every failed request.
+	// NotFound returns a missing secret, so the deletionPolicy applies.
+	// Defaults to Unavailable.
+	// +optional
+	// +kubebuilder:validation:items:Enum=PermissionDenied;NotFound;RateLimited;Unauthenticated;Unavailable
+	ErrorReasons []ProviderErrorReason `json:"errorReasons,omitempty"`
+
+	// Seed of the random generator.
+	// +optional
+	Seed int64 `json:"seed,omitempty"`
 }
 
 type FakeProviderData struct {
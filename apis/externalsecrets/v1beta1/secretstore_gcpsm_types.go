@@ -49,4 +49,9 @@ type GCPSMProvider struct {
 
 	// Location optionally defines a location for a secret
 	Location string `json:"location,omitempty"`
+
+	// Proxy routes the requests to GCP Secret Manager through a proxy,
+	// overriding the proxy environment variables of the controller.
+	// +optional
+	Proxy *ProxyConfig `json:"proxy,omitempty"`
 }
@@ -32,6 +32,21 @@ type KubernetesServer struct {
 	// see: https://external-secrets.io/v0.4.1/spec/#external-secrets.io/v1alpha1.CAProvider
 	// +optional
 	CAProvider *CAProvider `json:"caProvider,omitempty"`
+
+	// ServerName overrides the name used to verify the server certificate,
+	// it is also sent with SNI. Use it if the API server is reached through a gateway with another host name.
+	// Defaults to the host of the URL.
+	// +optional
+	// +kubebuilder:validation:MaxLength:=253
+	ServerName string `json:"serverName,omitempty"`
+
+	// TLSPinnedKeys pins the public keys of the TLS certificates of the API server.
+	// The connection is refused unless the verified certificate chain contains one of the keys,
+	// even if the certificate is signed by a trusted CA.
+	// A pin is the base64 encoded SHA-256 hash of the DER encoded SubjectPublicKeyInfo, prefixed with "sha256/".
+	// +optional
+	// +kubebuilder:validation:items:Pattern:=`^sha256/[A-Za-z0-9+/]{43}=$`
+	TLSPinnedKeys []string `json:"tlsPinnedKeys,omitempty"`
 }
 
 // Configures a store to sync secrets with a Kubernetes instance.
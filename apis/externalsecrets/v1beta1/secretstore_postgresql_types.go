@@ -0,0 +1,85 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	esmeta "github.com/external-secrets/external-secrets/apis/meta/v1"
+)
+
+// PostgreSQLSSLMode configures whether the connection to PostgreSQL uses TLS and how the server is verified.
+// +kubebuilder:validation:Enum=disable;require;verify-ca;verify-full
+type PostgreSQLSSLMode string
+
+const (
+	PostgreSQLSSLModeDisable    PostgreSQLSSLMode = "disable"
+	PostgreSQLSSLModeRequire    PostgreSQLSSLMode = "require"
+	PostgreSQLSSLModeVerifyCA   PostgreSQLSSLMode = "verify-ca"
+	PostgreSQLSSLModeVerifyFull PostgreSQLSSLMode = "verify-full"
+)
+
+// PostgreSQLProvider configures a store to sync secrets from the rows of a PostgreSQL table.
+// The remote key selects the row whose key column equals the key, its columns become the secret keys.
+type PostgreSQLProvider struct {
+	// Host of the PostgreSQL server.
+	// +kubebuilder:validation:MinLength:=1
+	Host string `json:"host"`
+
+	// Port of the PostgreSQL server.
+	// +optional
+	// +kubebuilder:default=5432
+	Port int `json:"port,omitempty"`
+
+	// Database to connect to.
+	// +kubebuilder:validation:MinLength:=1
+	Database string `json:"database"`
+
+	// SSLMode configures TLS for the connection. Defaults to verify-full.
+	// +optional
+	// +kubebuilder:default="verify-full"
+	SSLMode PostgreSQLSSLMode `json:"sslMode,omitempty"`
+
+	// CABundle is a base64-encoded CA certificate used to verify the PostgreSQL server.
+	// +optional
+	CABundle []byte `json:"caBundle,omitempty"`
+
+	// CAProvider points to a Secret or ConfigMap holding the CA certificate used to verify the PostgreSQL server.
+	// +optional
+	CAProvider *CAProvider `json:"caProvider,omitempty"`
+
+	// Auth configures the credentials of the connection.
+	Auth PostgreSQLAuth `json:"auth"`
+
+	// Table to read the secrets from, optionally qualified with its schema, e.g. app.credentials.
+	// +kubebuilder:validation:MinLength:=1
+	Table string `json:"table"`
+
+	// KeyColumn is the column of the table that the remote key is matched against.
+	// +kubebuilder:validation:MinLength:=1
+	KeyColumn string `json:"keyColumn"`
+
+	// Columns lists the columns that are returned as secret keys. Defaults to all columns except the key column.
+	// +optional
+	Columns []string `json:"columns,omitempty"`
+}
+
+// PostgreSQLAuth references the credentials of the PostgreSQL user.
+type PostgreSQLAuth struct {
+	// Username of the PostgreSQL user.
+	// +kubebuilder:validation:MinLength:=1
+	Username string `json:"username"`
+
+	// PasswordSecretRef references the password of the PostgreSQL user.
+	PasswordSecretRef esmeta.SecretKeySelector `json:"passwordSecretRef"`
+}
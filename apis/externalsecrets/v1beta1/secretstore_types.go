@@ -34,12 +34,25 @@ type SecretStoreSpec struct {
 	RetrySettings *SecretStoreRetrySettings `json:"retrySettings,omitempty"`
 
 	// Used to configure store refresh interval in seconds. Empty or 0 will default to the controller config.
+	// The store is validated again after this interval, e.g. 3600 for stores with costly authentication.
 	// +optional
+	// +kubebuilder:validation:Minimum=0
 	RefreshInterval int `json:"refreshInterval,omitempty"`
 
+	// Tolerated difference between the clock of the provider and the controller
+	// when expiry timestamps reported by the provider are compared, e.g. "30s".
+	// Empty will default to the --clock-skew flag of the controller.
+	// +optional
+	ClockSkew *metav1.Duration `json:"clockSkew,omitempty"`
+
 	// Used to constraint a ClusterSecretStore to specific namespaces. Relevant only to ClusterSecretStore
 	// +optional
 	Conditions []ClusterSecretStoreCondition `json:"conditions,omitempty"`
+
+	// Disables the store after the given time, e.g. for bootstrap credentials that must only be used
+	// during the initial setup. ExternalSecrets and PushSecrets can not use an expired store.
+	// +optional
+	ExpiresAt *metav1.Time `json:"expiresAt,omitempty"`
 }
 
 // ClusterSecretStoreCondition describes a condition by which to choose namespaces to process ExternalSecrets in
@@ -73,6 +86,10 @@ type SecretStoreProvider struct {
 	// +optional
 	AzureKV *AzureKVProvider `json:"azurekv,omitempty"`
 
+	// AzureAppConfig configures this store to sync key-values from Azure App Configuration
+	// +optional
+	AzureAppConfig *AzureAppConfigProvider `json:"azureappconfig,omitempty"`
+
 	// Akeyless configures this store to sync secrets using Akeyless Vault provider
 	// +optional
 	Akeyless *AkeylessProvider `json:"akeyless,omitempty"`
@@ -129,6 +146,19 @@ type SecretStoreProvider struct {
 	// +optional
 	Fake *FakeProvider `json:"fake,omitempty"`
 
+	// Env configures a store to read secrets from environment variables of the controller.
+	// It is intended for local development and testing only.
+	// +optional
+	Env *EnvProvider `json:"env,omitempty"`
+
+	// Etcd configures this store to sync secrets from the keys of an etcd v3 cluster
+	// +optional
+	Etcd *EtcdProvider `json:"etcd,omitempty"`
+
+	// PostgreSQL configures this store to sync secrets from the rows of a PostgreSQL table
+	// +optional
+	PostgreSQL *PostgreSQLProvider `json:"postgresql,omitempty"`
+
 	// Senhasegura configures this store to sync secrets using senhasegura provider
 	// +optional
 	Senhasegura *SenhaseguraProvider `json:"senhasegura,omitempty"`
@@ -235,6 +265,25 @@ type CAProvider struct {
 	Namespace *string `json:"namespace,omitempty"`
 }
 
+// ProxyConfig configures the proxy used for the requests of a provider.
+// It replaces the HTTP_PROXY, HTTPS_PROXY and NO_PROXY environment variables of the controller for the store.
+type ProxyConfig struct {
+	// HTTPProxy is the URL of the proxy for plain HTTP requests.
+	// http, https and socks5 proxy URLs are supported.
+	// +optional
+	HTTPProxy string `json:"httpProxy,omitempty"`
+
+	// HTTPSProxy is the URL of the proxy for HTTPS and gRPC requests.
+	// http, https and socks5 proxy URLs are supported.
+	// +optional
+	HTTPSProxy string `json:"httpsProxy,omitempty"`
+
+	// NoProxy is a comma-separated list of hosts, domains and CIDRs that are reached without the proxy,
+	// in the format of the NO_PROXY environment variable.
+	// +optional
+	NoProxy string `json:"noProxy,omitempty"`
+}
+
 type SecretStoreRetrySettings struct {
 	MaxRetries    *int32  `json:"maxRetries,omitempty"`
 	RetryInterval *string `json:"retryInterval,omitempty"`
@@ -248,9 +297,16 @@ const (
 	ReasonInvalidStore          = "InvalidStoreConfiguration"
 	ReasonInvalidProviderConfig = "InvalidProviderConfig"
 	ReasonValidationFailed      = "ValidationFailed"
+	ReasonTLSPinMismatch        = "TLSPinMismatch"
+	ReasonStoreExpired          = "Expired"
 	ReasonStoreValid            = "Valid"
+	ReasonForceSynced           = "ForceSynced"
 )
 
+// AnnotationStoreForceSync refreshes all ExternalSecrets using a SecretStore or ClusterSecretStore
+// when it is set. The controller removes it once the ExternalSecrets were refreshed.
+const AnnotationStoreForceSync = "external-secrets.io/force-sync"
+
 type SecretStoreStatusCondition struct {
 	Type   SecretStoreConditionType `json:"type"`
 	Status corev1.ConditionStatus   `json:"status"`
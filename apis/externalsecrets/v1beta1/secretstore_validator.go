@@ -27,7 +27,9 @@ import (
 var _ admission.CustomValidator = &GenericStoreValidator{}
 
 const (
-	errInvalidStore = "invalid store"
+	errInvalidStore         = "invalid store"
+	errNegativeRefreshStore = "refreshInterval must not be negative, got %d"
+	errNegativeClockSkew    = "clockSkew must not be negative, got %s"
 )
 
 type GenericStoreValidator struct{}
@@ -56,6 +58,13 @@ func (r *GenericStoreValidator) ValidateDelete(_ context.Context, _ runtime.Obje
 }
 
 func validateStore(store GenericStore) (admission.Warnings, error) {
+	if interval := store.GetSpec().RefreshInterval; interval < 0 {
+		return nil, fmt.Errorf(errNegativeRefreshStore, interval)
+	}
+	if skew := store.GetSpec().ClockSkew; skew != nil && skew.Duration < 0 {
+		return nil, fmt.Errorf(errNegativeClockSkew, skew.Duration)
+	}
+
 	if err := validateConditions(store); err != nil {
 		return nil, err
 	}
@@ -16,9 +16,11 @@ package v1beta1
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 )
 
@@ -121,6 +123,53 @@ func TestValidateSecretStore(t *testing.T) {
 				assert.EqualError(t, err, "store error for : secret stores must only have exactly one backend specified, found 2")
 			},
 		},
+		{
+			name: "valid refresh interval",
+			obj: &SecretStore{
+				Spec: SecretStoreSpec{
+					RefreshInterval: 3600,
+					Provider: &SecretStoreProvider{
+						AWS: &AWSProvider{},
+					},
+				},
+			},
+			mock: func() {
+				ForceRegister(&ValidationProvider{}, &SecretStoreProvider{
+					AWS: &AWSProvider{},
+				})
+			},
+			assertErr: func(t *testing.T, err error) {
+				require.NoError(t, err)
+			},
+		},
+		{
+			name: "negative refresh interval",
+			obj: &SecretStore{
+				Spec: SecretStoreSpec{
+					RefreshInterval: -1,
+					Provider: &SecretStoreProvider{
+						AWS: &AWSProvider{},
+					},
+				},
+			},
+			assertErr: func(t *testing.T, err error) {
+				assert.EqualError(t, err, "refreshInterval must not be negative, got -1")
+			},
+		},
+		{
+			name: "negative clock skew",
+			obj: &SecretStore{
+				Spec: SecretStoreSpec{
+					ClockSkew: &metav1.Duration{Duration: -time.Second},
+					Provider: &SecretStoreProvider{
+						AWS: &AWSProvider{},
+					},
+				},
+			},
+			assertErr: func(t *testing.T, err error) {
+				assert.EqualError(t, err, "clockSkew must not be negative, got -1s")
+			},
+		},
 		{
 			name: "no registered store backend",
 			obj: &SecretStore{
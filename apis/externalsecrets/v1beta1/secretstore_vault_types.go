@@ -73,6 +73,21 @@ type VaultProvider struct {
 	// +optional
 	CAProvider *CAProvider `json:"caProvider,omitempty"`
 
+	// ServerName overrides the name used to verify the Vault server certificate,
+	// it is also sent with SNI. Use it if Vault is reached through a gateway with another host name.
+	// Defaults to the host of the Server URL.
+	// +optional
+	// +kubebuilder:validation:MaxLength:=253
+	ServerName string `json:"serverName,omitempty"`
+
+	// TLSPinnedKeys pins the public keys of the TLS certificates of the Vault server.
+	// The connection is refused unless the verified certificate chain contains one of the keys,
+	// even if the certificate is signed by a trusted CA.
+	// A pin is the base64 encoded SHA-256 hash of the DER encoded SubjectPublicKeyInfo, prefixed with "sha256/".
+	// +optional
+	// +kubebuilder:validation:items:Pattern:=`^sha256/[A-Za-z0-9+/]{43}=$`
+	TLSPinnedKeys []string `json:"tlsPinnedKeys,omitempty"`
+
 	// ReadYourWrites ensures isolated read-after-write semantics by
 	// providing discovered cluster replication states in each request.
 	// More information about eventual consistency in Vault can be found here
@@ -90,6 +105,11 @@ type VaultProvider struct {
 	// Headers to be added in Vault request
 	// +optional
 	Headers map[string]string `json:"headers,omitempty"`
+
+	// Proxy routes the requests to the Vault server through a proxy,
+	// overriding the proxy environment variables of the controller.
+	// +optional
+	Proxy *ProxyConfig `json:"proxy,omitempty"`
 }
 
 // VaultClientTLS is the configuration used for client side related TLS communication,
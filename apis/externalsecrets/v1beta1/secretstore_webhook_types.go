@@ -33,6 +33,13 @@ type WebhookProvider struct {
 	// +optional
 	Headers map[string]string `json:"headers,omitempty"`
 
+	// AllowedProviderOptionHeaders lists the names of the headers ExternalSecrets may set with spec.providerOptions.
+	// Requests of an ExternalSecret with other providerOptions fail. Headers defined by the store,
+	// including the Authorization header of auth, are never overridden by providerOptions.
+	// +optional
+	// +kubebuilder:validation:items:Pattern:=`^[A-Za-z0-9-]+$`
+	AllowedProviderOptionHeaders []string `json:"allowedProviderOptionHeaders,omitempty"`
+
 	// Body
 	// +optional
 	Body string `json:"body,omitempty"`
@@ -41,6 +48,10 @@ type WebhookProvider struct {
 	// +optional
 	Timeout *metav1.Duration `json:"timeout,omitempty"`
 
+	// Auth specifies how to authenticate against the webhook endpoint.
+	// +optional
+	Auth *WebhookAuth `json:"auth,omitempty"`
+
 	// Result formatting
 	Result WebhookResult `json:"result"`
 
@@ -59,6 +70,26 @@ type WebhookProvider struct {
 	// The provider for the CA bundle to use to validate webhook server certificate.
 	// +optional
 	CAProvider *WebhookCAProvider `json:"caProvider,omitempty"`
+
+	// ServerName overrides the name used to verify the webhook server certificate,
+	// it is also sent with SNI. Use it if the webhook is reached through a gateway with another host name.
+	// Defaults to the host of the URL.
+	// +optional
+	// +kubebuilder:validation:MaxLength:=253
+	ServerName string `json:"serverName,omitempty"`
+
+	// TLSPinnedKeys pins the public keys of the TLS certificates of the webhook server.
+	// The connection is refused unless the verified certificate chain contains one of the keys,
+	// even if the certificate is signed by a trusted CA.
+	// A pin is the base64 encoded SHA-256 hash of the DER encoded SubjectPublicKeyInfo, prefixed with "sha256/".
+	// +optional
+	// +kubebuilder:validation:items:Pattern:=`^sha256/[A-Za-z0-9+/]{43}=$`
+	TLSPinnedKeys []string `json:"tlsPinnedKeys,omitempty"`
+
+	// Proxy routes the requests to the webhook through a proxy,
+	// overriding the proxy environment variables of the controller.
+	// +optional
+	Proxy *ProxyConfig `json:"proxy,omitempty"`
 }
 
 type WebhookCAProviderType string
@@ -95,6 +126,28 @@ type WebhookCAProvider struct {
 	Namespace *string `json:"namespace,omitempty"`
 }
 
+// WebhookAuth defines the authentication method used for webhook requests.
+type WebhookAuth struct {
+	// ServiceAccountToken sends a token of a ServiceAccount as a bearer token.
+	// +optional
+	ServiceAccountToken *WebhookServiceAccountTokenAuth `json:"serviceAccountToken,omitempty"`
+}
+
+// WebhookServiceAccountTokenAuth requests a token for a ServiceAccount with the TokenRequest API
+// and sends it in the Authorization header. A new token is requested before the previous one expires.
+type WebhookServiceAccountTokenAuth struct {
+	// ServiceAccountRef is the ServiceAccount the token is requested for.
+	// The token is only valid for the given audiences, at least one audience must be set
+	// so the token can not be used against the Kubernetes API server.
+	// In a SecretStore the ServiceAccount is in the namespace of the store.
+	ServiceAccountRef esmeta.ServiceAccountSelector `json:"serviceAccountRef"`
+
+	// ExpirationSeconds is the requested lifetime of the token, defaults to 600 seconds.
+	// +optional
+	// +kubebuilder:validation:Minimum:=600
+	ExpirationSeconds *int64 `json:"expirationSeconds,omitempty"`
+}
+
 type WebhookResult struct {
 	// Json path of return value
 	// +optional
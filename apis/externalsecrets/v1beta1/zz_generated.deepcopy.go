@@ -20,6 +20,7 @@ package v1beta1
 
 import (
 	metav1 "github.com/external-secrets/external-secrets/apis/meta/v1"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 )
@@ -71,6 +72,21 @@ func (in *AWSAuthSecretRef) DeepCopy() *AWSAuthSecretRef {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AWSChangeNotifications) DeepCopyInto(out *AWSChangeNotifications) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AWSChangeNotifications.
+func (in *AWSChangeNotifications) DeepCopy() *AWSChangeNotifications {
+	if in == nil {
+		return nil
+	}
+	out := new(AWSChangeNotifications)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AWSJWTAuth) DeepCopyInto(out *AWSJWTAuth) {
 	*out = *in
@@ -114,7 +130,7 @@ func (in *AWSProvider) DeepCopyInto(out *AWSProvider) {
 	if in.SecretsManager != nil {
 		in, out := &in.SecretsManager, &out.SecretsManager
 		*out = new(SecretsManager)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.TransitiveTagKeys != nil {
 		in, out := &in.TransitiveTagKeys, &out.TransitiveTagKeys
@@ -127,6 +143,11 @@ func (in *AWSProvider) DeepCopyInto(out *AWSProvider) {
 			}
 		}
 	}
+	if in.Proxy != nil {
+		in, out := &in.Proxy, &out.Proxy
+		*out = new(ProxyConfig)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AWSProvider.
@@ -311,6 +332,51 @@ func (in *AlibabaRRSAAuth) DeepCopy() *AlibabaRRSAAuth {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AzureAppConfigProvider) DeepCopyInto(out *AzureAppConfigProvider) {
+	*out = *in
+	if in.AuthType != nil {
+		in, out := &in.AuthType, &out.AuthType
+		*out = new(AzureAuthType)
+		**out = **in
+	}
+	if in.TenantID != nil {
+		in, out := &in.TenantID, &out.TenantID
+		*out = new(string)
+		**out = **in
+	}
+	if in.AuthSecretRef != nil {
+		in, out := &in.AuthSecretRef, &out.AuthSecretRef
+		*out = new(AzureKVAuth)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ServiceAccountRef != nil {
+		in, out := &in.ServiceAccountRef, &out.ServiceAccountRef
+		*out = new(metav1.ServiceAccountSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.IdentityID != nil {
+		in, out := &in.IdentityID, &out.IdentityID
+		*out = new(string)
+		**out = **in
+	}
+	if in.Proxy != nil {
+		in, out := &in.Proxy, &out.Proxy
+		*out = new(ProxyConfig)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzureAppConfigProvider.
+func (in *AzureAppConfigProvider) DeepCopy() *AzureAppConfigProvider {
+	if in == nil {
+		return nil
+	}
+	out := new(AzureAppConfigProvider)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AzureKVAuth) DeepCopyInto(out *AzureKVAuth) {
 	*out = *in
@@ -379,6 +445,11 @@ func (in *AzureKVProvider) DeepCopyInto(out *AzureKVProvider) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.Proxy != nil {
+		in, out := &in.Proxy, &out.Proxy
+		*out = new(ProxyConfig)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzureKVProvider.
@@ -763,6 +834,11 @@ func (in *ClusterExternalSecretStatus) DeepCopyInto(out *ClusterExternalSecretSt
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.Summary != nil {
+		in, out := &in.Summary, &out.Summary
+		*out = new(ClusterExternalSecretSummary)
+		**out = **in
+	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
 		*out = make([]ClusterExternalSecretStatusCondition, len(*in))
@@ -795,6 +871,21 @@ func (in *ClusterExternalSecretStatusCondition) DeepCopy() *ClusterExternalSecre
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterExternalSecretSummary) DeepCopyInto(out *ClusterExternalSecretSummary) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterExternalSecretSummary.
+func (in *ClusterExternalSecretSummary) DeepCopy() *ClusterExternalSecretSummary {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterExternalSecretSummary)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterSecretStore) DeepCopyInto(out *ClusterSecretStore) {
 	*out = *in
@@ -1125,6 +1216,93 @@ func (in *DopplerProvider) DeepCopy() *DopplerProvider {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EnvProvider) DeepCopyInto(out *EnvProvider) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EnvProvider.
+func (in *EnvProvider) DeepCopy() *EnvProvider {
+	if in == nil {
+		return nil
+	}
+	out := new(EnvProvider)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EtcdAuth) DeepCopyInto(out *EtcdAuth) {
+	*out = *in
+	if in.ClientCert != nil {
+		in, out := &in.ClientCert, &out.ClientCert
+		*out = new(EtcdClientCertAuth)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EtcdAuth.
+func (in *EtcdAuth) DeepCopy() *EtcdAuth {
+	if in == nil {
+		return nil
+	}
+	out := new(EtcdAuth)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EtcdClientCertAuth) DeepCopyInto(out *EtcdClientCertAuth) {
+	*out = *in
+	in.Certificate.DeepCopyInto(&out.Certificate)
+	in.PrivateKey.DeepCopyInto(&out.PrivateKey)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EtcdClientCertAuth.
+func (in *EtcdClientCertAuth) DeepCopy() *EtcdClientCertAuth {
+	if in == nil {
+		return nil
+	}
+	out := new(EtcdClientCertAuth)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EtcdProvider) DeepCopyInto(out *EtcdProvider) {
+	*out = *in
+	if in.Endpoints != nil {
+		in, out := &in.Endpoints, &out.Endpoints
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.CABundle != nil {
+		in, out := &in.CABundle, &out.CABundle
+		*out = make([]byte, len(*in))
+		copy(*out, *in)
+	}
+	if in.CAProvider != nil {
+		in, out := &in.CAProvider, &out.CAProvider
+		*out = new(CAProvider)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Auth != nil {
+		in, out := &in.Auth, &out.Auth
+		*out = new(EtcdAuth)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EtcdProvider.
+func (in *EtcdProvider) DeepCopy() *EtcdProvider {
+	if in == nil {
+		return nil
+	}
+	out := new(EtcdProvider)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ExternalSecret) DeepCopyInto(out *ExternalSecret) {
 	*out = *in
@@ -1152,15 +1330,45 @@ func (in *ExternalSecret) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExternalSecretBundle) DeepCopyInto(out *ExternalSecretBundle) {
+	*out = *in
+	if in.Sources != nil {
+		in, out := &in.Sources, &out.Sources
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Separator != nil {
+		in, out := &in.Separator, &out.Separator
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalSecretBundle.
+func (in *ExternalSecretBundle) DeepCopy() *ExternalSecretBundle {
+	if in == nil {
+		return nil
+	}
+	out := new(ExternalSecretBundle)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ExternalSecretData) DeepCopyInto(out *ExternalSecretData) {
 	*out = *in
-	out.RemoteRef = in.RemoteRef
+	in.RemoteRef.DeepCopyInto(&out.RemoteRef)
 	if in.SourceRef != nil {
 		in, out := &in.SourceRef, &out.SourceRef
 		*out = new(StoreSourceRef)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Decoders != nil {
+		in, out := &in.Decoders, &out.Decoders
+		*out = make([]ExternalSecretDecoder, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalSecretData.
@@ -1179,7 +1387,7 @@ func (in *ExternalSecretDataFromRemoteRef) DeepCopyInto(out *ExternalSecretDataF
 	if in.Extract != nil {
 		in, out := &in.Extract, &out.Extract
 		*out = new(ExternalSecretDataRemoteRef)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.Find != nil {
 		in, out := &in.Find, &out.Find
@@ -1213,6 +1421,18 @@ func (in *ExternalSecretDataFromRemoteRef) DeepCopy() *ExternalSecretDataFromRem
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ExternalSecretDataRemoteRef) DeepCopyInto(out *ExternalSecretDataRemoteRef) {
 	*out = *in
+	if in.KeyCandidates != nil {
+		in, out := &in.KeyCandidates, &out.KeyCandidates
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Properties != nil {
+		in, out := &in.Properties, &out.Properties
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalSecretDataRemoteRef.
@@ -1225,6 +1445,72 @@ func (in *ExternalSecretDataRemoteRef) DeepCopy() *ExternalSecretDataRemoteRef {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExternalSecretDecryption) DeepCopyInto(out *ExternalSecretDecryption) {
+	*out = *in
+	in.AgeKeySecretRef.DeepCopyInto(&out.AgeKeySecretRef)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalSecretDecryption.
+func (in *ExternalSecretDecryption) DeepCopy() *ExternalSecretDecryption {
+	if in == nil {
+		return nil
+	}
+	out := new(ExternalSecretDecryption)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExternalSecretDependency) DeepCopyInto(out *ExternalSecretDependency) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalSecretDependency.
+func (in *ExternalSecretDependency) DeepCopy() *ExternalSecretDependency {
+	if in == nil {
+		return nil
+	}
+	out := new(ExternalSecretDependency)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExternalSecretEncryption) DeepCopyInto(out *ExternalSecretEncryption) {
+	*out = *in
+	if in.Recipients != nil {
+		in, out := &in.Recipients, &out.Recipients
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalSecretEncryption.
+func (in *ExternalSecretEncryption) DeepCopy() *ExternalSecretEncryption {
+	if in == nil {
+		return nil
+	}
+	out := new(ExternalSecretEncryption)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExternalSecretEncryptionStatus) DeepCopyInto(out *ExternalSecretEncryptionStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalSecretEncryptionStatus.
+func (in *ExternalSecretEncryptionStatus) DeepCopy() *ExternalSecretEncryptionStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ExternalSecretEncryptionStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ExternalSecretFind) DeepCopyInto(out *ExternalSecretFind) {
 	*out = *in
@@ -1245,6 +1531,16 @@ func (in *ExternalSecretFind) DeepCopyInto(out *ExternalSecretFind) {
 			(*out)[key] = val
 		}
 	}
+	if in.Exclude != nil {
+		in, out := &in.Exclude, &out.Exclude
+		*out = make([]FindExclude, len(*in))
+		copy(*out, *in)
+	}
+	if in.Limit != nil {
+		in, out := &in.Limit, &out.Limit
+		*out = new(int)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalSecretFind.
@@ -1257,6 +1553,26 @@ func (in *ExternalSecretFind) DeepCopy() *ExternalSecretFind {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExternalSecretLastError) DeepCopyInto(out *ExternalSecretLastError) {
+	*out = *in
+	if in.StoreRef != nil {
+		in, out := &in.StoreRef, &out.StoreRef
+		*out = new(SecretStoreRef)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalSecretLastError.
+func (in *ExternalSecretLastError) DeepCopy() *ExternalSecretLastError {
+	if in == nil {
+		return nil
+	}
+	out := new(ExternalSecretLastError)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ExternalSecretList) DeepCopyInto(out *ExternalSecretList) {
 	*out = *in
@@ -1318,6 +1634,22 @@ func (in *ExternalSecretMetadata) DeepCopy() *ExternalSecretMetadata {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExternalSecretOrphanedSecret) DeepCopyInto(out *ExternalSecretOrphanedSecret) {
+	*out = *in
+	in.OrphanedAt.DeepCopyInto(&out.OrphanedAt)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalSecretOrphanedSecret.
+func (in *ExternalSecretOrphanedSecret) DeepCopy() *ExternalSecretOrphanedSecret {
+	if in == nil {
+		return nil
+	}
+	out := new(ExternalSecretOrphanedSecret)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ExternalSecretRewrite) DeepCopyInto(out *ExternalSecretRewrite) {
 	*out = *in
@@ -1373,6 +1705,77 @@ func (in *ExternalSecretRewriteTransform) DeepCopy() *ExternalSecretRewriteTrans
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExternalSecretShadow) DeepCopyInto(out *ExternalSecretShadow) {
+	*out = *in
+	out.StoreRef = in.StoreRef
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalSecretShadow.
+func (in *ExternalSecretShadow) DeepCopy() *ExternalSecretShadow {
+	if in == nil {
+		return nil
+	}
+	out := new(ExternalSecretShadow)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExternalSecretSizeLimits) DeepCopyInto(out *ExternalSecretSizeLimits) {
+	*out = *in
+	if in.MaxKeySize != nil {
+		in, out := &in.MaxKeySize, &out.MaxKeySize
+		*out = new(int64)
+		**out = **in
+	}
+	if in.MaxTotalSize != nil {
+		in, out := &in.MaxTotalSize, &out.MaxTotalSize
+		*out = new(int64)
+		**out = **in
+	}
+	if in.TruncateKeys != nil {
+		in, out := &in.TruncateKeys, &out.TruncateKeys
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalSecretSizeLimits.
+func (in *ExternalSecretSizeLimits) DeepCopy() *ExternalSecretSizeLimits {
+	if in == nil {
+		return nil
+	}
+	out := new(ExternalSecretSizeLimits)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExternalSecretSourceStatus) DeepCopyInto(out *ExternalSecretSourceStatus) {
+	*out = *in
+	if in.StoreRef != nil {
+		in, out := &in.StoreRef, &out.StoreRef
+		*out = new(SecretStoreRef)
+		**out = **in
+	}
+	if in.Keys != nil {
+		in, out := &in.Keys, &out.Keys
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalSecretSourceStatus.
+func (in *ExternalSecretSourceStatus) DeepCopy() *ExternalSecretSourceStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ExternalSecretSourceStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ExternalSecretSpec) DeepCopyInto(out *ExternalSecretSpec) {
 	*out = *in
@@ -1383,6 +1786,11 @@ func (in *ExternalSecretSpec) DeepCopyInto(out *ExternalSecretSpec) {
 		*out = new(v1.Duration)
 		**out = **in
 	}
+	if in.ProviderTimeout != nil {
+		in, out := &in.ProviderTimeout, &out.ProviderTimeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
 	if in.Data != nil {
 		in, out := &in.Data, &out.Data
 		*out = make([]ExternalSecretData, len(*in))
@@ -1397,6 +1805,33 @@ func (in *ExternalSecretSpec) DeepCopyInto(out *ExternalSecretSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.StatusPolicy != nil {
+		in, out := &in.StatusPolicy, &out.StatusPolicy
+		*out = new(ExternalSecretStatusPolicy)
+		**out = **in
+	}
+	if in.ProviderOptions != nil {
+		in, out := &in.ProviderOptions, &out.ProviderOptions
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Decryption != nil {
+		in, out := &in.Decryption, &out.Decryption
+		*out = new(ExternalSecretDecryption)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DependsOn != nil {
+		in, out := &in.DependsOn, &out.DependsOn
+		*out = make([]ExternalSecretDependency, len(*in))
+		copy(*out, *in)
+	}
+	if in.Shadow != nil {
+		in, out := &in.Shadow, &out.Shadow
+		*out = new(ExternalSecretShadow)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalSecretSpec.
@@ -1421,6 +1856,34 @@ func (in *ExternalSecretStatus) DeepCopyInto(out *ExternalSecretStatus) {
 		}
 	}
 	out.Binding = in.Binding
+	if in.Sources != nil {
+		in, out := &in.Sources, &out.Sources
+		*out = make([]ExternalSecretSourceStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.LastError != nil {
+		in, out := &in.LastError, &out.LastError
+		*out = new(ExternalSecretLastError)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.OrphanedSecrets != nil {
+		in, out := &in.OrphanedSecrets, &out.OrphanedSecrets
+		*out = make([]ExternalSecretOrphanedSecret, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.GeneratorRefreshTime != nil {
+		in, out := &in.GeneratorRefreshTime, &out.GeneratorRefreshTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Encryption != nil {
+		in, out := &in.Encryption, &out.Encryption
+		*out = new(ExternalSecretEncryptionStatus)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalSecretStatus.
@@ -1449,14 +1912,64 @@ func (in *ExternalSecretStatusCondition) DeepCopy() *ExternalSecretStatusConditi
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExternalSecretStatusPolicy) DeepCopyInto(out *ExternalSecretStatusPolicy) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalSecretStatusPolicy.
+func (in *ExternalSecretStatusPolicy) DeepCopy() *ExternalSecretStatusPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(ExternalSecretStatusPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ExternalSecretTarget) DeepCopyInto(out *ExternalSecretTarget) {
 	*out = *in
+	if in.OnErrorAfter != nil {
+		in, out := &in.OnErrorAfter, &out.OnErrorAfter
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.OrphanGracePeriod != nil {
+		in, out := &in.OrphanGracePeriod, &out.OrphanGracePeriod
+		*out = new(v1.Duration)
+		**out = **in
+	}
 	if in.Template != nil {
 		in, out := &in.Template, &out.Template
 		*out = new(ExternalSecretTemplate)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.TemplateRef != nil {
+		in, out := &in.TemplateRef, &out.TemplateRef
+		*out = new(ExternalSecretTemplateRef)
+		**out = **in
+	}
+	if in.Bundle != nil {
+		in, out := &in.Bundle, &out.Bundle
+		*out = new(ExternalSecretBundle)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SizeLimits != nil {
+		in, out := &in.SizeLimits, &out.SizeLimits
+		*out = new(ExternalSecretSizeLimits)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MetadataAnnotations != nil {
+		in, out := &in.MetadataAnnotations, &out.MetadataAnnotations
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.EncryptWith != nil {
+		in, out := &in.EncryptWith, &out.EncryptWith
+		*out = new(ExternalSecretEncryption)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalSecretTarget.
@@ -1528,9 +2041,29 @@ func (in *ExternalSecretTemplateMetadata) DeepCopy() *ExternalSecretTemplateMeta
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExternalSecretTemplateRef) DeepCopyInto(out *ExternalSecretTemplateRef) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalSecretTemplateRef.
+func (in *ExternalSecretTemplateRef) DeepCopy() *ExternalSecretTemplateRef {
+	if in == nil {
+		return nil
+	}
+	out := new(ExternalSecretTemplateRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ExternalSecretValidator) DeepCopyInto(out *ExternalSecretValidator) {
 	*out = *in
+	if in.AllowedSecretTypes != nil {
+		in, out := &in.AllowedSecretTypes, &out.AllowedSecretTypes
+		*out = make([]corev1.SecretType, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalSecretValidator.
@@ -1553,6 +2086,11 @@ func (in *FakeProvider) DeepCopyInto(out *FakeProvider) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Chaos != nil {
+		in, out := &in.Chaos, &out.Chaos
+		*out = new(FakeProviderChaos)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FakeProvider.
@@ -1565,6 +2103,36 @@ func (in *FakeProvider) DeepCopy() *FakeProvider {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FakeProviderChaos) DeepCopyInto(out *FakeProviderChaos) {
+	*out = *in
+	if in.Latency != nil {
+		in, out := &in.Latency, &out.Latency
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.LatencyJitter != nil {
+		in, out := &in.LatencyJitter, &out.LatencyJitter
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.ErrorReasons != nil {
+		in, out := &in.ErrorReasons, &out.ErrorReasons
+		*out = make([]ProviderErrorReason, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FakeProviderChaos.
+func (in *FakeProviderChaos) DeepCopy() *FakeProviderChaos {
+	if in == nil {
+		return nil
+	}
+	out := new(FakeProviderChaos)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FakeProviderData) DeepCopyInto(out *FakeProviderData) {
 	*out = *in
@@ -1587,6 +2155,21 @@ func (in *FakeProviderData) DeepCopy() *FakeProviderData {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FindExclude) DeepCopyInto(out *FindExclude) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FindExclude.
+func (in *FindExclude) DeepCopy() *FindExclude {
+	if in == nil {
+		return nil
+	}
+	out := new(FindExclude)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FindName) DeepCopyInto(out *FindName) {
 	*out = *in
@@ -1687,6 +2270,11 @@ func (in *GCPSMAuthSecretRef) DeepCopy() *GCPSMAuthSecretRef {
 func (in *GCPSMProvider) DeepCopyInto(out *GCPSMProvider) {
 	*out = *in
 	in.Auth.DeepCopyInto(&out.Auth)
+	if in.Proxy != nil {
+		in, out := &in.Proxy, &out.Proxy
+		*out = new(ProxyConfig)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPSMProvider.
@@ -1993,6 +2581,11 @@ func (in *KubernetesServer) DeepCopyInto(out *KubernetesServer) {
 		*out = new(CAProvider)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.TLSPinnedKeys != nil {
+		in, out := &in.TLSPinnedKeys, &out.TLSPinnedKeys
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubernetesServer.
@@ -2301,6 +2894,53 @@ func (in *PasswordDepotSecretRef) DeepCopy() *PasswordDepotSecretRef {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PostgreSQLAuth) DeepCopyInto(out *PostgreSQLAuth) {
+	*out = *in
+	in.PasswordSecretRef.DeepCopyInto(&out.PasswordSecretRef)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PostgreSQLAuth.
+func (in *PostgreSQLAuth) DeepCopy() *PostgreSQLAuth {
+	if in == nil {
+		return nil
+	}
+	out := new(PostgreSQLAuth)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PostgreSQLProvider) DeepCopyInto(out *PostgreSQLProvider) {
+	*out = *in
+	if in.CABundle != nil {
+		in, out := &in.CABundle, &out.CABundle
+		*out = make([]byte, len(*in))
+		copy(*out, *in)
+	}
+	if in.CAProvider != nil {
+		in, out := &in.CAProvider, &out.CAProvider
+		*out = new(CAProvider)
+		(*in).DeepCopyInto(*out)
+	}
+	in.Auth.DeepCopyInto(&out.Auth)
+	if in.Columns != nil {
+		in, out := &in.Columns, &out.Columns
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PostgreSQLProvider.
+func (in *PostgreSQLProvider) DeepCopy() *PostgreSQLProvider {
+	if in == nil {
+		return nil
+	}
+	out := new(PostgreSQLProvider)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PreviderAuth) DeepCopyInto(out *PreviderAuth) {
 	*out = *in
@@ -2353,6 +2993,21 @@ func (in *PreviderProvider) DeepCopy() *PreviderProvider {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProxyConfig) DeepCopyInto(out *ProxyConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProxyConfig.
+func (in *ProxyConfig) DeepCopy() *ProxyConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ProxyConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PulumiProvider) DeepCopyInto(out *PulumiProvider) {
 	*out = *in
@@ -2555,6 +3210,11 @@ func (in *SecretStoreProvider) DeepCopyInto(out *SecretStoreProvider) {
 		*out = new(AzureKVProvider)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.AzureAppConfig != nil {
+		in, out := &in.AzureAppConfig, &out.AzureAppConfig
+		*out = new(AzureAppConfigProvider)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Akeyless != nil {
 		in, out := &in.Akeyless, &out.Akeyless
 		*out = new(AkeylessProvider)
@@ -2625,6 +3285,21 @@ func (in *SecretStoreProvider) DeepCopyInto(out *SecretStoreProvider) {
 		*out = new(FakeProvider)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = new(EnvProvider)
+		**out = **in
+	}
+	if in.Etcd != nil {
+		in, out := &in.Etcd, &out.Etcd
+		*out = new(EtcdProvider)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PostgreSQL != nil {
+		in, out := &in.PostgreSQL, &out.PostgreSQL
+		*out = new(PostgreSQLProvider)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Senhasegura != nil {
 		in, out := &in.Senhasegura, &out.Senhasegura
 		*out = new(SenhaseguraProvider)
@@ -2775,6 +3450,11 @@ func (in *SecretStoreSpec) DeepCopyInto(out *SecretStoreSpec) {
 		*out = new(SecretStoreRetrySettings)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.ClockSkew != nil {
+		in, out := &in.ClockSkew, &out.ClockSkew
+		*out = new(v1.Duration)
+		**out = **in
+	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
 		*out = make([]ClusterSecretStoreCondition, len(*in))
@@ -2782,6 +3462,10 @@ func (in *SecretStoreSpec) DeepCopyInto(out *SecretStoreSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.ExpiresAt != nil {
+		in, out := &in.ExpiresAt, &out.ExpiresAt
+		*out = (*in).DeepCopy()
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretStoreSpec.
@@ -2835,6 +3519,11 @@ func (in *SecretStoreStatusCondition) DeepCopy() *SecretStoreStatusCondition {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SecretsManager) DeepCopyInto(out *SecretsManager) {
 	*out = *in
+	if in.ChangeNotifications != nil {
+		in, out := &in.ChangeNotifications, &out.ChangeNotifications
+		*out = new(AWSChangeNotifications)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretsManager.
@@ -2887,6 +3576,11 @@ func (in *StoreGeneratorSourceRef) DeepCopyInto(out *StoreGeneratorSourceRef) {
 		*out = new(SecretStoreRef)
 		**out = **in
 	}
+	if in.StoreGroup != nil {
+		in, out := &in.StoreGroup, &out.StoreGroup
+		*out = make([]SecretStoreRef, len(*in))
+		copy(*out, *in)
+	}
 	if in.GeneratorRef != nil {
 		in, out := &in.GeneratorRef, &out.GeneratorRef
 		*out = new(GeneratorRef)
@@ -2907,7 +3601,16 @@ func (in *StoreGeneratorSourceRef) DeepCopy() *StoreGeneratorSourceRef {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *StoreSourceRef) DeepCopyInto(out *StoreSourceRef) {
 	*out = *in
-	out.SecretStoreRef = in.SecretStoreRef
+	if in.SecretStoreRef != nil {
+		in, out := &in.SecretStoreRef, &out.SecretStoreRef
+		*out = new(SecretStoreRef)
+		**out = **in
+	}
+	if in.StoreGroup != nil {
+		in, out := &in.StoreGroup, &out.StoreGroup
+		*out = make([]SecretStoreRef, len(*in))
+		copy(*out, *in)
+	}
 	if in.GeneratorRef != nil {
 		in, out := &in.GeneratorRef, &out.GeneratorRef
 		*out = new(GeneratorRef)
@@ -3374,6 +4077,11 @@ func (in *VaultProvider) DeepCopyInto(out *VaultProvider) {
 		*out = new(CAProvider)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.TLSPinnedKeys != nil {
+		in, out := &in.TLSPinnedKeys, &out.TLSPinnedKeys
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.Headers != nil {
 		in, out := &in.Headers, &out.Headers
 		*out = make(map[string]string, len(*in))
@@ -3381,6 +4089,11 @@ func (in *VaultProvider) DeepCopyInto(out *VaultProvider) {
 			(*out)[key] = val
 		}
 	}
+	if in.Proxy != nil {
+		in, out := &in.Proxy, &out.Proxy
+		*out = new(ProxyConfig)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultProvider.
@@ -3409,6 +4122,26 @@ func (in *VaultUserPassAuth) DeepCopy() *VaultUserPassAuth {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WebhookAuth) DeepCopyInto(out *WebhookAuth) {
+	*out = *in
+	if in.ServiceAccountToken != nil {
+		in, out := &in.ServiceAccountToken, &out.ServiceAccountToken
+		*out = new(WebhookServiceAccountTokenAuth)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WebhookAuth.
+func (in *WebhookAuth) DeepCopy() *WebhookAuth {
+	if in == nil {
+		return nil
+	}
+	out := new(WebhookAuth)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *WebhookCAProvider) DeepCopyInto(out *WebhookCAProvider) {
 	*out = *in
@@ -3439,11 +4172,21 @@ func (in *WebhookProvider) DeepCopyInto(out *WebhookProvider) {
 			(*out)[key] = val
 		}
 	}
+	if in.AllowedProviderOptionHeaders != nil {
+		in, out := &in.AllowedProviderOptionHeaders, &out.AllowedProviderOptionHeaders
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.Timeout != nil {
 		in, out := &in.Timeout, &out.Timeout
 		*out = new(v1.Duration)
 		**out = **in
 	}
+	if in.Auth != nil {
+		in, out := &in.Auth, &out.Auth
+		*out = new(WebhookAuth)
+		(*in).DeepCopyInto(*out)
+	}
 	out.Result = in.Result
 	if in.Secrets != nil {
 		in, out := &in.Secrets, &out.Secrets
@@ -3462,6 +4205,16 @@ func (in *WebhookProvider) DeepCopyInto(out *WebhookProvider) {
 		*out = new(WebhookCAProvider)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.TLSPinnedKeys != nil {
+		in, out := &in.TLSPinnedKeys, &out.TLSPinnedKeys
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Proxy != nil {
+		in, out := &in.Proxy, &out.Proxy
+		*out = new(ProxyConfig)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WebhookProvider.
@@ -3505,6 +4258,27 @@ func (in *WebhookSecret) DeepCopy() *WebhookSecret {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WebhookServiceAccountTokenAuth) DeepCopyInto(out *WebhookServiceAccountTokenAuth) {
+	*out = *in
+	in.ServiceAccountRef.DeepCopyInto(&out.ServiceAccountRef)
+	if in.ExpirationSeconds != nil {
+		in, out := &in.ExpirationSeconds, &out.ExpirationSeconds
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WebhookServiceAccountTokenAuth.
+func (in *WebhookServiceAccountTokenAuth) DeepCopy() *WebhookServiceAccountTokenAuth {
+	if in == nil {
+		return nil
+	}
+	out := new(WebhookServiceAccountTokenAuth)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *YandexCertificateManagerAuth) DeepCopyInto(out *YandexCertificateManagerAuth) {
 	*out = *in
@@ -16,6 +16,7 @@ package v1alpha1
 
 import (
 	"context"
+	"time"
 
 	apiextensions "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -35,3 +36,21 @@ type Generator interface {
 		namespace string,
 	) (map[string][]byte, error)
 }
+
+// +kubebuilder:object:root=false
+// +kubebuilder:object:generate:false
+// +k8s:deepcopy-gen:interfaces=nil
+// +k8s:deepcopy-gen=nil
+
+// ExpiringGenerator is implemented by generators whose generated values expire,
+// the ExternalSecret is refreshed before the values expire.
+type ExpiringGenerator interface {
+	Generator
+	// GenerateWithExpiry generates the values like Generate and returns the time they expire.
+	GenerateWithExpiry(
+		ctx context.Context,
+		obj *apiextensions.JSON,
+		kube client.Client,
+		namespace string,
+	) (map[string][]byte, time.Time, error)
+}
@@ -14,6 +14,42 @@ limitations under the License.
 
 package v1alpha1
 
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// GeneratorConditionType is the type of a status condition of a generator.
+type GeneratorConditionType string
+
+const (
+	// GeneratorReady reports if a generator is ready to generate.
+	// ExternalSecrets wait for a generator with a Ready condition that is not True,
+	// generators without a Ready condition are always ready.
+	GeneratorReady GeneratorConditionType = "Ready"
+)
+
+// GeneratorStatusCondition is a status condition of a generator.
+type GeneratorStatusCondition struct {
+	Type   GeneratorConditionType `json:"type"`
+	Status corev1.ConditionStatus `json:"status"`
+
+	// +optional
+	Reason string `json:"reason,omitempty"`
+
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// +optional
+	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
+}
+
+// GeneratorStatus is the status of a generator that depends on an asynchronous setup.
+type GeneratorStatus struct {
+	// +optional
+	Conditions []GeneratorStatusCondition `json:"conditions,omitempty"`
+}
+
 type ControllerClassResource struct {
 	Spec struct {
 		ControllerClass string `json:"controller"`
@@ -60,6 +60,22 @@ var (
 	GCRAccessTokenGroupVersionKind = SchemeGroupVersion.WithKind(GCRAccessTokenKind)
 )
 
+// DeterministicSecret type metadata.
+var (
+	DeterministicSecretKind             = reflect.TypeOf(DeterministicSecret{}).Name()
+	DeterministicSecretGroupKind        = schema.GroupKind{Group: Group, Kind: DeterministicSecretKind}.String()
+	DeterministicSecretKindAPIVersion   = DeterministicSecretKind + "." + SchemeGroupVersion.String()
+	DeterministicSecretGroupVersionKind = SchemeGroupVersion.WithKind(DeterministicSecretKind)
+)
+
+// GCPKMSDecrypt type metadata.
+var (
+	GCPKMSDecryptKind             = reflect.TypeOf(GCPKMSDecrypt{}).Name()
+	GCPKMSDecryptGroupKind        = schema.GroupKind{Group: Group, Kind: GCPKMSDecryptKind}.String()
+	GCPKMSDecryptKindAPIVersion   = GCPKMSDecryptKind + "." + SchemeGroupVersion.String()
+	GCPKMSDecryptGroupVersionKind = SchemeGroupVersion.WithKind(GCPKMSDecryptKind)
+)
+
 // ACRAccessToken type metadata.
 var (
 	ACRAccessTokenKind             = reflect.TypeOf(ACRAccessToken{}).Name()
@@ -108,6 +124,14 @@ var (
 	GithubAccessTokenGroupVersionKind = SchemeGroupVersion.WithKind(GithubAccessTokenKind)
 )
 
+// ServiceAccountToken type metadata.
+var (
+	ServiceAccountTokenKind             = reflect.TypeOf(ServiceAccountToken{}).Name()
+	ServiceAccountTokenGroupKind        = schema.GroupKind{Group: Group, Kind: ServiceAccountTokenKind}.String()
+	ServiceAccountTokenKindAPIVersion   = ServiceAccountTokenKind + "." + SchemeGroupVersion.String()
+	ServiceAccountTokenGroupVersionKind = SchemeGroupVersion.WithKind(ServiceAccountTokenKind)
+)
+
 // Uuid type metadata.
 var (
 	UUIDKind             = reflect.TypeOf(UUID{}).Name()
@@ -142,11 +166,14 @@ func init() {
 
 	SchemeBuilder.Register(&ACRAccessToken{}, &ACRAccessTokenList{})
 	SchemeBuilder.Register(&ClusterGenerator{}, &ClusterGeneratorList{})
+	SchemeBuilder.Register(&DeterministicSecret{}, &DeterministicSecretList{})
 	SchemeBuilder.Register(&ECRAuthorizationToken{}, &ECRAuthorizationTokenList{})
 	SchemeBuilder.Register(&Fake{}, &FakeList{})
+	SchemeBuilder.Register(&GCPKMSDecrypt{}, &GCPKMSDecryptList{})
 	SchemeBuilder.Register(&GCRAccessToken{}, &GCRAccessTokenList{})
 	SchemeBuilder.Register(&GithubAccessToken{}, &GithubAccessTokenList{})
 	SchemeBuilder.Register(&Password{}, &PasswordList{})
+	SchemeBuilder.Register(&ServiceAccountToken{}, &ServiceAccountTokenList{})
 	SchemeBuilder.Register(&STSSessionToken{}, &STSSessionTokenList{})
 	SchemeBuilder.Register(&UUID{}, &UUIDList{})
 	SchemeBuilder.Register(&VaultDynamicSecret{}, &VaultDynamicSecretList{})
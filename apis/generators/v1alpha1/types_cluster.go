@@ -27,16 +27,19 @@ type ClusterGeneratorSpec struct {
 }
 
 // GeneratorKind represents a kind of generator.
-// +kubebuilder:validation:Enum=ACRAccessToken;ECRAuthorizationToken;Fake;GCRAccessToken;GithubAccessToken;Password;STSSessionToken;UUID;VaultDynamicSecret;Webhook
+// +kubebuilder:validation:Enum=ACRAccessToken;DeterministicSecret;ECRAuthorizationToken;Fake;GCPKMSDecrypt;GCRAccessToken;GithubAccessToken;Password;ServiceAccountToken;STSSessionToken;UUID;VaultDynamicSecret;Webhook
 type GeneratorKind string
 
 const (
 	GeneratorKindACRAccessToken        GeneratorKind = "ACRAccessToken"
+	GeneratorKindDeterministicSecret   GeneratorKind = "DeterministicSecret"
 	GeneratorKindECRAuthorizationToken GeneratorKind = "ECRAuthorizationToken"
 	GeneratorKindFake                  GeneratorKind = "Fake"
+	GeneratorKindGCPKMSDecrypt         GeneratorKind = "GCPKMSDecrypt"
 	GeneratorKindGCRAccessToken        GeneratorKind = "GCRAccessToken"
 	GeneratorKindGithubAccessToken     GeneratorKind = "GithubAccessToken"
 	GeneratorKindPassword              GeneratorKind = "Password"
+	GeneratorKindServiceAccountToken   GeneratorKind = "ServiceAccountToken"
 	GeneratorKindSTSSessionToken       GeneratorKind = "STSSessionToken"
 	GeneratorKindUUID                  GeneratorKind = "UUID"
 	GeneratorKindVaultDynamicSecret    GeneratorKind = "VaultDynamicSecret"
@@ -47,11 +50,14 @@ const (
 // +kubebuilder:validation:MinProperties=1
 type GeneratorSpec struct {
 	ACRAccessTokenSpec        *ACRAccessTokenSpec        `json:"acrAccessTokenSpec,omitempty"`
+	DeterministicSecretSpec   *DeterministicSecretSpec   `json:"deterministicSecretSpec,omitempty"`
 	ECRAuthorizationTokenSpec *ECRAuthorizationTokenSpec `json:"ecrAuthorizationTokenSpec,omitempty"`
 	FakeSpec                  *FakeSpec                  `json:"fakeSpec,omitempty"`
+	GCPKMSDecryptSpec         *GCPKMSDecryptSpec         `json:"gcpKMSDecryptSpec,omitempty"`
 	GCRAccessTokenSpec        *GCRAccessTokenSpec        `json:"gcrAccessTokenSpec,omitempty"`
 	GithubAccessTokenSpec     *GithubAccessTokenSpec     `json:"githubAccessTokenSpec,omitempty"`
 	PasswordSpec              *PasswordSpec              `json:"passwordSpec,omitempty"`
+	ServiceAccountTokenSpec   *ServiceAccountTokenSpec   `json:"serviceAccountTokenSpec,omitempty"`
 	STSSessionTokenSpec       *STSSessionTokenSpec       `json:"stsSessionTokenSpec,omitempty"`
 	UUIDSpec                  *UUIDSpec                  `json:"uuidSpec,omitempty"`
 	VaultDynamicSecretSpec    *VaultDynamicSecretSpec    `json:"vaultDynamicSecretSpec,omitempty"`
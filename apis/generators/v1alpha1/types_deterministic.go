@@ -0,0 +1,78 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	esmeta "github.com/external-secrets/external-secrets/apis/meta/v1"
+)
+
+// DeterministicSecretEncoding defines how the derived bytes are encoded.
+// +kubebuilder:validation:Enum=Raw;Base64;Hex
+type DeterministicSecretEncoding string
+
+const (
+	DeterministicSecretEncodingRaw    DeterministicSecretEncoding = "Raw"
+	DeterministicSecretEncodingBase64 DeterministicSecretEncoding = "Base64"
+	DeterministicSecretEncodingHex    DeterministicSecretEncoding = "Hex"
+)
+
+type DeterministicSecretSpec struct {
+	// SeedSecretRef references the key of a secret in the namespace of the ExternalSecret that holds the seed,
+	// e.g. a master key restored from a KMS protected backup. The seed must be at least 16 bytes long.
+	SeedSecretRef esmeta.SecretKeySelector `json:"seedSecretRef"`
+	// Salt is mixed into the derivation, use a distinct salt for every secret.
+	// Change it to rotate the value.
+	// +kubebuilder:validation:MinLength=1
+	Salt string `json:"salt"`
+	// Label is the context of the derivation (HKDF info), e.g. the purpose of the value.
+	// +optional
+	Label string `json:"label,omitempty"`
+	// Length is the number of bytes to derive.
+	// +kubebuilder:default=32
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=8160
+	// +optional
+	Length int `json:"length,omitempty"`
+	// Encoding of the derived bytes.
+	// +kubebuilder:default=Raw
+	// +optional
+	Encoding DeterministicSecretEncoding `json:"encoding,omitempty"`
+}
+
+// DeterministicSecret derives a value with HKDF-SHA256 from a seed, a salt and a label.
+// The same inputs always result in the same value, so the secret can be regenerated identically,
+// e.g. after a cluster has been rebuilt.
+// +kubebuilder:object:root=true
+// +kubebuilder:storageversion
+// +kubebuilder:subresource:status
+// +kubebuilder:metadata:labels="external-secrets.io/component=controller"
+// +kubebuilder:resource:scope=Namespaced,categories={external-secrets, external-secrets-generators}
+type DeterministicSecret struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec DeterministicSecretSpec `json:"spec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// DeterministicSecretList contains a list of DeterministicSecret resources.
+type DeterministicSecretList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []DeterministicSecret `json:"items"`
+}
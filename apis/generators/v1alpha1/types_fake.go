@@ -42,6 +42,10 @@ type Fake struct {
 	metav1.ObjectMeta `json:"metadata,omitempty"`
 
 	Spec FakeSpec `json:"spec,omitempty"`
+
+	// Status reports the readiness of the generator, it is set by whoever manages the Fake generator.
+	// +optional
+	Status GeneratorStatus `json:"status,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -0,0 +1,60 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type GCPKMSDecryptSpec struct {
+	// Auth defines the means for authenticating with GCP
+	Auth GCPSMAuth `json:"auth"`
+	// ProjectID defines which project to use to authenticate with
+	ProjectID string `json:"projectID"`
+	// KeyName is the resource name of the CryptoKey used to decrypt the ciphertext,
+	// e.g. projects/my-project/locations/global/keyRings/my-ring/cryptoKeys/my-key
+	// +kubebuilder:validation:Pattern=`^projects/[^/]+/locations/[^/]+/keyRings/[^/]+/cryptoKeys/[^/]+$`
+	KeyName string `json:"keyName"`
+	// Ciphertext is the base64 encoded ciphertext returned by Cloud KMS Encrypt.
+	// +kubebuilder:validation:MinLength=1
+	Ciphertext string `json:"ciphertext"`
+	// AdditionalAuthenticatedData is the base64 encoded additional authenticated data
+	// that was supplied when the ciphertext was encrypted.
+	// +optional
+	AdditionalAuthenticatedData string `json:"additionalAuthenticatedData,omitempty"`
+}
+
+// GCPKMSDecrypt decrypts a ciphertext with Google Cloud KMS
+// and returns the plaintext.
+// +kubebuilder:object:root=true
+// +kubebuilder:storageversion
+// +kubebuilder:subresource:status
+// +kubebuilder:metadata:labels="external-secrets.io/component=controller"
+// +kubebuilder:resource:scope=Namespaced,categories={external-secrets, external-secrets-generators}
+type GCPKMSDecrypt struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec GCPKMSDecryptSpec `json:"spec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// GCPKMSDecryptList contains a list of GCPKMSDecrypt resources.
+type GCPKMSDecryptList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []GCPKMSDecrypt `json:"items"`
+}
@@ -16,6 +16,8 @@ package v1alpha1
 
 import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	smmeta "github.com/external-secrets/external-secrets/apis/meta/v1"
 )
 
 // PasswordSpec controls the behavior of the password generator.
@@ -44,6 +46,11 @@ type PasswordSpec struct {
 	// set AllowRepeat to true to allow repeating characters.
 	// +kubebuilder:default=false
 	AllowRepeat bool `json:"allowRepeat"`
+
+	// Policy defines the length and complexity rules the generated password must comply with.
+	// Passwords that do not comply are generated again a few times before an error is returned.
+	// +optional
+	Policy *smmeta.SecretValuePolicy `json:"policy,omitempty"`
 }
 
 // Password generates a random password based on the
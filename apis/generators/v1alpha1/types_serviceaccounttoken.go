@@ -0,0 +1,65 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// AnnotationAllowServiceAccountToken must be set to "true" on a ServiceAccount
+// before the ServiceAccountToken generator requests tokens for it.
+const AnnotationAllowServiceAccountToken = "generators.external-secrets.io/allow-service-account-token"
+
+type ServiceAccountTokenSpec struct {
+	// ServiceAccountName is the name of the ServiceAccount to request a token for.
+	// The ServiceAccount must live in the namespace of the ExternalSecret and
+	// must be annotated with `generators.external-secrets.io/allow-service-account-token: "true"`.
+	ServiceAccountName string `json:"serviceAccountName"`
+
+	// Audiences are the intended audiences of the token.
+	// Defaults to the audiences of the Kubernetes API server.
+	// +optional
+	Audiences []string `json:"audiences,omitempty"`
+
+	// ExpirationSeconds is the requested duration of validity of the token.
+	// The API server may return a token with a different validity.
+	// +optional
+	// +kubebuilder:default=3600
+	// +kubebuilder:validation:Minimum=600
+	ExpirationSeconds *int64 `json:"expirationSeconds,omitempty"`
+}
+
+// ServiceAccountToken generates a short-lived token for a Kubernetes ServiceAccount
+// using the TokenRequest API.
+// +kubebuilder:object:root=true
+// +kubebuilder:storageversion
+// +kubebuilder:subresource:status
+// +kubebuilder:metadata:labels="external-secrets.io/component=controller"
+// +kubebuilder:resource:scope=Namespaced,categories={external-secrets, external-secrets-generators}
+type ServiceAccountToken struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec ServiceAccountTokenSpec `json:"spec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ServiceAccountTokenList contains a list of ServiceAccountToken resources.
+type ServiceAccountTokenList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ServiceAccountToken `json:"items"`
+}
@@ -355,6 +355,80 @@ func (in *ControllerClassResource) DeepCopy() *ControllerClassResource {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeterministicSecret) DeepCopyInto(out *DeterministicSecret) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeterministicSecret.
+func (in *DeterministicSecret) DeepCopy() *DeterministicSecret {
+	if in == nil {
+		return nil
+	}
+	out := new(DeterministicSecret)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DeterministicSecret) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeterministicSecretList) DeepCopyInto(out *DeterministicSecretList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]DeterministicSecret, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeterministicSecretList.
+func (in *DeterministicSecretList) DeepCopy() *DeterministicSecretList {
+	if in == nil {
+		return nil
+	}
+	out := new(DeterministicSecretList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DeterministicSecretList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeterministicSecretSpec) DeepCopyInto(out *DeterministicSecretSpec) {
+	*out = *in
+	in.SeedSecretRef.DeepCopyInto(&out.SeedSecretRef)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeterministicSecretSpec.
+func (in *DeterministicSecretSpec) DeepCopy() *DeterministicSecretSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DeterministicSecretSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ECRAuthorizationToken) DeepCopyInto(out *ECRAuthorizationToken) {
 	*out = *in
@@ -435,6 +509,7 @@ func (in *Fake) DeepCopyInto(out *Fake) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Fake.
@@ -509,6 +584,80 @@ func (in *FakeSpec) DeepCopy() *FakeSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GCPKMSDecrypt) DeepCopyInto(out *GCPKMSDecrypt) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPKMSDecrypt.
+func (in *GCPKMSDecrypt) DeepCopy() *GCPKMSDecrypt {
+	if in == nil {
+		return nil
+	}
+	out := new(GCPKMSDecrypt)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *GCPKMSDecrypt) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GCPKMSDecryptList) DeepCopyInto(out *GCPKMSDecryptList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]GCPKMSDecrypt, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPKMSDecryptList.
+func (in *GCPKMSDecryptList) DeepCopy() *GCPKMSDecryptList {
+	if in == nil {
+		return nil
+	}
+	out := new(GCPKMSDecryptList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *GCPKMSDecryptList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GCPKMSDecryptSpec) DeepCopyInto(out *GCPKMSDecryptSpec) {
+	*out = *in
+	in.Auth.DeepCopyInto(&out.Auth)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPKMSDecryptSpec.
+func (in *GCPKMSDecryptSpec) DeepCopy() *GCPKMSDecryptSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(GCPKMSDecryptSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GCPSMAuth) DeepCopyInto(out *GCPSMAuth) {
 	*out = *in
@@ -648,6 +797,11 @@ func (in *GeneratorSpec) DeepCopyInto(out *GeneratorSpec) {
 		*out = new(ACRAccessTokenSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.DeterministicSecretSpec != nil {
+		in, out := &in.DeterministicSecretSpec, &out.DeterministicSecretSpec
+		*out = new(DeterministicSecretSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.ECRAuthorizationTokenSpec != nil {
 		in, out := &in.ECRAuthorizationTokenSpec, &out.ECRAuthorizationTokenSpec
 		*out = new(ECRAuthorizationTokenSpec)
@@ -658,6 +812,11 @@ func (in *GeneratorSpec) DeepCopyInto(out *GeneratorSpec) {
 		*out = new(FakeSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.GCPKMSDecryptSpec != nil {
+		in, out := &in.GCPKMSDecryptSpec, &out.GCPKMSDecryptSpec
+		*out = new(GCPKMSDecryptSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.GCRAccessTokenSpec != nil {
 		in, out := &in.GCRAccessTokenSpec, &out.GCRAccessTokenSpec
 		*out = new(GCRAccessTokenSpec)
@@ -673,6 +832,11 @@ func (in *GeneratorSpec) DeepCopyInto(out *GeneratorSpec) {
 		*out = new(PasswordSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.ServiceAccountTokenSpec != nil {
+		in, out := &in.ServiceAccountTokenSpec, &out.ServiceAccountTokenSpec
+		*out = new(ServiceAccountTokenSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.STSSessionTokenSpec != nil {
 		in, out := &in.STSSessionTokenSpec, &out.STSSessionTokenSpec
 		*out = new(STSSessionTokenSpec)
@@ -705,6 +869,44 @@ func (in *GeneratorSpec) DeepCopy() *GeneratorSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GeneratorStatus) DeepCopyInto(out *GeneratorStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]GeneratorStatusCondition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GeneratorStatus.
+func (in *GeneratorStatus) DeepCopy() *GeneratorStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(GeneratorStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GeneratorStatusCondition) DeepCopyInto(out *GeneratorStatusCondition) {
+	*out = *in
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GeneratorStatusCondition.
+func (in *GeneratorStatusCondition) DeepCopy() *GeneratorStatusCondition {
+	if in == nil {
+		return nil
+	}
+	out := new(GeneratorStatusCondition)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GithubAccessToken) DeepCopyInto(out *GithubAccessToken) {
 	*out = *in
@@ -899,6 +1101,11 @@ func (in *PasswordSpec) DeepCopyInto(out *PasswordSpec) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.Policy != nil {
+		in, out := &in.Policy, &out.Policy
+		*out = new(v1.SecretValuePolicy)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PasswordSpec.
@@ -1035,6 +1242,89 @@ func (in *SecretKeySelector) DeepCopy() *SecretKeySelector {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceAccountToken) DeepCopyInto(out *ServiceAccountToken) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceAccountToken.
+func (in *ServiceAccountToken) DeepCopy() *ServiceAccountToken {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceAccountToken)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ServiceAccountToken) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceAccountTokenList) DeepCopyInto(out *ServiceAccountTokenList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ServiceAccountToken, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceAccountTokenList.
+func (in *ServiceAccountTokenList) DeepCopy() *ServiceAccountTokenList {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceAccountTokenList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ServiceAccountTokenList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceAccountTokenSpec) DeepCopyInto(out *ServiceAccountTokenSpec) {
+	*out = *in
+	if in.Audiences != nil {
+		in, out := &in.Audiences, &out.Audiences
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExpirationSeconds != nil {
+		in, out := &in.ExpirationSeconds, &out.ExpirationSeconds
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceAccountTokenSpec.
+func (in *ServiceAccountTokenSpec) DeepCopy() *ServiceAccountTokenSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceAccountTokenSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *UUID) DeepCopyInto(out *UUID) {
 	*out = *in
@@ -38,6 +38,39 @@ type SecretKeySelector struct {
 	// +kubebuilder:validation:MaxLength:=253
 	// +kubebuilder:validation:Pattern:=^[-._a-zA-Z0-9]+$
 	Key string `json:"key,omitempty"`
+
+	// StoreRef reads the value from a secret of another store instead of a Kubernetes Secret,
+	// e.g. to keep the credentials of this store in another provider.
+	// Name, Namespace and Key are ignored if it is set.
+	// +optional
+	StoreRef *StoreSecretRef `json:"storeRef,omitempty"`
+}
+
+// A reference to a secret of a SecretStore or ClusterSecretStore.
+type StoreSecretRef struct {
+	// The name of the store being referred to.
+	// +kubebuilder:validation:MinLength:=1
+	// +kubebuilder:validation:MaxLength:=253
+	// +kubebuilder:validation:Pattern:=^[a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*$
+	Name string `json:"name"`
+
+	// The kind of the store being referred to, SecretStore or ClusterSecretStore. Defaults to SecretStore.
+	// A SecretStore can only refer to SecretStores of its namespace, a ClusterSecretStore only to ClusterSecretStores.
+	// +optional
+	// +kubebuilder:validation:Enum=SecretStore;ClusterSecretStore
+	Kind string `json:"kind,omitempty"`
+
+	// The key of the secret in the referenced store.
+	// +kubebuilder:validation:MinLength:=1
+	RemoteKey string `json:"remoteKey"`
+
+	// The property of the secret, e.g. a key of a JSON secret.
+	// +optional
+	Property string `json:"property,omitempty"`
+
+	// The version of the secret.
+	// +optional
+	Version string `json:"version,omitempty"`
 }
 
 // A reference to a ServiceAccount resource.
@@ -62,3 +95,32 @@ type ServiceAccountSelector struct {
 	// +optional
 	Audiences []string `json:"audiences,omitempty"`
 }
+
+// CharacterClass is a class of characters a secret value must contain.
+// +kubebuilder:validation:Enum=Uppercase;Lowercase;Digit;Symbol
+type CharacterClass string
+
+const (
+	CharacterClassUppercase CharacterClass = "Uppercase"
+	CharacterClassLowercase CharacterClass = "Lowercase"
+	CharacterClassDigit     CharacterClass = "Digit"
+	CharacterClassSymbol    CharacterClass = "Symbol"
+)
+
+// SecretValuePolicy defines the length and complexity rules a secret value must comply with.
+type SecretValuePolicy struct {
+	// MinLength is the minimum number of characters of the value.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	MinLength int `json:"minLength,omitempty"`
+
+	// MaxLength is the maximum number of characters of the value, no limit if it is not set.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	MaxLength *int `json:"maxLength,omitempty"`
+
+	// RequiredClasses lists the classes the value must contain at least one character of.
+	// Symbols are all characters that are neither letters, digits nor whitespace.
+	// +optional
+	RequiredClasses []CharacterClass `json:"requiredClasses,omitempty"`
+}
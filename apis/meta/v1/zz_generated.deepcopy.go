@@ -28,6 +28,11 @@ func (in *SecretKeySelector) DeepCopyInto(out *SecretKeySelector) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.StoreRef != nil {
+		in, out := &in.StoreRef, &out.StoreRef
+		*out = new(StoreSecretRef)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretKeySelector.
@@ -40,6 +45,31 @@ func (in *SecretKeySelector) DeepCopy() *SecretKeySelector {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecretValuePolicy) DeepCopyInto(out *SecretValuePolicy) {
+	*out = *in
+	if in.MaxLength != nil {
+		in, out := &in.MaxLength, &out.MaxLength
+		*out = new(int)
+		**out = **in
+	}
+	if in.RequiredClasses != nil {
+		in, out := &in.RequiredClasses, &out.RequiredClasses
+		*out = make([]CharacterClass, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretValuePolicy.
+func (in *SecretValuePolicy) DeepCopy() *SecretValuePolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(SecretValuePolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ServiceAccountSelector) DeepCopyInto(out *ServiceAccountSelector) {
 	*out = *in
@@ -64,3 +94,18 @@ func (in *ServiceAccountSelector) DeepCopy() *ServiceAccountSelector {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StoreSecretRef) DeepCopyInto(out *StoreSecretRef) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StoreSecretRef.
+func (in *StoreSecretRef) DeepCopy() *StoreSecretRef {
+	if in == nil {
+		return nil
+	}
+	out := new(StoreSecretRef)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -0,0 +1,67 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"context"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+
+	"github.com/external-secrets/external-secrets/pkg/validatestore"
+)
+
+var listVersionsCmd = &cobra.Command{
+	Use:   "list-versions",
+	Short: "List the versions of a remote secret using a SecretStore or ClusterSecretStore manifest",
+	Long: `List the versions of a remote secret using a SecretStore or ClusterSecretStore manifest.
+	The provider client is created like with validate-store and the versions of the key
+	are printed with their creation time, e.g. to pick a remoteRef.version for a rollback.
+	Supported by AWS Secrets Manager, Vault KV v2 and GCP Secret Manager.
+	For more information visit https://external-secrets.io`,
+	Run: func(cmd *cobra.Command, args []string) {
+		ctrl.SetLogger(zap.New(zap.WriteTo(os.Stderr)))
+		ctx, cancel := context.WithTimeout(context.Background(), validateTimeout)
+		defer cancel()
+		err := validatestore.ListVersions(ctx, validatestore.Options{
+			StorePath:   storePath,
+			SecretPaths: storeSecretPaths,
+			SecretEnv:   storeSecretEnv,
+			Namespace:   namespace,
+		}, versionsKey, cmd.OutOrStdout(), cmd.ErrOrStderr())
+		if err != nil {
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(listVersionsCmd)
+
+	listVersionsCmd.Flags().StringVarP(&storePath, "file", "f", "", "Path of the SecretStore or ClusterSecretStore manifest")
+	listVersionsCmd.Flags().StringVar(&versionsKey, "key", "", "Remote key of the secret, like remoteRef.key")
+	listVersionsCmd.Flags().StringSliceVar(&storeSecretPaths, "secret-file", nil,
+		"Path of a manifest with Secrets referenced by the store, may contain multiple documents")
+	listVersionsCmd.Flags().StringSliceVar(&storeSecretEnv, "secret-env", nil,
+		"Secret key referenced by the store read from an environment variable, in the form name/key=ENV_VAR")
+	listVersionsCmd.Flags().StringVar(&namespace, "namespace", "",
+		"Namespace of Secrets without a namespace and of the client. Defaults to the namespace of the SecretStore or default")
+	listVersionsCmd.Flags().DurationVar(&validateTimeout, "timeout", 30*time.Second, "Timeout of the request")
+	_ = listVersionsCmd.MarkFlagRequired("file")
+	_ = listVersionsCmd.MarkFlagRequired("key")
+}
@@ -17,13 +17,17 @@ limitations under the License.
 package cmd
 
 import (
+	"context"
+	"fmt"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/spf13/cobra"
 	"go.uber.org/zap/zapcore"
 	v1 "k8s.io/api/core/v1"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
@@ -45,6 +49,7 @@ import (
 	ctrlmetrics "github.com/external-secrets/external-secrets/pkg/controllers/metrics"
 	"github.com/external-secrets/external-secrets/pkg/controllers/pushsecret"
 	"github.com/external-secrets/external-secrets/pkg/controllers/pushsecret/psmetrics"
+	"github.com/external-secrets/external-secrets/pkg/controllers/remotesecretdeletion"
 	"github.com/external-secrets/external-secrets/pkg/controllers/secretstore"
 	"github.com/external-secrets/external-secrets/pkg/controllers/secretstore/cssmetrics"
 	"github.com/external-secrets/external-secrets/pkg/controllers/secretstore/ssmetrics"
@@ -62,7 +67,12 @@ var (
 	metricsAddr                           string
 	healthzAddr                           string
 	controllerClass                       string
+	controllerIdentity                    string
+	labelSelector                         string
 	enableLeaderElection                  bool
+	leaderElectionLeaseDuration           time.Duration
+	leaderElectionRenewDeadline           time.Duration
+	leaderElectionRetryPeriod             time.Duration
 	enableSecretsCache                    bool
 	enableConfigMapsCache                 bool
 	enableManagedSecretsCache             bool
@@ -77,7 +87,21 @@ var (
 	enableClusterStoreReconciler          bool
 	enableClusterExternalSecretReconciler bool
 	enablePushSecretReconciler            bool
+	enableRemoteSecretDeletionReconciler  bool
 	enableFloodGate                       bool
+	enableStoreWatches                    bool
+	storeCircuitBreakerThreshold          int
+	storeCircuitBreakerCooldown           time.Duration
+	disableOwnerReferences                bool
+	skipTerminatingNamespaces             bool
+	templateNamespaceMetadata             bool
+	readOnly                              bool
+	allowedSecretTypes                    []string
+	maxManagedSecrets                     int
+	encryptionHashKeySecret               string
+	namespaceMaxConcurrentReconciles      int
+	namespaceReconcileRate                float64
+	namespaceReconcileBurst               int
 	enableExtendedMetricLabels            bool
 	storeRequeueInterval                  time.Duration
 	serviceName, serviceNamespace         string
@@ -86,8 +110,19 @@ var (
 	crdRequeueInterval                    time.Duration
 	certCheckInterval                     time.Duration
 	certLookaheadInterval                 time.Duration
+	conversionErrorThreshold              time.Duration
+	conversionErrorRatio                  float64
+	conversionMinRequests                 int
+	storePath                             string
+	storeSecretPaths                      []string
+	storeSecretEnv                        []string
+	skipClientValidation                  bool
+	validateTimeout                       time.Duration
+	versionsKey                           string
 	tlsCiphers                            string
 	tlsMinVersion                         string
+	enableV1alpha1                        bool
+	secretPreview                         string
 )
 
 const (
@@ -101,10 +136,21 @@ func init() {
 
 	// external-secrets schemes
 	utilruntime.Must(esv1beta1.AddToScheme(scheme))
-	utilruntime.Must(esv1alpha1.AddToScheme(scheme))
 	utilruntime.Must(genv1alpha1.AddToScheme(scheme))
 }
 
+// addV1alpha1ToScheme adds the v1alpha1 kinds to the scheme.
+// PushSecret and RemoteSecretDeletion only exist in v1alpha1 and are always added,
+// the v1alpha1 versions of ExternalSecret, SecretStore and ClusterSecretStore
+// only with --enable-v1alpha1.
+func addV1alpha1ToScheme() {
+	if enableV1alpha1 {
+		utilruntime.Must(esv1alpha1.AddToScheme(scheme))
+		return
+	}
+	utilruntime.Must(esv1alpha1.AddAlphaOnlyToScheme(scheme))
+}
+
 var rootCmd = &cobra.Command{
 	Use:   "external-secrets",
 	Short: "operator that reconciles ExternalSecrets and SecretStores",
@@ -128,6 +174,11 @@ var rootCmd = &cobra.Command{
 		}
 		logger := zap.New(zap.UseFlagOptions(&opts))
 		ctrl.SetLogger(logger)
+		addV1alpha1ToScheme()
+		if err := validateLeaderElection(); err != nil {
+			setupLog.Error(err, "invalid leader election configuration")
+			os.Exit(1)
+		}
 		ctrlmetrics.SetUpLabelNames(enableExtendedMetricLabels)
 		esmetrics.SetUpMetrics()
 		config := ctrl.GetConfigOrDie()
@@ -163,12 +214,26 @@ var rootCmd = &cobra.Command{
 			},
 			LeaderElection:   enableLeaderElection,
 			LeaderElectionID: "external-secrets-controller",
+			LeaseDuration:    &leaderElectionLeaseDuration,
+			RenewDeadline:    &leaderElectionRenewDeadline,
+			RetryPeriod:      &leaderElectionRetryPeriod,
 		}
 		if namespace != "" {
 			ctrlOpts.Cache.DefaultNamespaces = map[string]cache.Config{
 				namespace: {},
 			}
 		}
+		// only ExternalSecrets matching the selector are cached and reconciled
+		esSelector, err := labels.Parse(labelSelector)
+		if err != nil {
+			setupLog.Error(err, "unable to parse label selector")
+			os.Exit(1)
+		}
+		if !esSelector.Empty() {
+			ctrlOpts.Cache.ByObject = map[client.Object]cache.ByObject{
+				&esv1beta1.ExternalSecret{}: {Label: esSelector},
+			}
+		}
 		mgr, err := ctrl.NewManager(config, ctrlOpts)
 		if err != nil {
 			setupLog.Error(err, "unable to start manager")
@@ -188,6 +253,16 @@ var rootCmd = &cobra.Command{
 			}
 		}
 
+		// the store watchers refresh ExternalSecrets once the change notifications of a store report a changed secret
+		var storeWatchers *secretstore.StoreWatchers
+		if enableStoreWatches {
+			storeWatchers = secretstore.NewStoreWatchers(mgr.GetClient(), ctrl.Log.WithName("controllers").WithName("StoreWatchers"))
+			if err = mgr.Add(storeWatchers); err != nil {
+				setupLog.Error(err, "unable to add store watchers")
+				os.Exit(1)
+			}
+		}
+
 		ssmetrics.SetUpMetrics()
 		if err = (&secretstore.StoreReconciler{
 			Client:          mgr.GetClient(),
@@ -195,6 +270,7 @@ var rootCmd = &cobra.Command{
 			Scheme:          mgr.GetScheme(),
 			ControllerClass: controllerClass,
 			RequeueInterval: storeRequeueInterval,
+			Watchers:        storeWatchers,
 		}).SetupWithManager(mgr, controller.Options{
 			MaxConcurrentReconciles: concurrent,
 		}); err != nil {
@@ -209,6 +285,7 @@ var rootCmd = &cobra.Command{
 				Scheme:          mgr.GetScheme(),
 				ControllerClass: controllerClass,
 				RequeueInterval: storeRequeueInterval,
+				Watchers:        storeWatchers,
 			}).SetupWithManager(mgr, controller.Options{
 				MaxConcurrentReconciles: concurrent,
 			}); err != nil {
@@ -216,6 +293,20 @@ var rootCmd = &cobra.Command{
 				os.Exit(1)
 			}
 		}
+		// the plaintext hash of encrypted secrets is keyed, so it can not be used to guess the values
+		encryptionHashKey, err := externalsecret.LoadEncryptionHashKey(context.Background(), mgr.GetAPIReader(), encryptionHashKeySecret)
+		if err != nil {
+			setupLog.Error(err, "unable to load the encryption hash key")
+			os.Exit(1)
+		}
+		if readOnly {
+			setupLog.Info("running in read-only mode, secrets of ExternalSecrets are never created, updated or deleted")
+		}
+		// ExternalSecrets outside of the selector are not in the cache, they are read from the API server
+		var esAPIReader client.Reader
+		if !esSelector.Empty() {
+			esAPIReader = mgr.GetAPIReader()
+		}
 		if err = (&externalsecret.Reconciler{
 			Client:                    mgr.GetClient(),
 			SecretClient:              secretClient,
@@ -223,9 +314,24 @@ var rootCmd = &cobra.Command{
 			Scheme:                    mgr.GetScheme(),
 			RestConfig:                mgr.GetConfig(),
 			ControllerClass:           controllerClass,
+			ControllerIdentity:        controllerIdentity,
+			LabelSelector:             esSelector,
+			APIReader:                 esAPIReader,
 			RequeueInterval:           time.Hour,
 			ClusterSecretStoreEnabled: enableClusterStoreReconciler,
 			EnableFloodGate:           enableFloodGate,
+			DisableOwnerReferences:    disableOwnerReferences,
+			SkipTerminatingNamespaces: skipTerminatingNamespaces,
+			TemplateNamespaceMetadata: templateNamespaceMetadata,
+			ReadOnly:                  readOnly,
+			AllowedSecretTypes:        toSecretTypes(allowedSecretTypes),
+			MaxManagedSecrets:         maxManagedSecrets,
+			EncryptionHashKey:         encryptionHashKey,
+			StoreCircuitBreakers: secretstore.NewCircuitBreakers(storeCircuitBreakerThreshold, storeCircuitBreakerCooldown,
+				esmetrics.UpdateStoreCircuitBreakerState),
+			StoreWatchers: storeWatchers,
+			NamespaceThrottle: externalsecret.NewNamespaceThrottle(namespaceMaxConcurrentReconciles, namespaceReconcileRate, namespaceReconcileBurst,
+				esmetrics.UpdateNamespaceQueueDepth, esmetrics.DeleteNamespaceQueueDepth),
 		}).SetupWithManager(mgr, controller.Options{
 			MaxConcurrentReconciles: concurrent,
 		}); err != nil {
@@ -248,6 +354,19 @@ var rootCmd = &cobra.Command{
 				os.Exit(1)
 			}
 		}
+		if enableRemoteSecretDeletionReconciler {
+			if err = (&remotesecretdeletion.Reconciler{
+				Client:          mgr.GetClient(),
+				Log:             ctrl.Log.WithName("controllers").WithName("RemoteSecretDeletion"),
+				Scheme:          mgr.GetScheme(),
+				ControllerClass: controllerClass,
+			}).SetupWithManager(mgr, controller.Options{
+				MaxConcurrentReconciles: concurrent,
+			}); err != nil {
+				setupLog.Error(err, errCreateController, "controller", "RemoteSecretDeletion")
+				os.Exit(1)
+			}
+		}
 		if enableClusterExternalSecretReconciler {
 			cesmetrics.SetUpMetrics()
 
@@ -256,6 +375,7 @@ var rootCmd = &cobra.Command{
 				Log:             ctrl.Log.WithName("controllers").WithName("ClusterExternalSecret"),
 				Scheme:          mgr.GetScheme(),
 				RequeueInterval: time.Hour,
+				APIReader:       esAPIReader,
 			}).SetupWithManager(mgr, controller.Options{
 				MaxConcurrentReconciles: concurrent,
 			}); err != nil {
@@ -279,6 +399,28 @@ var rootCmd = &cobra.Command{
 	},
 }
 
+// validateLeaderElection checks that the leader can renew its lease before it expires.
+func validateLeaderElection() error {
+	if leaderElectionRenewDeadline >= leaderElectionLeaseDuration {
+		return fmt.Errorf("--leader-election-renew-deadline (%s) must be less than --leader-election-lease-duration (%s)", leaderElectionRenewDeadline, leaderElectionLeaseDuration)
+	}
+	if leaderElectionRetryPeriod >= leaderElectionRenewDeadline {
+		return fmt.Errorf("--leader-election-retry-period (%s) must be less than --leader-election-renew-deadline (%s)", leaderElectionRetryPeriod, leaderElectionRenewDeadline)
+	}
+	return nil
+}
+
+// toSecretTypes converts the values of --allowed-secret-types.
+func toSecretTypes(types []string) []v1.SecretType {
+	secretTypes := make([]v1.SecretType, 0, len(types))
+	for _, t := range types {
+		if t = strings.TrimSpace(t); t != "" {
+			secretTypes = append(secretTypes, v1.SecretType(t))
+		}
+	}
+	return secretTypes
+}
+
 func Execute() {
 	cobra.CheckErr(rootCmd.Execute())
 }
@@ -286,9 +428,17 @@ func Execute() {
 func init() {
 	rootCmd.Flags().StringVar(&metricsAddr, "metrics-addr", ":8080", "The address the metric endpoint binds to.")
 	rootCmd.Flags().StringVar(&controllerClass, "controller-class", "default", "The controller is instantiated with a specific controller name and filters ES based on this property")
+	rootCmd.Flags().StringVar(&controllerIdentity, "controller-identity", "", "Identity written to the Secrets managed by this controller. Secrets with a different identity are never updated or deleted, so multiple installations can coexist.")
+	rootCmd.Flags().StringVar(&labelSelector, "label-selector", "", "Only watch and reconcile ExternalSecrets matching this label selector, e.g. 'tenant=a'. Composes with the controller class.")
 	rootCmd.Flags().BoolVar(&enableLeaderElection, "enable-leader-election", false,
 		"Enable leader election for controller manager. "+
 			"Enabling this will ensure there is only one active controller manager.")
+	rootCmd.Flags().DurationVar(&leaderElectionLeaseDuration, "leader-election-lease-duration", 15*time.Second,
+		"Duration that non-leader candidates will wait to force acquire leadership.")
+	rootCmd.Flags().DurationVar(&leaderElectionRenewDeadline, "leader-election-renew-deadline", 10*time.Second,
+		"Duration that the acting leader will retry refreshing leadership before giving up. Must be less than the lease duration.")
+	rootCmd.Flags().DurationVar(&leaderElectionRetryPeriod, "leader-election-retry-period", 2*time.Second,
+		"Duration the leader election clients should wait between tries of actions.")
 	rootCmd.Flags().IntVar(&concurrent, "concurrent", 1, "The number of concurrent reconciles.")
 	rootCmd.Flags().Float32Var(&clientQPS, "client-qps", 50, "QPS configuration to be passed to rest.Client")
 	rootCmd.Flags().IntVar(&clientBurst, "client-burst", 100, "Maximum Burst allowed to be passed to rest.Client")
@@ -298,11 +448,31 @@ func init() {
 	rootCmd.Flags().BoolVar(&enableClusterStoreReconciler, "enable-cluster-store-reconciler", true, "Enable cluster store reconciler.")
 	rootCmd.Flags().BoolVar(&enableClusterExternalSecretReconciler, "enable-cluster-external-secret-reconciler", true, "Enable cluster external secret reconciler.")
 	rootCmd.Flags().BoolVar(&enablePushSecretReconciler, "enable-push-secret-reconciler", true, "Enable push secret reconciler.")
+	rootCmd.Flags().BoolVar(&enableRemoteSecretDeletionReconciler, "enable-remote-secret-deletion-reconciler", false, "Enable remote secret deletion reconciler. It deletes managed secrets from the providers.")
 	rootCmd.Flags().BoolVar(&enableSecretsCache, "enable-secrets-caching", false, "Enable secrets caching for ALL secrets in the cluster (WARNING: can increase memory usage).")
 	rootCmd.Flags().BoolVar(&enableConfigMapsCache, "enable-configmaps-caching", false, "Enable configmaps caching for ALL configmaps in the cluster (WARNING: can increase memory usage).")
 	rootCmd.Flags().BoolVar(&enableManagedSecretsCache, "enable-managed-secrets-caching", true, "Enable secrets caching for secrets managed by an ExternalSecret")
 	rootCmd.Flags().DurationVar(&storeRequeueInterval, "store-requeue-interval", time.Minute*5, "Default Time duration between reconciling (Cluster)SecretStores")
 	rootCmd.Flags().BoolVar(&enableFloodGate, "enable-flood-gate", true, "Enable flood gate. External secret will be reconciled only if the ClusterStore or Store have an healthy or unknown state.")
+	rootCmd.Flags().BoolVar(&enableStoreWatches, "enable-store-watches", true, "Refresh ExternalSecrets as soon as their secrets change, for stores that configure change notifications. Other stores are polled on the refresh interval.")
+	rootCmd.Flags().IntVar(&storeCircuitBreakerThreshold, "store-circuit-breaker-threshold", 0,
+		"Consecutive unavailable, rate limited or timed out provider calls of a store across all ExternalSecrets after which its provider calls are skipped for the cooldown. 0 disables the circuit breaker.")
+	rootCmd.Flags().DurationVar(&storeCircuitBreakerCooldown, "store-circuit-breaker-cooldown", time.Minute,
+		"Duration for which the provider calls of a store are skipped once its circuit breaker opened.")
+	rootCmd.Flags().BoolVar(&disableOwnerReferences, "disable-owner-references", false, "Do not set owner references on secrets created by an ExternalSecret. The secrets are deleted through a finalizer instead.")
+	rootCmd.Flags().BoolVar(&skipTerminatingNamespaces, "skip-terminating-namespaces", true, "Do not write secrets of an ExternalSecret whose namespace is terminating. Requires read access to namespaces.")
+	rootCmd.Flags().BoolVar(&templateNamespaceMetadata, "enable-namespace-template-metadata", true, "Expose the name, labels and annotations of the namespace as .Namespace in templates. Requires read access to namespaces.")
+	rootCmd.Flags().BoolVar(&readOnly, "read-only", false, "Fetch the provider data and report the status of ExternalSecrets, but never create, update or delete their secrets.")
+	rootCmd.Flags().StringSliceVar(&allowedSecretTypes, "allowed-secret-types", nil, "Comma separated list of the secret types ExternalSecrets may write, e.g. Opaque,kubernetes.io/tls. All types are allowed if it is empty.")
+	rootCmd.Flags().StringVar(&encryptionHashKeySecret, "encryption-hash-key-secret", "",
+		"<namespace>/<name> of a secret whose 'key' field keys the plaintext hash of ExternalSecrets with target.encryptWith. A random key is generated on start if it is empty.")
+	rootCmd.Flags().IntVar(&maxManagedSecrets, "max-managed-secrets", 0, "Maximum number of secrets managed by ExternalSecrets, new secrets are not created past it while existing ones are still updated. 0 means no limit.")
+	rootCmd.Flags().IntVar(&namespaceMaxConcurrentReconciles, "namespace-max-concurrent-reconciles", 0,
+		"Maximum number of ExternalSecrets of a single namespace that are reconciled at the same time, other ExternalSecrets of the namespace are requeued. 0 means no limit.")
+	rootCmd.Flags().Float64Var(&namespaceReconcileRate, "namespace-reconcile-rate", 0,
+		"Maximum number of ExternalSecret reconciles per second of a single namespace, other ExternalSecrets of the namespace are requeued. 0 means no limit.")
+	rootCmd.Flags().IntVar(&namespaceReconcileBurst, "namespace-reconcile-burst", 10, "Number of reconciles a namespace may run at once on top of --namespace-reconcile-rate.")
+	rootCmd.Flags().BoolVar(&enableV1alpha1, "enable-v1alpha1", true, "Enable the v1alpha1 versions of ExternalSecret, SecretStore and ClusterSecretStore. PushSecret and RemoteSecretDeletion are always enabled.")
 	rootCmd.Flags().BoolVar(&enableExtendedMetricLabels, "enable-extended-metric-labels", false, "Enable recommended kubernetes annotations as labels in metrics.")
 	fs := feature.Features()
 	for _, f := range fs {
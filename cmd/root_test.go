@@ -0,0 +1,133 @@
+/*
+Copyright © 2022 ESO Maintainer Team
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"testing"
+	"time"
+)
+
+func TestValidateLeaderElection(t *testing.T) {
+	tests := []struct {
+		name          string
+		leaseDuration time.Duration
+		renewDeadline time.Duration
+		retryPeriod   time.Duration
+		wantErr       bool
+	}{
+		{
+			name:          "valid durations",
+			leaseDuration: 15 * time.Second,
+			renewDeadline: 10 * time.Second,
+			retryPeriod:   2 * time.Second,
+		},
+		{
+			name:          "renew deadline equal to lease duration",
+			leaseDuration: 10 * time.Second,
+			renewDeadline: 10 * time.Second,
+			retryPeriod:   2 * time.Second,
+			wantErr:       true,
+		},
+		{
+			name:          "renew deadline longer than lease duration",
+			leaseDuration: 10 * time.Second,
+			renewDeadline: 15 * time.Second,
+			retryPeriod:   2 * time.Second,
+			wantErr:       true,
+		},
+		{
+			name:          "retry period equal to renew deadline",
+			leaseDuration: 15 * time.Second,
+			renewDeadline: 10 * time.Second,
+			retryPeriod:   10 * time.Second,
+			wantErr:       true,
+		},
+		{
+			name:          "retry period longer than renew deadline",
+			leaseDuration: 15 * time.Second,
+			renewDeadline: 10 * time.Second,
+			retryPeriod:   12 * time.Second,
+			wantErr:       true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			leaderElectionLeaseDuration = tt.leaseDuration
+			leaderElectionRenewDeadline = tt.renewDeadline
+			leaderElectionRetryPeriod = tt.retryPeriod
+			if err := validateLeaderElection(); (err != nil) != tt.wantErr {
+				t.Errorf("validateLeaderElection() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLeaderElectionFlags(t *testing.T) {
+	flags := []string{"leader-election-lease-duration", "leader-election-renew-deadline", "leader-election-retry-period"}
+	tests := []struct {
+		name              string
+		args              []string
+		wantLeaseDuration time.Duration
+		wantRenewDeadline time.Duration
+		wantRetryPeriod   time.Duration
+		wantErr           bool
+	}{
+		{
+			name:              "defaults",
+			wantLeaseDuration: 15 * time.Second,
+			wantRenewDeadline: 10 * time.Second,
+			wantRetryPeriod:   2 * time.Second,
+		},
+		{
+			name:              "custom durations",
+			args:              []string{"--leader-election-lease-duration=60s", "--leader-election-renew-deadline=40s", "--leader-election-retry-period=5s"},
+			wantLeaseDuration: time.Minute,
+			wantRenewDeadline: 40 * time.Second,
+			wantRetryPeriod:   5 * time.Second,
+		},
+		{
+			name:              "renew deadline longer than the default lease duration",
+			args:              []string{"--leader-election-renew-deadline=20s"},
+			wantLeaseDuration: 15 * time.Second,
+			wantRenewDeadline: 20 * time.Second,
+			wantRetryPeriod:   2 * time.Second,
+			wantErr:           true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// reset the flags of previous cases
+			for _, name := range flags {
+				f := rootCmd.Flags().Lookup(name)
+				if err := f.Value.Set(f.DefValue); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if err := rootCmd.Flags().Parse(tt.args); err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+			if leaderElectionLeaseDuration != tt.wantLeaseDuration || leaderElectionRenewDeadline != tt.wantRenewDeadline || leaderElectionRetryPeriod != tt.wantRetryPeriod {
+				t.Errorf("got lease duration %s, renew deadline %s, retry period %s, want %s, %s, %s",
+					leaderElectionLeaseDuration, leaderElectionRenewDeadline, leaderElectionRetryPeriod,
+					tt.wantLeaseDuration, tt.wantRenewDeadline, tt.wantRetryPeriod)
+			}
+			if err := validateLeaderElection(); (err != nil) != tt.wantErr {
+				t.Errorf("validateLeaderElection() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
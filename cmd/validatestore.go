@@ -0,0 +1,67 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"context"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+
+	"github.com/external-secrets/external-secrets/pkg/validatestore"
+)
+
+var validateStoreCmd = &cobra.Command{
+	Use:   "validate-store",
+	Short: "Validate a SecretStore or ClusterSecretStore manifest without a cluster",
+	Long: `Validate a SecretStore or ClusterSecretStore manifest without a cluster.
+	The store is validated, the provider client is created with the given credentials
+	and the client is validated against the provider. Exits with 1 if a step fails.
+	For more information visit https://external-secrets.io`,
+	Run: func(cmd *cobra.Command, args []string) {
+		ctrl.SetLogger(zap.New(zap.WriteTo(os.Stderr)))
+		ctx, cancel := context.WithTimeout(context.Background(), validateTimeout)
+		defer cancel()
+		err := validatestore.Run(ctx, validatestore.Options{
+			StorePath:            storePath,
+			SecretPaths:          storeSecretPaths,
+			SecretEnv:            storeSecretEnv,
+			Namespace:            namespace,
+			SkipClientValidation: skipClientValidation,
+		}, cmd.OutOrStdout())
+		if err != nil {
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(validateStoreCmd)
+
+	validateStoreCmd.Flags().StringVarP(&storePath, "file", "f", "", "Path of the SecretStore or ClusterSecretStore manifest")
+	validateStoreCmd.Flags().StringSliceVar(&storeSecretPaths, "secret-file", nil,
+		"Path of a manifest with Secrets referenced by the store, may contain multiple documents")
+	validateStoreCmd.Flags().StringSliceVar(&storeSecretEnv, "secret-env", nil,
+		"Secret key referenced by the store read from an environment variable, in the form name/key=ENV_VAR")
+	validateStoreCmd.Flags().StringVar(&namespace, "namespace", "",
+		"Namespace of Secrets without a namespace and of the client. Defaults to the namespace of the SecretStore or default")
+	validateStoreCmd.Flags().BoolVar(&skipClientValidation, "skip-client-validation", false,
+		"Only create the client, do not validate it against the provider")
+	validateStoreCmd.Flags().DurationVar(&validateTimeout, "timeout", 30*time.Second, "Timeout of the validation")
+	_ = validateStoreCmd.MarkFlagRequired("file")
+}
@@ -39,6 +39,7 @@ import (
 	esv1alpha1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1alpha1"
 	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
 	"github.com/external-secrets/external-secrets/pkg/controllers/crds"
+	"github.com/external-secrets/external-secrets/pkg/conversion"
 )
 
 const (
@@ -51,7 +52,6 @@ func init() {
 
 	// external-secrets schemes
 	utilruntime.Must(esv1beta1.AddToScheme(scheme))
-	utilruntime.Must(esv1alpha1.AddToScheme(scheme))
 }
 
 var webhookCmd = &cobra.Command{
@@ -84,6 +84,18 @@ var webhookCmd = &cobra.Command{
 		}
 		logger := zap.New(zap.UseFlagOptions(&opts))
 		ctrl.SetLogger(logger)
+		preview := esv1beta1.SecretPreviewMode(secretPreview)
+		switch preview {
+		case esv1beta1.SecretPreviewNever, esv1beta1.SecretPreviewDryRun, esv1beta1.SecretPreviewAlways:
+		default:
+			setupLog.Error(fmt.Errorf("unknown value %q, must be one of Never, DryRun, Always", secretPreview), "invalid --secret-preview")
+			os.Exit(1)
+		}
+		// without v1alpha1 the conversion webhook rejects objects of that version,
+		// so they must have been migrated to v1beta1 before.
+		if enableV1alpha1 {
+			utilruntime.Must(esv1alpha1.AddToScheme(scheme))
+		}
 
 		err := waitForCerts(c, time.Minute*2)
 		if err != nil {
@@ -142,7 +154,17 @@ var webhookCmd = &cobra.Command{
 			setupLog.Error(err, "unable to start manager")
 			os.Exit(1)
 		}
-		if err = (&esv1beta1.ExternalSecret{}).SetupWebhookWithManager(mgr); err != nil {
+		// registered before the webhooks, so they do not register the plain conversion handler
+		conversionHandler := conversion.NewHandler(mgr.GetScheme(), conversion.HealthCheckOptions{
+			Window:       conversionErrorThreshold,
+			FailureRatio: conversionErrorRatio,
+			MinRequests:  conversionMinRequests,
+		})
+		mgr.GetWebhookServer().Register(conversion.Path, conversionHandler)
+		if err = (&esv1beta1.ExternalSecret{}).SetupWebhookWithManager(mgr, &esv1beta1.ExternalSecretValidator{
+			AllowedSecretTypes: toSecretTypes(allowedSecretTypes),
+			SecretPreview:      preview,
+		}); err != nil {
 			setupLog.Error(err, errCreateWebhook, "webhook", "ExternalSecret-v1beta1")
 			os.Exit(1)
 		}
@@ -154,17 +176,19 @@ var webhookCmd = &cobra.Command{
 			setupLog.Error(err, errCreateWebhook, "webhook", "ClusterSecretStore-v1beta1")
 			os.Exit(1)
 		}
-		if err = (&esv1alpha1.ExternalSecret{}).SetupWebhookWithManager(mgr); err != nil {
-			setupLog.Error(err, errCreateWebhook, "webhook", "ExternalSecret-v1alpha1")
-			os.Exit(1)
-		}
-		if err = (&esv1alpha1.SecretStore{}).SetupWebhookWithManager(mgr); err != nil {
-			setupLog.Error(err, errCreateWebhook, "webhook", "SecretStore-v1alpha1")
-			os.Exit(1)
-		}
-		if err = (&esv1alpha1.ClusterSecretStore{}).SetupWebhookWithManager(mgr); err != nil {
-			setupLog.Error(err, errCreateWebhook, "webhook", "ClusterSecretStore-v1alpha1")
-			os.Exit(1)
+		if enableV1alpha1 {
+			if err = (&esv1alpha1.ExternalSecret{}).SetupWebhookWithManager(mgr); err != nil {
+				setupLog.Error(err, errCreateWebhook, "webhook", "ExternalSecret-v1alpha1")
+				os.Exit(1)
+			}
+			if err = (&esv1alpha1.SecretStore{}).SetupWebhookWithManager(mgr); err != nil {
+				setupLog.Error(err, errCreateWebhook, "webhook", "SecretStore-v1alpha1")
+				os.Exit(1)
+			}
+			if err = (&esv1alpha1.ClusterSecretStore{}).SetupWebhookWithManager(mgr); err != nil {
+				setupLog.Error(err, errCreateWebhook, "webhook", "ClusterSecretStore-v1alpha1")
+				os.Exit(1)
+			}
 		}
 
 		err = mgr.AddReadyzCheck("certs", func(_ *http.Request) error {
@@ -174,6 +198,11 @@ var webhookCmd = &cobra.Command{
 			setupLog.Error(err, "unable to add certs readyz check")
 			os.Exit(1)
 		}
+		err = mgr.AddHealthzCheck("convert", conversionHandler.Check)
+		if err != nil {
+			setupLog.Error(err, "unable to add conversion healthz check")
+			os.Exit(1)
+		}
 
 		setupLog.Info("starting manager")
 		if err := mgr.Start(ctx); err != nil {
@@ -256,6 +285,9 @@ func init() {
 	webhookCmd.Flags().StringVar(&loglevel, "loglevel", "info", "loglevel to use, one of: debug, info, warn, error, dpanic, panic, fatal")
 	webhookCmd.Flags().DurationVar(&certCheckInterval, "check-interval", 5*time.Minute, "certificate check interval")
 	webhookCmd.Flags().DurationVar(&certLookaheadInterval, "lookahead-interval", crds.LookaheadInterval, "certificate check interval")
+	webhookCmd.Flags().DurationVar(&conversionErrorThreshold, "conversion-error-threshold", 5*time.Minute, "sliding window of conversions the healthz check is based on, 0 disables the check")
+	webhookCmd.Flags().Float64Var(&conversionErrorRatio, "conversion-error-ratio", 0.5, "ratio of failed conversions in the window from which the healthz check fails")
+	webhookCmd.Flags().IntVar(&conversionMinRequests, "conversion-min-requests", 10, "number of conversions in the window below which the healthz check never fails")
 	// https://go.dev/blog/tls-cipher-suites explains the ciphers selection process
 	webhookCmd.Flags().StringVar(&tlsCiphers, "tls-ciphers", "", "comma separated list of tls ciphers allowed."+
 		" This does not apply to TLS 1.3 as the ciphers are selected automatically."+
@@ -263,4 +295,7 @@ func init() {
 		" Full lists of available ciphers can be found at https://pkg.go.dev/crypto/tls#pkg-constants."+
 		" E.g. 'TLS_ECDHE_ECDSA_WITH_CHACHA20_POLY1305_SHA256,TLS_ECDHE_RSA_WITH_CHACHA20_POLY1305_SHA256'")
 	webhookCmd.Flags().StringVar(&tlsMinVersion, "tls-min-version", "1.2", "minimum version of TLS supported.")
+	webhookCmd.Flags().StringSliceVar(&allowedSecretTypes, "allowed-secret-types", nil, "Comma separated list of the secret types ExternalSecrets may set in target.template.type, e.g. Opaque,kubernetes.io/tls. All types are allowed if it is empty.")
+	webhookCmd.Flags().StringVar(&secretPreview, "secret-preview", string(esv1beta1.SecretPreviewDryRun), "When to return a preview of the target secret of an ExternalSecret as admission warnings, one of: Never, DryRun, Always.")
+	webhookCmd.Flags().BoolVar(&enableV1alpha1, "enable-v1alpha1", true, "Enable the webhooks and conversion of the v1alpha1 versions of ExternalSecret, SecretStore and ClusterSecretStore.")
 }
@@ -0,0 +1,132 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	authv1 "k8s.io/api/authentication/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/external-secrets/external-secrets/pkg/utils/clockskew"
+)
+
+const (
+	// tokenRefreshRatio is the fraction of the token lifetime after which a new token is requested.
+	tokenRefreshRatio = 0.8
+	// defaultTokenExpirationSeconds is the lifetime of requested tokens, the minimum of the TokenRequest API.
+	defaultTokenExpirationSeconds = int64(600)
+
+	errNoAudiences     = "service account token must be requested for at least one audience"
+	errTokenNamespace  = "no namespace on ClusterScoped webhook service account %s"
+	errCreateToken     = "failed to create token for service account %s/%s: %w"
+	errNoToken         = "token request for service account %s/%s returned no token"
+	errAuthUnavailable = "service account token auth is not configured"
+)
+
+// serviceAccountTokenSource requests tokens for a ServiceAccount with the TokenRequest API
+// and caches them until most of their lifetime has passed.
+type serviceAccountTokenSource struct {
+	kube              client.Client
+	namespace         string
+	name              string
+	audiences         []string
+	expirationSeconds int64
+	skew              time.Duration
+	now               func() time.Time
+
+	mu        sync.Mutex
+	token     string
+	refreshAt time.Time
+}
+
+// newServiceAccountTokenSource returns the token source of the auth for a store in the given namespace.
+// The ServiceAccount of a SecretStore is always in the namespace of the store.
+func newServiceAccountTokenSource(kube client.Client, auth *ServiceAccountTokenAuth, namespace string, clusterScoped bool, skew time.Duration) (*serviceAccountTokenSource, error) {
+	// without an audience the token is valid for the Kubernetes API server,
+	// it must never be sent to a URL the store owner controls
+	if len(auth.ServiceAccountRef.Audiences) == 0 {
+		return nil, errors.New(errNoAudiences)
+	}
+	if clusterScoped && auth.ServiceAccountRef.Namespace != nil {
+		namespace = *auth.ServiceAccountRef.Namespace
+	}
+	if namespace == "" {
+		return nil, fmt.Errorf(errTokenNamespace, auth.ServiceAccountRef.Name)
+	}
+	expirationSeconds := defaultTokenExpirationSeconds
+	if auth.ExpirationSeconds != nil {
+		expirationSeconds = *auth.ExpirationSeconds
+	}
+	return &serviceAccountTokenSource{
+		kube:              kube,
+		namespace:         namespace,
+		name:              auth.ServiceAccountRef.Name,
+		audiences:         auth.ServiceAccountRef.Audiences,
+		expirationSeconds: expirationSeconds,
+		skew:              skew,
+		now:               time.Now,
+	}, nil
+}
+
+// Token returns the cached token, or requests a new one if it is due for a refresh.
+func (s *serviceAccountTokenSource) Token(ctx context.Context) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	if s.token != "" && now.Before(s.refreshAt) {
+		return s.token, nil
+	}
+
+	sa := &corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: s.name, Namespace: s.namespace}}
+	expirationSeconds := s.expirationSeconds
+	tokenRequest := &authv1.TokenRequest{
+		Spec: authv1.TokenRequestSpec{
+			Audiences:         s.audiences,
+			ExpirationSeconds: &expirationSeconds,
+		},
+	}
+	if err := s.kube.SubResource("token").Create(ctx, sa, tokenRequest); err != nil {
+		return "", fmt.Errorf(errCreateToken, s.namespace, s.name, err)
+	}
+	if tokenRequest.Status.Token == "" {
+		return "", fmt.Errorf(errNoToken, s.namespace, s.name)
+	}
+
+	s.token = tokenRequest.Status.Token
+	s.refreshAt = refreshTime(now, tokenRequest.Status.ExpirationTimestamp.Time, s.skew)
+	return s.token, nil
+}
+
+// refreshTime returns the point in time after which a new token should be requested.
+// It is never later than the expiry of the token minus the clock skew.
+func refreshTime(issuedAt, expiresAt time.Time, skew time.Duration) time.Time {
+	if expiresAt.IsZero() {
+		return issuedAt
+	}
+	lifetime := expiresAt.Sub(issuedAt)
+	refreshAt := issuedAt.Add(time.Duration(float64(lifetime) * tokenRefreshRatio))
+	if deadline := clockskew.RefreshBefore(expiresAt, skew); deadline.Before(refreshAt) {
+		return deadline
+	}
+	return refreshAt
+}
@@ -0,0 +1,179 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	authv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	esmeta "github.com/external-secrets/external-secrets/apis/meta/v1"
+)
+
+const testAudience = "secrets.example.com"
+
+type tokenRequests struct {
+	count     int
+	namespace string
+	audiences []string
+	lifetime  time.Duration
+	issuedAt  func() time.Time
+}
+
+// fakeTokenClient returns a client that issues a new token for every TokenRequest.
+func fakeTokenClient(requests *tokenRequests) client.Client {
+	return fake.NewClientBuilder().WithInterceptorFuncs(interceptor.Funcs{
+		SubResourceCreate: func(_ context.Context, _ client.Client, subResource string, obj client.Object, req client.Object, _ ...client.SubResourceCreateOption) error {
+			tokenRequest, ok := req.(*authv1.TokenRequest)
+			if subResource != "token" || !ok {
+				return fmt.Errorf("unexpected sub resource %q", subResource)
+			}
+			requests.count++
+			requests.namespace = obj.GetNamespace()
+			requests.audiences = tokenRequest.Spec.Audiences
+			tokenRequest.Status.Token = fmt.Sprintf("token-%d", requests.count)
+			tokenRequest.Status.ExpirationTimestamp = metav1.NewTime(requests.issuedAt().Add(requests.lifetime))
+			return nil
+		},
+	}).Build()
+}
+
+func TestServiceAccountTokenRefresh(t *testing.T) {
+	start := time.Now().Truncate(time.Second)
+	now := start
+	requests := &tokenRequests{lifetime: time.Hour, issuedAt: func() time.Time { return now }}
+	auth := &ServiceAccountTokenAuth{ServiceAccountRef: esmeta.ServiceAccountSelector{Name: "webhook", Audiences: []string{testAudience}}}
+	source, err := newServiceAccountTokenSource(fakeTokenClient(requests), auth, "default", false, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	source.now = func() time.Time { return now }
+
+	got, err := source.Token(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != "token-1" || requests.count != 1 {
+		t.Fatalf("expected initial token to be requested, got %q after %d requests", got, requests.count)
+	}
+	if requests.namespace != "default" || len(requests.audiences) != 1 || requests.audiences[0] != testAudience {
+		t.Fatalf("unexpected token request for %s with audiences %v", requests.namespace, requests.audiences)
+	}
+
+	// the cached token is still fresh
+	now = start.Add(30 * time.Minute)
+	got, err = source.Token(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != "token-1" || requests.count != 1 {
+		t.Fatalf("expected cached token before refresh time, got %q after %d requests", got, requests.count)
+	}
+
+	// after 80% of the lifetime a new token is requested
+	now = start.Add(50 * time.Minute)
+	got, err = source.Token(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != "token-2" || requests.count != 2 {
+		t.Fatalf("expected new token after refresh time, got %q after %d requests", got, requests.count)
+	}
+}
+
+func TestServiceAccountTokenSource(t *testing.T) {
+	otherNamespace := "other"
+	tests := []struct {
+		name          string
+		ref           esmeta.ServiceAccountSelector
+		namespace     string
+		clusterScoped bool
+		wantNamespace string
+		wantErr       string
+	}{
+		{
+			name:          "uses the namespace of the store",
+			ref:           esmeta.ServiceAccountSelector{Name: "webhook", Audiences: []string{testAudience}},
+			namespace:     "default",
+			wantNamespace: "default",
+		},
+		{
+			name:          "ignores the ref namespace of a SecretStore",
+			ref:           esmeta.ServiceAccountSelector{Name: "webhook", Namespace: &otherNamespace, Audiences: []string{testAudience}},
+			namespace:     "default",
+			wantNamespace: "default",
+		},
+		{
+			name:          "uses the ref namespace of a ClusterSecretStore",
+			ref:           esmeta.ServiceAccountSelector{Name: "webhook", Namespace: &otherNamespace, Audiences: []string{testAudience}},
+			namespace:     "default",
+			clusterScoped: true,
+			wantNamespace: otherNamespace,
+		},
+		{
+			name:      "requires an audience",
+			ref:       esmeta.ServiceAccountSelector{Name: "webhook"},
+			namespace: "default",
+			wantErr:   errNoAudiences,
+		},
+		{
+			name:          "requires a namespace",
+			ref:           esmeta.ServiceAccountSelector{Name: "webhook", Audiences: []string{testAudience}},
+			clusterScoped: true,
+			wantErr:       "no namespace",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requests := &tokenRequests{lifetime: time.Hour, issuedAt: time.Now}
+			source, err := newServiceAccountTokenSource(fakeTokenClient(requests), &ServiceAccountTokenAuth{ServiceAccountRef: tt.ref}, tt.namespace, tt.clusterScoped, 0)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if _, err := source.Token(context.Background()); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if requests.namespace != tt.wantNamespace {
+				t.Errorf("token requested in namespace %q, want %q", requests.namespace, tt.wantNamespace)
+			}
+		})
+	}
+}
+
+func TestServiceAccountTokenRefreshTime(t *testing.T) {
+	issuedAt := time.Now().Truncate(time.Second)
+	expiresAt := issuedAt.Add(10 * time.Minute)
+	if got, want := refreshTime(issuedAt, expiresAt, 0), issuedAt.Add(8*time.Minute); !got.Equal(want) {
+		t.Errorf("refreshTime() = %v, want %v", got, want)
+	}
+	// a clock skew larger than the rest of the lifetime refreshes the token earlier
+	if got, want := refreshTime(issuedAt, expiresAt, 5*time.Minute), issuedAt.Add(5*time.Minute); !got.Equal(want) {
+		t.Errorf("refreshTime() with clock skew = %v, want %v", got, want)
+	}
+}
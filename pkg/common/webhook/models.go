@@ -18,6 +18,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	esmeta "github.com/external-secrets/external-secrets/apis/meta/v1"
 )
 
 type Spec struct {
@@ -32,6 +33,10 @@ type Spec struct {
 	// +optional
 	Headers map[string]string `json:"headers,omitempty"`
 
+	// AllowedProviderOptionHeaders lists the names of the headers ExternalSecrets may set with providerOptions.
+	// +optional
+	AllowedProviderOptionHeaders []string `json:"allowedProviderOptionHeaders,omitempty"`
+
 	// Body
 	// +optional
 	Body string `json:"body,omitempty"`
@@ -40,6 +45,10 @@ type Spec struct {
 	// +optional
 	Timeout *metav1.Duration `json:"timeout,omitempty"`
 
+	// Auth specifies how to authenticate against the webhook endpoint.
+	// +optional
+	Auth *Auth `json:"auth,omitempty"`
+
 	// Result formatting
 	Result Result `json:"result"`
 
@@ -58,6 +67,33 @@ type Spec struct {
 	// The provider for the CA bundle to use to validate webhook server certificate.
 	// +optional
 	CAProvider *esv1beta1.CAProvider `json:"caProvider,omitempty"`
+
+	// ServerName overrides the name used to verify the webhook server certificate and sent with SNI.
+	// +optional
+	ServerName string `json:"serverName,omitempty"`
+
+	// TLSPinnedKeys pins the public keys of the webhook server certificate chain.
+	// +optional
+	TLSPinnedKeys []string `json:"tlsPinnedKeys,omitempty"`
+
+	// Proxy routes the requests to the webhook through a proxy.
+	// +optional
+	Proxy *esv1beta1.ProxyConfig `json:"proxy,omitempty"`
+}
+
+type Auth struct {
+	// ServiceAccountToken sends a token of a ServiceAccount as a bearer token.
+	// +optional
+	ServiceAccountToken *ServiceAccountTokenAuth `json:"serviceAccountToken,omitempty"`
+}
+
+type ServiceAccountTokenAuth struct {
+	// ServiceAccountRef is the ServiceAccount the token is requested for.
+	ServiceAccountRef esmeta.ServiceAccountSelector `json:"serviceAccountRef"`
+
+	// ExpirationSeconds is the requested lifetime of the token.
+	// +optional
+	ExpirationSeconds *int64 `json:"expirationSeconds,omitempty"`
 }
 
 type Result struct {
@@ -23,9 +23,12 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"maps"
 	"net/http"
 	"net/url"
+	"slices"
 	tpl "text/template"
+	"time"
 
 	"github.com/PaesslerAG/jsonpath"
 	corev1 "k8s.io/api/core/v1"
@@ -40,6 +43,8 @@ import (
 	"github.com/external-secrets/external-secrets/pkg/utils/resolvers"
 )
 
+const errProviderOptionNotAllowed = "providerOption %s is not allowed by the store, it must be listed in allowedProviderOptionHeaders"
+
 type Webhook struct {
 	Kube          client.Client
 	Namespace     string
@@ -47,6 +52,10 @@ type Webhook struct {
 	HTTP          *http.Client
 	EnforceLabels bool
 	ClusterScoped bool
+	// ClockSkew is the tolerated difference to the clock of the token issuer.
+	ClockSkew time.Duration
+
+	tokenSource *serviceAccountTokenSource
 }
 
 func (w *Webhook) getStoreSecret(ctx context.Context, ref SecretKeySelector) (*corev1.Secret, error) {
@@ -187,6 +196,16 @@ func (w *Webhook) GetWebhookData(ctx context.Context, provider *Spec, ref *esv1b
 		}
 		req.Header.Add(hKey, hValue)
 	}
+	if err := setProviderOptionHeaders(ctx, req, provider); err != nil {
+		return nil, err
+	}
+	if provider.Auth != nil && provider.Auth.ServiceAccountToken != nil {
+		token, err := w.getServiceAccountToken(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get auth token: %w", err)
+		}
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
 
 	resp, err := w.HTTP.Do(req)
 	metrics.ObserveAPICall(constants.ProviderWebhook, constants.CallWebhookHTTPReq, err)
@@ -208,26 +227,94 @@ func (w *Webhook) GetWebhookData(ctx context.Context, provider *Spec, ref *esv1b
 	return io.ReadAll(resp.Body)
 }
 
+// setProviderOptionHeaders sends the provider options of the ExternalSecret as headers.
+// Only headers allowed by the store can be set, headers configured in the store take precedence.
+func setProviderOptionHeaders(ctx context.Context, req *http.Request, provider *Spec) error {
+	options := esv1beta1.ProviderOptionsFromContext(ctx)
+	if len(options) == 0 {
+		return nil
+	}
+	allowed := make(map[string]bool, len(provider.AllowedProviderOptionHeaders))
+	for _, name := range provider.AllowedProviderOptionHeaders {
+		allowed[http.CanonicalHeaderKey(name)] = true
+	}
+	storeHeaders := make(map[string]bool, len(provider.Headers)+1)
+	for name := range provider.Headers {
+		storeHeaders[http.CanonicalHeaderKey(name)] = true
+	}
+	if provider.Auth != nil {
+		storeHeaders["Authorization"] = true
+	}
+	for _, hKey := range slices.Sorted(maps.Keys(options)) {
+		name := http.CanonicalHeaderKey(hKey)
+		if !allowed[name] {
+			return fmt.Errorf(errProviderOptionNotAllowed, hKey)
+		}
+		if storeHeaders[name] {
+			continue
+		}
+		req.Header.Set(name, options[hKey])
+	}
+	return nil
+}
+
+// ConfigureAuth sets up the authentication of the spec, it must be called once before the first request.
+// The token source is kept for the lifetime of the client, so tokens are only requested when they are about to expire.
+func (w *Webhook) ConfigureAuth(provider *Spec) error {
+	if provider.Auth == nil || provider.Auth.ServiceAccountToken == nil {
+		return nil
+	}
+	source, err := newServiceAccountTokenSource(w.Kube, provider.Auth.ServiceAccountToken, w.Namespace, w.ClusterScoped, w.ClockSkew)
+	if err != nil {
+		return err
+	}
+	w.tokenSource = source
+	return nil
+}
+
+// getServiceAccountToken returns a token of the ServiceAccount configured with ConfigureAuth.
+func (w *Webhook) getServiceAccountToken(ctx context.Context) (string, error) {
+	if w.tokenSource == nil {
+		return "", errors.New(errAuthUnavailable)
+	}
+	return w.tokenSource.Token(ctx)
+}
+
 func (w *Webhook) GetHTTPClient(ctx context.Context, provider *Spec) (*http.Client, error) {
 	client := &http.Client{}
 	if provider.Timeout != nil {
 		client.Timeout = provider.Timeout.Duration
 	}
-	if len(provider.CABundle) == 0 && provider.CAProvider == nil {
-		// No need to process ca stuff if it is not there
-		return client, nil
+	if provider.Proxy != nil {
+		client.Transport = utils.ProxyTransport(provider.Proxy)
 	}
-	caCertPool, err := w.GetCACertPool(ctx, provider)
-	if err != nil {
-		return nil, err
+	hasCA := len(provider.CABundle) != 0 || provider.CAProvider != nil
+	if !hasCA && provider.ServerName == "" && len(provider.TLSPinnedKeys) == 0 {
+		// No need to process tls stuff if it is not there
+		return client, nil
 	}
 
 	tlsConf := &tls.Config{
-		RootCAs:       caCertPool,
+		ServerName:    provider.ServerName,
 		MinVersion:    tls.VersionTLS12,
 		Renegotiation: tls.RenegotiateOnceAsClient,
 	}
-	client.Transport = &http.Transport{TLSClientConfig: tlsConf}
+	if hasCA {
+		caCertPool, err := w.GetCACertPool(ctx, provider)
+		if err != nil {
+			return nil, err
+		}
+		tlsConf.RootCAs = caCertPool
+	}
+	if err := utils.PinTLSConfig(tlsConf, provider.TLSPinnedKeys); err != nil {
+		return nil, err
+	}
+	transport := &http.Transport{}
+	if provider.Proxy != nil {
+		transport = utils.ProxyTransport(provider.Proxy)
+	}
+	transport.TLSClientConfig = tlsConf
+	client.Transport = transport
 	return client, nil
 }
 
@@ -24,6 +24,12 @@ const (
 	CallAWSSMPutSecretValue      = "PutSecretValue"
 	CallAWSSMListSecrets         = "ListSecrets"
 	CallAWSSMBatchGetSecretValue = "BatchGetSecretValue"
+	CallAWSSMListSecretVersions  = "ListSecretVersionIds"
+	CallAWSSMUpdateSecret        = "UpdateSecret"
+	CallAWSSMTagResource         = "TagResource"
+	CallAWSSMRotateSecret        = "RotateSecret"
+	CallAWSSMReceiveMessage      = "ReceiveMessage"
+	CallAWSSMDeleteMessageBatch  = "DeleteMessageBatch"
 
 	ProviderAWSPS                = "AWS/ParameterStore"
 	CallAWSPSGetParameter        = "GetParameter"
@@ -51,6 +57,7 @@ const (
 	CallGCPSMAccessSecretVersion = "AccessSecretVersion"
 	CallGCPSMAddSecretVersion    = "AddSecretVersion"
 	CallGCPSMListSecrets         = "ListSecrets"
+	CallGCPSMListSecretVersions  = "ListSecretVersions"
 	CallGCPSMGenerateSAToken     = "GenerateServiceAccountToken"
 	CallGCPSMGenerateIDBindToken = "GenerateIDBindToken"
 	CallGCPSMGenerateAccessToken = "GenerateAccessToken"
@@ -51,6 +51,9 @@ type Reconciler struct {
 	Log             logr.Logger
 	Scheme          *runtime.Scheme
 	RequeueInterval time.Duration
+	// APIReader reads ExternalSecrets from the API server. It must be set if the cache of the manager
+	// only holds the ExternalSecrets matching a label selector, nil reads them from the cache.
+	APIReader client.Reader
 }
 
 const (
@@ -59,6 +62,9 @@ const (
 	errConvertLabelSelector = "unable to convert labelselector"
 	errGetExistingES        = "could not get existing ExternalSecret"
 	errNamespacesFailed     = "one or more namespaces failed"
+	errNamespacesUnknown    = "target namespaces are unknown"
+
+	msgNamespacesSynced = "%d/%d namespaces synced, %d failed, %d pending"
 )
 
 func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
@@ -120,6 +126,11 @@ func (r *Reconciler) reconcile(ctx context.Context, log logr.Logger, clusterExte
 		}
 		condition := NewClusterExternalSecretCondition(failedNamespaces)
 		SetClusterExternalSecretCondition(clusterExternalSecret, *condition)
+		SetClusterExternalSecretCondition(clusterExternalSecret, esv1beta1.ClusterExternalSecretStatusCondition{
+			Type:    esv1beta1.ClusterExternalSecretSynced,
+			Status:  v1.ConditionFalse,
+			Message: errNamespacesUnknown,
+		})
 
 		clusterExternalSecret.Status.FailedNamespaces = toNamespaceFailures(failedNamespaces)
 
@@ -128,10 +139,13 @@ func (r *Reconciler) reconcile(ctx context.Context, log logr.Logger, clusterExte
 
 	failedNamespaces := r.deleteOutdatedExternalSecrets(ctx, namespaces, esName, clusterExternalSecret.Name, clusterExternalSecret.Status.ProvisionedNamespaces)
 
-	provisionedNamespaces := r.gatherProvisionedNamespaces(ctx, log, clusterExternalSecret, namespaces, esName, failedNamespaces)
+	summary := &esv1beta1.ClusterExternalSecretSummary{Total: len(namespaces)}
+	provisionedNamespaces := r.gatherProvisionedNamespaces(ctx, log, clusterExternalSecret, namespaces, esName, failedNamespaces, summary)
 
 	condition := NewClusterExternalSecretCondition(failedNamespaces)
 	SetClusterExternalSecretCondition(clusterExternalSecret, *condition)
+	SetClusterExternalSecretCondition(clusterExternalSecret, *NewClusterExternalSecretSyncedCondition(summary))
+	clusterExternalSecret.Status.Summary = summary
 
 	clusterExternalSecret.Status.FailedNamespaces = toNamespaceFailures(failedNamespaces)
 	sort.Strings(provisionedNamespaces)
@@ -147,31 +161,37 @@ func (r *Reconciler) gatherProvisionedNamespaces(
 	namespaces []v1.Namespace,
 	esName string,
 	failedNamespaces map[string]error,
+	summary *esv1beta1.ClusterExternalSecretSummary,
 ) []string {
 	var provisionedNamespaces []string //nolint:prealloc // we don't know the size
 	for _, namespace := range namespaces {
 		var existingES esv1beta1.ExternalSecret
-		err := r.Get(ctx, types.NamespacedName{
+		err := r.esClient().Get(ctx, types.NamespacedName{
 			Name:      esName,
 			Namespace: namespace.Name,
 		}, &existingES)
 		if err != nil && !apierrors.IsNotFound(err) {
 			log.Error(err, errGetExistingES)
 			failedNamespaces[namespace.Name] = err
+			summary.Failed++
 			continue
 		}
 
 		if err == nil && !isExternalSecretOwnedBy(&existingES, clusterExternalSecret.Name) {
 			failedNamespaces[namespace.Name] = errors.New("external secret already exists in namespace")
+			summary.Failed++
 			continue
 		}
 
 		if err := r.createOrUpdateExternalSecret(ctx, clusterExternalSecret, namespace, esName, clusterExternalSecret.Spec.ExternalSecretMetadata); err != nil {
 			log.Error(err, "failed to create or update external secret")
 			failedNamespaces[namespace.Name] = err
+			summary.Failed++
 			continue
 		}
 
+		// a new ExternalSecret has no status yet and is counted as pending
+		countExternalSecret(summary, &existingES)
 		provisionedNamespaces = append(provisionedNamespaces, namespace.Name)
 	}
 	return provisionedNamespaces
@@ -259,7 +279,7 @@ func (r *Reconciler) createOrUpdateExternalSecret(ctx context.Context, clusterEx
 		return nil
 	}
 
-	if _, err := ctrl.CreateOrUpdate(ctx, r.Client, externalSecret, mutateFunc); err != nil {
+	if _, err := ctrl.CreateOrUpdate(ctx, r.esClient(), externalSecret, mutateFunc); err != nil {
 		return fmt.Errorf("could not create or update ExternalSecret: %w", err)
 	}
 
@@ -268,7 +288,7 @@ func (r *Reconciler) createOrUpdateExternalSecret(ctx context.Context, clusterEx
 
 func (r *Reconciler) deleteExternalSecret(ctx context.Context, esName, cesName, namespace string) error {
 	var existingES esv1beta1.ExternalSecret
-	err := r.Get(ctx, types.NamespacedName{
+	err := r.esClient().Get(ctx, types.NamespacedName{
 		Name:      esName,
 		Namespace: namespace,
 	}, &existingES)
@@ -292,6 +312,28 @@ func (r *Reconciler) deleteExternalSecret(ctx context.Context, esName, cesName,
 	return nil
 }
 
+// esClient returns the client for ExternalSecrets, it reads them through the APIReader if it is set.
+func (r *Reconciler) esClient() client.Client {
+	if r.APIReader == nil {
+		return r.Client
+	}
+	return &apiReaderClient{Client: r.Client, reader: r.APIReader}
+}
+
+// apiReaderClient writes through the client and reads through the reader.
+type apiReaderClient struct {
+	client.Client
+	reader client.Reader
+}
+
+func (c *apiReaderClient) Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+	return c.reader.Get(ctx, key, obj, opts...)
+}
+
+func (c *apiReaderClient) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	return c.reader.List(ctx, list, opts...)
+}
+
 func (r *Reconciler) deferPatch(ctx context.Context, log logr.Logger, clusterExternalSecret *esv1beta1.ClusterExternalSecret, p client.Patch) {
 	if err := r.Status().Patch(ctx, clusterExternalSecret, p); err != nil {
 		log.Error(err, errPatchStatus)
@@ -182,11 +182,13 @@ var _ = Describe("ClusterExternalSecret controller", func() {
 					Status: esv1beta1.ClusterExternalSecretStatus{
 						ExternalSecretName:    created.Name,
 						ProvisionedNamespaces: []string{namespaces[0].Name},
+						Summary:               pendingSummary(1, 0),
 						Conditions: []esv1beta1.ClusterExternalSecretStatusCondition{
 							{
 								Type:   esv1beta1.ClusterExternalSecretReady,
 								Status: v1.ConditionTrue,
 							},
+							syncedCondition(1, 0),
 						},
 					},
 				}
@@ -228,11 +230,13 @@ var _ = Describe("ClusterExternalSecret controller", func() {
 					Status: esv1beta1.ClusterExternalSecretStatus{
 						ExternalSecretName:    "test-es",
 						ProvisionedNamespaces: []string{namespaces[0].Name},
+						Summary:               pendingSummary(1, 0),
 						Conditions: []esv1beta1.ClusterExternalSecretStatusCondition{
 							{
 								Type:   esv1beta1.ClusterExternalSecretReady,
 								Status: v1.ConditionTrue,
 							},
+							syncedCondition(1, 0),
 						},
 					},
 				}
@@ -287,11 +291,13 @@ var _ = Describe("ClusterExternalSecret controller", func() {
 					Status: esv1beta1.ClusterExternalSecretStatus{
 						ExternalSecretName:    "new-es-name",
 						ProvisionedNamespaces: []string{namespaces[0].Name},
+						Summary:               pendingSummary(1, 0),
 						Conditions: []esv1beta1.ClusterExternalSecretStatusCondition{
 							{
 								Type:   esv1beta1.ClusterExternalSecretReady,
 								Status: v1.ConditionTrue,
 							},
+							syncedCondition(1, 0),
 						},
 					},
 				}
@@ -354,11 +360,13 @@ var _ = Describe("ClusterExternalSecret controller", func() {
 					Status: esv1beta1.ClusterExternalSecretStatus{
 						ExternalSecretName:    created.Name,
 						ProvisionedNamespaces: []string{namespaces[0].Name},
+						Summary:               pendingSummary(1, 0),
 						Conditions: []esv1beta1.ClusterExternalSecretStatusCondition{
 							{
 								Type:   esv1beta1.ClusterExternalSecretReady,
 								Status: v1.ConditionTrue,
 							},
+							syncedCondition(1, 0),
 						},
 					},
 				}
@@ -414,12 +422,14 @@ var _ = Describe("ClusterExternalSecret controller", func() {
 								Reason:    "external secret already exists in namespace",
 							},
 						},
+						Summary: pendingSummary(1, 1),
 						Conditions: []esv1beta1.ClusterExternalSecretStatusCondition{
 							{
 								Type:    esv1beta1.ClusterExternalSecretReady,
 								Status:  v1.ConditionFalse,
 								Message: "one or more namespaces failed",
 							},
+							syncedCondition(1, 1),
 						},
 					},
 				}
@@ -486,11 +496,13 @@ var _ = Describe("ClusterExternalSecret controller", func() {
 					Status: esv1beta1.ClusterExternalSecretStatus{
 						ExternalSecretName:    created.Name,
 						ProvisionedNamespaces: []string{namespaces[0].Name},
+						Summary:               pendingSummary(1, 0),
 						Conditions: []esv1beta1.ClusterExternalSecretStatusCondition{
 							{
 								Type:   esv1beta1.ClusterExternalSecretReady,
 								Status: v1.ConditionTrue,
 							},
+							syncedCondition(1, 0),
 						},
 					},
 				}
@@ -551,11 +563,13 @@ var _ = Describe("ClusterExternalSecret controller", func() {
 					Status: esv1beta1.ClusterExternalSecretStatus{
 						ExternalSecretName:    created.Name,
 						ProvisionedNamespaces: []string{namespaces[1].Name},
+						Summary:               pendingSummary(1, 0),
 						Conditions: []esv1beta1.ClusterExternalSecretStatusCondition{
 							{
 								Type:   esv1beta1.ClusterExternalSecretReady,
 								Status: v1.ConditionTrue,
 							},
+							syncedCondition(1, 0),
 						},
 					},
 				}
@@ -618,11 +632,13 @@ var _ = Describe("ClusterExternalSecret controller", func() {
 					Status: esv1beta1.ClusterExternalSecretStatus{
 						ExternalSecretName:    created.Name,
 						ProvisionedNamespaces: provisionedNamespaces,
+						Summary:               pendingSummary(2, 0),
 						Conditions: []esv1beta1.ClusterExternalSecretStatusCondition{
 							{
 								Type:   esv1beta1.ClusterExternalSecretReady,
 								Status: v1.ConditionTrue,
 							},
+							syncedCondition(2, 0),
 						},
 					},
 				}
@@ -669,11 +685,13 @@ var _ = Describe("ClusterExternalSecret controller", func() {
 					Spec: created.Spec,
 					Status: esv1beta1.ClusterExternalSecretStatus{
 						ExternalSecretName: created.Name,
+						Summary:            pendingSummary(0, 0),
 						Conditions: []esv1beta1.ClusterExternalSecretStatusCondition{
 							{
 								Type:   esv1beta1.ClusterExternalSecretReady,
 								Status: v1.ConditionTrue,
 							},
+							syncedCondition(0, 0),
 						},
 					},
 				}
@@ -733,11 +751,13 @@ var _ = Describe("ClusterExternalSecret controller", func() {
 							"namespace1",
 							"namespace2",
 						},
+						Summary: pendingSummary(2, 0),
 						Conditions: []esv1beta1.ClusterExternalSecretStatusCondition{
 							{
 								Type:   esv1beta1.ClusterExternalSecretReady,
 								Status: v1.ConditionTrue,
 							},
+							syncedCondition(2, 0),
 						},
 					},
 				}
@@ -787,11 +807,13 @@ var _ = Describe("ClusterExternalSecret controller", func() {
 						ProvisionedNamespaces: []string{
 							"not-matching-namespace",
 						},
+						Summary: pendingSummary(1, 0),
 						Conditions: []esv1beta1.ClusterExternalSecretStatusCondition{
 							{
 								Type:   esv1beta1.ClusterExternalSecretReady,
 								Status: v1.ConditionTrue,
 							},
+							syncedCondition(1, 0),
 						},
 					},
 				}
@@ -812,6 +834,16 @@ var _ = Describe("ClusterExternalSecret controller", func() {
 
 var letterRunes = []rune("abcdefghijklmnopqrstuvwxyz")
 
+// pendingSummary is the summary of target namespaces whose ExternalSecrets
+// are never synced, as there is no ExternalSecret controller in this suite.
+func pendingSummary(total, failed int) *esv1beta1.ClusterExternalSecretSummary {
+	return &esv1beta1.ClusterExternalSecretSummary{Total: total, Failed: failed, Pending: total - failed}
+}
+
+func syncedCondition(total, failed int) esv1beta1.ClusterExternalSecretStatusCondition {
+	return *NewClusterExternalSecretSyncedCondition(pendingSummary(total, failed))
+}
+
 func randString(n int) string {
 	b := make([]rune, n)
 	for i := range b {
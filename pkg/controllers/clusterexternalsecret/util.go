@@ -15,6 +15,9 @@ limitations under the License.
 package clusterexternalsecret
 
 import (
+	"fmt"
+	"slices"
+
 	v1 "k8s.io/api/core/v1"
 
 	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
@@ -38,6 +41,49 @@ func NewClusterExternalSecretCondition(failedNamespaces map[string]error) *esv1b
 	return condition
 }
 
+// NewClusterExternalSecretSyncedCondition returns a Synced condition that is
+// True only if the ExternalSecrets of all target namespaces are Ready.
+func NewClusterExternalSecretSyncedCondition(summary *esv1beta1.ClusterExternalSecretSummary) *esv1beta1.ClusterExternalSecretStatusCondition {
+	status := v1.ConditionFalse
+	if summary.Synced == summary.Total {
+		status = v1.ConditionTrue
+	}
+	return &esv1beta1.ClusterExternalSecretStatusCondition{
+		Type:    esv1beta1.ClusterExternalSecretSynced,
+		Status:  status,
+		Message: fmt.Sprintf(msgNamespacesSynced, summary.Synced, summary.Total, summary.Failed, summary.Pending),
+	}
+}
+
+// countExternalSecret adds the ExternalSecret of a target namespace to the summary.
+// A Ready ExternalSecret is synced, one that waits for something else than
+// the provider is pending, as is one without a Ready condition.
+func countExternalSecret(summary *esv1beta1.ClusterExternalSecretSummary, es *esv1beta1.ExternalSecret) {
+	var condition *esv1beta1.ExternalSecretStatusCondition
+	for i := range es.Status.Conditions {
+		if es.Status.Conditions[i].Type == esv1beta1.ExternalSecretReady {
+			condition = &es.Status.Conditions[i]
+		}
+	}
+	switch {
+	case condition == nil || condition.Status == v1.ConditionUnknown:
+		summary.Pending++
+	case condition.Status == v1.ConditionTrue:
+		summary.Synced++
+	case slices.Contains(pendingReasons, condition.Reason):
+		summary.Pending++
+	default:
+		summary.Failed++
+	}
+}
+
+// pendingReasons are the reasons of a not Ready ExternalSecret that is expected to sync later.
+var pendingReasons = []string{
+	esv1beta1.ConditionReasonGeneratorNotReady,
+	esv1beta1.ConditionReasonDependencyNotReady,
+	esv1beta1.ConditionReasonNamespaceTerminating,
+}
+
 func SetClusterExternalSecretCondition(ces *esv1beta1.ClusterExternalSecret, condition esv1beta1.ClusterExternalSecretStatusCondition) {
 	ces.Status.Conditions = append(filterOutCondition(ces.Status.Conditions, condition.Type), condition)
 	cesmetrics.UpdateClusterExternalSecretCondition(ces, &condition)
@@ -0,0 +1,101 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusterexternalsecret
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	v1 "k8s.io/api/core/v1"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+)
+
+func TestSyncSummary(t *testing.T) {
+	withReady := func(status v1.ConditionStatus, reason string) *esv1beta1.ExternalSecret {
+		return &esv1beta1.ExternalSecret{
+			Status: esv1beta1.ExternalSecretStatus{
+				Conditions: []esv1beta1.ExternalSecretStatusCondition{
+					{Type: esv1beta1.ExternalSecretDeleted, Status: v1.ConditionFalse},
+					{Type: esv1beta1.ExternalSecretReady, Status: status, Reason: reason},
+				},
+			},
+		}
+	}
+
+	tests := []struct {
+		name            string
+		externalSecrets []*esv1beta1.ExternalSecret
+		failed          int
+		wantSummary     esv1beta1.ClusterExternalSecretSummary
+		wantCondition   esv1beta1.ClusterExternalSecretStatusCondition
+	}{
+		{
+			name: "synced if all ExternalSecrets are ready",
+			externalSecrets: []*esv1beta1.ExternalSecret{
+				withReady(v1.ConditionTrue, esv1beta1.ConditionReasonSecretSynced),
+				withReady(v1.ConditionTrue, esv1beta1.ConditionReasonSecretPartiallySynced),
+			},
+			wantSummary: esv1beta1.ClusterExternalSecretSummary{Total: 2, Synced: 2},
+			wantCondition: esv1beta1.ClusterExternalSecretStatusCondition{
+				Type:    esv1beta1.ClusterExternalSecretSynced,
+				Status:  v1.ConditionTrue,
+				Message: "2/2 namespaces synced, 0 failed, 0 pending",
+			},
+		},
+		{
+			name: "synced without target namespaces",
+			wantCondition: esv1beta1.ClusterExternalSecretStatusCondition{
+				Type:    esv1beta1.ClusterExternalSecretSynced,
+				Status:  v1.ConditionTrue,
+				Message: "0/0 namespaces synced, 0 failed, 0 pending",
+			},
+		},
+		{
+			name: "counts failed and pending namespaces",
+			externalSecrets: []*esv1beta1.ExternalSecret{
+				withReady(v1.ConditionTrue, esv1beta1.ConditionReasonSecretSynced),
+				withReady(v1.ConditionFalse, esv1beta1.ConditionReasonSecretSyncedError),
+				withReady(v1.ConditionFalse, esv1beta1.ConditionReasonDependencyNotReady),
+				withReady(v1.ConditionUnknown, ""),
+				{},
+			},
+			failed:      1,
+			wantSummary: esv1beta1.ClusterExternalSecretSummary{Total: 6, Synced: 1, Failed: 2, Pending: 3},
+			wantCondition: esv1beta1.ClusterExternalSecretStatusCondition{
+				Type:    esv1beta1.ClusterExternalSecretSynced,
+				Status:  v1.ConditionFalse,
+				Message: "1/6 namespaces synced, 2 failed, 3 pending",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			summary := &esv1beta1.ClusterExternalSecretSummary{
+				Total:  len(tt.externalSecrets) + tt.failed,
+				Failed: tt.failed,
+			}
+			for _, es := range tt.externalSecrets {
+				countExternalSecret(summary, es)
+			}
+			if diff := cmp.Diff(tt.wantSummary, *summary); diff != "" {
+				t.Errorf("unexpected summary (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tt.wantCondition, *NewClusterExternalSecretSyncedCondition(summary)); diff != "" {
+				t.Errorf("unexpected condition (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -15,12 +15,15 @@ limitations under the License.
 package esmetrics
 
 import (
+	"slices"
+
 	"github.com/prometheus/client_golang/prometheus"
 	v1 "k8s.io/api/core/v1"
 	"sigs.k8s.io/controller-runtime/pkg/metrics"
 
 	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
 	ctrlmetrics "github.com/external-secrets/external-secrets/pkg/controllers/metrics"
+	"github.com/external-secrets/external-secrets/pkg/controllers/secretstore"
 )
 
 const (
@@ -29,6 +32,15 @@ const (
 	SyncCallsErrorKey                  = "sync_calls_error"
 	ExternalSecretStatusConditionKey   = "status_condition"
 	ExternalSecretReconcileDurationKey = "reconcile_duration"
+	GeneratorCallsKey                  = "generator_calls_total"
+	StoreCircuitBreakerStateKey        = "store_circuit_breaker_state"
+	ManagedSecretsKey                  = "managed_secrets"
+	NamespaceQueueDepthKey             = "namespace_queue_depth"
+
+	// Generated values are not cached, every refresh calls the generator again.
+	// So there is no cache-hit outcome, a refresh that is skipped does not count as a call.
+	GeneratorOutcomeSuccess = "success"
+	GeneratorOutcomeError   = "error"
 )
 
 var counterVecMetrics = map[string]*prometheus.CounterVec{}
@@ -63,16 +75,44 @@ func SetUpMetrics() {
 		Help:      "The duration time to reconcile the External Secret",
 	}, ctrlmetrics.NonConditionMetricLabelNames)
 
-	metrics.Registry.MustRegister(syncCallsTotal, syncCallsError, externalSecretCondition, externalSecretReconcileDuration)
+	generatorCalls := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Subsystem: ExternalSecretSubsystem,
+		Name:      GeneratorCallsKey,
+		Help:      "Total number of generator calls made for External Secrets",
+	}, append(slices.Clone(ctrlmetrics.NonConditionMetricLabelNames), "generator_kind", "outcome"))
+
+	storeCircuitBreakerState := prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Subsystem: ExternalSecretSubsystem,
+		Name:      StoreCircuitBreakerStateKey,
+		Help:      "The circuit breaker state of a store used by External Secrets: 0 closed, 1 open, 2 half-open",
+	}, append(slices.Clone(ctrlmetrics.NonConditionMetricLabelNames), "kind"))
+
+	managedSecrets := prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Subsystem: ExternalSecretSubsystem,
+		Name:      ManagedSecretsKey,
+		Help:      "The number of secrets managed by External Secrets, counted when --max-managed-secrets is set",
+	}, []string{})
+
+	namespaceQueueDepth := prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Subsystem: ExternalSecretSubsystem,
+		Name:      NamespaceQueueDepthKey,
+		Help:      "The number of External Secrets of a namespace waiting for capacity, counted when --namespace-max-concurrent-reconciles or --namespace-reconcile-rate is set",
+	}, []string{"namespace"})
+
+	metrics.Registry.MustRegister(syncCallsTotal, syncCallsError, externalSecretCondition, externalSecretReconcileDuration, generatorCalls, storeCircuitBreakerState, managedSecrets, namespaceQueueDepth)
 
 	counterVecMetrics = map[string]*prometheus.CounterVec{
 		SyncCallsKey:      syncCallsTotal,
 		SyncCallsErrorKey: syncCallsError,
+		GeneratorCallsKey: generatorCalls,
 	}
 
 	gaugeVecMetrics = map[string]*prometheus.GaugeVec{
 		ExternalSecretStatusConditionKey:   externalSecretCondition,
 		ExternalSecretReconcileDurationKey: externalSecretReconcileDuration,
+		StoreCircuitBreakerStateKey:        storeCircuitBreakerState,
+		ManagedSecretsKey:                  managedSecrets,
+		NamespaceQueueDepthKey:             namespaceQueueDepth,
 	}
 }
 
@@ -146,6 +186,48 @@ func UpdateExternalSecretCondition(es *esv1beta1.ExternalSecret, condition *esv1
 		})).Set(value)
 }
 
+// RecordGeneratorCall counts a generator call made for the given External Secret.
+func RecordGeneratorCall(es *esv1beta1.ExternalSecret, kind, outcome string) {
+	esInfo := make(map[string]string)
+	esInfo["name"] = es.Name
+	esInfo["namespace"] = es.Namespace
+	for k, v := range es.Labels {
+		esInfo[k] = v
+	}
+	labels := ctrlmetrics.RefineNonConditionMetricLabels(esInfo)
+	labels["generator_kind"] = kind
+	labels["outcome"] = outcome
+	GetCounterVec(GeneratorCallsKey).With(labels).Inc()
+}
+
+// UpdateStoreCircuitBreakerState sets the circuit breaker state of a store.
+func UpdateStoreCircuitBreakerState(store esv1beta1.GenericStore, state secretstore.BreakerState) {
+	storeInfo := make(map[string]string)
+	storeInfo["name"] = store.GetName()
+	storeInfo["namespace"] = store.GetNamespace()
+	for k, v := range store.GetLabels() {
+		storeInfo[k] = v
+	}
+	labels := ctrlmetrics.RefineNonConditionMetricLabels(storeInfo)
+	labels["kind"] = store.GetKind()
+	GetGaugeVec(StoreCircuitBreakerStateKey).With(labels).Set(float64(state))
+}
+
+// UpdateManagedSecrets sets the number of secrets managed by External Secrets.
+func UpdateManagedSecrets(count int) {
+	GetGaugeVec(ManagedSecretsKey).WithLabelValues().Set(float64(count))
+}
+
+// UpdateNamespaceQueueDepth sets the number of External Secrets of a namespace waiting for capacity.
+func UpdateNamespaceQueueDepth(namespace string, depth int) {
+	GetGaugeVec(NamespaceQueueDepthKey).WithLabelValues(namespace).Set(float64(depth))
+}
+
+// DeleteNamespaceQueueDepth removes the series of a namespace once no External Secret is waiting.
+func DeleteNamespaceQueueDepth(namespace string) {
+	GetGaugeVec(NamespaceQueueDepthKey).DeleteLabelValues(namespace)
+}
+
 func GetCounterVec(key string) *prometheus.CounterVec {
 	return counterVecMetrics[key]
 }
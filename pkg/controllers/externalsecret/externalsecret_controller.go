@@ -15,6 +15,7 @@ limitations under the License.
 package externalsecret
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
@@ -53,6 +54,7 @@ import (
 	// Metrics.
 	"github.com/external-secrets/external-secrets/pkg/controllers/externalsecret/esmetrics"
 	ctrlmetrics "github.com/external-secrets/external-secrets/pkg/controllers/metrics"
+	"github.com/external-secrets/external-secrets/pkg/controllers/secretstore"
 	"github.com/external-secrets/external-secrets/pkg/utils"
 	"github.com/external-secrets/external-secrets/pkg/utils/resolvers"
 
@@ -65,24 +67,80 @@ import (
 const (
 	fieldOwnerTemplate = "externalsecrets.external-secrets.io/%v"
 
+	// externalSecretFinalizer is used to delete target secrets that have no owner reference.
+	externalSecretFinalizer = "externalsecret.externalsecrets.io/finalizer"
+
+	// generatorNotReadyRequeueInterval is the interval to check again a generator that is not ready,
+	// generators are not watched by the controller.
+	generatorNotReadyRequeueInterval = 10 * time.Second
+
+	// dependencyNotReadyRequeueInterval is the interval to check again the ExternalSecrets of spec.dependsOn,
+	// dependencies are not watched by the controller.
+	dependencyNotReadyRequeueInterval = 10 * time.Second
+
 	// condition messages for "SecretSynced" reason.
-	msgSynced       = "secret synced"
-	msgSyncedRetain = "secret retained due to DeletionPolicy=Retain"
+	msgSynced         = "secret synced"
+	msgSyncedRetain   = "secret retained due to DeletionPolicy=Retain"
+	msgSyncedNone     = "secret data fetched, secret not written due to CreationPolicy=None"
+	msgSyncedReadOnly = "secret data fetched, secret not written as the controller runs in read-only mode"
+
+	// condition messages for "Validated" and "ValidationFailed" reasons.
+	msgValidated        = "%d entries can be read, secret not written due to validateOnly"
+	msgValidationFailed = "%d of %d entries can not be read, see status.sources"
+
+	// condition messages for "PartiallySynced" reason.
+	msgPartiallySynced = "secret synced, %d entries with continueOnError could not be fetched, see status.sources"
 
 	// condition messages for "SecretDeleted" reason.
 	msgDeleted = "secret deleted due to DeletionPolicy=Delete"
 
 	// condition messages for "SecretMissing" reason.
-	msgMissing = "secret will not be created due to CreationPolicy=Merge"
+	msgMissing = "secret will not be created due to CreationPolicy=Merge or StrictMerge"
 
 	// condition messages for "SecretSyncedError" reason.
 	msgErrorGetSecretData   = "could not get secret data from provider"
+	msgErrorParseSecretData = "could not parse secret data from provider"
+	msgErrorDecryptSecret   = "could not decrypt secret data from provider"
 	msgErrorDeleteSecret    = "could not delete secret"
 	msgErrorDeleteOrphaned  = "could not delete orphaned secrets"
 	msgErrorUpdateSecret    = "could not update secret"
 	msgErrorUpdateImmutable = "could not update secret, target is immutable"
 	msgErrorBecomeOwner     = "failed to take ownership of target secret"
 	msgErrorIsOwned         = "target is owned by another ExternalSecret"
+	msgErrorOtherIdentity   = "target is managed by another controller identity"
+	msgErrorFieldConflict   = "target keys are owned by another field manager"
+	msgErrorSecretTooLarge  = "secret exceeds the size limits, %v (TIP: use target.sizeLimits.truncateKeys or reduce the data)"
+	msgErrorTypeNotAllowed  = "target secret type is not allowed by the controller"
+	msgErrorProviderTags    = "could not copy the provider tags to labels"
+
+	// condition messages for "StoreUnavailable" reason.
+	msgStoreUnavailable = "store is unavailable after consecutive provider failures, retrying after the cooldown"
+	msgStoreDenied      = "store can not be used, it is expired or its conditions exclude this namespace"
+
+	// condition messages for "QuotaExceeded" reason.
+	msgQuotaExceeded = "secret is not created, the controller manages the maximum number of secrets"
+
+	// condition messages for "GeneratorNotReady" reason.
+	msgGeneratorNotReady = "generator is not ready, waiting for its Ready condition"
+
+	// condition messages for "DigestMismatch" reason.
+	msgDigestMismatch = "provider value does not match remoteRef.expectedDigest, update the digest to accept the new value"
+
+	// condition messages for "NamespaceTerminating" reason.
+	msgNamespaceTerminating = "namespace is terminating, secret will not be written"
+
+	// condition messages for "DependencyNotReady" and "DependencyCycle" reasons.
+	msgDependencyNotReady = "waiting for dependencies, %v"
+	msgDependencyCycle    = "dependencies can not be resolved, %v"
+
+	// condition messages for the "ShadowMatch" condition.
+	msgShadowMatch    = "candidate store returned the same data"
+	msgShadowMismatch = "candidate store returned different data, %s"
+	msgShadowError    = "could not sync shadow secret: %v"
+
+	// condition messages for the "TemplateDeprecated" condition.
+	msgTemplateEngineV1     = "template engine v1 is deprecated, set engineVersion to v2"
+	msgTemplateIncompatible = "%s, these functions behave differently with v2: %s"
 
 	// log messages.
 	logErrorGetES                = "unable to get ExternalSecret"
@@ -91,27 +149,45 @@ const (
 	logErrorPatchSecret          = "unable to patch Secret"
 	logErrorSecretCacheNotSynced = "controller caches for Secret are not in sync"
 	logErrorUnmanagedStore       = "unable to determine if store is managed"
+	logErrorDeleteOwned          = "unable to delete owned secrets"
+	logErrorGetNamespace         = "unable to get namespace"
+	logErrorSyncShadow           = "unable to sync shadow secret"
+	logErrorOnError              = "unable to apply onError policy"
 
 	// error formats.
 	errConvert               = "error applying conversion strategy %s to keys: %w"
 	errRewrite               = "error applying rewrite to keys: %w"
 	errDecode                = "error applying decoding strategy %s to data: %w"
+	errDecodersKey           = "secret key %s: %w"
+	errTranscode             = "error converting data to UTF-8: %w"
 	errGenerate              = "error using generator: %w"
-	errInvalidKeys           = "invalid secret keys (TIP: use rewrite or conversionStrategy to change keys): %w"
+	errInvalidKeys           = "invalid secret keys (TIP: use rewrite, conversionStrategy or keyNamePolicy to change keys): %w"
+	errSanitizeKeys          = "unable to sanitize secret keys: %w"
+	errAffixKeys             = "unable to add keyPrefix/keySuffix to secret keys: %w"
+	errStoreGroup            = "storeGroup[%d] %q: %w"
 	errFetchTplFrom          = "error fetching templateFrom data: %w"
+	errFetchTplRef           = "error fetching templateRef configmap %s: %w"
+	errGetNamespace          = "error fetching namespace %s: %w"
+	errTplRefKeyMissing      = "key %s does not exist in templateRef configmap %s"
+	errParseTplRef           = "error parsing key %s of templateRef configmap %s: %w"
 	errApplyTemplate         = "could not apply template: %w"
 	errExecTpl               = "could not execute template: %w"
+	errBuildBundle           = "could not build bundle: %w"
 	errMutate                = "unable to mutate secret %s: %w"
 	errUpdate                = "unable to update secret %s: %w"
 	errUpdateNotFound        = "unable to update secret %s: not found"
 	errDeleteCreatePolicy    = "unable to delete secret %s: creationPolicy=%s is not Owner"
 	errSecretCachesNotSynced = "controller caches for secret %s are not in sync"
+	errUpdateFinalizer       = "could not update finalizers: %w"
+	errProviderOptions       = "error rendering providerOptions %s: %w"
+	errShadowNotOwned        = "shadow secret %s exists and is not owned by this ExternalSecret"
 
 	// event messages.
 	eventCreated                  = "secret created"
 	eventUpdated                  = "secret updated"
 	eventDeleted                  = "secret deleted due to DeletionPolicy=Delete"
 	eventDeletedOrphaned          = "secret deleted because it was orphaned"
+	eventNonUTF8Data              = "values of keys %s are not valid UTF-8, but target.encoding is Text"
 	eventMissingProviderSecret    = "secret does not exist at provider using spec.dataFrom[%d]"
 	eventMissingProviderSecretKey = "secret does not exist at provider using spec.dataFrom[%d] (key=%s)"
 )
@@ -120,8 +196,16 @@ const (
 var (
 	ErrSecretImmutable     = fmt.Errorf("secret is immutable")
 	ErrSecretIsOwned       = fmt.Errorf("secret is owned by another ExternalSecret")
+	ErrSecretOtherIdentity = fmt.Errorf("secret is managed by another controller identity")
+	ErrSecretFieldConflict = fmt.Errorf("secret keys are owned by another field manager")
 	ErrSecretSetCtrlRef    = fmt.Errorf("could not set controller reference on secret")
 	ErrSecretRemoveCtrlRef = fmt.Errorf("could not remove controller reference on secret")
+	ErrSecretParse         = fmt.Errorf("could not parse secret data")
+	ErrSecretDecrypt       = fmt.Errorf("could not decrypt secret data")
+	ErrProviderTimeout     = fmt.Errorf("provider call timed out")
+	ErrDigestMismatch      = fmt.Errorf("value does not match the expected digest")
+	ErrSecretTypeForbidden = fmt.Errorf("secret type is not allowed")
+	ErrManagedSecretsQuota = fmt.Errorf("the controller manages the maximum number of secrets")
 )
 
 const indexESTargetSecretNameField = ".metadata.targetSecretName"
@@ -134,10 +218,40 @@ type Reconciler struct {
 	Scheme                    *runtime.Scheme
 	RestConfig                *rest.Config
 	ControllerClass           string
+	ControllerIdentity        string
+	LabelSelector             labels.Selector
 	RequeueInterval           time.Duration
 	ClusterSecretStoreEnabled bool
 	EnableFloodGate           bool
-	recorder                  record.EventRecorder
+	DisableOwnerReferences    bool
+	// SkipTerminatingNamespaces skips writing the target secret while its namespace is terminating.
+	// It requires get, list and watch on namespaces.
+	SkipTerminatingNamespaces bool
+	// TemplateNamespaceMetadata exposes the metadata of the namespace as `.Namespace` in templates.
+	// It requires get, list and watch on namespaces.
+	TemplateNamespaceMetadata bool
+	// APIReader reads ExternalSecrets that are not in the cache because they do not match the LabelSelector,
+	// so the finalizer of an ExternalSecret that lost its label is still removed. nil disables it.
+	APIReader client.Reader
+	// ReadOnly fetches the provider data and reports the status of every ExternalSecret,
+	// but never creates, updates or deletes a target secret, regardless of the CreationPolicy.
+	ReadOnly bool
+	// AllowedSecretTypes restricts the types of the secrets that are written, every type is allowed if it is empty.
+	AllowedSecretTypes []v1.SecretType
+	// EncryptionHashKey keys the HMAC of the plaintext in status.encryption of ExternalSecrets with target.encryptWith.
+	// Without a key the values are encrypted again on every refresh.
+	EncryptionHashKey []byte
+	// MaxManagedSecrets is the number of managed secrets past which no new secret is created, 0 means no limit.
+	// Existing secrets are still updated.
+	MaxManagedSecrets int
+	// StoreCircuitBreakers skip the provider calls of stores that failed repeatedly, nil disables them.
+	StoreCircuitBreakers *secretstore.CircuitBreakers
+	// StoreWatchers enqueue the ExternalSecrets whose secrets changed in a store with change notifications, nil disables them.
+	StoreWatchers *secretstore.StoreWatchers
+	// NamespaceThrottle limits the concurrent and per-second reconciles of each namespace, nil disables it.
+	NamespaceThrottle *NamespaceThrottle
+	recorder          record.EventRecorder
+	managedSecrets    *managedSecrets
 }
 
 // Reconcile implements the main reconciliation loop
@@ -159,6 +273,10 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ct
 
 	externalSecret := &esv1beta1.ExternalSecret{}
 	err = r.Get(ctx, req.NamespacedName, externalSecret)
+	if apierrors.IsNotFound(err) && r.APIReader != nil {
+		// the cache only holds the ExternalSecrets that match the label selector
+		err = r.APIReader.Get(ctx, req.NamespacedName, externalSecret)
+	}
 	if err != nil {
 		if apierrors.IsNotFound(err) {
 			// NOTE: this does not actually set the condition on the ExternalSecret, because it does not exist
@@ -181,11 +299,38 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ct
 	}
 
 	// skip reconciliation if deletion timestamp is set on external secret
+	// secrets without owner references are not garbage collected, so we delete them before removing our finalizer
 	if !externalSecret.GetDeletionTimestamp().IsZero() {
+		if controllerutil.ContainsFinalizer(externalSecret, externalSecretFinalizer) {
+			// in read-only mode the secrets are retained, they are never deleted
+			if !r.ReadOnly {
+				err = r.deleteOrphanedSecrets(ctx, externalSecret, "")
+			}
+			if err != nil {
+				log.Error(err, logErrorDeleteOwned)
+				syncCallsError.With(resourceLabels).Inc()
+				return ctrl.Result{}, err
+			}
+			controllerutil.RemoveFinalizer(externalSecret, externalSecretFinalizer)
+			if err = r.Update(ctx, externalSecret); err != nil {
+				return ctrl.Result{}, fmt.Errorf(errUpdateFinalizer, err)
+			}
+		}
 		log.V(1).Info("skipping ExternalSecret, it is marked for deletion")
 		return ctrl.Result{}, nil
 	}
 
+	// skip ExternalSecrets that do not match the label selector, they are handled by another controller.
+	// requests from the secret, namespace and store watches bypass the predicate of the ExternalSecret watch.
+	// an ExternalSecret that carries our finalizer is checked again, so the finalizer is removed once it is deleted.
+	if !r.matchesLabelSelector(externalSecret) {
+		log.V(1).Info("skipping ExternalSecret, it does not match the label selector")
+		if controllerutil.ContainsFinalizer(externalSecret, externalSecretFinalizer) {
+			return ctrl.Result{RequeueAfter: r.RequeueInterval}, nil
+		}
+		return ctrl.Result{}, nil
+	}
+
 	// if extended metrics is enabled, refine the time series vector
 	resourceLabels = ctrlmetrics.RefineLabels(resourceLabels, externalSecret.Labels)
 
@@ -230,9 +375,34 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ct
 		return ctrl.Result{}, err
 	}
 
+	// skip ExternalSecrets whose target secret is managed by another controller identity.
+	// the other controller reconciles them, so neither the finalizer nor the status is touched here,
+	// otherwise both controllers would overwrite the status of the ExternalSecret.
+	if secretPartial.UID != "" && isManagedByOtherIdentity(secretPartial, r.ControllerIdentity) {
+		log.V(1).Info("skipping ExternalSecret, its secret is managed by another controller identity",
+			"identity", secretPartial.Labels[esv1beta1.LabelControllerIdentity])
+		return ctrl.Result{}, nil
+	}
+
+	// the finalizer is only needed if the target secret is owned but has no owner reference.
+	// in read-only mode the finalizer is left as it is, as no secret is created.
+	needsFinalizer := r.shouldDisableOwnerReference(externalSecret) && externalSecret.Spec.Target.CreationPolicy == esv1beta1.CreatePolicyOwner
+	if !r.ReadOnly && needsFinalizer != controllerutil.ContainsFinalizer(externalSecret, externalSecretFinalizer) {
+		if needsFinalizer {
+			controllerutil.AddFinalizer(externalSecret, externalSecretFinalizer)
+		} else {
+			controllerutil.RemoveFinalizer(externalSecret, externalSecretFinalizer)
+		}
+		if err = r.Update(ctx, externalSecret); err != nil {
+			syncCallsError.With(resourceLabels).Inc()
+			return ctrl.Result{}, fmt.Errorf(errUpdateFinalizer, err)
+		}
+	}
+
 	// if the secret exists but does not have the "managed" label, add the label
-	// using a PATCH so it is visible in the cache, then requeue immediately
-	if secretPartial.UID != "" && secretPartial.Labels[esv1beta1.LabelManaged] != esv1beta1.LabelManagedValue {
+	// using a PATCH so it is visible in the cache, then requeue immediately.
+	// in read-only mode the secret is not labeled, so it is never seen by the full cache.
+	if !r.ReadOnly && !externalSecret.Spec.ValidateOnly && secretPartial.UID != "" && secretPartial.Labels[esv1beta1.LabelManaged] != esv1beta1.LabelManagedValue {
 		fqdn := fmt.Sprintf(fieldOwnerTemplate, externalSecret.Name)
 		patch := client.MergeFrom(secretPartial.DeepCopy())
 		if secretPartial.Labels == nil {
@@ -265,7 +435,7 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ct
 	// NOTE: this prevents race conditions between the partial and full cache.
 	//       we return an error so we get an exponential backoff if we end up looping,
 	//       for example, during high cluster load and frequent updates to the target secret by other controllers.
-	if secretPartial.UID != existingSecret.UID || secretPartial.ResourceVersion != existingSecret.ResourceVersion {
+	if !r.ReadOnly && !externalSecret.Spec.ValidateOnly && (secretPartial.UID != existingSecret.UID || secretPartial.ResourceVersion != existingSecret.ResourceVersion) {
 		err = fmt.Errorf(errSecretCachesNotSynced, secretName)
 		log.Error(err, logErrorSecretCacheNotSynced, "secretName", secretName, "secretNamespace", externalSecret.Namespace)
 		syncCallsError.With(resourceLabels).Inc()
@@ -276,11 +446,21 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ct
 	// 1. refresh interval is not 0
 	// 2. resource generation of the ExternalSecret has not changed
 	// 3. the last refresh time of the ExternalSecret is within the refresh interval
+	//    OR the refresh policy is OnChange or Manual, so there is no refresh interval
 	// 4. the target secret is valid:
 	//     - it exists
 	//     - it has the correct "managed" label
 	//     - it has the correct "data-hash" annotation
-	if !shouldRefresh(externalSecret) && isSecretValid(existingSecret) {
+	//    OR the CreationPolicy is None or the controller is read-only, so there is no target secret to validate
+	// 5. the metadata of the namespace has not changed, if the templates use it
+	// 6. no secret of the ExternalSecret changed according to the change notifications of its stores
+	// 7. the grace period of no orphaned secret has passed
+	// 8. the disable-sync annotation of the target secret was neither added nor removed
+	// 9. no generated value must be generated again before it expires
+	storeChanged := r.StoreWatchers.TakeChanged(req.NamespacedName)
+	if !shouldRefresh(externalSecret) && (r.skipsSecretWrites(externalSecret) || isSecretValid(existingSecret, externalSecret)) &&
+		!r.namespaceMetadataChanged(ctx, externalSecret, existingSecret) && !storeChanged && !orphansExpired(externalSecret, time.Now()) &&
+		!r.syncDisabledChanged(externalSecret, secretPartial) && !generatorRefreshDue(externalSecret, time.Now()) {
 		log.V(1).Info("skipping refresh")
 		return r.getRequeueResult(externalSecret), nil
 	}
@@ -324,13 +504,154 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ct
 		}
 	}()
 
+	// while the target secret has the disable-sync annotation it is never written and the providers are not called,
+	// orphaned secrets of a previous target are still cleaned up.
+	// NOTE: removing the annotation triggers a reconcile through the secret watch, so the sync resumes immediately.
+	if r.shouldSkipSync(externalSecret, secretPartial) {
+		log.V(1).Info("skipping sync, secret has the disable-sync annotation", "secretName", secretName)
+		if externalSecret.Spec.Target.CreationPolicy == esv1beta1.CreatePolicyOwner {
+			if err = r.deleteOrphanedSecrets(ctx, externalSecret, secretName); err != nil {
+				r.markAsFailed(msgErrorDeleteOrphaned, err, externalSecret, syncCallsError.With(resourceLabels))
+				return ctrl.Result{}, err
+			}
+		}
+		r.markAsSyncDisabled(externalSecret)
+		return r.getRequeueResult(externalSecret), nil
+	}
+	r.clearSyncDisabled(externalSecret)
+
+	// the API server rejects new secrets in a terminating namespace, we skip the sync
+	// instead of failing on every reconcile until the namespace is deleted.
+	// NOTE: this is not an error, so we only check again after the refresh interval.
+	if r.SkipTerminatingNamespaces && !r.skipsSecretWrites(externalSecret) {
+		terminating, err := r.isNamespaceTerminating(ctx, externalSecret.Namespace)
+		if err != nil {
+			// the namespace lookup is best effort, the sync continues and reports any write error
+			log.Error(err, logErrorGetNamespace, "namespace", externalSecret.Namespace)
+		}
+		if terminating {
+			log.V(1).Info("skipping sync, namespace is terminating")
+			r.markAsNamespaceTerminating(externalSecret)
+			return r.getRequeueResult(externalSecret), nil
+		}
+	}
+
+	// wait for the ExternalSecrets of spec.dependsOn to be ready.
+	// NOTE: a dependency that is not ready is expected during bootstrapping and is not counted as a failure,
+	//       a cycle can only be fixed by changing the spec, so we only check again after the refresh interval.
+	err = r.checkDependencies(ctx, externalSecret)
+	switch {
+	case errors.Is(err, ErrDependencyNotReady):
+		r.markAsDependencyBlocked(err, externalSecret, v1.EventTypeNormal, esv1beta1.ConditionReasonDependencyNotReady, msgDependencyNotReady)
+		return ctrl.Result{RequeueAfter: dependencyNotReadyRequeueInterval}, nil
+	case errors.Is(err, ErrDependencyCycle):
+		r.markAsDependencyBlocked(err, externalSecret, v1.EventTypeWarning, esv1beta1.ConditionReasonDependencyCycle, msgDependencyCycle)
+		return r.getRequeueResult(externalSecret), nil
+	case err != nil:
+		syncCallsError.With(resourceLabels).Inc()
+		return ctrl.Result{}, err
+	}
+
+	// collect the metadata reported by the providers, if it is written to the secret
+	var providerMetadata *esv1beta1.ProviderMetadata
+	if len(externalSecret.Spec.Target.MetadataAnnotations) > 0 {
+		ctx, providerMetadata = esv1beta1.ContextWithProviderMetadata(ctx)
+	}
+	// collect the tags of the secrets, if they are written to the labels of the secret
+	var tags *providerTags
+	if externalSecret.Spec.Target.CopyProviderTagsToLabels {
+		// providers without tag support would return the secret value instead of the tags,
+		// so the secret is not synced until every store can read tags
+		if err := r.checkProviderTagStores(ctx, externalSecret); err != nil {
+			r.markAsFailed(msgErrorProviderTags, err, externalSecret, syncCallsError.With(resourceLabels))
+			if errors.Is(err, errProviderTagsUnsupported) {
+				return r.getRequeueResult(externalSecret), nil
+			}
+			return ctrl.Result{}, err
+		}
+		ctx, tags = contextWithProviderTags(ctx)
+	}
+
 	// retrieve the provider secret data.
 	dataMap, err := r.getProviderSecretData(ctx, externalSecret)
+	var storeUnavailable *secretstore.StoreUnavailableError
+	if errors.As(err, &storeUnavailable) {
+		// the error is not returned to skip the rate limited retries,
+		// the provider is called again once the cooldown of the store is over
+		r.markAsStoreUnavailable(err, externalSecret, syncCallsError.With(resourceLabels))
+		if onErr := r.applyOnErrorPolicy(ctx, externalSecret, existingSecret); onErr != nil {
+			log.Error(onErr, logErrorOnError)
+		}
+		return ctrl.Result{RequeueAfter: storeUnavailable.RetryAfter}, nil
+	}
+	var storeDenied *secretstore.StoreDeniedError
+	if errors.As(err, &storeDenied) {
+		// retrying does not help until the store or the ExternalSecret changes,
+		// so the store is only checked again after the refresh interval
+		r.markAsStoreDenied(err, externalSecret, syncCallsError.With(resourceLabels))
+		if onErr := r.applyOnErrorPolicy(ctx, externalSecret, existingSecret); onErr != nil {
+			log.Error(onErr, logErrorOnError)
+		}
+		return r.getRequeueResult(externalSecret), nil
+	}
+	if errors.Is(err, resolvers.ErrGeneratorNotReady) {
+		// the secret is not synced until the generator becomes ready,
+		// this is expected while the generator is being set up and is not counted as a failure.
+		r.markAsGeneratorNotReady(err, externalSecret)
+		return ctrl.Result{RequeueAfter: generatorNotReadyRequeueInterval}, nil
+	}
+	if errors.Is(err, ErrDigestMismatch) {
+		// the value is not synced until the digest is updated,
+		// it is checked again after the refresh interval.
+		r.markAsDigestMismatch(err, externalSecret, syncCallsError.With(resourceLabels))
+		return r.getRequeueResult(externalSecret), nil
+	}
 	if err != nil {
-		r.markAsFailed(msgErrorGetSecretData, err, externalSecret, syncCallsError.With(resourceLabels))
+		msg := msgErrorGetSecretData
+		if errors.Is(err, ErrSecretParse) {
+			msg = msgErrorParseSecretData
+		}
+		if errors.Is(err, ErrSecretDecrypt) {
+			msg = msgErrorDecryptSecret
+		}
+		r.markAsFailed(msg, err, externalSecret, syncCallsError.With(resourceLabels))
+		if onErr := r.applyOnErrorPolicy(ctx, externalSecret, existingSecret); onErr != nil {
+			log.Error(onErr, logErrorOnError)
+		}
 		return ctrl.Result{}, err
 	}
 
+	// warn while the template uses the deprecated v1 engine
+	r.reportTemplateEngine(ctx, externalSecret)
+
+	// with validateOnly every entry is fetched and its result is reported in status.sources,
+	// the target secret is never created, updated or deleted.
+	if externalSecret.Spec.ValidateOnly {
+		log.V(1).Info("secret write skipped due to validateOnly")
+		r.markAsValidated(externalSecret, start, log)
+		return r.getRequeueResult(externalSecret), nil
+	}
+
+	// with CreationPolicy=None we only report the provider health,
+	// the target secret is never created, updated or deleted.
+	if isCreationPolicyNone(externalSecret) {
+		log.V(1).Info("secret creation skipped due to CreationPolicy=None")
+		r.syncShadow(ctx, externalSecret, dataMap)
+		r.markAsDone(externalSecret, start, log, esv1beta1.ConditionReasonSecretSynced, msgSyncedNone)
+		return r.getRequeueResult(externalSecret), nil
+	}
+
+	// in read-only mode the provider data is fetched and reported in the same way,
+	// but the target secret is never created, updated or deleted.
+	if r.ReadOnly {
+		log.V(1).Info("secret write skipped due to read-only mode")
+		// orphaned secrets are retained as well, so there is nothing to track
+		externalSecret.Status.OrphanedSecrets = nil
+		r.syncShadow(ctx, externalSecret, dataMap)
+		r.markAsDone(externalSecret, start, log, esv1beta1.ConditionReasonSecretSynced, msgSyncedReadOnly)
+		return r.getRequeueResult(externalSecret), nil
+	}
+
 	// if no data was found we can delete the secret if needed.
 	if len(dataMap) == 0 {
 		switch externalSecret.Spec.Target.DeletionPolicy {
@@ -348,6 +669,11 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ct
 
 			// delete the secret, if it exists
 			if existingSecret.UID != "" {
+				if isManagedByOtherIdentity(existingSecret, r.ControllerIdentity) {
+					err = fmt.Errorf("%w: %s", ErrSecretOtherIdentity, existingSecret.Labels[esv1beta1.LabelControllerIdentity])
+					r.markAsFailed(msgErrorOtherIdentity, err, externalSecret, syncCallsError.With(resourceLabels))
+					return ctrl.Result{}, nil
+				}
 				err = r.Delete(ctx, existingSecret)
 				if err != nil && !apierrors.IsNotFound(err) {
 					r.markAsFailed(msgErrorDeleteSecret, err, externalSecret, syncCallsError.With(resourceLabels))
@@ -388,17 +714,25 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ct
 			return fmt.Errorf("%w: %s", ErrSecretIsOwned, currentOwner.Name)
 		}
 
+		// if another controller instance manages the secret, we should not touch it,
+		// this lets multiple installations with a different --controller-identity coexist.
+		if isManagedByOtherIdentity(secret, r.ControllerIdentity) {
+			return fmt.Errorf("%w: %s", ErrSecretOtherIdentity, secret.Labels[esv1beta1.LabelControllerIdentity])
+		}
+
 		// if the CreationPolicy is Owner, we should set ourselves as the owner of the secret
-		if externalSecret.Spec.Target.CreationPolicy == esv1beta1.CreatePolicyOwner {
+		// unless owner references are disabled, then the owner label and finalizer are used instead
+		setOwnerReference := externalSecret.Spec.Target.CreationPolicy == esv1beta1.CreatePolicyOwner && !r.shouldDisableOwnerReference(externalSecret)
+		if setOwnerReference {
 			err = controllerutil.SetControllerReference(externalSecret, secret, r.Scheme)
 			if err != nil {
 				return fmt.Errorf("%w: %w", ErrSecretSetCtrlRef, err)
 			}
 		}
 
-		// if the creation policy is not Owner, we should remove ourselves as the owner
+		// if we should not be the owner, we should remove ourselves as the owner
 		// this could happen if the creation policy was changed after the secret was created
-		if externalSecret.Spec.Target.CreationPolicy != esv1beta1.CreatePolicyOwner && ownerIsCurrentES {
+		if !setOwnerReference && ownerIsCurrentES {
 			err = controllerutil.RemoveControllerReference(externalSecret, secret, r.Scheme)
 			if err != nil {
 				return fmt.Errorf("%w: %w", ErrSecretRemoveCtrlRef, err)
@@ -416,6 +750,14 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ct
 			secret.Data = make(map[string][]byte)
 		}
 
+		// keep the current data, so templates can compute new keys from the previous values
+		previous := maps.Clone(secret.Data)
+		previousNonUTF8Keys := secret.Annotations[esv1beta1.AnnotationNonUTF8Keys]
+		previousSensitiveKeys := map[string]string{
+			esv1beta1.AnnotationSensitiveKeys: secret.Annotations[esv1beta1.AnnotationSensitiveKeys],
+			esv1beta1.AnnotationEncryptedKeys: secret.Annotations[esv1beta1.AnnotationEncryptedKeys],
+		}
+
 		// get the list of keys that are managed by this ExternalSecret
 		keys, err := getManagedDataKeys(secret, externalSecret.Name)
 		if err != nil {
@@ -428,13 +770,68 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ct
 			delete(secret.Data, key)
 		}
 
+		// remove the labels of the provider tags of the previous sync, they are written again below
+		removeProviderTagLabels(secret)
+
 		// WARNING: this will remove any labels or annotations managed by this ExternalSecret
 		//          so any updates to labels and annotations should be done AFTER this point
-		err = r.applyTemplate(ctx, externalSecret, secret, dataMap)
+		err = r.applyTemplate(ctx, externalSecret, secret, dataMap, previous)
 		if err != nil {
 			return fmt.Errorf(errApplyTemplate, err)
 		}
 
+		// write the bundle after the template, so it takes precedence over templated keys
+		if bundle := externalSecret.Spec.Target.Bundle; bundle != nil {
+			value, err := buildBundle(bundle, dataMap)
+			if err != nil {
+				return fmt.Errorf(errBuildBundle, err)
+			}
+			if secret.Data == nil {
+				secret.Data = make(map[string][]byte)
+			}
+			secret.Data[bundle.Key] = value
+		}
+
+		// truncate the values of sizeLimits.truncateKeys while they are plaintext, the size is checked after the encryption
+		truncateSecretValues(externalSecret.Spec.Target.SizeLimits, secret.Data)
+
+		// the type is final once the template was applied
+		if err := esv1beta1.CheckSecretTypeAllowed(r.AllowedSecretTypes, secret.Type); err != nil {
+			return fmt.Errorf("%w: %w", ErrSecretTypeForbidden, err)
+		}
+
+		// check the keys required by the type of the secret,
+		// with StrictMerge the data only holds the keys of this ExternalSecret
+		if externalSecret.Spec.Target.CreationPolicy != esv1beta1.CreatePolicyStrictMerge {
+			if err := validateSecretType(secret); err != nil {
+				return err
+			}
+		}
+
+		// flag values that are not valid UTF-8 if the target holds text,
+		// this is only a warning so binary data does not break the sync
+		r.flagNonUTF8Keys(externalSecret, secret, previousNonUTF8Keys)
+
+		// list the keys marked in spec.data[].sensitivity for downstream tooling
+		annotateSensitiveKeys(externalSecret, secret, previousSensitiveKeys)
+
+		// stamp the provider metadata selected in target.metadataAnnotations
+		annotateProviderMetadata(externalSecret, secret, providerMetadata.Get())
+
+		// mirror the provider tags for target.copyProviderTagsToLabels, labels set above take precedence
+		r.labelProviderTags(externalSecret, secret, tags.Get())
+
+		// encrypt the values for target.encryptWith, all checks above operate on the plaintext
+		if err := encryptSecretData(externalSecret, secret, previous, r.EncryptionHashKey); err != nil {
+			return err
+		}
+
+		// check the size of the final data, so an oversized value is reported with its key
+		// instead of being rejected by the API server
+		if err := limitSecretSize(externalSecret.Spec.Target.SizeLimits, secret.Data); err != nil {
+			return err
+		}
+
 		// set the immutable flag on the secret if requested by the ExternalSecret
 		if externalSecret.Spec.Target.Immutable {
 			secret.Immutable = ptr.To(true)
@@ -450,6 +847,9 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ct
 			delete(secret.Labels, esv1beta1.LabelOwner)
 		}
 
+		if r.ControllerIdentity != "" {
+			secret.Labels[esv1beta1.LabelControllerIdentity] = r.ControllerIdentity
+		}
 		secret.Labels[esv1beta1.LabelManaged] = esv1beta1.LabelManagedValue
 		secret.Annotations[esv1beta1.AnnotationDataHash] = utils.ObjectHash(secret.Data)
 
@@ -457,9 +857,8 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ct
 	}
 
 	switch externalSecret.Spec.Target.CreationPolicy {
+	// noop, handled above
 	case esv1beta1.CreatePolicyNone:
-		log.V(1).Info("secret creation skipped due to CreationPolicy=None")
-		err = nil
 	case esv1beta1.CreatePolicyMerge:
 		// update the secret, if it exists
 		if existingSecret.UID != "" {
@@ -470,6 +869,14 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ct
 			r.markAsDone(externalSecret, start, log, esv1beta1.ConditionReasonSecretMissing, msgMissing)
 			return r.getRequeueResult(externalSecret), nil
 		}
+	case esv1beta1.CreatePolicyStrictMerge:
+		// apply only our keys to the secret, if it exists
+		if existingSecret.UID != "" {
+			err = r.applySecret(ctx, existingSecret, mutationFunc, externalSecret, secretName)
+		} else {
+			r.markAsDone(externalSecret, start, log, esv1beta1.ConditionReasonSecretMissing, msgMissing)
+			return r.getRequeueResult(externalSecret), nil
+		}
 	case esv1beta1.CreatePolicyOrphan:
 		// create the secret, if it does not exist
 		if existingSecret.UID == "" {
@@ -496,6 +903,13 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ct
 		}
 	}
 	if err != nil {
+		// detect keys of the secret that are owned by another field manager, with CreationPolicy=StrictMerge we never take them over
+		// NOTE: this must be checked before update conflicts, as retrying immediately does not resolve it
+		if errors.Is(err, ErrSecretFieldConflict) {
+			r.markAsFailed(msgErrorFieldConflict, err, externalSecret, syncCallsError.With(resourceLabels))
+			return r.getRequeueResult(externalSecret), nil
+		}
+
 		// if we got an update conflict, we should requeue immediately
 		if apierrors.IsConflict(err) {
 			log.V(1).Info("conflict while updating secret, will requeue")
@@ -516,6 +930,34 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ct
 			return ctrl.Result{}, nil
 		}
 
+		// detect errors indicating that the secret is managed by another controller identity
+		// NOTE: this error cant be fixed by retrying so we don't return an error (which would requeue immediately)
+		if errors.Is(err, ErrSecretOtherIdentity) {
+			r.markAsFailed(msgErrorOtherIdentity, err, externalSecret, syncCallsError.With(resourceLabels))
+			return ctrl.Result{}, nil
+		}
+
+		// detect values or data exceeding the size limits, the condition names the offending key and its size
+		// NOTE: this error cant be fixed by retrying so we don't return an error (which would requeue immediately)
+		var sizeErr *SecretSizeError
+		if errors.As(err, &sizeErr) {
+			r.markAsFailed(fmt.Sprintf(msgErrorSecretTooLarge, sizeErr), err, externalSecret, syncCallsError.With(resourceLabels))
+			return r.getRequeueResult(externalSecret), nil
+		}
+
+		// detect secret types that are not in --allowed-secret-types
+		// NOTE: this error cant be fixed by retrying so we don't return an error (which would requeue immediately)
+		if errors.Is(err, ErrSecretTypeForbidden) {
+			r.markAsFailed(msgErrorTypeNotAllowed, err, externalSecret, syncCallsError.With(resourceLabels))
+			return ctrl.Result{}, nil
+		}
+
+		// detect new secrets that exceed --max-managed-secrets, they are created once the count drops
+		if errors.Is(err, ErrManagedSecretsQuota) {
+			r.markAsQuotaExceeded(err, externalSecret)
+			return r.getRequeueResult(externalSecret), nil
+		}
+
 		// detect errors indicating that the secret is immutable
 		// NOTE: this error cant be fixed by retrying so we don't return an error (which would requeue immediately)
 		if errors.Is(err, ErrSecretImmutable) {
@@ -527,12 +969,22 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ct
 		return ctrl.Result{}, err
 	}
 
+	// the shadow is synced after the target secret, so it never delays or fails it
+	r.syncShadow(ctx, externalSecret, dataMap)
+
 	r.markAsDone(externalSecret, start, log, esv1beta1.ConditionReasonSecretSynced, msgSynced)
 	return r.getRequeueResult(externalSecret), nil
 }
 
 // getRequeueResult create a result with requeueAfter based on the ExternalSecret refresh interval.
+// it requeues earlier if the grace period of an orphaned secret ends or generated values must be
+// generated again before the next refresh.
 func (r *Reconciler) getRequeueResult(externalSecret *esv1beta1.ExternalSecret) ctrl.Result {
+	return requeueForGenerators(externalSecret, requeueForOrphans(externalSecret, r.getRefreshResult(externalSecret)))
+}
+
+// getRefreshResult create a result with requeueAfter based on the ExternalSecret refresh interval.
+func (r *Reconciler) getRefreshResult(externalSecret *esv1beta1.ExternalSecret) ctrl.Result {
 	// default to the global requeue interval
 	// note, this will never be used because the CRD has a default value of 1 hour
 	refreshInterval := r.RequeueInterval
@@ -540,8 +992,8 @@ func (r *Reconciler) getRequeueResult(externalSecret *esv1beta1.ExternalSecret)
 		refreshInterval = externalSecret.Spec.RefreshInterval.Duration
 	}
 
-	// if the refresh interval is <= 0, we should not requeue
-	if refreshInterval <= 0 {
+	// if the refresh interval is <= 0 or the refresh is not periodic, we should not requeue
+	if refreshInterval <= 0 || !isRefreshPeriodic(externalSecret) {
 		return ctrl.Result{}
 	}
 
@@ -571,9 +1023,16 @@ func (r *Reconciler) getRequeueResult(externalSecret *esv1beta1.ExternalSecret)
 }
 
 func (r *Reconciler) markAsDone(externalSecret *esv1beta1.ExternalSecret, start time.Time, log logr.Logger, reason, msg string) {
+	// entries with continueOnError that failed are left out of the secret
+	if failed := countFailedSources(externalSecret.Status.Sources); failed > 0 && reason == esv1beta1.ConditionReasonSecretSynced {
+		reason = esv1beta1.ConditionReasonSecretPartiallySynced
+		msg = fmt.Sprintf(msgPartiallySynced, failed)
+	}
+
 	oldReadyCondition := GetExternalSecretCondition(externalSecret.Status, esv1beta1.ExternalSecretReady)
 	newReadyCondition := NewExternalSecretCondition(esv1beta1.ExternalSecretReady, v1.ConditionTrue, reason, msg)
 	SetExternalSecretCondition(externalSecret, *newReadyCondition)
+	externalSecret.Status.LastError = nil
 
 	externalSecret.Status.RefreshTime = metav1.NewTime(start)
 	externalSecret.Status.SyncedResourceVersion = getResourceVersion(externalSecret)
@@ -590,13 +1049,104 @@ func (r *Reconciler) markAsDone(externalSecret *esv1beta1.ExternalSecret, start
 	}
 }
 
+// markAsValidated sets the Ready condition of an ExternalSecret with validateOnly,
+// it is False if any entry could not be read.
+func (r *Reconciler) markAsValidated(externalSecret *esv1beta1.ExternalSecret, start time.Time, log logr.Logger) {
+	total := len(externalSecret.Spec.Data) + len(externalSecret.Spec.DataFrom)
+	failed := countFailedSources(externalSecret.Status.Sources)
+	if failed == 0 {
+		r.markAsDone(externalSecret, start, log, esv1beta1.ConditionReasonValidated, fmt.Sprintf(msgValidated, total))
+		return
+	}
+
+	msg := fmt.Sprintf(msgValidationFailed, failed, total)
+	if cond := GetExternalSecretCondition(externalSecret.Status, esv1beta1.ExternalSecretReady); cond == nil || cond.Reason != esv1beta1.ConditionReasonValidationFailed || cond.Message != msg {
+		r.recorder.Event(externalSecret, v1.EventTypeWarning, esv1beta1.ConditionReasonValidationFailed, msg)
+	}
+	conditionSynced := NewExternalSecretCondition(esv1beta1.ExternalSecretReady, v1.ConditionFalse, esv1beta1.ConditionReasonValidationFailed, msg)
+	SetExternalSecretCondition(externalSecret, *conditionSynced)
+	externalSecret.Status.LastError = nil
+	externalSecret.Status.RefreshTime = metav1.NewTime(start)
+	externalSecret.Status.SyncedResourceVersion = getResourceVersion(externalSecret)
+}
+
+func countFailedSources(sources []esv1beta1.ExternalSecretSourceStatus) int {
+	failed := 0
+	for _, source := range sources {
+		if source.Error != "" {
+			failed++
+		}
+	}
+	return failed
+}
+
 func (r *Reconciler) markAsFailed(msg string, err error, externalSecret *esv1beta1.ExternalSecret, counter prometheus.Counter) {
-	r.recorder.Event(externalSecret, v1.EventTypeWarning, esv1beta1.ReasonUpdateFailed, err.Error())
-	conditionSynced := NewExternalSecretCondition(esv1beta1.ExternalSecretReady, v1.ConditionFalse, esv1beta1.ConditionReasonSecretSyncedError, msg)
+	r.recorder.Event(externalSecret, v1.EventTypeWarning, esv1beta1.ReasonUpdateFailed, redactRemoteKeys(externalSecret, err.Error()))
+	reason, msg := failedCondition(externalSecret, msg, err)
+	conditionSynced := NewExternalSecretCondition(esv1beta1.ExternalSecretReady, v1.ConditionFalse, reason, msg)
 	SetExternalSecretCondition(externalSecret, *conditionSynced)
+	setLastError(externalSecret, reason, err)
 	counter.Inc()
 }
 
+func (r *Reconciler) markAsStoreUnavailable(err error, externalSecret *esv1beta1.ExternalSecret, counter prometheus.Counter) {
+	r.recorder.Event(externalSecret, v1.EventTypeWarning, esv1beta1.ReasonUpdateFailed, redactRemoteKeys(externalSecret, err.Error()))
+	conditionSynced := NewExternalSecretCondition(esv1beta1.ExternalSecretReady, v1.ConditionFalse, esv1beta1.ConditionReasonStoreUnavailable, msgStoreUnavailable)
+	SetExternalSecretCondition(externalSecret, *conditionSynced)
+	setLastError(externalSecret, esv1beta1.ConditionReasonStoreUnavailable, err)
+	counter.Inc()
+}
+
+func (r *Reconciler) markAsStoreDenied(err error, externalSecret *esv1beta1.ExternalSecret, counter prometheus.Counter) {
+	r.recorder.Event(externalSecret, v1.EventTypeWarning, esv1beta1.ReasonUpdateFailed, redactRemoteKeys(externalSecret, err.Error()))
+	conditionSynced := NewExternalSecretCondition(esv1beta1.ExternalSecretReady, v1.ConditionFalse, esv1beta1.ConditionReasonStoreDenied, msgStoreDenied)
+	SetExternalSecretCondition(externalSecret, *conditionSynced)
+	setLastError(externalSecret, esv1beta1.ConditionReasonStoreDenied, err)
+	counter.Inc()
+}
+
+func (r *Reconciler) markAsDigestMismatch(err error, externalSecret *esv1beta1.ExternalSecret, counter prometheus.Counter) {
+	r.recorder.Event(externalSecret, v1.EventTypeWarning, esv1beta1.ReasonUpdateFailed, redactRemoteKeys(externalSecret, err.Error()))
+	conditionSynced := NewExternalSecretCondition(esv1beta1.ExternalSecretReady, v1.ConditionFalse, esv1beta1.ConditionReasonDigestMismatch, msgDigestMismatch)
+	SetExternalSecretCondition(externalSecret, *conditionSynced)
+	setLastError(externalSecret, esv1beta1.ConditionReasonDigestMismatch, err)
+	counter.Inc()
+}
+
+func (r *Reconciler) markAsGeneratorNotReady(err error, externalSecret *esv1beta1.ExternalSecret) {
+	r.recorder.Event(externalSecret, v1.EventTypeNormal, esv1beta1.ReasonGeneratorNotReady, redactRemoteKeys(externalSecret, err.Error()))
+	conditionSynced := NewExternalSecretCondition(esv1beta1.ExternalSecretReady, v1.ConditionFalse, esv1beta1.ConditionReasonGeneratorNotReady, msgGeneratorNotReady)
+	SetExternalSecretCondition(externalSecret, *conditionSynced)
+}
+
+func (r *Reconciler) markAsDependencyBlocked(err error, externalSecret *esv1beta1.ExternalSecret, eventType, reason, msg string) {
+	msg = redactRemoteKeys(externalSecret, fmt.Sprintf(msg, err))
+	conditionSynced := NewExternalSecretCondition(esv1beta1.ExternalSecretReady, v1.ConditionFalse, reason, msg)
+	// only record an event when the condition changes, as dependencies are checked again every few seconds
+	if cond := GetExternalSecretCondition(externalSecret.Status, esv1beta1.ExternalSecretReady); cond == nil || cond.Reason != reason || cond.Message != msg {
+		r.recorder.Event(externalSecret, eventType, reason, msg)
+	}
+	SetExternalSecretCondition(externalSecret, *conditionSynced)
+}
+
+func (r *Reconciler) markAsNamespaceTerminating(externalSecret *esv1beta1.ExternalSecret) {
+	conditionSynced := NewExternalSecretCondition(esv1beta1.ExternalSecretReady, v1.ConditionFalse, esv1beta1.ConditionReasonNamespaceTerminating, msgNamespaceTerminating)
+	// only record an event when the condition changes, so a terminating namespace does not spam events
+	if cond := GetExternalSecretCondition(externalSecret.Status, esv1beta1.ExternalSecretReady); cond == nil || cond.Reason != conditionSynced.Reason {
+		r.recorder.Event(externalSecret, v1.EventTypeNormal, esv1beta1.ReasonNamespaceTerminating, msgNamespaceTerminating)
+	}
+	SetExternalSecretCondition(externalSecret, *conditionSynced)
+}
+
+// shouldDisableOwnerReference returns true if the target secret must not have an owner reference,
+// either because it is disabled for the whole controller or for this ExternalSecret.
+func (r *Reconciler) shouldDisableOwnerReference(externalSecret *esv1beta1.ExternalSecret) bool {
+	return r.DisableOwnerReferences || externalSecret.Spec.Target.DisableOwnerReference
+}
+
+// deleteOrphanedSecrets deletes all secrets with the owner label of the ExternalSecret, except secretName.
+// an empty secretName deletes all of them.
+// with target.orphanGracePeriod the secrets are kept in status.orphanedSecrets until the period has passed.
 func (r *Reconciler) deleteOrphanedSecrets(ctx context.Context, externalSecret *esv1beta1.ExternalSecret, secretName string) error {
 	ownerLabel := utils.ObjectHash(fmt.Sprintf("%v/%v", externalSecret.Namespace, externalSecret.Name))
 
@@ -615,8 +1165,20 @@ func (r *Reconciler) deleteOrphanedSecrets(ctx context.Context, externalSecret *
 	}
 
 	// delete all secrets that are not the target secret
+	now := time.Now()
+	var kept []esv1beta1.ExternalSecretOrphanedSecret
 	for _, secretPartial := range secretListPartial.Items {
+		if isManagedByOtherIdentity(&secretPartial, r.ControllerIdentity) {
+			continue
+		}
 		if secretPartial.GetName() != secretName {
+			// the grace period does not apply when the ExternalSecret itself is deleted
+			if secretName != "" {
+				if orphan, keep := keepOrphan(externalSecret, secretPartial.GetName(), now); keep {
+					kept = append(kept, orphan)
+					continue
+				}
+			}
 			err := r.Delete(ctx, &secretPartial)
 			if err != nil && !apierrors.IsNotFound(err) {
 				return err
@@ -624,6 +1186,9 @@ func (r *Reconciler) deleteOrphanedSecrets(ctx context.Context, externalSecret *
 			r.recorder.Event(externalSecret, v1.EventTypeNormal, esv1beta1.ReasonDeleted, eventDeletedOrphaned)
 		}
 	}
+	if secretName != "" {
+		r.recordOrphans(externalSecret, kept)
+	}
 
 	return nil
 }
@@ -632,6 +1197,13 @@ func (r *Reconciler) deleteOrphanedSecrets(ctx context.Context, externalSecret *
 func (r *Reconciler) createSecret(ctx context.Context, mutationFunc func(secret *v1.Secret) error, es *esv1beta1.ExternalSecret, secretName string) error {
 	fqdn := fmt.Sprintf(fieldOwnerTemplate, es.Name)
 
+	release, err := r.reserveManagedSecret(types.NamespacedName{Name: secretName, Namespace: es.Namespace})
+	if err != nil {
+		return err
+	}
+	created := false
+	defer func() { release(created) }()
+
 	// define and mutate the new secret
 	newSecret := &v1.Secret{
 		ObjectMeta: metav1.ObjectMeta{
@@ -648,6 +1220,7 @@ func (r *Reconciler) createSecret(ctx context.Context, mutationFunc func(secret
 	if err := r.Create(ctx, newSecret, client.FieldOwner(fqdn)); err != nil {
 		return err
 	}
+	created = true
 
 	// set the binding reference to the secret
 	// https://github.com/external-secrets/external-secrets/pull/2263
@@ -733,6 +1306,125 @@ func (r *Reconciler) updateSecret(ctx context.Context, existingSecret *v1.Secret
 	return nil
 }
 
+// applySecret applies only the data keys of the ExternalSecret to an existing secret using server-side apply.
+// The apply is never forced, so keys owned by another field manager are not taken over and fail with ErrSecretFieldConflict.
+// All other fields of the secret, including its type, are left to their owners.
+func (r *Reconciler) applySecret(ctx context.Context, existingSecret *v1.Secret, mutationFunc func(secret *v1.Secret) error, es *esv1beta1.ExternalSecret, secretName string) error {
+	fqdn := fmt.Sprintf(fieldOwnerTemplate, es.Name)
+
+	// fail if the secret does not exist
+	// this should never happen because we check this before calling this function
+	if existingSecret.UID == "" {
+		return fmt.Errorf(errUpdateNotFound, secretName)
+	}
+
+	// set the binding reference to the secret
+	// https://github.com/external-secrets/external-secrets/pull/2263
+	es.Status.Binding = v1.LocalObjectReference{Name: secretName}
+
+	// mutate a copy of the existing secret to get our keys,
+	// this also checks that the secret is not managed by another ExternalSecret or controller identity.
+	// NOTE: with CreationPolicy=StrictMerge the mutated data only contains the keys of the ExternalSecret
+	mutatedSecret := existingSecret.DeepCopy()
+	if err := mutationFunc(mutatedSecret); err != nil {
+		return fmt.Errorf(errMutate, mutatedSecret.Name, err)
+	}
+
+	// only the keys and the labels and annotations that keep track of the secret are applied
+	appliedSecret := &v1.Secret{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: v1.SchemeGroupVersion.String(),
+			Kind:       "Secret",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      secretName,
+			Namespace: es.Namespace,
+			Labels: map[string]string{
+				esv1beta1.LabelManaged: esv1beta1.LabelManagedValue,
+			},
+			Annotations: map[string]string{
+				esv1beta1.AnnotationDataHash: utils.ObjectHash(mutatedSecret.Data),
+			},
+		},
+		Data: mutatedSecret.Data,
+	}
+	if r.ControllerIdentity != "" {
+		appliedSecret.Labels[esv1beta1.LabelControllerIdentity] = r.ControllerIdentity
+	}
+	if hash, ok := mutatedSecret.Annotations[esv1beta1.AnnotationNamespaceHash]; ok {
+		appliedSecret.Annotations[esv1beta1.AnnotationNamespaceHash] = hash
+	}
+	for _, key := range es.Spec.Target.MetadataAnnotations {
+		if value, ok := mutatedSecret.Annotations[esv1beta1.AnnotationProviderMetadataPrefix+key]; ok {
+			appliedSecret.Annotations[esv1beta1.AnnotationProviderMetadataPrefix+key] = value
+		}
+	}
+	if tagLabels, ok := mutatedSecret.Annotations[esv1beta1.AnnotationProviderTagLabels]; ok {
+		appliedSecret.Annotations[esv1beta1.AnnotationProviderTagLabels] = tagLabels
+		for _, key := range strings.Split(tagLabels, ",") {
+			appliedSecret.Labels[key] = mutatedSecret.Labels[key]
+		}
+	}
+
+	// if the secret does not need to be updated, return early
+	managedKeys, err := getManagedDataKeys(existingSecret, es.Name)
+	if err != nil {
+		return err
+	}
+	dataChanged := appliedDataChanged(existingSecret, appliedSecret.Data, managedKeys)
+	if !dataChanged && !appliedMetadataChanged(existingSecret, appliedSecret) {
+		return nil
+	}
+
+	// the data of an immutable secret can not be changed
+	if dataChanged && ptr.Deref(existingSecret.Immutable, false) {
+		return fmt.Errorf(errUpdate, secretName, ErrSecretImmutable)
+	}
+
+	// NOTE: without client.ForceOwnership the API server rejects the apply if another manager owns a conflicting field
+	if err := r.Patch(ctx, appliedSecret, client.Apply, client.FieldOwner(fqdn)); err != nil {
+		if apierrors.IsConflict(err) {
+			return fmt.Errorf("%w: %w", ErrSecretFieldConflict, err)
+		}
+		return fmt.Errorf(errUpdate, secretName, err)
+	}
+
+	r.recorder.Event(es, v1.EventTypeNormal, esv1beta1.ReasonUpdated, eventUpdated)
+	return nil
+}
+
+// appliedDataChanged returns true if the applied data differs from the secret,
+// or if a key that is managed by the ExternalSecret is no longer applied.
+func appliedDataChanged(secret *v1.Secret, data map[string][]byte, managedKeys []string) bool {
+	for key, value := range data {
+		current, ok := secret.Data[key]
+		if !ok || !bytes.Equal(current, value) {
+			return true
+		}
+	}
+	for _, key := range managedKeys {
+		if _, ok := data[key]; !ok {
+			return true
+		}
+	}
+	return false
+}
+
+// appliedMetadataChanged returns true if a label or annotation of the applied secret differs from the secret.
+func appliedMetadataChanged(secret, appliedSecret *v1.Secret) bool {
+	for key, value := range appliedSecret.Labels {
+		if secret.Labels[key] != value {
+			return true
+		}
+	}
+	for key, value := range appliedSecret.Annotations {
+		if secret.Annotations[key] != value {
+			return true
+		}
+	}
+	return false
+}
+
 // getManagedDataKeys returns the list of data keys in a secret which are managed by a specified owner.
 func getManagedDataKeys(secret *v1.Secret, fieldOwner string) ([]string, error) {
 	return getManagedFieldKeys(secret, fieldOwner, func(fields map[string]any) []string {
@@ -776,6 +1468,10 @@ func getManagedFieldKeys(
 }
 
 func getResourceVersion(es *esv1beta1.ExternalSecret) string {
+	// with the Manual refresh policy, only the force-sync annotation triggers a refresh
+	if es.Spec.RefreshPolicy == esv1beta1.RefreshPolicyManual {
+		return "manual-" + utils.ObjectHash(es.ObjectMeta.Annotations[esv1beta1.AnnotationForceSync])
+	}
 	return fmt.Sprintf("%d-%s", es.ObjectMeta.GetGeneration(), hashMeta(es.ObjectMeta))
 }
 
@@ -792,6 +1488,27 @@ func hashMeta(m metav1.ObjectMeta) string {
 	return utils.ObjectHash(objectMeta)
 }
 
+// isRefreshPeriodic returns true if the ExternalSecret is refreshed every refresh interval.
+func isRefreshPeriodic(es *esv1beta1.ExternalSecret) bool {
+	return es.Spec.RefreshPolicy == "" || es.Spec.RefreshPolicy == esv1beta1.RefreshPolicyPeriodic
+}
+
+// isCreationPolicyNone returns true if the ExternalSecret only fetches provider data without managing a Secret.
+func isCreationPolicyNone(es *esv1beta1.ExternalSecret) bool {
+	return es.Spec.Target.CreationPolicy == esv1beta1.CreatePolicyNone
+}
+
+// skipsSecretWrites returns true if the target secret of the ExternalSecret is never written,
+// either due to CreationPolicy=None, validateOnly or because the controller is read-only.
+func (r *Reconciler) skipsSecretWrites(es *esv1beta1.ExternalSecret) bool {
+	return r.ReadOnly || isCreationPolicyNone(es) || es.Spec.ValidateOnly
+}
+
+// matchesLabelSelector returns true if the ExternalSecret matches the label selector of the controller.
+func (r *Reconciler) matchesLabelSelector(object client.Object) bool {
+	return r.LabelSelector == nil || r.LabelSelector.Matches(labels.Set(object.GetLabels()))
+}
+
 func shouldSkipClusterSecretStore(r *Reconciler, es *esv1beta1.ExternalSecret) bool {
 	return !r.ClusterSecretStoreEnabled && es.Spec.SecretStoreRef.Kind == esv1beta1.ClusterSecretStoreKind
 }
@@ -800,23 +1517,7 @@ func shouldSkipClusterSecretStore(r *Reconciler, es *esv1beta1.ExternalSecret) b
 // fetches the store and evaluates the controllerClass property.
 // Returns true if any storeRef points to store with a non-matching controllerClass.
 func shouldSkipUnmanagedStore(ctx context.Context, namespace string, r *Reconciler, es *esv1beta1.ExternalSecret) (bool, error) {
-	var storeList []esv1beta1.SecretStoreRef
-
-	if es.Spec.SecretStoreRef.Name != "" {
-		storeList = append(storeList, es.Spec.SecretStoreRef)
-	}
-
-	for _, ref := range es.Spec.Data {
-		if ref.SourceRef != nil {
-			storeList = append(storeList, ref.SourceRef.SecretStoreRef)
-		}
-	}
-
 	for _, ref := range es.Spec.DataFrom {
-		if ref.SourceRef != nil && ref.SourceRef.SecretStoreRef != nil {
-			storeList = append(storeList, *ref.SourceRef.SecretStoreRef)
-		}
-
 		// verify that generator's controllerClass matches
 		if ref.SourceRef != nil && ref.SourceRef.GeneratorRef != nil {
 			_, obj, err := resolvers.GeneratorRef(ctx, r.Client, r.Scheme, namespace, ref.SourceRef.GeneratorRef)
@@ -841,21 +1542,8 @@ func shouldSkipUnmanagedStore(ctx context.Context, namespace string, r *Reconcil
 		}
 	}
 
-	for _, ref := range storeList {
-		var store esv1beta1.GenericStore
-
-		switch ref.Kind {
-		case esv1beta1.SecretStoreKind, "":
-			store = &esv1beta1.SecretStore{}
-		case esv1beta1.ClusterSecretStoreKind:
-			store = &esv1beta1.ClusterSecretStore{}
-			namespace = ""
-		}
-
-		err := r.Get(ctx, types.NamespacedName{
-			Name:      ref.Name,
-			Namespace: namespace,
-		}, store)
+	for _, ref := range referencedStores(es) {
+		store, err := r.getReferencedStore(ctx, namespace, ref)
 		if err != nil {
 			if apierrors.IsNotFound(err) {
 				// skip non-existent stores
@@ -871,7 +1559,56 @@ func shouldSkipUnmanagedStore(ctx context.Context, namespace string, r *Reconcil
 	return false, nil
 }
 
+// referencedStores returns the store references of spec.secretStoreRef and the sourceRefs of the ExternalSecret.
+func referencedStores(es *esv1beta1.ExternalSecret) []esv1beta1.SecretStoreRef {
+	var storeList []esv1beta1.SecretStoreRef
+
+	if es.Spec.SecretStoreRef.Name != "" {
+		storeList = append(storeList, es.Spec.SecretStoreRef)
+	}
+
+	for _, ref := range es.Spec.Data {
+		if ref.SourceRef != nil && ref.SourceRef.SecretStoreRef != nil {
+			storeList = append(storeList, *ref.SourceRef.SecretStoreRef)
+		}
+		if ref.SourceRef != nil {
+			storeList = append(storeList, ref.SourceRef.StoreGroup...)
+		}
+	}
+
+	for _, ref := range es.Spec.DataFrom {
+		if ref.SourceRef != nil && ref.SourceRef.SecretStoreRef != nil {
+			storeList = append(storeList, *ref.SourceRef.SecretStoreRef)
+		}
+		if ref.SourceRef != nil {
+			storeList = append(storeList, ref.SourceRef.StoreGroup...)
+		}
+	}
+	return storeList
+}
+
+// getReferencedStore fetches the SecretStore or ClusterSecretStore of the reference.
+func (r *Reconciler) getReferencedStore(ctx context.Context, namespace string, ref esv1beta1.SecretStoreRef) (esv1beta1.GenericStore, error) {
+	var store esv1beta1.GenericStore = &esv1beta1.SecretStore{}
+	if ref.Kind == esv1beta1.ClusterSecretStoreKind {
+		store = &esv1beta1.ClusterSecretStore{}
+		namespace = ""
+	}
+	err := r.Get(ctx, types.NamespacedName{
+		Name:      ref.Name,
+		Namespace: namespace,
+	}, store)
+	return store, err
+}
+
 func shouldRefresh(es *esv1beta1.ExternalSecret) bool {
+	switch es.Spec.RefreshPolicy {
+	case esv1beta1.RefreshPolicyOnChange, esv1beta1.RefreshPolicyManual:
+		// never refresh on a timer, only if the ExternalSecret (or its force-sync annotation) changed
+		return es.Status.SyncedResourceVersion != getResourceVersion(es)
+	case esv1beta1.RefreshPolicyPeriodic, "":
+	}
+
 	// if the refresh interval is 0, and we have synced previously, we should not refresh
 	if es.Spec.RefreshInterval.Duration <= 0 && es.Status.SyncedResourceVersion != "" {
 		return false
@@ -897,7 +1634,8 @@ func shouldRefresh(es *esv1beta1.ExternalSecret) bool {
 }
 
 // isSecretValid checks if the secret exists, and it's data is consistent with the calculated hash.
-func isSecretValid(existingSecret *v1.Secret) bool {
+// With CreationPolicy=StrictMerge the hash only covers the keys managed by the ExternalSecret.
+func isSecretValid(existingSecret *v1.Secret, es *esv1beta1.ExternalSecret) bool {
 	// if target secret doesn't exist, we need to refresh
 	if existingSecret.UID == "" {
 		return false
@@ -910,7 +1648,20 @@ func isSecretValid(existingSecret *v1.Secret) bool {
 
 	// if the data-hash annotation is missing or incorrect, then it's invalid
 	// this is how we know if the data has chanced since we last updated the secret
-	if existingSecret.Annotations[esv1beta1.AnnotationDataHash] != utils.ObjectHash(existingSecret.Data) {
+	data := existingSecret.Data
+	if es.Spec.Target.CreationPolicy == esv1beta1.CreatePolicyStrictMerge {
+		keys, err := getManagedDataKeys(existingSecret, es.Name)
+		if err != nil {
+			return false
+		}
+		data = make(map[string][]byte, len(keys))
+		for _, key := range keys {
+			if value, ok := existingSecret.Data[key]; ok {
+				data[key] = value
+			}
+		}
+	}
+	if existingSecret.Annotations[esv1beta1.AnnotationDataHash] != utils.ObjectHash(data) {
 		return false
 	}
 
@@ -935,23 +1686,59 @@ func (r *Reconciler) SetupWithManager(mgr ctrl.Manager, opts controller.Options)
 		return err
 	}
 
+	// count the managed secrets from the events of the secret metadata informer, if their number is limited
+	if r.MaxManagedSecrets > 0 {
+		secretPartial := &metav1.PartialObjectMetadata{}
+		secretPartial.SetGroupVersionKind(v1.SchemeGroupVersion.WithKind("Secret"))
+		informer, err := mgr.GetCache().GetInformer(context.Background(), secretPartial)
+		if err != nil {
+			return err
+		}
+		r.managedSecrets = newManagedSecrets(r.ControllerIdentity)
+		if err := r.managedSecrets.register(informer); err != nil {
+			return err
+		}
+	}
+
 	// predicate function to ignore secret events unless they have the "managed" label
+	// and are not managed by another controller identity
 	secretHasESLabel := predicate.NewPredicateFuncs(func(object client.Object) bool {
 		value, hasLabel := object.GetLabels()[esv1beta1.LabelManaged]
-		return hasLabel && value == esv1beta1.LabelManagedValue
+		return hasLabel && value == esv1beta1.LabelManagedValue && !isManagedByOtherIdentity(object, r.ControllerIdentity)
 	})
 
-	return ctrl.NewControllerManagedBy(mgr).
+	// predicate function to ignore ExternalSecrets that do not match the label selector
+	esMatchesSelector := predicate.NewPredicateFuncs(r.matchesLabelSelector)
+
+	b := ctrl.NewControllerManagedBy(mgr).
 		WithOptions(opts).
-		For(&esv1beta1.ExternalSecret{}).
+		For(&esv1beta1.ExternalSecret{}, builder.WithPredicates(esMatchesSelector)).
 		// we cant use Owns(), as we don't set ownerReferences when the creationPolicy is not Owner.
 		// we use WatchesMetadata() to reduce memory usage, as otherwise we have to process full secret objects.
 		WatchesMetadata(
 			&v1.Secret{},
 			handler.EnqueueRequestsFromMapFunc(r.findObjectsForSecret),
 			builder.WithPredicates(predicate.ResourceVersionChangedPredicate{}, secretHasESLabel),
-		).
-		Complete(r)
+		)
+
+	// render the templates again if the labels or annotations of their namespace change
+	if r.TemplateNamespaceMetadata {
+		b = b.Watches(
+			&v1.Namespace{},
+			handler.EnqueueRequestsFromMapFunc(r.findObjectsForNamespace),
+			builder.WithPredicates(predicate.Or[client.Object](predicate.LabelChangedPredicate{}, predicate.AnnotationChangedPredicate{})),
+		)
+	}
+	// refresh the ExternalSecrets once the change notifications of a store report a changed secret
+	if r.StoreWatchers != nil {
+		b = b.WatchesRawSource(r.StoreWatchers.Source())
+	}
+	// a namespace over its limits is requeued, so it can not occupy the workers of other namespaces
+	var reconciler reconcile.Reconciler = r
+	if r.NamespaceThrottle != nil {
+		reconciler = r.NamespaceThrottle.Wrap(r)
+	}
+	return b.Complete(reconciler)
 }
 
 func (r *Reconciler) findObjectsForSecret(ctx context.Context, secret client.Object) []reconcile.Request {
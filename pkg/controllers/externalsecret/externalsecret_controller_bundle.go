@@ -0,0 +1,138 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalsecret
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+)
+
+const (
+	defaultBundleSeparator = "\n"
+
+	// NOTE: bundle errors must never contain the secret value.
+	errBundleMissingSource = "source key %q not found"
+	errBundleParseSource   = "unable to parse source key %q"
+	errBundleNotMapping    = "source key %q is not a mapping"
+	errBundleConflict      = "source key %q sets a conflicting value for %q"
+	errBundleUnknownFormat = "unknown bundle format %q"
+)
+
+// buildBundle combines the source keys of data into a single value.
+// Sources are processed in the given order, so later sources
+// take precedence when merging. Merged output is rendered with sorted keys.
+func buildBundle(bundle *esv1beta1.ExternalSecretBundle, data map[string][]byte) ([]byte, error) {
+	values := make([][]byte, 0, len(bundle.Sources))
+	for _, source := range bundle.Sources {
+		value, ok := data[source]
+		if !ok {
+			return nil, fmt.Errorf(errBundleMissingSource, source)
+		}
+		values = append(values, value)
+	}
+
+	switch bundle.Format {
+	case "", esv1beta1.BundleFormatConcat:
+		separator := defaultBundleSeparator
+		if bundle.Separator != nil {
+			separator = *bundle.Separator
+		}
+		out := make([]string, 0, len(values))
+		for _, v := range values {
+			out = append(out, string(v))
+		}
+		return []byte(strings.Join(out, separator)), nil
+	case esv1beta1.BundleFormatYAMLMerge:
+		merged, err := mergeBundleSources(bundle, values, func(b []byte, v any) error { return yaml.Unmarshal(b, v) })
+		if err != nil {
+			return nil, err
+		}
+		return yaml.Marshal(merged)
+	case esv1beta1.BundleFormatJSONMerge:
+		merged, err := mergeBundleSources(bundle, values, json.Unmarshal)
+		if err != nil {
+			return nil, err
+		}
+		return json.Marshal(merged)
+	}
+	return nil, fmt.Errorf(errBundleUnknownFormat, bundle.Format)
+}
+
+// mergeBundleSources deep merges the mappings of all sources.
+func mergeBundleSources(bundle *esv1beta1.ExternalSecretBundle, values [][]byte, unmarshal func([]byte, any) error) (map[string]any, error) {
+	merged := make(map[string]any)
+	for i, value := range values {
+		source := bundle.Sources[i]
+		var doc any
+		if err := unmarshal(value, &doc); err != nil {
+			// the parser error is dropped as it may quote the value
+			return nil, fmt.Errorf(errBundleParseSource, source)
+		}
+		if doc == nil {
+			continue
+		}
+		mapping, ok := doc.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf(errBundleNotMapping, source)
+		}
+		if err := mergeMap(merged, mapping, "", source, bundle.ConflictPolicy); err != nil {
+			return nil, err
+		}
+	}
+	return merged, nil
+}
+
+// mergeMap merges src into dst. Nested mappings are merged recursively,
+// all other values (including lists) are replaced.
+// Keys are merged in sorted order, so conflicts are always reported for the same key.
+func mergeMap(dst, src map[string]any, prefix, source string, policy esv1beta1.ExternalSecretBundleConflictPolicy) error {
+	keys := make([]string, 0, len(src))
+	for key := range src {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		value := src[key]
+		path := key
+		if prefix != "" {
+			path = prefix + nestedKeySeparator + key
+		}
+		existing, found := dst[key]
+		if !found {
+			dst[key] = value
+			continue
+		}
+		existingMap, existingIsMap := existing.(map[string]any)
+		valueMap, valueIsMap := value.(map[string]any)
+		if existingIsMap && valueIsMap {
+			if err := mergeMap(existingMap, valueMap, path, source, policy); err != nil {
+				return err
+			}
+			continue
+		}
+		if policy == esv1beta1.BundleConflictError && !reflect.DeepEqual(existing, value) {
+			return fmt.Errorf(errBundleConflict, source, path)
+		}
+		dst[key] = value
+	}
+	return nil
+}
@@ -0,0 +1,190 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalsecret
+
+import (
+	"strings"
+	"testing"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+)
+
+func TestBuildBundle(t *testing.T) {
+	data := map[string][]byte{
+		"base": []byte(`server:
+  host: db.example.com
+  port: 5432
+  tls:
+    enabled: false
+users: [admin]
+`),
+		"override": []byte(`server:
+  port: 6432
+  tls:
+    enabled: true
+users: [app]
+`),
+		"same":    []byte("server:\n  host: db.example.com\n"),
+		"list":    []byte("- s3cr3t"),
+		"broken":  []byte("a: [s3cr3t"),
+		"json-a":  []byte(`{"b":{"y":1},"a":"s3cr3t"}`),
+		"json-b":  []byte(`{"b":{"x":2}}`),
+		"cert":    []byte("-----BEGIN CERT-----"),
+		"key":     []byte("-----BEGIN KEY-----"),
+		"empty":   []byte(""),
+		"comment": []byte("# nothing here\n"),
+	}
+	sep := "|"
+	tests := []struct {
+		name    string
+		bundle  esv1beta1.ExternalSecretBundle
+		want    string
+		wantErr string
+	}{
+		{
+			name:   "concat uses newline by default",
+			bundle: esv1beta1.ExternalSecretBundle{Sources: []string{"key", "cert"}},
+			want:   "-----BEGIN KEY-----\n-----BEGIN CERT-----",
+		},
+		{
+			name: "concat with separator keeps source order",
+			bundle: esv1beta1.ExternalSecretBundle{
+				Format:    esv1beta1.BundleFormatConcat,
+				Separator: &sep,
+				Sources:   []string{"cert", "key"},
+			},
+			want: "-----BEGIN CERT-----|-----BEGIN KEY-----",
+		},
+		{
+			name: "yaml merge later sources override",
+			bundle: esv1beta1.ExternalSecretBundle{
+				Format:  esv1beta1.BundleFormatYAMLMerge,
+				Sources: []string{"base", "override", "empty", "comment"},
+			},
+			want: `server:
+  host: db.example.com
+  port: 6432
+  tls:
+    enabled: true
+users:
+- app
+`,
+		},
+		{
+			name: "yaml merge order matters",
+			bundle: esv1beta1.ExternalSecretBundle{
+				Format:  esv1beta1.BundleFormatYAMLMerge,
+				Sources: []string{"override", "base"},
+			},
+			want: `server:
+  host: db.example.com
+  port: 5432
+  tls:
+    enabled: false
+users:
+- admin
+`,
+		},
+		{
+			name: "yaml merge conflict error",
+			bundle: esv1beta1.ExternalSecretBundle{
+				Format:         esv1beta1.BundleFormatYAMLMerge,
+				ConflictPolicy: esv1beta1.BundleConflictError,
+				Sources:        []string{"base", "override"},
+			},
+			wantErr: `source key "override" sets a conflicting value for "server.port"`,
+		},
+		{
+			name: "yaml merge equal values do not conflict",
+			bundle: esv1beta1.ExternalSecretBundle{
+				Format:         esv1beta1.BundleFormatYAMLMerge,
+				ConflictPolicy: esv1beta1.BundleConflictError,
+				Sources:        []string{"same", "base"},
+			},
+			want: `server:
+  host: db.example.com
+  port: 5432
+  tls:
+    enabled: false
+users:
+- admin
+`,
+		},
+		{
+			name: "yaml merge requires mappings",
+			bundle: esv1beta1.ExternalSecretBundle{
+				Format:  esv1beta1.BundleFormatYAMLMerge,
+				Sources: []string{"base", "list"},
+			},
+			wantErr: `source key "list" is not a mapping`,
+		},
+		{
+			name: "yaml merge invalid source",
+			bundle: esv1beta1.ExternalSecretBundle{
+				Format:  esv1beta1.BundleFormatYAMLMerge,
+				Sources: []string{"broken"},
+			},
+			wantErr: `unable to parse source key "broken"`,
+		},
+		{
+			name: "json merge sorts keys",
+			bundle: esv1beta1.ExternalSecretBundle{
+				Format:  esv1beta1.BundleFormatJSONMerge,
+				Sources: []string{"json-a", "json-b"},
+			},
+			want: `{"a":"s3cr3t","b":{"x":2,"y":1}}`,
+		},
+		{
+			name: "json merge rejects yaml",
+			bundle: esv1beta1.ExternalSecretBundle{
+				Format:  esv1beta1.BundleFormatJSONMerge,
+				Sources: []string{"base"},
+			},
+			wantErr: `unable to parse source key "base"`,
+		},
+		{
+			name:    "missing source",
+			bundle:  esv1beta1.ExternalSecretBundle{Sources: []string{"cert", "missing"}},
+			wantErr: `source key "missing" not found`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := buildBundle(&tt.bundle, data)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+				}
+				if strings.Contains(err.Error(), "s3cr3t") {
+					t.Fatalf("error must not contain secret data: %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("expected:\n%s\ngot:\n%s", tt.want, got)
+			}
+			// merged output must be stable across reconciles
+			for i := 0; i < 10; i++ {
+				again, err := buildBundle(&tt.bundle, data)
+				if err != nil || string(again) != string(got) {
+					t.Fatalf("expected deterministic output, got:\n%s", again)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,47 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalsecret
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+)
+
+const errNoKeyCandidate = "none of the %d key candidates exist: %w"
+
+// getFirstFoundSecretData reads remoteRef.key and then remoteRef.keyCandidates
+// in order until a secret exists, and returns the key that was read.
+// A missing secret moves on to the next key, all other errors are returned.
+func getFirstFoundSecretData(ctx context.Context, client esv1beta1.SecretsClient, secretRef esv1beta1.ExternalSecretData, providerData map[string][]byte) (string, error) {
+	candidates := secretRef.RemoteRef.KeyCandidates
+	if len(candidates) == 0 {
+		return secretRef.RemoteRef.Key, getSecretData(ctx, client, secretRef, providerData)
+	}
+
+	keys := append([]string{secretRef.RemoteRef.Key}, candidates...)
+	for _, key := range keys {
+		ref := secretRef
+		ref.RemoteRef.Key = key
+		ref.RemoteRef.KeyCandidates = nil
+		err := getSecretData(ctx, client, ref, providerData)
+		if !errors.Is(err, esv1beta1.NoSecretErr) {
+			return key, err
+		}
+	}
+	return "", fmt.Errorf(errNoKeyCandidate, len(keys), esv1beta1.NoSecretErr)
+}
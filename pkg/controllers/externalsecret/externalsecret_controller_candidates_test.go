@@ -0,0 +1,114 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalsecret
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	"github.com/external-secrets/external-secrets/pkg/provider/testing/fake"
+)
+
+func TestGetFirstFoundSecretData(t *testing.T) {
+	errBoom := errors.New("boom")
+	tests := []struct {
+		name     string
+		remote   map[string][]byte
+		fail     string
+		ref      esv1beta1.ExternalSecretDataRemoteRef
+		wantKey  string
+		wantData map[string][]byte
+		wantErr  error
+		wantRead []string
+	}{
+		{
+			name:     "without candidates the key is read",
+			remote:   map[string][]byte{"new/key": []byte("new")},
+			ref:      esv1beta1.ExternalSecretDataRemoteRef{Key: "new/key"},
+			wantKey:  "new/key",
+			wantData: map[string][]byte{"token": []byte("new")},
+			wantRead: []string{"new/key"},
+		},
+		{
+			name:     "the key is preferred",
+			remote:   map[string][]byte{"new/key": []byte("new"), "old/key": []byte("old")},
+			ref:      esv1beta1.ExternalSecretDataRemoteRef{Key: "new/key", KeyCandidates: []string{"old/key"}},
+			wantKey:  "new/key",
+			wantData: map[string][]byte{"token": []byte("new")},
+			wantRead: []string{"new/key"},
+		},
+		{
+			name:     "the first candidate that exists is used",
+			remote:   map[string][]byte{"old/key": []byte("old"), "older/key": []byte("older")},
+			ref:      esv1beta1.ExternalSecretDataRemoteRef{Key: "new/key", KeyCandidates: []string{"old/key", "older/key"}},
+			wantKey:  "old/key",
+			wantData: map[string][]byte{"token": []byte("old")},
+			wantRead: []string{"new/key", "old/key"},
+		},
+		{
+			name:     "a missing secret in all candidates",
+			remote:   map[string][]byte{},
+			ref:      esv1beta1.ExternalSecretDataRemoteRef{Key: "new/key", KeyCandidates: []string{"old/key"}},
+			wantData: map[string][]byte{},
+			wantErr:  esv1beta1.NoSecretErr,
+			wantRead: []string{"new/key", "old/key"},
+		},
+		{
+			name:     "other errors are not skipped",
+			remote:   map[string][]byte{"old/key": []byte("old")},
+			fail:     "new/key",
+			ref:      esv1beta1.ExternalSecretDataRemoteRef{Key: "new/key", KeyCandidates: []string{"old/key"}},
+			wantKey:  "new/key",
+			wantData: map[string][]byte{},
+			wantErr:  errBoom,
+			wantRead: []string{"new/key"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var read []string
+			client := fake.New()
+			client.GetSecretFn = func(_ context.Context, ref esv1beta1.ExternalSecretDataRemoteRef) ([]byte, error) {
+				read = append(read, ref.Key)
+				if ref.Key == tt.fail {
+					return nil, errBoom
+				}
+				value, ok := tt.remote[ref.Key]
+				if !ok {
+					return nil, esv1beta1.NoSecretErr
+				}
+				return value, nil
+			}
+			data := map[string][]byte{}
+			key, err := getFirstFoundSecretData(context.Background(), client, esv1beta1.ExternalSecretData{SecretKey: "token", RemoteRef: tt.ref}, data)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("unexpected error: %v, want %v", err, tt.wantErr)
+			}
+			if key != tt.wantKey {
+				t.Errorf("unexpected key %q, want %q", key, tt.wantKey)
+			}
+			if diff := cmp.Diff(tt.wantData, data); diff != "" {
+				t.Errorf("unexpected data (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tt.wantRead, read); diff != "" {
+				t.Errorf("unexpected reads (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
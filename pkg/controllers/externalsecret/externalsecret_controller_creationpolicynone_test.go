@@ -0,0 +1,81 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalsecret
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+)
+
+func TestReconcileCreationPolicyNoneSources(t *testing.T) {
+	es := &esv1beta1.ExternalSecret{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-es", Namespace: "default"},
+		Spec: esv1beta1.ExternalSecretSpec{
+			RefreshInterval: &metav1.Duration{Duration: time.Hour},
+			SecretStoreRef:  esv1beta1.SecretStoreRef{Name: "test-store"},
+			Target:          esv1beta1.ExternalSecretTarget{Name: "target", CreationPolicy: esv1beta1.CreatePolicyNone},
+			Data: []esv1beta1.ExternalSecretData{
+				{SecretKey: "foo", RemoteRef: esv1beta1.ExternalSecretDataRemoteRef{Key: "bar", Version: "2"}},
+			},
+			DataFrom: []esv1beta1.ExternalSecretDataFromRemoteRef{
+				{Extract: &esv1beta1.ExternalSecretDataRemoteRef{Key: "missing"}},
+			},
+		},
+	}
+	c := newTestClientBuilder(t, newTestStore(), es).Build()
+	r := newTestReconciler(c)
+	ctx := context.Background()
+	key := types.NamespacedName{Name: "test-es", Namespace: "default"}
+
+	provider := newTestProvider(t)
+	provider.WithGetSecret([]byte("value"), nil)
+	provider.WithGetSecretMap(nil, esv1beta1.NoSecretError{})
+	if _, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key}); err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	if err := c.Get(ctx, types.NamespacedName{Name: "target", Namespace: "default"}, &v1.Secret{}); !apierrors.IsNotFound(err) {
+		t.Fatalf("expected no secret to be created with creationPolicy=None, got: %v", err)
+	}
+	got := &esv1beta1.ExternalSecret{}
+	if err := c.Get(ctx, key, got); err != nil {
+		t.Fatal(err)
+	}
+
+	// every entry is recorded with its key names and version, missing secrets with their error
+	sources := map[string]esv1beta1.ExternalSecretSourceStatus{}
+	for _, source := range got.Status.Sources {
+		sources[source.Path] = source
+	}
+	data, ok := sources["spec.data[0]"]
+	if !ok || data.Error != "" || data.Summary != "key=bar version=2" || len(data.Keys) != 1 || data.Keys[0] != "foo" {
+		t.Errorf("status.sources[spec.data[0]] = %+v, want the resolved entry", data)
+	}
+	if source, ok := sources["spec.dataFrom[0]"]; !ok || source.Error == "" {
+		t.Errorf("status.sources[spec.dataFrom[0]] = %+v, want the error of the missing secret", source)
+	}
+	cond := GetExternalSecretCondition(got.Status, esv1beta1.ExternalSecretReady)
+	if cond == nil || cond.Status != v1.ConditionTrue || cond.Reason != esv1beta1.ConditionReasonSecretPartiallySynced {
+		t.Errorf("Ready condition = %+v, want True with reason %s", cond, esv1beta1.ConditionReasonSecretPartiallySynced)
+	}
+}
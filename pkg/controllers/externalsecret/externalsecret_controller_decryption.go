@@ -0,0 +1,137 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalsecret
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	"github.com/external-secrets/external-secrets/pkg/decryption"
+	"github.com/external-secrets/external-secrets/pkg/utils/resolvers"
+)
+
+const (
+	errDecryptionKey      = "%w: unable to get decryption key: %w"
+	errDecryptionSetup    = "%w: %w"
+	errDecryptedNotObject = "decrypted secret is not a JSON object"
+)
+
+// getDecrypter returns the decrypter configured in spec.decryption, or nil.
+// The key is always read from the namespace of the ExternalSecret.
+func (r *Reconciler) getDecrypter(ctx context.Context, externalSecret *esv1beta1.ExternalSecret) (*decryption.Decrypter, error) {
+	spec := externalSecret.Spec.Decryption
+	if spec == nil {
+		return nil, nil
+	}
+	ref := spec.AgeKeySecretRef
+	ref.Namespace = nil
+	key, err := resolvers.SecretKeyRef(ctx, r.Client, resolvers.EmptyStoreKind, externalSecret.Namespace, &ref)
+	if err != nil {
+		return nil, fmt.Errorf(errDecryptionKey, ErrSecretDecrypt, err)
+	}
+	decrypter, err := decryption.New(spec.Format, key)
+	if err != nil {
+		return nil, fmt.Errorf(errDecryptionSetup, ErrSecretDecrypt, err)
+	}
+	return decrypter, nil
+}
+
+// withDecryption wraps the client to decrypt all values it returns, if a decrypter is configured.
+func withDecryption(client esv1beta1.SecretsClient, decrypter *decryption.Decrypter) esv1beta1.SecretsClient {
+	if decrypter == nil {
+		return client
+	}
+	return &decryptingClient{
+		SecretsClient: client,
+		decrypter:     decrypter,
+	}
+}
+
+// decryptingClient decrypts the values of the wrapped client.
+// Properties are resolved on the decrypted value, as the provider can not look into the ciphertext.
+type decryptingClient struct {
+	esv1beta1.SecretsClient
+	decrypter *decryption.Decrypter
+}
+
+func (c *decryptingClient) GetSecret(ctx context.Context, ref esv1beta1.ExternalSecretDataRemoteRef) ([]byte, error) {
+	// the metadata of a secret, e.g. its tags, is not encrypted
+	if ref.MetadataPolicy == esv1beta1.ExternalSecretMetadataPolicyFetch {
+		return c.SecretsClient.GetSecret(ctx, ref)
+	}
+	property := ref.Property
+	ref.Property = ""
+	data, err := c.SecretsClient.GetSecret(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+	data, err = c.decrypt(data)
+	if err != nil {
+		return nil, err
+	}
+	if property == "" {
+		return data, nil
+	}
+	return getJSONProperty(data, property)
+}
+
+// GetSecretMap returns the top level keys of the decrypted JSON object.
+func (c *decryptingClient) GetSecretMap(ctx context.Context, ref esv1beta1.ExternalSecretDataRemoteRef) (map[string][]byte, error) {
+	data, err := c.GetSecret(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+	kv := make(map[string]json.RawMessage)
+	if err := json.Unmarshal(data, &kv); err != nil {
+		// the unmarshal error is dropped as it may quote the decrypted value
+		return nil, fmt.Errorf("%w: %w", ErrSecretDecrypt, errors.New(errDecryptedNotObject))
+	}
+	secretMap := make(map[string][]byte, len(kv))
+	for k, v := range kv {
+		var strVal string
+		if err := json.Unmarshal(v, &strVal); err == nil {
+			secretMap[k] = []byte(strVal)
+		} else {
+			secretMap[k] = v
+		}
+	}
+	return secretMap, nil
+}
+
+func (c *decryptingClient) GetAllSecrets(ctx context.Context, ref esv1beta1.ExternalSecretFind) (map[string][]byte, error) {
+	secretMap, err := c.SecretsClient.GetAllSecrets(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+	for k, v := range secretMap {
+		dec, err := c.decrypt(v)
+		if err != nil {
+			return nil, fmt.Errorf("key %s: %w", k, err)
+		}
+		secretMap[k] = dec
+	}
+	return secretMap, nil
+}
+
+func (c *decryptingClient) decrypt(data []byte) ([]byte, error) {
+	out, err := c.decrypter.Decrypt(data)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrSecretDecrypt, err)
+	}
+	return out, nil
+}
@@ -0,0 +1,107 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalsecret
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	"github.com/external-secrets/external-secrets/pkg/decryption"
+	"github.com/external-secrets/external-secrets/pkg/provider/testing/fake"
+)
+
+// testAgeKey is only used for the test fixtures below.
+const testAgeKey = "AGE-SECRET-KEY-1HCRUJGKQZNTLCNMQQZ3GQ3HXSTFJRGLXQL2RTK7LQYEWAHY24UYSC542FJ"
+
+// testSOPSDocument contains username=admin and password=s3cr3t.
+const testSOPSDocument = `{
+	"username": "ENC[AES256_GCM,data:6BiIAao=,iv:sypMu/8XpfcDR8in5Mwo095z5QvftC35/Dup1t9NqJA=,tag:r+Jt7tZyRig12TRDSa2wig==,type:str]",
+	"password": "ENC[AES256_GCM,data:/Rsgf489,iv:nU6i4BACT+gVNt3Awu5+8bj80DSr3yHvBk5BhZbrDl0=,tag:jBnZDCnXRVkltNTjCFKi9A==,type:str]",
+	"sops": {
+		"age": [{"recipient": "age1test", "enc": "-----BEGIN AGE ENCRYPTED FILE-----\nYWdlLWVuY3J5cHRpb24ub3JnL3YxCi0+IHVua25vd24tdHlwZSBhcmcKCi0+IFgy\nNTUxOSB5T1dxRlAzRnRLaEppUkU4aW0rR1R1UEdldzhsVkJuS0dOTmFKUXRCdDBj\nCkNSeVZRRVBTNEJzSmpVQkNBUXBsellFcnZodVdBdFU1M3htSEVsMWxuVDAKLS0t\nIFR6NWxTUngrd1NqcnoxYzNQMWpBbktleERzRit4M29HK2hkQUxOUkdtQXcK3EWU\nqIGEh04jwJ1QmQbgbErpq80t2wwV4uCn0L/lsCyN46x13fV2sRflMkgGwcnMtuAy\nAdeW+GZPm5GaTRBc+g==\n-----END AGE ENCRYPTED FILE-----\n"}],
+		"lastmodified": "2024-01-01T00:00:00Z",
+		"mac": "ENC[AES256_GCM,data:ojNme271AVBU6MbRpDemWDrc9rABwD9QKYwo4A+FjhAy8vtpPcVWakRYRr9TWHZh5qdxab1mEndayXOUhv1XRGNyvWvaXKqKid8loCiCdbvwbLkeJKdi+18UwRx7mYKVyCYH3OdMA2Pi3fSYjv+bNuEmKkvuSVNquK5URo151Iw=,iv:RF+MrOxG16vOqCjPcbhC7Wh2LX5Jluw2r0MQHhXjBQA=,tag:Om+m1Xu179H2r/p2OBCohQ==,type:str]",
+		"unencrypted_suffix": "_unencrypted",
+		"version": "3.8.1"
+	}
+}`
+
+// testAgeDocument contains {"token":"s3cr3t"}.
+const testAgeDocument = `-----BEGIN AGE ENCRYPTED FILE-----
+YWdlLWVuY3J5cHRpb24ub3JnL3YxCi0+IHVua25vd24tdHlwZSBhcmcKCi0+IFgy
+NTUxOSBZcW5nK3BFYVg4bkVJZ2YwYXhlN3RKZ1pEZWQyZTdrOVBqRi8yMjFsQW1v
+Cit1bVRseGlxRGJLYXFPYitsM0FEZkt5SURHenFOdXdBaUQ3aWN3SWNzZUkKLS0t
+IERYQmpMQ2JTZGJVOWs2aXQwTWNFeGlMUzVNaWNYRjlxZGpnWkJwZDE5Y00KdVaC
+dMurxZJFjBxTZLx0G9XrjVknTdEdezrTvNem+cK9QTxqWlA0T5wkwUtjTBGbwn0=
+-----END AGE ENCRYPTED FILE-----`
+
+func TestDecryptingClient(t *testing.T) {
+	sops, err := decryption.New(esv1beta1.DecryptionFormatSOPS, testAgeKey)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	age, err := decryption.New(esv1beta1.DecryptionFormatAge, testAgeKey)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	ctx := context.Background()
+
+	client := withDecryption(fake.New().WithGetSecret([]byte(testSOPSDocument), nil), sops)
+	got, err := client.GetSecret(ctx, esv1beta1.ExternalSecretDataRemoteRef{Key: "db", Property: "password"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(got) != "s3cr3t" {
+		t.Errorf("unexpected property value")
+	}
+	if _, err := client.GetSecret(ctx, esv1beta1.ExternalSecretDataRemoteRef{Key: "db", Property: "missing"}); !errors.Is(err, esv1beta1.NoSecretErr) {
+		t.Errorf("expected missing property error, got %v", err)
+	}
+	secretMap, err := client.GetSecretMap(ctx, esv1beta1.ExternalSecretDataRemoteRef{Key: "db"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := map[string][]byte{
+		"username": []byte("admin"),
+		"password": []byte("s3cr3t"),
+	}
+	if diff := cmp.Diff(want, secretMap); diff != "" {
+		t.Errorf("unexpected result (-want +got):\n%s", diff)
+	}
+
+	client = withDecryption(fake.New().WithGetAllSecrets(map[string][]byte{"api": []byte(testAgeDocument)}, nil), age)
+	secretMap, err = client.GetAllSecrets(ctx, esv1beta1.ExternalSecretFind{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(secretMap["api"]) != `{"token":"s3cr3t"}` {
+		t.Errorf("unexpected decrypted value")
+	}
+
+	// the plain SOPS document can not be decrypted with the age format
+	client = withDecryption(fake.New().WithGetSecret([]byte(testSOPSDocument), nil), age)
+	_, err = client.GetSecret(ctx, esv1beta1.ExternalSecretDataRemoteRef{Key: "db"})
+	if !errors.Is(err, ErrSecretDecrypt) {
+		t.Fatalf("expected decryption error, got %v", err)
+	}
+	if strings.Contains(err.Error(), "ENC[") || strings.Contains(err.Error(), testAgeKey) {
+		t.Errorf("error must not contain ciphertext or keys: %v", err)
+	}
+}
@@ -0,0 +1,96 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalsecret
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+)
+
+// these errors are explicitly defined so we can detect them with `errors.Is()`.
+var (
+	ErrDependencyNotReady = errors.New("dependency is not ready")
+	ErrDependencyCycle    = errors.New("dependency cycle")
+)
+
+// checkDependencies returns an error if the ExternalSecrets of spec.dependsOn are not all Ready,
+// ErrDependencyNotReady if the sync must wait and ErrDependencyCycle if it can never happen.
+func (r *Reconciler) checkDependencies(ctx context.Context, es *esv1beta1.ExternalSecret) error {
+	if len(es.Spec.DependsOn) == 0 {
+		return nil
+	}
+
+	cycle, err := r.findDependencyCycle(ctx, es.Namespace, []string{es.Name}, es.Spec.DependsOn, map[string]bool{es.Name: true})
+	if err != nil {
+		return err
+	}
+	if cycle != nil {
+		return fmt.Errorf("%w: %s", ErrDependencyCycle, strings.Join(cycle, " -> "))
+	}
+
+	for _, dep := range es.Spec.DependsOn {
+		var dependency esv1beta1.ExternalSecret
+		err := r.Get(ctx, client.ObjectKey{Name: dep.Name, Namespace: es.Namespace}, &dependency)
+		if apierrors.IsNotFound(err) {
+			return fmt.Errorf("%w: ExternalSecret %s does not exist", ErrDependencyNotReady, dep.Name)
+		}
+		if err != nil {
+			return err
+		}
+		cond := GetExternalSecretCondition(dependency.Status, esv1beta1.ExternalSecretReady)
+		if cond == nil || cond.Status != v1.ConditionTrue {
+			return fmt.Errorf("%w: ExternalSecret %s is not Ready", ErrDependencyNotReady, dep.Name)
+		}
+	}
+	return nil
+}
+
+// findDependencyCycle walks the dependencies depth-first and returns the first cycle it finds.
+// path holds the names from the ExternalSecret to the current one, visited all names seen so far.
+// Missing ExternalSecrets end a path, they are reported as not ready instead.
+func (r *Reconciler) findDependencyCycle(ctx context.Context, namespace string, path []string, deps []esv1beta1.ExternalSecretDependency, visited map[string]bool) ([]string, error) {
+	for _, dep := range deps {
+		if i := slices.Index(path, dep.Name); i >= 0 {
+			return append(slices.Clone(path[i:]), dep.Name), nil
+		}
+		if visited[dep.Name] {
+			continue
+		}
+		visited[dep.Name] = true
+
+		var es esv1beta1.ExternalSecret
+		err := r.Get(ctx, client.ObjectKey{Name: dep.Name, Namespace: namespace}, &es)
+		if apierrors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		cycle, err := r.findDependencyCycle(ctx, namespace, append(path, dep.Name), es.Spec.DependsOn, visited)
+		if err != nil || cycle != nil {
+			return cycle, err
+		}
+	}
+	return nil, nil
+}
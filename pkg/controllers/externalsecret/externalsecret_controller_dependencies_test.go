@@ -0,0 +1,134 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalsecret
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+)
+
+func newDependentExternalSecret(name string, ready bool, dependsOn ...string) *esv1beta1.ExternalSecret {
+	es := &esv1beta1.ExternalSecret{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "foo"},
+	}
+	for _, dep := range dependsOn {
+		es.Spec.DependsOn = append(es.Spec.DependsOn, esv1beta1.ExternalSecretDependency{Name: dep})
+	}
+	status := v1.ConditionFalse
+	if ready {
+		status = v1.ConditionTrue
+	}
+	es.Status.Conditions = []esv1beta1.ExternalSecretStatusCondition{{Type: esv1beta1.ExternalSecretReady, Status: status}}
+	return es
+}
+
+func TestCheckDependencies(t *testing.T) {
+	tests := []struct {
+		name    string
+		es      *esv1beta1.ExternalSecret
+		objs    []client.Object
+		wantErr error
+		wantMsg string
+	}{
+		{
+			name: "without dependencies",
+			es:   newDependentExternalSecret("leaf", false),
+		},
+		{
+			name: "ready dependencies",
+			es:   newDependentExternalSecret("leaf", false, "ca", "intermediate"),
+			objs: []client.Object{
+				newDependentExternalSecret("ca", true),
+				newDependentExternalSecret("intermediate", true, "ca"),
+			},
+		},
+		{
+			name:    "dependency does not exist",
+			es:      newDependentExternalSecret("leaf", false, "ca"),
+			wantErr: ErrDependencyNotReady,
+			wantMsg: "dependency is not ready: ExternalSecret ca does not exist",
+		},
+		{
+			name: "dependency is not ready",
+			es:   newDependentExternalSecret("leaf", false, "ca", "intermediate"),
+			objs: []client.Object{
+				newDependentExternalSecret("ca", true),
+				newDependentExternalSecret("intermediate", false, "ca"),
+			},
+			wantErr: ErrDependencyNotReady,
+			wantMsg: "dependency is not ready: ExternalSecret intermediate is not Ready",
+		},
+		{
+			name: "cycle through the ExternalSecret",
+			es:   newDependentExternalSecret("leaf", false, "ca"),
+			objs: []client.Object{
+				newDependentExternalSecret("ca", true, "intermediate"),
+				newDependentExternalSecret("intermediate", true, "leaf"),
+			},
+			wantErr: ErrDependencyCycle,
+			wantMsg: "dependency cycle: leaf -> ca -> intermediate -> leaf",
+		},
+		{
+			name: "cycle between dependencies",
+			es:   newDependentExternalSecret("leaf", false, "ca"),
+			objs: []client.Object{
+				newDependentExternalSecret("ca", false, "intermediate"),
+				newDependentExternalSecret("intermediate", false, "ca"),
+			},
+			wantErr: ErrDependencyCycle,
+			wantMsg: "dependency cycle: ca -> intermediate -> ca",
+		},
+		{
+			name: "shared dependency is not a cycle",
+			es:   newDependentExternalSecret("leaf", false, "a", "b"),
+			objs: []client.Object{
+				newDependentExternalSecret("a", true, "ca"),
+				newDependentExternalSecret("b", true, "ca"),
+				newDependentExternalSecret("ca", true),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scheme := runtime.NewScheme()
+			if err := esv1beta1.AddToScheme(scheme); err != nil {
+				t.Fatal(err)
+			}
+			r := &Reconciler{Client: fakeclient.NewClientBuilder().WithScheme(scheme).WithObjects(tt.objs...).Build()}
+			err := r.checkDependencies(context.Background(), tt.es)
+			if tt.wantErr == nil {
+				if err != nil {
+					t.Fatalf("checkDependencies() returned an unexpected error: %v", err)
+				}
+				return
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("checkDependencies() error = %v, want %v", err, tt.wantErr)
+			}
+			if err.Error() != tt.wantMsg {
+				t.Errorf("checkDependencies() error = %q, want %q", err.Error(), tt.wantMsg)
+			}
+		})
+	}
+}
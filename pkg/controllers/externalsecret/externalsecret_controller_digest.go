@@ -0,0 +1,31 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalsecret
+
+import (
+	"fmt"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	"github.com/external-secrets/external-secrets/pkg/utils"
+)
+
+// verifyDigest fails with ErrDigestMismatch if the value does not match remoteRef.expectedDigest.
+// The error contains the expected digest, but neither the value nor its actual digest.
+func verifyDigest(ref esv1beta1.ExternalSecretDataRemoteRef, value []byte) error {
+	if ref.ExpectedDigest == "" || utils.ValueDigest(value) == ref.ExpectedDigest {
+		return nil
+	}
+	return fmt.Errorf("%w (key: %s, expected: %s)", ErrDigestMismatch, ref.Key, ref.ExpectedDigest)
+}
@@ -0,0 +1,83 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalsecret
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	"github.com/external-secrets/external-secrets/pkg/provider/testing/fake"
+)
+
+func TestExpectedDigest(t *testing.T) {
+	const digest = "sha256:12998c017066eb0d2a70b94e6ed3192985855ce390f321bbdb832022888bd251" // "hello there"
+	tests := []struct {
+		name     string
+		value    string
+		ref      esv1beta1.ExternalSecretDataRemoteRef
+		want     string
+		mismatch bool
+	}{
+		{
+			name:  "no digest",
+			value: "rotated",
+			want:  "rotated",
+		},
+		{
+			name:  "match",
+			value: "hello there",
+			ref:   esv1beta1.ExternalSecretDataRemoteRef{ExpectedDigest: digest},
+			want:  "hello there",
+		},
+		{
+			name:  "match after decoding",
+			value: "aGVsbG8gdGhlcmU=",
+			ref:   esv1beta1.ExternalSecretDataRemoteRef{ExpectedDigest: digest, DecodingStrategy: esv1beta1.ExternalSecretDecodeBase64},
+			want:  "hello there",
+		},
+		{
+			name:     "mismatch",
+			value:    "rotated",
+			ref:      esv1beta1.ExternalSecretDataRemoteRef{ExpectedDigest: digest},
+			mismatch: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := fake.New().WithGetSecret([]byte(tt.value), nil)
+			ref := tt.ref
+			ref.Key = "greeting"
+			data := map[string][]byte{}
+			err := getSecretData(context.Background(), client, esv1beta1.ExternalSecretData{SecretKey: "greeting", RemoteRef: ref}, data)
+			if tt.mismatch {
+				if !errors.Is(err, ErrDigestMismatch) {
+					t.Fatalf("unexpected error: %v, want %v", err, ErrDigestMismatch)
+				}
+				if _, ok := data["greeting"]; ok {
+					t.Errorf("the value was stored despite the mismatch")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := string(data["greeting"]); got != tt.want {
+				t.Errorf("unexpected value %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,66 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalsecret
+
+import (
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	"github.com/external-secrets/external-secrets/pkg/controllers/externalsecret/esmetrics"
+)
+
+const (
+	msgSyncDisabled = "secret is not written while it has the annotation " + esv1beta1.AnnotationDisableSync + "=" + esv1beta1.AnnotationDisableSyncValue
+	msgSyncEnabled  = "annotation " + esv1beta1.AnnotationDisableSync + " was removed, secret is written again"
+)
+
+// isSyncDisabled returns true if the target secret has the disable-sync annotation.
+func isSyncDisabled(secret metav1.Object) bool {
+	return secret.GetAnnotations()[esv1beta1.AnnotationDisableSync] == esv1beta1.AnnotationDisableSyncValue
+}
+
+// shouldSkipSync returns true if the target secret must not be written because of the disable-sync annotation.
+// the annotation has no effect if the secret is never written anyway.
+func (r *Reconciler) shouldSkipSync(es *esv1beta1.ExternalSecret, secret metav1.Object) bool {
+	return !r.skipsSecretWrites(es) && isSyncDisabled(secret)
+}
+
+// syncDisabledChanged returns true if the disable-sync annotation of the target secret
+// does not match the SyncDisabled condition, so the refresh must not be skipped.
+func (r *Reconciler) syncDisabledChanged(es *esv1beta1.ExternalSecret, secret metav1.Object) bool {
+	disabled := GetExternalSecretCondition(es.Status, esv1beta1.ExternalSecretSyncDisabled) != nil
+	return disabled != r.shouldSkipSync(es, secret)
+}
+
+// markAsSyncDisabled sets the SyncDisabled condition, the Ready condition keeps reporting the last sync.
+func (r *Reconciler) markAsSyncDisabled(es *esv1beta1.ExternalSecret) {
+	if GetExternalSecretCondition(es.Status, esv1beta1.ExternalSecretSyncDisabled) == nil {
+		r.recorder.Event(es, v1.EventTypeNormal, esv1beta1.ReasonSyncDisabled, msgSyncDisabled)
+	}
+	cond := NewExternalSecretCondition(esv1beta1.ExternalSecretSyncDisabled, v1.ConditionTrue, esv1beta1.ConditionReasonSyncDisabled, msgSyncDisabled)
+	SetExternalSecretCondition(es, *cond)
+}
+
+// clearSyncDisabled removes the SyncDisabled condition once the annotation is removed.
+func (r *Reconciler) clearSyncDisabled(es *esv1beta1.ExternalSecret) {
+	current := GetExternalSecretCondition(es.Status, esv1beta1.ExternalSecretSyncDisabled)
+	if current == nil {
+		return
+	}
+	es.Status.Conditions = filterOutCondition(es.Status.Conditions, esv1beta1.ExternalSecretSyncDisabled)
+	esmetrics.UpdateExternalSecretCondition(es, current, 0.0)
+	r.recorder.Event(es, v1.EventTypeNormal, esv1beta1.ReasonSyncEnabled, msgSyncEnabled)
+}
@@ -0,0 +1,181 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalsecret
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	"github.com/external-secrets/external-secrets/pkg/utils"
+)
+
+func TestReconcileDisableSync(t *testing.T) {
+	newTestProvider(t).WithGetSecret([]byte("value"), nil)
+	es := &esv1beta1.ExternalSecret{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-es", Namespace: "default", UID: "test-es-uid"},
+		Spec: esv1beta1.ExternalSecretSpec{
+			RefreshInterval: &metav1.Duration{Duration: time.Hour},
+			SecretStoreRef:  esv1beta1.SecretStoreRef{Name: "test-store"},
+			Target: esv1beta1.ExternalSecretTarget{
+				Name:           "target",
+				CreationPolicy: esv1beta1.CreatePolicyOwner,
+			},
+			Data: []esv1beta1.ExternalSecretData{
+				{SecretKey: "foo", RemoteRef: esv1beta1.ExternalSecretDataRemoteRef{Key: "bar"}},
+			},
+		},
+	}
+	ownerLabels := map[string]string{
+		esv1beta1.LabelManaged: esv1beta1.LabelManagedValue,
+		esv1beta1.LabelOwner:   utils.ObjectHash(fmt.Sprintf("%v/%v", es.Namespace, es.Name)),
+	}
+	// the target was edited manually and annotated to keep the edit
+	target := &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "target",
+			Namespace:   "default",
+			UID:         "target-uid",
+			Labels:      ownerLabels,
+			Annotations: map[string]string{esv1beta1.AnnotationDisableSync: esv1beta1.AnnotationDisableSyncValue},
+		},
+		Data: map[string][]byte{"foo": []byte("manual")},
+	}
+	// the previous target of the ExternalSecret, before spec.target.name was changed
+	oldSecret := &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "old-target", Namespace: "default", UID: "old-target-uid", Labels: ownerLabels},
+		Data:       map[string][]byte{"foo": []byte("value")},
+	}
+	c := newTestClientBuilder(t, newTestStore(), es, target, oldSecret).
+		WithInterceptorFuncs(interceptor.Funcs{Delete: deleteWithSecretKind}).Build()
+	recorder := record.NewFakeRecorder(10)
+	r := newTestReconciler(c)
+	r.recorder = recorder
+	ctx := context.Background()
+	key := types.NamespacedName{Name: "test-es", Namespace: "default"}
+	targetKey := types.NamespacedName{Name: "target", Namespace: "default"}
+
+	// while the annotation is set the target is not written, orphaned secrets are still deleted
+	result, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+	if err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	if result.RequeueAfter != time.Hour {
+		t.Errorf("Reconcile() RequeueAfter = %v, want the refresh interval", result.RequeueAfter)
+	}
+	secret := &v1.Secret{}
+	if err := c.Get(ctx, targetKey, secret); err != nil {
+		t.Fatal(err)
+	}
+	if string(secret.Data["foo"]) != "manual" {
+		t.Errorf("the target was written while sync is disabled: %q", secret.Data["foo"])
+	}
+	if err := c.Get(ctx, types.NamespacedName{Name: "old-target", Namespace: "default"}, &v1.Secret{}); !apierrors.IsNotFound(err) {
+		t.Errorf("expected the orphaned secret to be deleted, got: %v", err)
+	}
+	got := &esv1beta1.ExternalSecret{}
+	if err := c.Get(ctx, key, got); err != nil {
+		t.Fatal(err)
+	}
+	cond := GetExternalSecretCondition(got.Status, esv1beta1.ExternalSecretSyncDisabled)
+	if cond == nil || cond.Status != v1.ConditionTrue || cond.Reason != esv1beta1.ConditionReasonSyncDisabled {
+		t.Fatalf("expected a SyncDisabled condition, got %+v", got.Status.Conditions)
+	}
+	expectEvent(t, recorder, esv1beta1.ReasonSyncDisabled)
+
+	// once the annotation is removed the target is written again and the condition is removed
+	delete(secret.Annotations, esv1beta1.AnnotationDisableSync)
+	if err := c.Update(ctx, secret); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key}); err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	if err := c.Get(ctx, targetKey, secret); err != nil {
+		t.Fatal(err)
+	}
+	if string(secret.Data["foo"]) != "value" {
+		t.Errorf("the target was not written after the annotation was removed: %q", secret.Data["foo"])
+	}
+	if err := c.Get(ctx, key, got); err != nil {
+		t.Fatal(err)
+	}
+	if cond := GetExternalSecretCondition(got.Status, esv1beta1.ExternalSecretSyncDisabled); cond != nil {
+		t.Errorf("expected the SyncDisabled condition to be removed, got %+v", cond)
+	}
+	if cond := GetExternalSecretCondition(got.Status, esv1beta1.ExternalSecretReady); cond == nil || cond.Status != v1.ConditionTrue {
+		t.Errorf("expected the ExternalSecret to be ready, got %+v", got.Status.Conditions)
+	}
+	expectEvent(t, recorder, esv1beta1.ReasonSyncEnabled)
+}
+
+func TestSyncDisabledChanged(t *testing.T) {
+	annotated := &v1.Secret{ObjectMeta: metav1.ObjectMeta{
+		Annotations: map[string]string{esv1beta1.AnnotationDisableSync: esv1beta1.AnnotationDisableSyncValue},
+	}}
+	disabled := &esv1beta1.ExternalSecret{Status: esv1beta1.ExternalSecretStatus{
+		Conditions: []esv1beta1.ExternalSecretStatusCondition{{Type: esv1beta1.ExternalSecretSyncDisabled, Status: v1.ConditionTrue}},
+	}}
+	r := &Reconciler{}
+	if !r.syncDisabledChanged(&esv1beta1.ExternalSecret{}, annotated) {
+		t.Error("expected a change once the annotation is added")
+	}
+	if r.syncDisabledChanged(disabled, annotated) {
+		t.Error("expected no change while the annotation is set")
+	}
+	if !r.syncDisabledChanged(disabled, &v1.Secret{}) {
+		t.Error("expected a change once the annotation is removed")
+	}
+	annotated.Annotations[esv1beta1.AnnotationDisableSync] = "false"
+	if r.syncDisabledChanged(&esv1beta1.ExternalSecret{}, annotated) {
+		t.Error("expected only the value true to disable the sync")
+	}
+	// the secret is never written in read-only mode, so the annotation has no effect
+	r.ReadOnly = true
+	annotated.Annotations[esv1beta1.AnnotationDisableSync] = esv1beta1.AnnotationDisableSyncValue
+	if r.syncDisabledChanged(&esv1beta1.ExternalSecret{}, annotated) {
+		t.Error("expected the annotation to be ignored in read-only mode")
+	}
+}
+
+// expectEvent fails the test if no event with the reason was recorded.
+func expectEvent(t *testing.T, recorder *record.FakeRecorder, reason string) {
+	t.Helper()
+	found := false
+	for {
+		select {
+		case event := <-recorder.Events:
+			if strings.Contains(event, reason) {
+				found = true
+			}
+		default:
+			if !found {
+				t.Errorf("expected an event with reason %s", reason)
+			}
+			return
+		}
+	}
+}
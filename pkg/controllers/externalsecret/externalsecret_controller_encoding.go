@@ -0,0 +1,57 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalsecret
+
+import (
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	v1 "k8s.io/api/core/v1"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+)
+
+// flagNonUTF8Keys lists the keys with values that are not valid UTF-8 in an annotation of the secret
+// if the target encoding is Text, otherwise the annotation is removed.
+// A warning event is recorded when the flagged keys change, previous is the former value of the annotation.
+func (r *Reconciler) flagNonUTF8Keys(es *esv1beta1.ExternalSecret, secret *v1.Secret, previous string) {
+	var keys []string
+	if es.Spec.Target.Encoding == esv1beta1.TargetEncodingText {
+		keys = nonUTF8Keys(secret.Data)
+	}
+	if len(keys) == 0 {
+		delete(secret.Annotations, esv1beta1.AnnotationNonUTF8Keys)
+		return
+	}
+
+	value := strings.Join(keys, ",")
+	secret.Annotations[esv1beta1.AnnotationNonUTF8Keys] = value
+	if value != previous {
+		r.recorder.Eventf(es, v1.EventTypeWarning, esv1beta1.ReasonNonUTF8Data, eventNonUTF8Data, value)
+	}
+}
+
+// nonUTF8Keys returns the sorted keys of data with values that are not valid UTF-8.
+func nonUTF8Keys(data map[string][]byte) []string {
+	var keys []string
+	for key, value := range data {
+		if !utf8.Valid(value) {
+			keys = append(keys, key)
+		}
+	}
+	slices.Sort(keys)
+	return keys
+}
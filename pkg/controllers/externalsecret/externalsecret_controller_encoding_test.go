@@ -0,0 +1,107 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalsecret
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/record"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+)
+
+func TestFlagNonUTF8Keys(t *testing.T) {
+	binary := []byte{0xff, 0xfe, 0x00}
+	tests := []struct {
+		name           string
+		encoding       esv1beta1.ExternalSecretTargetEncoding
+		data           map[string][]byte
+		annotations    map[string]string
+		previous       string
+		wantAnnotation string
+		wantEvent      bool
+	}{
+		{
+			name:     "binary encoding accepts any value",
+			encoding: esv1beta1.TargetEncodingBinary,
+			data:     map[string][]byte{"cert": binary},
+		},
+		{
+			name: "no encoding accepts any value",
+			data: map[string][]byte{"cert": binary},
+		},
+		{
+			name:     "text values",
+			encoding: esv1beta1.TargetEncodingText,
+			data:     map[string][]byte{"user": []byte("admin"), "greeting": []byte("grüezi")},
+		},
+		{
+			name:           "binary values are flagged",
+			encoding:       esv1beta1.TargetEncodingText,
+			data:           map[string][]byte{"user": []byte("admin"), "keystore": binary, "cert": binary},
+			wantAnnotation: "cert,keystore",
+			wantEvent:      true,
+		},
+		{
+			name:           "no event if the flagged keys did not change",
+			encoding:       esv1beta1.TargetEncodingText,
+			data:           map[string][]byte{"cert": binary},
+			previous:       "cert",
+			wantAnnotation: "cert",
+		},
+		{
+			name:        "annotation is removed once the values are text",
+			encoding:    esv1beta1.TargetEncodingText,
+			data:        map[string][]byte{"cert": []byte("-----BEGIN CERTIFICATE-----")},
+			annotations: map[string]string{esv1beta1.AnnotationNonUTF8Keys: "cert"},
+			previous:    "cert",
+		},
+		{
+			name:        "annotation is removed with binary encoding",
+			encoding:    esv1beta1.TargetEncodingBinary,
+			data:        map[string][]byte{"cert": binary},
+			annotations: map[string]string{esv1beta1.AnnotationNonUTF8Keys: "cert"},
+			previous:    "cert",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := record.NewFakeRecorder(1)
+			r := &Reconciler{recorder: recorder}
+			es := &esv1beta1.ExternalSecret{
+				Spec: esv1beta1.ExternalSecretSpec{
+					Target: esv1beta1.ExternalSecretTarget{Encoding: tt.encoding},
+				},
+			}
+			annotations := map[string]string{}
+			for k, v := range tt.annotations {
+				annotations[k] = v
+			}
+			secret := &v1.Secret{Data: tt.data}
+			secret.Annotations = annotations
+
+			r.flagNonUTF8Keys(es, secret, tt.previous)
+
+			if diff := cmp.Diff(tt.wantAnnotation, secret.Annotations[esv1beta1.AnnotationNonUTF8Keys]); diff != "" {
+				t.Errorf("flagNonUTF8Keys() annotation mismatch (-want +got):\n%s", diff)
+			}
+			if gotEvent := len(recorder.Events) > 0; gotEvent != tt.wantEvent {
+				t.Errorf("flagNonUTF8Keys() recorded an event = %v, want %v", gotEvent, tt.wantEvent)
+			}
+		})
+	}
+}
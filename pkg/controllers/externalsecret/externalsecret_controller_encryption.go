@@ -0,0 +1,158 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalsecret
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	"github.com/external-secrets/external-secrets/pkg/encryption"
+	"github.com/external-secrets/external-secrets/pkg/utils"
+)
+
+const (
+	errEncryptCreatePolicy = "target.encryptWith can not be used with creationPolicy=%s"
+	errEncryptValue        = "unable to encrypt key %s: %w"
+	errHashKeyRef          = "invalid hash key secret %q, expected <namespace>/<name>"
+	errHashKeyGet          = "unable to get hash key secret %q: %w"
+	errHashKeyLength       = "the key %q of the hash key secret %q must be at least %d bytes long"
+
+	// hashKeySecretKey is the key of the hash key secret that holds the HMAC key.
+	hashKeySecretKey = "key"
+	// minHashKeyLength is the minimum length of the HMAC key.
+	minHashKeyLength = 32
+)
+
+var errEncryptSetup = errors.New("unable to set up the encryption of the secret")
+
+// encryptSecretData encrypts every value of the secret for spec.target.encryptWith.
+// The ciphertext differs on every encryption, so the values are only encrypted again if the plaintext
+// or the recipients changed since the last write, or the previous data is not the ciphertext written for them.
+// Otherwise the previous ciphertext is kept and the secret is not updated.
+// The hashes are recorded in status.encryption. The plaintext hash is an HMAC keyed with hashKey, which is only
+// known to the controller, so it can not be used to guess the plaintext. Without a hashKey the values are always encrypted again.
+func encryptSecretData(es *esv1beta1.ExternalSecret, secret *v1.Secret, previous map[string][]byte, hashKey []byte) error {
+	spec := es.Spec.Target.EncryptWith
+	if spec == nil {
+		es.Status.Encryption = nil
+		return nil
+	}
+	switch policy := es.Spec.Target.CreationPolicy; policy {
+	case esv1beta1.CreatePolicyMerge, esv1beta1.CreatePolicyStrictMerge, esv1beta1.CreatePolicyNone:
+		return fmt.Errorf(errEncryptCreatePolicy, policy)
+	}
+
+	hash := plaintextHash(hashKey, spec, secret.Data)
+	if canKeepCiphertext(es.Status.Encryption, hash, previous, secret.Data) {
+		for key := range secret.Data {
+			secret.Data[key] = previous[key]
+		}
+	} else {
+		encrypter, err := encryption.New(spec.Format, spec.Recipients)
+		if err != nil {
+			return fmt.Errorf("%w: %w", errEncryptSetup, err)
+		}
+		for key, value := range secret.Data {
+			ciphertext, err := encrypter.Encrypt(value)
+			if err != nil {
+				return fmt.Errorf(errEncryptValue, key, err)
+			}
+			secret.Data[key] = ciphertext
+		}
+	}
+	es.Status.Encryption = &esv1beta1.ExternalSecretEncryptionStatus{
+		PlaintextHash: hash,
+		DataHash:      utils.ObjectHash(secret.Data),
+	}
+	return nil
+}
+
+// canKeepCiphertext returns true if the previous data was written for the same plaintext and recipients,
+// and was not modified since.
+func canKeepCiphertext(status *esv1beta1.ExternalSecretEncryptionStatus, hash string, previous, data map[string][]byte) bool {
+	if status == nil || hash == "" || status.PlaintextHash != hash || status.DataHash != utils.ObjectHash(previous) {
+		return false
+	}
+	for key := range data {
+		if _, ok := previous[key]; !ok {
+			return false
+		}
+	}
+	return len(previous) == len(data)
+}
+
+// plaintextHash returns a HMAC-SHA256 of the encryption spec and the plaintext data keyed with hashKey.
+// It returns an empty string without a hashKey.
+func plaintextHash(hashKey []byte, spec *esv1beta1.ExternalSecretEncryption, data map[string][]byte) string {
+	if len(hashKey) == 0 {
+		return ""
+	}
+	h := hmac.New(sha256.New, hashKey)
+	writeField := func(b []byte) {
+		// prefix every field with its length, so the encoding is unambiguous
+		fmt.Fprintf(h, "%d:", len(b))
+		h.Write(b)
+	}
+	writeField([]byte(spec.Format))
+	fmt.Fprintf(h, "%d;", len(spec.Recipients))
+	for _, recipient := range spec.Recipients {
+		writeField([]byte(recipient))
+	}
+	for _, key := range slices.Sorted(maps.Keys(data)) {
+		writeField([]byte(key))
+		writeField(data[key])
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// LoadEncryptionHashKey returns the key of the plaintext hash of encrypted secrets.
+// ref is the <namespace>/<name> of a secret that holds the key in its "key" field,
+// so the key stays the same across restarts. Without a ref a random key is generated,
+// then the secrets are encrypted again once after every restart of the controller.
+func LoadEncryptionHashKey(ctx context.Context, reader client.Reader, ref string) ([]byte, error) {
+	if ref == "" {
+		key := make([]byte, minHashKeyLength)
+		if _, err := rand.Read(key); err != nil {
+			return nil, err
+		}
+		return key, nil
+	}
+	namespace, name, ok := strings.Cut(ref, "/")
+	if !ok || namespace == "" || name == "" {
+		return nil, fmt.Errorf(errHashKeyRef, ref)
+	}
+	secret := &v1.Secret{}
+	if err := reader.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, secret); err != nil {
+		return nil, fmt.Errorf(errHashKeyGet, ref, err)
+	}
+	key := secret.Data[hashKeySecretKey]
+	if len(key) < minHashKeyLength {
+		return nil, fmt.Errorf(errHashKeyLength, hashKeySecretKey, ref, minHashKeyLength)
+	}
+	return key, nil
+}
@@ -0,0 +1,343 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalsecret
+
+import (
+	"bytes"
+	"context"
+	"maps"
+	"testing"
+	"time"
+
+	"filippo.io/age"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	"github.com/external-secrets/external-secrets/pkg/decryption"
+	"github.com/external-secrets/external-secrets/pkg/utils"
+)
+
+// testHashKey is the key of the plaintext hash in the encryption tests.
+var testHashKey = []byte("0123456789abcdef0123456789abcdef")
+
+// newEncryptionTestKey returns a random age identity and its recipient.
+func newEncryptionTestKey(t *testing.T) (string, string) {
+	t.Helper()
+	id, err := age.GenerateX25519Identity()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return id.String(), id.Recipient().String()
+}
+
+func TestEncryptSecretData(t *testing.T) {
+	identity, recipient := newEncryptionTestKey(t)
+	_, otherRecipient := newEncryptionTestKey(t)
+	newES := func(recipients ...string) *esv1beta1.ExternalSecret {
+		return &esv1beta1.ExternalSecret{
+			Spec: esv1beta1.ExternalSecretSpec{
+				Target: esv1beta1.ExternalSecretTarget{
+					EncryptWith: &esv1beta1.ExternalSecretEncryption{
+						Format:     esv1beta1.EncryptionFormatAge,
+						Recipients: recipients,
+					},
+				},
+			},
+		}
+	}
+	// encrypted is the data and status written by an encryption
+	type encrypted struct {
+		data   map[string][]byte
+		status *esv1beta1.ExternalSecretEncryptionStatus
+	}
+	// encrypt mutates the secret like the mutation function does, and returns the written state
+	encrypt := func(t *testing.T, es *esv1beta1.ExternalSecret, data map[string][]byte, previous encrypted) encrypted {
+		t.Helper()
+		secret := &v1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{}},
+			Data:       maps.Clone(data),
+		}
+		es.Status.Encryption = previous.status
+		if err := encryptSecretData(es, secret, previous.data, testHashKey); err != nil {
+			t.Fatalf("encryptSecretData() returned an error: %v", err)
+		}
+		if len(secret.Annotations) != 0 {
+			t.Errorf("unexpected annotations on the secret: %v", secret.Annotations)
+		}
+		return encrypted{data: secret.Data, status: es.Status.Encryption}
+	}
+	data := map[string][]byte{"foo": []byte("bar"), "baz": []byte("qux")}
+
+	first := encrypt(t, newES(recipient), data, encrypted{})
+	decrypter, err := decryption.New(esv1beta1.DecryptionFormatAge, identity)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for key, value := range data {
+		got, err := decrypter.Decrypt(first.data[key])
+		if err != nil {
+			t.Fatalf("unable to decrypt key %s: %v", key, err)
+		}
+		if !bytes.Equal(got, value) {
+			t.Errorf("unexpected plaintext for key %s", key)
+		}
+	}
+	if first.status == nil || first.status.DataHash != utils.ObjectHash(first.data) {
+		t.Fatalf("unexpected status %v", first.status)
+	}
+
+	// the same plaintext keeps the ciphertext, so the secret is not updated
+	second := encrypt(t, newES(recipient), data, first)
+	if second.status.DataHash != first.status.DataHash {
+		t.Errorf("the ciphertext changed for the same plaintext")
+	}
+
+	tamperedData := maps.Clone(first.data)
+	tamperedData["foo"] = []byte("tampered")
+	tests := []struct {
+		name          string
+		es            *esv1beta1.ExternalSecret
+		data          map[string][]byte
+		previous      encrypted
+		samePlaintext bool
+	}{
+		{
+			name:     "changed value",
+			es:       newES(recipient),
+			data:     map[string][]byte{"foo": []byte("changed"), "baz": []byte("qux")},
+			previous: first,
+		},
+		{
+			name:     "added key",
+			es:       newES(recipient),
+			data:     map[string][]byte{"foo": []byte("bar"), "baz": []byte("qux"), "new": []byte("value")},
+			previous: first,
+		},
+		{
+			name:     "changed recipients",
+			es:       newES(recipient, otherRecipient),
+			data:     data,
+			previous: first,
+		},
+		{
+			name:          "modified ciphertext",
+			es:            newES(recipient),
+			data:          data,
+			previous:      encrypted{data: tamperedData, status: first.status},
+			samePlaintext: true,
+		},
+		{
+			// e.g. the update of the secret failed after the status was written
+			name:          "ciphertext not written",
+			es:            newES(recipient),
+			data:          data,
+			previous:      encrypted{data: map[string][]byte{}, status: first.status},
+			samePlaintext: true,
+		},
+		{
+			name:          "no status",
+			es:            newES(recipient),
+			data:          data,
+			previous:      encrypted{data: first.data},
+			samePlaintext: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := encrypt(t, tt.es, tt.data, tt.previous)
+			if (got.status.PlaintextHash == first.status.PlaintextHash) != tt.samePlaintext {
+				t.Errorf("unexpected plaintext hash")
+			}
+			for key := range tt.data {
+				if bytes.Equal(got.data[key], tt.previous.data[key]) {
+					t.Errorf("the value of key %s was not encrypted again", key)
+				}
+			}
+		})
+	}
+
+	t.Run("not encrypted", func(t *testing.T) {
+		es := &esv1beta1.ExternalSecret{}
+		es.Status.Encryption = first.status
+		secret := &v1.Secret{Data: maps.Clone(data)}
+		if err := encryptSecretData(es, secret, first.data, testHashKey); err != nil {
+			t.Fatal(err)
+		}
+		if es.Status.Encryption != nil {
+			t.Errorf("status.encryption was not removed")
+		}
+		if !maps.EqualFunc(secret.Data, data, bytes.Equal) {
+			t.Errorf("the data was modified")
+		}
+	})
+
+	t.Run("merge", func(t *testing.T) {
+		es := newES(recipient)
+		es.Spec.Target.CreationPolicy = esv1beta1.CreatePolicyMerge
+		secret := &v1.Secret{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{}}, Data: maps.Clone(data)}
+		if err := encryptSecretData(es, secret, nil, testHashKey); err == nil {
+			t.Errorf("expected an error for creationPolicy=Merge")
+		}
+	})
+}
+
+func TestReconcileEncryptWithNoChurn(t *testing.T) {
+	identity, recipient := newEncryptionTestKey(t)
+	es := &esv1beta1.ExternalSecret{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-es", Namespace: "default"},
+		Spec: esv1beta1.ExternalSecretSpec{
+			RefreshInterval: &metav1.Duration{Duration: time.Hour},
+			SecretStoreRef:  esv1beta1.SecretStoreRef{Name: "test-store"},
+			Target: esv1beta1.ExternalSecretTarget{
+				Name:           "target",
+				CreationPolicy: esv1beta1.CreatePolicyOwner,
+				EncryptWith: &esv1beta1.ExternalSecretEncryption{
+					Format:     esv1beta1.EncryptionFormatAge,
+					Recipients: []string{recipient},
+				},
+			},
+			Data: []esv1beta1.ExternalSecretData{
+				{SecretKey: "foo", RemoteRef: esv1beta1.ExternalSecretDataRemoteRef{Key: "bar"}},
+			},
+		},
+	}
+	// the fake client does not set the uid of created objects, so the target secret already exists
+	target := &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "target",
+			Namespace: "default",
+			UID:       "target-uid",
+			Labels:    map[string]string{esv1beta1.LabelManaged: esv1beta1.LabelManagedValue},
+		},
+	}
+	c := newTestClientBuilder(t, newTestStore(), es, target).Build()
+	r := newTestReconciler(c)
+	r.EncryptionHashKey = testHashKey
+	ctx := context.Background()
+	esKey := types.NamespacedName{Name: "test-es", Namespace: "default"}
+	secretKey := types.NamespacedName{Name: "target", Namespace: "default"}
+
+	// reconcile forces a refresh of the ExternalSecret and returns the target secret
+	reconcile := func(t *testing.T) *v1.Secret {
+		t.Helper()
+		current := &esv1beta1.ExternalSecret{}
+		if err := c.Get(ctx, esKey, current); err != nil {
+			t.Fatal(err)
+		}
+		current.Status.RefreshTime = metav1.Time{}
+		if err := c.Status().Update(ctx, current); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: esKey}); err != nil {
+			t.Fatalf("Reconcile() returned an error: %v", err)
+		}
+		secret := &v1.Secret{}
+		if err := c.Get(ctx, secretKey, secret); err != nil {
+			t.Fatal(err)
+		}
+		return secret
+	}
+	decrypt := func(t *testing.T, secret *v1.Secret) string {
+		t.Helper()
+		decrypter, err := decryption.New(esv1beta1.DecryptionFormatAge, identity)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := decrypter.Decrypt(secret.Data["foo"])
+		if err != nil {
+			t.Fatalf("unable to decrypt the secret: %v", err)
+		}
+		return string(got)
+	}
+
+	provider := newTestProvider(t)
+	provider.WithGetSecret([]byte("value"), nil)
+	first := reconcile(t)
+	if got := decrypt(t, first); got != "value" {
+		t.Errorf("unexpected plaintext %q", got)
+	}
+	// the secret must not hold a hash of the plaintext
+	if want := map[string]string{esv1beta1.AnnotationDataHash: utils.ObjectHash(first.Data)}; !maps.Equal(first.Annotations, want) {
+		t.Errorf("unexpected annotations %v, want %v", first.Annotations, want)
+	}
+	current := &esv1beta1.ExternalSecret{}
+	if err := c.Get(ctx, esKey, current); err != nil {
+		t.Fatal(err)
+	}
+	if current.Status.Encryption == nil || current.Status.Encryption.DataHash != utils.ObjectHash(first.Data) {
+		t.Errorf("unexpected status.encryption %v", current.Status.Encryption)
+	}
+
+	// identical inputs keep the secret as is
+	second := reconcile(t)
+	if second.ResourceVersion != first.ResourceVersion || !bytes.Equal(second.Data["foo"], first.Data["foo"]) {
+		t.Errorf("the secret was updated for identical inputs")
+	}
+
+	// a new value is encrypted again
+	provider.WithGetSecret([]byte("changed"), nil)
+	third := reconcile(t)
+	if got := decrypt(t, third); got != "changed" {
+		t.Errorf("unexpected plaintext %q", got)
+	}
+}
+
+func TestPlaintextHashKey(t *testing.T) {
+	spec := &esv1beta1.ExternalSecretEncryption{Format: esv1beta1.EncryptionFormatAge, Recipients: []string{"age1recipient"}}
+	data := map[string][]byte{"password": []byte("hunter2")}
+	otherKey := []byte("fedcba9876543210fedcba9876543210")
+
+	hash := plaintextHash(testHashKey, spec, data)
+	if hash == "" || hash != plaintextHash(testHashKey, spec, data) {
+		t.Fatalf("unexpected plaintext hash %q", hash)
+	}
+	// the hash can not be computed without the key of the controller
+	if hash == plaintextHash(otherKey, spec, data) {
+		t.Errorf("the plaintext hash does not depend on the key")
+	}
+	if got := plaintextHash(nil, spec, data); got != "" {
+		t.Errorf("plaintextHash() without a key = %q, want an empty hash", got)
+	}
+	// without a key the ciphertext is never kept
+	if canKeepCiphertext(&esv1beta1.ExternalSecretEncryptionStatus{DataHash: utils.ObjectHash(data)}, "", data, data) {
+		t.Errorf("canKeepCiphertext() = true without a plaintext hash")
+	}
+}
+
+func TestLoadEncryptionHashKey(t *testing.T) {
+	key := []byte("0123456789abcdef0123456789abcdef")
+	c := newTestClientBuilder(t,
+		&v1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "hash-key", Namespace: "es"}, Data: map[string][]byte{hashKeySecretKey: key}},
+		&v1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "short", Namespace: "es"}, Data: map[string][]byte{hashKeySecretKey: []byte("short")}},
+	).Build()
+	ctx := context.Background()
+
+	got, err := LoadEncryptionHashKey(ctx, c, "es/hash-key")
+	if err != nil || !bytes.Equal(got, key) {
+		t.Errorf("LoadEncryptionHashKey() = %q, %v, want the key of the secret", got, err)
+	}
+	random, err := LoadEncryptionHashKey(ctx, c, "")
+	if err != nil || len(random) != minHashKeyLength {
+		t.Errorf("LoadEncryptionHashKey() = %d bytes, %v, want a random key", len(random), err)
+	}
+	for _, ref := range []string{"hash-key", "es/short", "es/missing"} {
+		if _, err := LoadEncryptionHashKey(ctx, c, ref); err == nil {
+			t.Errorf("LoadEncryptionHashKey(%q) returned no error", ref)
+		}
+	}
+}
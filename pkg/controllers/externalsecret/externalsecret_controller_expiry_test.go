@@ -0,0 +1,101 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalsecret
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+)
+
+func TestReconcileStoreExpiry(t *testing.T) {
+	tests := []struct {
+		name       string
+		expiresIn  time.Duration
+		wantReason string
+		wantSecret bool
+	}{
+		{
+			name:       "store within its window",
+			expiresIn:  time.Hour,
+			wantReason: esv1beta1.ConditionReasonSecretSynced,
+			wantSecret: true,
+		},
+		{
+			name:       "expired store",
+			expiresIn:  -time.Minute,
+			wantReason: esv1beta1.ConditionReasonStoreDenied,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			newTestProvider(t).WithGetSecret([]byte("bootstrap"), nil)
+			store := &esv1beta1.ClusterSecretStore{
+				ObjectMeta: metav1.ObjectMeta{Name: "bootstrap-store"},
+				Spec: esv1beta1.SecretStoreSpec{
+					Provider:   testStoreProvider.DeepCopy(),
+					Conditions: []esv1beta1.ClusterSecretStoreCondition{{Namespaces: []string{"bootstrap"}}},
+					ExpiresAt:  &metav1.Time{Time: time.Now().Add(tt.expiresIn)},
+				},
+			}
+			es := &esv1beta1.ExternalSecret{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-es", Namespace: "bootstrap"},
+				Spec: esv1beta1.ExternalSecretSpec{
+					RefreshInterval: &metav1.Duration{Duration: time.Hour},
+					SecretStoreRef:  esv1beta1.SecretStoreRef{Name: "bootstrap-store", Kind: esv1beta1.ClusterSecretStoreKind},
+					Target:          esv1beta1.ExternalSecretTarget{Name: "target", CreationPolicy: esv1beta1.CreatePolicyOrphan},
+					Data: []esv1beta1.ExternalSecretData{
+						{SecretKey: "token", RemoteRef: esv1beta1.ExternalSecretDataRemoteRef{Key: "token"}},
+					},
+				},
+			}
+			namespace := &v1.Namespace{ObjectMeta: metav1.ObjectMeta{
+				Name:   "bootstrap",
+				Labels: map[string]string{"kubernetes.io/metadata.name": "bootstrap"},
+			}}
+			c := newTestClientBuilder(t, store, es, namespace).Build()
+			r := newTestReconciler(c)
+			r.ClusterSecretStoreEnabled = true
+			key := types.NamespacedName{Name: "test-es", Namespace: "bootstrap"}
+			res, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: key})
+			if err != nil {
+				t.Fatalf("Reconcile() returned an error: %v", err)
+			}
+			if res.RequeueAfter < 59*time.Minute || res.RequeueAfter > time.Hour {
+				t.Errorf("expected a requeue after the refresh interval, got %v", res.RequeueAfter)
+			}
+
+			got := &esv1beta1.ExternalSecret{}
+			if err := c.Get(context.Background(), key, got); err != nil {
+				t.Fatal(err)
+			}
+			cond := GetExternalSecretCondition(got.Status, esv1beta1.ExternalSecretReady)
+			if cond == nil || cond.Reason != tt.wantReason {
+				t.Errorf("expected Ready condition with reason %s, got %+v", tt.wantReason, cond)
+			}
+			err = c.Get(context.Background(), types.NamespacedName{Name: "target", Namespace: "bootstrap"}, &v1.Secret{})
+			if tt.wantSecret != (err == nil) {
+				t.Errorf("expected the secret to exist: %v, got error %v", tt.wantSecret, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,49 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalsecret
+
+import (
+	"time"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+)
+
+// generatorRefreshRatio is the part of the lifetime of generated values after which they are generated again.
+const generatorRefreshRatio = 0.8
+
+// generatorRefreshTime returns the time values that were generated at now and expire at expiry are generated again.
+// it returns the zero time if the values do not expire.
+func generatorRefreshTime(now, expiry time.Time) time.Time {
+	lifetime := expiry.Sub(now)
+	if expiry.IsZero() || lifetime <= 0 {
+		return time.Time{}
+	}
+	return now.Add(time.Duration(float64(lifetime) * generatorRefreshRatio))
+}
+
+// generatorRefreshDue returns true if generated values must be generated again, so the refresh must not be skipped.
+func generatorRefreshDue(es *esv1beta1.ExternalSecret, now time.Time) bool {
+	return es.Status.GeneratorRefreshTime != nil && !now.Before(es.Status.GeneratorRefreshTime.Time)
+}
+
+// requeueForGenerators requeues before the refresh result if generated values must be generated again earlier.
+func requeueForGenerators(es *esv1beta1.ExternalSecret, result ctrl.Result) ctrl.Result {
+	if es.Status.GeneratorRefreshTime == nil {
+		return result
+	}
+	return requeueBefore(result, es.Status.GeneratorRefreshTime.Time)
+}
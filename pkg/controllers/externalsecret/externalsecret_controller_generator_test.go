@@ -0,0 +1,148 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalsecret
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	authv1 "k8s.io/api/authentication/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	genv1alpha1 "github.com/external-secrets/external-secrets/apis/generators/v1alpha1"
+)
+
+func TestReconcileGeneratorRefresh(t *testing.T) {
+	sa := &v1.ServiceAccount{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "app",
+			Namespace:   "default",
+			Annotations: map[string]string{genv1alpha1.AnnotationAllowServiceAccountToken: "true"},
+		},
+	}
+	generator := &genv1alpha1.ServiceAccountToken{
+		ObjectMeta: metav1.ObjectMeta{Name: "app-token", Namespace: "default"},
+		Spec: genv1alpha1.ServiceAccountTokenSpec{
+			ServiceAccountName: "app",
+			ExpirationSeconds:  ptr.To(int64(600)),
+		},
+	}
+	es := &esv1beta1.ExternalSecret{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-es", Namespace: "default"},
+		Spec: esv1beta1.ExternalSecretSpec{
+			RefreshInterval: &metav1.Duration{Duration: 24 * time.Hour},
+			Target:          esv1beta1.ExternalSecretTarget{Name: "target", CreationPolicy: esv1beta1.CreatePolicyOrphan},
+			DataFrom: []esv1beta1.ExternalSecretDataFromRemoteRef{{
+				SourceRef: &esv1beta1.StoreGeneratorSourceRef{
+					GeneratorRef: &esv1beta1.GeneratorRef{
+						APIVersion: genv1alpha1.Group + "/" + genv1alpha1.Version,
+						Kind:       genv1alpha1.ServiceAccountTokenKind,
+						Name:       "app-token",
+					},
+				},
+			}},
+		},
+	}
+	var requests int
+	c := newTestClientBuilder(t, sa, generator, es).
+		WithInterceptorFuncs(interceptor.Funcs{
+			Create: createWithUID,
+			// the fake client does not support the token subresource
+			SubResourceCreate: func(ctx context.Context, c client.Client, subResourceName string, obj, subResource client.Object, opts ...client.SubResourceCreateOption) error {
+				requests++
+				tr := subResource.(*authv1.TokenRequest)
+				tr.Status = authv1.TokenRequestStatus{
+					Token:               "token",
+					ExpirationTimestamp: metav1.NewTime(time.Now().Add(time.Duration(*tr.Spec.ExpirationSeconds) * time.Second)),
+				}
+				return nil
+			},
+		}).Build()
+	r := newTestReconciler(c)
+	ctx := context.Background()
+	key := types.NamespacedName{Name: "test-es", Namespace: "default"}
+
+	// the ExternalSecret is requeued after 80% of the token lifetime instead of the refresh interval
+	result, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+	if err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	if result.RequeueAfter <= 7*time.Minute || result.RequeueAfter > 8*time.Minute {
+		t.Errorf("Reconcile() RequeueAfter = %v, want 80%% of the token lifetime", result.RequeueAfter)
+	}
+	got := &esv1beta1.ExternalSecret{}
+	if err := c.Get(ctx, key, got); err != nil {
+		t.Fatal(err)
+	}
+	if got.Status.GeneratorRefreshTime == nil {
+		t.Fatalf("status.generatorRefreshTime is not set")
+	}
+
+	// the refresh is skipped before the refresh time
+	if _, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key}); err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	if requests != 1 {
+		t.Fatalf("requested %d tokens before the refresh time, want 1", requests)
+	}
+
+	// a new token is requested once the refresh time has passed
+	got.Status.GeneratorRefreshTime = &metav1.Time{Time: time.Now().Add(-time.Second)}
+	if err := c.Status().Update(ctx, got); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key}); err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	if requests != 2 {
+		t.Errorf("requested %d tokens after the refresh time, want 2", requests)
+	}
+}
+
+func TestGeneratorRefreshTime(t *testing.T) {
+	now := time.Now()
+	if got := generatorRefreshTime(now, now.Add(10*time.Minute)); !got.Equal(now.Add(8 * time.Minute)) {
+		t.Errorf("generatorRefreshTime() = %v, want after 80%% of the lifetime", got)
+	}
+	if got := generatorRefreshTime(now, time.Time{}); !got.IsZero() {
+		t.Errorf("generatorRefreshTime() = %v, want no refresh for values without expiry", got)
+	}
+	if got := generatorRefreshTime(now, now.Add(-time.Minute)); !got.IsZero() {
+		t.Errorf("generatorRefreshTime() = %v, want no refresh for expired values", got)
+	}
+
+	es := &esv1beta1.ExternalSecret{}
+	if got := requeueForGenerators(es, ctrl.Result{RequeueAfter: time.Hour}); got.RequeueAfter != time.Hour {
+		t.Errorf("requeueForGenerators() = %v, want the refresh interval without expiring values", got)
+	}
+	es.Status.GeneratorRefreshTime = &metav1.Time{Time: now.Add(10 * time.Minute)}
+	if got := requeueForGenerators(es, ctrl.Result{RequeueAfter: time.Hour}); got.RequeueAfter <= 0 || got.RequeueAfter > 10*time.Minute {
+		t.Errorf("requeueForGenerators() = %v, want the refresh time of the generated values", got)
+	}
+	if got := requeueForGenerators(es, ctrl.Result{}); got.RequeueAfter <= 0 {
+		t.Errorf("requeueForGenerators() = %v, want a requeue without a periodic refresh", got)
+	}
+	if generatorRefreshDue(es, now) || !generatorRefreshDue(es, now.Add(time.Hour)) {
+		t.Errorf("generatorRefreshDue() must be true once the refresh time has passed")
+	}
+}
@@ -0,0 +1,87 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalsecret
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+)
+
+func TestReconcileControllerIdentities(t *testing.T) {
+	newTestProvider(t).WithGetSecret([]byte("value"), nil)
+	es := &esv1beta1.ExternalSecret{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-es", Namespace: "default"},
+		Spec: esv1beta1.ExternalSecretSpec{
+			// every reconcile refreshes the secret, like a refresh interval that passed
+			RefreshInterval: &metav1.Duration{Duration: time.Nanosecond},
+			SecretStoreRef:  esv1beta1.SecretStoreRef{Name: "test-store"},
+			Target:          esv1beta1.ExternalSecretTarget{Name: "target", CreationPolicy: esv1beta1.CreatePolicyOrphan},
+			Data: []esv1beta1.ExternalSecretData{
+				{SecretKey: "foo", RemoteRef: esv1beta1.ExternalSecretDataRemoteRef{Key: "bar"}},
+			},
+		},
+	}
+	c := newTestClientBuilder(t, newTestStore(), es).
+		WithInterceptorFuncs(interceptor.Funcs{Create: createWithUID}).Build()
+	blue := newTestReconciler(c)
+	blue.ControllerIdentity = "blue"
+	green := newTestReconciler(c)
+	green.ControllerIdentity = "green"
+	ctx := context.Background()
+	key := types.NamespacedName{Name: "test-es", Namespace: "default"}
+
+	getStatus := func() (string, *esv1beta1.ExternalSecretStatusCondition) {
+		t.Helper()
+		got := &esv1beta1.ExternalSecret{}
+		if err := c.Get(ctx, key, got); err != nil {
+			t.Fatal(err)
+		}
+		return got.ResourceVersion, GetExternalSecretCondition(got.Status, esv1beta1.ExternalSecretReady)
+	}
+
+	for i := range 3 {
+		if _, err := blue.Reconcile(ctx, ctrl.Request{NamespacedName: key}); err != nil {
+			t.Fatalf("Reconcile(blue) error = %v", err)
+		}
+		version, cond := getStatus()
+		if cond == nil || cond.Status != v1.ConditionTrue {
+			t.Fatalf("round %d: expected blue to mark the ExternalSecret ready, got %v", i, cond)
+		}
+		if _, err := green.Reconcile(ctx, ctrl.Request{NamespacedName: key}); err != nil {
+			t.Fatalf("Reconcile(green) error = %v", err)
+		}
+		gotVersion, gotCond := getStatus()
+		if gotVersion != version || gotCond == nil || gotCond.Status != v1.ConditionTrue {
+			t.Errorf("round %d: green changed the ExternalSecret to version %s with Ready %v, want it untouched", i, gotVersion, gotCond)
+		}
+	}
+
+	secret := &v1.Secret{}
+	if err := c.Get(ctx, types.NamespacedName{Name: "target", Namespace: "default"}, secret); err != nil {
+		t.Fatal(err)
+	}
+	if got := secret.Labels[esv1beta1.LabelControllerIdentity]; got != "blue" {
+		t.Errorf("identity label = %q, want blue", got)
+	}
+}
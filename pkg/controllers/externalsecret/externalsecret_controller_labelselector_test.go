@@ -0,0 +1,159 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalsecret
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	"github.com/external-secrets/external-secrets/pkg/utils"
+)
+
+func TestReconcileLabelSelector(t *testing.T) {
+	newTestProvider(t).WithGetSecret([]byte("value"), nil)
+	newES := func(name, tenant string) *esv1beta1.ExternalSecret {
+		return &esv1beta1.ExternalSecret{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", Labels: map[string]string{"tenant": tenant}},
+			Spec: esv1beta1.ExternalSecretSpec{
+				RefreshInterval: &metav1.Duration{Duration: time.Hour},
+				SecretStoreRef:  esv1beta1.SecretStoreRef{Name: "test-store"},
+				Target:          esv1beta1.ExternalSecretTarget{Name: name, CreationPolicy: esv1beta1.CreatePolicyOrphan},
+				Data: []esv1beta1.ExternalSecretData{
+					{SecretKey: "foo", RemoteRef: esv1beta1.ExternalSecretDataRemoteRef{Key: "bar"}},
+				},
+			},
+		}
+	}
+	c := newTestClientBuilder(t, newTestStore(), newES("tenant-a", "a"), newES("tenant-b", "b")).Build()
+	r := newTestReconciler(c)
+	r.LabelSelector = labels.SelectorFromSet(labels.Set{"tenant": "a"})
+	ctx := context.Background()
+
+	// both ExternalSecrets are in the cache, e.g. requested through a watch of their target secret
+	for _, name := range []string{"tenant-a", "tenant-b"} {
+		if _, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: types.NamespacedName{Name: name, Namespace: "default"}}); err != nil {
+			t.Fatalf("Reconcile(%s) error = %v", name, err)
+		}
+	}
+	if err := c.Get(ctx, types.NamespacedName{Name: "tenant-a", Namespace: "default"}, &v1.Secret{}); err != nil {
+		t.Errorf("the secret of the matching ExternalSecret was not created: %v", err)
+	}
+	if err := c.Get(ctx, types.NamespacedName{Name: "tenant-b", Namespace: "default"}, &v1.Secret{}); !apierrors.IsNotFound(err) {
+		t.Errorf("expected no secret for the ExternalSecret that does not match the selector, got: %v", err)
+	}
+	got := &esv1beta1.ExternalSecret{}
+	if err := c.Get(ctx, types.NamespacedName{Name: "tenant-b", Namespace: "default"}, got); err != nil {
+		t.Fatal(err)
+	}
+	if len(got.Status.Conditions) != 0 {
+		t.Errorf("status.conditions = %v, want the ExternalSecret to be left untouched", got.Status.Conditions)
+	}
+
+	// without a selector every ExternalSecret is reconciled
+	r.LabelSelector = nil
+	if !r.matchesLabelSelector(got) {
+		t.Errorf("matchesLabelSelector() = false, want true without a selector")
+	}
+}
+
+func TestReconcileLabelSelectorFinalizer(t *testing.T) {
+	selector := labels.SelectorFromSet(labels.Set{"tenant": "a"})
+	newES := func(name string, deleted bool) *esv1beta1.ExternalSecret {
+		es := &esv1beta1.ExternalSecret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:       name,
+				Namespace:  "default",
+				Labels:     map[string]string{"tenant": "b"},
+				Finalizers: []string{externalSecretFinalizer},
+			},
+			Spec: esv1beta1.ExternalSecretSpec{
+				SecretStoreRef: esv1beta1.SecretStoreRef{Name: "test-store"},
+				Target:         esv1beta1.ExternalSecretTarget{Name: name},
+			},
+		}
+		if deleted {
+			es.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+		}
+		return es
+	}
+	// the target secret has no owner reference, it is deleted through the finalizer
+	secret := &v1.Secret{ObjectMeta: metav1.ObjectMeta{
+		Name:      "deleted",
+		Namespace: "default",
+		Labels: map[string]string{
+			esv1beta1.LabelManaged: esv1beta1.LabelManagedValue,
+			esv1beta1.LabelOwner:   utils.ObjectHash("default/deleted"),
+		},
+	}}
+	c := newTestClientBuilder(t, newTestStore(), newES("deleted", true), newES("relabeled", false), secret).
+		WithInterceptorFuncs(interceptor.Funcs{Delete: deleteWithSecretKind}).Build()
+	// the cache only holds the ExternalSecrets that match the selector
+	cached := interceptor.NewClient(c, interceptor.Funcs{
+		Get: func(ctx context.Context, c client.WithWatch, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+			if err := c.Get(ctx, key, obj, opts...); err != nil {
+				return err
+			}
+			if _, ok := obj.(*esv1beta1.ExternalSecret); ok && !selector.Matches(labels.Set(obj.GetLabels())) {
+				return apierrors.NewNotFound(esv1beta1.SchemeGroupVersion.WithResource("externalsecrets").GroupResource(), key.Name)
+			}
+			return nil
+		},
+	})
+	r := newTestReconciler(cached)
+	r.LabelSelector = selector
+	r.APIReader = c
+	ctx := context.Background()
+
+	t.Run("a deleted ExternalSecret that lost its label is finalized", func(t *testing.T) {
+		if _, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: types.NamespacedName{Name: "deleted", Namespace: "default"}}); err != nil {
+			t.Fatalf("Reconcile() error = %v", err)
+		}
+		if err := c.Get(ctx, types.NamespacedName{Name: "deleted", Namespace: "default"}, &v1.Secret{}); !apierrors.IsNotFound(err) {
+			t.Errorf("expected the target secret to be deleted, got: %v", err)
+		}
+		if err := c.Get(ctx, types.NamespacedName{Name: "deleted", Namespace: "default"}, &esv1beta1.ExternalSecret{}); !apierrors.IsNotFound(err) {
+			t.Errorf("expected the finalizer to be removed and the ExternalSecret to be gone, got: %v", err)
+		}
+	})
+
+	t.Run("an ExternalSecret that lost its label is checked again until it is deleted", func(t *testing.T) {
+		res, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: types.NamespacedName{Name: "relabeled", Namespace: "default"}})
+		if err != nil {
+			t.Fatalf("Reconcile() error = %v", err)
+		}
+		if res.RequeueAfter != r.RequeueInterval {
+			t.Errorf("RequeueAfter = %v, want %v", res.RequeueAfter, r.RequeueInterval)
+		}
+		got := &esv1beta1.ExternalSecret{}
+		if err := c.Get(ctx, types.NamespacedName{Name: "relabeled", Namespace: "default"}, got); err != nil {
+			t.Fatal(err)
+		}
+		if !controllerutil.ContainsFinalizer(got, externalSecretFinalizer) || len(got.Status.Conditions) != 0 {
+			t.Errorf("expected the ExternalSecret to be left untouched, got finalizers %v and conditions %v", got.Finalizers, got.Status.Conditions)
+		}
+	})
+}
@@ -0,0 +1,74 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalsecret
+
+import (
+	"errors"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+)
+
+// entryError is the error of a single data or dataFrom entry.
+// It keeps the message of the Ready condition, the fields are recorded in status.lastError.
+type entryError struct {
+	path     string
+	key      string
+	storeRef *esv1beta1.SecretStoreRef
+	// cause is the error returned for the entry.
+	cause error
+	// err is cause with the context of the entry.
+	err error
+}
+
+func (e *entryError) Error() string {
+	return e.err.Error()
+}
+
+func (e *entryError) Unwrap() error {
+	return e.err
+}
+
+// newEntryError wraps err, which adds the context of the entry to cause.
+func newEntryError(es *esv1beta1.ExternalSecret, path, key string, sourceRef *esv1beta1.StoreGeneratorSourceRef, cause, err error) error {
+	entryErr := &entryError{
+		path:  path,
+		key:   key,
+		cause: cause,
+		err:   err,
+	}
+	// the store is ambiguous for store groups and not used for generators
+	if sourceRef == nil || (len(sourceRef.StoreGroup) == 0 && sourceRef.GeneratorRef == nil) {
+		entryErr.storeRef = resolvedStoreRef(es, sourceRef, nil)
+	}
+	return entryErr
+}
+
+// setLastError records err in status.lastError, with the reason of the Ready condition.
+func setLastError(es *esv1beta1.ExternalSecret, reason string, err error) {
+	lastErr := &esv1beta1.ExternalSecretLastError{
+		Reason: reason,
+		Error:  redactRemoteKeys(es, err.Error()),
+	}
+	var entryErr *entryError
+	if errors.As(err, &entryErr) {
+		lastErr.Path = entryErr.path
+		lastErr.StoreRef = entryErr.storeRef
+		lastErr.Error = redactRemoteKeys(es, entryErr.cause.Error())
+		if entryErr.key != "" {
+			lastErr.Key = redactRemoteKeys(es, entryErr.key)
+		}
+	}
+	es.Status.LastError = lastErr
+}
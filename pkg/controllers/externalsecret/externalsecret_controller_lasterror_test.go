@@ -0,0 +1,190 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalsecret
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/prometheus/client_golang/prometheus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+)
+
+func TestReconcileLastError(t *testing.T) {
+	es := &esv1beta1.ExternalSecret{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-es", Namespace: "default"},
+		Spec: esv1beta1.ExternalSecretSpec{
+			RefreshInterval: &metav1.Duration{Duration: time.Hour},
+			SecretStoreRef:  esv1beta1.SecretStoreRef{Name: "test-store"},
+			Target: esv1beta1.ExternalSecretTarget{
+				Name:           "target",
+				CreationPolicy: esv1beta1.CreatePolicyOwner,
+			},
+			Data: []esv1beta1.ExternalSecretData{
+				{SecretKey: "foo", RemoteRef: esv1beta1.ExternalSecretDataRemoteRef{Key: "bar"}},
+			},
+		},
+	}
+	c := newTestClientBuilder(t, newTestStore(), es).Build()
+	r := newTestReconciler(c)
+	key := types.NamespacedName{Name: "test-es", Namespace: "default"}
+
+	provider := newTestProvider(t)
+	provider.WithGetSecret(nil, errors.New("provider is down"))
+	if _, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: key}); err == nil {
+		t.Fatalf("Reconcile() did not return the provider error")
+	}
+	got := &esv1beta1.ExternalSecret{}
+	if err := c.Get(context.Background(), key, got); err != nil {
+		t.Fatal(err)
+	}
+	want := &esv1beta1.ExternalSecretLastError{
+		Path:     "spec.data[0]",
+		Key:      "bar",
+		StoreRef: &esv1beta1.SecretStoreRef{Name: "test-store", Kind: esv1beta1.SecretStoreKind},
+		Reason:   esv1beta1.ConditionReasonSecretSyncedError,
+		Error:    "provider is down",
+	}
+	if diff := cmp.Diff(want, got.Status.LastError); diff != "" {
+		t.Errorf("unexpected status.lastError (-want +got):\n%s", diff)
+	}
+	cond := GetExternalSecretCondition(got.Status, esv1beta1.ExternalSecretReady)
+	if cond == nil || cond.Message != msgErrorGetSecretData {
+		t.Errorf("unexpected Ready condition: %v", cond)
+	}
+
+	provider.WithGetSecret([]byte("value"), nil)
+	if _, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: key}); err != nil {
+		t.Fatalf("Reconcile() returned an error: %v", err)
+	}
+	if err := c.Get(context.Background(), key, got); err != nil {
+		t.Fatal(err)
+	}
+	if got.Status.LastError != nil {
+		t.Errorf("status.lastError was not removed after the sync: %v", got.Status.LastError)
+	}
+}
+
+func TestSetLastError(t *testing.T) {
+	es := &esv1beta1.ExternalSecret{
+		Spec: esv1beta1.ExternalSecretSpec{
+			SecretStoreRef: esv1beta1.SecretStoreRef{Name: "default-store"},
+			StatusPolicy:   &esv1beta1.ExternalSecretStatusPolicy{RemoteKeys: esv1beta1.KeyVisibilityOmit},
+			DataFrom: []esv1beta1.ExternalSecretDataFromRemoteRef{
+				{Extract: &esv1beta1.ExternalSecretDataRemoteRef{Key: "team/db"}},
+			},
+		},
+	}
+	tests := []struct {
+		name string
+		err  error
+		want *esv1beta1.ExternalSecretLastError
+	}{
+		{
+			name: "error of an entry",
+			err: newEntryError(es, "spec.dataFrom[0]", "team/db", &esv1beta1.StoreGeneratorSourceRef{
+				SecretStoreRef: &esv1beta1.SecretStoreRef{Name: "other-store", Kind: esv1beta1.ClusterSecretStoreKind},
+			}, errors.New("team/db: access denied"), errors.New("error processing spec.dataFrom[0].extract, err: team/db: access denied")),
+			want: &esv1beta1.ExternalSecretLastError{
+				Path:     "spec.dataFrom[0]",
+				Key:      redactedKey,
+				StoreRef: &esv1beta1.SecretStoreRef{Name: "other-store", Kind: esv1beta1.ClusterSecretStoreKind},
+				Reason:   esv1beta1.ConditionReasonSecretSyncedError,
+				Error:    redactedKey + ": access denied",
+			},
+		},
+		{
+			name: "no store for store groups",
+			err: newEntryError(es, "spec.dataFrom[0]", "", &esv1beta1.StoreGeneratorSourceRef{
+				StoreGroup: []esv1beta1.SecretStoreRef{{Name: "a"}, {Name: "b"}},
+			}, errors.New("not found"), errors.New("error processing spec.dataFrom[0].find, err: not found")),
+			want: &esv1beta1.ExternalSecretLastError{
+				Path:   "spec.dataFrom[0]",
+				Reason: esv1beta1.ConditionReasonSecretSyncedError,
+				Error:  "not found",
+			},
+		},
+		{
+			name: "error without an entry",
+			err:  errors.New("unable to update secret target"),
+			want: &esv1beta1.ExternalSecretLastError{
+				Reason: esv1beta1.ConditionReasonSecretSyncedError,
+				Error:  "unable to update secret target",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setLastError(es, esv1beta1.ConditionReasonSecretSyncedError, tt.err)
+			if diff := cmp.Diff(tt.want, es.Status.LastError); diff != "" {
+				t.Errorf("unexpected status.lastError (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestMarkAsRedactsRemoteKeys(t *testing.T) {
+	es := &esv1beta1.ExternalSecret{
+		Spec: esv1beta1.ExternalSecretSpec{
+			StatusPolicy: &esv1beta1.ExternalSecretStatusPolicy{RemoteKeys: esv1beta1.KeyVisibilityOmit},
+			Data: []esv1beta1.ExternalSecretData{
+				{SecretKey: "password", RemoteRef: esv1beta1.ExternalSecretDataRemoteRef{Key: "team/db"}},
+			},
+		},
+	}
+	err := errors.New("team/db: access denied")
+	tests := []struct {
+		name string
+		mark func(r *Reconciler)
+	}{
+		{
+			name: "store denied",
+			mark: func(r *Reconciler) {
+				r.markAsStoreDenied(err, es, prometheus.NewCounter(prometheus.CounterOpts{Name: "test"}))
+			},
+		},
+		{
+			name: "generator not ready",
+			mark: func(r *Reconciler) { r.markAsGeneratorNotReady(err, es) },
+		},
+		{
+			name: "quota exceeded",
+			mark: func(r *Reconciler) { r.markAsQuotaExceeded(err, es) },
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			es.Status = esv1beta1.ExternalSecretStatus{}
+			recorder := record.NewFakeRecorder(1)
+			tt.mark(&Reconciler{recorder: recorder})
+			event := <-recorder.Events
+			if strings.Contains(event, "team/db") || !strings.Contains(event, redactedKey+": access denied") {
+				t.Errorf("event does not redact the remote key: %q", event)
+			}
+			if es.Status.LastError != nil && strings.Contains(es.Status.LastError.Error, "team/db") {
+				t.Errorf("status.lastError does not redact the remote key: %q", es.Status.LastError.Error)
+			}
+		})
+	}
+}
@@ -0,0 +1,70 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalsecret
+
+import (
+	"encoding/json"
+	"fmt"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	"github.com/external-secrets/external-secrets/pkg/utils"
+)
+
+// NOTE: merge errors must never contain the secret value.
+const errMergeMarshal = "unable to merge JSON value of key %q"
+
+// mergeDataFrom merges the keys of a dataFrom entry into the keys of the previous entries.
+// With DeepMerge, a key whose existing and new values are both JSON objects is merged
+// recursively, the new value wins on conflicts and the result is rendered with sorted keys.
+// All other keys are replaced.
+func mergeDataFrom(strategy esv1beta1.ExternalSecretMergeStrategy, dst, src map[string][]byte) (map[string][]byte, error) {
+	if strategy != esv1beta1.MergeStrategyDeepMerge {
+		return utils.MergeByteMap(dst, src), nil
+	}
+	for key, value := range src {
+		existing, found := dst[key]
+		if !found {
+			dst[key] = value
+			continue
+		}
+		existingObj, ok := parseJSONObject(existing)
+		if !ok {
+			dst[key] = value
+			continue
+		}
+		valueObj, ok := parseJSONObject(value)
+		if !ok {
+			dst[key] = value
+			continue
+		}
+		// the override policy never returns an error
+		_ = mergeMap(existingObj, valueObj, "", key, esv1beta1.BundleConflictOverride)
+		merged, err := json.Marshal(existingObj)
+		if err != nil {
+			return nil, fmt.Errorf(errMergeMarshal, key)
+		}
+		dst[key] = merged
+	}
+	return dst, nil
+}
+
+// parseJSONObject returns the value as a map if it is a JSON object.
+func parseJSONObject(value []byte) (map[string]any, bool) {
+	var obj map[string]any
+	if err := json.Unmarshal(value, &obj); err != nil || obj == nil {
+		return nil, false
+	}
+	return obj, true
+}
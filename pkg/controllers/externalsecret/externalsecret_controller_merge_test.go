@@ -0,0 +1,135 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalsecret
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+)
+
+func TestMergeDataFrom(t *testing.T) {
+	entries := []map[string][]byte{
+		{
+			"config": []byte(`{"db":{"host":"db.example.com","port":5432,"tls":{"enabled":false}},"users":["admin"]}`),
+			"token":  []byte("first"),
+			"list":   []byte(`["a"]`),
+			"mixed":  []byte(`{"a":"s3cr3t"}`),
+		},
+		{
+			"config": []byte(`{"db":{"port":6432,"tls":{"enabled":true,"ca":"ca.pem"}},"users":["app"],"debug":true}`),
+			"token":  []byte("second"),
+			"list":   []byte(`["b"]`),
+			"mixed":  []byte("plain"),
+			"new":    []byte(`{"x":1}`),
+		},
+	}
+	tests := []struct {
+		name     string
+		strategy esv1beta1.ExternalSecretMergeStrategy
+		want     map[string][]byte
+	}{
+		{
+			name: "default overwrites keys",
+			want: entries[1],
+		},
+		{
+			name:     "overwrite replaces keys",
+			strategy: esv1beta1.MergeStrategyOverwrite,
+			want:     entries[1],
+		},
+		{
+			name:     "deep merge merges nested objects",
+			strategy: esv1beta1.MergeStrategyDeepMerge,
+			want: map[string][]byte{
+				"config": []byte(`{"db":{"host":"db.example.com","port":6432,"tls":{"ca":"ca.pem","enabled":true}},"debug":true,"users":["app"]}`),
+				"token":  []byte("second"),
+				"list":   []byte(`["b"]`),
+				"mixed":  []byte("plain"),
+				"new":    []byte(`{"x":1}`),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := make(map[string][]byte)
+			for _, entry := range entries {
+				var err error
+				got, err = mergeDataFrom(tt.strategy, got, entry)
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+			}
+			if diff := cmp.Diff(stringMap(tt.want), stringMap(got)); diff != "" {
+				t.Errorf("unexpected result (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestMergeDataFromPlainAfterObject(t *testing.T) {
+	got, err := mergeDataFrom(esv1beta1.MergeStrategyDeepMerge,
+		map[string][]byte{"key": []byte("plain")},
+		map[string][]byte{"key": []byte(`{"a":1}`)})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(got["key"]) != `{"a":1}` {
+		t.Errorf("unexpected value %q", got["key"])
+	}
+}
+
+func stringMap(in map[string][]byte) map[string]string {
+	out := make(map[string]string, len(in))
+	for k, v := range in {
+		out[k] = string(v)
+	}
+	return out
+}
+
+func TestMergeDataPrecedence(t *testing.T) {
+	tests := []struct {
+		name       string
+		precedence esv1beta1.ExternalSecretMergePrecedence
+		want       map[string][]byte
+	}{
+		{
+			name: "data wins by default",
+			want: map[string][]byte{"shared": []byte("data"), "from-data": []byte("1"), "from-datafrom": []byte("2")},
+		},
+		{
+			name:       "data wins",
+			precedence: esv1beta1.MergePrecedenceData,
+			want:       map[string][]byte{"shared": []byte("data"), "from-data": []byte("1"), "from-datafrom": []byte("2")},
+		},
+		{
+			name:       "dataFrom wins",
+			precedence: esv1beta1.MergePrecedenceDataFrom,
+			want:       map[string][]byte{"shared": []byte("dataFrom"), "from-data": []byte("1"), "from-datafrom": []byte("2")},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dataFrom := map[string][]byte{"shared": []byte("dataFrom"), "from-datafrom": []byte("2")}
+			data := map[string][]byte{"shared": []byte("data"), "from-data": []byte("1")}
+			got := mergeDataPrecedence(tt.precedence, dataFrom, data)
+			if diff := cmp.Diff(stringMap(tt.want), stringMap(got)); diff != "" {
+				t.Errorf("unexpected data (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
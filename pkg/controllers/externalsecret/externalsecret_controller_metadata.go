@@ -0,0 +1,38 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalsecret
+
+import (
+	v1 "k8s.io/api/core/v1"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+)
+
+// annotateProviderMetadata writes the provider metadata selected in target.metadataAnnotations
+// to the annotations of the secret. Keys that were not reported are not written,
+// their annotations from a previous sync are removed with the other annotations of the ExternalSecret.
+// Sensitive keys are skipped, in case they were not rejected by the webhook.
+func annotateProviderMetadata(es *esv1beta1.ExternalSecret, secret *v1.Secret, metadata map[string]string) {
+	for _, key := range es.Spec.Target.MetadataAnnotations {
+		value, ok := metadata[key]
+		if !ok || esv1beta1.IsSensitiveMetadataKey(key) {
+			continue
+		}
+		if secret.Annotations == nil {
+			secret.Annotations = make(map[string]string)
+		}
+		secret.Annotations[esv1beta1.AnnotationProviderMetadataPrefix+key] = value
+	}
+}
@@ -0,0 +1,71 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalsecret
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	"github.com/external-secrets/external-secrets/pkg/provider/testing/fake"
+)
+
+func TestAnnotateProviderMetadata(t *testing.T) {
+	// the provider reports the metadata while the secret is read
+	client := fake.New()
+	client.GetSecretFn = func(ctx context.Context, _ esv1beta1.ExternalSecretDataRemoteRef) ([]byte, error) {
+		esv1beta1.ReportProviderMetadata(ctx, map[string]string{
+			"source":        "vault-eu",
+			"retrieved_at":  "2024-01-02T03:04:05Z",
+			"session_token": "s.abcdef",
+			"unused":        "value",
+		})
+		return []byte("value"), nil
+	}
+	ctx, metadata := esv1beta1.ContextWithProviderMetadata(context.Background())
+	data := map[string][]byte{}
+	if err := getSecretData(ctx, client, esv1beta1.ExternalSecretData{SecretKey: "foo", RemoteRef: esv1beta1.ExternalSecretDataRemoteRef{Key: "foo"}}, data); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	es := &esv1beta1.ExternalSecret{
+		ObjectMeta: metav1.ObjectMeta{Name: "es"},
+		Spec: esv1beta1.ExternalSecretSpec{
+			Target: esv1beta1.ExternalSecretTarget{
+				MetadataAnnotations: []string{"source", "retrieved_at", "session_token", "missing"},
+			},
+		},
+	}
+	secret := &v1.Secret{}
+	annotateProviderMetadata(es, secret, metadata.Get())
+	want := map[string]string{
+		esv1beta1.AnnotationProviderMetadataPrefix + "source":       "vault-eu",
+		esv1beta1.AnnotationProviderMetadataPrefix + "retrieved_at": "2024-01-02T03:04:05Z",
+	}
+	if diff := cmp.Diff(want, secret.Annotations); diff != "" {
+		t.Errorf("unexpected annotations (-want +got):\n%s", diff)
+	}
+
+	// without a collector the report is a no-op
+	esv1beta1.ReportProviderMetadata(context.Background(), map[string]string{"source": "other"})
+	var none *esv1beta1.ProviderMetadata
+	if got := none.Get(); got != nil {
+		t.Errorf("unexpected metadata without a collector: %v", got)
+	}
+}
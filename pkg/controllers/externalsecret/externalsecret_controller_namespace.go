@@ -0,0 +1,109 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalsecret
+
+import (
+	"context"
+	"fmt"
+	"maps"
+
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	"github.com/external-secrets/external-secrets/pkg/utils"
+)
+
+// isNamespaceTerminating returns true if the namespace is being deleted.
+// The API server rejects new objects in a terminating namespace, so writing the target secret
+// would fail on every reconcile until the namespace is gone.
+// A namespace that does not exist is not terminating, the write reports the actual error.
+func (r *Reconciler) isNamespaceTerminating(ctx context.Context, name string) (bool, error) {
+	namespace := &v1.Namespace{}
+	if err := r.Get(ctx, client.ObjectKey{Name: name}, namespace); err != nil {
+		return false, client.IgnoreNotFound(err)
+	}
+	return namespace.Status.Phase == v1.NamespaceTerminating || !namespace.DeletionTimestamp.IsZero(), nil
+}
+
+// usesNamespaceMetadata returns true if the target secret of the ExternalSecret is rendered
+// with the metadata of its namespace, that is if it has a template and the option is enabled.
+func (r *Reconciler) usesNamespaceMetadata(es *esv1beta1.ExternalSecret) bool {
+	return r.TemplateNamespaceMetadata && (es.Spec.Target.Template != nil || es.Spec.Target.TemplateRef != nil)
+}
+
+// namespaceMetadata returns the name, labels and annotations of the namespace,
+// as they are available as `.Namespace` in templates.
+// A namespace that does not exist has no labels and annotations, the write of the secret reports the actual error.
+func (r *Reconciler) namespaceMetadata(ctx context.Context, name string) (map[string]any, error) {
+	labels := make(map[string]string)
+	annotations := make(map[string]string)
+	namespace := &v1.Namespace{}
+	err := r.Get(ctx, client.ObjectKey{Name: name}, namespace)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return nil, fmt.Errorf(errGetNamespace, name, err)
+	}
+	if err == nil {
+		maps.Copy(labels, namespace.Labels)
+		maps.Copy(annotations, namespace.Annotations)
+	}
+	return map[string]any{
+		"name":        name,
+		"labels":      labels,
+		"annotations": annotations,
+	}, nil
+}
+
+// namespaceMetadataChanged returns true if the labels or annotations of the namespace changed
+// since the target secret was rendered, so the templates are rendered again.
+// If the namespace can not be read, the sync runs and reports the error.
+// With CreationPolicy None or in read-only mode there is no secret to render.
+func (r *Reconciler) namespaceMetadataChanged(ctx context.Context, es *esv1beta1.ExternalSecret, secret *v1.Secret) bool {
+	if !r.usesNamespaceMetadata(es) || r.skipsSecretWrites(es) {
+		return false
+	}
+	metadata, err := r.namespaceMetadata(ctx, es.Namespace)
+	if err != nil {
+		return true
+	}
+	return secret.Annotations[esv1beta1.AnnotationNamespaceHash] != utils.ObjectHash(metadata)
+}
+
+// findObjectsForNamespace returns the ExternalSecrets of the namespace that are rendered with its metadata.
+func (r *Reconciler) findObjectsForNamespace(ctx context.Context, namespace client.Object) []reconcile.Request {
+	externalSecretsList := &esv1beta1.ExternalSecretList{}
+	err := r.List(ctx, externalSecretsList, client.InNamespace(namespace.GetName()))
+	if err != nil {
+		return []reconcile.Request{}
+	}
+
+	requests := make([]reconcile.Request, 0, len(externalSecretsList.Items))
+	for i := range externalSecretsList.Items {
+		es := &externalSecretsList.Items[i]
+		if !r.usesNamespaceMetadata(es) {
+			continue
+		}
+		requests = append(requests, reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Name:      es.Name,
+				Namespace: es.Namespace,
+			},
+		})
+	}
+	return requests
+}
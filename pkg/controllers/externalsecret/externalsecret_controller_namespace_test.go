@@ -0,0 +1,181 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalsecret
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+)
+
+func TestIsNamespaceTerminating(t *testing.T) {
+	tests := []struct {
+		name      string
+		namespace *v1.Namespace
+		want      bool
+	}{
+		{
+			name: "active namespace",
+			namespace: &v1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{Name: "foo"},
+				Status:     v1.NamespaceStatus{Phase: v1.NamespaceActive},
+			},
+		},
+		{
+			name: "terminating phase",
+			namespace: &v1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{Name: "foo"},
+				Status:     v1.NamespaceStatus{Phase: v1.NamespaceTerminating},
+			},
+			want: true,
+		},
+		{
+			name: "deletion timestamp before the phase is updated",
+			namespace: &v1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:              "foo",
+					DeletionTimestamp: ptr.To(metav1.Now()),
+					Finalizers:        []string{"kubernetes"},
+				},
+				Status: v1.NamespaceStatus{Phase: v1.NamespaceActive},
+			},
+			want: true,
+		},
+		{
+			name: "missing namespace",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			objs := []client.Object{}
+			if tt.namespace != nil {
+				objs = append(objs, tt.namespace)
+			}
+			r := &Reconciler{Client: fakeclient.NewClientBuilder().WithObjects(objs...).Build()}
+			got, err := r.isNamespaceTerminating(context.Background(), "foo")
+			if err != nil {
+				t.Fatalf("isNamespaceTerminating() returned an unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("isNamespaceTerminating() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestApplyTemplateNamespaceMetadata(t *testing.T) {
+	namespace := &v1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   "foo",
+			Labels: map[string]string{"tenant": "acme"},
+		},
+	}
+	es := &esv1beta1.ExternalSecret{
+		ObjectMeta: metav1.ObjectMeta{Name: "es", Namespace: "foo"},
+		Spec: esv1beta1.ExternalSecretSpec{
+			Target: esv1beta1.ExternalSecretTarget{
+				Template: &esv1beta1.ExternalSecretTemplate{
+					EngineVersion: esv1beta1.TemplateEngineV2,
+					Data: map[string]string{
+						"url": "https://{{ .Namespace.labels.tenant }}.example.com/{{ .Namespace.name }}",
+					},
+				},
+			},
+		},
+	}
+	cl := fakeclient.NewClientBuilder().WithObjects(namespace).Build()
+	r := &Reconciler{Client: cl, TemplateNamespaceMetadata: true}
+
+	secret := &v1.Secret{}
+	if err := r.applyTemplate(context.Background(), es, secret, map[string][]byte{}, nil); err != nil {
+		t.Fatalf("applyTemplate() returned an unexpected error: %v", err)
+	}
+	if diff := cmp.Diff(stringMap(map[string][]byte{"url": []byte("https://acme.example.com/foo")}), stringMap(secret.Data)); diff != "" {
+		t.Errorf("unexpected data (-want +got):\n%s", diff)
+	}
+	if r.namespaceMetadataChanged(context.Background(), es, secret) {
+		t.Errorf("namespaceMetadataChanged() = true right after rendering")
+	}
+
+	namespace.Labels["tenant"] = "other"
+	if err := cl.Update(context.Background(), namespace); err != nil {
+		t.Fatalf("unable to update the namespace: %v", err)
+	}
+	if !r.namespaceMetadataChanged(context.Background(), es, secret) {
+		t.Errorf("namespaceMetadataChanged() = false after the labels changed")
+	}
+
+	r.TemplateNamespaceMetadata = false
+	if r.namespaceMetadataChanged(context.Background(), es, secret) {
+		t.Errorf("namespaceMetadataChanged() = true with the option disabled")
+	}
+}
+
+func TestNamespaceMetadata(t *testing.T) {
+	r := &Reconciler{Client: fakeclient.NewClientBuilder().Build()}
+	got, err := r.namespaceMetadata(context.Background(), "missing")
+	if err != nil {
+		t.Fatalf("namespaceMetadata() returned an unexpected error: %v", err)
+	}
+	want := map[string]any{
+		"name":        "missing",
+		"labels":      map[string]string{},
+		"annotations": map[string]string{},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("unexpected metadata (-want +got):\n%s", diff)
+	}
+}
+
+func TestFindObjectsForNamespace(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	if err := esv1beta1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	newES := func(namespace, name string, target esv1beta1.ExternalSecretTarget) client.Object {
+		return &esv1beta1.ExternalSecret{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+			Spec:       esv1beta1.ExternalSecretSpec{Target: target},
+		}
+	}
+	cl := fakeclient.NewClientBuilder().WithScheme(scheme).WithObjects(
+		newES("foo", "template", esv1beta1.ExternalSecretTarget{Template: &esv1beta1.ExternalSecretTemplate{}}),
+		newES("foo", "template-ref", esv1beta1.ExternalSecretTarget{TemplateRef: &esv1beta1.ExternalSecretTemplateRef{Name: "base"}}),
+		newES("foo", "no-template", esv1beta1.ExternalSecretTarget{}),
+		newES("bar", "other-namespace", esv1beta1.ExternalSecretTarget{Template: &esv1beta1.ExternalSecretTemplate{}}),
+	).Build()
+	r := &Reconciler{Client: cl, TemplateNamespaceMetadata: true}
+
+	var got []string
+	for _, req := range r.findObjectsForNamespace(context.Background(), &v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "foo"}}) {
+		got = append(got, req.Name)
+	}
+	if diff := cmp.Diff([]string{"template", "template-ref"}, got); diff != "" {
+		t.Errorf("unexpected requests (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,112 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalsecret
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+)
+
+const (
+	eventOnErrorCleared = "secret cleared due to OnError=Clear, the sync has been failing for %s"
+	eventOnErrorDeleted = "secret deleted due to OnError=Delete, the sync has been failing for %s"
+	errOnErrorClear     = "unable to clear secret %s: %w"
+	errOnErrorDelete    = "unable to delete secret %s: %w"
+)
+
+// applyOnErrorPolicy applies spec.target.onError to the existing secret once the sync
+// has been failing for longer than spec.target.onErrorAfter.
+// It must be called after the Ready condition was set to False, the last transition time
+// of the condition is the time the sync started to fail.
+func (r *Reconciler) applyOnErrorPolicy(ctx context.Context, es *esv1beta1.ExternalSecret, existingSecret *v1.Secret) error {
+	policy := es.Spec.Target.OnError
+	if policy != esv1beta1.OnErrorClear && policy != esv1beta1.OnErrorDelete {
+		return nil
+	}
+	// the secret is only changed if the controller owns it,
+	// this is also implemented in the es validation webhook.
+	if r.skipsSecretWrites(es) || !isOnErrorCreationPolicy(es.Spec.Target.CreationPolicy) {
+		return nil
+	}
+	if existingSecret.UID == "" || !isOnErrorTarget(existingSecret, es, r.ControllerIdentity) {
+		return nil
+	}
+
+	failingFor, ok := onErrorFailingFor(es)
+	if !ok {
+		return nil
+	}
+
+	switch policy {
+	case esv1beta1.OnErrorClear:
+		if len(existingSecret.Data) == 0 {
+			return nil
+		}
+		if ptr.Deref(existingSecret.Immutable, false) {
+			return fmt.Errorf(errOnErrorClear, existingSecret.Name, ErrSecretImmutable)
+		}
+		fqdn := fmt.Sprintf(fieldOwnerTemplate, es.Name)
+		patch := client.MergeFrom(existingSecret.DeepCopy())
+		existingSecret.Data = nil
+		if err := r.Patch(ctx, existingSecret, patch, client.FieldOwner(fqdn)); err != nil {
+			return fmt.Errorf(errOnErrorClear, existingSecret.Name, err)
+		}
+		r.recorder.Event(es, v1.EventTypeWarning, esv1beta1.ReasonCleared, fmt.Sprintf(eventOnErrorCleared, failingFor))
+	case esv1beta1.OnErrorDelete:
+		if err := r.Delete(ctx, existingSecret); err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf(errOnErrorDelete, existingSecret.Name, err)
+		}
+		r.recorder.Event(es, v1.EventTypeWarning, esv1beta1.ReasonDeleted, fmt.Sprintf(eventOnErrorDeleted, failingFor))
+	}
+	return nil
+}
+
+// onErrorFailingFor returns for how long the sync has been failing,
+// and whether this exceeds spec.target.onErrorAfter.
+func onErrorFailingFor(es *esv1beta1.ExternalSecret) (time.Duration, bool) {
+	cond := GetExternalSecretCondition(es.Status, esv1beta1.ExternalSecretReady)
+	if cond == nil || cond.Status != v1.ConditionFalse {
+		return 0, false
+	}
+	threshold := esv1beta1.DefaultOnErrorAfter
+	if after := es.Spec.Target.OnErrorAfter; after != nil && after.Duration > 0 {
+		threshold = after.Duration
+	}
+	failingFor := time.Since(cond.LastTransitionTime.Time).Round(time.Second)
+	return failingFor, failingFor >= threshold
+}
+
+func isOnErrorCreationPolicy(policy esv1beta1.ExternalSecretCreationPolicy) bool {
+	return policy == "" || policy == esv1beta1.CreatePolicyOwner || policy == esv1beta1.CreatePolicyOrphan
+}
+
+// isOnErrorTarget returns true if the secret is managed by this controller
+// and not controlled by another object.
+func isOnErrorTarget(secret *v1.Secret, es *esv1beta1.ExternalSecret, identity string) bool {
+	if secret.Labels[esv1beta1.LabelManaged] != esv1beta1.LabelManagedValue || isManagedByOtherIdentity(secret, identity) {
+		return false
+	}
+	owner := metav1.GetControllerOf(secret)
+	return owner == nil || owner.UID == es.UID
+}
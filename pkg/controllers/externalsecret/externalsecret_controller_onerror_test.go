@@ -0,0 +1,162 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalsecret
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+)
+
+func TestReconcileOnError(t *testing.T) {
+	newExternalSecret := func(policy esv1beta1.ExternalSecretOnErrorPolicy, after *metav1.Duration, failingSince time.Duration) *esv1beta1.ExternalSecret {
+		return &esv1beta1.ExternalSecret{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-es", Namespace: "default"},
+			Spec: esv1beta1.ExternalSecretSpec{
+				RefreshInterval: &metav1.Duration{Duration: time.Hour},
+				SecretStoreRef:  esv1beta1.SecretStoreRef{Name: "test-store"},
+				Target: esv1beta1.ExternalSecretTarget{
+					Name:           "target",
+					CreationPolicy: esv1beta1.CreatePolicyOrphan,
+					OnError:        policy,
+					OnErrorAfter:   after,
+				},
+				Data: []esv1beta1.ExternalSecretData{
+					{SecretKey: "foo", RemoteRef: esv1beta1.ExternalSecretDataRemoteRef{Key: "bar"}},
+				},
+			},
+			Status: esv1beta1.ExternalSecretStatus{
+				Conditions: []esv1beta1.ExternalSecretStatusCondition{
+					{
+						Type:               esv1beta1.ExternalSecretReady,
+						Status:             v1.ConditionFalse,
+						Reason:             esv1beta1.ConditionReasonSecretSyncedError,
+						Message:            msgErrorGetSecretData,
+						LastTransitionTime: metav1.NewTime(time.Now().Add(-failingSince)),
+					},
+				},
+			},
+		}
+	}
+	tests := []struct {
+		name         string
+		policy       esv1beta1.ExternalSecretOnErrorPolicy
+		after        *metav1.Duration
+		failingSince time.Duration
+		wantSecret   map[string]string
+		wantDeleted  bool
+	}{
+		{
+			name:         "retain by default",
+			failingSince: 48 * time.Hour,
+			wantSecret:   map[string]string{"foo": "old"},
+		},
+		{
+			name:         "clear before the default threshold",
+			policy:       esv1beta1.OnErrorClear,
+			failingSince: 30 * time.Minute,
+			wantSecret:   map[string]string{"foo": "old"},
+		},
+		{
+			name:         "clear after the default threshold",
+			policy:       esv1beta1.OnErrorClear,
+			failingSince: 2 * time.Hour,
+			wantSecret:   map[string]string{},
+		},
+		{
+			name:         "clear after a custom threshold",
+			policy:       esv1beta1.OnErrorClear,
+			after:        &metav1.Duration{Duration: 5 * time.Minute},
+			failingSince: 10 * time.Minute,
+			wantSecret:   map[string]string{},
+		},
+		{
+			name:         "delete before a custom threshold",
+			policy:       esv1beta1.OnErrorDelete,
+			after:        &metav1.Duration{Duration: 24 * time.Hour},
+			failingSince: 2 * time.Hour,
+			wantSecret:   map[string]string{"foo": "old"},
+		},
+		{
+			name:         "delete after the threshold",
+			policy:       esv1beta1.OnErrorDelete,
+			after:        &metav1.Duration{Duration: 5 * time.Minute},
+			failingSince: 10 * time.Minute,
+			wantDeleted:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			newTestProvider(t).WithGetSecret(nil, errors.New("provider is down"))
+			secret := &v1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "target",
+					Namespace: "default",
+					UID:       "target-uid",
+					Labels:    map[string]string{esv1beta1.LabelManaged: esv1beta1.LabelManagedValue},
+				},
+				Data: map[string][]byte{"foo": []byte("old")},
+			}
+			objs := []client.Object{newTestStore(), newExternalSecret(tt.policy, tt.after, tt.failingSince), secret}
+			c := newTestClientBuilder(t, objs...).Build()
+			r := newTestReconciler(c)
+			key := types.NamespacedName{Name: "test-es", Namespace: "default"}
+			if _, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: key}); err == nil {
+				t.Fatalf("Reconcile() did not return the provider error")
+			}
+
+			got := &v1.Secret{}
+			err := c.Get(context.Background(), types.NamespacedName{Name: "target", Namespace: "default"}, got)
+			if tt.wantDeleted {
+				if !apierrors.IsNotFound(err) {
+					t.Fatalf("expected the secret to be deleted, got: %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("the secret was deleted: %v", err)
+			}
+			if diff := cmp.Diff(tt.wantSecret, stringMap(got.Data)); diff != "" {
+				t.Errorf("unexpected secret data (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestOnErrorFailingFor(t *testing.T) {
+	es := &esv1beta1.ExternalSecret{}
+	if _, ok := onErrorFailingFor(es); ok {
+		t.Errorf("onErrorFailingFor() = true without a Ready condition")
+	}
+	es.Status.Conditions = []esv1beta1.ExternalSecretStatusCondition{{
+		Type:               esv1beta1.ExternalSecretReady,
+		Status:             v1.ConditionTrue,
+		LastTransitionTime: metav1.NewTime(time.Now().Add(-48 * time.Hour)),
+	}}
+	if _, ok := onErrorFailingFor(es); ok {
+		t.Errorf("onErrorFailingFor() = true while the ExternalSecret is ready")
+	}
+}
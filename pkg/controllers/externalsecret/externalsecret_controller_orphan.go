@@ -0,0 +1,123 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalsecret
+
+import (
+	"fmt"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+)
+
+const eventOrphanKept = "secret %s is orphaned, it is deleted after the grace period of %s"
+
+// orphanGracePeriod returns spec.target.orphanGracePeriod, 0 if the orphaned secrets are deleted immediately.
+func orphanGracePeriod(es *esv1beta1.ExternalSecret) time.Duration {
+	if es.Spec.Target.CreationPolicy != esv1beta1.CreatePolicyOwner || es.Spec.Target.OrphanGracePeriod == nil {
+		return 0
+	}
+	return es.Spec.Target.OrphanGracePeriod.Duration
+}
+
+// keepOrphan returns whether the orphaned secret is still within the grace period,
+// and the entry of status.orphanedSecrets that tracks it.
+func keepOrphan(es *esv1beta1.ExternalSecret, name string, now time.Time) (esv1beta1.ExternalSecretOrphanedSecret, bool) {
+	grace := orphanGracePeriod(es)
+	if grace <= 0 {
+		return esv1beta1.ExternalSecretOrphanedSecret{}, false
+	}
+	orphan := esv1beta1.ExternalSecretOrphanedSecret{Name: name, OrphanedAt: metav1.NewTime(now)}
+	for _, tracked := range es.Status.OrphanedSecrets {
+		if tracked.Name == name {
+			orphan.OrphanedAt = tracked.OrphanedAt
+			break
+		}
+	}
+	return orphan, now.Sub(orphan.OrphanedAt.Time) < grace
+}
+
+// recordOrphans replaces status.orphanedSecrets with the secrets that are kept,
+// an event is recorded for every secret that is kept for the first time.
+func (r *Reconciler) recordOrphans(es *esv1beta1.ExternalSecret, kept []esv1beta1.ExternalSecretOrphanedSecret) {
+	for _, orphan := range kept {
+		if !isTrackedOrphan(es, orphan.Name) {
+			r.recorder.Event(es, v1.EventTypeNormal, esv1beta1.ReasonOrphaned, fmt.Sprintf(eventOrphanKept, orphan.Name, orphanGracePeriod(es)))
+		}
+	}
+	es.Status.OrphanedSecrets = kept
+}
+
+func isTrackedOrphan(es *esv1beta1.ExternalSecret, name string) bool {
+	for _, tracked := range es.Status.OrphanedSecrets {
+		if tracked.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// nextOrphanExpiry returns the time the grace period of the first orphaned secret ends.
+// it returns false if no orphaned secret is kept.
+func nextOrphanExpiry(es *esv1beta1.ExternalSecret) (time.Time, bool) {
+	grace := orphanGracePeriod(es)
+	if grace <= 0 || len(es.Status.OrphanedSecrets) == 0 {
+		return time.Time{}, false
+	}
+	var next time.Time
+	for i, orphan := range es.Status.OrphanedSecrets {
+		expiry := orphan.OrphanedAt.Add(grace)
+		if i == 0 || expiry.Before(next) {
+			next = expiry
+		}
+	}
+	return next, true
+}
+
+// orphansExpired returns true if the grace period of an orphaned secret has passed,
+// so the refresh must not be skipped.
+func orphansExpired(es *esv1beta1.ExternalSecret, now time.Time) bool {
+	expiry, ok := nextOrphanExpiry(es)
+	return ok && !now.Before(expiry)
+}
+
+// requeueForOrphans requeues before the refresh result if the grace period of an orphaned secret ends earlier.
+// an orphaned secret that has already expired, e.g. because the sync failed, is deleted by the next refresh.
+func requeueForOrphans(es *esv1beta1.ExternalSecret, result ctrl.Result) ctrl.Result {
+	expiry, ok := nextOrphanExpiry(es)
+	if !ok {
+		return result
+	}
+	return requeueBefore(result, expiry)
+}
+
+// requeueBefore requeues at the given time if the result would requeue later or not at all.
+// a time in the past does not change the result.
+func requeueBefore(result ctrl.Result, at time.Time) ctrl.Result {
+	if result.Requeue && result.RequeueAfter == 0 {
+		return result
+	}
+	remaining := time.Until(at)
+	if remaining <= 0 {
+		return result
+	}
+	if result.RequeueAfter == 0 || remaining < result.RequeueAfter {
+		return ctrl.Result{RequeueAfter: remaining}
+	}
+	return result
+}
@@ -0,0 +1,142 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalsecret
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	"github.com/external-secrets/external-secrets/pkg/utils"
+)
+
+func TestReconcileOrphanGracePeriod(t *testing.T) {
+	newTestProvider(t).WithGetSecret([]byte("value"), nil)
+	es := &esv1beta1.ExternalSecret{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-es", Namespace: "default", UID: "test-es-uid"},
+		Spec: esv1beta1.ExternalSecretSpec{
+			RefreshInterval: &metav1.Duration{Duration: 24 * time.Hour},
+			SecretStoreRef:  esv1beta1.SecretStoreRef{Name: "test-store"},
+			Target: esv1beta1.ExternalSecretTarget{
+				Name:              "new-target",
+				CreationPolicy:    esv1beta1.CreatePolicyOwner,
+				OrphanGracePeriod: &metav1.Duration{Duration: time.Hour},
+			},
+			Data: []esv1beta1.ExternalSecretData{
+				{SecretKey: "foo", RemoteRef: esv1beta1.ExternalSecretDataRemoteRef{Key: "bar"}},
+			},
+		},
+	}
+	// the previous target of the ExternalSecret, before spec.target.name was changed
+	oldSecret := &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "old-target",
+			Namespace: "default",
+			UID:       "old-target-uid",
+			Labels: map[string]string{
+				esv1beta1.LabelManaged: esv1beta1.LabelManagedValue,
+				esv1beta1.LabelOwner:   utils.ObjectHash(fmt.Sprintf("%v/%v", es.Namespace, es.Name)),
+			},
+		},
+		Data: map[string][]byte{"foo": []byte("value")},
+	}
+	c := newTestClientBuilder(t, newTestStore(), es, oldSecret).
+		WithInterceptorFuncs(interceptor.Funcs{
+			Create: createWithUID,
+			Delete: deleteWithSecretKind,
+		}).Build()
+	r := newTestReconciler(c)
+	ctx := context.Background()
+	key := types.NamespacedName{Name: "test-es", Namespace: "default"}
+	oldKey := types.NamespacedName{Name: "old-target", Namespace: "default"}
+
+	// within the grace period the previous secret survives and is tracked in status
+	result, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+	if err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	if err := c.Get(ctx, oldKey, &v1.Secret{}); err != nil {
+		t.Fatalf("the previous secret was deleted within the grace period: %v", err)
+	}
+	if err := c.Get(ctx, types.NamespacedName{Name: "new-target", Namespace: "default"}, &v1.Secret{}); err != nil {
+		t.Fatalf("the new secret was not created: %v", err)
+	}
+	if result.RequeueAfter <= 0 || result.RequeueAfter > time.Hour {
+		t.Errorf("Reconcile() RequeueAfter = %v, want the end of the grace period", result.RequeueAfter)
+	}
+	got := &esv1beta1.ExternalSecret{}
+	if err := c.Get(ctx, key, got); err != nil {
+		t.Fatal(err)
+	}
+	if len(got.Status.OrphanedSecrets) != 1 || got.Status.OrphanedSecrets[0].Name != "old-target" {
+		t.Fatalf("status.orphanedSecrets = %v, want old-target", got.Status.OrphanedSecrets)
+	}
+
+	// once the grace period has passed, the previous secret is deleted
+	got.Status.OrphanedSecrets[0].OrphanedAt = metav1.NewTime(time.Now().Add(-2 * time.Hour))
+	if err := c.Status().Update(ctx, got); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key}); err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	if err := c.Get(ctx, oldKey, &v1.Secret{}); !apierrors.IsNotFound(err) {
+		t.Fatalf("expected the previous secret to be deleted after the grace period, got: %v", err)
+	}
+	if err := c.Get(ctx, key, got); err != nil {
+		t.Fatal(err)
+	}
+	if len(got.Status.OrphanedSecrets) != 0 {
+		t.Errorf("status.orphanedSecrets = %v, want none", got.Status.OrphanedSecrets)
+	}
+}
+
+func TestRequeueForOrphans(t *testing.T) {
+	es := &esv1beta1.ExternalSecret{
+		Spec: esv1beta1.ExternalSecretSpec{
+			Target: esv1beta1.ExternalSecretTarget{
+				CreationPolicy:    esv1beta1.CreatePolicyOwner,
+				OrphanGracePeriod: &metav1.Duration{Duration: time.Hour},
+			},
+		},
+		Status: esv1beta1.ExternalSecretStatus{
+			OrphanedSecrets: []esv1beta1.ExternalSecretOrphanedSecret{
+				{Name: "a", OrphanedAt: metav1.NewTime(time.Now().Add(-30 * time.Minute))},
+			},
+		},
+	}
+	if got := requeueForOrphans(es, ctrl.Result{RequeueAfter: 24 * time.Hour}); got.RequeueAfter <= 0 || got.RequeueAfter > 30*time.Minute {
+		t.Errorf("requeueForOrphans() = %v, want the end of the grace period", got)
+	}
+	if got := requeueForOrphans(es, ctrl.Result{RequeueAfter: time.Minute}); got.RequeueAfter != time.Minute {
+		t.Errorf("requeueForOrphans() = %v, want the earlier refresh", got)
+	}
+	if got := requeueForOrphans(es, ctrl.Result{}); got.RequeueAfter <= 0 {
+		t.Errorf("requeueForOrphans() = %v, want a requeue without a periodic refresh", got)
+	}
+	es.Spec.Target.CreationPolicy = esv1beta1.CreatePolicyOrphan
+	if got := requeueForOrphans(es, ctrl.Result{}); got != (ctrl.Result{}) {
+		t.Errorf("requeueForOrphans() = %v, want no requeue without creationPolicy=Owner", got)
+	}
+}
@@ -0,0 +1,433 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalsecret
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/tidwall/gjson"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	"sigs.k8s.io/yaml"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	"github.com/external-secrets/external-secrets/pkg/utils"
+)
+
+const (
+	// nestedKeySeparator joins the keys of nested YAML mappings and INI sections.
+	nestedKeySeparator = "."
+
+	// NOTE: parser errors must never contain the secret value.
+	errParserUnknown          = "unknown parser %q"
+	errParserMissingKey       = "line %d: missing key"
+	errParserMissingSeparator = "line %d: expected KEY=VALUE"
+	errParserUnterminated     = "line %d: unterminated quoted value"
+	errParserTrailingData     = "line %d: unexpected characters after quoted value"
+	errParserEmptySection     = "line %d: empty section name"
+	errParserNotMapping       = "expected a YAML mapping at the top level"
+	errParserDocument         = "document %d: %w"
+	errParserDocumentKey      = "document %d: documentKey %s must be a non-empty string"
+	errParserDuplicateDoc     = "document %d: duplicate documentKey %s"
+	errBlobNotJSON            = "asBlob requires a JSON value, invalid JSON at offset %d"
+	errSplitInvalid           = "invalid split: %w"
+	errSplitNoMatch           = "value of key %s does not match the split expression"
+)
+
+// getExtractSecretMap returns the key/value pairs of an extracted remote secret.
+// JSON values are parsed by the provider, all other formats are fetched
+// as a single value and parsed by the controller.
+func getExtractSecretMap(ctx context.Context, client esv1beta1.SecretsClient, ref esv1beta1.ExternalSecretDataRemoteRef) (map[string][]byte, error) {
+	if ref.Parser == "" || ref.Parser == esv1beta1.ExternalSecretParserJSON {
+		if ref.PropertyPointer == "" {
+			return client.GetSecretMap(ctx, ref)
+		}
+		pointer := ref.PropertyPointer
+		ref.PropertyPointer = ""
+		data, err := client.GetSecret(ctx, ref)
+		if err != nil {
+			return nil, err
+		}
+		return utils.JSONPointerMap(data, pointer)
+	}
+
+	data, err := getSecretValue(ctx, client, ref)
+	if err != nil {
+		return nil, err
+	}
+
+	secretMap, err := parseSecretData(ref, data)
+	if err != nil {
+		return nil, fmt.Errorf("%w using parser %s: %w", ErrSecretParse, ref.Parser, err)
+	}
+	return secretMap, nil
+}
+
+// getSecretValue returns the value of ref.
+// If a propertyPointer is set it is evaluated on the whole value, so it works with all providers.
+func getSecretValue(ctx context.Context, client esv1beta1.SecretsClient, ref esv1beta1.ExternalSecretDataRemoteRef) ([]byte, error) {
+	if ref.PropertyPointer == "" {
+		return client.GetSecret(ctx, ref)
+	}
+	pointer := ref.PropertyPointer
+	ref.PropertyPointer = ""
+	data, err := client.GetSecret(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+	return utils.JSONPointer(data, pointer)
+}
+
+// getSecretProperties fetches the value of ref once and returns the
+// properties listed in ref.Properties under their secret keys.
+func getSecretProperties(ctx context.Context, client esv1beta1.SecretsClient, ref esv1beta1.ExternalSecretDataRemoteRef) (map[string][]byte, error) {
+	properties := ref.Properties
+	ref.Properties = nil
+	data, err := client.GetSecret(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+	secretMap := make(map[string][]byte, len(properties))
+	for secretKey, property := range properties {
+		val, err := getJSONProperty(data, property)
+		if err != nil {
+			return nil, fmt.Errorf("property %s: %w", property, err)
+		}
+		secretMap[secretKey] = val
+	}
+	return secretMap, nil
+}
+
+// splitValue matches the value against the regular expression of ref.Split
+// and returns every named capture group under its name.
+// Optional groups that did not participate in the match are returned as empty values.
+func splitValue(ref esv1beta1.ExternalSecretDataRemoteRef, data []byte) (map[string][]byte, error) {
+	re, keys, err := esv1beta1.CompileSplit(ref.Split)
+	if err != nil {
+		return nil, fmt.Errorf(errSplitInvalid, err)
+	}
+	match := re.FindSubmatchIndex(data)
+	if match == nil {
+		return nil, fmt.Errorf("%w: "+errSplitNoMatch, ErrSecretParse, ref.Key)
+	}
+	secretMap := make(map[string][]byte, len(keys))
+	for _, key := range keys {
+		secretMap[key] = []byte{}
+	}
+	for i, name := range re.SubexpNames() {
+		// with duplicate names the first group that participated wins
+		if name == "" || match[2*i] < 0 || len(secretMap[name]) > 0 {
+			continue
+		}
+		secretMap[name] = bytes.Clone(data[match[2*i]:match[2*i+1]])
+	}
+	return secretMap, nil
+}
+
+// getJSONProperty selects a property of a JSON value with a gjson path.
+// Strings are returned unquoted, all other types as raw JSON.
+func getJSONProperty(data []byte, property string) ([]byte, error) {
+	val := gjson.GetBytes(data, property)
+	if !val.Exists() {
+		return nil, esv1beta1.NoSecretErr
+	}
+	if val.Type == gjson.String {
+		return []byte(val.Str), nil
+	}
+	return []byte(val.Raw), nil
+}
+
+// canonicalJSON re-encodes a JSON value with sorted object keys and without whitespace,
+// so the same structure always results in the same bytes. Numbers are kept as they are.
+func canonicalJSON(data []byte) ([]byte, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var value any
+	if err := dec.Decode(&value); err != nil {
+		// the decoder error is not returned, as it quotes the value
+		return nil, fmt.Errorf(errBlobNotJSON, dec.InputOffset())
+	}
+	end := dec.InputOffset()
+	if _, err := dec.Token(); !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf(errBlobNotJSON, end)
+	}
+
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(value); err != nil {
+		return nil, err
+	}
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
+
+// parseSecretData parses a structured secret value into key/value pairs with the parser of ref.
+func parseSecretData(ref esv1beta1.ExternalSecretDataRemoteRef, data []byte) (map[string][]byte, error) {
+	switch ref.Parser {
+	case esv1beta1.ExternalSecretParserDotEnv:
+		return parseDotEnv(string(data))
+	case esv1beta1.ExternalSecretParserYAML:
+		return parseYAML(data)
+	case esv1beta1.ExternalSecretParserYAMLMultiDoc:
+		return parseYAMLMultiDoc(data, ref.DocumentKey)
+	case esv1beta1.ExternalSecretParserINI:
+		return parseINI(string(data))
+	case esv1beta1.ExternalSecretParserPEMBundle:
+		return parsePEMBundle(data)
+	case esv1beta1.ExternalSecretParserJSON:
+		// JSON is parsed by the provider
+	}
+	return nil, fmt.Errorf(errParserUnknown, ref.Parser)
+}
+
+// parseDotEnv parses `KEY=VALUE` lines. It supports:
+//   - comments and empty lines
+//   - an optional `export` prefix
+//   - double-quoted values with escape sequences (\n, \r, \t, \", \\), which may span multiple lines
+//   - single-quoted values, which are taken literally and may span multiple lines
+//   - unquoted values, where everything after ` #` is treated as a comment
+func parseDotEnv(data string) (map[string][]byte, error) {
+	out := make(map[string][]byte)
+	lines := strings.Split(strings.ReplaceAll(data, "\r\n", "\n"), "\n")
+	for i := 0; i < len(lines); i++ {
+		lineNo := i + 1
+		line := strings.TrimSpace(lines[i])
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		line = strings.TrimPrefix(line, "export ")
+
+		key, value, found := strings.Cut(line, "=")
+		if !found {
+			return nil, fmt.Errorf(errParserMissingSeparator, lineNo)
+		}
+		key = strings.TrimSpace(key)
+		if key == "" {
+			return nil, fmt.Errorf(errParserMissingKey, lineNo)
+		}
+		value = strings.TrimLeft(value, " \t")
+
+		if value == "" || (value[0] != '"' && value[0] != '\'') {
+			out[key] = []byte(stripInlineComment(value))
+			continue
+		}
+
+		// quoted values may span multiple lines
+		quote := value[0]
+		rest := value[1:]
+		var parsed strings.Builder
+		for {
+			end, ok := scanQuoted(rest, quote, &parsed)
+			if ok {
+				trailing := strings.TrimSpace(rest[end+1:])
+				if trailing != "" && !strings.HasPrefix(trailing, "#") {
+					return nil, fmt.Errorf(errParserTrailingData, i+1)
+				}
+				break
+			}
+			i++
+			if i >= len(lines) {
+				return nil, fmt.Errorf(errParserUnterminated, lineNo)
+			}
+			parsed.WriteByte('\n')
+			rest = lines[i]
+		}
+		out[key] = []byte(parsed.String())
+	}
+	return out, nil
+}
+
+// scanQuoted writes the content of s up to the closing quote into out.
+// It returns the index of the closing quote and true, or false if s does not contain it.
+func scanQuoted(s string, quote byte, out *strings.Builder) (int, bool) {
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c == quote {
+			return i, true
+		}
+		// single-quoted values are literal
+		if c != '\\' || quote == '\'' || i+1 >= len(s) {
+			out.WriteByte(c)
+			continue
+		}
+		i++
+		switch s[i] {
+		case 'n':
+			out.WriteByte('\n')
+		case 'r':
+			out.WriteByte('\r')
+		case 't':
+			out.WriteByte('\t')
+		case '"', '\\':
+			out.WriteByte(s[i])
+		default:
+			out.WriteByte('\\')
+			out.WriteByte(s[i])
+		}
+	}
+	return 0, false
+}
+
+// stripInlineComment removes a trailing ` # comment` from an unquoted value.
+func stripInlineComment(value string) string {
+	for i := 1; i < len(value); i++ {
+		if value[i] == '#' && (value[i-1] == ' ' || value[i-1] == '\t') {
+			value = value[:i]
+			break
+		}
+	}
+	return strings.TrimSpace(value)
+}
+
+// parseYAML parses a YAML mapping. Nested mappings are flattened by joining
+// the keys with a dot, e.g. `db: {user: foo}` results in the key `db.user`.
+// Sequences are stored as JSON, scalars as their string representation.
+func parseYAML(data []byte) (map[string][]byte, error) {
+	var doc any
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	mapping, ok := doc.(map[string]any)
+	if !ok {
+		return nil, errors.New(errParserNotMapping)
+	}
+	out := make(map[string][]byte)
+	if err := flattenMap("", mapping, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// parseYAMLMultiDoc parses `---` separated YAML documents. Each document must be a mapping,
+// its keys are flattened like with parseYAML and prefixed with the value of documentKey,
+// or with doc0, doc1, ... if documentKey is empty. Empty documents are skipped and not counted.
+func parseYAMLMultiDoc(data []byte, documentKey string) (map[string][]byte, error) {
+	reader := utilyaml.NewYAMLReader(bufio.NewReader(bytes.NewReader(data)))
+	out := make(map[string][]byte)
+	names := make(map[string]bool)
+	for index := 0; ; {
+		raw, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return out, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf(errParserDocument, index, err)
+		}
+		var doc any
+		if err := yaml.Unmarshal(raw, &doc); err != nil {
+			return nil, fmt.Errorf(errParserDocument, index, err)
+		}
+		if doc == nil {
+			continue
+		}
+		mapping, ok := doc.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf(errParserDocument, index, errors.New(errParserNotMapping))
+		}
+		name := fmt.Sprintf("doc%d", index)
+		if documentKey != "" {
+			name, ok = lookupPath(mapping, documentKey)
+			if !ok {
+				return nil, fmt.Errorf(errParserDocumentKey, index, documentKey)
+			}
+		}
+		if names[name] {
+			return nil, fmt.Errorf(errParserDuplicateDoc, index, documentKey)
+		}
+		names[name] = true
+		if err := flattenMap(name, mapping, out); err != nil {
+			return nil, fmt.Errorf(errParserDocument, index, err)
+		}
+		index++
+	}
+}
+
+// lookupPath returns the string at the dot-separated path of a YAML mapping.
+func lookupPath(mapping map[string]any, path string) (string, bool) {
+	var value any = mapping
+	for _, part := range strings.Split(path, nestedKeySeparator) {
+		nested, ok := value.(map[string]any)
+		if !ok {
+			return "", false
+		}
+		value = nested[part]
+	}
+	s, ok := value.(string)
+	return s, ok && s != ""
+}
+
+func flattenMap(prefix string, in map[string]any, out map[string][]byte) error {
+	for key, value := range in {
+		if prefix != "" {
+			key = prefix + nestedKeySeparator + key
+		}
+		if nested, ok := value.(map[string]any); ok {
+			if err := flattenMap(key, nested, out); err != nil {
+				return err
+			}
+			continue
+		}
+		b, err := utils.GetByteValue(value)
+		if err != nil {
+			return fmt.Errorf("key %s: %w", key, err)
+		}
+		out[key] = b
+	}
+	return nil
+}
+
+// parseINI parses `key = value` pairs. Keys within a `[section]` are
+// prefixed with the section name, e.g. `[db]` and `user = foo` result in the key `db.user`.
+// Lines starting with `;` or `#` are comments, surrounding quotes of values are removed.
+func parseINI(data string) (map[string][]byte, error) {
+	out := make(map[string][]byte)
+	section := ""
+	for i, line := range strings.Split(strings.ReplaceAll(data, "\r\n", "\n"), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, ";") || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			section = strings.TrimSpace(line[1 : len(line)-1])
+			if section == "" {
+				return nil, fmt.Errorf(errParserEmptySection, i+1)
+			}
+			continue
+		}
+		key, value, found := strings.Cut(line, "=")
+		if !found {
+			return nil, fmt.Errorf(errParserMissingSeparator, i+1)
+		}
+		key = strings.TrimSpace(key)
+		if key == "" {
+			return nil, fmt.Errorf(errParserMissingKey, i+1)
+		}
+		value = strings.TrimSpace(value)
+		if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+			value = value[1 : len(value)-1]
+		}
+		if section != "" {
+			key = section + nestedKeySeparator + key
+		}
+		out[key] = []byte(value)
+	}
+	return out, nil
+}
@@ -0,0 +1,463 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalsecret
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	"github.com/external-secrets/external-secrets/pkg/provider/testing/fake"
+	"github.com/external-secrets/external-secrets/pkg/utils"
+)
+
+func TestParseSecretData(t *testing.T) {
+	tests := []struct {
+		name        string
+		parser      esv1beta1.ExternalSecretParser
+		documentKey string
+		data        string
+		want        map[string]string
+		wantErr     string
+	}{
+		{
+			name:   "dotenv unquoted values and comments",
+			parser: esv1beta1.ExternalSecretParserDotEnv,
+			data: `# database settings
+DB_USER=admin
+export DB_HOST = db.example.com # primary
+
+DB_URL=postgres://admin@db/app#fragment
+EMPTY=
+`,
+			want: map[string]string{
+				"DB_USER": "admin",
+				"DB_HOST": "db.example.com",
+				"DB_URL":  "postgres://admin@db/app#fragment",
+				"EMPTY":   "",
+			},
+		},
+		{
+			name:   "dotenv quoting and escaping",
+			parser: esv1beta1.ExternalSecretParserDotEnv,
+			data: `DOUBLE="say \"hi\"\tnow\\n"
+SINGLE='literal \n $HOME'
+HASH="value # not a comment" # a comment
+`,
+			want: map[string]string{
+				"DOUBLE": "say \"hi\"\tnow\\n",
+				"SINGLE": `literal \n $HOME`,
+				"HASH":   "value # not a comment",
+			},
+		},
+		{
+			name:   "dotenv multiline values",
+			parser: esv1beta1.ExternalSecretParserDotEnv,
+			data:   "CERT=\"-----BEGIN CERTIFICATE-----\r\nMIIB\r\n-----END CERTIFICATE-----\"\r\nNEXT=1",
+			want: map[string]string{
+				"CERT": "-----BEGIN CERTIFICATE-----\nMIIB\n-----END CERTIFICATE-----",
+				"NEXT": "1",
+			},
+		},
+		{
+			name:    "dotenv unterminated quote",
+			parser:  esv1beta1.ExternalSecretParserDotEnv,
+			data:    "A=1\nB=\"s3cr3t\nC=3",
+			wantErr: "line 2: unterminated quoted value",
+		},
+		{
+			name:    "dotenv missing separator",
+			parser:  esv1beta1.ExternalSecretParserDotEnv,
+			data:    "A=1\ns3cr3t",
+			wantErr: "line 2: expected KEY=VALUE",
+		},
+		{
+			name:    "dotenv trailing data",
+			parser:  esv1beta1.ExternalSecretParserDotEnv,
+			data:    `A="s3cr3t"s3cr3t`,
+			wantErr: "line 1: unexpected characters after quoted value",
+		},
+		{
+			name:   "yaml nested flattening",
+			parser: esv1beta1.ExternalSecretParserYAML,
+			data: `db:
+  user: admin
+  port: 5432
+  replica:
+    host: replica.example.com
+hosts:
+  - a
+  - b
+enabled: true
+`,
+			want: map[string]string{
+				"db.user":         "admin",
+				"db.port":         "5432",
+				"db.replica.host": "replica.example.com",
+				"hosts":           `["a","b"]`,
+				"enabled":         "true",
+			},
+		},
+		{
+			name:    "yaml not a mapping",
+			parser:  esv1beta1.ExternalSecretParserYAML,
+			data:    "- s3cr3t",
+			wantErr: "expected a YAML mapping",
+		},
+		{
+			name:   "ini sections",
+			parser: esv1beta1.ExternalSecretParserINI,
+			data: `; global settings
+name = app
+
+[db]
+user = admin
+password = "p@ss=word"
+# comment
+[cache]
+host=redis
+`,
+			want: map[string]string{
+				"name":        "app",
+				"db.user":     "admin",
+				"db.password": "p@ss=word",
+				"cache.host":  "redis",
+			},
+		},
+		{
+			name:    "ini empty section",
+			parser:  esv1beta1.ExternalSecretParserINI,
+			data:    "[ ]\na=b",
+			wantErr: "line 1: empty section name",
+		},
+		{
+			name:   "yaml multidoc indexed",
+			parser: esv1beta1.ExternalSecretParserYAMLMultiDoc,
+			data: `---
+# empty documents are skipped
+---
+db:
+  user: admin
+  port: 5432
+---
+cache:
+  password: s3cr3t
+`,
+			want: map[string]string{
+				"doc0.db.user":        "admin",
+				"doc0.db.port":        "5432",
+				"doc1.cache.password": "s3cr3t",
+			},
+		},
+		{
+			name:        "yaml multidoc by document key",
+			parser:      esv1beta1.ExternalSecretParserYAMLMultiDoc,
+			documentKey: "metadata.name",
+			data: `metadata:
+  name: primary
+password: s3cr3t
+---
+metadata:
+  name: replica
+password: r3plica
+`,
+			want: map[string]string{
+				"primary.metadata.name": "primary",
+				"primary.password":      "s3cr3t",
+				"replica.metadata.name": "replica",
+				"replica.password":      "r3plica",
+			},
+		},
+		{
+			name:        "yaml multidoc missing document key",
+			parser:      esv1beta1.ExternalSecretParserYAMLMultiDoc,
+			documentKey: "metadata.name",
+			data:        "metadata:\n  name: primary\n---\npassword: s3cr3t\n",
+			wantErr:     "document 1: documentKey metadata.name must be a non-empty string",
+		},
+		{
+			name:        "yaml multidoc duplicate document key",
+			parser:      esv1beta1.ExternalSecretParserYAMLMultiDoc,
+			documentKey: "name",
+			data:        "name: a\npassword: s3cr3t\n---\nname: a\n",
+			wantErr:     "document 1: duplicate documentKey name",
+		},
+		{
+			name:    "yaml multidoc invalid document",
+			parser:  esv1beta1.ExternalSecretParserYAMLMultiDoc,
+			data:    "a: b\n---\npassword: [s3cr3t\n",
+			wantErr: "document 1:",
+		},
+		{
+			name:    "yaml multidoc document is not a mapping",
+			parser:  esv1beta1.ExternalSecretParserYAMLMultiDoc,
+			data:    "a: b\n---\n- s3cr3t\n",
+			wantErr: "document 1: expected a YAML mapping",
+		},
+		{
+			name:    "json is parsed by the provider",
+			parser:  esv1beta1.ExternalSecretParserJSON,
+			data:    `{"a":"b"}`,
+			wantErr: "unknown parser",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseSecretData(esv1beta1.ExternalSecretDataRemoteRef{Parser: tt.parser, DocumentKey: tt.documentKey}, []byte(tt.data))
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+				}
+				if strings.Contains(err.Error(), "s3cr3t") {
+					t.Fatalf("error must not contain secret data: %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			gotStr := make(map[string]string, len(got))
+			for k, v := range got {
+				gotStr[k] = string(v)
+			}
+			if diff := cmp.Diff(tt.want, gotStr); diff != "" {
+				t.Errorf("unexpected result (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestPropertyPointer(t *testing.T) {
+	ctx := context.Background()
+	client := fake.New().WithGetSecret([]byte(`{"keys":[{"value":"first"},{"value":"second","env":"API_KEY=s3cr3t"}]}`), nil)
+
+	got, err := getSecretValue(ctx, client, esv1beta1.ExternalSecretDataRemoteRef{Key: "api", PropertyPointer: "/keys/1/value"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(got) != "second" {
+		t.Errorf("unexpected value %q", got)
+	}
+
+	_, err = getSecretValue(ctx, client, esv1beta1.ExternalSecretDataRemoteRef{Key: "api", PropertyPointer: "/keys/2/value"})
+	if !errors.Is(err, utils.ErrJSONPointer) {
+		t.Errorf("expected json pointer error, got %v", err)
+	}
+
+	gotMap, err := getExtractSecretMap(ctx, client, esv1beta1.ExternalSecretDataRemoteRef{Key: "api", PropertyPointer: "/keys/0"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if diff := cmp.Diff(map[string][]byte{"value": []byte("first")}, gotMap); diff != "" {
+		t.Errorf("unexpected result (-want +got):\n%s", diff)
+	}
+
+	// the selected value is parsed by the parser
+	gotMap, err = getExtractSecretMap(ctx, client, esv1beta1.ExternalSecretDataRemoteRef{
+		Key:             "api",
+		PropertyPointer: "/keys/1/env",
+		Parser:          esv1beta1.ExternalSecretParserDotEnv,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if diff := cmp.Diff(map[string][]byte{"API_KEY": []byte("s3cr3t")}, gotMap); diff != "" {
+		t.Errorf("unexpected result (-want +got):\n%s", diff)
+	}
+}
+
+func TestSecretProperties(t *testing.T) {
+	ctx := context.Background()
+	calls := 0
+	client := fake.New()
+	client.GetSecretFn = func(_ context.Context, ref esv1beta1.ExternalSecretDataRemoteRef) ([]byte, error) {
+		calls++
+		if ref.Property != "" || len(ref.Properties) != 0 {
+			t.Errorf("expected the whole value to be fetched, got %+v", ref)
+		}
+		return []byte(`{"db":{"user":"admin","password":"s3cr3t","port":5432},"hosts":["a.example.com","b.example.com"]}`), nil
+	}
+
+	got, err := getSecretProperties(ctx, client, esv1beta1.ExternalSecretDataRemoteRef{
+		Key: "db",
+		Properties: map[string]string{
+			"username": "db.user",
+			"password": "db.password",
+			"port":     "db.port",
+			"host":     "hosts.1",
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := map[string][]byte{
+		"username": []byte("admin"),
+		"password": []byte("s3cr3t"),
+		"port":     []byte("5432"),
+		"host":     []byte("b.example.com"),
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("unexpected result (-want +got):\n%s", diff)
+	}
+	if calls != 1 {
+		t.Errorf("expected a single call to the provider, got %d", calls)
+	}
+
+	_, err = getSecretProperties(ctx, client, esv1beta1.ExternalSecretDataRemoteRef{
+		Key:        "db",
+		Properties: map[string]string{"username": "db.missing"},
+	})
+	if !errors.Is(err, esv1beta1.NoSecretErr) {
+		t.Errorf("expected no secret error, got %v", err)
+	}
+}
+
+func TestAsBlob(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		ref     esv1beta1.ExternalSecretDataRemoteRef
+		want    string
+		wantErr string
+	}{
+		{
+			name:  "object",
+			value: "{\n  \"z\": [1, 2.50, {\"b\": true, \"a\": null}],\n  \"a\": \"<tls>&\",\n  \"big\": 12345678901234567890\n}\n",
+			want:  `{"a":"<tls>&","big":12345678901234567890,"z":[1,2.50,{"a":null,"b":true}]}`,
+		},
+		{
+			name:  "the same structure in a different order",
+			value: `{"big":12345678901234567890,"z":[1,2.50,{"a":null,"b":true}],"a":"<tls>&"}`,
+			want:  `{"a":"<tls>&","big":12345678901234567890,"z":[1,2.50,{"a":null,"b":true}]}`,
+		},
+		{
+			name:  "decoded first",
+			value: "eyJiIjoxLCJhIjoyfQ==",
+			ref:   esv1beta1.ExternalSecretDataRemoteRef{DecodingStrategy: esv1beta1.ExternalSecretDecodeBase64},
+			want:  `{"a":2,"b":1}`,
+		},
+		{
+			name:    "not JSON",
+			value:   "password=s3cr3t",
+			wantErr: "asBlob requires a JSON value, invalid JSON at offset 0",
+		},
+		{
+			name:    "trailing data",
+			value:   `{"a":1} {"b":2}`,
+			wantErr: "asBlob requires a JSON value, invalid JSON at offset 7",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := fake.New().WithGetSecret([]byte(tt.value), nil)
+			ref := tt.ref
+			ref.Key = "config"
+			ref.AsBlob = true
+			data := map[string][]byte{}
+			err := getSecretData(context.Background(), client, esv1beta1.ExternalSecretData{SecretKey: "config.json", RemoteRef: ref}, data)
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Fatalf("unexpected error: %v, want %s", err, tt.wantErr)
+				}
+				if strings.Contains(err.Error(), "s3cr3t") {
+					t.Errorf("the error contains the secret value: %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := string(data["config.json"]); got != tt.want {
+				t.Errorf("unexpected value %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSplit(t *testing.T) {
+	const dsn = `^(?P<user>[^:]+):(?P<pass>[^@]+)@(?P<host>[^:]+):(?P<port>\d+)$`
+	tests := []struct {
+		name    string
+		value   string
+		ref     esv1beta1.ExternalSecretDataRemoteRef
+		want    map[string]string
+		wantErr string
+	}{
+		{
+			name:  "named groups",
+			value: "admin:s3cr3t@db.example.com:5432",
+			ref:   esv1beta1.ExternalSecretDataRemoteRef{Split: dsn},
+			want:  map[string]string{"user": "admin", "pass": "s3cr3t", "host": "db.example.com", "port": "5432"},
+		},
+		{
+			name:  "unnamed groups are ignored",
+			value: "admin:s3cr3t@db.example.com:5432",
+			ref:   esv1beta1.ExternalSecretDataRemoteRef{Split: `^(?P<user>[^:]+):([^@]+)@(.*)$`},
+			want:  map[string]string{"user": "admin"},
+		},
+		{
+			name:  "optional group without a match",
+			value: "db.example.com",
+			ref:   esv1beta1.ExternalSecretDataRemoteRef{Split: `^(?P<host>[^:]+)(?::(?P<port>\d+))?$`},
+			want:  map[string]string{"host": "db.example.com", "port": ""},
+		},
+		{
+			name:  "decoded first",
+			value: "YWRtaW46czNjcjN0QGRiLmV4YW1wbGUuY29tOjU0MzI=",
+			ref:   esv1beta1.ExternalSecretDataRemoteRef{Split: dsn, DecodingStrategy: esv1beta1.ExternalSecretDecodeBase64},
+			want:  map[string]string{"user": "admin", "pass": "s3cr3t", "host": "db.example.com", "port": "5432"},
+		},
+		{
+			name:    "no match",
+			value:   "admin:s3cr3t@db.example.com",
+			ref:     esv1beta1.ExternalSecretDataRemoteRef{Split: dsn},
+			wantErr: "could not parse secret data: value of key db does not match the split expression",
+		},
+		{
+			name:    "no named groups",
+			value:   "admin:s3cr3t",
+			ref:     esv1beta1.ExternalSecretDataRemoteRef{Split: `^(.*):(.*)$`},
+			wantErr: "invalid split: split must contain at least one named capture group",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := fake.New().WithGetSecret([]byte(tt.value), nil)
+			ref := tt.ref
+			ref.Key = "db"
+			data := map[string][]byte{}
+			err := getSecretData(context.Background(), client, esv1beta1.ExternalSecretData{RemoteRef: ref}, data)
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Fatalf("unexpected error: %v, want %s", err, tt.wantErr)
+				}
+				if strings.Contains(err.Error(), "s3cr3t") {
+					t.Errorf("the error contains the secret value: %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tt.want, stringMap(data)); diff != "" {
+				t.Errorf("unexpected data (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,147 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalsecret
+
+import (
+	"bytes"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+
+	v1 "k8s.io/api/core/v1"
+)
+
+const (
+	pemTypeCertificate = "CERTIFICATE"
+
+	// pemBundleCAKey holds the intermediate and root certificates of a PEM bundle.
+	pemBundleCAKey = "ca.crt"
+
+	// NOTE: parser errors must never contain the secret value.
+	errPEMNoCertificate   = "no PEM certificate found"
+	errPEMTrailingData    = "unexpected data after PEM block %d"
+	errPEMUnsupportedType = "PEM block %d: unsupported type %q"
+	errPEMCertificate     = "PEM block %d: %w"
+	errPEMMultipleKeys    = "PEM block %d: found more than one private key"
+	errPEMLeafCount       = "found %d leaf certificates, expected exactly one"
+	errPEMNotInChain      = "PEM block %d: certificate is not part of the chain of the leaf certificate"
+)
+
+// pemPrivateKeyTypes are the PEM block types written to tls.key.
+var pemPrivateKeyTypes = map[string]bool{
+	"PRIVATE KEY":     true,
+	"RSA PRIVATE KEY": true,
+	"EC PRIVATE KEY":  true,
+}
+
+type pemCertificate struct {
+	block  int
+	cert   *x509.Certificate
+	issuer *pemCertificate
+	issues bool
+}
+
+// parsePEMBundle splits a PEM bundle into the leaf certificate (tls.crt),
+// the certificates of its chain ordered from the leaf to the root (ca.crt)
+// and an optional private key (tls.key).
+// The leaf is the only certificate that has not issued another certificate of the bundle.
+func parsePEMBundle(data []byte) (map[string][]byte, error) {
+	var (
+		certs []*pemCertificate
+		key   []byte
+	)
+	rest := data
+	for i := 0; ; i++ {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			if len(bytes.TrimSpace(rest)) > 0 {
+				return nil, fmt.Errorf(errPEMTrailingData, i)
+			}
+			break
+		}
+		switch {
+		case block.Type == pemTypeCertificate:
+			cert, err := x509.ParseCertificate(block.Bytes)
+			if err != nil {
+				return nil, fmt.Errorf(errPEMCertificate, i, err)
+			}
+			certs = append(certs, &pemCertificate{block: i, cert: cert})
+		case pemPrivateKeyTypes[block.Type]:
+			if key != nil {
+				return nil, fmt.Errorf(errPEMMultipleKeys, i)
+			}
+			key = pem.EncodeToMemory(block)
+		default:
+			return nil, fmt.Errorf(errPEMUnsupportedType, i, block.Type)
+		}
+	}
+	if len(certs) == 0 {
+		return nil, errors.New(errPEMNoCertificate)
+	}
+
+	// link every certificate to the certificate of the bundle that signed it
+	for _, c := range certs {
+		for _, p := range certs {
+			if c == p || !bytes.Equal(c.cert.RawIssuer, p.cert.RawSubject) {
+				continue
+			}
+			if c.cert.CheckSignatureFrom(p.cert) == nil {
+				c.issuer = p
+				p.issues = true
+				break
+			}
+		}
+	}
+
+	var leaves []*pemCertificate
+	for _, c := range certs {
+		if !c.issues {
+			leaves = append(leaves, c)
+		}
+	}
+	if len(leaves) != 1 {
+		return nil, fmt.Errorf(errPEMLeafCount, len(leaves))
+	}
+
+	leaf := leaves[0]
+	inChain := map[*pemCertificate]bool{leaf: true}
+	var chain []byte
+	for c := leaf.issuer; c != nil && !inChain[c]; c = c.issuer {
+		inChain[c] = true
+		chain = append(chain, encodePEMCertificate(c.cert)...)
+	}
+	for _, c := range certs {
+		if !inChain[c] {
+			return nil, fmt.Errorf(errPEMNotInChain, c.block)
+		}
+	}
+
+	out := map[string][]byte{
+		v1.TLSCertKey: encodePEMCertificate(leaf.cert),
+	}
+	if chain != nil {
+		out[pemBundleCAKey] = chain
+	}
+	if key != nil {
+		out[v1.TLSPrivateKeyKey] = key
+	}
+	return out, nil
+}
+
+func encodePEMCertificate(cert *x509.Certificate) []byte {
+	return pem.EncodeToMemory(&pem.Block{Type: pemTypeCertificate, Bytes: cert.Raw})
+}
@@ -0,0 +1,181 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalsecret
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+type testCert struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	pem  string
+}
+
+func newTestCert(t *testing.T, name string, isCA bool, parent *testCert) *testCert {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(time.Now().UnixNano()),
+		Subject:               pkix.Name{CommonName: name},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  isCA,
+		BasicConstraintsValid: true,
+	}
+	if isCA {
+		tpl.KeyUsage = x509.KeyUsageCertSign
+	}
+	signer, signerKey := tpl, key
+	if parent != nil {
+		signer, signerKey = parent.cert, parent.key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tpl, signer, &key.PublicKey, signerKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &testCert{
+		cert: cert,
+		key:  key,
+		pem:  string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})),
+	}
+}
+
+func TestParsePEMBundle(t *testing.T) {
+	root := newTestCert(t, "root", true, nil)
+	intermediate := newTestCert(t, "intermediate", true, root)
+	leaf := newTestCert(t, "leaf", false, intermediate)
+	other := newTestCert(t, "other", false, root)
+	unrelatedRoot := newTestCert(t, "unrelated", true, nil)
+	keyDER, err := x509.MarshalPKCS8PrivateKey(leaf.key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	key := string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDER}))
+
+	tests := []struct {
+		name    string
+		data    string
+		want    map[string]string
+		wantErr string
+	}{
+		{
+			name: "ordered chain",
+			data: leaf.pem + intermediate.pem + root.pem,
+			want: map[string]string{
+				"tls.crt": leaf.pem,
+				"ca.crt":  intermediate.pem + root.pem,
+			},
+		},
+		{
+			name: "unordered chain with private key and comments",
+			data: "subject=leaf\n" + root.pem + key + leaf.pem + "\n" + intermediate.pem,
+			want: map[string]string{
+				"tls.crt": leaf.pem,
+				"ca.crt":  intermediate.pem + root.pem,
+				"tls.key": key,
+			},
+		},
+		{
+			name: "chain without root",
+			data: intermediate.pem + leaf.pem,
+			want: map[string]string{
+				"tls.crt": leaf.pem,
+				"ca.crt":  intermediate.pem,
+			},
+		},
+		{
+			name: "single certificate",
+			data: leaf.pem,
+			want: map[string]string{
+				"tls.crt": leaf.pem,
+			},
+		},
+		{
+			name:    "two leaf certificates",
+			data:    leaf.pem + intermediate.pem + root.pem + other.pem,
+			wantErr: "found 2 leaf certificates, expected exactly one",
+		},
+		{
+			name:    "unrelated root certificate",
+			data:    leaf.pem + intermediate.pem + unrelatedRoot.pem,
+			wantErr: "found 2 leaf certificates, expected exactly one",
+		},
+		{
+			name:    "no certificate",
+			data:    key,
+			wantErr: "no PEM certificate found",
+		},
+		{
+			name:    "not PEM",
+			data:    "s3cr3t",
+			wantErr: "unexpected data after PEM block 0",
+		},
+		{
+			name:    "truncated block",
+			data:    leaf.pem + root.pem[:len(root.pem)-10],
+			wantErr: "unexpected data after PEM block 1",
+		},
+		{
+			name:    "invalid certificate",
+			data:    leaf.pem + "-----BEGIN CERTIFICATE-----\nczNjcjN0\n-----END CERTIFICATE-----\n",
+			wantErr: "PEM block 1: x509: malformed certificate",
+		},
+		{
+			name:    "unsupported block type",
+			data:    leaf.pem + "-----BEGIN PUBLIC KEY-----\nczNjcjN0\n-----END PUBLIC KEY-----\n",
+			wantErr: `PEM block 1: unsupported type "PUBLIC KEY"`,
+		},
+		{
+			name:    "two private keys",
+			data:    key + leaf.pem + key,
+			wantErr: "PEM block 2: found more than one private key",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parsePEMBundle([]byte(tt.data))
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Fatalf("expected error %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tt.want, stringMap(got)); diff != "" {
+				t.Errorf("unexpected result (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
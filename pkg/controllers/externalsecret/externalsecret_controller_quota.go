@@ -0,0 +1,146 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalsecret
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	toolscache "k8s.io/client-go/tools/cache"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	"github.com/external-secrets/external-secrets/pkg/controllers/externalsecret/esmetrics"
+)
+
+const errManagedSecretsNotSynced = "the managed secrets are not counted yet"
+
+// managedSecrets counts the secrets with the managed label from the events of the secret metadata informer,
+// so the quota is checked without listing the secrets. Secrets of other controller identities are not counted.
+// A slot is reserved for every secret that is being created, so concurrent workers can not exceed the limit.
+type managedSecrets struct {
+	mu       sync.Mutex
+	identity string
+	secrets  map[types.NamespacedName]struct{}
+	reserved int
+	synced   func() bool
+}
+
+func newManagedSecrets(identity string) *managedSecrets {
+	return &managedSecrets{
+		identity: identity,
+		secrets:  make(map[types.NamespacedName]struct{}),
+		synced:   func() bool { return true },
+	}
+}
+
+// register counts the secrets of the informer and keeps the count up to date.
+func (m *managedSecrets) register(informer cache.Informer) error {
+	registration, err := informer.AddEventHandler(toolscache.ResourceEventHandlerFuncs{
+		AddFunc:    m.observe,
+		UpdateFunc: func(_, obj any) { m.observe(obj) },
+		DeleteFunc: m.forget,
+	})
+	if err != nil {
+		return err
+	}
+	m.synced = registration.HasSynced
+	return nil
+}
+
+// observe counts a created or updated secret, or stops counting it once it lost the managed label.
+func (m *managedSecrets) observe(obj any) {
+	secret, ok := obj.(metav1.Object)
+	if !ok {
+		return
+	}
+	key := types.NamespacedName{Name: secret.GetName(), Namespace: secret.GetNamespace()}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if secret.GetLabels()[esv1beta1.LabelManaged] == esv1beta1.LabelManagedValue && !isManagedByOtherIdentity(secret, m.identity) {
+		m.secrets[key] = struct{}{}
+	} else {
+		delete(m.secrets, key)
+	}
+	esmetrics.UpdateManagedSecrets(len(m.secrets))
+}
+
+// forget stops counting a deleted secret.
+func (m *managedSecrets) forget(obj any) {
+	if tombstone, ok := obj.(toolscache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+	secret, ok := obj.(metav1.Object)
+	if !ok {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.secrets, types.NamespacedName{Name: secret.GetName(), Namespace: secret.GetNamespace()})
+	esmetrics.UpdateManagedSecrets(len(m.secrets))
+}
+
+// reserve takes a slot for a new secret, it returns ErrManagedSecretsQuota if all slots are taken.
+// The slot must be released once the secret was created or its creation failed.
+func (m *managedSecrets) reserve(limit int) error {
+	if !m.synced() {
+		return errors.New(errManagedSecretsNotSynced)
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if count := len(m.secrets) + m.reserved; count >= limit {
+		return fmt.Errorf("%w: %d secrets are managed, the limit is %d", ErrManagedSecretsQuota, count, limit)
+	}
+	m.reserved++
+	return nil
+}
+
+// release frees a slot. A created secret is counted right away,
+// it may take a moment until the informer reports it.
+func (m *managedSecrets) release(key types.NamespacedName, created bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.reserved--
+	if created {
+		m.secrets[key] = struct{}{}
+		esmetrics.UpdateManagedSecrets(len(m.secrets))
+	}
+}
+
+// reserveManagedSecret reserves a slot for a new secret if --max-managed-secrets is set.
+// The returned function releases the slot, it must be called once the secret was created or its creation failed.
+func (r *Reconciler) reserveManagedSecret(key types.NamespacedName) (func(created bool), error) {
+	if r.MaxManagedSecrets <= 0 || r.managedSecrets == nil {
+		return func(bool) {}, nil
+	}
+	if err := r.managedSecrets.reserve(r.MaxManagedSecrets); err != nil {
+		return nil, err
+	}
+	return func(created bool) { r.managedSecrets.release(key, created) }, nil
+}
+
+func (r *Reconciler) markAsQuotaExceeded(err error, externalSecret *esv1beta1.ExternalSecret) {
+	conditionSynced := NewExternalSecretCondition(esv1beta1.ExternalSecretReady, v1.ConditionFalse, esv1beta1.ConditionReasonQuotaExceeded, msgQuotaExceeded)
+	// only record an event when the condition changes, the quota is checked again on every refresh
+	if cond := GetExternalSecretCondition(externalSecret.Status, esv1beta1.ExternalSecretReady); cond == nil || cond.Reason != conditionSynced.Reason {
+		r.recorder.Event(externalSecret, v1.EventTypeWarning, esv1beta1.ReasonUpdateFailed, redactRemoteKeys(externalSecret, err.Error()))
+	}
+	SetExternalSecretCondition(externalSecret, *conditionSynced)
+	setLastError(externalSecret, esv1beta1.ConditionReasonQuotaExceeded, err)
+}
@@ -0,0 +1,266 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalsecret
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	toolscache "k8s.io/client-go/tools/cache"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	"github.com/external-secrets/external-secrets/pkg/controllers/externalsecret/esmetrics"
+)
+
+func TestReconcileMaxManagedSecrets(t *testing.T) {
+	managedSecret := func(name string, labels map[string]string) *v1.Secret {
+		secret := &v1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "default",
+				UID:       types.UID(name + "-uid"),
+				Labels:    map[string]string{esv1beta1.LabelManaged: esv1beta1.LabelManagedValue},
+			},
+			Data: map[string][]byte{"foo": []byte("old")},
+		}
+		for k, v := range labels {
+			secret.Labels[k] = v
+		}
+		return secret
+	}
+	tests := []struct {
+		name        string
+		max         int
+		secrets     []client.Object
+		wantCreated bool
+		wantReason  string
+	}{
+		{
+			name:        "no limit",
+			secrets:     []client.Object{managedSecret("a", nil), managedSecret("b", nil)},
+			wantCreated: true,
+			wantReason:  esv1beta1.ConditionReasonSecretSynced,
+		},
+		{
+			name:        "below the limit",
+			max:         3,
+			secrets:     []client.Object{managedSecret("a", nil), managedSecret("b", nil)},
+			wantCreated: true,
+			wantReason:  esv1beta1.ConditionReasonSecretSynced,
+		},
+		{
+			name:       "at the limit",
+			max:        2,
+			secrets:    []client.Object{managedSecret("a", nil), managedSecret("b", nil)},
+			wantReason: esv1beta1.ConditionReasonQuotaExceeded,
+		},
+		{
+			name: "secrets of other identities are not counted",
+			max:  2,
+			secrets: []client.Object{
+				managedSecret("a", nil),
+				managedSecret("b", map[string]string{esv1beta1.LabelControllerIdentity: "other"}),
+			},
+			wantCreated: true,
+			wantReason:  esv1beta1.ConditionReasonSecretSynced,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			newTestProvider(t).WithGetSecret([]byte("new"), nil)
+			es := &esv1beta1.ExternalSecret{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-es", Namespace: "default"},
+				Spec: esv1beta1.ExternalSecretSpec{
+					RefreshInterval: &metav1.Duration{Duration: time.Hour},
+					SecretStoreRef:  esv1beta1.SecretStoreRef{Name: "test-store"},
+					Target:          esv1beta1.ExternalSecretTarget{Name: "target", CreationPolicy: esv1beta1.CreatePolicyOrphan},
+					Data: []esv1beta1.ExternalSecretData{
+						{SecretKey: "foo", RemoteRef: esv1beta1.ExternalSecretDataRemoteRef{Key: "bar"}},
+					},
+				},
+			}
+			objs := append([]client.Object{newTestStore(), es}, tt.secrets...)
+			c := newTestClientBuilder(t, objs...).Build()
+			r := newTestReconciler(c)
+			r.MaxManagedSecrets = tt.max
+			r.managedSecrets = newTestManagedSecrets(r, tt.secrets...)
+			key := types.NamespacedName{Name: "test-es", Namespace: "default"}
+			if _, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: key}); err != nil {
+				t.Fatalf("Reconcile() error = %v", err)
+			}
+
+			err := c.Get(context.Background(), types.NamespacedName{Name: "target", Namespace: "default"}, &v1.Secret{})
+			if tt.wantCreated && err != nil {
+				t.Errorf("the secret was not created: %v", err)
+			}
+			if !tt.wantCreated && !apierrors.IsNotFound(err) {
+				t.Errorf("the secret was created past the limit: %v", err)
+			}
+
+			got := &esv1beta1.ExternalSecret{}
+			if err := c.Get(context.Background(), key, got); err != nil {
+				t.Fatal(err)
+			}
+			cond := GetExternalSecretCondition(got.Status, esv1beta1.ExternalSecretReady)
+			if cond == nil || cond.Reason != tt.wantReason {
+				t.Errorf("unexpected Ready condition, want reason %s, got %v", tt.wantReason, cond)
+			}
+		})
+	}
+}
+
+func TestReconcileMaxManagedSecretsUpdatesExisting(t *testing.T) {
+	newTestProvider(t).WithGetSecret([]byte("new"), nil)
+	objs := []client.Object{
+		newTestStore(),
+		&esv1beta1.ExternalSecret{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-es", Namespace: "default"},
+			Spec: esv1beta1.ExternalSecretSpec{
+				RefreshInterval: &metav1.Duration{Duration: time.Hour},
+				SecretStoreRef:  esv1beta1.SecretStoreRef{Name: "test-store"},
+				Target:          esv1beta1.ExternalSecretTarget{Name: "target", CreationPolicy: esv1beta1.CreatePolicyOrphan},
+				Data: []esv1beta1.ExternalSecretData{
+					{SecretKey: "foo", RemoteRef: esv1beta1.ExternalSecretDataRemoteRef{Key: "bar"}},
+				},
+			},
+		},
+	}
+	for i := range 3 {
+		name := fmt.Sprintf("secret-%d", i)
+		if i == 0 {
+			name = "target"
+		}
+		objs = append(objs, &v1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "default",
+				UID:       types.UID(name + "-uid"),
+				Labels:    map[string]string{esv1beta1.LabelManaged: esv1beta1.LabelManagedValue},
+			},
+			Data: map[string][]byte{"foo": []byte("old")},
+		})
+	}
+	c := newTestClientBuilder(t, objs...).Build()
+	r := newTestReconciler(c)
+	r.MaxManagedSecrets = 1
+	r.managedSecrets = newTestManagedSecrets(r, objs...)
+	key := types.NamespacedName{Name: "test-es", Namespace: "default"}
+	if _, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: key}); err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	got := &v1.Secret{}
+	if err := c.Get(context.Background(), types.NamespacedName{Name: "target", Namespace: "default"}, got); err != nil {
+		t.Fatal(err)
+	}
+	if string(got.Data["foo"]) != "new" {
+		t.Errorf("the existing secret was not updated past the limit, got %q", got.Data["foo"])
+	}
+}
+
+func TestManagedSecrets(t *testing.T) {
+	secret := func(name string, labels map[string]string) *v1.Secret {
+		return &v1.Secret{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", Labels: labels}}
+	}
+	managed := map[string]string{esv1beta1.LabelManaged: esv1beta1.LabelManagedValue}
+	gauge := func() int {
+		return int(testutil.ToFloat64(esmetrics.GetGaugeVec(esmetrics.ManagedSecretsKey).WithLabelValues()))
+	}
+	m := newManagedSecrets("blue")
+
+	m.observe(secret("a", managed))
+	m.observe(secret("b", managed))
+	m.observe(secret("unmanaged", nil))
+	m.observe(secret("green", map[string]string{esv1beta1.LabelManaged: esv1beta1.LabelManagedValue, esv1beta1.LabelControllerIdentity: "green"}))
+	if got := gauge(); got != 2 {
+		t.Errorf("managed secrets = %d after the adds, want 2", got)
+	}
+
+	// a secret that lost the managed label and a deleted secret are no longer counted
+	m.observe(secret("a", nil))
+	m.forget(toolscache.DeletedFinalStateUnknown{Key: "default/b", Obj: secret("b", managed)})
+	if got := gauge(); got != 0 {
+		t.Errorf("managed secrets = %d after the label removal and delete, want 0", got)
+	}
+
+	t.Run("concurrent creates do not exceed the limit", func(t *testing.T) {
+		m := newManagedSecrets("")
+		m.observe(secret("existing", managed))
+		var wg sync.WaitGroup
+		var mu sync.Mutex
+		reserved := 0
+		for i := range 10 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				if err := m.reserve(3); err != nil {
+					if !errors.Is(err, ErrManagedSecretsQuota) {
+						t.Errorf("reserve() error = %v, want ErrManagedSecretsQuota", err)
+					}
+					return
+				}
+				mu.Lock()
+				reserved++
+				mu.Unlock()
+				m.release(types.NamespacedName{Name: fmt.Sprintf("new-%d", i), Namespace: "default"}, true)
+			}()
+		}
+		wg.Wait()
+		if reserved != 2 {
+			t.Errorf("%d secrets were created, want 2 next to the existing secret", reserved)
+		}
+	})
+
+	t.Run("a failed create frees its slot", func(t *testing.T) {
+		m := newManagedSecrets("")
+		if err := m.reserve(1); err != nil {
+			t.Fatal(err)
+		}
+		if err := m.reserve(1); !errors.Is(err, ErrManagedSecretsQuota) {
+			t.Errorf("reserve() error = %v while a create is pending, want ErrManagedSecretsQuota", err)
+		}
+		m.release(types.NamespacedName{Name: "new", Namespace: "default"}, false)
+		if err := m.reserve(1); err != nil {
+			t.Errorf("reserve() error = %v after a failed create, want a free slot", err)
+		}
+	})
+
+	t.Run("no secret is created before the secrets are counted", func(t *testing.T) {
+		m := newManagedSecrets("")
+		m.synced = func() bool { return false }
+		if err := m.reserve(1); err == nil || errors.Is(err, ErrManagedSecretsQuota) {
+			t.Errorf("reserve() error = %v, want the not synced error", err)
+		}
+	})
+}
+
+// newTestManagedSecrets counts the secrets among objs, like the informer of SetupWithManager.
+func newTestManagedSecrets(r *Reconciler, objs ...client.Object) *managedSecrets {
+	m := newManagedSecrets(r.ControllerIdentity)
+	for _, obj := range objs {
+		m.observe(obj)
+	}
+	return m
+}
@@ -0,0 +1,123 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalsecret
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+)
+
+func TestReconcileReadOnly(t *testing.T) {
+	newExternalSecret := func() *esv1beta1.ExternalSecret {
+		return &esv1beta1.ExternalSecret{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-es", Namespace: "default"},
+			Spec: esv1beta1.ExternalSecretSpec{
+				RefreshInterval: &metav1.Duration{Duration: time.Hour},
+				SecretStoreRef:  esv1beta1.SecretStoreRef{Name: "test-store"},
+				Target: esv1beta1.ExternalSecretTarget{
+					Name:           "target",
+					CreationPolicy: esv1beta1.CreatePolicyOwner,
+					DeletionPolicy: esv1beta1.DeletionPolicyDelete,
+				},
+				Data: []esv1beta1.ExternalSecretData{
+					{SecretKey: "foo", RemoteRef: esv1beta1.ExternalSecretDataRemoteRef{Key: "bar"}},
+				},
+			},
+		}
+	}
+	tests := []struct {
+		name       string
+		value      []byte
+		err        error
+		secret     *v1.Secret
+		wantSecret map[string]string
+	}{
+		{
+			name:  "missing secret is not created",
+			value: []byte("new"),
+		},
+		{
+			name:  "existing secret is not updated",
+			value: []byte("new"),
+			secret: &v1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "target", Namespace: "default"},
+				Data:       map[string][]byte{"foo": []byte("old")},
+			},
+			wantSecret: map[string]string{"foo": "old"},
+		},
+		{
+			name: "existing secret is not deleted without provider data",
+			err:  esv1beta1.NoSecretErr,
+			secret: &v1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "target", Namespace: "default"},
+				Data:       map[string][]byte{"foo": []byte("old")},
+			},
+			wantSecret: map[string]string{"foo": "old"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			newTestProvider(t).WithGetSecret(tt.value, tt.err)
+			objs := []client.Object{newTestStore(), newExternalSecret()}
+			if tt.secret != nil {
+				objs = append(objs, tt.secret)
+			}
+			c := newTestClientBuilder(t, objs...).Build()
+			r := newTestReconciler(c)
+			r.ReadOnly = true
+			key := types.NamespacedName{Name: "test-es", Namespace: "default"}
+			if _, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: key}); err != nil {
+				t.Fatalf("Reconcile() returned an unexpected error: %v", err)
+			}
+
+			secret := &v1.Secret{}
+			err := c.Get(context.Background(), types.NamespacedName{Name: "target", Namespace: "default"}, secret)
+			if tt.wantSecret == nil {
+				if err == nil {
+					t.Fatalf("the secret was created in read-only mode")
+				}
+			} else {
+				if err != nil {
+					t.Fatalf("the secret was deleted in read-only mode: %v", err)
+				}
+				if diff := cmp.Diff(tt.wantSecret, stringMap(secret.Data)); diff != "" {
+					t.Errorf("the secret was updated in read-only mode (-want +got):\n%s", diff)
+				}
+				if _, ok := secret.Labels[esv1beta1.LabelManaged]; ok {
+					t.Errorf("the secret was labeled in read-only mode")
+				}
+			}
+
+			es := &esv1beta1.ExternalSecret{}
+			if err := c.Get(context.Background(), key, es); err != nil {
+				t.Fatal(err)
+			}
+			cond := GetExternalSecretCondition(es.Status, esv1beta1.ExternalSecretReady)
+			if cond == nil || cond.Status != v1.ConditionTrue || cond.Message != msgSyncedReadOnly {
+				t.Errorf("unexpected Ready condition: %+v", cond)
+			}
+		})
+	}
+}
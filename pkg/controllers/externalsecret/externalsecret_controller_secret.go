@@ -19,13 +19,19 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"maps"
+	"time"
 
 	v1 "k8s.io/api/core/v1"
 	apiextensions "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
 	genv1alpha1 "github.com/external-secrets/external-secrets/apis/generators/v1alpha1"
+	"github.com/external-secrets/external-secrets/pkg/controllers/externalsecret/esmetrics"
 	"github.com/external-secrets/external-secrets/pkg/controllers/secretstore"
+	"github.com/external-secrets/external-secrets/pkg/decryption"
+	"github.com/external-secrets/external-secrets/pkg/find"
 	"github.com/external-secrets/external-secrets/pkg/utils"
 	"github.com/external-secrets/external-secrets/pkg/utils/resolvers"
 
@@ -40,64 +46,226 @@ func (r *Reconciler) getProviderSecretData(ctx context.Context, externalSecret *
 	// Clientmanager keeps track of the client instances
 	// that are created during the fetching process and closes clients
 	// if needed.
-	mgr := secretstore.NewManager(r.Client, r.ControllerClass, r.EnableFloodGate)
+	mgr := secretstore.NewManager(r.Client, r.ControllerClass, r.EnableFloodGate).
+		WithCircuitBreakers(r.StoreCircuitBreakers).
+		WithErrorClassification()
 	defer mgr.Close(ctx)
 
+	// pass the provider options to all provider calls made for this ExternalSecret
+	providerOptions, err := renderProviderOptions(externalSecret)
+	if err != nil {
+		return nil, err
+	}
+	ctx = esv1beta1.ContextWithProviderOptions(ctx, providerOptions)
+
+	// decrypt all values fetched from providers if spec.decryption is set
+	decrypter, err := r.getDecrypter(ctx, externalSecret)
+	if err != nil {
+		return nil, err
+	}
+
 	providerData := make(map[string][]byte)
+	var sources []esv1beta1.ExternalSecretSourceStatus
+	var generatorRefresh time.Time
 	for i, remoteRef := range externalSecret.Spec.DataFrom {
 		var secretMap map[string][]byte
+		var servedBy *esv1beta1.SecretStoreRef
 		var err error
+		path := fmt.Sprintf("spec.dataFrom[%d]", i)
 
 		if remoteRef.Find != nil {
-			secretMap, err = r.handleFindAllSecrets(ctx, externalSecret, remoteRef, mgr)
+			secretMap, err = r.handleFindAllSecrets(ctx, externalSecret, remoteRef, mgr, decrypter)
 			if err != nil {
-				err = fmt.Errorf("error processing spec.dataFrom[%d].find, err: %w", i, err)
+				err = newEntryError(externalSecret, path, "", remoteRef.SourceRef, err, fmt.Errorf("error processing spec.dataFrom[%d].find, err: %w", i, err))
 			}
 		} else if remoteRef.Extract != nil {
-			secretMap, err = r.handleExtractSecrets(ctx, externalSecret, remoteRef, mgr)
+			secretMap, servedBy, err = r.handleExtractSecrets(ctx, externalSecret, remoteRef, mgr, decrypter)
 			if err != nil {
-				err = fmt.Errorf("error processing spec.dataFrom[%d].extract, err: %w", i, err)
+				err = newEntryError(externalSecret, path, remoteRef.Extract.Key, remoteRef.SourceRef, err, fmt.Errorf("error processing spec.dataFrom[%d].extract, err: %w", i, err))
 			}
 		} else if remoteRef.SourceRef != nil && remoteRef.SourceRef.GeneratorRef != nil {
-			secretMap, err = r.handleGenerateSecrets(ctx, externalSecret.Namespace, remoteRef)
+			var refreshAt time.Time
+			secretMap, refreshAt, err = r.handleGenerateSecrets(ctx, externalSecret, remoteRef)
+			if !refreshAt.IsZero() && (generatorRefresh.IsZero() || refreshAt.Before(generatorRefresh)) {
+				generatorRefresh = refreshAt
+			}
 			if err != nil {
-				err = fmt.Errorf("error processing spec.dataFrom[%d].sourceRef.generatorRef, err: %w", i, err)
+				err = newEntryError(externalSecret, path, "", remoteRef.SourceRef, err, fmt.Errorf("error processing spec.dataFrom[%d].sourceRef.generatorRef, err: %w", i, err))
 			}
 		}
 
+		// with validateOnly the other entries are still validated, the error is recorded in the status
+		if err != nil && externalSecret.Spec.ValidateOnly {
+			source := dataFromSourceStatus(externalSecret, i, remoteRef, servedBy, nil)
+			setSourceError(externalSecret, source, err)
+			sources = append(sources, *source)
+			continue
+		}
 		if errors.Is(err, esv1beta1.NoSecretErr) && externalSecret.Spec.Target.DeletionPolicy != esv1beta1.DeletionPolicyRetain {
 			r.recorder.Eventf(externalSecret, v1.EventTypeNormal, esv1beta1.ReasonMissingProviderSecret, eventMissingProviderSecret, i)
+			// with creationPolicy=None the status reports which remote secrets exist
+			if isCreationPolicyNone(externalSecret) {
+				source := dataFromSourceStatus(externalSecret, i, remoteRef, servedBy, nil)
+				setSourceError(externalSecret, source, err)
+				sources = append(sources, *source)
+			}
 			continue
 		}
 		if err != nil {
 			return nil, err
 		}
 
-		providerData = utils.MergeByteMap(providerData, secretMap)
+		providerData, err = mergeDataFrom(remoteRef.MergeStrategy, providerData, secretMap)
+		if err != nil {
+			return nil, fmt.Errorf("error processing spec.dataFrom[%d], err: %w", i, err)
+		}
+		if source := dataFromSourceStatus(externalSecret, i, remoteRef, servedBy, secretMap); source != nil {
+			sources = append(sources, *source)
+		}
 	}
 
+	// data is merged with dataFrom afterwards, depending on spec.mergePrecedence
+	secretData := make(map[string][]byte)
 	for i, secretRef := range externalSecret.Spec.Data {
-		err := r.handleSecretData(ctx, *externalSecret, secretRef, providerData, mgr)
-		if errors.Is(err, esv1beta1.NoSecretErr) && externalSecret.Spec.Target.DeletionPolicy != esv1beta1.DeletionPolicyRetain {
-			r.recorder.Eventf(externalSecret, v1.EventTypeNormal, esv1beta1.ReasonMissingProviderSecret, eventMissingProviderSecretKey, i, secretRef.RemoteRef.Key)
+		servedBy, key, err := r.handleSecretData(ctx, *externalSecret, secretRef, secretData, mgr, decrypter)
+		if errors.Is(err, esv1beta1.NoSecretErr) && externalSecret.Spec.Target.DeletionPolicy != esv1beta1.DeletionPolicyRetain && !externalSecret.Spec.ValidateOnly {
+			r.recorder.Event(externalSecret, v1.EventTypeNormal, esv1beta1.ReasonMissingProviderSecret, redactRemoteKeys(externalSecret, fmt.Sprintf(eventMissingProviderSecretKey, i, secretRef.RemoteRef.Key)))
+			if isCreationPolicyNone(externalSecret) {
+				source := dataSourceStatus(externalSecret, i, secretRef, servedBy, "")
+				setSourceError(externalSecret, source, fmt.Errorf("key: %s, err: %w", secretRef.RemoteRef.Key, err))
+				sources = append(sources, *source)
+			}
+			continue
+		}
+		// the other keys are still synced or validated, the error is recorded in the status
+		if err != nil && (secretRef.ContinueOnError || externalSecret.Spec.ValidateOnly) {
+			source := dataSourceStatus(externalSecret, i, secretRef, servedBy, "")
+			if source == nil {
+				source = &esv1beta1.ExternalSecretSourceStatus{Path: fmt.Sprintf("spec.data[%d]", i)}
+			}
+			setSourceError(externalSecret, source, fmt.Errorf("key: %s, err: %w", secretRef.RemoteRef.Key, err))
+			sources = append(sources, *source)
 			continue
 		}
 		if err != nil {
-			return nil, fmt.Errorf("error processing spec.data[%d] (key: %s), err: %w", i, secretRef.RemoteRef.Key, err)
+			return nil, newEntryError(externalSecret, fmt.Sprintf("spec.data[%d]", i), secretRef.RemoteRef.Key, toStoreGenSourceRef(secretRef.SourceRef), err,
+				fmt.Errorf("error processing spec.data[%d] (key: %s), err: %w", i, secretRef.RemoteRef.Key, err))
+		}
+		if source := dataSourceStatus(externalSecret, i, secretRef, servedBy, key); source != nil {
+			sources = append(sources, *source)
 		}
 	}
 
+	providerData = mergeDataPrecedence(externalSecret.Spec.MergePrecedence, providerData, secretData)
+
+	// namespace all keys, after they have been rewritten
+	providerData, err = utils.AffixKeys(externalSecret.Spec.Target.KeyPrefix, externalSecret.Spec.Target.KeySuffix, providerData)
+	if err != nil {
+		return nil, fmt.Errorf(errAffixKeys, err)
+	}
+	affixSourceKeys(sources, externalSecret.Spec.Target.KeyPrefix, externalSecret.Spec.Target.KeySuffix)
+
+	externalSecret.Status.Sources = sources
+	externalSecret.Status.GeneratorRefreshTime = nil
+	if !generatorRefresh.IsZero() {
+		externalSecret.Status.GeneratorRefreshTime = &metav1.Time{Time: generatorRefresh}
+	}
 	return providerData, nil
 }
 
-func (r *Reconciler) handleSecretData(ctx context.Context, externalSecret esv1beta1.ExternalSecret, secretRef esv1beta1.ExternalSecretData, providerData map[string][]byte, cmgr *secretstore.Manager) error {
-	client, err := cmgr.Get(ctx, externalSecret.Spec.SecretStoreRef, externalSecret.Namespace, toStoreGenSourceRef(secretRef.SourceRef))
+// mergeDataPrecedence merges the keys of data and dataFrom.
+// By default data wins, with MergePrecedenceDataFrom the keys of dataFrom win.
+func mergeDataPrecedence(precedence esv1beta1.ExternalSecretMergePrecedence, dataFrom, data map[string][]byte) map[string][]byte {
+	if precedence == esv1beta1.MergePrecedenceDataFrom {
+		maps.Copy(data, dataFrom)
+		return data
+	}
+	maps.Copy(dataFrom, data)
+	return dataFrom
+}
+
+// fromStores calls fetch with the client of the store to read from.
+// With a store group the stores are tried in order until fetch succeeds, a missing
+// secret or an unavailable store moves on to the next store.
+// The store that served the data is returned if a store group is used.
+func fromStores(ctx context.Context, externalSecret *esv1beta1.ExternalSecret, sourceRef *esv1beta1.StoreGeneratorSourceRef, cmgr *secretstore.Manager, decrypter *decryption.Decrypter, fetch func(esv1beta1.SecretsClient) error) (*esv1beta1.SecretStoreRef, error) {
+	if sourceRef == nil || len(sourceRef.StoreGroup) == 0 {
+		client, err := cmgr.Get(ctx, externalSecret.Spec.SecretStoreRef, externalSecret.Namespace, sourceRef)
+		if err != nil {
+			return nil, err
+		}
+		return nil, fetch(withDecryption(withVersionAliases(withProviderTimeout(client, externalSecret.Spec.ProviderTimeout)), decrypter))
+	}
+
+	var storeErrs, missingErrs []error
+	for i := range sourceRef.StoreGroup {
+		storeRef := sourceRef.StoreGroup[i]
+		client, err := cmgr.Get(ctx, storeRef, externalSecret.Namespace, nil)
+		if err == nil {
+			err = fetch(withDecryption(withVersionAliases(withProviderTimeout(client, externalSecret.Spec.ProviderTimeout)), decrypter))
+		}
+		if err == nil {
+			return &storeRef, nil
+		}
+		err = fmt.Errorf(errStoreGroup, i, storeRef.Name, err)
+		if errors.Is(err, esv1beta1.NoSecretErr) {
+			missingErrs = append(missingErrs, err)
+		} else {
+			storeErrs = append(storeErrs, err)
+		}
+	}
+	// the secret is only treated as missing if every store was available
+	if len(storeErrs) > 0 {
+		return nil, errors.Join(append(storeErrs, missingErrs...)...)
+	}
+	return nil, errors.Join(missingErrs...)
+}
+
+// handleSecretData reads spec.data[] and returns the store and the remote key that served it.
+func (r *Reconciler) handleSecretData(ctx context.Context, externalSecret esv1beta1.ExternalSecret, secretRef esv1beta1.ExternalSecretData, providerData map[string][]byte, cmgr *secretstore.Manager, decrypter *decryption.Decrypter) (*esv1beta1.SecretStoreRef, string, error) {
+	var key string
+	servedBy, err := fromStores(ctx, &externalSecret, toStoreGenSourceRef(secretRef.SourceRef), cmgr, decrypter, func(client esv1beta1.SecretsClient) error {
+		var err error
+		key, err = getFirstFoundSecretData(ctx, client, secretRef, providerData)
+		if err != nil {
+			return err
+		}
+		return collectProviderTags(ctx, client, key)
+	})
 	if err != nil {
-		return err
+		return servedBy, "", err
+	}
+	return servedBy, key, nil
+}
+
+func getSecretData(ctx context.Context, client esv1beta1.SecretsClient, secretRef esv1beta1.ExternalSecretData, providerData map[string][]byte) error {
+	// get several properties of a single secret from the store
+	if len(secretRef.RemoteRef.Properties) > 0 {
+		secretMap, err := getSecretProperties(ctx, client, secretRef.RemoteRef)
+		if err != nil {
+			return err
+		}
+		// decode all properties first, so a failure does not leave some of them behind
+		secretMap, err = utils.DecodeMap(secretRef.RemoteRef.DecodingStrategy, secretMap)
+		if err != nil {
+			return fmt.Errorf(errDecode, secretRef.RemoteRef.DecodingStrategy, err)
+		}
+		for secretKey, value := range secretMap {
+			secretMap[secretKey], err = utils.DecodeChain(secretRef.Decoders, value)
+			if err != nil {
+				return fmt.Errorf(errDecodersKey, secretKey, err)
+			}
+		}
+		secretMap, err = utils.TranscodeMap(secretRef.RemoteRef.SourceEncoding, secretRef.RemoteRef.InvalidEncodingPolicy, secretMap)
+		if err != nil {
+			return fmt.Errorf(errTranscode, err)
+		}
+		maps.Copy(providerData, secretMap)
+		return nil
 	}
 
 	// get a single secret from the store
-	secretData, err := client.GetSecret(ctx, secretRef.RemoteRef)
+	secretData, err := getSecretValue(ctx, client, secretRef.RemoteRef)
 	if err != nil {
 		return err
 	}
@@ -107,6 +275,38 @@ func (r *Reconciler) handleSecretData(ctx context.Context, externalSecret esv1be
 	if err != nil {
 		return fmt.Errorf(errDecode, secretRef.RemoteRef.DecodingStrategy, err)
 	}
+	secretData, err = utils.DecodeChain(secretRef.Decoders, secretData)
+	if err != nil {
+		return err
+	}
+	// convert the decoded value to UTF-8
+	secretData, err = utils.Transcode(secretRef.RemoteRef.SourceEncoding, secretRef.RemoteRef.InvalidEncodingPolicy, secretData)
+	if err != nil {
+		return fmt.Errorf(errTranscode, err)
+	}
+
+	// split the decoded value into keys by the named groups of the expression
+	if secretRef.RemoteRef.Split != "" {
+		secretMap, err := splitValue(secretRef.RemoteRef, secretData)
+		if err != nil {
+			return err
+		}
+		maps.Copy(providerData, secretMap)
+		return nil
+	}
+
+	// store the whole value as canonical JSON, after it has been decoded
+	if secretRef.RemoteRef.AsBlob {
+		secretData, err = canonicalJSON(secretData)
+		if err != nil {
+			return err
+		}
+	}
+
+	// verify the value that is stored against the pinned digest
+	if err := verifyDigest(secretRef.RemoteRef, secretData); err != nil {
+		return err
+	}
 
 	// store the secret data
 	providerData[secretRef.SecretKey] = secretData
@@ -119,48 +319,81 @@ func toStoreGenSourceRef(ref *esv1beta1.StoreSourceRef) *esv1beta1.StoreGenerato
 		return nil
 	}
 	return &esv1beta1.StoreGeneratorSourceRef{
-		SecretStoreRef: &ref.SecretStoreRef,
+		SecretStoreRef: ref.SecretStoreRef,
+		StoreGroup:     ref.StoreGroup,
 	}
 }
 
-func (r *Reconciler) handleGenerateSecrets(ctx context.Context, namespace string, remoteRef esv1beta1.ExternalSecretDataFromRemoteRef) (map[string][]byte, error) {
-	gen, obj, err := resolvers.GeneratorRef(ctx, r.Client, r.Scheme, namespace, remoteRef.SourceRef.GeneratorRef)
+// handleGenerateSecrets returns the generated values and, for an expiring generator,
+// the time they must be generated again.
+func (r *Reconciler) handleGenerateSecrets(ctx context.Context, externalSecret *esv1beta1.ExternalSecret, remoteRef esv1beta1.ExternalSecretDataFromRemoteRef) (map[string][]byte, time.Time, error) {
+	kind := remoteRef.SourceRef.GeneratorRef.Kind
+	gen, obj, err := resolvers.GeneratorRef(ctx, r.Client, r.Scheme, externalSecret.Namespace, remoteRef.SourceRef.GeneratorRef)
 	if err != nil {
-		return nil, err
+		esmetrics.RecordGeneratorCall(externalSecret, kind, esmetrics.GeneratorOutcomeError)
+		return nil, time.Time{}, err
+	}
+
+	// wait for generators that depend on an asynchronous setup
+	if err := resolvers.GeneratorReady(obj); err != nil {
+		return nil, time.Time{}, err
 	}
 
 	// use the generator
-	secretMap, err := gen.Generate(ctx, obj, r.Client, namespace)
+	now := time.Now()
+	var secretMap map[string][]byte
+	var refreshAt time.Time
+	if expiring, ok := gen.(genv1alpha1.ExpiringGenerator); ok {
+		var expiry time.Time
+		secretMap, expiry, err = expiring.GenerateWithExpiry(ctx, obj, r.Client, externalSecret.Namespace)
+		refreshAt = generatorRefreshTime(now, expiry)
+	} else {
+		secretMap, err = gen.Generate(ctx, obj, r.Client, externalSecret.Namespace)
+	}
 	if err != nil {
-		return nil, fmt.Errorf(errGenerate, err)
+		esmetrics.RecordGeneratorCall(externalSecret, kind, esmetrics.GeneratorOutcomeError)
+		return nil, time.Time{}, fmt.Errorf(errGenerate, err)
 	}
+	esmetrics.RecordGeneratorCall(externalSecret, kind, esmetrics.GeneratorOutcomeSuccess)
 
 	// rewrite the keys if needed
 	secretMap, err = utils.RewriteMap(remoteRef.Rewrite, secretMap)
 	if err != nil {
-		return nil, fmt.Errorf(errRewrite, err)
+		return nil, time.Time{}, fmt.Errorf(errRewrite, err)
 	}
 
 	// validate the keys
-	err = utils.ValidateKeys(secretMap)
+	secretMap, err = validateKeys(externalSecret.Spec.KeyNamePolicy, secretMap)
 	if err != nil {
-		return nil, fmt.Errorf(errInvalidKeys, err)
+		return nil, time.Time{}, err
 	}
 
-	return secretMap, err
+	return secretMap, refreshAt, nil
 }
 
-func (r *Reconciler) handleExtractSecrets(ctx context.Context, externalSecret *esv1beta1.ExternalSecret, remoteRef esv1beta1.ExternalSecretDataFromRemoteRef, cmgr *secretstore.Manager) (map[string][]byte, error) {
-	client, err := cmgr.Get(ctx, externalSecret.Spec.SecretStoreRef, externalSecret.Namespace, remoteRef.SourceRef)
+func (r *Reconciler) handleExtractSecrets(ctx context.Context, externalSecret *esv1beta1.ExternalSecret, remoteRef esv1beta1.ExternalSecretDataFromRemoteRef, cmgr *secretstore.Manager, decrypter *decryption.Decrypter) (map[string][]byte, *esv1beta1.SecretStoreRef, error) {
+	// get multiple secrets from the store
+	var secretMap map[string][]byte
+	servedBy, err := fromStores(ctx, externalSecret, remoteRef.SourceRef, cmgr, decrypter, func(client esv1beta1.SecretsClient) error {
+		var err error
+		secretMap, err = getExtractSecretMap(ctx, client, *remoteRef.Extract)
+		if err != nil {
+			return err
+		}
+		return collectProviderTags(ctx, client, remoteRef.Extract.Key)
+	})
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
-
-	// get multiple secrets from the store
-	secretMap, err := client.GetSecretMap(ctx, *remoteRef.Extract)
+	secretMap, err = transformExtractSecrets(externalSecret, remoteRef, secretMap)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
+	return secretMap, servedBy, nil
+}
+
+func transformExtractSecrets(externalSecret *esv1beta1.ExternalSecret, remoteRef esv1beta1.ExternalSecretDataFromRemoteRef, secretMap map[string][]byte) (map[string][]byte, error) {
+	var err error
 
 	// rewrite the keys if needed
 	secretMap, err = utils.RewriteMap(remoteRef.Rewrite, secretMap)
@@ -175,9 +408,9 @@ func (r *Reconciler) handleExtractSecrets(ctx context.Context, externalSecret *e
 	}
 
 	// validate the keys
-	err = utils.ValidateKeys(secretMap)
+	secretMap, err = validateKeys(externalSecret.Spec.KeyNamePolicy, secretMap)
 	if err != nil {
-		return nil, fmt.Errorf(errInvalidKeys, err)
+		return nil, err
 	}
 
 	// decode the secrets if needed
@@ -186,14 +419,21 @@ func (r *Reconciler) handleExtractSecrets(ctx context.Context, externalSecret *e
 		return nil, fmt.Errorf(errDecode, remoteRef.Extract.DecodingStrategy, err)
 	}
 
+	// convert the decoded secrets to UTF-8
+	secretMap, err = utils.TranscodeMap(remoteRef.Extract.SourceEncoding, remoteRef.Extract.InvalidEncodingPolicy, secretMap)
+	if err != nil {
+		return nil, fmt.Errorf(errTranscode, err)
+	}
+
 	return secretMap, err
 }
 
-func (r *Reconciler) handleFindAllSecrets(ctx context.Context, externalSecret *esv1beta1.ExternalSecret, remoteRef esv1beta1.ExternalSecretDataFromRemoteRef, cmgr *secretstore.Manager) (map[string][]byte, error) {
+func (r *Reconciler) handleFindAllSecrets(ctx context.Context, externalSecret *esv1beta1.ExternalSecret, remoteRef esv1beta1.ExternalSecretDataFromRemoteRef, cmgr *secretstore.Manager, decrypter *decryption.Decrypter) (map[string][]byte, error) {
 	client, err := cmgr.Get(ctx, externalSecret.Spec.SecretStoreRef, externalSecret.Namespace, remoteRef.SourceRef)
 	if err != nil {
 		return nil, err
 	}
+	client = withDecryption(withProviderTimeout(client, externalSecret.Spec.ProviderTimeout), decrypter)
 
 	// get all secrets from the store that match the selector
 	secretMap, err := client.GetAllSecrets(ctx, *remoteRef.Find)
@@ -201,6 +441,13 @@ func (r *Reconciler) handleFindAllSecrets(ctx context.Context, externalSecret *e
 		return nil, fmt.Errorf("error getting all secrets: %w", err)
 	}
 
+	// remove excluded secrets before the keys are rewritten
+	secretMap, err = find.Exclude(remoteRef.Find.Exclude, secretMap)
+	if err != nil {
+		return nil, err
+	}
+	secretMap = find.Limit(remoteRef.Find.Limit, secretMap)
+
 	// rewrite the keys if needed
 	secretMap, err = utils.RewriteMap(remoteRef.Rewrite, secretMap)
 	if err != nil {
@@ -214,9 +461,9 @@ func (r *Reconciler) handleFindAllSecrets(ctx context.Context, externalSecret *e
 	}
 
 	// validate the keys
-	err = utils.ValidateKeys(secretMap)
+	secretMap, err = validateKeys(externalSecret.Spec.KeyNamePolicy, secretMap)
 	if err != nil {
-		return nil, fmt.Errorf(errInvalidKeys, err)
+		return nil, err
 	}
 
 	// decode the secrets if needed
@@ -227,6 +474,22 @@ func (r *Reconciler) handleFindAllSecrets(ctx context.Context, externalSecret *e
 	return secretMap, err
 }
 
+// validateKeys validates the keys of the secret map.
+// With the Sanitize key name policy invalid characters are replaced first.
+func validateKeys(policy esv1beta1.ExternalSecretKeyNamePolicy, secretMap map[string][]byte) (map[string][]byte, error) {
+	if policy == esv1beta1.KeyNamePolicySanitize {
+		var err error
+		secretMap, err = utils.SanitizeKeys(secretMap)
+		if err != nil {
+			return nil, fmt.Errorf(errSanitizeKeys, err)
+		}
+	}
+	if err := utils.ValidateKeys(secretMap); err != nil {
+		return nil, fmt.Errorf(errInvalidKeys, err)
+	}
+	return secretMap, nil
+}
+
 func shouldSkipGenerator(r *Reconciler, generatorDef *apiextensions.JSON) (bool, error) {
 	var genControllerClass genv1alpha1.ControllerClassResource
 	err := json.Unmarshal(generatorDef.Raw, &genControllerClass)
@@ -0,0 +1,47 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalsecret
+
+import (
+	"fmt"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+)
+
+const errSecretTypeMissingKeys = "secret of type %s is missing the required keys: %s"
+
+// requiredKeysByType lists the keys a Secret of a built-in type must contain.
+var requiredKeysByType = map[v1.SecretType][]string{
+	v1.SecretTypeTLS:       {v1.TLSCertKey, v1.TLSPrivateKeyKey},
+	v1.SecretTypeBasicAuth: {v1.BasicAuthUsernameKey, v1.BasicAuthPasswordKey},
+	v1.SecretTypeSSHAuth:   {v1.SSHAuthPrivateKey},
+}
+
+// validateSecretType checks that the data of the Secret contains the keys required by its type,
+// so a missing key is reported by name instead of being rejected by the API server.
+// Other types are not checked.
+func validateSecretType(secret *v1.Secret) error {
+	var missing []string
+	for _, key := range requiredKeysByType[secret.Type] {
+		if _, ok := secret.Data[key]; !ok {
+			missing = append(missing, key)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf(errSecretTypeMissingKeys, secret.Type, strings.Join(missing, ", "))
+	}
+	return nil
+}
@@ -0,0 +1,158 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalsecret
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+)
+
+func TestValidateSecretType(t *testing.T) {
+	tests := []struct {
+		name    string
+		typ     v1.SecretType
+		data    map[string][]byte
+		wantErr string
+	}{
+		{
+			name: "opaque is not checked",
+			typ:  v1.SecretTypeOpaque,
+		},
+		{
+			name: "basic-auth with username and password",
+			typ:  v1.SecretTypeBasicAuth,
+			data: map[string][]byte{"username": []byte("admin"), "password": []byte("s3cr3t")},
+		},
+		{
+			name:    "basic-auth without password",
+			typ:     v1.SecretTypeBasicAuth,
+			data:    map[string][]byte{"username": []byte("admin")},
+			wantErr: "secret of type kubernetes.io/basic-auth is missing the required keys: password",
+		},
+		{
+			name:    "basic-auth without keys",
+			typ:     v1.SecretTypeBasicAuth,
+			wantErr: "secret of type kubernetes.io/basic-auth is missing the required keys: username, password",
+		},
+		{
+			name: "ssh-auth with private key",
+			typ:  v1.SecretTypeSSHAuth,
+			data: map[string][]byte{"ssh-privatekey": []byte("key")},
+		},
+		{
+			name:    "ssh-auth without private key",
+			typ:     v1.SecretTypeSSHAuth,
+			data:    map[string][]byte{"id_rsa": []byte("key")},
+			wantErr: "secret of type kubernetes.io/ssh-auth is missing the required keys: ssh-privatekey",
+		},
+		{
+			name:    "tls without key",
+			typ:     v1.SecretTypeTLS,
+			data:    map[string][]byte{"tls.crt": []byte("cert")},
+			wantErr: "secret of type kubernetes.io/tls is missing the required keys: tls.key",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateSecretType(&v1.Secret{Type: tt.typ, Data: tt.data})
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.wantErr {
+				t.Errorf("expected error %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestReconcileAllowedSecretTypes(t *testing.T) {
+	tests := []struct {
+		name       string
+		secretType v1.SecretType
+		wantSynced bool
+	}{
+		{
+			name:       "allowed type",
+			secretType: v1.SecretTypeOpaque,
+			wantSynced: true,
+		},
+		{
+			name:       "disallowed type",
+			secretType: v1.SecretTypeServiceAccountToken,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			newTestProvider(t).WithGetSecret([]byte("value"), nil)
+			es := &esv1beta1.ExternalSecret{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-es", Namespace: "default"},
+				Spec: esv1beta1.ExternalSecretSpec{
+					RefreshInterval: &metav1.Duration{Duration: time.Hour},
+					SecretStoreRef:  esv1beta1.SecretStoreRef{Name: "test-store"},
+					Target: esv1beta1.ExternalSecretTarget{
+						Name:           "target",
+						CreationPolicy: esv1beta1.CreatePolicyOwner,
+						Template:       &esv1beta1.ExternalSecretTemplate{Type: tt.secretType},
+					},
+					Data: []esv1beta1.ExternalSecretData{
+						{SecretKey: "foo", RemoteRef: esv1beta1.ExternalSecretDataRemoteRef{Key: "bar"}},
+					},
+				},
+			}
+			c := newTestClientBuilder(t, newTestStore(), es).Build()
+			r := newTestReconciler(c)
+			r.AllowedSecretTypes = []v1.SecretType{v1.SecretTypeOpaque, v1.SecretTypeTLS}
+			key := types.NamespacedName{Name: "test-es", Namespace: "default"}
+			if _, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: key}); err != nil {
+				t.Fatalf("Reconcile() returned an error: %v", err)
+			}
+
+			err := c.Get(context.Background(), types.NamespacedName{Name: "target", Namespace: "default"}, &v1.Secret{})
+			if tt.wantSynced && err != nil {
+				t.Fatalf("the secret was not created: %v", err)
+			}
+			if !tt.wantSynced && !apierrors.IsNotFound(err) {
+				t.Fatalf("expected the secret not to be created, got: %v", err)
+			}
+
+			got := &esv1beta1.ExternalSecret{}
+			if err := c.Get(context.Background(), key, got); err != nil {
+				t.Fatal(err)
+			}
+			cond := GetExternalSecretCondition(got.Status, esv1beta1.ExternalSecretReady)
+			if tt.wantSynced {
+				if cond == nil || cond.Status != v1.ConditionTrue {
+					t.Errorf("unexpected Ready condition: %v", cond)
+				}
+				return
+			}
+			if cond == nil || cond.Status != v1.ConditionFalse || cond.Message != msgErrorTypeNotAllowed {
+				t.Errorf("unexpected Ready condition: %v", cond)
+			}
+		})
+	}
+}
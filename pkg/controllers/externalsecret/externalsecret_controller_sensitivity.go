@@ -0,0 +1,87 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalsecret
+
+import (
+	"slices"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+)
+
+// annotateSensitiveKeys lists the keys marked in spec.data[].sensitivity in the
+// sensitive-keys and encrypted-keys annotations of the secret, the annotations are removed if no key is marked.
+// previous holds the former values of the annotations. Keys listed there that are not written by this ExternalSecret
+// are kept while they exist in the secret, so ExternalSecrets merging into the same secret do not remove each other's keys.
+func annotateSensitiveKeys(es *esv1beta1.ExternalSecret, secret *v1.Secret, previous map[string]string) {
+	own := make(map[string]bool)
+	marked := map[string]map[string]bool{
+		esv1beta1.AnnotationSensitiveKeys: {},
+		esv1beta1.AnnotationEncryptedKeys: {},
+	}
+	for _, data := range es.Spec.Data {
+		for _, key := range dataSecretKeys(es, data) {
+			own[key] = true
+			switch data.Sensitivity {
+			case esv1beta1.KeySensitivityEncrypted:
+				marked[esv1beta1.AnnotationEncryptedKeys][key] = true
+				marked[esv1beta1.AnnotationSensitiveKeys][key] = true
+			case esv1beta1.KeySensitivitySensitive:
+				marked[esv1beta1.AnnotationSensitiveKeys][key] = true
+			case esv1beta1.KeySensitivityNone:
+			}
+		}
+	}
+
+	for annotation, keys := range marked {
+		for _, key := range strings.Split(previous[annotation], ",") {
+			if key != "" && !own[key] {
+				keys[key] = true
+			}
+		}
+		var present []string
+		for key := range keys {
+			if _, ok := secret.Data[key]; ok {
+				present = append(present, key)
+			}
+		}
+		if len(present) == 0 {
+			delete(secret.Annotations, annotation)
+			continue
+		}
+		slices.Sort(present)
+		secret.Annotations[annotation] = strings.Join(present, ",")
+	}
+}
+
+// dataSecretKeys returns the keys a spec.data[] entry writes to the secret, after keyPrefix and keySuffix.
+func dataSecretKeys(es *esv1beta1.ExternalSecret, data esv1beta1.ExternalSecretData) []string {
+	keys := []string{data.SecretKey}
+	if len(data.RemoteRef.Properties) > 0 {
+		keys = keys[:0]
+		for key := range data.RemoteRef.Properties {
+			keys = append(keys, key)
+		}
+	}
+	if data.RemoteRef.Split != "" {
+		_, keys, _ = esv1beta1.CompileSplit(data.RemoteRef.Split)
+	}
+	for i, key := range keys {
+		keys[i] = es.Spec.Target.KeyPrefix + key + es.Spec.Target.KeySuffix
+	}
+	return keys
+}
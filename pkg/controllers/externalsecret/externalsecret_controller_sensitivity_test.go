@@ -0,0 +1,128 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalsecret
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	v1 "k8s.io/api/core/v1"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+)
+
+func TestAnnotateSensitiveKeys(t *testing.T) {
+	tests := []struct {
+		name        string
+		target      esv1beta1.ExternalSecretTarget
+		data        []esv1beta1.ExternalSecretData
+		secretData  []string
+		annotations map[string]string
+		previous    map[string]string
+		want        map[string]string
+	}{
+		{
+			name: "no sensitivity",
+			data: []esv1beta1.ExternalSecretData{
+				{SecretKey: "user"},
+				{SecretKey: "password", Sensitivity: esv1beta1.KeySensitivityNone},
+			},
+			secretData: []string{"user", "password"},
+			want:       map[string]string{},
+		},
+		{
+			name: "sensitive and encrypted keys",
+			data: []esv1beta1.ExternalSecretData{
+				{SecretKey: "user"},
+				{SecretKey: "password", Sensitivity: esv1beta1.KeySensitivitySensitive},
+				{SecretKey: "token", Sensitivity: esv1beta1.KeySensitivityEncrypted},
+				{SecretKey: "api-key", Sensitivity: esv1beta1.KeySensitivityEncrypted},
+			},
+			secretData: []string{"user", "password", "token", "api-key"},
+			want: map[string]string{
+				esv1beta1.AnnotationSensitiveKeys: "api-key,password,token",
+				esv1beta1.AnnotationEncryptedKeys: "api-key,token",
+			},
+		},
+		{
+			name:   "properties with key prefix",
+			target: esv1beta1.ExternalSecretTarget{KeyPrefix: "db-"},
+			data: []esv1beta1.ExternalSecretData{
+				{
+					RemoteRef: esv1beta1.ExternalSecretDataRemoteRef{
+						Key:        "database",
+						Properties: map[string]string{"user": "username", "password": "password"},
+					},
+					Sensitivity: esv1beta1.KeySensitivitySensitive,
+				},
+			},
+			secretData: []string{"db-user", "db-password"},
+			want: map[string]string{
+				esv1beta1.AnnotationSensitiveKeys: "db-password,db-user",
+			},
+		},
+		{
+			name: "keys missing in the secret are not listed",
+			data: []esv1beta1.ExternalSecretData{
+				{SecretKey: "token", Sensitivity: esv1beta1.KeySensitivityEncrypted},
+			},
+			secretData: []string{"config"},
+			annotations: map[string]string{
+				esv1beta1.AnnotationSensitiveKeys: "token",
+				esv1beta1.AnnotationEncryptedKeys: "token",
+			},
+			want: map[string]string{},
+		},
+		{
+			name:   "keys of other ExternalSecrets are kept with merge",
+			target: esv1beta1.ExternalSecretTarget{CreationPolicy: esv1beta1.CreatePolicyMerge},
+			data: []esv1beta1.ExternalSecretData{
+				{SecretKey: "password", Sensitivity: esv1beta1.KeySensitivityNone},
+				{SecretKey: "token", Sensitivity: esv1beta1.KeySensitivityEncrypted},
+			},
+			secretData: []string{"password", "token", "other", "config"},
+			previous: map[string]string{
+				esv1beta1.AnnotationSensitiveKeys: "other,password,removed",
+				esv1beta1.AnnotationEncryptedKeys: "other",
+			},
+			want: map[string]string{
+				esv1beta1.AnnotationSensitiveKeys: "other,token",
+				esv1beta1.AnnotationEncryptedKeys: "other,token",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			es := &esv1beta1.ExternalSecret{
+				Spec: esv1beta1.ExternalSecretSpec{
+					Target: tt.target,
+					Data:   tt.data,
+				},
+			}
+			secret := &v1.Secret{Data: map[string][]byte{}}
+			secret.Annotations = map[string]string{}
+			for k, v := range tt.annotations {
+				secret.Annotations[k] = v
+			}
+			for _, key := range tt.secretData {
+				secret.Data[key] = []byte("value")
+			}
+			annotateSensitiveKeys(es, secret, tt.previous)
+			if diff := cmp.Diff(tt.want, secret.Annotations); diff != "" {
+				t.Errorf("unexpected annotations (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,172 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalsecret
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	"github.com/external-secrets/external-secrets/pkg/controllers/externalsecret/esmetrics"
+	"github.com/external-secrets/external-secrets/pkg/utils"
+)
+
+// syncShadow reads the data of the ExternalSecret from the candidate store of spec.shadow,
+// writes it to the shadow secret and compares it with the data of the primary store.
+// The result is reported in the ShadowMatch condition, errors never fail the primary sync.
+func (r *Reconciler) syncShadow(ctx context.Context, es *esv1beta1.ExternalSecret, primaryData map[string][]byte) {
+	if es.Spec.Shadow == nil {
+		// remove the condition once spec.shadow is removed
+		if cond := GetExternalSecretCondition(es.Status, esv1beta1.ExternalSecretShadowMatch); cond != nil {
+			es.Status.Conditions = filterOutCondition(es.Status.Conditions, esv1beta1.ExternalSecretShadowMatch)
+			esmetrics.UpdateExternalSecretCondition(es, cond, 0.0)
+		}
+		return
+	}
+
+	shadowES := shadowExternalSecret(es)
+	candidateData, err := r.getProviderSecretData(ctx, shadowES)
+	// in read-only mode the data is only compared, the shadow secret is not written
+	if err == nil && !r.ReadOnly {
+		err = r.writeShadowSecret(ctx, es, shadowES, candidateData)
+	}
+	if err != nil {
+		r.Log.Error(err, logErrorSyncShadow, "ExternalSecret", client.ObjectKeyFromObject(es))
+		msg := redactRemoteKeys(es, fmt.Sprintf(msgShadowError, err))
+		cond := NewExternalSecretCondition(esv1beta1.ExternalSecretShadowMatch, v1.ConditionUnknown, esv1beta1.ConditionReasonShadowError, msg)
+		SetExternalSecretCondition(es, *cond)
+		return
+	}
+
+	if diff := diffShadowData(primaryData, candidateData); diff != "" {
+		cond := NewExternalSecretCondition(esv1beta1.ExternalSecretShadowMatch, v1.ConditionFalse, esv1beta1.ConditionReasonShadowMismatch, fmt.Sprintf(msgShadowMismatch, diff))
+		SetExternalSecretCondition(es, *cond)
+		return
+	}
+	cond := NewExternalSecretCondition(esv1beta1.ExternalSecretShadowMatch, v1.ConditionTrue, esv1beta1.ConditionReasonShadowMatch, msgShadowMatch)
+	SetExternalSecretCondition(es, *cond)
+}
+
+// shadowExternalSecret returns a copy of es that reads every entry from the candidate store
+// and targets the shadow secret.
+func shadowExternalSecret(es *esv1beta1.ExternalSecret) *esv1beta1.ExternalSecret {
+	shadow := es.DeepCopy()
+	shadow.Spec.SecretStoreRef = es.Spec.Shadow.StoreRef
+	for i := range shadow.Spec.Data {
+		shadow.Spec.Data[i].SourceRef = nil
+	}
+	for i := range shadow.Spec.DataFrom {
+		shadow.Spec.DataFrom[i].SourceRef = nil
+	}
+	shadow.Spec.Target.Name = es.Spec.Shadow.SecretName
+	shadow.Spec.Target.CreationPolicy = esv1beta1.CreatePolicyOwner
+	shadow.Spec.Shadow = nil
+	return shadow
+}
+
+// writeShadowSecret creates or updates the shadow secret with the data of the candidate store.
+// The shadow secret is always owned by the ExternalSecret, secrets owned by others are never written.
+func (r *Reconciler) writeShadowSecret(ctx context.Context, es, shadowES *esv1beta1.ExternalSecret, dataMap map[string][]byte) error {
+	name := es.Spec.Shadow.SecretName
+	secret := &v1.Secret{}
+	err := r.SecretClient.Get(ctx, client.ObjectKey{Name: name, Namespace: es.Namespace}, secret)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+	exists := err == nil
+	if exists && !metav1.IsControlledBy(secret, es) {
+		return fmt.Errorf(errShadowNotOwned, name)
+	}
+	if !exists {
+		secret = &v1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: es.Namespace,
+			},
+		}
+	}
+	existing := secret.DeepCopy()
+
+	if err := r.applyTemplate(ctx, shadowES, secret, dataMap, maps.Clone(secret.Data)); err != nil {
+		return fmt.Errorf(errApplyTemplate, err)
+	}
+	// the shadow secret is only written by this ExternalSecret, so previous annotations are not kept
+	annotateSensitiveKeys(shadowES, secret, nil)
+	if err := controllerutil.SetControllerReference(es, secret, r.Scheme); err != nil {
+		return err
+	}
+	if r.ControllerIdentity != "" {
+		secret.Labels[esv1beta1.LabelControllerIdentity] = r.ControllerIdentity
+	}
+	secret.Labels[esv1beta1.LabelManaged] = esv1beta1.LabelManagedValue
+	secret.Annotations[esv1beta1.AnnotationDataHash] = utils.ObjectHash(secret.Data)
+
+	fqdn := fmt.Sprintf(fieldOwnerTemplate, es.Name)
+	if !exists {
+		return r.Create(ctx, secret, client.FieldOwner(fqdn))
+	}
+	if equality.Semantic.DeepEqual(existing, secret) {
+		return nil
+	}
+	return r.Update(ctx, secret, client.FieldOwner(fqdn))
+}
+
+// diffShadowData describes the keys that differ between the primary and the candidate data,
+// it is empty if both are equal. Values are never included.
+func diffShadowData(primary, candidate map[string][]byte) string {
+	var changed, missing, unexpected []string
+	for key, value := range primary {
+		candidateValue, ok := candidate[key]
+		switch {
+		case !ok:
+			missing = append(missing, key)
+		case !bytes.Equal(value, candidateValue):
+			changed = append(changed, key)
+		}
+	}
+	for key := range candidate {
+		if _, ok := primary[key]; !ok {
+			unexpected = append(unexpected, key)
+		}
+	}
+
+	var parts []string
+	for _, part := range []struct {
+		name string
+		keys []string
+	}{
+		{"changed", changed},
+		{"missing", missing},
+		{"unexpected", unexpected},
+	} {
+		if len(part.keys) == 0 {
+			continue
+		}
+		slices.Sort(part.keys)
+		parts = append(parts, fmt.Sprintf("%s keys: %s", part.name, strings.Join(part.keys, ", ")))
+	}
+	return strings.Join(parts, "; ")
+}
@@ -0,0 +1,101 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalsecret
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+)
+
+func TestDiffShadowData(t *testing.T) {
+	tests := []struct {
+		name      string
+		primary   map[string][]byte
+		candidate map[string][]byte
+		want      string
+	}{
+		{
+			name:      "equal",
+			primary:   map[string][]byte{"user": []byte("admin"), "password": []byte("s3cr3t")},
+			candidate: map[string][]byte{"password": []byte("s3cr3t"), "user": []byte("admin")},
+		},
+		{
+			name:      "empty",
+			primary:   map[string][]byte{},
+			candidate: nil,
+		},
+		{
+			name:      "changed, missing and unexpected keys",
+			primary:   map[string][]byte{"user": []byte("admin"), "password": []byte("s3cr3t"), "token": []byte("a"), "host": []byte("db")},
+			candidate: map[string][]byte{"user": []byte("admin"), "password": []byte("other"), "token": []byte("b"), "port": []byte("5432")},
+			want:      "changed keys: password, token; missing keys: host; unexpected keys: port",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := diffShadowData(tt.primary, tt.candidate); got != tt.want {
+				t.Errorf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestShadowExternalSecret(t *testing.T) {
+	candidate := esv1beta1.SecretStoreRef{Name: "vault-new", Kind: esv1beta1.ClusterSecretStoreKind}
+	es := &esv1beta1.ExternalSecret{
+		Spec: esv1beta1.ExternalSecretSpec{
+			SecretStoreRef: esv1beta1.SecretStoreRef{Name: "vault"},
+			Target: esv1beta1.ExternalSecretTarget{
+				Name:           "app",
+				CreationPolicy: esv1beta1.CreatePolicyMerge,
+			},
+			Data: []esv1beta1.ExternalSecretData{
+				{
+					SecretKey: "user",
+					SourceRef: &esv1beta1.StoreSourceRef{SecretStoreRef: &esv1beta1.SecretStoreRef{Name: "aws"}},
+				},
+			},
+			DataFrom: []esv1beta1.ExternalSecretDataFromRemoteRef{
+				{
+					Extract:   &esv1beta1.ExternalSecretDataRemoteRef{Key: "app"},
+					SourceRef: &esv1beta1.StoreGeneratorSourceRef{StoreGroup: []esv1beta1.SecretStoreRef{{Name: "aws"}}},
+				},
+			},
+			Shadow: &esv1beta1.ExternalSecretShadow{
+				StoreRef:   candidate,
+				SecretName: "app-shadow",
+			},
+		},
+	}
+	want := es.DeepCopy()
+	want.Spec.SecretStoreRef = candidate
+	want.Spec.Target.Name = "app-shadow"
+	want.Spec.Target.CreationPolicy = esv1beta1.CreatePolicyOwner
+	want.Spec.Data[0].SourceRef = nil
+	want.Spec.DataFrom[0].SourceRef = nil
+	want.Spec.Shadow = nil
+
+	original := es.DeepCopy()
+	got := shadowExternalSecret(es)
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("unexpected shadow (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(original, es); diff != "" {
+		t.Errorf("ExternalSecret was modified (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,88 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalsecret
+
+import (
+	"bytes"
+	"fmt"
+	"maps"
+	"slices"
+
+	v1 "k8s.io/api/core/v1"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+)
+
+// SecretSizeError is returned if a value or the data of the Secret exceed a size limit.
+// NOTE: size errors must never contain the secret value.
+type SecretSizeError struct {
+	// Key is the key of the value, empty if the total size of the data exceeds the limit.
+	Key   string
+	Size  int
+	Limit int
+}
+
+func (e *SecretSizeError) Error() string {
+	if e.Key == "" {
+		return fmt.Sprintf("secret data has %d bytes, the limit is %d bytes", e.Size, e.Limit)
+	}
+	return fmt.Sprintf("key %q has %d bytes, the limit is %d bytes", e.Key, e.Size, e.Limit)
+}
+
+// truncateSecretValues truncates the values of limits.truncateKeys that exceed maxKeySize in place.
+// It runs before target.encryptWith, a truncated ciphertext could not be decrypted.
+func truncateSecretValues(limits *esv1beta1.ExternalSecretSizeLimits, data map[string][]byte) {
+	if limits == nil || limits.MaxKeySize == nil {
+		return
+	}
+	maxKeySize := int(*limits.MaxKeySize)
+	for _, key := range limits.TruncateKeys {
+		if value, ok := data[key]; ok && len(value) > maxKeySize {
+			data[key] = bytes.Clone(value[:maxKeySize])
+		}
+	}
+}
+
+// limitSecretSize checks the values of data against the size limits of the ExternalSecret.
+// It runs on the final data, after target.encryptWith, as the ciphertext is larger than the plaintext.
+// Values of limits.truncateKeys were truncated before, so only their share of the total size is checked.
+// Keys are checked in lexical order, so the same key is reported on every sync.
+func limitSecretSize(limits *esv1beta1.ExternalSecretSizeLimits, data map[string][]byte) error {
+	maxTotalSize := v1.MaxSecretSize
+	var maxKeySize int
+	var truncateKeys []string
+	if limits != nil {
+		if limits.MaxTotalSize != nil {
+			maxTotalSize = int(*limits.MaxTotalSize)
+		}
+		if limits.MaxKeySize != nil {
+			maxKeySize = int(*limits.MaxKeySize)
+		}
+		truncateKeys = limits.TruncateKeys
+	}
+
+	var totalSize int
+	for _, key := range slices.Sorted(maps.Keys(data)) {
+		size := len(data[key])
+		if maxKeySize > 0 && size > maxKeySize && !slices.Contains(truncateKeys, key) {
+			return &SecretSizeError{Key: key, Size: size, Limit: maxKeySize}
+		}
+		totalSize += size
+	}
+	if totalSize > maxTotalSize {
+		return &SecretSizeError{Size: totalSize, Limit: maxTotalSize}
+	}
+	return nil
+}
@@ -0,0 +1,180 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalsecret
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+)
+
+func TestLimitSecretSize(t *testing.T) {
+	tests := []struct {
+		name    string
+		limits  *esv1beta1.ExternalSecretSizeLimits
+		data    map[string][]byte
+		want    map[string][]byte
+		wantErr *SecretSizeError
+	}{
+		{
+			name: "without limits",
+			data: map[string][]byte{"foo": []byte("bar")},
+			want: map[string][]byte{"foo": []byte("bar")},
+		},
+		{
+			name:    "default total size",
+			data:    map[string][]byte{"foo": []byte(strings.Repeat("a", v1.MaxSecretSize)), "bar": []byte("b")},
+			wantErr: &SecretSizeError{Size: v1.MaxSecretSize + 1, Limit: v1.MaxSecretSize},
+		},
+		{
+			name:    "key size",
+			limits:  &esv1beta1.ExternalSecretSizeLimits{MaxKeySize: ptr.To[int64](4)},
+			data:    map[string][]byte{"foo": []byte("bar"), "large": []byte("value")},
+			wantErr: &SecretSizeError{Key: "large", Size: 5, Limit: 4},
+		},
+		{
+			name:    "total size",
+			limits:  &esv1beta1.ExternalSecretSizeLimits{MaxTotalSize: ptr.To[int64](5)},
+			data:    map[string][]byte{"foo": []byte("bar"), "baz": []byte("bar")},
+			wantErr: &SecretSizeError{Size: 6, Limit: 5},
+		},
+		{
+			name: "truncate keys",
+			limits: &esv1beta1.ExternalSecretSizeLimits{
+				MaxKeySize:   ptr.To[int64](4),
+				MaxTotalSize: ptr.To[int64](7),
+				TruncateKeys: []string{"large"},
+			},
+			data: map[string][]byte{"foo": []byte("bar"), "large": []byte("value")},
+			want: map[string][]byte{"foo": []byte("bar"), "large": []byte("valu")},
+		},
+		{
+			name: "total size after truncation",
+			limits: &esv1beta1.ExternalSecretSizeLimits{
+				MaxKeySize:   ptr.To[int64](4),
+				MaxTotalSize: ptr.To[int64](6),
+				TruncateKeys: []string{"large"},
+			},
+			data:    map[string][]byte{"foo": []byte("bar"), "large": []byte("value")},
+			wantErr: &SecretSizeError{Size: 7, Limit: 6},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			truncateSecretValues(tt.limits, tt.data)
+			err := limitSecretSize(tt.limits, tt.data)
+			if tt.wantErr != nil {
+				var sizeErr *SecretSizeError
+				if !errors.As(err, &sizeErr) {
+					t.Fatalf("expected a size error, got %v", err)
+				}
+				if diff := cmp.Diff(tt.wantErr, sizeErr); diff != "" {
+					t.Errorf("size error mismatch (-want +got):\n%s", diff)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tt.want, tt.data); diff != "" {
+				t.Errorf("data mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestLimitSecretSizeTruncatedCiphertext(t *testing.T) {
+	// the ciphertext of a truncated value exceeds maxKeySize, only the total size is checked
+	limits := &esv1beta1.ExternalSecretSizeLimits{
+		MaxKeySize:   ptr.To[int64](4),
+		MaxTotalSize: ptr.To[int64](16),
+		TruncateKeys: []string{"large"},
+	}
+	if err := limitSecretSize(limits, map[string][]byte{"large": []byte("ciphertext")}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	wantErr := &SecretSizeError{Size: 20, Limit: 16}
+	err := limitSecretSize(limits, map[string][]byte{"large": []byte("ciphertextciphertext")})
+	var sizeErr *SecretSizeError
+	if !errors.As(err, &sizeErr) {
+		t.Fatalf("expected a size error, got %v", err)
+	}
+	if diff := cmp.Diff(wantErr, sizeErr); diff != "" {
+		t.Errorf("size error mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestReconcileSizeLimitsEncrypted(t *testing.T) {
+	_, recipient := newEncryptionTestKey(t)
+	// the plaintext is within the limit, the armored ciphertext is not
+	value := []byte(strings.Repeat("a", 800*1024))
+	newTestProvider(t).WithGetSecret(value, nil)
+	es := &esv1beta1.ExternalSecret{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-es", Namespace: "default"},
+		Spec: esv1beta1.ExternalSecretSpec{
+			RefreshInterval: &metav1.Duration{Duration: time.Hour},
+			SecretStoreRef:  esv1beta1.SecretStoreRef{Name: "test-store"},
+			Target: esv1beta1.ExternalSecretTarget{
+				Name:           "target",
+				CreationPolicy: esv1beta1.CreatePolicyOwner,
+				EncryptWith: &esv1beta1.ExternalSecretEncryption{
+					Format:     esv1beta1.EncryptionFormatAge,
+					Recipients: []string{recipient},
+				},
+			},
+			Data: []esv1beta1.ExternalSecretData{
+				{SecretKey: "foo", RemoteRef: esv1beta1.ExternalSecretDataRemoteRef{Key: "bar"}},
+			},
+		},
+	}
+	c := newTestClientBuilder(t, newTestStore(), es).Build()
+	r := newTestReconciler(c)
+	ctx := context.Background()
+	key := types.NamespacedName{Name: "test-es", Namespace: "default"}
+
+	if _, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key}); err != nil {
+		t.Fatalf("Reconcile() returned an error: %v", err)
+	}
+	if err := c.Get(ctx, types.NamespacedName{Name: "target", Namespace: "default"}, &v1.Secret{}); !apierrors.IsNotFound(err) {
+		t.Errorf("expected the oversized secret not to be created, got: %v", err)
+	}
+	got := &esv1beta1.ExternalSecret{}
+	if err := c.Get(ctx, key, got); err != nil {
+		t.Fatal(err)
+	}
+	cond := GetExternalSecretCondition(got.Status, esv1beta1.ExternalSecretReady)
+	if cond == nil || cond.Status != v1.ConditionFalse || !strings.Contains(cond.Message, "secret data has") {
+		t.Errorf("unexpected Ready condition: %v", cond)
+	}
+}
+
+func TestSecretSizeError(t *testing.T) {
+	err := &SecretSizeError{Key: "tls.crt", Size: 2048, Limit: 1024}
+	if got, want := err.Error(), `key "tls.crt" has 2048 bytes, the limit is 1024 bytes`; got != want {
+		t.Errorf("unexpected message: %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,212 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalsecret
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+)
+
+// dataSourceStatus returns the status of spec.data[i] that was read from the remote key.
+// Without statusPolicy.resolvedSources only entries served by a store group or with
+// key candidates are recorded, nil is returned for all other entries.
+func dataSourceStatus(es *esv1beta1.ExternalSecret, i int, secretRef esv1beta1.ExternalSecretData, servedBy *esv1beta1.SecretStoreRef, key string) *esv1beta1.ExternalSecretSourceStatus {
+	path := fmt.Sprintf("spec.data[%d]", i)
+	resolvedKey := ""
+	if len(secretRef.RemoteRef.KeyCandidates) > 0 && key != "" {
+		resolvedKey = redactRemoteKeys(es, key)
+	}
+	if !hasResolvedSources(es) {
+		status := servedByStatus(path, servedBy)
+		if resolvedKey != "" {
+			if status == nil {
+				status = &esv1beta1.ExternalSecretSourceStatus{Path: path}
+			}
+			status.Key = resolvedKey
+		}
+		return status
+	}
+
+	keys := []string{secretRef.SecretKey}
+	if len(secretRef.RemoteRef.Properties) > 0 {
+		keys = slices.Sorted(maps.Keys(secretRef.RemoteRef.Properties))
+	}
+	if secretRef.RemoteRef.Split != "" {
+		_, keys, _ = esv1beta1.CompileSplit(secretRef.RemoteRef.Split)
+		slices.Sort(keys)
+	}
+	remoteRef := secretRef.RemoteRef
+	if key != "" {
+		remoteRef.Key = key
+	}
+	return &esv1beta1.ExternalSecretSourceStatus{
+		Path:     path,
+		StoreRef: resolvedStoreRef(es, toStoreGenSourceRef(secretRef.SourceRef), servedBy),
+		Key:      resolvedKey,
+		Summary:  redactRemoteKeys(es, describeRemoteRef(remoteRef)),
+		Keys:     redactSourceKeys(es, keys),
+	}
+}
+
+// dataFromSourceStatus returns the status of spec.dataFrom[i], see dataSourceStatus.
+func dataFromSourceStatus(es *esv1beta1.ExternalSecret, i int, remoteRef esv1beta1.ExternalSecretDataFromRemoteRef, servedBy *esv1beta1.SecretStoreRef, secretMap map[string][]byte) *esv1beta1.ExternalSecretSourceStatus {
+	path := fmt.Sprintf("spec.dataFrom[%d]", i)
+	if !hasResolvedSources(es) {
+		return servedByStatus(path, servedBy)
+	}
+
+	status := &esv1beta1.ExternalSecretSourceStatus{
+		Path: path,
+		Keys: redactSourceKeys(es, slices.Sorted(maps.Keys(secretMap))),
+	}
+	switch {
+	case remoteRef.Find != nil:
+		status.StoreRef = resolvedStoreRef(es, remoteRef.SourceRef, servedBy)
+		status.Summary = redactRemoteKeys(es, describeFind(*remoteRef.Find, len(secretMap)))
+		// the keys of find results are derived from remote key names,
+		// so they are left out if remote keys must not be shown
+		if es.Spec.StatusPolicy != nil && (es.Spec.StatusPolicy.RemoteKeys == esv1beta1.KeyVisibilityHash || es.Spec.StatusPolicy.RemoteKeys == esv1beta1.KeyVisibilityOmit) {
+			status.Keys = nil
+		}
+	case remoteRef.Extract != nil:
+		status.StoreRef = resolvedStoreRef(es, remoteRef.SourceRef, servedBy)
+		status.Summary = redactRemoteKeys(es, "extract "+describeRemoteRef(*remoteRef.Extract))
+	case remoteRef.SourceRef != nil && remoteRef.SourceRef.GeneratorRef != nil:
+		status.Summary = fmt.Sprintf("generator %s/%s", remoteRef.SourceRef.GeneratorRef.Kind, remoteRef.SourceRef.GeneratorRef.Name)
+	}
+	if len(remoteRef.Rewrite) > 0 {
+		status.Summary += fmt.Sprintf(", %d rewrites", len(remoteRef.Rewrite))
+	}
+	return status
+}
+
+// redactSourceKeys applies spec.statusPolicy.remoteKeys to the keys of a source,
+// as keys of properties and extracted secrets may repeat remote keys.
+func redactSourceKeys(es *esv1beta1.ExternalSecret, keys []string) []string {
+	for i, key := range keys {
+		keys[i] = redactRemoteKeys(es, key)
+	}
+	return keys
+}
+
+// affixSourceKeys adds target.keyPrefix and target.keySuffix to the keys of the sources,
+// so they match the keys of the target secret.
+func affixSourceKeys(sources []esv1beta1.ExternalSecretSourceStatus, prefix, suffix string) {
+	for i := range sources {
+		for j, key := range sources[i].Keys {
+			sources[i].Keys[j] = prefix + key + suffix
+		}
+	}
+}
+
+// hasResolvedSources returns true if every entry is recorded in status.sources,
+// which is always the case with validateOnly and creationPolicy=None, as the status is all they report.
+func hasResolvedSources(es *esv1beta1.ExternalSecret) bool {
+	return es.Spec.ValidateOnly || isCreationPolicyNone(es) || (es.Spec.StatusPolicy != nil && es.Spec.StatusPolicy.ResolvedSources)
+}
+
+// setSourceError records the error of an entry that could not be fetched,
+// with the reason if the provider classified it.
+func setSourceError(es *esv1beta1.ExternalSecret, source *esv1beta1.ExternalSecretSourceStatus, err error) {
+	source.Error = redactRemoteKeys(es, err.Error())
+	var providerErr *esv1beta1.ProviderError
+	if errors.As(err, &providerErr) {
+		source.Reason = providerErr.Reason
+	}
+}
+
+func servedByStatus(path string, servedBy *esv1beta1.SecretStoreRef) *esv1beta1.ExternalSecretSourceStatus {
+	if servedBy == nil {
+		return nil
+	}
+	return &esv1beta1.ExternalSecretSourceStatus{
+		Path:     path,
+		StoreRef: servedBy,
+	}
+}
+
+// resolvedStoreRef returns the store an entry was read from.
+func resolvedStoreRef(es *esv1beta1.ExternalSecret, sourceRef *esv1beta1.StoreGeneratorSourceRef, servedBy *esv1beta1.SecretStoreRef) *esv1beta1.SecretStoreRef {
+	storeRef := servedBy
+	if storeRef == nil && sourceRef != nil && sourceRef.SecretStoreRef != nil {
+		storeRef = sourceRef.SecretStoreRef
+	}
+	if storeRef == nil {
+		storeRef = &es.Spec.SecretStoreRef
+	}
+	if storeRef.Name == "" {
+		return nil
+	}
+
+	resolved := storeRef.DeepCopy()
+	if resolved.Kind == "" {
+		resolved.Kind = esv1beta1.SecretStoreKind
+	}
+	return resolved
+}
+
+// describeRemoteRef describes a remote reference in a human-readable way, e.g. `key=db property=password`.
+func describeRemoteRef(ref esv1beta1.ExternalSecretDataRemoteRef) string {
+	parts := []string{"key=" + ref.Key}
+	if ref.Property != "" {
+		parts = append(parts, "property="+ref.Property)
+	}
+	if ref.PropertyPointer != "" {
+		parts = append(parts, "propertyPointer="+ref.PropertyPointer)
+	}
+	if len(ref.Properties) > 0 {
+		properties := make([]string, 0, len(ref.Properties))
+		for _, secretKey := range slices.Sorted(maps.Keys(ref.Properties)) {
+			properties = append(properties, secretKey+":"+ref.Properties[secretKey])
+		}
+		parts = append(parts, "properties="+strings.Join(properties, ","))
+	}
+	if ref.Split != "" {
+		parts = append(parts, "split="+ref.Split)
+	}
+	if ref.Version != "" {
+		parts = append(parts, "version="+ref.Version)
+	}
+	if ref.DecodingStrategy != "" && ref.DecodingStrategy != esv1beta1.ExternalSecretDecodeNone {
+		parts = append(parts, "decodingStrategy="+string(ref.DecodingStrategy))
+	}
+	if ref.SourceEncoding != "" && ref.SourceEncoding != esv1beta1.ExternalSecretSourceEncodingUTF8 {
+		parts = append(parts, "sourceEncoding="+string(ref.SourceEncoding))
+	}
+	return strings.Join(parts, " ")
+}
+
+// describeFind describes a find in a human-readable way, e.g. `find path=db name=^prod- found 3 secrets`.
+func describeFind(find esv1beta1.ExternalSecretFind, found int) string {
+	parts := []string{"find"}
+	if find.Path != nil {
+		parts = append(parts, "path="+*find.Path)
+	}
+	if find.Name != nil {
+		parts = append(parts, "name="+find.Name.RegExp)
+	}
+	for _, tag := range slices.Sorted(maps.Keys(find.Tags)) {
+		parts = append(parts, fmt.Sprintf("tag:%s=%s", tag, find.Tags[tag]))
+	}
+	if len(find.Exclude) > 0 {
+		parts = append(parts, fmt.Sprintf("%d excludes", len(find.Exclude)))
+	}
+	return fmt.Sprintf("%s found %d secrets", strings.Join(parts, " "), found)
+}
@@ -0,0 +1,222 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalsecret
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/utils/ptr"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+)
+
+func TestSourceStatus(t *testing.T) {
+	groupStore := &esv1beta1.SecretStoreRef{Name: "secondary", Kind: esv1beta1.ClusterSecretStoreKind}
+	data := esv1beta1.ExternalSecretData{
+		SecretKey: "password",
+		RemoteRef: esv1beta1.ExternalSecretDataRemoteRef{Key: "db/creds", Property: "password", Version: "2"},
+	}
+	extract := esv1beta1.ExternalSecretDataFromRemoteRef{
+		Extract: &esv1beta1.ExternalSecretDataRemoteRef{Key: "db/config"},
+		Rewrite: []esv1beta1.ExternalSecretRewrite{{}},
+		SourceRef: &esv1beta1.StoreGeneratorSourceRef{
+			SecretStoreRef: &esv1beta1.SecretStoreRef{Name: "other"},
+		},
+	}
+	find := esv1beta1.ExternalSecretDataFromRemoteRef{
+		Find: &esv1beta1.ExternalSecretFind{Path: ptr.To("db"), Name: &esv1beta1.FindName{RegExp: "^prod-"}},
+	}
+	generator := esv1beta1.ExternalSecretDataFromRemoteRef{
+		SourceRef: &esv1beta1.StoreGeneratorSourceRef{
+			GeneratorRef: &esv1beta1.GeneratorRef{Kind: "Password", Name: "db-password"},
+		},
+	}
+	candidates := esv1beta1.ExternalSecretData{
+		SecretKey: "token",
+		RemoteRef: esv1beta1.ExternalSecretDataRemoteRef{Key: "app/token", KeyCandidates: []string{"legacy/token"}},
+	}
+	secretMap := map[string][]byte{"prod-b": nil, "prod-a": nil}
+
+	newES := func(policy *esv1beta1.ExternalSecretStatusPolicy) *esv1beta1.ExternalSecret {
+		es := &esv1beta1.ExternalSecret{}
+		es.Spec.SecretStoreRef = esv1beta1.SecretStoreRef{Name: "vault"}
+		es.Spec.Data = []esv1beta1.ExternalSecretData{data, candidates}
+		es.Spec.DataFrom = []esv1beta1.ExternalSecretDataFromRemoteRef{extract, find, generator}
+		es.Spec.StatusPolicy = policy
+		return es
+	}
+
+	tests := []struct {
+		name string
+		es   *esv1beta1.ExternalSecret
+		got  func(es *esv1beta1.ExternalSecret) *esv1beta1.ExternalSecretSourceStatus
+		want *esv1beta1.ExternalSecretSourceStatus
+	}{
+		{
+			name: "only store group entries are recorded by default",
+			es:   newES(nil),
+			got: func(es *esv1beta1.ExternalSecret) *esv1beta1.ExternalSecretSourceStatus {
+				return dataSourceStatus(es, 0, data, nil, "db/creds")
+			},
+			want: nil,
+		},
+		{
+			name: "key candidate entry by default",
+			es:   newES(nil),
+			got: func(es *esv1beta1.ExternalSecret) *esv1beta1.ExternalSecretSourceStatus {
+				return dataSourceStatus(es, 1, candidates, nil, "legacy/token")
+			},
+			want: &esv1beta1.ExternalSecretSourceStatus{Path: "spec.data[1]", Key: "legacy/token"},
+		},
+		{
+			name: "resolved key candidate entry hides remote keys",
+			es:   newES(&esv1beta1.ExternalSecretStatusPolicy{ResolvedSources: true, RemoteKeys: esv1beta1.KeyVisibilityOmit}),
+			got: func(es *esv1beta1.ExternalSecret) *esv1beta1.ExternalSecretSourceStatus {
+				return dataSourceStatus(es, 1, candidates, nil, "legacy/token")
+			},
+			want: &esv1beta1.ExternalSecretSourceStatus{
+				Path:     "spec.data[1]",
+				StoreRef: &esv1beta1.SecretStoreRef{Name: "vault", Kind: esv1beta1.SecretStoreKind},
+				Key:      "<redacted>",
+				Summary:  "key=<redacted>",
+				Keys:     []string{"token"},
+			},
+		},
+		{
+			name: "store group entry by default",
+			es:   newES(nil),
+			got: func(es *esv1beta1.ExternalSecret) *esv1beta1.ExternalSecretSourceStatus {
+				return dataFromSourceStatus(es, 0, extract, groupStore, secretMap)
+			},
+			want: &esv1beta1.ExternalSecretSourceStatus{Path: "spec.dataFrom[0]", StoreRef: groupStore},
+		},
+		{
+			name: "resolved data entry",
+			es:   newES(&esv1beta1.ExternalSecretStatusPolicy{ResolvedSources: true}),
+			got: func(es *esv1beta1.ExternalSecret) *esv1beta1.ExternalSecretSourceStatus {
+				return dataSourceStatus(es, 0, data, nil, "db/creds")
+			},
+			want: &esv1beta1.ExternalSecretSourceStatus{
+				Path:     "spec.data[0]",
+				StoreRef: &esv1beta1.SecretStoreRef{Name: "vault", Kind: esv1beta1.SecretStoreKind},
+				Summary:  "key=db/creds property=password version=2",
+				Keys:     []string{"password"},
+			},
+		},
+		{
+			name: "resolved extract uses the store of the sourceRef",
+			es:   newES(&esv1beta1.ExternalSecretStatusPolicy{ResolvedSources: true}),
+			got: func(es *esv1beta1.ExternalSecret) *esv1beta1.ExternalSecretSourceStatus {
+				return dataFromSourceStatus(es, 0, extract, nil, secretMap)
+			},
+			want: &esv1beta1.ExternalSecretSourceStatus{
+				Path:     "spec.dataFrom[0]",
+				StoreRef: &esv1beta1.SecretStoreRef{Name: "other", Kind: esv1beta1.SecretStoreKind},
+				Summary:  "extract key=db/config, 1 rewrites",
+				Keys:     []string{"prod-a", "prod-b"},
+			},
+		},
+		{
+			name: "resolved find",
+			es:   newES(&esv1beta1.ExternalSecretStatusPolicy{ResolvedSources: true}),
+			got: func(es *esv1beta1.ExternalSecret) *esv1beta1.ExternalSecretSourceStatus {
+				return dataFromSourceStatus(es, 1, find, nil, secretMap)
+			},
+			want: &esv1beta1.ExternalSecretSourceStatus{
+				Path:     "spec.dataFrom[1]",
+				StoreRef: &esv1beta1.SecretStoreRef{Name: "vault", Kind: esv1beta1.SecretStoreKind},
+				Summary:  "find path=db name=^prod- found 2 secrets",
+				Keys:     []string{"prod-a", "prod-b"},
+			},
+		},
+		{
+			name: "resolved extract hides remote keys in the listed keys",
+			es:   newES(&esv1beta1.ExternalSecretStatusPolicy{ResolvedSources: true, RemoteKeys: esv1beta1.KeyVisibilityOmit}),
+			got: func(es *esv1beta1.ExternalSecret) *esv1beta1.ExternalSecretSourceStatus {
+				return dataFromSourceStatus(es, 0, extract, nil, map[string][]byte{"db/config": nil, "user": nil})
+			},
+			want: &esv1beta1.ExternalSecretSourceStatus{
+				Path:     "spec.dataFrom[0]",
+				StoreRef: &esv1beta1.SecretStoreRef{Name: "other", Kind: esv1beta1.SecretStoreKind},
+				Summary:  "extract key=<redacted>, 1 rewrites",
+				Keys:     []string{"<redacted>", "user"},
+			},
+		},
+		{
+			name: "find with validateOnly and no status policy",
+			es: func() *esv1beta1.ExternalSecret {
+				es := newES(nil)
+				es.Spec.ValidateOnly = true
+				return es
+			}(),
+			got: func(es *esv1beta1.ExternalSecret) *esv1beta1.ExternalSecretSourceStatus {
+				return dataFromSourceStatus(es, 1, find, nil, secretMap)
+			},
+			want: &esv1beta1.ExternalSecretSourceStatus{
+				Path:     "spec.dataFrom[1]",
+				StoreRef: &esv1beta1.SecretStoreRef{Name: "vault", Kind: esv1beta1.SecretStoreKind},
+				Summary:  "find path=db name=^prod- found 2 secrets",
+				Keys:     []string{"prod-a", "prod-b"},
+			},
+		},
+		{
+			name: "resolved find hides remote keys",
+			es:   newES(&esv1beta1.ExternalSecretStatusPolicy{ResolvedSources: true, RemoteKeys: esv1beta1.KeyVisibilityOmit}),
+			got: func(es *esv1beta1.ExternalSecret) *esv1beta1.ExternalSecretSourceStatus {
+				return dataFromSourceStatus(es, 1, find, nil, secretMap)
+			},
+			want: &esv1beta1.ExternalSecretSourceStatus{
+				Path:     "spec.dataFrom[1]",
+				StoreRef: &esv1beta1.SecretStoreRef{Name: "vault", Kind: esv1beta1.SecretStoreKind},
+				Summary:  "find path=<redacted> name=^prod- found 2 secrets",
+			},
+		},
+		{
+			name: "resolved generator has no store",
+			es:   newES(&esv1beta1.ExternalSecretStatusPolicy{ResolvedSources: true}),
+			got: func(es *esv1beta1.ExternalSecret) *esv1beta1.ExternalSecretSourceStatus {
+				return dataFromSourceStatus(es, 2, generator, nil, map[string][]byte{"password": nil})
+			},
+			want: &esv1beta1.ExternalSecretSourceStatus{
+				Path:    "spec.dataFrom[2]",
+				Summary: "generator Password/db-password",
+				Keys:    []string{"password"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if diff := cmp.Diff(tt.want, tt.got(tt.es)); diff != "" {
+				t.Errorf("source status mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestAffixSourceKeys(t *testing.T) {
+	sources := []esv1beta1.ExternalSecretSourceStatus{
+		{Path: "spec.dataFrom[0]", Keys: []string{"password", "user"}},
+		{Path: "spec.dataFrom[1]"},
+	}
+	affixSourceKeys(sources, "DB_", "_V1")
+	want := []esv1beta1.ExternalSecretSourceStatus{
+		{Path: "spec.dataFrom[0]", Keys: []string{"DB_password_V1", "DB_user_V1"}},
+		{Path: "spec.dataFrom[1]"},
+	}
+	if diff := cmp.Diff(want, sources); diff != "" {
+		t.Errorf("source keys mismatch (-want +got):\n%s", diff)
+	}
+}
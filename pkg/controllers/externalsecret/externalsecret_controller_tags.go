@@ -0,0 +1,203 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalsecret
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+	"unicode"
+
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+)
+
+const (
+	errGetProviderTags = "could not read the tags of %q for target.copyProviderTagsToLabels: %w"
+
+	eventProviderTagSkipped = "provider tags %s can not be written as labels and were skipped"
+)
+
+var (
+	errProviderTagsFormat      = errors.New("the provider did not return tags, it must support metadataPolicy Fetch")
+	errProviderTagsUnsupported = errors.New("the provider can not read tags, supported providers are aws, azurekv and gcpsm")
+)
+
+// invalidLabelChars matches the characters that are not allowed in label names and values.
+var invalidLabelChars = regexp.MustCompile(`[^-_.a-zA-Z0-9]+`)
+
+type providerTagsKey struct{}
+
+// providerTags collects the tags of the secrets read for an ExternalSecret with target.copyProviderTagsToLabels.
+type providerTags struct {
+	mu   sync.Mutex
+	tags map[string]string
+}
+
+// contextWithProviderTags returns a context that collects the tags read with collectProviderTags.
+func contextWithProviderTags(ctx context.Context) (context.Context, *providerTags) {
+	collector := &providerTags{tags: make(map[string]string)}
+	return context.WithValue(ctx, providerTagsKey{}, collector), collector
+}
+
+// Get returns a copy of the collected tags.
+func (t *providerTags) Get() map[string]string {
+	if t == nil {
+		return nil
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return maps.Clone(t.tags)
+}
+
+// supportsProviderTags reports whether the provider returns the tags of a secret with metadataPolicy Fetch.
+func supportsProviderTags(provider *esv1beta1.SecretStoreProvider) bool {
+	return provider != nil && (provider.AWS != nil || provider.AzureKV != nil || provider.GCPSM != nil)
+}
+
+// checkProviderTagStores verifies that every store referenced by the ExternalSecret can read tags.
+// Stores that do not exist are reported when the secret is read.
+func (r *Reconciler) checkProviderTagStores(ctx context.Context, es *esv1beta1.ExternalSecret) error {
+	for _, ref := range referencedStores(es) {
+		store, err := r.getReferencedStore(ctx, es.Namespace, ref)
+		if apierrors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if !supportsProviderTags(store.GetSpec().Provider) {
+			return fmt.Errorf("%s %q: %w", store.GetKind(), store.GetName(), errProviderTagsUnsupported)
+		}
+	}
+	return nil
+}
+
+// collectProviderTags reads the tags of the secret with the given key, if the context collects them.
+// If several secrets have the same tag, the last value wins.
+func collectProviderTags(ctx context.Context, client esv1beta1.SecretsClient, key string) error {
+	collector, ok := ctx.Value(providerTagsKey{}).(*providerTags)
+	if !ok {
+		return nil
+	}
+	raw, err := client.GetSecret(ctx, esv1beta1.ExternalSecretDataRemoteRef{
+		Key:            key,
+		MetadataPolicy: esv1beta1.ExternalSecretMetadataPolicyFetch,
+	})
+	if err != nil {
+		return fmt.Errorf(errGetProviderTags, key, err)
+	}
+	tags, err := parseProviderTags(raw)
+	if err != nil {
+		return fmt.Errorf(errGetProviderTags, key, err)
+	}
+	collector.mu.Lock()
+	defer collector.mu.Unlock()
+	maps.Copy(collector.tags, tags)
+	return nil
+}
+
+// parseProviderTags parses the metadata returned with metadataPolicy Fetch:
+// a JSON object of tags, e.g. by AWS and Azure, or of labels and annotations, e.g. by GCP, of which the labels are used.
+func parseProviderTags(raw []byte) (map[string]string, error) {
+	var tags map[string]string
+	if err := json.Unmarshal(raw, &tags); err == nil {
+		return tags, nil
+	}
+	var metadata map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &metadata); err != nil {
+		return nil, errProviderTagsFormat
+	}
+	labels, ok := metadata["labels"]
+	if !ok {
+		return nil, errProviderTagsFormat
+	}
+	var labelTags map[string]string
+	if err := json.Unmarshal(labels, &labelTags); err != nil {
+		return nil, errProviderTagsFormat
+	}
+	return labelTags, nil
+}
+
+// removeProviderTagLabels removes the labels written from provider tags by the previous sync,
+// so the labels of tags that were removed upstream do not stay behind.
+func removeProviderTagLabels(secret *v1.Secret) {
+	for _, key := range strings.Split(secret.Annotations[esv1beta1.AnnotationProviderTagLabels], ",") {
+		delete(secret.Labels, key)
+	}
+	delete(secret.Annotations, esv1beta1.AnnotationProviderTagLabels)
+}
+
+// labelProviderTags writes the provider tags as labels of the secret and lists them in the
+// reconcile.external-secrets.io/provider-tag-labels annotation. Existing labels are not overwritten,
+// tags that can not be sanitized to a valid label are skipped with a warning event.
+func (r *Reconciler) labelProviderTags(es *esv1beta1.ExternalSecret, secret *v1.Secret, tags map[string]string) {
+	if !es.Spec.Target.CopyProviderTagsToLabels {
+		return
+	}
+	labels, skipped := providerTagLabels(tags, secret.Labels)
+	if len(skipped) > 0 {
+		r.recorder.Eventf(es, v1.EventTypeWarning, esv1beta1.ReasonProviderTagSkipped, eventProviderTagSkipped, strings.Join(skipped, ","))
+	}
+	if len(labels) == 0 {
+		return
+	}
+	maps.Copy(secret.Labels, labels)
+	secret.Annotations[esv1beta1.AnnotationProviderTagLabels] = strings.Join(slices.Sorted(maps.Keys(labels)), ",")
+}
+
+// providerTagLabels returns the sanitized labels of the tags that are not in existing and the sorted keys of the skipped tags.
+// Tags are processed in order of their keys, so of several tags with the same sanitized key the first one is used.
+func providerTagLabels(tags, existing map[string]string) (map[string]string, []string) {
+	labels := make(map[string]string)
+	var skipped []string
+	for _, tag := range slices.Sorted(maps.Keys(tags)) {
+		key := sanitizeLabel(tag)
+		value := sanitizeLabel(tags[tag])
+		if _, ok := existing[key]; ok {
+			continue
+		}
+		if _, ok := labels[key]; ok ||
+			len(validation.IsQualifiedName(key)) > 0 ||
+			len(validation.IsValidLabelValue(value)) > 0 ||
+			(value == "" && tags[tag] != "") {
+			skipped = append(skipped, tag)
+			continue
+		}
+		labels[key] = value
+	}
+	return labels, skipped
+}
+
+// sanitizeLabel replaces the characters that are not allowed in label names and values with "-",
+// truncates it to the maximum length and trims it to start and end with an alphanumeric character.
+func sanitizeLabel(s string) string {
+	s = invalidLabelChars.ReplaceAllString(s, "-")
+	if len(s) > validation.LabelValueMaxLength {
+		s = s[:validation.LabelValueMaxLength]
+	}
+	return strings.TrimFunc(s, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
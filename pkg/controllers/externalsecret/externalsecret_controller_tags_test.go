@@ -0,0 +1,268 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalsecret
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	"github.com/external-secrets/external-secrets/pkg/provider/testing/fake"
+)
+
+func TestProviderTagLabels(t *testing.T) {
+	tests := []struct {
+		name        string
+		tags        map[string]string
+		existing    map[string]string
+		wantLabels  map[string]string
+		wantSkipped []string
+	}{
+		{
+			name:       "valid tags are copied",
+			tags:       map[string]string{"team": "payments", "env": ""},
+			wantLabels: map[string]string{"team": "payments", "env": ""},
+		},
+		{
+			name: "invalid characters are replaced",
+			tags: map[string]string{
+				"aws:cloudformation:stack-name": "payments stack",
+				"cost center":                   "cc/1234",
+				"_owner_":                       "@team",
+			},
+			wantLabels: map[string]string{
+				"aws-cloudformation-stack-name": "payments-stack",
+				"cost-center":                   "cc-1234",
+				"owner":                         "team",
+			},
+		},
+		{
+			name:       "long keys and values are truncated",
+			tags:       map[string]string{strings.Repeat("k", 70): strings.Repeat("v", 62) + "-v"},
+			wantLabels: map[string]string{strings.Repeat("k", 63): strings.Repeat("v", 62)},
+		},
+		{
+			name:        "tags without a valid key or value are skipped",
+			tags:        map[string]string{"::": "value", "empty": "@@@", "ok": "yes"},
+			wantLabels:  map[string]string{"ok": "yes"},
+			wantSkipped: []string{"::", "empty"},
+		},
+		{
+			name:        "the first of several tags with the same label is used",
+			tags:        map[string]string{"cost center": "a", "cost:center": "b"},
+			wantLabels:  map[string]string{"cost-center": "a"},
+			wantSkipped: []string{"cost:center"},
+		},
+		{
+			name:       "existing labels take precedence",
+			tags:       map[string]string{"team": "payments", "app": "api"},
+			existing:   map[string]string{"team": "platform"},
+			wantLabels: map[string]string{"app": "api"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			labels, skipped := providerTagLabels(tt.tags, tt.existing)
+			if diff := cmp.Diff(tt.wantLabels, labels); diff != "" {
+				t.Errorf("unexpected labels (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tt.wantSkipped, skipped); diff != "" {
+				t.Errorf("unexpected skipped tags (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestParseProviderTags(t *testing.T) {
+	tests := []struct {
+		name    string
+		raw     string
+		want    map[string]string
+		wantErr bool
+	}{
+		{
+			name: "tags",
+			raw:  `{"team":"payments"}`,
+			want: map[string]string{"team": "payments"},
+		},
+		{
+			name: "labels and annotations",
+			raw:  `{"annotations":{"note":"ignored"},"labels":{"team":"payments"}}`,
+			want: map[string]string{"team": "payments"},
+		},
+		{
+			name:    "secret value",
+			raw:     `{"password":{"nested":true}}`,
+			wantErr: true,
+		},
+		{
+			name:    "no JSON",
+			raw:     `s3cr3t`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseProviderTags([]byte(tt.raw))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseProviderTags() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("unexpected tags (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestLabelProviderTags(t *testing.T) {
+	tags := `{"team":"payments","cost center":"cc-1"}`
+	client := fake.New()
+	client.GetSecretFn = func(_ context.Context, ref esv1beta1.ExternalSecretDataRemoteRef) ([]byte, error) {
+		if ref.MetadataPolicy == esv1beta1.ExternalSecretMetadataPolicyFetch {
+			return []byte(tags), nil
+		}
+		return []byte("value"), nil
+	}
+	es := &esv1beta1.ExternalSecret{
+		ObjectMeta: metav1.ObjectMeta{Name: "es"},
+		Spec: esv1beta1.ExternalSecretSpec{
+			Target: esv1beta1.ExternalSecretTarget{CopyProviderTagsToLabels: true},
+		},
+	}
+	r := &Reconciler{recorder: record.NewFakeRecorder(10)}
+	secret := &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Labels:      map[string]string{"app": "api"},
+			Annotations: map[string]string{},
+		},
+	}
+	// sync runs the steps of the secret mutation that handle the tags
+	sync := func() {
+		ctx, collector := contextWithProviderTags(context.Background())
+		if err := collectProviderTags(ctx, client, "foo"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		removeProviderTagLabels(secret)
+		r.labelProviderTags(es, secret, collector.Get())
+	}
+
+	sync()
+	want := map[string]string{"app": "api", "team": "payments", "cost-center": "cc-1"}
+	if diff := cmp.Diff(want, secret.Labels); diff != "" {
+		t.Errorf("unexpected labels (-want +got):\n%s", diff)
+	}
+	if got := secret.Annotations[esv1beta1.AnnotationProviderTagLabels]; got != "cost-center,team" {
+		t.Errorf("unexpected %s annotation: %q", esv1beta1.AnnotationProviderTagLabels, got)
+	}
+
+	// the label of a tag removed upstream is deleted, other labels are kept
+	tags = `{"team":"checkout"}`
+	sync()
+	want = map[string]string{"app": "api", "team": "checkout"}
+	if diff := cmp.Diff(want, secret.Labels); diff != "" {
+		t.Errorf("unexpected labels after the tag was removed (-want +got):\n%s", diff)
+	}
+
+	// all tag labels are deleted once the option is disabled
+	es.Spec.Target.CopyProviderTagsToLabels = false
+	sync()
+	want = map[string]string{"app": "api"}
+	if diff := cmp.Diff(want, secret.Labels); diff != "" {
+		t.Errorf("unexpected labels after disabling the option (-want +got):\n%s", diff)
+	}
+	if _, ok := secret.Annotations[esv1beta1.AnnotationProviderTagLabels]; ok {
+		t.Errorf("expected the %s annotation to be removed", esv1beta1.AnnotationProviderTagLabels)
+	}
+
+	// tags are only read with a collector
+	if err := collectProviderTags(context.Background(), client, "foo"); err != nil {
+		t.Errorf("unexpected error without a collector: %v", err)
+	}
+}
+
+func TestCheckProviderTagStores(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := esv1beta1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	aws := &esv1beta1.SecretStore{
+		ObjectMeta: metav1.ObjectMeta{Name: "aws", Namespace: "default"},
+		Spec: esv1beta1.SecretStoreSpec{
+			Provider: &esv1beta1.SecretStoreProvider{AWS: &esv1beta1.AWSProvider{}},
+		},
+	}
+	fakeStore := &esv1beta1.ClusterSecretStore{
+		ObjectMeta: metav1.ObjectMeta{Name: "fake"},
+		Spec: esv1beta1.SecretStoreSpec{
+			Provider: &esv1beta1.SecretStoreProvider{Fake: &esv1beta1.FakeProvider{}},
+		},
+	}
+	r := &Reconciler{
+		Client: fakeclient.NewClientBuilder().WithScheme(scheme).WithObjects(aws, fakeStore).Build(),
+	}
+
+	tests := []struct {
+		name    string
+		spec    esv1beta1.ExternalSecretSpec
+		wantErr bool
+	}{
+		{
+			name: "supported store",
+			spec: esv1beta1.ExternalSecretSpec{
+				SecretStoreRef: esv1beta1.SecretStoreRef{Name: "aws"},
+			},
+		},
+		{
+			name: "missing store is skipped",
+			spec: esv1beta1.ExternalSecretSpec{
+				SecretStoreRef: esv1beta1.SecretStoreRef{Name: "missing"},
+			},
+		},
+		{
+			name: "unsupported store in a sourceRef",
+			spec: esv1beta1.ExternalSecretSpec{
+				SecretStoreRef: esv1beta1.SecretStoreRef{Name: "aws"},
+				Data: []esv1beta1.ExternalSecretData{{
+					SecretKey: "foo",
+					SourceRef: &esv1beta1.StoreSourceRef{
+						SecretStoreRef: &esv1beta1.SecretStoreRef{Name: "fake", Kind: esv1beta1.ClusterSecretStoreKind},
+					},
+				}},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			es := &esv1beta1.ExternalSecret{
+				ObjectMeta: metav1.ObjectMeta{Name: "es", Namespace: "default"},
+				Spec:       tt.spec,
+			}
+			err := r.checkProviderTagStores(context.Background(), es)
+			if got := errors.Is(err, errProviderTagsUnsupported); got != tt.wantErr {
+				t.Errorf("checkProviderTagStores() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
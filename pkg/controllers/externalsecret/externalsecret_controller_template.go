@@ -18,51 +18,84 @@ import (
 	"context"
 	"fmt"
 	"maps"
+	"strings"
 
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/yaml"
 
 	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	"github.com/external-secrets/external-secrets/pkg/controllers/externalsecret/esmetrics"
 	"github.com/external-secrets/external-secrets/pkg/controllers/templating"
 	"github.com/external-secrets/external-secrets/pkg/template"
+	v2 "github.com/external-secrets/external-secrets/pkg/template/v2"
 	"github.com/external-secrets/external-secrets/pkg/utils"
 
 	_ "github.com/external-secrets/external-secrets/pkg/provider/register" // Loading registered providers.
 )
 
+const defaultTemplateRefKey = "template"
+
 // merge template in the following order:
 // * template.Data (highest precedence)
 // * template.TemplateFrom
 // * secret via es.data or es.dataFrom (if template.MergePolicy is Merge, or there is no template)
 // * existing secret keys (if CreationPolicy is Merge).
-func (r *Reconciler) applyTemplate(ctx context.Context, es *esv1beta1.ExternalSecret, secret *v1.Secret, dataMap map[string][]byte) error {
+// previous is the data of the secret before the update, it is available as `.Previous` in v2 templates.
+// the base template of target.templateRef is merged into the template beforehand, see mergeTemplates.
+func (r *Reconciler) applyTemplate(ctx context.Context, es *esv1beta1.ExternalSecret, secret *v1.Secret, dataMap, previous map[string][]byte) error {
+	// merge the inline template on top of the base template from target.templateRef
+	tpl, err := r.getTemplate(ctx, es)
+	if err != nil {
+		return err
+	}
+
+	// a template gated by enabledKey is skipped until the fetched flag is true,
+	// the data is then written as if there was no template
+	if !templating.Enabled(tpl, dataMap) {
+		tpl = nil
+	}
+
 	// update metadata (labels, annotations) of the secret
-	if err := setMetadata(secret, es); err != nil {
+	if err := setMetadata(secret, es, tpl); err != nil {
 		return err
 	}
 
 	// we only keep existing keys if creation policy is Merge, otherwise we clear the secret
+	// NOTE: with StrictMerge the data is cleared as well, so it only contains the keys to apply
 	if es.Spec.Target.CreationPolicy != esv1beta1.CreatePolicyMerge {
 		secret.Data = make(map[string][]byte)
 	}
 
 	// no template: copy data and return
-	if es.Spec.Target.Template == nil {
+	if tpl == nil {
 		maps.Insert(secret.Data, maps.All(dataMap))
 		return nil
 	}
 
 	// set the secret type if it is defined in the template, otherwise keep the existing type
-	if es.Spec.Target.Template.Type != "" {
-		secret.Type = es.Spec.Target.Template.Type
+	if tpl.Type != "" {
+		secret.Type = tpl.Type
 	}
 
 	// when TemplateMergePolicy is Merge, or there is no data template, we include the keys from `dataMap`
-	noTemplate := len(es.Spec.Target.Template.Data) == 0 && len(es.Spec.Target.Template.TemplateFrom) == 0
-	if es.Spec.Target.Template.MergePolicy == esv1beta1.MergePolicyMerge || noTemplate {
+	noTemplate := len(tpl.Data) == 0 && len(tpl.TemplateFrom) == 0
+	if tpl.MergePolicy == esv1beta1.MergePolicyMerge || noTemplate {
 		maps.Insert(secret.Data, maps.All(dataMap))
 	}
 
-	execute, err := template.EngineForVersion(es.Spec.Target.Template.EngineVersion)
+	// the metadata of the namespace is available as `.Namespace` in v2 templates,
+	// its hash is kept on the secret so a change of the namespace renders the templates again
+	tplCtx := v2.Context{Previous: previous}
+	if r.usesNamespaceMetadata(es) {
+		tplCtx.Namespace, err = r.namespaceMetadata(ctx, es.Namespace)
+		if err != nil {
+			return err
+		}
+		secret.Annotations[esv1beta1.AnnotationNamespaceHash] = utils.ObjectHash(tplCtx.Namespace)
+	}
+
+	execute, err := template.EngineWithContext(tpl.EngineVersion, tplCtx)
 	if err != nil {
 		return err
 	}
@@ -75,28 +108,28 @@ func (r *Reconciler) applyTemplate(ctx context.Context, es *esv1beta1.ExternalSe
 	}
 
 	// apply templates defined in template.templateFrom
-	err = p.MergeTemplateFrom(ctx, es.Namespace, es.Spec.Target.Template)
+	err = p.MergeTemplateFrom(ctx, es.Namespace, tpl)
 	if err != nil {
 		return fmt.Errorf(errFetchTplFrom, err)
 	}
 
 	// apply data templates
 	// NOTE: explicitly defined template.data templates take precedence over templateFrom
-	err = p.MergeMap(es.Spec.Target.Template.Data, esv1beta1.TemplateTargetData)
+	err = p.MergeMap(tpl.Data, esv1beta1.TemplateTargetData)
 	if err != nil {
 		return fmt.Errorf(errExecTpl, err)
 	}
 
 	// apply templates for labels
 	// NOTE: this only works for v2 templates
-	err = p.MergeMap(es.Spec.Target.Template.Metadata.Labels, esv1beta1.TemplateTargetLabels)
+	err = p.MergeMap(tpl.Metadata.Labels, esv1beta1.TemplateTargetLabels)
 	if err != nil {
 		return fmt.Errorf(errExecTpl, err)
 	}
 
 	// apply template for annotations
 	// NOTE: this only works for v2 templates
-	err = p.MergeMap(es.Spec.Target.Template.Metadata.Annotations, esv1beta1.TemplateTargetAnnotations)
+	err = p.MergeMap(tpl.Metadata.Annotations, esv1beta1.TemplateTargetAnnotations)
 	if err != nil {
 		return fmt.Errorf(errExecTpl, err)
 	}
@@ -104,8 +137,51 @@ func (r *Reconciler) applyTemplate(ctx context.Context, es *esv1beta1.ExternalSe
 	return nil
 }
 
+// reportTemplateEngine sets the TemplateDeprecated condition while the template uses the v1 engine,
+// with the functions that behave differently with v2. A warning event is emitted when the condition is added.
+// The condition is removed once the template is migrated or removed.
+func (r *Reconciler) reportTemplateEngine(ctx context.Context, es *esv1beta1.ExternalSecret) {
+	// errors of the templateRef are reported when the template is applied
+	tpl, err := r.getTemplate(ctx, es)
+	if err != nil {
+		return
+	}
+	current := GetExternalSecretCondition(es.Status, esv1beta1.ExternalSecretTemplateDeprecated)
+	// an empty version is executed with the v1 engine, see template.EngineForVersion
+	if tpl == nil || (tpl.EngineVersion != esv1beta1.TemplateEngineV1 && tpl.EngineVersion != "") {
+		if current != nil {
+			es.Status.Conditions = filterOutCondition(es.Status.Conditions, esv1beta1.ExternalSecretTemplateDeprecated)
+			esmetrics.UpdateExternalSecretCondition(es, current, 0.0)
+		}
+		return
+	}
+
+	templates := maps.Clone(tpl.Data)
+	for i, from := range tpl.TemplateFrom {
+		if from.Literal != nil {
+			if templates == nil {
+				templates = make(map[string]string)
+			}
+			templates[fmt.Sprintf("templateFrom[%d].literal", i)] = *from.Literal
+		}
+	}
+	msg := msgTemplateEngineV1
+	if found := template.LintV1(templates); len(found) > 0 {
+		incompatible := make([]string, 0, len(found))
+		for _, f := range found {
+			incompatible = append(incompatible, f.String())
+		}
+		msg = fmt.Sprintf(msgTemplateIncompatible, msg, strings.Join(incompatible, "; "))
+	}
+	cond := NewExternalSecretCondition(esv1beta1.ExternalSecretTemplateDeprecated, v1.ConditionTrue, esv1beta1.ConditionReasonTemplateEngineV1, msg)
+	SetExternalSecretCondition(es, *cond)
+	if current == nil || current.Message != msg {
+		r.recorder.Event(es, v1.EventTypeWarning, esv1beta1.ReasonDeprecated, msg)
+	}
+}
+
 // setMetadata sets Labels and Annotations to the given secret.
-func setMetadata(secret *v1.Secret, es *esv1beta1.ExternalSecret) error {
+func setMetadata(secret *v1.Secret, es *esv1beta1.ExternalSecret, tpl *esv1beta1.ExternalSecretTemplate) error {
 	// ensure that Labels and Annotations are not nil
 	// so it is safe to merge them
 	if secret.Labels == nil {
@@ -133,14 +209,89 @@ func setMetadata(secret *v1.Secret, es *esv1beta1.ExternalSecret) error {
 	}
 
 	// if no template is defined, copy labels and annotations from the ExternalSecret
-	if es.Spec.Target.Template == nil {
+	if tpl == nil {
 		utils.MergeStringMap(secret.ObjectMeta.Labels, es.ObjectMeta.Labels)
 		utils.MergeStringMap(secret.ObjectMeta.Annotations, es.ObjectMeta.Annotations)
 		return nil
 	}
 
 	// copy labels and annotations from the template
-	utils.MergeStringMap(secret.ObjectMeta.Labels, es.Spec.Target.Template.Metadata.Labels)
-	utils.MergeStringMap(secret.ObjectMeta.Annotations, es.Spec.Target.Template.Metadata.Annotations)
+	utils.MergeStringMap(secret.ObjectMeta.Labels, tpl.Metadata.Labels)
+	utils.MergeStringMap(secret.ObjectMeta.Annotations, tpl.Metadata.Annotations)
 	return nil
 }
+
+// getTemplate returns the template of the ExternalSecret,
+// merged on top of the base template referenced by target.templateRef.
+func (r *Reconciler) getTemplate(ctx context.Context, es *esv1beta1.ExternalSecret) (*esv1beta1.ExternalSecretTemplate, error) {
+	ref := es.Spec.Target.TemplateRef
+	if ref == nil {
+		return es.Spec.Target.Template, nil
+	}
+
+	key := ref.Key
+	if key == "" {
+		key = defaultTemplateRefKey
+	}
+	cm := &v1.ConfigMap{}
+	err := r.Get(ctx, types.NamespacedName{Namespace: es.Namespace, Name: ref.Name}, cm)
+	if err != nil {
+		return nil, fmt.Errorf(errFetchTplRef, ref.Name, err)
+	}
+	raw, ok := cm.Data[key]
+	if !ok {
+		return nil, fmt.Errorf(errTplRefKeyMissing, key, ref.Name)
+	}
+	base := &esv1beta1.ExternalSecretTemplate{}
+	if err := yaml.UnmarshalStrict([]byte(raw), base); err != nil {
+		return nil, fmt.Errorf(errParseTplRef, key, ref.Name, err)
+	}
+
+	return mergeTemplates(base, es.Spec.Target.Template), nil
+}
+
+// mergeTemplates merges the inline template on top of the base template:
+//   - type, engineVersion, mergePolicy and enabledKey of the inline template win, if they are set
+//   - metadata and data are merged key by key, inline keys win
+//   - templateFrom of the base is applied first, so the inline templateFrom takes precedence.
+func mergeTemplates(base, inline *esv1beta1.ExternalSecretTemplate) *esv1beta1.ExternalSecretTemplate {
+	merged := base.DeepCopy()
+	if merged.EngineVersion == "" {
+		merged.EngineVersion = esv1beta1.TemplateEngineV2
+	}
+	if merged.MergePolicy == "" {
+		merged.MergePolicy = esv1beta1.MergePolicyReplace
+	}
+	if inline == nil {
+		return merged
+	}
+
+	if inline.Type != "" {
+		merged.Type = inline.Type
+	}
+	if inline.EngineVersion != "" {
+		merged.EngineVersion = inline.EngineVersion
+	}
+	if inline.MergePolicy != "" {
+		merged.MergePolicy = inline.MergePolicy
+	}
+	if inline.EnabledKey != "" {
+		merged.EnabledKey = inline.EnabledKey
+	}
+	merged.Metadata.Labels = mergeStringMaps(merged.Metadata.Labels, inline.Metadata.Labels)
+	merged.Metadata.Annotations = mergeStringMaps(merged.Metadata.Annotations, inline.Metadata.Annotations)
+	merged.Data = mergeStringMaps(merged.Data, inline.Data)
+	merged.TemplateFrom = append(merged.TemplateFrom, inline.DeepCopy().TemplateFrom...)
+	return merged
+}
+
+func mergeStringMaps(base, override map[string]string) map[string]string {
+	if len(override) == 0 {
+		return base
+	}
+	if base == nil {
+		base = make(map[string]string, len(override))
+	}
+	maps.Copy(base, override)
+	return base
+}
@@ -0,0 +1,217 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalsecret
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+)
+
+func TestMergeTemplates(t *testing.T) {
+	base := &esv1beta1.ExternalSecretTemplate{
+		Type: v1.SecretTypeDockerConfigJson,
+		Metadata: esv1beta1.ExternalSecretTemplateMetadata{
+			Labels:      map[string]string{"team": "payments", "tier": "backend"},
+			Annotations: map[string]string{"owner": "platform"},
+		},
+		Data: map[string]string{
+			".dockerconfigjson": "{{ .config }}",
+			"registry":          "base.example.com",
+		},
+		TemplateFrom: []esv1beta1.TemplateFrom{
+			{ConfigMap: &esv1beta1.TemplateRef{Name: "base-tpl"}},
+		},
+	}
+
+	tests := []struct {
+		name   string
+		inline *esv1beta1.ExternalSecretTemplate
+		want   *esv1beta1.ExternalSecretTemplate
+	}{
+		{
+			name:   "base only is defaulted",
+			inline: nil,
+			want: &esv1beta1.ExternalSecretTemplate{
+				Type:          v1.SecretTypeDockerConfigJson,
+				EngineVersion: esv1beta1.TemplateEngineV2,
+				MergePolicy:   esv1beta1.MergePolicyReplace,
+				Metadata:      base.Metadata,
+				Data:          base.Data,
+				TemplateFrom:  base.TemplateFrom,
+			},
+		},
+		{
+			name: "inline fields take precedence",
+			inline: &esv1beta1.ExternalSecretTemplate{
+				Type:          v1.SecretTypeOpaque,
+				EngineVersion: esv1beta1.TemplateEngineV1,
+				MergePolicy:   esv1beta1.MergePolicyMerge,
+				EnabledKey:    "new-template",
+				Metadata: esv1beta1.ExternalSecretTemplateMetadata{
+					Labels: map[string]string{"tier": "frontend"},
+				},
+				Data: map[string]string{"registry": "inline.example.com"},
+				TemplateFrom: []esv1beta1.TemplateFrom{
+					{Secret: &esv1beta1.TemplateRef{Name: "inline-tpl"}},
+				},
+			},
+			want: &esv1beta1.ExternalSecretTemplate{
+				Type:          v1.SecretTypeOpaque,
+				EngineVersion: esv1beta1.TemplateEngineV1,
+				MergePolicy:   esv1beta1.MergePolicyMerge,
+				EnabledKey:    "new-template",
+				Metadata: esv1beta1.ExternalSecretTemplateMetadata{
+					Labels:      map[string]string{"team": "payments", "tier": "frontend"},
+					Annotations: map[string]string{"owner": "platform"},
+				},
+				Data: map[string]string{
+					".dockerconfigjson": "{{ .config }}",
+					"registry":          "inline.example.com",
+				},
+				TemplateFrom: []esv1beta1.TemplateFrom{
+					{ConfigMap: &esv1beta1.TemplateRef{Name: "base-tpl"}},
+					{Secret: &esv1beta1.TemplateRef{Name: "inline-tpl"}},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := mergeTemplates(base, tt.inline)
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("mergeTemplates() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+
+	// the base template must not be modified, it can be shared
+	if len(base.TemplateFrom) != 1 || base.Metadata.Labels["tier"] != "backend" {
+		t.Errorf("mergeTemplates() modified the base template: %+v", base)
+	}
+}
+
+func TestApplyTemplateEnabledKey(t *testing.T) {
+	es := &esv1beta1.ExternalSecret{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-es", Namespace: "default"},
+		Spec: esv1beta1.ExternalSecretSpec{
+			Target: esv1beta1.ExternalSecretTarget{
+				Template: &esv1beta1.ExternalSecretTemplate{
+					EngineVersion: esv1beta1.TemplateEngineV2,
+					EnabledKey:    "new-template",
+					Data:          map[string]string{"dsn": "postgres://{{ .user }}@db"},
+				},
+			},
+		},
+	}
+	tests := []struct {
+		name string
+		flag []byte
+		want map[string]string
+	}{
+		{
+			name: "flag is true",
+			flag: []byte("true"),
+			want: map[string]string{"dsn": "postgres://admin@db"},
+		},
+		{
+			name: "flag is false",
+			flag: []byte("false"),
+			want: map[string]string{"user": "admin", "new-template": "false"},
+		},
+		{
+			name: "flag is not a boolean",
+			flag: []byte("yes please"),
+			want: map[string]string{"user": "admin", "new-template": "yes please"},
+		},
+		{
+			name: "flag is missing",
+			want: map[string]string{"user": "admin"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dataMap := map[string][]byte{"user": []byte("admin")}
+			if tt.flag != nil {
+				dataMap["new-template"] = tt.flag
+			}
+			secret := &v1.Secret{}
+			r := &Reconciler{}
+			if err := r.applyTemplate(context.Background(), es, secret, dataMap, nil); err != nil {
+				t.Fatalf("applyTemplate() returned an error: %v", err)
+			}
+			if diff := cmp.Diff(tt.want, stringMap(secret.Data)); diff != "" {
+				t.Errorf("unexpected secret data (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestReportTemplateEngine(t *testing.T) {
+	literal := `{{ .cert | pkcs12cert | pemCertificate }}`
+	es := &esv1beta1.ExternalSecret{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-es", Namespace: "default"},
+		Spec: esv1beta1.ExternalSecretSpec{
+			Target: esv1beta1.ExternalSecretTarget{
+				Template: &esv1beta1.ExternalSecretTemplate{
+					EngineVersion: esv1beta1.TemplateEngineV1,
+					Data:          map[string]string{"token": "{{ .token | base64decode | toString }}"},
+					TemplateFrom:  []esv1beta1.TemplateFrom{{Literal: &literal}},
+				},
+			},
+		},
+	}
+	recorder := record.NewFakeRecorder(10)
+	r := &Reconciler{recorder: recorder}
+
+	r.reportTemplateEngine(context.Background(), es)
+	cond := GetExternalSecretCondition(es.Status, esv1beta1.ExternalSecretTemplateDeprecated)
+	if cond == nil || cond.Status != v1.ConditionTrue || cond.Reason != esv1beta1.ConditionReasonTemplateEngineV1 {
+		t.Fatalf("unexpected TemplateDeprecated condition: %+v", cond)
+	}
+	for _, want := range []string{
+		"token: base64decode",
+		"templateFrom[0].literal: pemCertificate",
+		"templateFrom[0].literal: pkcs12cert",
+	} {
+		if !strings.Contains(cond.Message, want) {
+			t.Errorf("expected %q in the condition message %q", want, cond.Message)
+		}
+	}
+	if len(recorder.Events) != 1 {
+		t.Fatalf("expected one warning event, got %d", len(recorder.Events))
+	}
+	<-recorder.Events
+
+	// the event is only emitted when the condition changes
+	r.reportTemplateEngine(context.Background(), es)
+	if len(recorder.Events) != 0 {
+		t.Errorf("expected no event for an unchanged condition, got %d", len(recorder.Events))
+	}
+
+	// the condition is removed once the template is migrated
+	es.Spec.Target.Template.EngineVersion = esv1beta1.TemplateEngineV2
+	r.reportTemplateEngine(context.Background(), es)
+	if cond := GetExternalSecretCondition(es.Status, esv1beta1.ExternalSecretTemplateDeprecated); cond != nil {
+		t.Errorf("expected the condition to be removed, got %+v", cond)
+	}
+}
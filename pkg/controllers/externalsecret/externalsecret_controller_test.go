@@ -22,6 +22,7 @@ import (
 	"fmt"
 	"os"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/google/go-cmp/cmp"
@@ -34,7 +35,10 @@ import (
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
 	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
 	genv1alpha1 "github.com/external-secrets/external-secrets/apis/generators/v1alpha1"
@@ -68,6 +72,7 @@ var (
 var (
 	testSyncCallsTotal *prometheus.CounterVec
 	testSyncCallsError *prometheus.CounterVec
+	testGeneratorCalls *prometheus.CounterVec
 
 	testExternalSecretCondition         *prometheus.GaugeVec
 	testExternalSecretReconcileDuration *prometheus.GaugeVec
@@ -156,7 +161,7 @@ var _ = Describe("Kind=secret existence logic", func() {
 
 	for _, tt := range tests {
 		It(tt.Name, func() {
-			Expect(isSecretValid(tt.Input)).To(BeEquivalentTo(tt.ExpectedOutput))
+			Expect(isSecretValid(tt.Input, &esv1beta1.ExternalSecret{})).To(BeEquivalentTo(tt.ExpectedOutput))
 		})
 	}
 })
@@ -192,6 +197,7 @@ var _ = Describe("ExternalSecret controller", Serial, func() {
 		metric.Reset()
 		testSyncCallsTotal.Reset()
 		testSyncCallsError.Reset()
+		testGeneratorCalls.Reset()
 		testExternalSecretCondition.Reset()
 		testExternalSecretReconcileDuration.Reset()
 		fakeProvider.Reset()
@@ -325,6 +331,45 @@ var _ = Describe("ExternalSecret controller", Serial, func() {
 		}
 	}
 
+	// with creationPolicy=None the provider data is still fetched
+	// and reported in the condition, but no secret is created
+	creationPolicyNoneReportsStatus := func(tc *testCase) {
+		tc.externalSecret.Spec.Target.CreationPolicy = esv1beta1.CreatePolicyNone
+		fakeProvider.WithGetSecret([]byte(secretVal), nil)
+		tc.checkCondition = func(es *esv1beta1.ExternalSecret) bool {
+			cond := GetExternalSecretCondition(es.Status, esv1beta1.ExternalSecretReady)
+			if cond == nil || cond.Status != v1.ConditionTrue || cond.Message != msgSyncedNone {
+				return false
+			}
+			return true
+		}
+		tc.checkExternalSecret = func(es *esv1beta1.ExternalSecret) {
+			Expect(es.Status.RefreshTime.IsZero()).To(BeFalse())
+			Expect(es.Status.Binding.Name).To(BeEmpty())
+			secretLookupKey := types.NamespacedName{
+				Name:      ExternalSecretTargetSecretName,
+				Namespace: ExternalSecretNamespace,
+			}
+			Consistently(func() bool {
+				err := k8sClient.Get(context.Background(), secretLookupKey, &v1.Secret{})
+				return apierrors.IsNotFound(err)
+			}, time.Second, interval).Should(BeTrue())
+		}
+	}
+
+	// with creationPolicy=None provider errors are reported in the condition
+	creationPolicyNoneProviderErr := func(tc *testCase) {
+		tc.externalSecret.Spec.Target.CreationPolicy = esv1beta1.CreatePolicyNone
+		fakeProvider.WithGetSecret(nil, errors.New("boom"))
+		tc.checkCondition = func(es *esv1beta1.ExternalSecret) bool {
+			cond := GetExternalSecretCondition(es.Status, esv1beta1.ExternalSecretReady)
+			if cond == nil || cond.Status != v1.ConditionFalse || cond.Reason != esv1beta1.ConditionReasonSecretSyncedError {
+				return false
+			}
+			return true
+		}
+	}
+
 	// labels and annotations from the Kind=ExternalSecret
 	// should be copied over to the Kind=Secret
 	syncLabelsAnnotations := func(tc *testCase) {
@@ -482,6 +527,35 @@ var _ = Describe("ExternalSecret controller", Serial, func() {
 		}
 	}
 
+	// a secret managed by another controller identity must not be touched
+	mergeWithSecretOfOtherIdentity := func(tc *testCase) {
+		tc.externalSecret.Spec.Target.CreationPolicy = esv1beta1.CreatePolicyMerge
+
+		Expect(k8sClient.Create(context.Background(), &v1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      ExternalSecretTargetSecretName,
+				Namespace: ExternalSecretNamespace,
+				Labels: map[string]string{
+					esv1beta1.LabelManaged:            esv1beta1.LabelManagedValue,
+					esv1beta1.LabelControllerIdentity: "other",
+				},
+			},
+			Data: map[string][]byte{
+				existingKey: []byte(existingVal),
+			},
+		}, client.FieldOwner(FakeManager))).To(Succeed())
+
+		fakeProvider.WithGetSecret([]byte("someValue"), nil)
+		// the ExternalSecret is left to the other controller, its status is not written
+		tc.checkCondition = func(es *esv1beta1.ExternalSecret) bool {
+			return GetExternalSecretCondition(es.Status, esv1beta1.ExternalSecretReady) == nil
+		}
+		tc.checkSecret = func(es *esv1beta1.ExternalSecret, secret *v1.Secret) {
+			Expect(secret.Data).To(Equal(map[string][]byte{existingKey: []byte(existingVal)}))
+			Expect(secret.Labels).To(HaveKeyWithValue(esv1beta1.LabelControllerIdentity, "other"))
+		}
+	}
+
 	mergeWithSecretUpdate := func(tc *testCase) {
 		const secretVal = "someValue"
 		tc.externalSecret.Spec.Target.CreationPolicy = esv1beta1.CreatePolicyMerge
@@ -623,6 +697,80 @@ var _ = Describe("ExternalSecret controller", Serial, func() {
 		}
 	}
 
+	// with creationPolicy=StrictMerge a key owned by another manager is never taken over
+	strictMergeWithConflict := func(tc *testCase) {
+		const secretVal = "someValue"
+		// this should conflict
+		const existingKey = targetProp
+		tc.externalSecret.Spec.Target.CreationPolicy = esv1beta1.CreatePolicyStrictMerge
+
+		// create secret beforehand
+		Expect(k8sClient.Create(context.Background(), &v1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      ExternalSecretTargetSecretName,
+				Namespace: ExternalSecretNamespace,
+			},
+			Data: map[string][]byte{
+				existingKey: []byte(existingVal),
+			},
+		}, client.FieldOwner(FakeManager))).To(Succeed())
+		fakeProvider.WithGetSecret([]byte(secretVal), nil)
+
+		tc.checkCondition = func(es *esv1beta1.ExternalSecret) bool {
+			cond := GetExternalSecretCondition(es.Status, esv1beta1.ExternalSecretReady)
+			return cond != nil && cond.Status == v1.ConditionFalse && cond.Reason == esv1beta1.ConditionReasonSecretSyncedError && cond.Message == msgErrorFieldConflict
+		}
+		tc.checkSecret = func(es *esv1beta1.ExternalSecret, secret *v1.Secret) {
+			// check that the value of the other manager is kept
+			Expect(string(secret.Data[existingKey])).To(Equal(existingVal))
+			Expect(ctest.FirstManagedFieldForManager(secret.ObjectMeta, ExternalSecretFQDN)).ToNot(ContainSubstring(existingKey))
+		}
+	}
+
+	// with creationPolicy=StrictMerge only the keys of the ExternalSecret are applied,
+	// the type and the other keys of the secret are left to their manager
+	strictMergeWithSecret := func(tc *testCase) {
+		const secretVal = "someValue"
+		const existingKey = "pre-existing-key"
+		tc.externalSecret.Spec.Target.CreationPolicy = esv1beta1.CreatePolicyStrictMerge
+
+		// create secret beforehand
+		Expect(k8sClient.Create(context.Background(), &v1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      ExternalSecretTargetSecretName,
+				Namespace: ExternalSecretNamespace,
+			},
+			Type: v1.SecretTypeBasicAuth,
+			Data: map[string][]byte{
+				existingKey:             []byte(existingVal),
+				v1.BasicAuthUsernameKey: []byte("user"),
+			},
+		}, client.FieldOwner(FakeManager))).To(Succeed())
+		fakeProvider.WithGetSecret([]byte(secretVal), nil)
+
+		tc.checkSecret = func(es *esv1beta1.ExternalSecret, secret *v1.Secret) {
+			Expect(string(secret.Data[targetProp])).To(Equal(secretVal))
+			Expect(string(secret.Data[existingKey])).To(Equal(existingVal))
+			Expect(string(secret.Data[v1.BasicAuthUsernameKey])).To(Equal("user"))
+			Expect(secret.Type).To(Equal(v1.SecretTypeBasicAuth))
+			Expect(ctest.HasOwnerRef(secret.ObjectMeta, "ExternalSecret", ExternalSecretFQDN)).To(BeFalse())
+
+			// the apply only claims our key
+			managedKeys, err := getManagedDataKeys(secret, ExternalSecretName)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(managedKeys).To(ConsistOf(targetProp))
+
+			// the other manager can still update its keys without interference
+			secret.Data[existingKey] = []byte("updated")
+			Expect(k8sClient.Update(context.Background(), secret, client.FieldOwner(FakeManager))).To(Succeed())
+			Consistently(func() bool {
+				updated := &v1.Secret{}
+				err := k8sClient.Get(context.Background(), client.ObjectKeyFromObject(secret), updated)
+				return err == nil && string(updated.Data[existingKey]) == "updated" && string(updated.Data[targetProp]) == secretVal
+			}, time.Second, interval).Should(BeTrue())
+		}
+	}
+
 	syncWithGeneratorRef := func(tc *testCase) {
 		const secretKey = "somekey"
 		const secretVal = "someValue"
@@ -657,6 +805,88 @@ var _ = Describe("ExternalSecret controller", Serial, func() {
 		tc.checkSecret = func(es *esv1beta1.ExternalSecret, secret *v1.Secret) {
 			// check values
 			Expect(string(secret.Data[secretKey])).To(Equal(secretVal))
+			Expect(testGeneratorCalls.WithLabelValues(ExternalSecretName, ExternalSecretNamespace, "Fake", esmetrics.GeneratorOutcomeSuccess).Write(&metric)).To(Succeed())
+			Expect(metric.GetCounter().GetValue()).To(BeNumerically(">=", 1.0))
+		}
+	}
+	// a generator with a Ready condition that is not True must not be used,
+	// the secret is synced once the generator becomes ready
+	// the secret is not synced while an ExternalSecret of spec.dependsOn does not exist
+	syncWithDependencyNotReady := func(tc *testCase) {
+		tc.externalSecret.Spec.DependsOn = []esv1beta1.ExternalSecretDependency{{Name: "ca"}}
+		tc.checkSecret = nil
+		tc.checkCondition = func(es *esv1beta1.ExternalSecret) bool {
+			cond := GetExternalSecretCondition(es.Status, esv1beta1.ExternalSecretReady)
+			return cond != nil && cond.Status == v1.ConditionFalse && cond.Reason == esv1beta1.ConditionReasonDependencyNotReady
+		}
+		tc.checkExternalSecret = func(es *esv1beta1.ExternalSecret) {
+			secret := &v1.Secret{}
+			err := k8sClient.Get(context.Background(), types.NamespacedName{Name: ExternalSecretTargetSecretName, Namespace: ExternalSecretNamespace}, secret)
+			Expect(apierrors.IsNotFound(err)).To(BeTrue())
+		}
+	}
+	syncWithGeneratorNotReady := func(tc *testCase) {
+		const secretKey = "somekey"
+		const secretVal = "someValue"
+
+		fakeGenerator := &genv1alpha1.Fake{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "mytestfake",
+				Namespace: ExternalSecretNamespace,
+			},
+			Spec: genv1alpha1.FakeSpec{
+				Data: map[string]string{
+					secretKey: secretVal,
+				},
+			},
+		}
+		Expect(k8sClient.Create(context.Background(), fakeGenerator)).To(Succeed())
+		fakeGenerator.Status.Conditions = []genv1alpha1.GeneratorStatusCondition{
+			{
+				Type:               genv1alpha1.GeneratorReady,
+				Status:             v1.ConditionFalse,
+				Reason:             "Provisioning",
+				Message:            "waiting for the backend",
+				LastTransitionTime: metav1.Now(),
+			},
+		}
+		Expect(k8sClient.Status().Update(context.Background(), fakeGenerator)).To(Succeed())
+
+		tc.externalSecret.Spec.SecretStoreRef = esv1beta1.SecretStoreRef{}
+		tc.externalSecret.Spec.Data = nil
+		tc.externalSecret.Spec.DataFrom = []esv1beta1.ExternalSecretDataFromRemoteRef{
+			{
+				SourceRef: &esv1beta1.StoreGeneratorSourceRef{
+					GeneratorRef: &esv1beta1.GeneratorRef{
+						APIVersion: genv1alpha1.Group + "/" + genv1alpha1.Version,
+						Kind:       "Fake",
+						Name:       "mytestfake",
+					},
+				},
+			},
+		}
+
+		tc.checkCondition = func(es *esv1beta1.ExternalSecret) bool {
+			cond := GetExternalSecretCondition(es.Status, esv1beta1.ExternalSecretReady)
+			return cond != nil && cond.Status == v1.ConditionFalse && cond.Reason == esv1beta1.ConditionReasonGeneratorNotReady
+		}
+		tc.checkExternalSecret = func(es *esv1beta1.ExternalSecret) {
+			// the target secret is not created while the generator is not ready
+			secret := &v1.Secret{}
+			err := k8sClient.Get(context.Background(), types.NamespacedName{Name: ExternalSecretTargetSecretName, Namespace: ExternalSecretNamespace}, secret)
+			Expect(apierrors.IsNotFound(err)).To(BeTrue())
+
+			// mark the generator as ready
+			Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(fakeGenerator), fakeGenerator)).To(Succeed())
+			fakeGenerator.Status.Conditions[0].Status = v1.ConditionTrue
+			fakeGenerator.Status.Conditions[0].Reason = "Provisioned"
+			fakeGenerator.Status.Conditions[0].Message = ""
+			Expect(k8sClient.Status().Update(context.Background(), fakeGenerator)).To(Succeed())
+
+			Eventually(func() bool {
+				err := k8sClient.Get(context.Background(), types.NamespacedName{Name: ExternalSecretTargetSecretName, Namespace: ExternalSecretNamespace}, secret)
+				return err == nil && string(secret.Data[secretKey]) == secretVal
+			}, timeout, interval).Should(BeTrue())
 		}
 	}
 	syncWithClusterGeneratorRef := func(tc *testCase) {
@@ -729,6 +959,29 @@ var _ = Describe("ExternalSecret controller", Serial, func() {
 		}
 	}
 
+	// with disableOwnerReference the secret has no ownerRef
+	// and is deleted through the finalizer of the ExternalSecret
+	disableOwnerReference := func(tc *testCase) {
+		tc.externalSecret.Spec.Target.DisableOwnerReference = true
+		fakeProvider.WithGetSecret([]byte(secretVal), nil)
+		tc.checkSecret = func(es *esv1beta1.ExternalSecret, secret *v1.Secret) {
+			Expect(ctest.HasOwnerRef(secret.ObjectMeta, "ExternalSecret", ExternalSecretName)).To(BeFalse())
+			Expect(secret.Labels).To(HaveKey(esv1beta1.LabelOwner))
+			Expect(secret.Labels).To(HaveKeyWithValue(esv1beta1.LabelManaged, esv1beta1.LabelManagedValue))
+			Expect(controllerutil.ContainsFinalizer(es, externalSecretFinalizer)).To(BeTrue())
+
+			Expect(k8sClient.Delete(context.Background(), es)).To(Succeed())
+			Eventually(func() bool {
+				err := k8sClient.Get(context.Background(), client.ObjectKeyFromObject(secret), &v1.Secret{})
+				return apierrors.IsNotFound(err)
+			}, time.Second*10, time.Millisecond*200).Should(BeTrue())
+			Eventually(func() bool {
+				err := k8sClient.Get(context.Background(), client.ObjectKeyFromObject(es), &esv1beta1.ExternalSecret{})
+				return apierrors.IsNotFound(err)
+			}, time.Second*10, time.Millisecond*200).Should(BeTrue())
+		}
+	}
+
 	ignoreMismatchControllerForGeneratorRef := func(tc *testCase) {
 		const secretKey = "somekey"
 		const secretVal = "someValue"
@@ -837,6 +1090,118 @@ var _ = Describe("ExternalSecret controller", Serial, func() {
 		}
 	}
 
+	// a storeGroup falls back to the next store if a store is unavailable or misses the secret
+	syncWithStoreGroup := func(tc *testCase) {
+		for name, data := range map[string][]esv1beta1.FakeProviderData{
+			"primary": {
+				{
+					Key:   "other",
+					Value: "value",
+				},
+			},
+			"secondary": {
+				{
+					Key:      "db",
+					ValueMap: map[string]string{"user": "admin"},
+				},
+			},
+		} {
+			Expect(k8sClient.Create(context.Background(), &esv1beta1.SecretStore{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      name,
+					Namespace: ExternalSecretNamespace,
+				},
+				Spec: esv1beta1.SecretStoreSpec{
+					Provider: &esv1beta1.SecretStoreProvider{
+						Fake: &esv1beta1.FakeProvider{
+							Data: data,
+						},
+					},
+				},
+			})).To(Succeed())
+		}
+
+		tc.externalSecret.Spec.DataFrom = []esv1beta1.ExternalSecretDataFromRemoteRef{
+			{
+				Extract: &esv1beta1.ExternalSecretDataRemoteRef{
+					Key: "db",
+				},
+				SourceRef: &esv1beta1.StoreGeneratorSourceRef{
+					StoreGroup: []esv1beta1.SecretStoreRef{
+						{Name: "does-not-exist", Kind: esv1beta1.SecretStoreKind},
+						{Name: "primary", Kind: esv1beta1.SecretStoreKind},
+						{Name: "secondary", Kind: esv1beta1.SecretStoreKind},
+					},
+				},
+			},
+		}
+
+		tc.checkSecret = func(es *esv1beta1.ExternalSecret, secret *v1.Secret) {
+			Expect(string(secret.Data["user"])).To(Equal("admin"))
+			Expect(es.Status.Sources).To(Equal([]esv1beta1.ExternalSecretSourceStatus{
+				{
+					Path:     "spec.dataFrom[0]",
+					StoreRef: &esv1beta1.SecretStoreRef{Name: "secondary", Kind: esv1beta1.SecretStoreKind},
+				},
+			}))
+		}
+	}
+
+	// an entry with continueOnError that fails does not block the other keys
+	syncWithContinueOnError := func(tc *testCase) {
+		Expect(k8sClient.Create(context.Background(), &esv1beta1.SecretStore{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "partial",
+				Namespace: ExternalSecretNamespace,
+			},
+			Spec: esv1beta1.SecretStoreSpec{
+				Provider: &esv1beta1.SecretStoreProvider{
+					Fake: &esv1beta1.FakeProvider{
+						Data: []esv1beta1.FakeProviderData{
+							{
+								Key:   "foo",
+								Value: "bar",
+							},
+						},
+					},
+				},
+			},
+		})).To(Succeed())
+
+		storeRef := &esv1beta1.StoreSourceRef{
+			SecretStoreRef: &esv1beta1.SecretStoreRef{
+				Name: "partial",
+				Kind: esv1beta1.SecretStoreKind,
+			},
+		}
+		tc.externalSecret.Spec.Target.DeletionPolicy = esv1beta1.DeletionPolicyRetain
+		tc.externalSecret.Spec.Data = []esv1beta1.ExternalSecretData{
+			{
+				SecretKey: "foo",
+				RemoteRef: esv1beta1.ExternalSecretDataRemoteRef{Key: "foo"},
+				SourceRef: storeRef,
+			},
+			{
+				SecretKey:       "missing",
+				RemoteRef:       esv1beta1.ExternalSecretDataRemoteRef{Key: "missing"},
+				SourceRef:       storeRef,
+				ContinueOnError: true,
+			},
+		}
+
+		tc.checkCondition = func(es *esv1beta1.ExternalSecret) bool {
+			cond := GetExternalSecretCondition(es.Status, esv1beta1.ExternalSecretReady)
+			return cond != nil && cond.Status == v1.ConditionTrue && cond.Reason == esv1beta1.ConditionReasonSecretPartiallySynced
+		}
+		tc.checkSecret = func(es *esv1beta1.ExternalSecret, secret *v1.Secret) {
+			Expect(string(secret.Data["foo"])).To(Equal("bar"))
+			Expect(secret.Data).ToNot(HaveKey("missing"))
+			Expect(es.Status.Sources).To(HaveLen(1))
+			Expect(es.Status.Sources[0].Path).To(Equal("spec.data[1]"))
+			Expect(es.Status.Sources[0].Error).ToNot(BeEmpty())
+		}
+	}
+
 	// when using a template it should be used as a blueprint
 	// to construct a new secret: labels, annotations and type
 	syncWithTemplate := func(tc *testCase) {
@@ -882,6 +1247,44 @@ var _ = Describe("ExternalSecret controller", Serial, func() {
 		}
 	}
 
+	// a base template from target.templateRef is merged with the inline template
+	syncWithTemplateRef := func(tc *testCase) {
+		const secretVal = "someValue"
+		Expect(k8sClient.Create(context.Background(), &v1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "base-template",
+				Namespace: ExternalSecretNamespace,
+			},
+			Data: map[string]string{
+				"template": `type: kubernetes.io/basic-auth
+metadata:
+  labels:
+    team: payments
+    tier: backend
+data:
+  username: admin
+  password: "{{ .targetProperty | upper }}"
+`,
+			},
+		})).To(Succeed())
+
+		tc.externalSecret.Spec.Target.TemplateRef = &esv1beta1.ExternalSecretTemplateRef{Name: "base-template"}
+		tc.externalSecret.Spec.Target.Template = &esv1beta1.ExternalSecretTemplate{
+			Metadata: esv1beta1.ExternalSecretTemplateMetadata{
+				Labels: map[string]string{"tier": "frontend"},
+			},
+			Data: map[string]string{"username": "app"},
+		}
+		fakeProvider.WithGetSecret([]byte(secretVal), nil)
+		tc.checkSecret = func(es *esv1beta1.ExternalSecret, secret *v1.Secret) {
+			Expect(secret.Type).To(Equal(v1.SecretTypeBasicAuth))
+			Expect(string(secret.Data["username"])).To(Equal("app"))
+			Expect(string(secret.Data["password"])).To(Equal("SOMEVALUE"))
+			Expect(secret.Labels).To(HaveKeyWithValue("team", "payments"))
+			Expect(secret.Labels).To(HaveKeyWithValue("tier", "frontend"))
+		}
+	}
+
 	// when using a v2 template it should use the v2 engine version
 	syncWithTemplateV2 := func(tc *testCase) {
 		const secretVal = "someValue"
@@ -988,6 +1391,68 @@ var _ = Describe("ExternalSecret controller", Serial, func() {
 			Expect(string(secret.Data[tplFromSecKey])).To(Equal("tpl-from-sec-value: someValue // map-bar-value"))
 		}
 	}
+	// same as syncWithTemplatePrecedence, but dataFrom overrides data
+	syncWithDataFromPrecedence := func(tc *testCase) {
+		const secretVal = "someValue"
+		const tplStaticKey = "tplstatickey"
+		const tplStaticVal = "tplstaticvalue"
+		const tplFromCMName = "template-cm"
+		const tplFromKey = "tpl-from-key"
+		const tplFromVal = "tpl-from-value: {{ .targetProperty | toString }} // {{ .bar | toString }}"
+		Expect(k8sClient.Create(context.Background(), &v1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      tplFromCMName,
+				Namespace: ExternalSecretNamespace,
+			},
+			Data: map[string]string{
+				tplFromKey: tplFromVal,
+			},
+		})).To(Succeed())
+		tc.externalSecret.Spec.MergePrecedence = esv1beta1.MergePrecedenceDataFrom
+		tc.externalSecret.Spec.Target.Template = &esv1beta1.ExternalSecretTemplate{
+			Metadata: esv1beta1.ExternalSecretTemplateMetadata{},
+			Type:     v1.SecretTypeOpaque,
+			TemplateFrom: []esv1beta1.TemplateFrom{
+				{
+					ConfigMap: &esv1beta1.TemplateRef{
+						Name: tplFromCMName,
+						Items: []esv1beta1.TemplateRefItem{
+							{
+								Key: tplFromKey,
+							},
+						},
+					},
+				},
+			},
+			Data: map[string]string{
+				// this should be the dataFrom value, not data
+				targetProp: targetPropObj,
+				// this should use the value from the map
+				"bar": "value from map: {{ .bar | toString }}",
+				// just a static value
+				tplStaticKey: tplStaticVal,
+			},
+		}
+		tc.externalSecret.Spec.DataFrom = []esv1beta1.ExternalSecretDataFromRemoteRef{
+			{
+				Extract: &esv1beta1.ExternalSecretDataRemoteRef{
+					Key: "datamap",
+				},
+			},
+		}
+		fakeProvider.WithGetSecret([]byte(secretVal), nil)
+		fakeProvider.WithGetSecretMap(map[string][]byte{
+			"targetProperty": []byte(FooValue),
+			"bar":            []byte(BarValue),
+		}, nil)
+		tc.checkSecret = func(es *esv1beta1.ExternalSecret, secret *v1.Secret) {
+			// check values
+			Expect(string(secret.Data[targetProp])).To(Equal("MAP-FOO-VALUE was templated"))
+			Expect(string(secret.Data[tplStaticKey])).To(Equal(tplStaticVal))
+			Expect(string(secret.Data["bar"])).To(Equal("value from map: map-bar-value"))
+			Expect(string(secret.Data[tplFromKey])).To(Equal("tpl-from-value: map-foo-value // map-bar-value"))
+		}
+	}
 	syncTemplateFromKeysAndValues := func(tc *testCase) {
 		const tplFromCMName = "template-cm"
 		const tplFromSecretName = "template-secret"
@@ -1581,8 +2046,87 @@ var _ = Describe("ExternalSecret controller", Serial, func() {
 			Expect(string(secret.Data["old-bar"])).To(Equal(BarValue))
 		}
 	}
+	// target.keyPrefix and target.keySuffix are added to the keys of data and dataFrom after rewrite
+	syncWithKeyPrefixAndSuffix := func(tc *testCase) {
+		const secretVal = "someValue"
+		tc.externalSecret.Spec.Target.KeyPrefix = "DB_"
+		tc.externalSecret.Spec.Target.KeySuffix = "_V1"
+		tc.externalSecret.Spec.DataFrom = []esv1beta1.ExternalSecretDataFromRemoteRef{
+			{
+				Extract: &esv1beta1.ExternalSecretDataRemoteRef{
+					Key: remoteKey,
+				},
+				Rewrite: []esv1beta1.ExternalSecretRewrite{{
+					Regexp: &esv1beta1.ExternalSecretRewriteRegexp{
+						Source: "(.*)",
+						Target: "new-$1",
+					},
+				}},
+			},
+		}
+		fakeProvider.WithGetSecret([]byte(secretVal), nil)
+		fakeProvider.WithGetSecretMap(map[string][]byte{
+			"foo": []byte(FooValue),
+		}, nil)
+		tc.checkSecret = func(es *esv1beta1.ExternalSecret, secret *v1.Secret) {
+			Expect(string(secret.Data["DB_"+targetProp+"_V1"])).To(Equal(secretVal))
+			Expect(string(secret.Data["DB_new-foo_V1"])).To(Equal(FooValue))
+			Expect(secret.Data).To(HaveLen(2))
+		}
+	}
+	// values above target.sizeLimits fail the sync and name the key in the condition
+	syncWithSizeLimitExceeded := func(tc *testCase) {
+		tc.externalSecret.Spec.Target.SizeLimits = &esv1beta1.ExternalSecretSizeLimits{
+			MaxKeySize: ptr.To[int64](4),
+		}
+		fakeProvider.WithGetSecret([]byte("someValue"), nil)
+		tc.checkSecret = nil
+		tc.checkCondition = func(es *esv1beta1.ExternalSecret) bool {
+			cond := GetExternalSecretCondition(es.Status, esv1beta1.ExternalSecretReady)
+			return cond != nil && cond.Status == v1.ConditionFalse && cond.Reason == esv1beta1.ConditionReasonSecretSyncedError &&
+				strings.Contains(cond.Message, targetProp)
+		}
+	}
+	// keys listed in target.sizeLimits.truncateKeys are cut to maxKeySize
+	syncWithSizeLimitTruncated := func(tc *testCase) {
+		tc.externalSecret.Spec.Target.SizeLimits = &esv1beta1.ExternalSecretSizeLimits{
+			MaxKeySize:   ptr.To[int64](4),
+			TruncateKeys: []string{targetProp},
+		}
+		fakeProvider.WithGetSecret([]byte("someValue"), nil)
+		tc.checkSecret = func(es *esv1beta1.ExternalSecret, secret *v1.Secret) {
+			Expect(string(secret.Data[targetProp])).To(Equal("some"))
+		}
+	}
 	// with rewrite keys from dataFrom
 	// should error if keys are not compliant
+	// with keyNamePolicy=Sanitize invalid characters are replaced instead of failing the sync
+	syncAndSanitizeDataFromExtract := func(tc *testCase) {
+		tc.externalSecret.Spec.Data = nil
+		tc.externalSecret.Spec.KeyNamePolicy = esv1beta1.KeyNamePolicySanitize
+		tc.externalSecret.Spec.DataFrom = []esv1beta1.ExternalSecretDataFromRemoteRef{
+			{
+				Extract: &esv1beta1.ExternalSecretDataRemoteRef{
+					Key: remoteKey,
+				},
+				Rewrite: []esv1beta1.ExternalSecretRewrite{{
+					Regexp: &esv1beta1.ExternalSecretRewriteRegexp{
+						Source: "(.*)",
+						Target: "$1",
+					},
+				}},
+			},
+		}
+		fakeProvider.WithGetSecretMap(map[string][]byte{
+			"foo/bar": []byte(FooValue),
+			"bar.foo": []byte(BarValue),
+		}, nil)
+		tc.checkSecret = func(es *esv1beta1.ExternalSecret, secret *v1.Secret) {
+			Expect(string(secret.Data["foo_bar"])).To(Equal(FooValue))
+			Expect(string(secret.Data["bar.foo"])).To(Equal(BarValue))
+		}
+	}
+
 	invalidExtractKeysErrCondition := func(tc *testCase) {
 		tc.externalSecret.Spec.Data = nil
 		tc.externalSecret.Spec.DataFrom = []esv1beta1.ExternalSecretDataFromRemoteRef{
@@ -1698,6 +2242,100 @@ var _ = Describe("ExternalSecret controller", Serial, func() {
 			Expect(string(secret.Data["bar"])).To(Equal(BarValue))
 		}
 	}
+	// with dataFrom.extract.parser the remote value is parsed by the controller
+	syncWithDataFromParser := func(tc *testCase) {
+		tc.externalSecret.Spec.Data = nil
+		tc.externalSecret.Spec.DataFrom = []esv1beta1.ExternalSecretDataFromRemoteRef{
+			{
+				Extract: &esv1beta1.ExternalSecretDataRemoteRef{
+					Key:    remoteKey,
+					Parser: esv1beta1.ExternalSecretParserDotEnv,
+				},
+			},
+		}
+		fakeProvider.WithGetSecret([]byte("foo="+FooValue+"\nexport bar=\""+BarValue+"\"\n"), nil)
+		tc.checkSecret = func(es *esv1beta1.ExternalSecret, secret *v1.Secret) {
+			Expect(string(secret.Data["foo"])).To(Equal(FooValue))
+			Expect(string(secret.Data["bar"])).To(Equal(BarValue))
+		}
+	}
+
+	// parse errors are reported with a distinct condition message
+	syncWithDataFromParserErr := func(tc *testCase) {
+		tc.externalSecret.Spec.Data = nil
+		tc.externalSecret.Spec.DataFrom = []esv1beta1.ExternalSecretDataFromRemoteRef{
+			{
+				Extract: &esv1beta1.ExternalSecretDataRemoteRef{
+					Key:    remoteKey,
+					Parser: esv1beta1.ExternalSecretParserDotEnv,
+				},
+			},
+		}
+		fakeProvider.WithGetSecret([]byte("foo=\"unterminated"), nil)
+		tc.checkCondition = func(es *esv1beta1.ExternalSecret) bool {
+			cond := GetExternalSecretCondition(es.Status, esv1beta1.ExternalSecretReady)
+			if cond == nil || cond.Status != v1.ConditionFalse || cond.Reason != esv1beta1.ConditionReasonSecretSyncedError {
+				return false
+			}
+			return cond.Message == msgErrorParseSecretData
+		}
+	}
+
+	// target.bundle merges the given keys into a single key
+	syncWithBundle := func(tc *testCase) {
+		tc.externalSecret.Spec.Data = nil
+		tc.externalSecret.Spec.DataFrom = []esv1beta1.ExternalSecretDataFromRemoteRef{
+			{
+				Extract: &esv1beta1.ExternalSecretDataRemoteRef{
+					Key: remoteKey,
+				},
+			},
+		}
+		tc.externalSecret.Spec.Target.Bundle = &esv1beta1.ExternalSecretBundle{
+			Key:     "config.yaml",
+			Format:  esv1beta1.BundleFormatYAMLMerge,
+			Sources: []string{"defaults", "overrides"},
+		}
+		fakeProvider.WithGetSecretMap(map[string][]byte{
+			"defaults":  []byte("foo: " + FooValue + "\nbar: bar\n"),
+			"overrides": []byte("bar: " + BarValue + "\n"),
+		}, nil)
+		tc.checkSecret = func(es *esv1beta1.ExternalSecret, secret *v1.Secret) {
+			Expect(string(secret.Data["config.yaml"])).To(Equal("bar: " + BarValue + "\nfoo: " + FooValue + "\n"))
+			Expect(secret.Data).To(HaveKey("defaults"))
+		}
+	}
+
+	// the limit keeps the first keys in lexical order after the exclusions
+	syncDataFromFindWithLimit := func(tc *testCase) {
+		tc.externalSecret.Spec.Data = nil
+		tc.externalSecret.Spec.DataFrom = []esv1beta1.ExternalSecretDataFromRemoteRef{
+			{
+				Find: &esv1beta1.ExternalSecretFind{
+					Name: &esv1beta1.FindName{
+						RegExp: "^app-",
+					},
+					Exclude: []esv1beta1.FindExclude{
+						{Name: "app-a"},
+					},
+					Limit: ptr.To(2),
+				},
+			},
+		}
+		fakeProvider.WithGetAllSecrets(map[string][]byte{
+			"app-a": []byte("a"),
+			"app-b": []byte("b"),
+			"app-c": []byte("c"),
+			"app-d": []byte("d"),
+		}, nil)
+		tc.checkSecret = func(es *esv1beta1.ExternalSecret, secret *v1.Secret) {
+			Expect(secret.Data).To(HaveKey("app-b"))
+			Expect(secret.Data).To(HaveKey("app-c"))
+			Expect(secret.Data).ToNot(HaveKey("app-a"))
+			Expect(secret.Data).ToNot(HaveKey("app-d"))
+		}
+	}
+
 	// with dataFrom.Find the change is on the called method GetAllSecrets
 	// all keys should be put into the secret
 	syncAndRewriteDataFromFind := func(tc *testCase) {
@@ -1730,6 +2368,42 @@ var _ = Describe("ExternalSecret controller", Serial, func() {
 		}
 	}
 
+	// excluded keys are removed before the rewrite is applied
+	syncAndRewriteDataFromFindWithExclude := func(tc *testCase) {
+		tc.externalSecret.Spec.Data = nil
+		tc.externalSecret.Spec.DataFrom = []esv1beta1.ExternalSecretDataFromRemoteRef{
+			{
+				Find: &esv1beta1.ExternalSecretFind{
+					Name: &esv1beta1.FindName{
+						RegExp: "^app-",
+					},
+					Exclude: []esv1beta1.FindExclude{
+						{RegExp: "-admin$"},
+						{Name: "app-bar"},
+					},
+				},
+				Rewrite: []esv1beta1.ExternalSecretRewrite{
+					{
+						Regexp: &esv1beta1.ExternalSecretRewriteRegexp{
+							Source: "app-(.*)",
+							Target: "$1",
+						},
+					},
+				},
+			},
+		}
+		fakeProvider.WithGetAllSecrets(map[string][]byte{
+			"app-foo":   []byte(FooValue),
+			"app-bar":   []byte(BarValue),
+			"app-admin": []byte("admin"),
+		}, nil)
+		tc.checkSecret = func(es *esv1beta1.ExternalSecret, secret *v1.Secret) {
+			Expect(string(secret.Data["foo"])).To(Equal(FooValue))
+			Expect(secret.Data).ToNot(HaveKey("bar"))
+			Expect(secret.Data).ToNot(HaveKey("admin"))
+		}
+	}
+
 	// with dataFrom.Find the change is on the called method GetAllSecrets
 	// all keys should be put into the secret
 	syncDataFromFind := func(tc *testCase) {
@@ -2313,28 +2987,40 @@ var _ = Describe("ExternalSecret controller", Serial, func() {
 		Entry("should create proper hash annotation for the external secret", checkSecretDataHashAnnotation),
 		Entry("should create proper hash annotation for the external secret with creationPolicy=Merge", checkMergeSecretDataHashAnnotation),
 		Entry("es deletes orphaned secrets", deleteOrphanedSecrets),
+		Entry("es deletes secrets without owner reference on removal", disableOwnerReference),
 		Entry("should refresh when the hash annotation doesn't correspond to secret data", checkSecretDataHashAnnotationChange),
 		Entry("should use external secret name if target secret name isn't defined", syncWithoutTargetName),
 		Entry("should sync to target secrets with naming bigger than 63 characters", syncBigNames),
 		Entry("should expose the secret as a provisioned service binding secret", syncBindingSecret),
 		Entry("should not expose a provisioned service when no secret is synced", skipBindingSecret),
+		Entry("should report provider data without creating a secret with creationPolicy=None", creationPolicyNoneReportsStatus),
+		Entry("should report provider errors with creationPolicy=None", creationPolicyNoneProviderErr),
 		Entry("should set labels and annotations from the ExternalSecret", syncLabelsAnnotations),
 		Entry("should merge labels and annotations to the ones owned by other entity", mergeLabelsAnnotations),
 		Entry("should removed outdated labels and annotations", removeOutdatedLabelsAnnotations),
 		Entry("should set prometheus counters", checkPrometheusCounters),
 		Entry("should merge with existing secret using creationPolicy=Merge", mergeWithSecret),
+		Entry("should skip a secret managed by another controller identity", mergeWithSecretOfOtherIdentity),
 		Entry("should kick reconciliation when secret changes using creationPolicy=Merge", mergeWithSecretUpdate),
 		Entry("should error if secret doesn't exist when using creationPolicy=Merge", mergeWithSecretErr),
 		Entry("should not resolve conflicts with creationPolicy=Merge", mergeWithConflict),
+		Entry("should yield to other managers on conflicts with creationPolicy=StrictMerge", strictMergeWithConflict),
+		Entry("should only apply its own keys with creationPolicy=StrictMerge", strictMergeWithSecret),
 		Entry("should not update unchanged secret using creationPolicy=Merge", mergeWithSecretNoChange),
 		Entry("should not delete pre-existing secret with creationPolicy=Orphan", createSecretPolicyOrphan),
 		Entry("should sync cluster generator ref", syncWithClusterGeneratorRef),
 		Entry("should sync with generatorRef", syncWithGeneratorRef),
+		Entry("should wait for a generator that is not ready", syncWithGeneratorNotReady),
+		Entry("should wait for an ExternalSecret of dependsOn", syncWithDependencyNotReady),
 		Entry("should not process generatorRef with mismatching controller field", ignoreMismatchControllerForGeneratorRef),
 		Entry("should sync with multiple secret stores via sourceRef", syncWithMultipleSecretStores),
+		Entry("should fall back to the next store of a storeGroup", syncWithStoreGroup),
+		Entry("should sync the other keys if an entry with continueOnError fails", syncWithContinueOnError),
 		Entry("should sync with template", syncWithTemplate),
+		Entry("should sync with a base template from templateRef", syncWithTemplateRef),
 		Entry("should sync with template engine v2", syncWithTemplateV2),
 		Entry("should sync template with correct value precedence", syncWithTemplatePrecedence),
+		Entry("should sync with dataFrom taking precedence over data", syncWithDataFromPrecedence),
 		Entry("should sync template from keys and values", syncTemplateFromKeysAndValues),
 		Entry("should sync template from literal", syncTemplateFromLiteral),
 		Entry("should update template if ExternalSecret is updated", templateShouldRewrite),
@@ -2346,10 +3032,19 @@ var _ = Describe("ExternalSecret controller", Serial, func() {
 		Entry("should refresh secret map when provider secret changes when using a template", refreshSecretValueMapTemplate),
 		Entry("should not refresh secret value when provider secret changes but refreshInterval is zero", refreshintervalZero),
 		Entry("should fetch secret using dataFrom", syncWithDataFrom),
+		Entry("should parse secret using dataFrom.extract.parser", syncWithDataFromParser),
+		Entry("should report parse errors using dataFrom.extract.parser", syncWithDataFromParserErr),
+		Entry("should bundle keys into a single key using target.bundle", syncWithBundle),
 		Entry("should rewrite secret using dataFrom", syncAndRewriteWithDataFrom),
+		Entry("should add keyPrefix and keySuffix to all keys", syncWithKeyPrefixAndSuffix),
+		Entry("should fail when a value exceeds target.sizeLimits", syncWithSizeLimitExceeded),
+		Entry("should truncate keys listed in target.sizeLimits.truncateKeys", syncWithSizeLimitTruncated),
 		Entry("should not automatically convert from extract if rewrite is used", invalidExtractKeysErrCondition),
+		Entry("should sanitize keys from extract with keyNamePolicy=Sanitize", syncAndSanitizeDataFromExtract),
 		Entry("should fetch secret using dataFrom.find", syncDataFromFind),
 		Entry("should rewrite secret using dataFrom.find", syncAndRewriteDataFromFind),
+		Entry("should exclude keys from dataFrom.find before rewriting", syncAndRewriteDataFromFindWithExclude),
+		Entry("should keep the first keys of dataFrom.find up to the limit", syncDataFromFindWithLimit),
 		Entry("should not automatically convert from find if rewrite is used", invalidFindKeysErrCondition),
 		Entry("should fetch secret using dataFrom and a template", syncWithDataFromTemplate),
 		Entry("should set error condition when provider errors", providerErrCondition),
@@ -2503,6 +3198,62 @@ var _ = Describe("ExternalSecret refresh logic", func() {
 			Expect(shouldRefresh(es)).To(BeTrue())
 		})
 
+
+		It("should only refresh on changes with refreshPolicy OnChange", func() {
+			es := &esv1beta1.ExternalSecret{
+				ObjectMeta: metav1.ObjectMeta{
+					Generation: 1,
+				},
+				Spec: esv1beta1.ExternalSecretSpec{
+					RefreshInterval: &metav1.Duration{Duration: time.Second},
+					RefreshPolicy:   esv1beta1.RefreshPolicyOnChange,
+				},
+				Status: esv1beta1.ExternalSecretStatus{
+					RefreshTime: metav1.NewTime(metav1.Now().Add(-time.Second * 5)),
+				},
+			}
+			// never synced -> refresh
+			Expect(shouldRefresh(es)).To(BeTrue())
+
+			// refresh interval has passed, but the resource version matches
+			es.Status.SyncedResourceVersion = getResourceVersion(es)
+			Expect(shouldRefresh(es)).To(BeFalse())
+			Expect((&Reconciler{}).getRequeueResult(es)).To(Equal(ctrl.Result{}))
+
+			// update gen -> refresh
+			es.ObjectMeta.Generation = 2
+			Expect(shouldRefresh(es)).To(BeTrue())
+		})
+
+		It("should only refresh on force-sync with refreshPolicy Manual", func() {
+			es := &esv1beta1.ExternalSecret{
+				ObjectMeta: metav1.ObjectMeta{
+					Generation:  1,
+					Annotations: map[string]string{},
+				},
+				Spec: esv1beta1.ExternalSecretSpec{
+					RefreshInterval: &metav1.Duration{Duration: time.Second},
+					RefreshPolicy:   esv1beta1.RefreshPolicyManual,
+				},
+				Status: esv1beta1.ExternalSecretStatus{
+					RefreshTime: metav1.NewTime(metav1.Now().Add(-time.Second * 5)),
+				},
+			}
+			// never synced -> refresh
+			Expect(shouldRefresh(es)).To(BeTrue())
+
+			es.Status.SyncedResourceVersion = getResourceVersion(es)
+			Expect(shouldRefresh(es)).To(BeFalse())
+			Expect((&Reconciler{}).getRequeueResult(es)).To(Equal(ctrl.Result{}))
+
+			// spec and other annotations do not trigger a refresh
+			es.ObjectMeta.Generation = 2
+			es.ObjectMeta.Annotations["foo"] = "bar"
+			Expect(shouldRefresh(es)).To(BeFalse())
+
+			es.ObjectMeta.Annotations[esv1beta1.AnnotationForceSync] = "1"
+			Expect(shouldRefresh(es)).To(BeTrue())
+		})
 	})
 	Context("objectmeta hash", func() {
 		It("should produce different hashes for different k/v pairs", func() {
@@ -2588,6 +3339,7 @@ func init() {
 	esmetrics.SetUpMetrics()
 	testSyncCallsTotal = esmetrics.GetCounterVec(esmetrics.SyncCallsKey)
 	testSyncCallsError = esmetrics.GetCounterVec(esmetrics.SyncCallsErrorKey)
+	testGeneratorCalls = esmetrics.GetCounterVec(esmetrics.GeneratorCallsKey)
 	testExternalSecretCondition = esmetrics.GetGaugeVec(esmetrics.ExternalSecretStatusConditionKey)
 	testExternalSecretReconcileDuration = esmetrics.GetGaugeVec(esmetrics.ExternalSecretReconcileDurationKey)
 }
@@ -0,0 +1,162 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalsecret
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// throttleRetryInterval is the delay after which a request is retried
+// if its namespace already has the maximum number of concurrent reconciles.
+const throttleRetryInterval = time.Second
+
+// NamespaceThrottle limits how many ExternalSecrets of a single namespace are reconciled
+// at the same time and how often, so a namespace that churns ExternalSecrets
+// can not occupy the workers of all other namespaces.
+// Requests over the limit are not reconciled but requeued until their namespace has capacity.
+type NamespaceThrottle struct {
+	maxConcurrent int
+	rate          rate.Limit
+	burst         int
+	// onQueueDepth is called with the number of requests of a namespace that wait for capacity.
+	onQueueDepth func(namespace string, depth int)
+	// onQueueDrained is called once no request of a namespace waits anymore or its state is dropped.
+	onQueueDrained func(namespace string)
+
+	mu         sync.Mutex
+	namespaces map[string]*namespaceThrottleState
+}
+
+type namespaceThrottleState struct {
+	inFlight int
+	limiter  *rate.Limiter
+	// waiting are the names of the ExternalSecrets that were requeued for capacity.
+	waiting map[string]struct{}
+}
+
+// NewNamespaceThrottle returns a throttle that allows maxConcurrent concurrent reconciles
+// and reconcilesPerSecond reconciles per second with the given burst per namespace.
+// A limit of 0 disables it, nil is returned if both limits are disabled.
+func NewNamespaceThrottle(maxConcurrent int, reconcilesPerSecond float64, burst int,
+	onQueueDepth func(namespace string, depth int), onQueueDrained func(namespace string)) *NamespaceThrottle {
+	if maxConcurrent <= 0 && reconcilesPerSecond <= 0 {
+		return nil
+	}
+	if burst < 1 {
+		burst = 1
+	}
+	return &NamespaceThrottle{
+		maxConcurrent:  maxConcurrent,
+		rate:           rate.Limit(reconcilesPerSecond),
+		burst:          burst,
+		onQueueDepth:   onQueueDepth,
+		onQueueDrained: onQueueDrained,
+		namespaces:     make(map[string]*namespaceThrottleState),
+	}
+}
+
+// Wrap returns a reconciler that only calls next once the namespace of the request has capacity.
+func (t *NamespaceThrottle) Wrap(next reconcile.Reconciler) reconcile.Reconciler {
+	return reconcile.Func(func(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+		release, delay := t.admit(req, time.Now())
+		if release == nil {
+			return ctrl.Result{RequeueAfter: delay}, nil
+		}
+		defer release()
+		return next.Reconcile(ctx, req)
+	})
+}
+
+// admit returns a function to release the capacity once the request was reconciled,
+// or nil and the delay after which the request is retried.
+func (t *NamespaceThrottle) admit(req ctrl.Request, now time.Time) (func(), time.Duration) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	state := t.namespaces[req.Namespace]
+	if state == nil {
+		state = &namespaceThrottleState{waiting: make(map[string]struct{})}
+		if t.rate > 0 {
+			state.limiter = rate.NewLimiter(t.rate, t.burst)
+		}
+		t.namespaces[req.Namespace] = state
+	}
+
+	if t.maxConcurrent > 0 && state.inFlight >= t.maxConcurrent {
+		t.wait(req, state)
+		return nil, throttleRetryInterval
+	}
+	if state.limiter != nil {
+		reservation := state.limiter.ReserveN(now, 1)
+		if delay := reservation.DelayFrom(now); delay > 0 {
+			reservation.CancelAt(now)
+			t.wait(req, state)
+			return nil, delay
+		}
+	}
+
+	state.inFlight++
+	if _, ok := state.waiting[req.Name]; ok {
+		delete(state.waiting, req.Name)
+		t.reportQueueDepth(req.Namespace, state)
+	}
+	return func() {
+		t.release(req.Namespace)
+	}, 0
+}
+
+func (t *NamespaceThrottle) release(namespace string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	state := t.namespaces[namespace]
+	state.inFlight--
+	// forget idle namespaces, a full token bucket is the same as a new one
+	if state.inFlight == 0 && len(state.waiting) == 0 &&
+		(state.limiter == nil || state.limiter.Tokens() >= float64(t.burst)) {
+		delete(t.namespaces, namespace)
+		t.drainQueue(namespace)
+	}
+}
+
+func (t *NamespaceThrottle) wait(req ctrl.Request, state *namespaceThrottleState) {
+	if _, ok := state.waiting[req.Name]; ok {
+		return
+	}
+	state.waiting[req.Name] = struct{}{}
+	t.reportQueueDepth(req.Namespace, state)
+}
+
+func (t *NamespaceThrottle) reportQueueDepth(namespace string, state *namespaceThrottleState) {
+	if len(state.waiting) == 0 {
+		t.drainQueue(namespace)
+		return
+	}
+	if t.onQueueDepth != nil {
+		t.onQueueDepth(namespace, len(state.waiting))
+	}
+}
+
+func (t *NamespaceThrottle) drainQueue(namespace string) {
+	if t.onQueueDrained != nil {
+		t.onQueueDrained(namespace)
+	}
+}
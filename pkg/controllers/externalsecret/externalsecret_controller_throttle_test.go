@@ -0,0 +1,161 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalsecret
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/external-secrets/external-secrets/pkg/controllers/externalsecret/esmetrics"
+)
+
+func throttleRequest(namespace, name string) ctrl.Request {
+	return ctrl.Request{NamespacedName: types.NamespacedName{Namespace: namespace, Name: name}}
+}
+
+func TestNewNamespaceThrottleDisabled(t *testing.T) {
+	if throttle := NewNamespaceThrottle(0, 0, 10, nil, nil); throttle != nil {
+		t.Errorf("expected no throttle without limits, got %v", throttle)
+	}
+}
+
+func TestNamespaceThrottleConcurrency(t *testing.T) {
+	depths := map[string]int{}
+	throttle := NewNamespaceThrottle(1, 0, 0, func(namespace string, depth int) {
+		depths[namespace] = depth
+	}, func(namespace string) {
+		delete(depths, namespace)
+	})
+	now := time.Now()
+
+	release, _ := throttle.admit(throttleRequest("tenant-a", "first"), now)
+	if release == nil {
+		t.Fatalf("expected the first request to be admitted")
+	}
+	if other, _ := throttle.admit(throttleRequest("tenant-b", "first"), now); other == nil {
+		t.Errorf("expected a request of another namespace to be admitted")
+	} else {
+		other()
+	}
+
+	blocked, delay := throttle.admit(throttleRequest("tenant-a", "second"), now)
+	if blocked != nil {
+		t.Fatalf("expected the second request of the namespace to be throttled")
+	}
+	if delay != throttleRetryInterval {
+		t.Errorf("expected delay %v, got %v", throttleRetryInterval, delay)
+	}
+	if depths["tenant-a"] != 1 {
+		t.Errorf("expected queue depth 1, got %d", depths["tenant-a"])
+	}
+
+	// a request that is retried while still waiting is counted once
+	throttle.admit(throttleRequest("tenant-a", "second"), now)
+	if depths["tenant-a"] != 1 {
+		t.Errorf("expected queue depth 1 after retry, got %d", depths["tenant-a"])
+	}
+
+	release()
+	second, _ := throttle.admit(throttleRequest("tenant-a", "second"), now)
+	if second == nil {
+		t.Fatalf("expected the second request to be admitted after release")
+	}
+	if depth, ok := depths["tenant-a"]; ok {
+		t.Errorf("expected the queue depth to be removed, got %d", depth)
+	}
+	second()
+	if len(throttle.namespaces) != 0 {
+		t.Errorf("expected idle namespaces to be forgotten, got %d", len(throttle.namespaces))
+	}
+}
+
+func TestNamespaceThrottleRate(t *testing.T) {
+	throttle := NewNamespaceThrottle(0, 1, 2, nil, nil)
+	now := time.Now()
+
+	for _, name := range []string{"first", "second"} {
+		release, _ := throttle.admit(throttleRequest("tenant-a", name), now)
+		if release == nil {
+			t.Fatalf("expected %s to be admitted within the burst", name)
+		}
+		release()
+	}
+	release, delay := throttle.admit(throttleRequest("tenant-a", "third"), now)
+	if release != nil {
+		t.Fatalf("expected the third request to be throttled")
+	}
+	if delay <= 0 || delay > time.Second {
+		t.Errorf("expected a delay up to one second, got %v", delay)
+	}
+	if release, _ := throttle.admit(throttleRequest("tenant-b", "first"), now); release == nil {
+		t.Errorf("expected a request of another namespace to be admitted")
+	}
+	if release, _ := throttle.admit(throttleRequest("tenant-a", "third"), now.Add(time.Second)); release == nil {
+		t.Errorf("expected the third request to be admitted after the delay")
+	}
+}
+
+func TestNamespaceThrottleWrap(t *testing.T) {
+	throttle := NewNamespaceThrottle(1, 0, 0, nil, nil)
+	calls := 0
+	var wrapped reconcile.Reconciler
+	wrapped = throttle.Wrap(reconcile.Func(func(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+		calls++
+		// the namespace is at capacity during the reconcile
+		res, err := wrapped.Reconcile(ctx, throttleRequest(req.Namespace, "other"))
+		if err != nil || res.RequeueAfter != throttleRetryInterval {
+			t.Errorf("expected the nested request to be requeued, got %v, %v", res, err)
+		}
+		return ctrl.Result{}, nil
+	}))
+
+	if _, err := wrapped.Reconcile(context.Background(), throttleRequest("tenant-a", "first")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if calls != 1 {
+		t.Errorf("expected one reconcile, got %d", calls)
+	}
+}
+
+func TestNamespaceThrottleQueueDepthMetric(t *testing.T) {
+	queueDepth := esmetrics.GetGaugeVec(esmetrics.NamespaceQueueDepthKey)
+	throttle := NewNamespaceThrottle(0, 1, 1, esmetrics.UpdateNamespaceQueueDepth, esmetrics.DeleteNamespaceQueueDepth)
+	now := time.Now()
+
+	release, _ := throttle.admit(throttleRequest("tenant-metric", "first"), now)
+	release()
+	if waiting, _ := throttle.admit(throttleRequest("tenant-metric", "second"), now); waiting != nil {
+		t.Fatalf("expected the second request to wait for a token")
+	}
+	if got := testutil.ToFloat64(queueDepth.WithLabelValues("tenant-metric")); got != 1 {
+		t.Errorf("expected queue depth 1, got %v", got)
+	}
+
+	// the series is removed once the request is admitted and the idle namespace is dropped
+	release, _ = throttle.admit(throttleRequest("tenant-metric", "second"), now.Add(time.Second))
+	if release == nil {
+		t.Fatalf("expected the second request to be admitted after the delay")
+	}
+	if queueDepth.DeleteLabelValues("tenant-metric") {
+		t.Errorf("expected the series of the drained namespace to be removed")
+	}
+	release()
+}
@@ -0,0 +1,84 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalsecret
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+)
+
+// withProviderTimeout wraps the client to limit every provider call to spec.providerTimeout, if it is set.
+func withProviderTimeout(client esv1beta1.SecretsClient, timeout *metav1.Duration) esv1beta1.SecretsClient {
+	if timeout == nil || timeout.Duration <= 0 {
+		return client
+	}
+	return &timeoutClient{
+		SecretsClient: client,
+		timeout:       timeout.Duration,
+	}
+}
+
+// timeoutClient runs every read of the wrapped client with its own deadline.
+// A call that exceeds the deadline fails with ErrProviderTimeout, it is handled like
+// every other provider error: the store counts as failed and the sync is retried with backoff.
+type timeoutClient struct {
+	esv1beta1.SecretsClient
+	timeout time.Duration
+}
+
+func (c *timeoutClient) GetSecret(ctx context.Context, ref esv1beta1.ExternalSecretDataRemoteRef) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+	data, err := c.SecretsClient.GetSecret(ctx, ref)
+	return data, c.wrapError(ctx, err)
+}
+
+func (c *timeoutClient) GetSecretMap(ctx context.Context, ref esv1beta1.ExternalSecretDataRemoteRef) (map[string][]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+	data, err := c.SecretsClient.GetSecretMap(ctx, ref)
+	return data, c.wrapError(ctx, err)
+}
+
+func (c *timeoutClient) GetAllSecrets(ctx context.Context, ref esv1beta1.ExternalSecretFind) (map[string][]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+	data, err := c.SecretsClient.GetAllSecrets(ctx, ref)
+	return data, c.wrapError(ctx, err)
+}
+
+// ResolveVersionAlias forwards to the wrapped client, so version aliases
+// are resolved within the same deadline as the reads.
+func (c *timeoutClient) ResolveVersionAlias(ctx context.Context, ref esv1beta1.ExternalSecretDataRemoteRef) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+	version, err := esv1beta1.ResolveVersionAlias(ctx, c.SecretsClient, ref)
+	return version, c.wrapError(ctx, err)
+}
+
+// wrapError marks the error as a timeout if the deadline of the call expired,
+// regardless of how the provider reports the cancellation.
+func (c *timeoutClient) wrapError(ctx context.Context, err error) error {
+	if err == nil || !errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return err
+	}
+	return fmt.Errorf("%w after %s: %w", ErrProviderTimeout, c.timeout, err)
+}
@@ -0,0 +1,88 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalsecret
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	"github.com/external-secrets/external-secrets/pkg/provider/testing/fake"
+)
+
+func TestProviderTimeout(t *testing.T) {
+	errBoom := errors.New("boom")
+	provider := fake.New()
+	provider.GetSecretFn = func(ctx context.Context, ref esv1beta1.ExternalSecretDataRemoteRef) ([]byte, error) {
+		switch ref.Key {
+		case "slow":
+			// the provider only returns once the call is canceled
+			<-ctx.Done()
+			return nil, ctx.Err()
+		case "broken":
+			return nil, errBoom
+		}
+		return []byte(ref.Key), nil
+	}
+	provider.GetSecretMapFn = func(ctx context.Context, _ esv1beta1.ExternalSecretDataRemoteRef) (map[string][]byte, error) {
+		<-ctx.Done()
+		return nil, errors.New("request canceled")
+	}
+
+	if client := withProviderTimeout(provider, nil); client != provider {
+		t.Fatalf("withProviderTimeout() wrapped the client without a timeout")
+	}
+	client := withProviderTimeout(provider, &metav1.Duration{Duration: 50 * time.Millisecond})
+
+	data := map[string][]byte{}
+	var failed []string
+	start := time.Now()
+	for _, key := range []string{"fast", "slow", "broken", "other"} {
+		err := getSecretData(context.Background(), client, esv1beta1.ExternalSecretData{SecretKey: key, RemoteRef: esv1beta1.ExternalSecretDataRemoteRef{Key: key}}, data)
+		switch key {
+		case "slow":
+			if !errors.Is(err, ErrProviderTimeout) || !errors.Is(err, context.DeadlineExceeded) {
+				t.Errorf("unexpected error for the slow key: %v", err)
+			}
+		case "broken":
+			if !errors.Is(err, errBoom) || errors.Is(err, ErrProviderTimeout) {
+				t.Errorf("unexpected error for the broken key: %v", err)
+			}
+		}
+		if err != nil {
+			failed = append(failed, key)
+		}
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("the slow key blocked the other keys for %s", elapsed)
+	}
+	if diff := cmp.Diff([]string{"slow", "broken"}, failed); diff != "" {
+		t.Errorf("unexpected failed keys (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(stringMap(map[string][]byte{"fast": []byte("fast"), "other": []byte("other")}), stringMap(data)); diff != "" {
+		t.Errorf("unexpected data (-want +got):\n%s", diff)
+	}
+
+	// providers that do not return the context error are classified as timeout as well
+	_, err := client.GetSecretMap(context.Background(), esv1beta1.ExternalSecretDataRemoteRef{Key: "slow"})
+	if !errors.Is(err, ErrProviderTimeout) {
+		t.Errorf("unexpected error for GetSecretMap: %v", err)
+	}
+}
@@ -0,0 +1,79 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalsecret
+
+import (
+	"context"
+	"testing"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	"github.com/external-secrets/external-secrets/pkg/provider/testing/fake"
+)
+
+func TestSourceEncoding(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		ref     esv1beta1.ExternalSecretDataRemoteRef
+		want    string
+		wantErr bool
+	}{
+		{
+			name:  "latin1",
+			value: "gr\xfc\xdf",
+			ref:   esv1beta1.ExternalSecretDataRemoteRef{SourceEncoding: esv1beta1.ExternalSecretSourceEncodingLatin1},
+			want:  "grüß",
+		},
+		{
+			name:  "latin1 after decoding",
+			value: "Z3L83w==",
+			ref: esv1beta1.ExternalSecretDataRemoteRef{
+				SourceEncoding:   esv1beta1.ExternalSecretSourceEncodingLatin1,
+				DecodingStrategy: esv1beta1.ExternalSecretDecodeBase64,
+			},
+			want: "grüß",
+		},
+		{
+			name:    "invalid sequence",
+			value:   "\x81",
+			ref:     esv1beta1.ExternalSecretDataRemoteRef{SourceEncoding: esv1beta1.ExternalSecretSourceEncodingShiftJIS},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := fake.New().WithGetSecret([]byte(tt.value), nil)
+			ref := tt.ref
+			ref.Key = "greeting"
+			data := map[string][]byte{}
+			err := getSecretData(context.Background(), client, esv1beta1.ExternalSecretData{SecretKey: "greeting", RemoteRef: ref}, data)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected an error")
+				}
+				if _, ok := data["greeting"]; ok {
+					t.Errorf("the value was stored despite the error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := string(data["greeting"]); got != tt.want {
+				t.Errorf("unexpected value %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
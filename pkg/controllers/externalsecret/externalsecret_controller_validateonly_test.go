@@ -0,0 +1,127 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalsecret
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+)
+
+func TestReconcileValidateOnly(t *testing.T) {
+	es := &esv1beta1.ExternalSecret{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-es", Namespace: "default"},
+		Spec: esv1beta1.ExternalSecretSpec{
+			RefreshInterval: &metav1.Duration{Duration: time.Hour},
+			SecretStoreRef:  esv1beta1.SecretStoreRef{Name: "test-store"},
+			Target:          esv1beta1.ExternalSecretTarget{Name: "target", CreationPolicy: esv1beta1.CreatePolicyOrphan},
+			Data: []esv1beta1.ExternalSecretData{
+				{SecretKey: "foo", RemoteRef: esv1beta1.ExternalSecretDataRemoteRef{Key: "bar"}},
+			},
+			DataFrom: []esv1beta1.ExternalSecretDataFromRemoteRef{
+				{Extract: &esv1beta1.ExternalSecretDataRemoteRef{Key: "missing"}},
+			},
+			ValidateOnly: true,
+		},
+	}
+	c := newTestClientBuilder(t, newTestStore(), es).Build()
+	r := newTestReconciler(c)
+	ctx := context.Background()
+	key := types.NamespacedName{Name: "test-es", Namespace: "default"}
+	targetKey := types.NamespacedName{Name: "target", Namespace: "default"}
+
+	// the data entry can be read, the dataFrom entry is missing
+	provider := newTestProvider(t)
+	provider.WithGetSecret([]byte("value"), nil)
+	provider.WithGetSecretMap(nil, esv1beta1.NoSecretError{})
+	if _, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key}); err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	if err := c.Get(ctx, targetKey, &v1.Secret{}); !apierrors.IsNotFound(err) {
+		t.Fatalf("expected no secret to be created with validateOnly, got: %v", err)
+	}
+	got := &esv1beta1.ExternalSecret{}
+	if err := c.Get(ctx, key, got); err != nil {
+		t.Fatal(err)
+	}
+	sources := map[string]esv1beta1.ExternalSecretSourceStatus{}
+	for _, source := range got.Status.Sources {
+		sources[source.Path] = source
+	}
+	if source, ok := sources["spec.data[0]"]; !ok || source.Error != "" {
+		t.Errorf("status.sources[spec.data[0]] = %+v, want a readable entry", source)
+	}
+	if source, ok := sources["spec.dataFrom[0]"]; !ok || source.Error == "" {
+		t.Errorf("status.sources[spec.dataFrom[0]] = %+v, want the error of the missing secret", source)
+	}
+	cond := GetExternalSecretCondition(got.Status, esv1beta1.ExternalSecretReady)
+	if cond == nil || cond.Status != v1.ConditionFalse || cond.Reason != esv1beta1.ConditionReasonValidationFailed {
+		t.Errorf("Ready condition = %+v, want False with reason %s", cond, esv1beta1.ConditionReasonValidationFailed)
+	}
+
+	// once every entry can be read, the ExternalSecret is validated
+	provider.WithGetSecretMap(map[string][]byte{"baz": []byte("qux")}, nil)
+	got.Status.RefreshTime = metav1.Time{}
+	if err := c.Status().Update(ctx, got); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key}); err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	if err := c.Get(ctx, targetKey, &v1.Secret{}); !apierrors.IsNotFound(err) {
+		t.Fatalf("expected no secret to be created with validateOnly, got: %v", err)
+	}
+	if err := c.Get(ctx, key, got); err != nil {
+		t.Fatal(err)
+	}
+	cond = GetExternalSecretCondition(got.Status, esv1beta1.ExternalSecretReady)
+	if cond == nil || cond.Status != v1.ConditionTrue || cond.Reason != esv1beta1.ConditionReasonValidated {
+		t.Errorf("Ready condition = %+v, want True with reason %s", cond, esv1beta1.ConditionReasonValidated)
+	}
+
+	// flipping validateOnly to false performs the real sync
+	got.Spec.ValidateOnly = false
+	if err := c.Update(ctx, got); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key}); err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	secret := &v1.Secret{}
+	if err := c.Get(ctx, targetKey, secret); err != nil {
+		t.Fatalf("the secret was not created after validateOnly was disabled: %v", err)
+	}
+	if string(secret.Data["foo"]) != "value" || string(secret.Data["baz"]) != "qux" {
+		t.Errorf("secret data = %v, want foo and baz", stringMap(secret.Data))
+	}
+}
+
+func TestSetSourceError(t *testing.T) {
+	es := &esv1beta1.ExternalSecret{}
+	source := &esv1beta1.ExternalSecretSourceStatus{Path: "spec.data[0]"}
+	setSourceError(es, source, &esv1beta1.ProviderError{Reason: esv1beta1.ProviderErrorPermissionDenied, Err: errors.New("access denied")})
+	if source.Error != "access denied" || source.Reason != esv1beta1.ProviderErrorPermissionDenied {
+		t.Errorf("setSourceError() = %+v, want the error with reason PermissionDenied", source)
+	}
+}
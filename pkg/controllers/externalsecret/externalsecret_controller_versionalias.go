@@ -0,0 +1,62 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalsecret
+
+import (
+	"context"
+	"fmt"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+)
+
+const errVersionAlias = "unable to resolve version %s of %s: %w"
+
+// withVersionAliases resolves the version aliases of remoteRef.version, e.g. previous,
+// to the native version of the provider before the value is read.
+func withVersionAliases(client esv1beta1.SecretsClient) esv1beta1.SecretsClient {
+	return &versionAliasClient{SecretsClient: client}
+}
+
+type versionAliasClient struct {
+	esv1beta1.SecretsClient
+}
+
+func (c *versionAliasClient) GetSecret(ctx context.Context, ref esv1beta1.ExternalSecretDataRemoteRef) ([]byte, error) {
+	ref, err := c.resolve(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+	return c.SecretsClient.GetSecret(ctx, ref)
+}
+
+func (c *versionAliasClient) GetSecretMap(ctx context.Context, ref esv1beta1.ExternalSecretDataRemoteRef) (map[string][]byte, error) {
+	ref, err := c.resolve(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+	return c.SecretsClient.GetSecretMap(ctx, ref)
+}
+
+func (c *versionAliasClient) resolve(ctx context.Context, ref esv1beta1.ExternalSecretDataRemoteRef) (esv1beta1.ExternalSecretDataRemoteRef, error) {
+	if !esv1beta1.IsVersionAlias(ref.Version) {
+		return ref, nil
+	}
+	version, err := esv1beta1.ResolveVersionAlias(ctx, c.SecretsClient, ref)
+	if err != nil {
+		return ref, fmt.Errorf(errVersionAlias, ref.Version, ref.Key, err)
+	}
+	ref.Version = version
+	return ref, nil
+}
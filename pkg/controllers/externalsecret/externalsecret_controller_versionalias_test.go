@@ -0,0 +1,86 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalsecret
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	"github.com/external-secrets/external-secrets/pkg/provider/testing/fake"
+)
+
+// aliasClient maps every alias to a native version prefixed with native-.
+type aliasClient struct {
+	*fake.Client
+}
+
+func (c *aliasClient) ResolveVersionAlias(_ context.Context, ref esv1beta1.ExternalSecretDataRemoteRef) (string, error) {
+	return "native-" + ref.Version, nil
+}
+
+func TestVersionAliases(t *testing.T) {
+	var gotVersion string
+	provider := fake.New()
+	provider.GetSecretFn = func(_ context.Context, ref esv1beta1.ExternalSecretDataRemoteRef) ([]byte, error) {
+		gotVersion = ref.Version
+		return []byte("value"), nil
+	}
+
+	tests := []struct {
+		name        string
+		client      esv1beta1.SecretsClient
+		version     string
+		wantVersion string
+		wantErr     error
+	}{
+		{
+			name:        "alias is resolved",
+			client:      &aliasClient{Client: provider},
+			version:     esv1beta1.VersionAliasPrevious,
+			wantVersion: "native-previous",
+		},
+		{
+			name:        "native version is passed through",
+			client:      &aliasClient{Client: provider},
+			version:     "42",
+			wantVersion: "42",
+		},
+		{
+			name:    "provider without aliases",
+			client:  provider,
+			version: esv1beta1.VersionAliasPrevious,
+			wantErr: esv1beta1.ErrVersionAliasNotSupported,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotVersion = ""
+			// the timeout wrapper forwards the resolution to the provider
+			client := withVersionAliases(withProviderTimeout(tt.client, &metav1.Duration{Duration: time.Minute}))
+			_, err := client.GetSecret(context.Background(), esv1beta1.ExternalSecretDataRemoteRef{Key: "db", Version: tt.version})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("GetSecret() error = %v, want %v", err, tt.wantErr)
+			}
+			if gotVersion != tt.wantVersion {
+				t.Errorf("GetSecret() read version %q, want %q", gotVersion, tt.wantVersion)
+			}
+		})
+	}
+}
@@ -114,6 +114,7 @@ var _ = BeforeSuite(func() {
 		Log:                       ctrl.Log.WithName("controllers").WithName("ExternalSecrets"),
 		RequeueInterval:           time.Second,
 		ClusterSecretStoreEnabled: true,
+		SkipTerminatingNamespaces: true,
 	}).SetupWithManager(k8sManager, controller.Options{
 		MaxConcurrentReconciles: 1,
 	})
@@ -15,11 +15,25 @@ limitations under the License.
 package externalsecret
 
 import (
+	"bytes"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"text/template"
+
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
 	"github.com/external-secrets/external-secrets/pkg/controllers/externalsecret/esmetrics"
+	"github.com/external-secrets/external-secrets/pkg/utils"
+)
+
+const (
+	redactedKey     = "<redacted>"
+	hashedKeyPrefix = "hash:"
+	hashedKeyLength = 12
 )
 
 // NewExternalSecretCondition a set of default options for creating an External Secret Condition.
@@ -79,3 +93,113 @@ func filterOutCondition(conditions []esv1beta1.ExternalSecretStatusCondition, co
 	}
 	return newConditions
 }
+
+// redactRemoteKeys replaces the remote keys referenced by the ExternalSecret in msg,
+// according to spec.statusPolicy.remoteKeys.
+func redactRemoteKeys(es *esv1beta1.ExternalSecret, msg string) string {
+	if es.Spec.StatusPolicy == nil {
+		return msg
+	}
+
+	// keys are shown by default
+	replace := func(key string) string {
+		return key
+	}
+	switch es.Spec.StatusPolicy.RemoteKeys {
+	case esv1beta1.KeyVisibilityHash:
+		replace = func(key string) string {
+			return hashedKeyPrefix + utils.ObjectHash(key)[:hashedKeyLength]
+		}
+	case esv1beta1.KeyVisibilityOmit:
+		replace = func(string) string {
+			return redactedKey
+		}
+	case esv1beta1.KeyVisibilityShow:
+		return msg
+	}
+
+	keys := getRemoteKeys(es)
+	if len(keys) == 0 {
+		return msg
+	}
+
+	// replace longer keys first, so a key which is a prefix of another key
+	// does not leave parts of the longer key behind
+	slices.SortFunc(keys, func(a, b string) int {
+		return len(b) - len(a)
+	})
+	oldNew := make([]string, 0, 2*len(keys))
+	for _, key := range keys {
+		oldNew = append(oldNew, key, replace(key))
+	}
+	return strings.NewReplacer(oldNew...).Replace(msg)
+}
+
+// getRemoteKeys returns the unique remote keys and paths referenced by the ExternalSecret.
+// failedCondition returns the reason and message of the Ready condition of a failed sync.
+// Classified provider errors are reported with their reason and the message of the provider.
+func failedCondition(es *esv1beta1.ExternalSecret, msg string, err error) (string, string) {
+	var providerErr *esv1beta1.ProviderError
+	if !errors.As(err, &providerErr) {
+		return esv1beta1.ConditionReasonSecretSyncedError, msg
+	}
+	return string(providerErr.Reason), fmt.Sprintf("%s: %s", msg, redactRemoteKeys(es, err.Error()))
+}
+
+func getRemoteKeys(es *esv1beta1.ExternalSecret) []string {
+	var keys []string
+	for _, data := range es.Spec.Data {
+		keys = append(keys, data.RemoteRef.Key)
+		keys = append(keys, data.RemoteRef.KeyCandidates...)
+	}
+	for _, dataFrom := range es.Spec.DataFrom {
+		if dataFrom.Extract != nil {
+			keys = append(keys, dataFrom.Extract.Key)
+		}
+		if dataFrom.Find != nil && dataFrom.Find.Path != nil {
+			keys = append(keys, *dataFrom.Find.Path)
+		}
+	}
+	keys = slices.DeleteFunc(keys, func(key string) bool {
+		return key == ""
+	})
+	slices.Sort(keys)
+	return slices.Compact(keys)
+}
+
+// renderProviderOptions executes the templates in spec.providerOptions
+// with the metadata of the ExternalSecret.
+// NOTE: the rendered values must never be logged or included in errors.
+func renderProviderOptions(es *esv1beta1.ExternalSecret) (map[string]string, error) {
+	if len(es.Spec.ProviderOptions) == 0 {
+		return nil, nil
+	}
+	data := map[string]any{
+		"metadata": map[string]any{
+			"name":        es.Name,
+			"namespace":   es.Namespace,
+			"labels":      es.Labels,
+			"annotations": es.Annotations,
+		},
+	}
+	options := make(map[string]string, len(es.Spec.ProviderOptions))
+	for key, tplString := range es.Spec.ProviderOptions {
+		tpl, err := template.New(key).Option("missingkey=error").Parse(tplString)
+		if err != nil {
+			return nil, fmt.Errorf(errProviderOptions, key, err)
+		}
+		var buf bytes.Buffer
+		if err := tpl.Execute(&buf, data); err != nil {
+			return nil, fmt.Errorf(errProviderOptions, key, err)
+		}
+		options[key] = buf.String()
+	}
+	return options, nil
+}
+
+// isManagedByOtherIdentity returns true if the secret carries the controller identity label of
+// another controller instance. Secrets without the label can be managed by any instance.
+func isManagedByOtherIdentity(secret metav1.Object, identity string) bool {
+	value, ok := secret.GetLabels()[esv1beta1.LabelControllerIdentity]
+	return ok && value != identity
+}
@@ -15,14 +15,26 @@ limitations under the License.
 package externalsecret
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"testing"
 	"time"
 
+	"github.com/go-logr/logr"
 	"github.com/google/go-cmp/cmp"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	genv1alpha1 "github.com/external-secrets/external-secrets/apis/generators/v1alpha1"
+	"github.com/external-secrets/external-secrets/pkg/provider/testing/fake"
 )
 
 func TestGetExternalSecretCondition(t *testing.T) {
@@ -223,3 +235,252 @@ func TestSetExternalSecretCondition(t *testing.T) {
 		})
 	}
 }
+
+func TestRedactRemoteKeys(t *testing.T) {
+	findPath := "customers/4711"
+	es := &esv1beta1.ExternalSecret{
+		Spec: esv1beta1.ExternalSecretSpec{
+			Data: []esv1beta1.ExternalSecretData{
+				{SecretKey: "a", RemoteRef: esv1beta1.ExternalSecretDataRemoteRef{Key: "customers/4711/db"}},
+				{SecretKey: "b", RemoteRef: esv1beta1.ExternalSecretDataRemoteRef{Key: "customers/4711/db-password"}},
+			},
+			DataFrom: []esv1beta1.ExternalSecretDataFromRemoteRef{
+				{Find: &esv1beta1.ExternalSecretFind{Path: &findPath}},
+			},
+		},
+	}
+	msg := "error processing spec.data[1] (key: customers/4711/db-password), err: customers/4711/db-password not found"
+
+	tests := []struct {
+		name     string
+		policy   *esv1beta1.ExternalSecretStatusPolicy
+		expected string
+	}{
+		{
+			name:     "no status policy",
+			expected: msg,
+		},
+		{
+			name:     "show keys",
+			policy:   &esv1beta1.ExternalSecretStatusPolicy{RemoteKeys: esv1beta1.KeyVisibilityShow},
+			expected: msg,
+		},
+		{
+			name:     "omit keys",
+			policy:   &esv1beta1.ExternalSecretStatusPolicy{RemoteKeys: esv1beta1.KeyVisibilityOmit},
+			expected: "error processing spec.data[1] (key: <redacted>), err: <redacted> not found",
+		},
+		{
+			name:     "hash keys",
+			policy:   &esv1beta1.ExternalSecretStatusPolicy{RemoteKeys: esv1beta1.KeyVisibilityHash},
+			expected: "error processing spec.data[1] (key: hash:5b1dc74ff03d), err: hash:5b1dc74ff03d not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			es := es.DeepCopy()
+			es.Spec.StatusPolicy = tt.policy
+			got := redactRemoteKeys(es, msg)
+			if diff := cmp.Diff(tt.expected, got); diff != "" {
+				t.Errorf("(-want, +got)\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestFailedCondition(t *testing.T) {
+	es := &esv1beta1.ExternalSecret{
+		Spec: esv1beta1.ExternalSecretSpec{
+			Data: []esv1beta1.ExternalSecretData{
+				{SecretKey: "a", RemoteRef: esv1beta1.ExternalSecretDataRemoteRef{Key: "db-password"}},
+			},
+			StatusPolicy: &esv1beta1.ExternalSecretStatusPolicy{RemoteKeys: esv1beta1.KeyVisibilityOmit},
+		},
+	}
+	denied := &esv1beta1.ProviderError{
+		Reason: esv1beta1.ProviderErrorPermissionDenied,
+		Err:    errors.New("AccessDeniedException: not authorized to read db-password"),
+	}
+
+	tests := []struct {
+		name       string
+		err        error
+		wantReason string
+		wantMsg    string
+	}{
+		{
+			name:       "unclassified error",
+			err:        errors.New("boom"),
+			wantReason: esv1beta1.ConditionReasonSecretSyncedError,
+			wantMsg:    msgErrorGetSecretData,
+		},
+		{
+			name:       "classified provider error",
+			err:        fmt.Errorf("error processing spec.data[0] (key: db-password), err: %w", denied),
+			wantReason: "PermissionDenied",
+			wantMsg: msgErrorGetSecretData + ": error processing spec.data[0] (key: <redacted>), " +
+				"err: AccessDeniedException: not authorized to read <redacted>",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reason, msg := failedCondition(es, msgErrorGetSecretData, tt.err)
+			if reason != tt.wantReason {
+				t.Errorf("unexpected reason %q, want %q", reason, tt.wantReason)
+			}
+			if diff := cmp.Diff(tt.wantMsg, msg); diff != "" {
+				t.Errorf("(-want, +got)\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestRenderProviderOptions(t *testing.T) {
+	es := &esv1beta1.ExternalSecret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "db-credentials",
+			Namespace: "team-a",
+			Labels:    map[string]string{"app": "billing"},
+		},
+		Spec: esv1beta1.ExternalSecretSpec{
+			ProviderOptions: map[string]string{
+				"X-Tenant": "{{ .metadata.namespace }}",
+				"X-App":    "{{ .metadata.labels.app }}/{{ .metadata.name }}",
+				"X-Static": "static",
+			},
+		},
+	}
+	got, err := renderProviderOptions(es)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := map[string]string{
+		"X-Tenant": "team-a",
+		"X-App":    "billing/db-credentials",
+		"X-Static": "static",
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("unexpected result (-want +got):\n%s", diff)
+	}
+
+	es.Spec.ProviderOptions = map[string]string{"X-Missing": "{{ .metadata.owner }}"}
+	if _, err := renderProviderOptions(es); err == nil {
+		t.Errorf("expected error for missing template key")
+	}
+}
+
+func TestIsManagedByOtherIdentity(t *testing.T) {
+	tests := []struct {
+		name     string
+		labels   map[string]string
+		identity string
+		expected bool
+	}{
+		{
+			name:     "secret without identity is managed by the default controller",
+			identity: "",
+			expected: false,
+		},
+		{
+			name:     "secret without identity can be taken over",
+			identity: "blue",
+			expected: false,
+		},
+		{
+			name:     "secret with the same identity",
+			labels:   map[string]string{esv1beta1.LabelControllerIdentity: "blue"},
+			identity: "blue",
+			expected: false,
+		},
+		{
+			name:     "secret with another identity",
+			labels:   map[string]string{esv1beta1.LabelControllerIdentity: "green"},
+			identity: "blue",
+			expected: true,
+		},
+		{
+			name:     "secret with an identity is not touched by the default controller",
+			labels:   map[string]string{esv1beta1.LabelControllerIdentity: "green"},
+			identity: "",
+			expected: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Labels: tt.labels}}
+			if got := isManagedByOtherIdentity(secret, tt.identity); got != tt.expected {
+				t.Errorf("isManagedByOtherIdentity() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+// testStoreProvider is the provider of the stores of the tests, the fake provider is registered for it.
+var testStoreProvider = &esv1beta1.SecretStoreProvider{
+	AWS: &esv1beta1.AWSProvider{Service: esv1beta1.AWSServiceSecretsManager},
+}
+
+// newTestProvider registers a new fake provider for testStoreProvider until the test finishes,
+// then the provider of the Ginkgo suite is registered again.
+// The registry of the providers is global, so tests using it must not run in parallel.
+func newTestProvider(t *testing.T) *fake.Client {
+	t.Helper()
+	provider := fake.New()
+	provider.RegisterAs(testStoreProvider)
+	t.Cleanup(func() {
+		fakeProvider.RegisterAs(testStoreProvider)
+	})
+	return provider
+}
+
+// newTestStore returns the SecretStore "test-store" in the "default" namespace, which is served by the fake provider.
+func newTestStore() *esv1beta1.SecretStore {
+	return &esv1beta1.SecretStore{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-store", Namespace: "default"},
+		Spec:       esv1beta1.SecretStoreSpec{Provider: testStoreProvider.DeepCopy()},
+	}
+}
+
+// newTestClientBuilder returns a builder for a fake client with the objects,
+// which serves the status subresource of ExternalSecrets.
+func newTestClientBuilder(t *testing.T, objs ...client.Object) *fakeclient.ClientBuilder {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	for _, add := range []func(*runtime.Scheme) error{clientgoscheme.AddToScheme, esv1beta1.AddToScheme, genv1alpha1.AddToScheme} {
+		if err := add(scheme); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return fakeclient.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).
+		WithStatusSubresource(&esv1beta1.ExternalSecret{})
+}
+
+// newTestReconciler returns a Reconciler for the client with a fake event recorder.
+func newTestReconciler(c client.Client) *Reconciler {
+	return &Reconciler{
+		Client:          c,
+		SecretClient:    c,
+		Log:             logr.Discard(),
+		Scheme:          c.Scheme(),
+		RequeueInterval: time.Hour,
+		recorder:        record.NewFakeRecorder(10),
+	}
+}
+
+// createWithUID sets the uid of created objects, which the fake client does not set.
+// The controller uses it to find an existing secret.
+func createWithUID(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+	obj.SetUID(types.UID(obj.GetName() + "-uid"))
+	return c.Create(ctx, obj, opts...)
+}
+
+// deleteWithSecretKind sets the kind of deleted secret metadata,
+// which the fake client does not set on the items of a PartialObjectMetadataList.
+func deleteWithSecretKind(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.DeleteOption) error {
+	if partial, ok := obj.(*metav1.PartialObjectMetadata); ok {
+		partial.SetGroupVersionKind(corev1.SchemeGroupVersion.WithKind("Secret"))
+	}
+	return c.Delete(ctx, obj, opts...)
+}
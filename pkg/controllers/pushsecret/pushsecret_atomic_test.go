@@ -0,0 +1,126 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pushsecret
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+
+	esapi "github.com/external-secrets/external-secrets/apis/externalsecrets/v1alpha1"
+	"github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+)
+
+// fakeAtomicClient stores the pushed properties per remote key,
+// a remote key listed in fail is rejected as a whole.
+type fakeAtomicClient struct {
+	remote map[string]map[string]string
+	fail   map[string]bool
+	calls  int
+}
+
+func (c *fakeAtomicClient) PushSecrets(_ context.Context, secret *v1.Secret, data []v1beta1.PushSecretData) error {
+	c.calls++
+	remoteKey := data[0].GetRemoteKey()
+	if c.fail[remoteKey] {
+		return errors.New("write rejected")
+	}
+	if c.remote[remoteKey] == nil {
+		c.remote[remoteKey] = make(map[string]string)
+	}
+	if len(secret.Data) != len(data) {
+		return fmt.Errorf("expected only the keys of the batch, got %d keys", len(secret.Data))
+	}
+	for _, d := range data {
+		c.remote[remoteKey][d.GetProperty()] = string(secret.Data[d.GetSecretKey()])
+	}
+	return nil
+}
+
+func pushData(secretKey, remoteKey, property string) esapi.PushSecretData {
+	return esapi.PushSecretData{
+		Match: esapi.PushSecretMatch{
+			SecretKey: secretKey,
+			RemoteRef: esapi.PushSecretRemoteRef{RemoteKey: remoteKey, Property: property},
+		},
+	}
+}
+
+func TestPushAtomicBatches(t *testing.T) {
+	secret := &v1.Secret{}
+	originalData := map[string][]byte{
+		"user":     []byte("admin"),
+		"password": []byte("s3cr3t"),
+		"host":     []byte("db.internal"),
+	}
+
+	var batches []*atomicBatch
+	for _, data := range []esapi.PushSecretData{
+		pushData("user", "db", "user"),
+		pushData("user", "cache", "user"),
+		pushData("password", "db", "password"),
+		pushData("password", "cache", "password"),
+	} {
+		batches = addToAtomicBatch(batches, data)
+	}
+	if len(batches) != 2 || len(batches[0].data) != 2 || len(batches[1].data) != 2 {
+		t.Fatalf("expected two batches with two entries each, got %d", len(batches))
+	}
+
+	t.Run("all batches succeed", func(t *testing.T) {
+		client := &fakeAtomicClient{remote: map[string]map[string]string{}}
+		out := make(map[string]esapi.PushSecretData)
+		if err := pushAtomicBatches(context.Background(), client, secret, originalData, batches, "store", out); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if client.calls != 2 {
+			t.Errorf("expected one write per remote secret, got %d", client.calls)
+		}
+		if client.remote["db"]["password"] != "s3cr3t" || client.remote["cache"]["user"] != "admin" {
+			t.Errorf("unexpected remote state: %v", client.remote)
+		}
+		if len(out) != 4 {
+			t.Errorf("expected all entries in status, got %v", out)
+		}
+	})
+
+	t.Run("failed batch is not recorded", func(t *testing.T) {
+		client := &fakeAtomicClient{
+			remote: map[string]map[string]string{},
+			fail:   map[string]bool{"cache": true},
+		}
+		out := make(map[string]esapi.PushSecretData)
+		if err := pushAtomicBatches(context.Background(), client, secret, originalData, batches, "store", out); err == nil {
+			t.Fatalf("expected error")
+		}
+		if _, ok := client.remote["cache"]; ok {
+			t.Errorf("expected rejected remote secret to be unchanged, got %v", client.remote["cache"])
+		}
+		if len(client.remote["db"]) != 2 {
+			t.Errorf("expected previous batch to be written completely, got %v", client.remote["db"])
+		}
+		for ref := range out {
+			if ref == "cache/user" || ref == "cache/password" {
+				t.Errorf("entry %s of failed batch must not be recorded", ref)
+			}
+		}
+		if len(out) != 2 {
+			t.Errorf("expected only entries of the successful batch, got %v", out)
+		}
+	})
+}
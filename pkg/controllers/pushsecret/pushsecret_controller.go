@@ -173,6 +173,12 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 		return ctrl.Result{}, err
 	}
 
+	// a non-compliant value is not pushed to any store, retrying does not help until the secret changes
+	if err := checkPolicy(&ps, secret); err != nil {
+		r.markAsPolicyViolation(err, &ps)
+		return ctrl.Result{RequeueAfter: refreshInt}, nil
+	}
+
 	secretStores, err = removeUnmanagedStores(ctx, req.Namespace, r, secretStores)
 	if err != nil {
 		r.markAsFailed(err.Error(), &ps, nil)
@@ -320,6 +326,8 @@ func (r *Reconciler) handlePushSecretDataForStore(ctx context.Context, ps esapi.
 	if err != nil {
 		return out, fmt.Errorf("could not get secrets client for store %v: %w", storeName, err)
 	}
+	atomicClient, atomic := secretClient.(v1beta1.AtomicPushSecretClient)
+	var batches []*atomicBatch
 	for _, data := range ps.Spec.Data {
 		secretData, err := utils.ReverseKeys(data.ConversionStrategy, originalSecretData)
 		if err != nil {
@@ -342,14 +350,86 @@ func (r *Reconciler) handlePushSecretDataForStore(ctx context.Context, ps esapi.
 		case esapi.PushSecretUpdatePolicyReplace:
 		default:
 		}
-		if err := secretClient.PushSecret(ctx, secret, data); err != nil {
+		// properties of the same remote secret are pushed together if the provider supports it
+		if atomic && data.GetProperty() != "" {
+			batches = addToAtomicBatch(batches, data)
+			continue
+		}
+		if err := secretClient.PushSecret(ctx, selectSecretKeys(secret, data), data); err != nil {
 			return out, fmt.Errorf(errSetSecretFailed, key, storeName, err)
 		}
 		out[storeKey][statusRef(data)] = data
 	}
+
+	if err := pushAtomicBatches(ctx, atomicClient, secret, originalSecretData, batches, storeName, out[storeKey]); err != nil {
+		return out, err
+	}
 	return out, nil
 }
 
+// atomicBatch holds the PushSecret data entries that are written to one remote secret in a single operation.
+type atomicBatch struct {
+	remoteKey string
+	strategy  esapi.PushSecretConversionStrategy
+	data      []v1beta1.PushSecretData
+	entries   []esapi.PushSecretData
+}
+
+// addToAtomicBatch adds data to the batch of its remote key and conversion strategy.
+func addToAtomicBatch(batches []*atomicBatch, data esapi.PushSecretData) []*atomicBatch {
+	for _, batch := range batches {
+		if batch.remoteKey == data.GetRemoteKey() && batch.strategy == data.ConversionStrategy {
+			batch.data = append(batch.data, data)
+			batch.entries = append(batch.entries, data)
+			return batches
+		}
+	}
+	return append(batches, &atomicBatch{
+		remoteKey: data.GetRemoteKey(),
+		strategy:  data.ConversionStrategy,
+		data:      []v1beta1.PushSecretData{data},
+		entries:   []esapi.PushSecretData{data},
+	})
+}
+
+// pushAtomicBatches writes every batch in a single operation and records the pushed entries in out.
+// A failed batch leaves the remote secret unchanged, so none of its entries are recorded.
+func pushAtomicBatches(ctx context.Context, client v1beta1.AtomicPushSecretClient, secret *v1.Secret, originalSecretData map[string][]byte, batches []*atomicBatch, storeName string, out map[string]esapi.PushSecretData) error {
+	for _, batch := range batches {
+		secretData, err := utils.ReverseKeys(batch.strategy, originalSecretData)
+		if err != nil {
+			return fmt.Errorf(errConvert, err)
+		}
+		secret.Data = secretData
+		if err := client.PushSecrets(ctx, selectSecretKeys(secret, batch.entries...), batch.data); err != nil {
+			return fmt.Errorf(errSetSecretFailed, batch.remoteKey, storeName, err)
+		}
+		for _, data := range batch.entries {
+			out[statusRef(data)] = data
+		}
+	}
+	return nil
+}
+
+// selectSecretKeys returns a copy of secret that only holds the keys selected by data,
+// so keys of the source secret that are not listed are never passed to the provider.
+// If an entry has no secret key the whole secret is pushed and it is returned as is.
+func selectSecretKeys(secret *v1.Secret, data ...esapi.PushSecretData) *v1.Secret {
+	selected := make(map[string][]byte, len(data))
+	for _, d := range data {
+		key := d.GetSecretKey()
+		if key == "" {
+			return secret
+		}
+		if value, ok := secret.Data[key]; ok {
+			selected[key] = value
+		}
+	}
+	out := secret.DeepCopy()
+	out.Data = selected
+	return out
+}
+
 func secretKeyExists(key string, secret *v1.Secret) bool {
 	_, ok := secret.Data[key]
 	return key == "" || ok
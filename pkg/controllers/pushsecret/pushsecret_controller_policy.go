@@ -0,0 +1,66 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pushsecret
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+
+	v1 "k8s.io/api/core/v1"
+
+	esapi "github.com/external-secrets/external-secrets/apis/externalsecrets/v1alpha1"
+	"github.com/external-secrets/external-secrets/pkg/utils/valuepolicy"
+)
+
+const errPolicyViolation = "secret key %q: %w"
+
+// checkPolicy verifies that every value selected by spec.data complies with spec.policy.
+// An entry without a secret key pushes the whole secret, so all of its values are checked.
+func checkPolicy(ps *esapi.PushSecret, secret *v1.Secret) error {
+	if ps.Spec.Policy == nil {
+		return nil
+	}
+	var keys []string
+	for _, data := range ps.Spec.Data {
+		key := data.GetSecretKey()
+		if key == "" {
+			keys = append(keys, slices.Collect(maps.Keys(secret.Data))...)
+			continue
+		}
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	for _, key := range slices.Compact(keys) {
+		value, ok := secret.Data[key]
+		if !ok {
+			// missing keys are reported when the secret is pushed
+			continue
+		}
+		if err := valuepolicy.Check(ps.Spec.Policy, value); err != nil {
+			return fmt.Errorf(errPolicyViolation, key, err)
+		}
+	}
+	return nil
+}
+
+// markAsPolicyViolation sets the Ready condition to false with the PolicyViolation reason.
+// The synced secrets in the status are kept, as nothing was pushed.
+func (r *Reconciler) markAsPolicyViolation(err error, ps *esapi.PushSecret) {
+	msg := err.Error()
+	cond := newPushSecretCondition(esapi.PushSecretReady, v1.ConditionFalse, esapi.ReasonPolicyViolation, msg)
+	setPushSecretCondition(ps, *cond)
+	r.recorder.Event(ps, v1.EventTypeWarning, esapi.ReasonPolicyViolation, msg)
+}
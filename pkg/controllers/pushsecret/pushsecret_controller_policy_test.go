@@ -0,0 +1,98 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pushsecret
+
+import (
+	"errors"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/record"
+
+	esapi "github.com/external-secrets/external-secrets/apis/externalsecrets/v1alpha1"
+	esmeta "github.com/external-secrets/external-secrets/apis/meta/v1"
+	"github.com/external-secrets/external-secrets/pkg/utils/valuepolicy"
+)
+
+func TestCheckPolicy(t *testing.T) {
+	secret := &v1.Secret{
+		Data: map[string][]byte{
+			"username": []byte("admin"),
+			"password": []byte("Sup3r-Secret"),
+		},
+	}
+	policy := &esmeta.SecretValuePolicy{
+		MinLength:       8,
+		RequiredClasses: []esmeta.CharacterClass{esmeta.CharacterClassDigit},
+	}
+
+	tests := []struct {
+		name    string
+		policy  *esmeta.SecretValuePolicy
+		data    []esapi.PushSecretData
+		wantErr bool
+	}{
+		{
+			name: "no policy",
+			data: []esapi.PushSecretData{pushData("username", "db", "")},
+		},
+		{
+			name:   "compliant value",
+			policy: policy,
+			data:   []esapi.PushSecretData{pushData("password", "db", "")},
+		},
+		{
+			name:    "non-compliant value",
+			policy:  policy,
+			data:    []esapi.PushSecretData{pushData("password", "db", "pass"), pushData("username", "db", "user")},
+			wantErr: true,
+		},
+		{
+			name:    "no secret key checks the whole secret",
+			policy:  policy,
+			data:    []esapi.PushSecretData{pushData("", "db", "")},
+			wantErr: true,
+		},
+		{
+			name:   "missing keys are not checked",
+			policy: policy,
+			data:   []esapi.PushSecretData{pushData("missing", "db", "")},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ps := &esapi.PushSecret{Spec: esapi.PushSecretSpec{Policy: tt.policy, Data: tt.data}}
+			err := checkPolicy(ps, secret)
+			if got := errors.Is(err, valuepolicy.ErrViolation); got != tt.wantErr {
+				t.Errorf("checkPolicy() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestMarkAsPolicyViolation(t *testing.T) {
+	recorder := record.NewFakeRecorder(1)
+	r := &Reconciler{recorder: recorder}
+	ps := &esapi.PushSecret{}
+	r.markAsPolicyViolation(errors.New("violation"), ps)
+
+	cond := getPushSecretCondition(ps.Status, esapi.PushSecretReady)
+	if cond == nil || cond.Status != v1.ConditionFalse || cond.Reason != esapi.ReasonPolicyViolation {
+		t.Fatalf("unexpected Ready condition: %+v", cond)
+	}
+	if event := <-recorder.Events; event != "Warning PolicyViolation violation" {
+		t.Errorf("unexpected event: %q", event)
+	}
+}
@@ -41,8 +41,8 @@ const (
 // Apply template modifications for the source secret. These modifications will only live in memory as we will
 // never modify it.
 func (r *Reconciler) applyTemplate(ctx context.Context, ps *v1alpha1.PushSecret, secret *v1.Secret) error {
-	// no template: nothing to do
-	if ps.Spec.Template == nil {
+	// no template, or a template gated by enabledKey whose flag in the source secret is not true: nothing to do
+	if ps.Spec.Template == nil || !templating.Enabled(ps.Spec.Template, secret.Data) {
 		return nil
 	}
 
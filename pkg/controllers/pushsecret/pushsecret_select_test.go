@@ -0,0 +1,79 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pushsecret
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	esapi "github.com/external-secrets/external-secrets/apis/externalsecrets/v1alpha1"
+)
+
+func TestSelectSecretKeys(t *testing.T) {
+	secret := &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "db-credentials", Namespace: "default"},
+		Data: map[string][]byte{
+			"username": []byte("admin"),
+			"password": []byte("s3cr3t"),
+			"host":     []byte("db.internal"),
+			"ca.crt":   []byte("cert"),
+		},
+	}
+
+	tests := []struct {
+		name string
+		data []esapi.PushSecretData
+		want map[string][]byte
+	}{
+		{
+			name: "single key",
+			data: []esapi.PushSecretData{pushData("password", "db", "pass")},
+			want: map[string][]byte{"password": []byte("s3cr3t")},
+		},
+		{
+			name: "renamed keys exclude the other keys",
+			data: []esapi.PushSecretData{
+				pushData("username", "db", "login"),
+				pushData("password", "db", "pass"),
+			},
+			want: map[string][]byte{
+				"username": []byte("admin"),
+				"password": []byte("s3cr3t"),
+			},
+		},
+		{
+			name: "no secret key pushes the whole secret",
+			data: []esapi.PushSecretData{pushData("", "db", "")},
+			want: secret.Data,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := selectSecretKeys(secret, tt.data...)
+			if diff := cmp.Diff(tt.want, got.Data); diff != "" {
+				t.Errorf("unexpected data (-want +got):\n%s", diff)
+			}
+			if got.Name != secret.Name || got.Namespace != secret.Namespace {
+				t.Errorf("expected metadata of the source secret, got %s/%s", got.Namespace, got.Name)
+			}
+		})
+	}
+	if len(secret.Data) != 4 {
+		t.Errorf("source secret must not be modified, got %v", secret.Data)
+	}
+}
@@ -0,0 +1,237 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package remotesecretdeletion
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+
+	esapi "github.com/external-secrets/external-secrets/apis/externalsecrets/v1alpha1"
+	"github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	"github.com/external-secrets/external-secrets/pkg/controllers/secretstore"
+)
+
+const (
+	errPatchStatus      = "error merging"
+	errNotConfirmed     = "spec.confirmation must be set to %s"
+	errFindRequired     = "spec.find.name or spec.find.tags must be set"
+	errDeleteFromStores = "could not delete secrets from all stores: %v"
+	msgDeleted          = "deleted %d secrets"
+	msgDryRun           = "dry run, %d secrets would be deleted"
+)
+
+// Reconciler deletes the managed remote secrets matching a RemoteSecretDeletion
+// once per generation of the resource.
+type Reconciler struct {
+	client.Client
+	Log             logr.Logger
+	Scheme          *runtime.Scheme
+	recorder        record.EventRecorder
+	ControllerClass string
+}
+
+func (r *Reconciler) SetupWithManager(mgr ctrl.Manager, opts controller.Options) error {
+	r.recorder = mgr.GetEventRecorderFor("remotesecretdeletion")
+
+	return ctrl.NewControllerManagedBy(mgr).
+		WithOptions(opts).
+		For(&esapi.RemoteSecretDeletion{}).
+		Complete(r)
+}
+
+func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := r.Log.WithValues("remotesecretdeletion", req.NamespacedName)
+
+	var rsd esapi.RemoteSecretDeletion
+	if err := r.Get(ctx, req.NamespacedName, &rsd); err != nil {
+		if apierrors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, fmt.Errorf("get resource: %w", err)
+	}
+	// the secrets are only deleted once, a new run requires a change of the spec
+	if rsd.Status.ObservedGeneration == rsd.Generation {
+		return ctrl.Result{}, nil
+	}
+
+	storeRefs, err := r.managedStores(ctx, &rsd)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	// if no stores are managed by this controller
+	if len(storeRefs) == 0 {
+		return ctrl.Result{}, nil
+	}
+
+	p := client.MergeFrom(rsd.DeepCopy())
+	defer func() {
+		if err := r.Client.Status().Patch(ctx, &rsd, p); err != nil {
+			log.Error(err, errPatchStatus)
+		}
+	}()
+
+	// invalid specs are not retried, they need to be changed anyway
+	if rsd.Spec.Confirmation != esapi.RemoteSecretDeletionConfirmed {
+		r.markAsFailed(&rsd, fmt.Sprintf(errNotConfirmed, esapi.RemoteSecretDeletionConfirmed))
+		return ctrl.Result{}, nil
+	}
+	if rsd.Spec.Find.Name == nil && len(rsd.Spec.Find.Tags) == 0 {
+		r.markAsFailed(&rsd, errFindRequired)
+		return ctrl.Result{}, nil
+	}
+
+	mgr := secretstore.NewManager(r.Client, r.ControllerClass, false)
+	defer mgr.Close(ctx)
+
+	find := v1beta1.ExternalSecretFind{
+		Path: rsd.Spec.Find.Path,
+		Name: rsd.Spec.Find.Name,
+		Tags: rsd.Spec.Find.Tags,
+	}
+	var errs error
+	retry := false
+	total := 0
+	rsd.Status.Stores = make([]esapi.RemoteSecretDeletionStoreStatus, 0, len(storeRefs))
+	for _, ref := range storeRefs {
+		status := esapi.RemoteSecretDeletionStoreStatus{Name: ref.Name, Kind: ref.Kind}
+		secretsClient, err := mgr.Get(ctx, ref, rsd.Namespace, nil)
+		if err == nil {
+			status.Secrets, err = v1beta1.DeleteAllSecrets(ctx, secretsClient, find, rsd.Spec.DryRun)
+		}
+		if err != nil {
+			status.Error = err.Error()
+			errs = errors.Join(errs, fmt.Errorf("%s/%s: %w", ref.Kind, ref.Name, err))
+			// an unsupported provider won't succeed on a retry
+			retry = retry || !errors.Is(err, v1beta1.ErrDeleteAllSecretsNotSupported)
+		}
+		total += len(status.Secrets)
+		rsd.Status.Stores = append(rsd.Status.Stores, status)
+	}
+	rsd.Status.DryRun = rsd.Spec.DryRun
+
+	if errs != nil {
+		r.markAsFailed(&rsd, fmt.Sprintf(errDeleteFromStores, errs))
+		if retry {
+			return ctrl.Result{}, errs
+		}
+		return ctrl.Result{}, nil
+	}
+
+	rsd.Status.ObservedGeneration = rsd.Generation
+	if rsd.Spec.DryRun {
+		r.markAsDone(&rsd, esapi.ReasonDryRun, fmt.Sprintf(msgDryRun, total))
+	} else {
+		r.markAsDone(&rsd, esapi.ReasonDeleted, fmt.Sprintf(msgDeleted, total))
+	}
+	return ctrl.Result{}, nil
+}
+
+// managedStores returns the store references of the stores handled by this controller.
+func (r *Reconciler) managedStores(ctx context.Context, rsd *esapi.RemoteSecretDeletion) ([]v1beta1.SecretStoreRef, error) {
+	refs := make([]v1beta1.SecretStoreRef, 0, len(rsd.Spec.SecretStoreRefs))
+	for _, ref := range rsd.Spec.SecretStoreRefs {
+		if ref.Kind == "" {
+			ref.Kind = v1beta1.SecretStoreKind
+		}
+		var store v1beta1.GenericStore
+		namespace := rsd.Namespace
+		switch ref.Kind {
+		case v1beta1.ClusterSecretStoreKind:
+			store = &v1beta1.ClusterSecretStore{}
+			namespace = ""
+		default:
+			store = &v1beta1.SecretStore{}
+		}
+		err := r.Client.Get(ctx, types.NamespacedName{Name: ref.Name, Namespace: namespace}, store)
+		// a missing store is reported in the status of the store
+		if apierrors.IsNotFound(err) {
+			refs = append(refs, ref)
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if secretstore.ShouldProcessStore(store, r.ControllerClass) {
+			refs = append(refs, ref)
+		}
+	}
+	return refs, nil
+}
+
+func (r *Reconciler) markAsFailed(rsd *esapi.RemoteSecretDeletion, msg string) {
+	cond := newCondition(esapi.RemoteSecretDeletionReady, v1.ConditionFalse, esapi.ReasonErrored, msg)
+	setCondition(rsd, *cond)
+	r.recorder.Event(rsd, v1.EventTypeWarning, esapi.ReasonErrored, msg)
+}
+
+func (r *Reconciler) markAsDone(rsd *esapi.RemoteSecretDeletion, reason, msg string) {
+	cond := newCondition(esapi.RemoteSecretDeletionReady, v1.ConditionTrue, reason, msg)
+	setCondition(rsd, *cond)
+	r.recorder.Event(rsd, v1.EventTypeNormal, reason, msg)
+}
+
+func newCondition(condType esapi.RemoteSecretDeletionConditionType, status v1.ConditionStatus, reason, message string) *esapi.RemoteSecretDeletionStatusCondition {
+	return &esapi.RemoteSecretDeletionStatusCondition{
+		Type:               condType,
+		Status:             status,
+		LastTransitionTime: metav1.Now(),
+		Reason:             reason,
+		Message:            message,
+	}
+}
+
+func setCondition(rsd *esapi.RemoteSecretDeletion, condition esapi.RemoteSecretDeletionStatusCondition) {
+	currentCond := getCondition(rsd.Status, condition.Type)
+	if currentCond != nil && currentCond.Status == condition.Status &&
+		currentCond.Reason == condition.Reason && currentCond.Message == condition.Message {
+		return
+	}
+
+	// Do not update lastTransitionTime if the status of the condition doesn't change.
+	if currentCond != nil && currentCond.Status == condition.Status {
+		condition.LastTransitionTime = currentCond.LastTransitionTime
+	}
+
+	conditions := make([]esapi.RemoteSecretDeletionStatusCondition, 0, len(rsd.Status.Conditions))
+	for _, c := range rsd.Status.Conditions {
+		if c.Type != condition.Type {
+			conditions = append(conditions, c)
+		}
+	}
+	rsd.Status.Conditions = append(conditions, condition)
+}
+
+// getCondition returns the condition with the provided type.
+func getCondition(status esapi.RemoteSecretDeletionStatus, condType esapi.RemoteSecretDeletionConditionType) *esapi.RemoteSecretDeletionStatusCondition {
+	for i := range status.Conditions {
+		c := status.Conditions[i]
+		if c.Type == condType {
+			return &c
+		}
+	}
+	return nil
+}
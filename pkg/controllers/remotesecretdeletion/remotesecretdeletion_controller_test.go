@@ -0,0 +1,215 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package remotesecretdeletion
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	esapi "github.com/external-secrets/external-secrets/apis/externalsecrets/v1alpha1"
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	"github.com/external-secrets/external-secrets/pkg/provider/testing/fake"
+)
+
+const testNamespace = "foo"
+
+// bulkDeleter is a SecretsClient that supports DeleteAllSecrets.
+type bulkDeleter struct {
+	*fake.Client
+	calls  int
+	dryRun bool
+	keys   []string
+	err    error
+}
+
+func (b *bulkDeleter) DeleteAllSecrets(_ context.Context, _ esv1beta1.ExternalSecretFind, dryRun bool) ([]string, error) {
+	b.calls++
+	b.dryRun = dryRun
+	return b.keys, b.err
+}
+
+func newDeletion(mutate func(*esapi.RemoteSecretDeletion)) *esapi.RemoteSecretDeletion {
+	rsd := &esapi.RemoteSecretDeletion{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "teardown",
+			Namespace:  testNamespace,
+			Generation: 1,
+		},
+		Spec: esapi.RemoteSecretDeletionSpec{
+			SecretStoreRefs: []esv1beta1.SecretStoreRef{{Name: "store", Kind: esv1beta1.SecretStoreKind}},
+			Find: esapi.RemoteSecretDeletionFind{
+				Name: &esv1beta1.FindName{RegExp: "^app/"},
+			},
+			Confirmation: esapi.RemoteSecretDeletionConfirmed,
+		},
+	}
+	if mutate != nil {
+		mutate(rsd)
+	}
+	return rsd
+}
+
+func TestReconcile(t *testing.T) {
+	scheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(esv1beta1.AddToScheme(scheme))
+	utilruntime.Must(esapi.AddToScheme(scheme))
+
+	store := &esv1beta1.SecretStore{
+		ObjectMeta: metav1.ObjectMeta{Name: "store", Namespace: testNamespace},
+		Spec: esv1beta1.SecretStoreSpec{
+			Provider: &esv1beta1.SecretStoreProvider{Fake: &esv1beta1.FakeProvider{}},
+		},
+	}
+
+	tests := []struct {
+		name          string
+		rsd           *esapi.RemoteSecretDeletion
+		supported     bool
+		deleter       *bulkDeleter
+		wantErr       bool
+		wantCalls     int
+		wantReason    string
+		wantStores    []esapi.RemoteSecretDeletionStoreStatus
+		wantGenerated int64
+	}{
+		{
+			name:          "deletes the secrets",
+			rsd:           newDeletion(nil),
+			supported:     true,
+			deleter:       &bulkDeleter{keys: []string{"app/a", "app/b"}},
+			wantCalls:     1,
+			wantReason:    esapi.ReasonDeleted,
+			wantStores:    []esapi.RemoteSecretDeletionStoreStatus{{Name: "store", Kind: esv1beta1.SecretStoreKind, Secrets: []string{"app/a", "app/b"}}},
+			wantGenerated: 1,
+		},
+		{
+			name: "dry run",
+			rsd: newDeletion(func(rsd *esapi.RemoteSecretDeletion) {
+				rsd.Spec.DryRun = true
+			}),
+			supported:     true,
+			deleter:       &bulkDeleter{keys: []string{"app/a"}},
+			wantCalls:     1,
+			wantReason:    esapi.ReasonDryRun,
+			wantStores:    []esapi.RemoteSecretDeletionStoreStatus{{Name: "store", Kind: esv1beta1.SecretStoreKind, Secrets: []string{"app/a"}}},
+			wantGenerated: 1,
+		},
+		{
+			name: "already processed generation",
+			rsd: newDeletion(func(rsd *esapi.RemoteSecretDeletion) {
+				rsd.Status.ObservedGeneration = 1
+			}),
+			supported:     true,
+			deleter:       &bulkDeleter{},
+			wantGenerated: 1,
+		},
+		{
+			name: "missing confirmation",
+			rsd: newDeletion(func(rsd *esapi.RemoteSecretDeletion) {
+				rsd.Spec.Confirmation = ""
+			}),
+			supported:  true,
+			deleter:    &bulkDeleter{},
+			wantReason: esapi.ReasonErrored,
+		},
+		{
+			name: "find without name or tags",
+			rsd: newDeletion(func(rsd *esapi.RemoteSecretDeletion) {
+				rsd.Spec.Find = esapi.RemoteSecretDeletionFind{}
+			}),
+			supported:  true,
+			deleter:    &bulkDeleter{},
+			wantReason: esapi.ReasonErrored,
+		},
+		{
+			name:       "unsupported provider is not retried",
+			rsd:        newDeletion(nil),
+			wantReason: esapi.ReasonErrored,
+			wantStores: []esapi.RemoteSecretDeletionStoreStatus{{Name: "store", Kind: esv1beta1.SecretStoreKind, Error: esv1beta1.ErrDeleteAllSecretsNotSupported.Error()}},
+		},
+		{
+			name:       "provider error is retried",
+			rsd:        newDeletion(nil),
+			supported:  true,
+			deleter:    &bulkDeleter{keys: []string{"app/a"}, err: errors.New("boom")},
+			wantErr:    true,
+			wantCalls:  1,
+			wantReason: esapi.ReasonErrored,
+			wantStores: []esapi.RemoteSecretDeletionStoreStatus{{Name: "store", Kind: esv1beta1.SecretStoreKind, Secrets: []string{"app/a"}, Error: "boom"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider := fake.New()
+			if tt.supported {
+				tt.deleter.Client = provider
+				provider.NewFn = func(context.Context, esv1beta1.GenericStore, client.Client, string) (esv1beta1.SecretsClient, error) {
+					return tt.deleter, nil
+				}
+			}
+			provider.RegisterAs(&esv1beta1.SecretStoreProvider{Fake: &esv1beta1.FakeProvider{}})
+
+			kube := fakeclient.NewClientBuilder().
+				WithScheme(scheme).
+				WithObjects(store.DeepCopy(), tt.rsd).
+				WithStatusSubresource(&esapi.RemoteSecretDeletion{}).
+				Build()
+			r := &Reconciler{
+				Client:   kube,
+				Log:      logr.Discard(),
+				Scheme:   scheme,
+				recorder: record.NewFakeRecorder(10),
+			}
+			key := types.NamespacedName{Name: tt.rsd.Name, Namespace: testNamespace}
+			_, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: key})
+			if tt.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+
+			var got esapi.RemoteSecretDeletion
+			require.NoError(t, kube.Get(context.Background(), key, &got))
+			assert.Equal(t, tt.wantGenerated, got.Status.ObservedGeneration)
+			assert.Equal(t, tt.wantStores, got.Status.Stores)
+			if tt.wantReason != "" {
+				cond := getCondition(got.Status, esapi.RemoteSecretDeletionReady)
+				require.NotNil(t, cond)
+				assert.Equal(t, tt.wantReason, cond.Reason)
+				assert.Equal(t, tt.wantReason != esapi.ReasonErrored, cond.Status == corev1.ConditionTrue)
+			}
+			if tt.deleter != nil {
+				assert.Equal(t, tt.wantCalls, tt.deleter.calls)
+				assert.Equal(t, tt.rsd.Spec.DryRun, tt.deleter.dryRun)
+			}
+		})
+	}
+}
@@ -0,0 +1,208 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secretstore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+)
+
+const errStoreUnavailable = "%s %q is unavailable after %d consecutive provider failures, retrying in %s"
+
+// BreakerState is the state of the circuit breaker of a store.
+type BreakerState int
+
+const (
+	// BreakerClosed lets all provider calls through.
+	BreakerClosed BreakerState = iota
+	// BreakerOpen rejects all provider calls until the cooldown ends.
+	BreakerOpen
+	// BreakerHalfOpen lets a single probe through after the cooldown,
+	// its result closes or opens the breaker again.
+	BreakerHalfOpen
+)
+
+// StoreUnavailableError is returned by Manager.Get while the circuit breaker of a store is open.
+type StoreUnavailableError struct {
+	Store      esv1beta1.GenericStore
+	Failures   int
+	RetryAfter time.Duration
+}
+
+func (e *StoreUnavailableError) Error() string {
+	return fmt.Sprintf(errStoreUnavailable, e.Store.GetKind(), e.Store.GetName(), e.Failures, e.RetryAfter.Round(time.Second))
+}
+
+// CircuitBreakers track the consecutive provider failures per store UID, shared by all
+// ExternalSecrets using the store. After threshold consecutive failures the breaker opens
+// and provider calls are rejected with a StoreUnavailableError for the cooldown.
+// A nil *CircuitBreakers lets all calls through.
+type CircuitBreakers struct {
+	threshold     int
+	cooldown      time.Duration
+	onStateChange func(store esv1beta1.GenericStore, state BreakerState)
+	now           func() time.Time
+
+	mu       sync.Mutex
+	breakers map[types.UID]*breaker
+}
+
+type breaker struct {
+	state    BreakerState
+	failures int
+	// openedAt is the time the breaker opened or the last probe was let through
+	openedAt   time.Time
+	generation int64
+}
+
+// NewCircuitBreakers returns circuit breakers that open after threshold consecutive failures
+// for the cooldown. onStateChange is called on every state change, e.g. to update a metric.
+// It returns nil if threshold is not positive, which disables the circuit breakers.
+func NewCircuitBreakers(threshold int, cooldown time.Duration, onStateChange func(store esv1beta1.GenericStore, state BreakerState)) *CircuitBreakers {
+	if threshold <= 0 {
+		return nil
+	}
+	return &CircuitBreakers{
+		threshold:     threshold,
+		cooldown:      cooldown,
+		onStateChange: onStateChange,
+		now:           time.Now,
+		breakers:      make(map[types.UID]*breaker),
+	}
+}
+
+// Allow returns a StoreUnavailableError if the breaker of the store is open.
+// After the cooldown the first caller is let through as a probe, its result
+// must be reported with Record.
+func (c *CircuitBreakers) Allow(store esv1beta1.GenericStore) error {
+	if c == nil {
+		return nil
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	b, ok := c.breakers[store.GetUID()]
+	if !ok {
+		return nil
+	}
+	// a change of the store may fix the provider access, try again right away
+	if b.generation != store.GetGeneration() {
+		c.setState(store, b, BreakerClosed)
+		return nil
+	}
+	switch b.state {
+	case BreakerOpen:
+		elapsed := c.now().Sub(b.openedAt)
+		if elapsed < c.cooldown {
+			return &StoreUnavailableError{Store: store, Failures: b.failures, RetryAfter: c.cooldown - elapsed}
+		}
+		b.openedAt = c.now()
+		c.setState(store, b, BreakerHalfOpen)
+		return nil
+	case BreakerHalfOpen:
+		// a probe is in flight, another one is let through if it never reported back
+		elapsed := c.now().Sub(b.openedAt)
+		if elapsed < c.cooldown {
+			return &StoreUnavailableError{Store: store, Failures: b.failures, RetryAfter: c.cooldown - elapsed}
+		}
+		b.openedAt = c.now()
+		return nil
+	default:
+		return nil
+	}
+}
+
+// Record reports the result of a provider call.
+// Only availability errors count as failures. Any other error, like a missing secret,
+// a denied key or a failed decoding, belongs to a single secret and counts as success,
+// the provider was reachable.
+func (c *CircuitBreakers) Record(store esv1beta1.GenericStore, err error) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	b, ok := c.breakers[store.GetUID()]
+	if !isAvailabilityError(err) {
+		if ok {
+			c.setState(store, b, BreakerClosed)
+		}
+		return
+	}
+	if !ok {
+		b = &breaker{}
+		c.breakers[store.GetUID()] = b
+	}
+	b.generation = store.GetGeneration()
+	b.failures++
+	if b.state == BreakerHalfOpen || (b.state == BreakerClosed && b.failures >= c.threshold) {
+		b.openedAt = c.now()
+		c.setState(store, b, BreakerOpen)
+	}
+}
+
+// isAvailabilityError returns true if the provider was unavailable, throttled the call or timed out.
+func isAvailabilityError(err error) bool {
+	var providerErr *esv1beta1.ProviderError
+	if errors.As(err, &providerErr) {
+		return providerErr.Reason == esv1beta1.ProviderErrorUnavailable || providerErr.Reason == esv1beta1.ProviderErrorRateLimited
+	}
+	return errors.Is(err, context.DeadlineExceeded)
+}
+
+// setState must be called with c.mu held.
+func (c *CircuitBreakers) setState(store esv1beta1.GenericStore, b *breaker, state BreakerState) {
+	if state == BreakerClosed {
+		delete(c.breakers, store.GetUID())
+	}
+	if b.state == state {
+		return
+	}
+	b.state = state
+	if c.onStateChange != nil {
+		c.onStateChange(store, state)
+	}
+}
+
+// breakerClient reports the result of every read to the circuit breaker of its store.
+type breakerClient struct {
+	esv1beta1.SecretsClient
+	breakers *CircuitBreakers
+	store    esv1beta1.GenericStore
+}
+
+func (c *breakerClient) GetSecret(ctx context.Context, ref esv1beta1.ExternalSecretDataRemoteRef) ([]byte, error) {
+	data, err := c.SecretsClient.GetSecret(ctx, ref)
+	c.breakers.Record(c.store, err)
+	return data, err
+}
+
+func (c *breakerClient) GetSecretMap(ctx context.Context, ref esv1beta1.ExternalSecretDataRemoteRef) (map[string][]byte, error) {
+	data, err := c.SecretsClient.GetSecretMap(ctx, ref)
+	c.breakers.Record(c.store, err)
+	return data, err
+}
+
+func (c *breakerClient) GetAllSecrets(ctx context.Context, ref esv1beta1.ExternalSecretFind) (map[string][]byte, error) {
+	data, err := c.SecretsClient.GetAllSecrets(ctx, ref)
+	c.breakers.Record(c.store, err)
+	return data, err
+}
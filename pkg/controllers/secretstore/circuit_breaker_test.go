@@ -0,0 +1,166 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secretstore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+)
+
+func TestCircuitBreakers(t *testing.T) {
+	errDown := &esv1beta1.ProviderError{Reason: esv1beta1.ProviderErrorUnavailable, Err: errors.New("connection refused")}
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	newStore := func() *esv1beta1.SecretStore {
+		return &esv1beta1.SecretStore{
+			TypeMeta:   metav1.TypeMeta{Kind: esv1beta1.SecretStoreKind},
+			ObjectMeta: metav1.ObjectMeta{Name: "vault", Namespace: "default", UID: "store-uid", Generation: 1},
+		}
+	}
+	newBreakers := func(states *[]BreakerState) *CircuitBreakers {
+		c := NewCircuitBreakers(3, time.Minute, func(_ esv1beta1.GenericStore, state BreakerState) {
+			*states = append(*states, state)
+		})
+		c.now = func() time.Time { return now }
+		return c
+	}
+	assertUnavailable := func(t *testing.T, err error, retryAfter time.Duration) {
+		t.Helper()
+		var unavailable *StoreUnavailableError
+		if assert.ErrorAs(t, err, &unavailable) {
+			assert.Equal(t, retryAfter, unavailable.RetryAfter)
+		}
+	}
+
+	t.Run("disabled without threshold", func(t *testing.T) {
+		c := NewCircuitBreakers(0, time.Minute, nil)
+		assert.Nil(t, c)
+		c.Record(newStore(), errDown)
+		assert.NoError(t, c.Allow(newStore()))
+	})
+
+	t.Run("opens after consecutive failures", func(t *testing.T) {
+		var states []BreakerState
+		c := newBreakers(&states)
+		store := newStore()
+		c.Record(store, errDown)
+		c.Record(store, errDown)
+		c.Record(store, nil)
+		c.Record(store, errDown)
+		c.Record(store, errDown)
+		assert.NoError(t, c.Allow(store), "a success resets the failures")
+		c.Record(store, errDown)
+		assertUnavailable(t, c.Allow(store), time.Minute)
+		assert.Equal(t, []BreakerState{BreakerOpen}, states)
+	})
+
+	t.Run("missing secrets are no failures", func(t *testing.T) {
+		var states []BreakerState
+		c := newBreakers(&states)
+		store := newStore()
+		for range 5 {
+			c.Record(store, esv1beta1.NoSecretError{})
+		}
+		assert.NoError(t, c.Allow(store))
+		assert.Empty(t, states)
+	})
+
+	t.Run("rate limits and timeouts are failures", func(t *testing.T) {
+		var states []BreakerState
+		c := newBreakers(&states)
+		store := newStore()
+		c.Record(store, &esv1beta1.ProviderError{Reason: esv1beta1.ProviderErrorRateLimited, Err: errors.New("too many requests")})
+		c.Record(store, fmt.Errorf("get secret: %w", context.DeadlineExceeded))
+		c.Record(store, errDown)
+		assertUnavailable(t, c.Allow(store), time.Minute)
+		assert.Equal(t, []BreakerState{BreakerOpen}, states)
+	})
+
+	t.Run("per-secret errors never open the breaker", func(t *testing.T) {
+		var states []BreakerState
+		c := newBreakers(&states)
+		store := newStore()
+		perSecretErrs := []error{
+			errors.New("key \"password\" does not exist in secret"),
+			errors.New("unable to decode base64 value"),
+			&esv1beta1.ProviderError{Reason: esv1beta1.ProviderErrorPermissionDenied, Err: errors.New("403 forbidden")},
+			&esv1beta1.ProviderError{Reason: esv1beta1.ProviderErrorNotFound, Err: errors.New("404 not found")},
+			&esv1beta1.ProviderError{Reason: esv1beta1.ProviderErrorUnauthenticated, Err: errors.New("401 unauthorized")},
+		}
+		for range 5 {
+			for _, err := range perSecretErrs {
+				c.Record(store, err)
+			}
+		}
+		assert.NoError(t, c.Allow(store))
+		c.Record(store, errDown)
+		c.Record(store, errDown)
+		c.Record(store, perSecretErrs[2])
+		c.Record(store, errDown)
+		assert.NoError(t, c.Allow(store), "a per-secret error shows the provider is reachable")
+		assert.Empty(t, states)
+	})
+
+	t.Run("half-open probe closes on success", func(t *testing.T) {
+		var states []BreakerState
+		c := newBreakers(&states)
+		store := newStore()
+		for range 3 {
+			c.Record(store, errDown)
+		}
+		now = now.Add(40 * time.Second)
+		assertUnavailable(t, c.Allow(store), 20*time.Second)
+
+		now = now.Add(20 * time.Second)
+		assert.NoError(t, c.Allow(store), "the probe is let through")
+		assertUnavailable(t, c.Allow(store), time.Minute)
+		c.Record(store, nil)
+		assert.NoError(t, c.Allow(store))
+		assert.Equal(t, []BreakerState{BreakerOpen, BreakerHalfOpen, BreakerClosed}, states)
+	})
+
+	t.Run("half-open probe opens again on failure", func(t *testing.T) {
+		var states []BreakerState
+		c := newBreakers(&states)
+		store := newStore()
+		for range 3 {
+			c.Record(store, errDown)
+		}
+		now = now.Add(time.Minute)
+		assert.NoError(t, c.Allow(store))
+		c.Record(store, errDown)
+		assertUnavailable(t, c.Allow(store), time.Minute)
+		assert.Equal(t, []BreakerState{BreakerOpen, BreakerHalfOpen, BreakerOpen}, states)
+	})
+
+	t.Run("store change closes the breaker", func(t *testing.T) {
+		var states []BreakerState
+		c := newBreakers(&states)
+		store := newStore()
+		for range 3 {
+			c.Record(store, errDown)
+		}
+		store.Generation++
+		assert.NoError(t, c.Allow(store))
+		assert.Equal(t, []BreakerState{BreakerOpen, BreakerClosed}, states)
+	})
+}
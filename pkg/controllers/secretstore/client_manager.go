@@ -18,18 +18,17 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"regexp"
 	"strings"
+	"time"
 
 	"github.com/go-logr/logr"
 	v1 "k8s.io/api/core/v1"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	"github.com/external-secrets/external-secrets/pkg/utils/resolvers"
 )
 
 const (
@@ -37,8 +36,31 @@ const (
 	errGetSecretStore        = "could not get SecretStore %q, %w"
 	errSecretStoreNotReady   = "%s %q is not ready"
 	errClusterStoreMismatch  = "using cluster store %q is not allowed from namespace %q: denied by spec.condition"
+	errStoreExpired          = "using %s %q is not allowed: expired at %s by spec.expiresAt"
 )
 
+// StoreDeniedError is returned by Manager.Get if a store must not be used,
+// because spec.conditions exclude the namespace or spec.expiresAt has passed.
+type StoreDeniedError struct {
+	Store     esv1beta1.GenericStore
+	Namespace string
+	// Expired is true if the store is denied by spec.expiresAt.
+	Expired bool
+}
+
+func (e *StoreDeniedError) Error() string {
+	if e.Expired {
+		return fmt.Sprintf(errStoreExpired, e.Store.GetKind(), e.Store.GetName(), e.Store.GetSpec().ExpiresAt.UTC().Format(time.RFC3339))
+	}
+	return fmt.Sprintf(errClusterStoreMismatch, e.Store.GetName(), e.Namespace)
+}
+
+// IsStoreExpired returns true if spec.expiresAt of the store is not after now.
+func IsStoreExpired(store esv1beta1.GenericStore, now time.Time) bool {
+	expiresAt := store.GetSpec().ExpiresAt
+	return expiresAt != nil && !now.Before(expiresAt.Time)
+}
+
 // Manager stores instances of provider clients
 // At any given time we must have no more than one instance
 // of a client (due to limitations in GCP / see mutexlock there)
@@ -49,6 +71,8 @@ type Manager struct {
 	client          client.Client
 	controllerClass string
 	enableFloodgate bool
+	breakers        *CircuitBreakers
+	classifyErrors  bool
 
 	// store clients by provider type
 	clientMap map[clientKey]*clientVal
@@ -75,6 +99,21 @@ func NewManager(ctrlClient client.Client, controllerClass string, enableFloodgat
 	}
 }
 
+// WithCircuitBreakers makes Get reject stores with an open circuit breaker
+// and report the result of all reads of the returned clients to the breakers.
+func (m *Manager) WithCircuitBreakers(breakers *CircuitBreakers) *Manager {
+	m.breakers = breakers
+	return m
+}
+
+// WithErrorClassification makes Get classify the errors of providers that implement
+// esv1beta1.ErrorClassifier. The returned clients do not implement optional interfaces
+// of the provider, e.g. esv1beta1.AtomicPushSecretClient.
+func (m *Manager) WithErrorClassification() *Manager {
+	m.classifyErrors = true
+	return m
+}
+
 func (m *Manager) GetFromStore(ctx context.Context, store esv1beta1.GenericStore, namespace string) (esv1beta1.SecretsClient, error) {
 	storeProvider, err := esv1beta1.GetProvider(store)
 	if err != nil {
@@ -123,7 +162,10 @@ func (m *Manager) Get(ctx context.Context, storeRef esv1beta1.SecretStoreRef, na
 		return nil, err
 	}
 	if !shouldProcess {
-		return nil, fmt.Errorf(errClusterStoreMismatch, store.GetName(), namespace)
+		return nil, &StoreDeniedError{Store: store, Namespace: namespace}
+	}
+	if IsStoreExpired(store, time.Now()) {
+		return nil, &StoreDeniedError{Store: store, Namespace: namespace, Expired: true}
 	}
 
 	if m.enableFloodgate {
@@ -132,7 +174,18 @@ func (m *Manager) Get(ctx context.Context, storeRef esv1beta1.SecretStoreRef, na
 			return nil, err
 		}
 	}
-	return m.GetFromStore(ctx, store, namespace)
+	if m.breakers == nil {
+		return m.getClassifiedFromStore(ctx, store, namespace)
+	}
+	if err := m.breakers.Allow(store); err != nil {
+		return nil, err
+	}
+	secretClient, err := m.getClassifiedFromStore(ctx, store, namespace)
+	if err != nil {
+		m.breakers.Record(store, err)
+		return nil, err
+	}
+	return &breakerClient{SecretsClient: secretClient, breakers: m.breakers, store: store}, nil
 }
 
 // returns a previously stored client from the cache if store and store-version match
@@ -211,57 +264,7 @@ func (m *Manager) Close(ctx context.Context) error {
 }
 
 func (m *Manager) shouldProcessSecret(store esv1beta1.GenericStore, ns string) (bool, error) {
-	if store.GetKind() != esv1beta1.ClusterSecretStoreKind {
-		return true, nil
-	}
-
-	if len(store.GetSpec().Conditions) == 0 {
-		return true, nil
-	}
-
-	namespace := v1.Namespace{}
-	if err := m.client.Get(context.Background(), client.ObjectKey{Name: ns}, &namespace); err != nil {
-		return false, fmt.Errorf("failed to get a namespace %q: %w", ns, err)
-	}
-
-	nsLabels := labels.Set(namespace.GetLabels())
-	for _, condition := range store.GetSpec().Conditions {
-		var labelSelectors []*metav1.LabelSelector
-		if condition.NamespaceSelector != nil {
-			labelSelectors = append(labelSelectors, condition.NamespaceSelector)
-		}
-		for _, n := range condition.Namespaces {
-			labelSelectors = append(labelSelectors, &metav1.LabelSelector{
-				MatchLabels: map[string]string{
-					"kubernetes.io/metadata.name": n,
-				},
-			})
-		}
-
-		for _, ls := range labelSelectors {
-			selector, err := metav1.LabelSelectorAsSelector(ls)
-			if err != nil {
-				return false, fmt.Errorf("failed to convert label selector into selector %v: %w", ls, err)
-			}
-			if selector.Matches(nsLabels) {
-				return true, nil
-			}
-		}
-
-		for _, reg := range condition.NamespaceRegexes {
-			match, err := regexp.MatchString(reg, ns)
-			if err != nil {
-				// Should not happen since store validation already verified the regexes.
-				return false, fmt.Errorf("failed to compile regex %v: %w", reg, err)
-			}
-
-			if match {
-				return true, nil
-			}
-		}
-	}
-
-	return false, nil
+	return resolvers.StoreAllowsNamespace(context.Background(), m.client, store, ns)
 }
 
 // assertStoreIsUsable assert that the store is ready to use.
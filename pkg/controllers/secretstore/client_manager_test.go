@@ -17,6 +17,7 @@ package secretstore
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/stretchr/testify/assert"
@@ -93,6 +94,14 @@ func TestManagerGet(t *testing.T) {
 		Status: readyStatus,
 	}
 
+	expiredStore := defaultStore.DeepCopy()
+	expiredStore.Name = "expired"
+	expiredStore.Spec.ExpiresAt = &metav1.Time{Time: time.Now().Add(-time.Minute)}
+
+	expiringStore := defaultStore.DeepCopy()
+	expiringStore.Name = "expiring"
+	expiringStore.Spec.ExpiresAt = &metav1.Time{Time: time.Now().Add(time.Hour)}
+
 	var mgr *Manager
 
 	provKey := clientKey{
@@ -121,6 +130,8 @@ func TestManagerGet(t *testing.T) {
 		afterClose func()
 		want       esv1beta1.SecretsClient
 		wantErr    bool
+		// wantExpired expects a StoreDeniedError for spec.expiresAt
+		wantExpired bool
 	}{
 		{
 			name:    "creates a new client from storeRef and stores it",
@@ -291,6 +302,63 @@ func TestManagerGet(t *testing.T) {
 				assert.Nil(t, v)
 			},
 		},
+		{
+			name:        "rejects a store after spec.expiresAt",
+			wantErr:     true,
+			wantExpired: true,
+			fields: fields{
+				client: fakeclient.NewClientBuilder().
+					WithScheme(scheme).
+					WithObjects(expiredStore).
+					Build(),
+				clientMap: make(map[clientKey]*clientVal),
+			},
+			args: args{
+				storeRef: esv1beta1.SecretStoreRef{
+					Name: expiredStore.Name,
+					Kind: esv1beta1.SecretStoreKind,
+				},
+				namespace: expiredStore.Namespace,
+			},
+			clientConstructor: func(ctx context.Context, store esv1beta1.GenericStore, kube client.Client, namespace string) (esv1beta1.SecretsClient, error) {
+				// the credentials of an expired store must not be used
+				t.Fail()
+				return nil, nil
+			},
+			verify: func(sc esv1beta1.SecretsClient) {
+				assert.Nil(t, sc)
+			},
+			afterClose: func() {
+				assert.Empty(t, mgr.clientMap)
+			},
+		},
+		{
+			name:    "creates a new client before spec.expiresAt",
+			wantErr: false,
+			fields: fields{
+				client: fakeclient.NewClientBuilder().
+					WithScheme(scheme).
+					WithObjects(expiringStore).
+					Build(),
+				clientMap: make(map[clientKey]*clientVal),
+			},
+			args: args{
+				storeRef: esv1beta1.SecretStoreRef{
+					Name: expiringStore.Name,
+					Kind: esv1beta1.SecretStoreKind,
+				},
+				namespace: expiringStore.Namespace,
+			},
+			clientConstructor: func(ctx context.Context, store esv1beta1.GenericStore, kube client.Client, namespace string) (esv1beta1.SecretsClient, error) {
+				return clientA, nil
+			},
+			verify: func(sc esv1beta1.SecretsClient) {
+				assert.Same(t, sc, clientA)
+			},
+			afterClose: func() {
+				assert.True(t, clientA.closeCalled)
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -308,6 +376,11 @@ func TestManagerGet(t *testing.T) {
 				t.Errorf("Manager.Get() error = %v, wantErr %v", err, tt.wantErr)
 				return
 			}
+			if tt.wantExpired {
+				var denied *StoreDeniedError
+				require.ErrorAs(t, err, &denied)
+				assert.True(t, denied.Expired)
+			}
 			tt.verify(got)
 			mgr.Close(context.Background())
 			tt.afterClose()
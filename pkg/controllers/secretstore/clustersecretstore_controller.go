@@ -40,6 +40,8 @@ type ClusterStoreReconciler struct {
 	Log             logr.Logger
 	Scheme          *runtime.Scheme
 	ControllerClass string
+	// Watchers refresh the ExternalSecrets of the store once their secrets change, nil disables them.
+	Watchers        *StoreWatchers
 	RequeueInterval time.Duration
 	recorder        record.EventRecorder
 }
@@ -57,6 +59,7 @@ func (r *ClusterStoreReconciler) Reconcile(ctx context.Context, req ctrl.Request
 	err := r.Get(ctx, req.NamespacedName, &css)
 	if apierrors.IsNotFound(err) {
 		cssmetrics.RemoveMetrics(req.Namespace, req.Name)
+		r.Watchers.Stop(esapi.ClusterSecretStoreKind, req.NamespacedName)
 		return ctrl.Result{}, nil
 	} else if err != nil {
 		log.Error(err, "unable to get ClusterSecretStore")
@@ -68,6 +71,7 @@ func (r *ClusterStoreReconciler) Reconcile(ctx context.Context, req ctrl.Request
 		GaugeVecGetter:  cssmetrics.GetGaugeVec,
 		Recorder:        r.recorder,
 		RequeueInterval: r.RequeueInterval,
+		Watchers:        r.Watchers,
 	})
 }
 
@@ -75,6 +79,11 @@ func (r *ClusterStoreReconciler) Reconcile(ctx context.Context, req ctrl.Request
 func (r *ClusterStoreReconciler) SetupWithManager(mgr ctrl.Manager, opts controller.Options) error {
 	r.recorder = mgr.GetEventRecorderFor("cluster-secret-store")
 
+	// index ExternalSecrets by the stores they reference, to refresh them with the force-sync annotation
+	if err := indexExternalSecretsByStore(mgr, esapi.ClusterSecretStoreKind); err != nil {
+		return err
+	}
+
 	return ctrl.NewControllerManagedBy(mgr).
 		WithOptions(opts).
 		For(&esapi.ClusterSecretStore{}).
@@ -16,11 +16,13 @@ package secretstore
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
 	"github.com/go-logr/logr"
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -37,6 +39,7 @@ const (
 	errUnableValidateStore = "unable to validate store: %s"
 
 	msgStoreValidated = "store validated"
+	msgStoreExpired   = "store expired at %s, it can not be used anymore"
 )
 
 type Opts struct {
@@ -44,11 +47,14 @@ type Opts struct {
 	GaugeVecGetter  metrics.GaugeVevGetter
 	Recorder        record.EventRecorder
 	RequeueInterval time.Duration
+	Watchers        *StoreWatchers
 }
 
 func reconcile(ctx context.Context, req ctrl.Request, ss esapi.GenericStore, cl client.Client, log logr.Logger, opts Opts) (ctrl.Result, error) {
+	storeName := types.NamespacedName{Namespace: ss.GetNamespace(), Name: ss.GetName()}
 	if !ShouldProcessStore(ss, opts.ControllerClass) {
 		log.V(1).Info("skip store")
+		opts.Watchers.Stop(ss.GetKind(), storeName)
 		return ctrl.Result{}, nil
 	}
 
@@ -58,6 +64,13 @@ func reconcile(ctx context.Context, req ctrl.Request, ss esapi.GenericStore, cl
 		requeueInterval = time.Second * time.Duration(ss.GetSpec().RefreshInterval)
 	}
 
+	// refresh the ExternalSecrets using the store before validating it,
+	// so they pick up rotated credentials even if the store is not valid yet
+	if err := forceSyncExternalSecrets(ctx, ss, cl, opts.Recorder, log); err != nil {
+		log.Error(err, "unable to force sync ExternalSecrets")
+		return ctrl.Result{}, err
+	}
+
 	// patch status when done processing
 	p := client.MergeFrom(ss.Copy())
 	defer func() {
@@ -67,12 +80,29 @@ func reconcile(ctx context.Context, req ctrl.Request, ss esapi.GenericStore, cl
 		}
 	}()
 
+	// an expired store is not validated anymore, so its credentials are not used after spec.expiresAt
+	now := time.Now()
+	if IsStoreExpired(ss, now) {
+		log.V(1).Info("skip expired store")
+		msg := fmt.Sprintf(msgStoreExpired, ss.GetSpec().ExpiresAt.UTC().Format(time.RFC3339))
+		cond := NewSecretStoreCondition(esapi.SecretStoreReady, v1.ConditionFalse, esapi.ReasonStoreExpired, msg)
+		SetExternalSecretCondition(ss, *cond, opts.GaugeVecGetter)
+		opts.Recorder.Event(ss, v1.EventTypeWarning, esapi.ReasonStoreExpired, msg)
+		opts.Watchers.Stop(ss.GetKind(), storeName)
+		return ctrl.Result{}, nil
+	}
+	// check the store again when it expires to report the condition
+	if expiresAt := ss.GetSpec().ExpiresAt; expiresAt != nil && expiresAt.Sub(now) < requeueInterval {
+		requeueInterval = expiresAt.Sub(now)
+	}
+
 	// validateStore modifies the store conditions
 	// we have to patch the status
 	log.V(1).Info("validating")
 	err := validateStore(ctx, req.Namespace, opts.ControllerClass, ss, cl, opts.GaugeVecGetter, opts.Recorder)
 	if err != nil {
 		log.Error(err, "unable to validate store")
+		opts.Watchers.Stop(ss.GetKind(), storeName)
 		return ctrl.Result{}, err
 	}
 	storeProvider, err := esapi.GetProvider(ss)
@@ -89,6 +119,9 @@ func reconcile(ctx context.Context, req ctrl.Request, ss esapi.GenericStore, cl
 	cond := NewSecretStoreCondition(esapi.SecretStoreReady, v1.ConditionTrue, esapi.ReasonStoreValid, msgStoreValidated)
 	SetExternalSecretCondition(ss, *cond, opts.GaugeVecGetter)
 
+	// refresh the ExternalSecrets as soon as their secrets change, if the provider supports it
+	opts.Watchers.Ensure(ss)
+
 	return ctrl.Result{
 		RequeueAfter: requeueInterval,
 	}, err
@@ -102,22 +135,34 @@ func validateStore(ctx context.Context, namespace, controllerClass string, store
 	defer mgr.Close(ctx)
 	cl, err := mgr.GetFromStore(ctx, store, namespace)
 	if err != nil {
-		cond := NewSecretStoreCondition(esapi.SecretStoreReady, v1.ConditionFalse, esapi.ReasonInvalidProviderConfig, errUnableCreateClient)
+		reason, msg := failureReason(err, esapi.ReasonInvalidProviderConfig, errUnableCreateClient)
+		cond := NewSecretStoreCondition(esapi.SecretStoreReady, v1.ConditionFalse, reason, msg)
 		SetExternalSecretCondition(store, *cond, gaugeVecGetter)
-		recorder.Event(store, v1.EventTypeWarning, esapi.ReasonInvalidProviderConfig, err.Error())
+		recorder.Event(store, v1.EventTypeWarning, reason, err.Error())
 		return fmt.Errorf(errStoreClient, err)
 	}
 	validationResult, err := cl.Validate()
 	if err != nil && validationResult != esapi.ValidationResultUnknown {
-		cond := NewSecretStoreCondition(esapi.SecretStoreReady, v1.ConditionFalse, esapi.ReasonValidationFailed, fmt.Sprintf(errUnableValidateStore, err))
+		reason, msg := failureReason(err, esapi.ReasonValidationFailed, fmt.Sprintf(errUnableValidateStore, err))
+		cond := NewSecretStoreCondition(esapi.SecretStoreReady, v1.ConditionFalse, reason, msg)
 		SetExternalSecretCondition(store, *cond, gaugeVecGetter)
-		recorder.Event(store, v1.EventTypeWarning, esapi.ReasonValidationFailed, err.Error())
+		recorder.Event(store, v1.EventTypeWarning, reason, err.Error())
 		return fmt.Errorf(errValidationFailed, err)
 	}
 
 	return nil
 }
 
+// failureReason returns the reason and message of a failed store.
+// A TLS pin mismatch is reported with its own reason and the error,
+// as it may indicate an attack and must not be mistaken for a misconfiguration.
+func failureReason(err error, reason, msg string) (string, string) {
+	if errors.Is(err, esapi.ErrTLSPinMismatch) {
+		return esapi.ReasonTLSPinMismatch, err.Error()
+	}
+	return reason, msg
+}
+
 // ShouldProcessStore returns true if the store should be processed.
 func ShouldProcessStore(store esapi.GenericStore, class string) bool {
 	if store == nil || store.GetSpec().Controller == "" || store.GetSpec().Controller == class {
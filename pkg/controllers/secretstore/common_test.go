@@ -158,6 +158,40 @@ var _ = Describe("SecretStore reconcile", func() {
 
 	}
 
+	// an expired store is not validated and reports why it can not be used
+	expiredStore := func(tc *testCase) {
+		spc := tc.store.GetSpec()
+		spc.Provider.Vault = nil
+		spc.Provider.Fake = &esapi.FakeProvider{
+			Data: []esapi.FakeProviderData{},
+		}
+		spc.ExpiresAt = &metav1.Time{Time: time.Now().Add(-time.Minute)}
+
+		tc.assert = func() {
+			Eventually(func() bool {
+				ss := tc.store.Copy()
+				err := k8sClient.Get(context.Background(), types.NamespacedName{
+					Name:      defaultStoreName,
+					Namespace: ss.GetNamespace(),
+				}, ss)
+				if err != nil {
+					return false
+				}
+
+				if len(ss.GetStatus().Conditions) != 1 {
+					return false
+				}
+
+				return ss.GetStatus().Conditions[0].Reason == esapi.ReasonStoreExpired &&
+					ss.GetStatus().Conditions[0].Status == corev1.ConditionFalse &&
+					hasEvent(tc.store.GetTypeMeta().Kind, ss.GetName(), esapi.ReasonStoreExpired)
+			}).
+				WithTimeout(time.Second * 10).
+				WithPolling(time.Second).
+				Should(BeTrue())
+		}
+	}
+
 	DescribeTable("Controller Reconcile logic", func(muts ...func(tc *testCase)) {
 		for _, mut := range muts {
 			mut(test)
@@ -171,12 +205,14 @@ var _ = Describe("SecretStore reconcile", func() {
 		Entry("[namespace] ignore stores with non-matching class", ignoreControllerClass),
 		Entry("[namespace] valid provider has status=ready", validProvider),
 		Entry("[namespace] valid provider has capabilities=ReadWrite", readWrite),
+		Entry("[namespace] expired store has status=expired", expiredStore),
 
 		// cluster store
 		Entry("[cluster] invalid provider with secretStore should set InvalidStore condition", invalidProvider, useClusterStore),
 		Entry("[cluster] ignore stores with non-matching class", ignoreControllerClass, useClusterStore),
 		Entry("[cluster] valid provider has status=ready", validProvider, useClusterStore),
 		Entry("[cluster] valid provider has capabilities=ReadWrite", readWrite, useClusterStore),
+		Entry("[cluster] expired store has status=expired", expiredStore, useClusterStore),
 	)
 
 })
@@ -0,0 +1,62 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secretstore
+
+import (
+	"context"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+)
+
+// getClassifiedFromStore returns the client of the store,
+// which classifies its errors if the manager was created WithErrorClassification.
+func (m *Manager) getClassifiedFromStore(ctx context.Context, store esv1beta1.GenericStore, namespace string) (esv1beta1.SecretsClient, error) {
+	secretClient, err := m.GetFromStore(ctx, store, namespace)
+	if !m.classifyErrors {
+		return secretClient, err
+	}
+	provider, providerErr := esv1beta1.GetProvider(store)
+	if providerErr != nil {
+		return secretClient, err
+	}
+	if err != nil {
+		return nil, esv1beta1.ClassifyError(provider, err)
+	}
+	if _, ok := provider.(esv1beta1.ErrorClassifier); !ok {
+		return secretClient, nil
+	}
+	return &classifyingClient{SecretsClient: secretClient, provider: provider}, nil
+}
+
+// classifyingClient classifies the errors of every read with the ErrorClassifier of its provider.
+type classifyingClient struct {
+	esv1beta1.SecretsClient
+	provider esv1beta1.Provider
+}
+
+func (c *classifyingClient) GetSecret(ctx context.Context, ref esv1beta1.ExternalSecretDataRemoteRef) ([]byte, error) {
+	data, err := c.SecretsClient.GetSecret(ctx, ref)
+	return data, esv1beta1.ClassifyError(c.provider, err)
+}
+
+func (c *classifyingClient) GetSecretMap(ctx context.Context, ref esv1beta1.ExternalSecretDataRemoteRef) (map[string][]byte, error) {
+	data, err := c.SecretsClient.GetSecretMap(ctx, ref)
+	return data, esv1beta1.ClassifyError(c.provider, err)
+}
+
+func (c *classifyingClient) GetAllSecrets(ctx context.Context, ref esv1beta1.ExternalSecretFind) (map[string][]byte, error) {
+	data, err := c.SecretsClient.GetAllSecrets(ctx, ref)
+	return data, esv1beta1.ClassifyError(c.provider, err)
+}
@@ -0,0 +1,139 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secretstore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+)
+
+var errDenied = errors.New("denied")
+
+type classifyingWrapProvider struct {
+	WrapProvider
+}
+
+func (p *classifyingWrapProvider) ClassifyError(err error) esv1beta1.ProviderErrorReason {
+	if errors.Is(err, errDenied) {
+		return esv1beta1.ProviderErrorPermissionDenied
+	}
+	return ""
+}
+
+type deniedClient struct {
+	MockFakeClient
+}
+
+func (c *deniedClient) GetSecret(_ context.Context, _ esv1beta1.ExternalSecretDataRemoteRef) ([]byte, error) {
+	return nil, errDenied
+}
+
+func TestManagerErrorClassification(t *testing.T) {
+	scheme := runtime.NewScheme()
+	utilruntime.Must(esv1beta1.AddToScheme(scheme))
+
+	provider := &classifyingWrapProvider{}
+	esv1beta1.ForceRegister(provider, &esv1beta1.SecretStoreProvider{
+		Fake: &esv1beta1.FakeProvider{},
+	})
+	store := &esv1beta1.SecretStore{
+		TypeMeta:   metav1.TypeMeta{Kind: esv1beta1.SecretStoreKind},
+		ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "default"},
+		Spec: esv1beta1.SecretStoreSpec{
+			Provider: &esv1beta1.SecretStoreProvider{Fake: &esv1beta1.FakeProvider{}},
+		},
+		Status: esv1beta1.SecretStoreStatus{
+			Conditions: []esv1beta1.SecretStoreStatusCondition{
+				{Type: esv1beta1.SecretStoreReady, Status: corev1.ConditionTrue},
+			},
+		},
+	}
+	storeRef := esv1beta1.SecretStoreRef{Name: "foo", Kind: esv1beta1.SecretStoreKind}
+	reasonOf := func(err error) esv1beta1.ProviderErrorReason {
+		var providerErr *esv1beta1.ProviderError
+		if errors.As(err, &providerErr) {
+			return providerErr.Reason
+		}
+		return ""
+	}
+
+	tests := []struct {
+		name       string
+		classify   bool
+		newErr     error
+		wantReason esv1beta1.ProviderErrorReason
+	}{
+		{
+			name: "errors are not classified by default",
+		},
+		{
+			name:       "errors of reads are classified",
+			classify:   true,
+			wantReason: esv1beta1.ProviderErrorPermissionDenied,
+		},
+		{
+			name:       "errors of the client constructor are classified",
+			classify:   true,
+			newErr:     errDenied,
+			wantReason: esv1beta1.ProviderErrorPermissionDenied,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider.newClientFunc = func(context.Context, esv1beta1.GenericStore, client.Client, string) (esv1beta1.SecretsClient, error) {
+				if tt.newErr != nil {
+					return nil, tt.newErr
+				}
+				return &deniedClient{}, nil
+			}
+			kube := fakeclient.NewClientBuilder().WithScheme(scheme).WithObjects(store.DeepCopy()).Build()
+			mgr := NewManager(kube, "", false)
+			if tt.classify {
+				mgr = mgr.WithErrorClassification()
+			}
+			secretClient, err := mgr.Get(context.Background(), storeRef, "default", nil)
+			if tt.newErr == nil {
+				require.NoError(t, err)
+				_, err = secretClient.GetSecret(context.Background(), esv1beta1.ExternalSecretDataRemoteRef{Key: "foo"})
+			}
+			assert.ErrorIs(t, err, errDenied)
+			assert.Equal(t, tt.wantReason, reasonOf(err))
+		})
+	}
+}
+
+func TestFailureReason(t *testing.T) {
+	reason, msg := failureReason(errDenied, esv1beta1.ReasonValidationFailed, "unable to validate store")
+	assert.Equal(t, esv1beta1.ReasonValidationFailed, reason)
+	assert.Equal(t, "unable to validate store", msg)
+
+	pinErr := fmt.Errorf("Get %q: %w", "https://vault.example.com", esv1beta1.ErrTLSPinMismatch)
+	reason, msg = failureReason(pinErr, esv1beta1.ReasonValidationFailed, "unable to validate store")
+	assert.Equal(t, esv1beta1.ReasonTLSPinMismatch, reason)
+	assert.Equal(t, pinErr.Error(), msg)
+}
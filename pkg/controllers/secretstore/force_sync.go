@@ -0,0 +1,142 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secretstore
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	esapi "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+)
+
+const (
+	// indexESSecretStoreRefField indexes ExternalSecrets by the names of the SecretStores they reference.
+	indexESSecretStoreRefField = ".spec.secretStoreRefs"
+	// indexESClusterSecretStoreRefField indexes ExternalSecrets by the names of the ClusterSecretStores they reference.
+	indexESClusterSecretStoreRefField = ".spec.clusterSecretStoreRefs"
+
+	errListExternalSecrets = "could not list ExternalSecrets using the store: %w"
+	errForceSyncES         = "could not force sync ExternalSecret %s/%s: %w"
+	errClearForceSync      = "could not remove the force-sync annotation: %w"
+
+	msgForceSynced = "force sync of %d ExternalSecrets using the store"
+)
+
+// indexExternalSecretsByStore registers an index of ExternalSecrets by the stores of the given kind they reference.
+func indexExternalSecretsByStore(mgr ctrl.Manager, kind string) error {
+	return mgr.GetFieldIndexer().IndexField(context.Background(), &esapi.ExternalSecret{}, storeRefIndexField(kind), func(obj client.Object) []string {
+		return storeRefNames(obj.(*esapi.ExternalSecret), kind)
+	})
+}
+
+func storeRefIndexField(kind string) string {
+	if kind == esapi.ClusterSecretStoreKind {
+		return indexESClusterSecretStoreRefField
+	}
+	return indexESSecretStoreRefField
+}
+
+// storeRefNames returns the unique names of the stores of the given kind referenced by the ExternalSecret.
+func storeRefNames(es *esapi.ExternalSecret, kind string) []string {
+	refs := []esapi.SecretStoreRef{es.Spec.SecretStoreRef}
+	for _, data := range es.Spec.Data {
+		if data.SourceRef != nil {
+			if data.SourceRef.SecretStoreRef != nil {
+				refs = append(refs, *data.SourceRef.SecretStoreRef)
+			}
+			refs = append(refs, data.SourceRef.StoreGroup...)
+		}
+	}
+	for _, dataFrom := range es.Spec.DataFrom {
+		if dataFrom.SourceRef != nil {
+			if dataFrom.SourceRef.SecretStoreRef != nil {
+				refs = append(refs, *dataFrom.SourceRef.SecretStoreRef)
+			}
+			refs = append(refs, dataFrom.SourceRef.StoreGroup...)
+		}
+	}
+	if es.Spec.Shadow != nil {
+		refs = append(refs, es.Spec.Shadow.StoreRef)
+	}
+
+	var names []string
+	seen := make(map[string]bool)
+	for _, ref := range refs {
+		refKind := ref.Kind
+		if refKind == "" {
+			refKind = esapi.SecretStoreKind
+		}
+		if ref.Name == "" || refKind != kind || seen[ref.Name] {
+			continue
+		}
+		seen[ref.Name] = true
+		names = append(names, ref.Name)
+	}
+	return names
+}
+
+// forceSyncExternalSecrets refreshes every ExternalSecret using the store if the store has the
+// force-sync annotation, by setting the force-sync annotation of the ExternalSecrets.
+// The annotation of the store is removed afterwards, so the ExternalSecrets are only refreshed once.
+// ExternalSecrets of a ClusterSecretStore are refreshed in all namespaces.
+func forceSyncExternalSecrets(ctx context.Context, store esapi.GenericStore, cl client.Client, recorder record.EventRecorder, log logr.Logger) error {
+	if _, ok := store.GetAnnotations()[esapi.AnnotationStoreForceSync]; !ok {
+		return nil
+	}
+
+	listOpts := []client.ListOption{
+		client.MatchingFields{storeRefIndexField(store.GetKind()): store.GetName()},
+	}
+	if store.GetKind() == esapi.SecretStoreKind {
+		listOpts = append(listOpts, client.InNamespace(store.GetNamespace()))
+	}
+	var esList esapi.ExternalSecretList
+	if err := cl.List(ctx, &esList, listOpts...); err != nil {
+		return fmt.Errorf(errListExternalSecrets, err)
+	}
+
+	// every bump of the store results in a new value, even if the store annotation is set to the same value again
+	value := time.Now().UTC().Format(time.RFC3339Nano)
+	for i := range esList.Items {
+		es := &esList.Items[i]
+		patch := client.MergeFrom(es.DeepCopy())
+		if es.Annotations == nil {
+			es.Annotations = make(map[string]string)
+		}
+		es.Annotations[esapi.AnnotationForceSync] = value
+		if err := cl.Patch(ctx, es, patch); err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf(errForceSyncES, es.Namespace, es.Name, err)
+		}
+	}
+	log.V(1).Info("force synced ExternalSecrets", "count", len(esList.Items))
+	recorder.Eventf(store, v1.EventTypeNormal, esapi.ReasonForceSynced, msgForceSynced, len(esList.Items))
+
+	patch := client.MergeFrom(store.Copy())
+	annotations := store.GetAnnotations()
+	delete(annotations, esapi.AnnotationStoreForceSync)
+	store.SetAnnotations(annotations)
+	if err := cl.Patch(ctx, store, patch); err != nil {
+		return fmt.Errorf(errClearForceSync, err)
+	}
+	return nil
+}
@@ -0,0 +1,146 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secretstore
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+)
+
+func TestForceSyncExternalSecrets(t *testing.T) {
+	scheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(esv1beta1.AddToScheme(scheme))
+
+	newES := func(namespace, name string, spec esv1beta1.ExternalSecretSpec) *esv1beta1.ExternalSecret {
+		return &esv1beta1.ExternalSecret{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+			Spec:       spec,
+		}
+	}
+	storeRef := func(kind, name string) *esv1beta1.SecretStoreRef {
+		return &esv1beta1.SecretStoreRef{Kind: kind, Name: name}
+	}
+	externalSecrets := []client.Object{
+		newES("team-a", "default-ref", esv1beta1.ExternalSecretSpec{
+			SecretStoreRef: *storeRef("", "vault"),
+		}),
+		newES("team-a", "source-ref", esv1beta1.ExternalSecretSpec{
+			Data: []esv1beta1.ExternalSecretData{
+				{SourceRef: &esv1beta1.StoreSourceRef{SecretStoreRef: storeRef(esv1beta1.SecretStoreKind, "vault")}},
+			},
+		}),
+		newES("team-a", "store-group", esv1beta1.ExternalSecretSpec{
+			DataFrom: []esv1beta1.ExternalSecretDataFromRemoteRef{
+				{SourceRef: &esv1beta1.StoreGeneratorSourceRef{StoreGroup: []esv1beta1.SecretStoreRef{*storeRef("", "aws"), *storeRef("", "vault")}}},
+			},
+		}),
+		newES("team-a", "other-store", esv1beta1.ExternalSecretSpec{
+			SecretStoreRef: *storeRef("", "aws"),
+		}),
+		newES("team-b", "other-namespace", esv1beta1.ExternalSecretSpec{
+			SecretStoreRef: *storeRef("", "vault"),
+		}),
+		newES("team-b", "cluster-store", esv1beta1.ExternalSecretSpec{
+			SecretStoreRef: *storeRef(esv1beta1.ClusterSecretStoreKind, "vault"),
+		}),
+	}
+
+	tests := []struct {
+		name       string
+		store      esv1beta1.GenericStore
+		wantSynced []string
+	}{
+		{
+			name: "secret store",
+			store: &esv1beta1.SecretStore{
+				ObjectMeta: metav1.ObjectMeta{Name: "vault", Namespace: "team-a", Annotations: map[string]string{esv1beta1.AnnotationStoreForceSync: "rotated"}},
+			},
+			wantSynced: []string{"team-a/default-ref", "team-a/source-ref", "team-a/store-group"},
+		},
+		{
+			name: "cluster secret store",
+			store: &esv1beta1.ClusterSecretStore{
+				ObjectMeta: metav1.ObjectMeta{Name: "vault", Annotations: map[string]string{esv1beta1.AnnotationStoreForceSync: "rotated"}},
+			},
+			wantSynced: []string{"team-b/cluster-store"},
+		},
+		{
+			name: "no annotation",
+			store: &esv1beta1.SecretStore{
+				ObjectMeta: metav1.ObjectMeta{Name: "vault", Namespace: "team-a"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			kind := tt.store.GetKind()
+			cl := fakeclient.NewClientBuilder().
+				WithScheme(scheme).
+				WithObjects(append([]client.Object{tt.store}, externalSecrets...)...).
+				WithIndex(&esv1beta1.ExternalSecret{}, storeRefIndexField(kind), func(obj client.Object) []string {
+					return storeRefNames(obj.(*esv1beta1.ExternalSecret), kind)
+				}).
+				Build()
+
+			err := forceSyncExternalSecrets(context.Background(), tt.store, cl, record.NewFakeRecorder(10), logr.Discard())
+			require.NoError(t, err)
+
+			var esList esv1beta1.ExternalSecretList
+			require.NoError(t, cl.List(context.Background(), &esList))
+			var synced []string
+			for _, es := range esList.Items {
+				if _, ok := es.Annotations[esv1beta1.AnnotationForceSync]; ok {
+					synced = append(synced, es.Namespace+"/"+es.Name)
+				}
+			}
+			assert.ElementsMatch(t, tt.wantSynced, synced)
+
+			store := tt.store.Copy()
+			require.NoError(t, cl.Get(context.Background(), client.ObjectKeyFromObject(tt.store), store))
+			assert.NotContains(t, store.GetAnnotations(), esv1beta1.AnnotationStoreForceSync)
+		})
+	}
+}
+
+func TestStoreRefNames(t *testing.T) {
+	es := &esv1beta1.ExternalSecret{
+		Spec: esv1beta1.ExternalSecretSpec{
+			SecretStoreRef: esv1beta1.SecretStoreRef{Name: "vault"},
+			Data: []esv1beta1.ExternalSecretData{
+				{SourceRef: &esv1beta1.StoreSourceRef{SecretStoreRef: &esv1beta1.SecretStoreRef{Name: "vault", Kind: esv1beta1.SecretStoreKind}}},
+				{SourceRef: &esv1beta1.StoreSourceRef{StoreGroup: []esv1beta1.SecretStoreRef{{Name: "aws"}, {Name: "gcp", Kind: esv1beta1.ClusterSecretStoreKind}}}},
+			},
+			Shadow: &esv1beta1.ExternalSecretShadow{
+				StoreRef: esv1beta1.SecretStoreRef{Name: "vault-new", Kind: esv1beta1.ClusterSecretStoreKind},
+			},
+		},
+	}
+	assert.Equal(t, []string{"vault", "aws"}, storeRefNames(es, esv1beta1.SecretStoreKind))
+	assert.Equal(t, []string{"gcp", "vault-new"}, storeRefNames(es, esv1beta1.ClusterSecretStoreKind))
+}
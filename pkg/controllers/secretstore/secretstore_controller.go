@@ -42,6 +42,8 @@ type StoreReconciler struct {
 	recorder        record.EventRecorder
 	RequeueInterval time.Duration
 	ControllerClass string
+	// Watchers refresh the ExternalSecrets of the store once their secrets change, nil disables them.
+	Watchers *StoreWatchers
 }
 
 func (r *StoreReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
@@ -57,6 +59,7 @@ func (r *StoreReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl
 	err := r.Get(ctx, req.NamespacedName, &ss)
 	if apierrors.IsNotFound(err) {
 		ssmetrics.RemoveMetrics(req.Namespace, req.Name)
+		r.Watchers.Stop(esapi.SecretStoreKind, req.NamespacedName)
 		return ctrl.Result{}, nil
 	} else if err != nil {
 		log.Error(err, "unable to get SecretStore")
@@ -68,6 +71,7 @@ func (r *StoreReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl
 		GaugeVecGetter:  ssmetrics.GetGaugeVec,
 		Recorder:        r.recorder,
 		RequeueInterval: r.RequeueInterval,
+		Watchers:        r.Watchers,
 	})
 }
 
@@ -75,6 +79,11 @@ func (r *StoreReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl
 func (r *StoreReconciler) SetupWithManager(mgr ctrl.Manager, opts controller.Options) error {
 	r.recorder = mgr.GetEventRecorderFor("secret-store")
 
+	// index ExternalSecrets by the stores they reference, to refresh them with the force-sync annotation
+	if err := indexExternalSecretsByStore(mgr, esapi.SecretStoreKind); err != nil {
+		return err
+	}
+
 	return ctrl.NewControllerManagedBy(mgr).
 		WithOptions(opts).
 		For(&esapi.SecretStore{}).
@@ -0,0 +1,254 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secretstore
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	esapi "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+)
+
+const (
+	watchMinBackoff = 5 * time.Second
+	watchMaxBackoff = 5 * time.Minute
+	// a watch that ran for this long is considered healthy, so its backoff starts over.
+	watchHealthyAfter = time.Minute
+)
+
+// StoreWatchers subscribe to the change notifications of the stores whose provider implements
+// esapi.SecretWatcher, and enqueue the ExternalSecrets that use a changed secret.
+// The ExternalSecrets of other stores are only refreshed on their refresh interval.
+// A nil *StoreWatchers watches no store.
+type StoreWatchers struct {
+	client client.Client
+	log    logr.Logger
+	events chan event.GenericEvent
+
+	mu       sync.Mutex
+	ctx      context.Context
+	watchers map[watcherKey]*storeWatcher
+	changed  map[types.NamespacedName]struct{}
+}
+
+type watcherKey struct {
+	kind string
+	name types.NamespacedName
+}
+
+type storeWatcher struct {
+	store      esapi.GenericStore
+	generation int64
+	cancel     context.CancelFunc
+}
+
+// NewStoreWatchers returns the store watchers, they must be added to the manager
+// so the watches are started with it.
+func NewStoreWatchers(cl client.Client, log logr.Logger) *StoreWatchers {
+	return &StoreWatchers{
+		client:   cl,
+		log:      log,
+		events:   make(chan event.GenericEvent, 1024),
+		watchers: make(map[watcherKey]*storeWatcher),
+		changed:  make(map[types.NamespacedName]struct{}),
+	}
+}
+
+// Start starts the watches of the stores reconciled so far and stops all of them once ctx is done.
+func (w *StoreWatchers) Start(ctx context.Context) error {
+	w.mu.Lock()
+	w.ctx = ctx
+	for _, sw := range w.watchers {
+		w.run(sw)
+	}
+	w.mu.Unlock()
+
+	<-ctx.Done()
+	return nil
+}
+
+// Source returns the source of the ExternalSecrets to refresh, for the ExternalSecret controller.
+func (w *StoreWatchers) Source() source.Source {
+	return source.Channel(w.events, &handler.EnqueueRequestForObject{})
+}
+
+// Ensure watches the store if its provider implements esapi.SecretWatcher.
+// A running watch is restarted if the store changed.
+func (w *StoreWatchers) Ensure(store esapi.GenericStore) {
+	if w == nil {
+		return
+	}
+	provider, err := esapi.GetProvider(store)
+	if _, ok := provider.(esapi.SecretWatcher); err != nil || !ok {
+		w.Stop(store.GetKind(), types.NamespacedName{Namespace: store.GetNamespace(), Name: store.GetName()})
+		return
+	}
+	key := watcherKey{kind: store.GetKind(), name: types.NamespacedName{Namespace: store.GetNamespace(), Name: store.GetName()}}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if sw, ok := w.watchers[key]; ok {
+		if sw.generation == store.GetGeneration() {
+			return
+		}
+		sw.cancel()
+	}
+	sw := &storeWatcher{store: store.Copy(), generation: store.GetGeneration(), cancel: func() {}}
+	w.watchers[key] = sw
+	if w.ctx != nil {
+		w.run(sw)
+	}
+}
+
+// Stop stops the watch of the store, e.g. once it was deleted.
+func (w *StoreWatchers) Stop(kind string, name types.NamespacedName) {
+	if w == nil {
+		return
+	}
+	key := watcherKey{kind: kind, name: name}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if sw, ok := w.watchers[key]; ok {
+		sw.cancel()
+		delete(w.watchers, key)
+	}
+}
+
+// TakeChanged returns true if a secret of the ExternalSecret changed since the last call.
+func (w *StoreWatchers) TakeChanged(name types.NamespacedName) bool {
+	if w == nil {
+		return false
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	_, ok := w.changed[name]
+	delete(w.changed, name)
+	return ok
+}
+
+// run starts the watch of the store, w.mu must be held.
+func (w *StoreWatchers) run(sw *storeWatcher) {
+	ctx, cancel := context.WithCancel(w.ctx)
+	sw.cancel = cancel
+	go w.watch(ctx, sw.store)
+}
+
+// watch calls the provider until ctx is done, failed watches are retried with a backoff.
+func (w *StoreWatchers) watch(ctx context.Context, store esapi.GenericStore) {
+	log := w.log.WithValues("kind", store.GetKind(), "store", store.GetNamespacedName())
+	provider, err := esapi.GetProvider(store)
+	if err != nil {
+		log.Error(err, "unable to watch store")
+		return
+	}
+	// the watch of a ClusterSecretStore is not bound to the namespace of an ExternalSecret
+	namespace := ""
+	if store.GetKind() == esapi.SecretStoreKind {
+		namespace = store.GetNamespace()
+	}
+
+	backoff := watchMinBackoff
+	for {
+		start := time.Now()
+		err := esapi.WatchSecrets(ctx, provider, store, w.client, namespace, func(key string) {
+			w.notify(ctx, store, key)
+		})
+		if ctx.Err() != nil {
+			return
+		}
+		if errors.Is(err, esapi.ErrWatchSecretsNotSupported) {
+			log.V(1).Info("store does not support change notifications", "reason", err.Error())
+			return
+		}
+		if time.Since(start) > watchHealthyAfter {
+			backoff = watchMinBackoff
+		}
+		log.Error(err, "watch of store failed, retrying", "backoff", backoff)
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+		backoff = min(2*backoff, watchMaxBackoff)
+	}
+}
+
+// notify enqueues the ExternalSecrets of the store that use the changed key.
+// Only ExternalSecrets with a periodic refresh are refreshed, the others are not polled either.
+func (w *StoreWatchers) notify(ctx context.Context, store esapi.GenericStore, key string) {
+	listOpts := []client.ListOption{
+		client.MatchingFields{storeRefIndexField(store.GetKind()): store.GetName()},
+	}
+	if store.GetKind() == esapi.SecretStoreKind {
+		listOpts = append(listOpts, client.InNamespace(store.GetNamespace()))
+	}
+	var esList esapi.ExternalSecretList
+	if err := w.client.List(ctx, &esList, listOpts...); err != nil {
+		w.log.Error(err, "unable to list ExternalSecrets of changed secret", "kind", store.GetKind(), "store", store.GetNamespacedName())
+		return
+	}
+	for i := range esList.Items {
+		es := &esList.Items[i]
+		if !isRefreshPeriodic(es) || !usesRemoteKey(es, key) {
+			continue
+		}
+		w.mu.Lock()
+		w.changed[types.NamespacedName{Namespace: es.Namespace, Name: es.Name}] = struct{}{}
+		w.mu.Unlock()
+		select {
+		case w.events <- event.GenericEvent{Object: es}:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func isRefreshPeriodic(es *esapi.ExternalSecret) bool {
+	policy := es.Spec.RefreshPolicy
+	return (policy == "" || policy == esapi.RefreshPolicyPeriodic) &&
+		(es.Spec.RefreshInterval == nil || es.Spec.RefreshInterval.Duration > 0)
+}
+
+// usesRemoteKey returns true if the ExternalSecret may read the key.
+// An empty key, dataFrom.find and generators match every key.
+func usesRemoteKey(es *esapi.ExternalSecret, key string) bool {
+	if key == "" {
+		return true
+	}
+	for _, data := range es.Spec.Data {
+		if data.RemoteRef.Key == key || slices.Contains(data.RemoteRef.KeyCandidates, key) {
+			return true
+		}
+	}
+	for _, dataFrom := range es.Spec.DataFrom {
+		if dataFrom.Find != nil || (dataFrom.SourceRef != nil && dataFrom.SourceRef.GeneratorRef != nil) {
+			return true
+		}
+		if dataFrom.Extract != nil && dataFrom.Extract.Key == key {
+			return true
+		}
+	}
+	return false
+}
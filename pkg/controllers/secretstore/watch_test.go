@@ -0,0 +1,147 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secretstore
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+)
+
+// WatchingProvider notifies the keys once and blocks until the watch is stopped.
+type WatchingProvider struct {
+	WrapProvider
+	keys []string
+}
+
+func (p *WatchingProvider) WatchSecrets(ctx context.Context, _ esv1beta1.GenericStore, _ client.Client, _ string, notify func(key string)) error {
+	for _, key := range p.keys {
+		notify(key)
+	}
+	<-ctx.Done()
+	return nil
+}
+
+func TestStoreWatchers(t *testing.T) {
+	scheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(esv1beta1.AddToScheme(scheme))
+
+	esv1beta1.ForceRegister(&WatchingProvider{keys: []string{"db-creds"}}, &esv1beta1.SecretStoreProvider{
+		Fake: &esv1beta1.FakeProvider{},
+	})
+
+	newES := func(name string, spec esv1beta1.ExternalSecretSpec) client.Object {
+		spec.SecretStoreRef = esv1beta1.SecretStoreRef{Name: "fake"}
+		return &esv1beta1.ExternalSecret{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec:       spec,
+		}
+	}
+	externalSecrets := []client.Object{
+		newES("data", esv1beta1.ExternalSecretSpec{
+			Data: []esv1beta1.ExternalSecretData{{SecretKey: "password", RemoteRef: esv1beta1.ExternalSecretDataRemoteRef{Key: "db-creds"}}},
+		}),
+		newES("extract", esv1beta1.ExternalSecretSpec{
+			DataFrom: []esv1beta1.ExternalSecretDataFromRemoteRef{{Extract: &esv1beta1.ExternalSecretDataRemoteRef{Key: "db-creds"}}},
+		}),
+		newES("find", esv1beta1.ExternalSecretSpec{
+			DataFrom: []esv1beta1.ExternalSecretDataFromRemoteRef{{Find: &esv1beta1.ExternalSecretFind{Tags: map[string]string{"team": "a"}}}},
+		}),
+		newES("other-key", esv1beta1.ExternalSecretSpec{
+			Data: []esv1beta1.ExternalSecretData{{SecretKey: "token", RemoteRef: esv1beta1.ExternalSecretDataRemoteRef{Key: "api-token"}}},
+		}),
+		newES("manual", esv1beta1.ExternalSecretSpec{
+			RefreshPolicy: esv1beta1.RefreshPolicyManual,
+			Data:          []esv1beta1.ExternalSecretData{{SecretKey: "password", RemoteRef: esv1beta1.ExternalSecretDataRemoteRef{Key: "db-creds"}}},
+		}),
+		newES("sync-once", esv1beta1.ExternalSecretSpec{
+			RefreshInterval: &metav1.Duration{},
+			Data:            []esv1beta1.ExternalSecretData{{SecretKey: "password", RemoteRef: esv1beta1.ExternalSecretDataRemoteRef{Key: "db-creds"}}},
+		}),
+	}
+	cl := fakeclient.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(externalSecrets...).
+		WithIndex(&esv1beta1.ExternalSecret{}, storeRefIndexField(esv1beta1.SecretStoreKind), func(obj client.Object) []string {
+			return storeRefNames(obj.(*esv1beta1.ExternalSecret), esv1beta1.SecretStoreKind)
+		}).
+		Build()
+
+	w := NewStoreWatchers(cl, logr.Discard())
+	store := &esv1beta1.SecretStore{
+		ObjectMeta: metav1.ObjectMeta{Name: "fake", Namespace: "default", Generation: 1},
+		Spec:       esv1beta1.SecretStoreSpec{Provider: &esv1beta1.SecretStoreProvider{Fake: &esv1beta1.FakeProvider{}}},
+	}
+	// the store is watched once the watchers are started
+	w.Ensure(store)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		_ = w.Start(ctx)
+	}()
+
+	var enqueued []string
+	timeout := time.After(5 * time.Second)
+	for len(enqueued) < 3 {
+		select {
+		case e := <-w.events:
+			enqueued = append(enqueued, e.Object.GetName())
+		case <-timeout:
+			t.Fatalf("timed out, enqueued %v", enqueued)
+		}
+	}
+	assert.ElementsMatch(t, []string{"data", "extract", "find"}, enqueued)
+
+	name := types.NamespacedName{Namespace: "default", Name: "data"}
+	assert.True(t, w.TakeChanged(name))
+	assert.False(t, w.TakeChanged(name), "the change must only be taken once")
+	assert.False(t, w.TakeChanged(types.NamespacedName{Namespace: "default", Name: "other-key"}))
+
+	w.Stop(esv1beta1.SecretStoreKind, types.NamespacedName{Namespace: "default", Name: "fake"})
+	w.mu.Lock()
+	assert.Empty(t, w.watchers)
+	w.mu.Unlock()
+}
+
+func TestStoreWatchersUnsupportedProvider(t *testing.T) {
+	esv1beta1.ForceRegister(&WrapProvider{}, &esv1beta1.SecretStoreProvider{
+		AWS: &esv1beta1.AWSProvider{},
+	})
+	w := NewStoreWatchers(nil, logr.Discard())
+	w.Ensure(&esv1beta1.SecretStore{
+		ObjectMeta: metav1.ObjectMeta{Name: "aws", Namespace: "default"},
+		Spec:       esv1beta1.SecretStoreSpec{Provider: &esv1beta1.SecretStoreProvider{AWS: &esv1beta1.AWSProvider{}}},
+	})
+	require.Empty(t, w.watchers)
+
+	var nilWatchers *StoreWatchers
+	nilWatchers.Ensure(&esv1beta1.SecretStore{})
+	assert.False(t, nilWatchers.TakeChanged(types.NamespacedName{Name: "es"}))
+}
@@ -18,6 +18,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strconv"
 	"strings"
 
 	v1 "k8s.io/api/core/v1"
@@ -36,6 +37,16 @@ var (
 	errExecTpl          = "could not execute template: %w"
 )
 
+// Enabled returns false if the template is gated by enabledKey
+// and the value of the key in data is missing or not true.
+func Enabled(tpl *esv1beta1.ExternalSecretTemplate, data map[string][]byte) bool {
+	if tpl == nil || tpl.EnabledKey == "" {
+		return true
+	}
+	enabled, err := strconv.ParseBool(strings.TrimSpace(string(data[tpl.EnabledKey])))
+	return err == nil && enabled
+}
+
 type Parser struct {
 	Exec         template.ExecFunc
 	DataMap      map[string][]byte
@@ -0,0 +1,250 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package conversion instruments the CRD conversion webhook with metrics,
+// failure logs and a health check.
+package conversion
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	apix "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/conversion"
+)
+
+const (
+	// Path is the path the conversion webhook is served at.
+	Path = "/convert"
+
+	ConversionWebhookSubsystem = "conversion_webhook"
+	conversionsTotalKey        = "conversions_total"
+	conversionErrorsTotalKey   = "conversion_errors_total"
+
+	errConversionFailing = "%d of %d conversions failed in the last %s"
+)
+
+var (
+	log = ctrl.Log.WithName("conversion")
+
+	conversionsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Subsystem: ConversionWebhookSubsystem,
+		Name:      conversionsTotalKey,
+		Help:      "Total number of objects sent to the conversion webhook",
+	}, []string{"from_version", "to_version"})
+
+	conversionErrorsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Subsystem: ConversionWebhookSubsystem,
+		Name:      conversionErrorsTotalKey,
+		Help:      "Total number of objects the conversion webhook failed to convert",
+	}, []string{"from_version", "to_version"})
+)
+
+func init() {
+	metrics.Registry.MustRegister(conversionsTotal, conversionErrorsTotal)
+}
+
+// HealthCheckOptions configures when the health check of the Handler fails.
+type HealthCheckOptions struct {
+	// Window is the duration of the sliding window of conversions the check is based on, zero disables the check.
+	Window time.Duration
+	// FailureRatio is the ratio of failed conversions in the window from which the check fails.
+	FailureRatio float64
+	// MinRequests is the number of conversions in the window below which the check never fails.
+	MinRequests int
+}
+
+// Handler wraps the controller-runtime conversion webhook.
+// It records every converted object and logs the objects of failed conversions.
+type Handler struct {
+	next   http.Handler
+	health HealthCheckOptions
+	now    func() time.Time
+
+	mu sync.Mutex
+	// buckets counts the conversions of the window per second, oldest first.
+	buckets []conversionBucket
+}
+
+// conversionBucket counts the conversions of one second.
+type conversionBucket struct {
+	second int64
+	total  int
+	failed int
+}
+
+// NewHandler returns a conversion webhook handler for the types in scheme.
+// Its health check fails once the ratio of failed conversions in the sliding window reaches the failure ratio.
+func NewHandler(scheme *runtime.Scheme, health HealthCheckOptions) *Handler {
+	return &Handler{
+		next:   conversion.NewWebhookHandler(scheme),
+		health: health,
+		now:    time.Now,
+	}
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		log.Error(err, "failed to read conversion request")
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	r.Body = io.NopCloser(bytes.NewReader(body))
+
+	review := &apix.ConversionReview{}
+	if err := json.Unmarshal(body, review); err != nil || review.Request == nil {
+		// invalid requests are rejected by the wrapped handler
+		h.next.ServeHTTP(w, r)
+		return
+	}
+
+	rec := &responseRecorder{ResponseWriter: w}
+	h.next.ServeHTTP(rec, r)
+	h.observe(review.Request, rec.body.Bytes())
+}
+
+// Check implements a healthz.Checker.
+func (h *Handler) Check(_ *http.Request) error {
+	if h.health.Window <= 0 {
+		return nil
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.prune()
+	if total, failed := h.counts(); h.failing(total, failed) {
+		return fmt.Errorf(errConversionFailing, failed, total, h.health.Window)
+	}
+	return nil
+}
+
+// prune removes the buckets that left the window.
+func (h *Handler) prune() {
+	oldest := h.now().Add(-h.health.Window).Unix()
+	i := 0
+	for i < len(h.buckets) && h.buckets[i].second <= oldest {
+		i++
+	}
+	h.buckets = h.buckets[i:]
+}
+
+// counts returns the number of all and of failed conversions in the window.
+func (h *Handler) counts() (int, int) {
+	total, failed := 0, 0
+	for _, b := range h.buckets {
+		total += b.total
+		failed += b.failed
+	}
+	return total, failed
+}
+
+func (h *Handler) failing(total, failed int) bool {
+	return total > 0 && total >= h.health.MinRequests && float64(failed)/float64(total) >= h.health.FailureRatio
+}
+
+// record adds a conversion to the window. A successful conversion while the check
+// is failing clears the window, so the check recovers as soon as conversions work again.
+func (h *Handler) record(failed bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.prune()
+	if !failed && h.failing(h.counts()) {
+		h.buckets = nil
+	}
+	second := h.now().Unix()
+	if n := len(h.buckets); n == 0 || h.buckets[n-1].second != second {
+		h.buckets = append(h.buckets, conversionBucket{second: second})
+	}
+	bucket := &h.buckets[len(h.buckets)-1]
+	bucket.total++
+	if failed {
+		bucket.failed++
+	}
+}
+
+func (h *Handler) observe(req *apix.ConversionRequest, respBody []byte) {
+	convErr := conversionError(respBody)
+	to := versionOf(req.DesiredAPIVersion)
+	for _, raw := range req.Objects {
+		var obj struct {
+			metav1.TypeMeta   `json:",inline"`
+			metav1.ObjectMeta `json:"metadata,omitempty"`
+		}
+		// a broken object fails the conversion, it is still counted with an empty version
+		_ = json.Unmarshal(raw.Raw, &obj)
+		from := versionOf(obj.APIVersion)
+		conversionsTotal.WithLabelValues(from, to).Inc()
+		if convErr == nil {
+			continue
+		}
+		conversionErrorsTotal.WithLabelValues(from, to).Inc()
+		log.Error(convErr, "failed to convert object",
+			"kind", obj.Kind,
+			"name", obj.Name,
+			"namespace", obj.Namespace,
+			"from", obj.APIVersion,
+			"to", req.DesiredAPIVersion,
+			"request", req.UID)
+	}
+
+	if h.health.Window > 0 {
+		h.record(convErr != nil)
+	}
+}
+
+// conversionError returns the error reported in a ConversionReview response.
+func conversionError(respBody []byte) error {
+	review := &apix.ConversionReview{}
+	if err := json.Unmarshal(respBody, review); err != nil {
+		return fmt.Errorf("invalid conversion response: %w", err)
+	}
+	if review.Response == nil {
+		return errors.New("missing conversion response")
+	}
+	if review.Response.Result.Status != metav1.StatusSuccess {
+		return errors.New(review.Response.Result.Message)
+	}
+	return nil
+}
+
+func versionOf(apiVersion string) string {
+	gv, err := schema.ParseGroupVersion(apiVersion)
+	if err != nil {
+		return ""
+	}
+	return gv.Version
+}
+
+// responseRecorder passes the response through and keeps a copy of the body.
+type responseRecorder struct {
+	http.ResponseWriter
+	body bytes.Buffer
+}
+
+func (r *responseRecorder) Write(b []byte) (int, error) {
+	r.body.Write(b)
+	return r.ResponseWriter.Write(b)
+}
@@ -0,0 +1,178 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package conversion
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	apix "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	esv1alpha1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1alpha1"
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+)
+
+func newReview(t *testing.T, desiredAPIVersion string) *bytes.Buffer {
+	t.Helper()
+	obj, err := json.Marshal(&esv1alpha1.SecretStore{
+		TypeMeta:   metav1.TypeMeta{APIVersion: esv1alpha1.SchemeGroupVersion.String(), Kind: esv1alpha1.SecretStoreKind},
+		ObjectMeta: metav1.ObjectMeta{Name: "store", Namespace: "default"},
+		Spec: esv1alpha1.SecretStoreSpec{
+			Provider: &esv1alpha1.SecretStoreProvider{Fake: &esv1alpha1.FakeProvider{}},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	review, err := json.Marshal(&apix.ConversionReview{
+		TypeMeta: metav1.TypeMeta{APIVersion: "apiextensions.k8s.io/v1", Kind: "ConversionReview"},
+		Request: &apix.ConversionRequest{
+			UID:               "uid",
+			DesiredAPIVersion: desiredAPIVersion,
+			Objects:           []runtime.RawExtension{{Raw: obj}},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return bytes.NewBuffer(review)
+}
+
+func TestHandler(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := esv1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	if err := esv1beta1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	now := time.Now()
+	h := NewHandler(scheme, HealthCheckOptions{Window: time.Minute, FailureRatio: 0.5, MinRequests: 3})
+	h.now = func() time.Time { return now }
+
+	convert := func(desiredAPIVersion string) *apix.ConversionReview {
+		t.Helper()
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, Path, newReview(t, desiredAPIVersion)))
+		resp := &apix.ConversionReview{}
+		if err := json.Unmarshal(rec.Body.Bytes(), resp); err != nil {
+			t.Fatalf("invalid response: %v", err)
+		}
+		return resp
+	}
+
+	okTotal := testutil.ToFloat64(conversionsTotal.WithLabelValues("v1alpha1", "v1beta1"))
+	resp := convert(esv1beta1.SchemeGroupVersion.String())
+	if resp.Response.Result.Status != metav1.StatusSuccess || len(resp.Response.ConvertedObjects) != 1 {
+		t.Fatalf("expected successful conversion, got %v", resp.Response.Result)
+	}
+	if got := testutil.ToFloat64(conversionsTotal.WithLabelValues("v1alpha1", "v1beta1")); got != okTotal+1 {
+		t.Errorf("expected conversion to be counted, got %v", got)
+	}
+
+	errTotal := testutil.ToFloat64(conversionErrorsTotal.WithLabelValues("v1alpha1", "v2"))
+	resp = convert("external-secrets.io/v2")
+	if resp.Response.Result.Status == metav1.StatusSuccess {
+		t.Fatalf("expected failed conversion")
+	}
+	if got := testutil.ToFloat64(conversionErrorsTotal.WithLabelValues("v1alpha1", "v2")); got != errTotal+1 {
+		t.Errorf("expected conversion error to be counted, got %v", got)
+	}
+
+	if err := h.Check(nil); err != nil {
+		t.Errorf("expected healthy handler below the minimum number of requests, got %v", err)
+	}
+	convert("external-secrets.io/v2")
+	if err := h.Check(nil); err == nil {
+		t.Errorf("expected unhealthy handler with 2 of 3 conversions failing")
+	}
+
+	convert(esv1beta1.SchemeGroupVersion.String())
+	if err := h.Check(nil); err != nil {
+		t.Errorf("expected successful conversion to reset health, got %v", err)
+	}
+}
+
+func TestHandlerCheck(t *testing.T) {
+	start := time.Now()
+	tests := []struct {
+		name    string
+		events  []bool
+		elapsed time.Duration
+		health  HealthCheckOptions
+		wantErr bool
+	}{
+		{
+			name:    "disabled",
+			events:  []bool{true, true, true},
+			health:  HealthCheckOptions{FailureRatio: 0.5, MinRequests: 1},
+			wantErr: false,
+		},
+		{
+			name:    "below the minimum number of requests",
+			events:  []bool{true, true},
+			health:  HealthCheckOptions{Window: time.Minute, FailureRatio: 0.5, MinRequests: 3},
+			wantErr: false,
+		},
+		{
+			name:    "failure ratio reached",
+			events:  []bool{false, false, true, true},
+			health:  HealthCheckOptions{Window: time.Minute, FailureRatio: 0.5, MinRequests: 3},
+			wantErr: true,
+		},
+		{
+			name:    "failure ratio not reached",
+			events:  []bool{true, false, false, false},
+			health:  HealthCheckOptions{Window: time.Minute, FailureRatio: 0.5, MinRequests: 3},
+			wantErr: false,
+		},
+		{
+			name:    "failures left the window",
+			events:  []bool{true, true, true},
+			elapsed: 2 * time.Minute,
+			health:  HealthCheckOptions{Window: time.Minute, FailureRatio: 0.5, MinRequests: 1},
+			wantErr: false,
+		},
+		{
+			name:    "success clears a failing window",
+			events:  []bool{true, true, true, false},
+			health:  HealthCheckOptions{Window: time.Minute, FailureRatio: 0.5, MinRequests: 3},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			now := start
+			h := &Handler{health: tt.health, now: func() time.Time { return now }}
+			for _, failed := range tt.events {
+				if tt.health.Window > 0 {
+					h.record(failed)
+				}
+				now = now.Add(time.Second)
+			}
+			now = now.Add(tt.elapsed)
+			if err := h.Check(nil); (err != nil) != tt.wantErr {
+				t.Errorf("Check() = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,79 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package decryption
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+
+	"filippo.io/age"
+	"filippo.io/age/armor"
+)
+
+// NOTE: age errors must never contain key material, ciphertext or plaintext.
+var (
+	errAgeInvalidIdentity = errors.New("invalid age identity")
+	errAgeNoIdentity      = errors.New("no age identity found")
+	errAgeNoMatch         = errors.New("no age identity matches the file")
+	errAgeInvalidHeader   = errors.New("invalid age header")
+	errAgeInvalidPayload  = errors.New("invalid age payload")
+)
+
+// parseAgeIdentities parses one `AGE-SECRET-KEY-1...` identity per line.
+// Empty lines and lines starting with `#` are ignored.
+func parseAgeIdentities(data string) ([]age.Identity, error) {
+	if !hasAgeIdentity(data) {
+		return nil, errAgeNoIdentity
+	}
+	// the parser error is dropped as it may quote the key
+	ids, err := age.ParseIdentities(strings.NewReader(data))
+	if err != nil {
+		return nil, errAgeInvalidIdentity
+	}
+	return ids, nil
+}
+
+// ageDecrypt decrypts an armored or binary age file.
+func ageDecrypt(ids []age.Identity, data []byte) ([]byte, error) {
+	var src io.Reader = bytes.NewReader(data)
+	if bytes.HasPrefix(bytes.TrimSpace(data), []byte(armor.Header)) {
+		src = armor.NewReader(bytes.NewReader(bytes.TrimSpace(data)))
+	}
+	r, err := age.Decrypt(src, ids...)
+	var noMatch *age.NoIdentityMatchError
+	if errors.As(err, &noMatch) {
+		return nil, errAgeNoMatch
+	}
+	if err != nil {
+		return nil, errAgeInvalidHeader
+	}
+	out, err := io.ReadAll(r)
+	if err != nil {
+		return nil, errAgeInvalidPayload
+	}
+	return out, nil
+}
+
+func hasAgeIdentity(data string) bool {
+	for _, line := range strings.Split(data, "\n") {
+		line = strings.TrimSpace(line)
+		if line != "" && !strings.HasPrefix(line, "#") {
+			return true
+		}
+	}
+	return false
+}
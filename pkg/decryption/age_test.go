@@ -0,0 +1,147 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package decryption
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"filippo.io/age"
+	"filippo.io/age/armor"
+	"golang.org/x/crypto/chacha20poly1305"
+)
+
+// ageChunkSize is the size of an age payload chunk.
+const ageChunkSize = 64 * 1024
+
+// newTestIdentity returns a random age identity and its encoded form.
+func newTestIdentity(t *testing.T) (*age.X25519Identity, string) {
+	t.Helper()
+	id, err := age.GenerateX25519Identity()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return id, id.String()
+}
+
+// ageEncrypt encrypts plaintext to the recipient of id.
+func ageEncrypt(t *testing.T, id *age.X25519Identity, plaintext []byte, armored bool) []byte {
+	t.Helper()
+	var out bytes.Buffer
+	var dst io.WriteCloser = nopCloser{&out}
+	if armored {
+		dst = armor.NewWriter(&out)
+	}
+	w, err := age.Encrypt(dst, id.Recipient())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := w.Write(plaintext); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := dst.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return out.Bytes()
+}
+
+type nopCloser struct{ io.Writer }
+
+func (nopCloser) Close() error { return nil }
+
+func TestParseAgeIdentities(t *testing.T) {
+	_, encoded := newTestIdentity(t)
+	_, other := newTestIdentity(t)
+	ids, err := parseAgeIdentities("# created: 2024-01-01\n" + encoded + "\n\n" + other + "\n")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(ids) != 2 {
+		t.Errorf("expected two identities, got %d", len(ids))
+	}
+
+	if _, err := parseAgeIdentities("# no keys\n"); !errors.Is(err, errAgeNoIdentity) {
+		t.Errorf("expected no identity error, got %v", err)
+	}
+	// a broken checksum must not be accepted
+	broken := encoded[:len(encoded)-1] + "Q"
+	if broken == encoded {
+		broken = encoded[:len(encoded)-1] + "P"
+	}
+	_, err = parseAgeIdentities(broken)
+	if !errors.Is(err, errAgeInvalidIdentity) {
+		t.Errorf("expected invalid identity error, got %v", err)
+	}
+	if err != nil && strings.Contains(err.Error(), broken) {
+		t.Errorf("error must not contain the key: %v", err)
+	}
+}
+
+func TestAgeDecrypt(t *testing.T) {
+	id, _ := newTestIdentity(t)
+	other, _ := newTestIdentity(t)
+	large := bytes.Repeat([]byte("s3cr3t"), ageChunkSize/3)
+
+	tests := []struct {
+		name      string
+		plaintext []byte
+		armor     bool
+	}{
+		{name: "binary", plaintext: []byte("s3cr3t")},
+		{name: "armored", plaintext: []byte("s3cr3t"), armor: true},
+		{name: "empty", plaintext: []byte{}},
+		{name: "multiple chunks", plaintext: large},
+		{name: "exactly one chunk", plaintext: large[:ageChunkSize]},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ciphertext := ageEncrypt(t, id, tt.plaintext, tt.armor)
+			got, err := ageDecrypt([]age.Identity{other, id}, ciphertext)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !bytes.Equal(got, tt.plaintext) {
+				t.Errorf("unexpected plaintext")
+			}
+		})
+	}
+
+	ciphertext := ageEncrypt(t, id, []byte("s3cr3t"), false)
+	if _, err := ageDecrypt([]age.Identity{other}, ciphertext); !errors.Is(err, errAgeNoMatch) {
+		t.Errorf("expected no match error, got %v", err)
+	}
+
+	tampered := bytes.Clone(ciphertext)
+	tampered[len(tampered)-1] ^= 1
+	if _, err := ageDecrypt([]age.Identity{id}, tampered); !errors.Is(err, errAgeInvalidPayload) {
+		t.Errorf("expected invalid payload error, got %v", err)
+	}
+
+	truncated := ageEncrypt(t, id, large, false)
+	truncated = truncated[:len(truncated)-len(large)%ageChunkSize-chacha20poly1305.Overhead]
+	if _, err := ageDecrypt([]age.Identity{id}, truncated); !errors.Is(err, errAgeInvalidPayload) {
+		t.Errorf("expected truncated file to be rejected, got %v", err)
+	}
+
+	if _, err := ageDecrypt([]age.Identity{id}, []byte("s3cr3t")); !errors.Is(err, errAgeInvalidHeader) {
+		t.Errorf("expected invalid header error, got %v", err)
+	}
+}
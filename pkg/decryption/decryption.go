@@ -0,0 +1,71 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package decryption decrypts secret values that are encrypted on the client side,
+// before they are stored in a provider.
+package decryption
+
+import (
+	"fmt"
+
+	"filippo.io/age"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+)
+
+const (
+	errUnknownFormat = "unknown decryption format %q"
+	errDecrypt       = "unable to decrypt secret using format %s: %w"
+)
+
+// Decrypter decrypts secret values with age identities.
+type Decrypter struct {
+	format     esv1beta1.ExternalSecretDecryptionFormat
+	identities []age.Identity
+}
+
+// New returns a Decrypter for the given format. ageKeys contains
+// one age identity per line.
+func New(format esv1beta1.ExternalSecretDecryptionFormat, ageKeys string) (*Decrypter, error) {
+	switch format {
+	case esv1beta1.DecryptionFormatSOPS, esv1beta1.DecryptionFormatAge:
+	default:
+		return nil, fmt.Errorf(errUnknownFormat, format)
+	}
+	ids, err := parseAgeIdentities(ageKeys)
+	if err != nil {
+		return nil, err
+	}
+	return &Decrypter{
+		format:     format,
+		identities: ids,
+	}, nil
+}
+
+// Decrypt returns the plaintext of data. SOPS documents are returned as JSON,
+// without the sops metadata. Errors never contain the data or the keys.
+func (d *Decrypter) Decrypt(data []byte) ([]byte, error) {
+	var out []byte
+	var err error
+	switch d.format {
+	case esv1beta1.DecryptionFormatSOPS:
+		out, err = sopsDecrypt(d.identities, data)
+	case esv1beta1.DecryptionFormatAge:
+		out, err = ageDecrypt(d.identities, data)
+	}
+	if err != nil {
+		return nil, fmt.Errorf(errDecrypt, d.format, err)
+	}
+	return out, nil
+}
@@ -0,0 +1,121 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package decryption
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"filippo.io/age"
+	"github.com/getsops/sops/v3"
+	"github.com/getsops/sops/v3/aes"
+	sopsage "github.com/getsops/sops/v3/age"
+	"github.com/getsops/sops/v3/config"
+	sopsjson "github.com/getsops/sops/v3/stores/json"
+	sopsyaml "github.com/getsops/sops/v3/stores/yaml"
+)
+
+// This file decrypts SOPS documents (https://github.com/getsops/sops)
+// in YAML or JSON format whose data key is encrypted with age.
+
+// NOTE: sops errors must never contain key material, ciphertext or plaintext.
+var (
+	errSOPSInvalidDocument = errors.New("invalid sops document")
+	errSOPSMultiDocument   = errors.New("sops documents with multiple YAML documents are not supported")
+	errSOPSMissingMetadata = errors.New("missing sops metadata")
+	errSOPSNoAgeKey        = errors.New("sops data key is not encrypted with age")
+	errSOPSKeyGroups       = errors.New("sops key groups are not supported")
+	errSOPSDataKey         = errors.New("unable to decrypt sops data key")
+	errSOPSDecrypt         = errors.New("unable to decrypt sops document")
+	errSOPSMACMismatch     = errors.New("sops MAC mismatch, the document has been modified")
+)
+
+// sopsDecrypt decrypts a SOPS document and returns it as JSON without the sops metadata.
+// It mirrors decrypt.DataWithFormat, which can only read age identities from the environment.
+func sopsDecrypt(ids []age.Identity, data []byte) ([]byte, error) {
+	tree, err := sopsStore(data).LoadEncryptedFile(data)
+	if errors.Is(err, sops.MetadataNotFound) {
+		return nil, errSOPSMissingMetadata
+	}
+	if err != nil {
+		// the store error is dropped as it may quote the document
+		return nil, errSOPSInvalidDocument
+	}
+	if len(tree.Branches) != 1 {
+		return nil, errSOPSMultiDocument
+	}
+
+	key, err := sopsDataKey(ids, &tree.Metadata)
+	if err != nil {
+		return nil, err
+	}
+	cipher := aes.NewCipher()
+	mac, err := tree.Decrypt(key, cipher)
+	if err != nil {
+		return nil, errSOPSDecrypt
+	}
+	originalMAC, err := cipher.Decrypt(tree.Metadata.MessageAuthenticationCode, key, tree.Metadata.LastModified.Format(time.RFC3339))
+	if err != nil || originalMAC != mac {
+		return nil, errSOPSMACMismatch
+	}
+
+	out, err := sopsjson.NewStore(&config.JSONStoreConfig{}).EmitPlainFile(tree.Branches)
+	if err != nil {
+		return nil, errSOPSDecrypt
+	}
+	var buf bytes.Buffer
+	if err := json.Compact(&buf, out); err != nil {
+		return nil, errSOPSDecrypt
+	}
+	return buf.Bytes(), nil
+}
+
+// sopsStore returns the JSON store for JSON documents and the YAML store otherwise.
+func sopsStore(data []byte) sops.Store {
+	if bytes.HasPrefix(bytes.TrimSpace(data), []byte("{")) {
+		return sopsjson.NewStore(&config.JSONStoreConfig{})
+	}
+	return sopsyaml.NewStore(&config.YAMLStoreConfig{})
+}
+
+// sopsDataKey decrypts the data key with the first age master key that accepts one of the identities.
+func sopsDataKey(ids []age.Identity, meta *sops.Metadata) ([]byte, error) {
+	if len(meta.KeyGroups) != 1 || meta.ShamirThreshold > 1 {
+		return nil, errSOPSKeyGroups
+	}
+	// an empty set of identities makes sops read them from the environment
+	if len(ids) == 0 {
+		return nil, errSOPSDataKey
+	}
+	var found bool
+	for _, k := range meta.KeyGroups[0] {
+		key, ok := k.(*sopsage.MasterKey)
+		if !ok {
+			continue
+		}
+		found = true
+		sopsage.ParsedIdentities(ids).ApplyToMasterKey(key)
+		dataKey, err := key.Decrypt()
+		if err == nil {
+			return dataKey, nil
+		}
+	}
+	if !found {
+		return nil, errSOPSNoAgeKey
+	}
+	return nil, errSOPSDataKey
+}
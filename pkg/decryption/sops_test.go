@@ -0,0 +1,221 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package decryption
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha512"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"filippo.io/age"
+	"github.com/google/go-cmp/cmp"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+)
+
+const (
+	testLastModified = "2024-01-01T00:00:00Z"
+	sopsDataKeySize  = 32
+	sopsNonceSize    = 32
+)
+
+func testRandom(t *testing.T, n int) []byte {
+	t.Helper()
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+// sopsEncrypt encrypts a value the way SOPS does.
+func sopsEncrypt(t *testing.T, dataKey []byte, value, typ, additionalData string) string {
+	t.Helper()
+	block, err := aes.NewCipher(dataKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	gcm, err := cipher.NewGCMWithNonceSize(block, sopsNonceSize)
+	if err != nil {
+		t.Fatal(err)
+	}
+	iv := testRandom(t, sopsNonceSize)
+	sealed := gcm.Seal(nil, iv, []byte(value), []byte(additionalData))
+	data, tag := sealed[:len(sealed)-gcm.Overhead()], sealed[len(sealed)-gcm.Overhead():]
+	return fmt.Sprintf("ENC[AES256_GCM,data:%s,iv:%s,tag:%s,type:%s]",
+		base64.StdEncoding.EncodeToString(data),
+		base64.StdEncoding.EncodeToString(iv),
+		base64.StdEncoding.EncodeToString(tag),
+		typ)
+}
+
+// sopsMAC returns the encrypted MAC of the given values.
+func sopsMAC(t *testing.T, dataKey []byte, values ...string) string {
+	t.Helper()
+	h := sha512.New()
+	for _, v := range values {
+		h.Write([]byte(v))
+	}
+	return sopsEncrypt(t, dataKey, fmt.Sprintf("%X", h.Sum(nil)), "str", testLastModified)
+}
+
+func sopsAgeMetadata(t *testing.T, id *age.X25519Identity, dataKey []byte) string {
+	t.Helper()
+	armored := strings.TrimSpace(string(ageEncrypt(t, id, dataKey, true)))
+	return "      enc: |\n        " + strings.ReplaceAll(armored, "\n", "\n        ") + "\n"
+}
+
+func TestSOPSDecrypt(t *testing.T) {
+	id, encoded := newTestIdentity(t)
+	other, _ := newTestIdentity(t)
+	dataKey := testRandom(t, sopsDataKeySize)
+	enc := func(value, typ, path string) string {
+		return sopsEncrypt(t, dataKey, value, typ, path)
+	}
+	metadata := "sops:\n    age:\n    - recipient: age1test\n" + sopsAgeMetadata(t, id, dataKey) +
+		"    lastmodified: \"" + testLastModified + "\"\n" +
+		"    unencrypted_suffix: _unencrypted\n" +
+		"    version: 3.8.1\n"
+
+	yamlDoc := fmt.Sprintf(`#%s
+db:
+    user: %s
+    port: %s
+    tls: %s
+    hosts:
+        - %s
+        #%s
+        - %s
+public_unencrypted: plain
+empty: ""
+%s    mac: %s
+`,
+		enc(" database settings", "comment", ":"),
+		enc("admin", "str", "db:user:"),
+		enc("5432", "int", "db:port:"),
+		enc("true", "bool", "db:tls:"),
+		enc("a.example.com", "str", "db:hosts:"),
+		enc(" replica", "comment", "db:hosts:"),
+		enc("b.example.com", "str", "db:hosts:"),
+		metadata,
+		sopsMAC(t, dataKey, "admin", "5432", "True", "a.example.com", "b.example.com", "plain"),
+	)
+
+	jsonDoc := fmt.Sprintf(`{
+	"password": "%s",
+	"sops": {
+		"age": [{"recipient": "age1test", "enc": %q}],
+		"lastmodified": %q,
+		"mac": "%s",
+		"unencrypted_suffix": "_unencrypted"
+	}
+}`,
+		enc("s3cr3t", "str", "password:"),
+		string(ageEncrypt(t, id, dataKey, true)),
+		testLastModified,
+		sopsMAC(t, dataKey, "s3cr3t"),
+	)
+
+	tests := []struct {
+		name    string
+		doc     string
+		ids     []age.Identity
+		want    string
+		wantErr error
+	}{
+		{
+			name: "yaml document",
+			doc:  yamlDoc,
+			ids:  []age.Identity{other, id},
+			want: `{"db":{"user":"admin","port":5432,"tls":true,"hosts":["a.example.com","b.example.com"]},"public_unencrypted":"plain","empty":""}`,
+		},
+		{
+			name: "json document",
+			doc:  jsonDoc,
+			ids:  []age.Identity{id},
+			want: `{"password":"s3cr3t"}`,
+		},
+		{
+			name:    "wrong identity",
+			doc:     yamlDoc,
+			ids:     []age.Identity{other},
+			wantErr: errSOPSDataKey,
+		},
+		{
+			name:    "modified unencrypted value",
+			doc:     strings.Replace(yamlDoc, "public_unencrypted: plain", "public_unencrypted: changed", 1),
+			ids:     []age.Identity{id},
+			wantErr: errSOPSMACMismatch,
+		},
+		{
+			name:    "removed value",
+			doc:     strings.Replace(yamlDoc, "public_unencrypted: plain\n", "", 1),
+			ids:     []age.Identity{id},
+			wantErr: errSOPSMACMismatch,
+		},
+		{
+			name:    "value moved to another key",
+			doc:     strings.Replace(jsonDoc, `"password"`, `"username"`, 1),
+			ids:     []age.Identity{id},
+			wantErr: errSOPSDecrypt,
+		},
+		{
+			name:    "missing metadata",
+			doc:     "password: s3cr3t\n",
+			ids:     []age.Identity{id},
+			wantErr: errSOPSMissingMetadata,
+		},
+		{
+			name:    "invalid document",
+			doc:     "password: [s3cr3t\n",
+			ids:     []age.Identity{id},
+			wantErr: errSOPSInvalidDocument,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := sopsDecrypt(tt.ids, []byte(tt.doc))
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+				}
+				if strings.Contains(err.Error(), "s3cr3t") || strings.Contains(err.Error(), "ENC[") {
+					t.Fatalf("error must not contain secret data: %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tt.want, string(got)); diff != "" {
+				t.Errorf("unexpected result (-want +got):\n%s", diff)
+			}
+		})
+	}
+
+	d, err := New(esv1beta1.DecryptionFormatSOPS, encoded)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := d.Decrypt([]byte(jsonDoc)); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,65 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package encryption
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+
+	"filippo.io/age"
+	"filippo.io/age/armor"
+)
+
+// This file encrypts to the age v1 format (https://age-encryption.org/v1)
+// for X25519 recipients. The output is armored, so it can be read by `age --decrypt`.
+
+var (
+	errAgeInvalidRecipient = errors.New("invalid age recipient")
+	errAgeEncrypt          = errors.New("unable to encrypt age file")
+)
+
+// parseAgeRecipients parses `age1...` X25519 recipients.
+func parseAgeRecipients(recipients []string) ([]age.Recipient, error) {
+	out := make([]age.Recipient, 0, len(recipients))
+	for _, recipient := range recipients {
+		r, err := age.ParseX25519Recipient(strings.TrimSpace(recipient))
+		if err != nil {
+			return nil, errAgeInvalidRecipient
+		}
+		out = append(out, r)
+	}
+	return out, nil
+}
+
+// ageEncrypt encrypts plaintext for all recipients and returns the armored file.
+func ageEncrypt(recipients []age.Recipient, plaintext []byte) ([]byte, error) {
+	var out bytes.Buffer
+	a := armor.NewWriter(&out)
+	w, err := age.Encrypt(a, recipients...)
+	if err != nil {
+		return nil, errAgeEncrypt
+	}
+	if _, err := w.Write(plaintext); err != nil {
+		return nil, errAgeEncrypt
+	}
+	if err := w.Close(); err != nil {
+		return nil, errAgeEncrypt
+	}
+	if err := a.Close(); err != nil {
+		return nil, errAgeEncrypt
+	}
+	return out.Bytes(), nil
+}
@@ -0,0 +1,76 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package encryption encrypts secret values for recipients outside of the cluster,
+// before they are written to a Kubernetes Secret.
+package encryption
+
+import (
+	"fmt"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+)
+
+const (
+	errUnknownFormat = "unknown encryption format %q"
+	errNoRecipients  = "at least one recipient is required"
+	errEncrypt       = "unable to encrypt secret using format %s: %w"
+)
+
+// Encrypter encrypts secret values for a set of age or OpenPGP recipients.
+type Encrypter struct {
+	format  esv1beta1.ExternalSecretEncryptionFormat
+	encrypt func(plaintext []byte) ([]byte, error)
+}
+
+// New returns an Encrypter for the given format. recipients holds age recipients (age1...)
+// or ASCII armored OpenPGP public keys, depending on the format.
+func New(format esv1beta1.ExternalSecretEncryptionFormat, recipients []string) (*Encrypter, error) {
+	if len(recipients) == 0 {
+		return nil, fmt.Errorf(errNoRecipients)
+	}
+	e := &Encrypter{format: format}
+	switch format {
+	case esv1beta1.EncryptionFormatAge:
+		keys, err := parseAgeRecipients(recipients)
+		if err != nil {
+			return nil, err
+		}
+		e.encrypt = func(plaintext []byte) ([]byte, error) {
+			return ageEncrypt(keys, plaintext)
+		}
+	case esv1beta1.EncryptionFormatGPG:
+		entities, err := parseGPGRecipients(recipients)
+		if err != nil {
+			return nil, err
+		}
+		e.encrypt = func(plaintext []byte) ([]byte, error) {
+			return gpgEncrypt(entities, plaintext)
+		}
+	default:
+		return nil, fmt.Errorf(errUnknownFormat, format)
+	}
+	return e, nil
+}
+
+// Encrypt returns the ASCII armored ciphertext of plaintext.
+// The ciphertext differs on every call, even for the same plaintext.
+// Errors never contain the plaintext.
+func (e *Encrypter) Encrypt(plaintext []byte) ([]byte, error) {
+	out, err := e.encrypt(plaintext)
+	if err != nil {
+		return nil, fmt.Errorf(errEncrypt, e.format, err)
+	}
+	return out, nil
+}
@@ -0,0 +1,156 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package encryption
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+
+	"filippo.io/age"
+	agearmor "filippo.io/age/armor"
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/ProtonMail/go-crypto/openpgp/armor"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	"github.com/external-secrets/external-secrets/pkg/decryption"
+)
+
+// ageChunkSize is the size of an age payload chunk.
+const ageChunkSize = 64 * 1024
+
+// newAgeKey returns a random age identity and its recipient.
+func newAgeKey(t *testing.T) (string, string) {
+	t.Helper()
+	id, err := age.GenerateX25519Identity()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return id.String(), id.Recipient().String()
+}
+
+func TestAgeEncrypt(t *testing.T) {
+	identity, recipient := newAgeKey(t)
+	otherIdentity, otherRecipient := newAgeKey(t)
+	large := bytes.Repeat([]byte("s3cr3t"), ageChunkSize/3)
+
+	encrypter, err := New(esv1beta1.EncryptionFormatAge, []string{recipient, otherRecipient})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name      string
+		plaintext []byte
+	}{
+		{name: "value", plaintext: []byte("s3cr3t")},
+		{name: "empty", plaintext: []byte{}},
+		{name: "multiple chunks", plaintext: large},
+		{name: "exactly one chunk", plaintext: large[:ageChunkSize]},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ciphertext, err := encrypter.Encrypt(tt.plaintext)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.HasPrefix(ciphertext, []byte(agearmor.Header)) {
+				t.Errorf("ciphertext is not armored")
+			}
+			// every recipient can decrypt the value
+			for _, id := range []string{identity, otherIdentity} {
+				decrypter, err := decryption.New(esv1beta1.DecryptionFormatAge, id)
+				if err != nil {
+					t.Fatal(err)
+				}
+				got, err := decrypter.Decrypt(ciphertext)
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if !bytes.Equal(got, tt.plaintext) {
+					t.Errorf("unexpected plaintext")
+				}
+			}
+		})
+	}
+
+	first, _ := encrypter.Encrypt([]byte("s3cr3t"))
+	second, _ := encrypter.Encrypt([]byte("s3cr3t"))
+	if bytes.Equal(first, second) {
+		t.Errorf("the ciphertext of the same plaintext must differ")
+	}
+
+	if _, err := New(esv1beta1.EncryptionFormatAge, []string{identity}); !errors.Is(err, errAgeInvalidRecipient) {
+		t.Errorf("expected invalid recipient error for an identity, got %v", err)
+	}
+}
+
+func TestGPGEncrypt(t *testing.T) {
+	entity, err := openpgp.NewEntity("eso", "", "eso@example.com", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var public bytes.Buffer
+	w, err := armor.Encode(&public, openpgp.PublicKeyType, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := entity.Serialize(w); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	encrypter, err := New(esv1beta1.EncryptionFormatGPG, []string{public.String()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ciphertext, err := encrypter.Encrypt([]byte("s3cr3t"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	block, err := armor.Decode(bytes.NewReader(ciphertext))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if block.Type != gpgMessageType {
+		t.Errorf("unexpected armor type %q", block.Type)
+	}
+	md, err := openpgp.ReadMessage(block.Body, openpgp.EntityList{entity}, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := io.ReadAll(md.UnverifiedBody)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != "s3cr3t" {
+		t.Errorf("unexpected plaintext")
+	}
+
+	if _, err := New(esv1beta1.EncryptionFormatGPG, []string{"not a key"}); !errors.Is(err, errGPGInvalidRecipient) {
+		t.Errorf("expected invalid recipient error, got %v", err)
+	}
+}
+
+func TestNew(t *testing.T) {
+	if _, err := New("ROT13", []string{"recipient"}); err == nil {
+		t.Errorf("expected an error for an unknown format")
+	}
+	if _, err := New(esv1beta1.EncryptionFormatAge, nil); err == nil {
+		t.Errorf("expected an error without recipients")
+	}
+}
@@ -0,0 +1,75 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package encryption
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/ProtonMail/go-crypto/openpgp/armor"
+)
+
+const gpgMessageType = "PGP MESSAGE"
+
+var (
+	errGPGInvalidRecipient = errors.New("invalid OpenPGP public key")
+	errGPGNoEncryptionKey  = errors.New("OpenPGP public key has no valid encryption key")
+)
+
+// parseGPGRecipients parses ASCII armored OpenPGP public keys, a key block may hold several keys.
+func parseGPGRecipients(recipients []string) ([]*openpgp.Entity, error) {
+	var entities []*openpgp.Entity
+	for _, recipient := range recipients {
+		list, err := openpgp.ReadArmoredKeyRing(strings.NewReader(recipient))
+		if err != nil || len(list) == 0 {
+			return nil, errGPGInvalidRecipient
+		}
+		for _, entity := range list {
+			if _, ok := entity.EncryptionKey(time.Now()); !ok {
+				return nil, errGPGNoEncryptionKey
+			}
+		}
+		entities = append(entities, list...)
+	}
+	return entities, nil
+}
+
+// gpgEncrypt encrypts plaintext for all entities and returns an armored OpenPGP message.
+// The message is not signed.
+func gpgEncrypt(entities []*openpgp.Entity, plaintext []byte) ([]byte, error) {
+	var out bytes.Buffer
+	armored, err := armor.Encode(&out, gpgMessageType, nil)
+	if err != nil {
+		return nil, err
+	}
+	w, err := openpgp.Encrypt(armored, entities, nil, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := w.Write(plaintext); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	if err := armored.Close(); err != nil {
+		return nil, err
+	}
+	out.WriteString("\n")
+	return out.Bytes(), nil
+}
@@ -16,7 +16,9 @@ package find
 
 import (
 	"fmt"
+	"maps"
 	"regexp"
+	"slices"
 
 	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
 )
@@ -38,3 +40,57 @@ func New(findName esv1beta1.FindName) (*Matcher, error) {
 func (m *Matcher) MatchName(name string) bool {
 	return m.re.MatchString(name)
 }
+
+// Exclude returns the secrets of secretMap that do not match any of the excludes.
+func Exclude(excludes []esv1beta1.FindExclude, secretMap map[string][]byte) (map[string][]byte, error) {
+	if len(excludes) == 0 {
+		return secretMap, nil
+	}
+	matchers := make([]*regexp.Regexp, 0, len(excludes))
+	for _, exclude := range excludes {
+		if exclude.RegExp == "" {
+			continue
+		}
+		re, err := regexp.Compile(exclude.RegExp)
+		if err != nil {
+			return nil, fmt.Errorf("could not compile find.exclude.regexp [%s]: %w", exclude.RegExp, err)
+		}
+		matchers = append(matchers, re)
+	}
+
+	out := make(map[string][]byte, len(secretMap))
+	for key, value := range secretMap {
+		if !isExcluded(key, excludes, matchers) {
+			out[key] = value
+		}
+	}
+	return out, nil
+}
+
+func isExcluded(key string, excludes []esv1beta1.FindExclude, matchers []*regexp.Regexp) bool {
+	for _, exclude := range excludes {
+		if exclude.Name != "" && exclude.Name == key {
+			return true
+		}
+	}
+	for _, re := range matchers {
+		if re.MatchString(key) {
+			return true
+		}
+	}
+	return false
+}
+
+// Limit returns the first limit secrets of secretMap in lexical order of their keys,
+// so the same subset is kept on every sync. A nil limit keeps all secrets.
+func Limit(limit *int, secretMap map[string][]byte) map[string][]byte {
+	if limit == nil || len(secretMap) <= *limit {
+		return secretMap
+	}
+	keys := slices.Sorted(maps.Keys(secretMap))
+	out := make(map[string][]byte, *limit)
+	for _, key := range keys[:max(*limit, 0)] {
+		out[key] = secretMap[key]
+	}
+	return out
+}
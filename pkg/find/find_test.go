@@ -0,0 +1,152 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package find
+
+import (
+	"maps"
+	"slices"
+	"sort"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/utils/ptr"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+)
+
+func TestExclude(t *testing.T) {
+	secrets := map[string][]byte{
+		"db-password":   []byte("a"),
+		"db-admin":      []byte("b"),
+		"api-key":       []byte("c"),
+		"api-key-admin": []byte("d"),
+	}
+	tests := []struct {
+		name     string
+		include  string
+		excludes []esv1beta1.FindExclude
+		want     []string
+		wantErr  bool
+	}{
+		{
+			name:    "no excludes",
+			include: ".*",
+			want:    []string{"api-key", "api-key-admin", "db-admin", "db-password"},
+		},
+		{
+			name:     "exclude by name",
+			include:  ".*",
+			excludes: []esv1beta1.FindExclude{{Name: "api-key"}},
+			want:     []string{"api-key-admin", "db-admin", "db-password"},
+		},
+		{
+			name:     "exclude wins over include",
+			include:  "^db-",
+			excludes: []esv1beta1.FindExclude{{RegExp: "admin$"}},
+			want:     []string{"db-password"},
+		},
+		{
+			name:     "name and regexp",
+			include:  ".*",
+			excludes: []esv1beta1.FindExclude{{Name: "db-password"}, {RegExp: "^api-"}},
+			want:     []string{"db-admin"},
+		},
+		{
+			name:     "name must match exactly",
+			include:  ".*",
+			excludes: []esv1beta1.FindExclude{{Name: "db"}},
+			want:     []string{"api-key", "api-key-admin", "db-admin", "db-password"},
+		},
+		{
+			name:     "invalid regexp",
+			include:  ".*",
+			excludes: []esv1beta1.FindExclude{{RegExp: "["}},
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, err := New(esv1beta1.FindName{RegExp: tt.include})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			found := make(map[string][]byte)
+			for k, v := range secrets {
+				if m.MatchName(k) {
+					found[k] = v
+				}
+			}
+			got, err := Exclude(tt.excludes, found)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			keys := make([]string, 0, len(got))
+			for k := range got {
+				keys = append(keys, k)
+			}
+			sort.Strings(keys)
+			if diff := cmp.Diff(tt.want, keys); diff != "" {
+				t.Errorf("unexpected keys (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestLimit(t *testing.T) {
+	secrets := map[string][]byte{
+		"db-password": []byte("a"),
+		"db-admin":    []byte("b"),
+		"api-key":     []byte("c"),
+		"cache-token": []byte("d"),
+	}
+	tests := []struct {
+		name  string
+		limit *int
+		want  []string
+	}{
+		{
+			name: "no limit",
+			want: []string{"api-key", "cache-token", "db-admin", "db-password"},
+		},
+		{
+			name:  "limit keeps the first keys in lexical order",
+			limit: ptr.To(2),
+			want:  []string{"api-key", "cache-token"},
+		},
+		{
+			name:  "limit above the number of secrets",
+			limit: ptr.To(10),
+			want:  []string{"api-key", "cache-token", "db-admin", "db-password"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// the same subset is kept regardless of the map iteration order
+			for range 10 {
+				got := Limit(tt.limit, maps.Clone(secrets))
+				keys := slices.Sorted(maps.Keys(got))
+				if diff := cmp.Diff(tt.want, keys); diff != "" {
+					t.Fatalf("unexpected keys (-want +got):\n%s", diff)
+				}
+			}
+		})
+	}
+}
@@ -42,6 +42,7 @@ import (
 	genv1alpha1 "github.com/external-secrets/external-secrets/apis/generators/v1alpha1"
 	smmeta "github.com/external-secrets/external-secrets/apis/meta/v1"
 	"github.com/external-secrets/external-secrets/pkg/provider/azure/keyvault"
+	"github.com/external-secrets/external-secrets/pkg/utils/resolvers"
 )
 
 type Generator struct {
@@ -339,21 +340,11 @@ func (g *Generator) accessTokenForServicePrincipal(ctx context.Context, crClient
 
 // secretKeyRef fetches a secret key.
 func secretKeyRef(ctx context.Context, crClient client.Client, namespace string, secretRef smmeta.SecretKeySelector) (string, error) {
-	var secret corev1.Secret
-	ref := types.NamespacedName{
-		Namespace: namespace,
-		Name:      secretRef.Name,
-	}
-	err := crClient.Get(ctx, ref, &secret)
+	value, err := resolvers.SecretKeyRef(ctx, crClient, resolvers.EmptyStoreKind, namespace, &secretRef)
 	if err != nil {
-		return "", fmt.Errorf("unable to find namespace=%q secret=%q %w", ref.Namespace, ref.Name, err)
-	}
-	keyBytes, ok := secret.Data[secretRef.Key]
-	if !ok {
-		return "", fmt.Errorf("unable to find key=%q secret=%q namespace=%q", secretRef.Key, secretRef.Name, namespace)
+		return "", err
 	}
-	value := strings.TrimSpace(string(keyBytes))
-	return value, nil
+	return strings.TrimSpace(value), nil
 }
 
 func audienceForType(t v1beta1.AzureEnvironmentType) string {
@@ -0,0 +1,125 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deterministic
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+
+	"golang.org/x/crypto/hkdf"
+	apiextensions "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	genv1alpha1 "github.com/external-secrets/external-secrets/apis/generators/v1alpha1"
+	"github.com/external-secrets/external-secrets/pkg/utils/resolvers"
+)
+
+// Generator derives a value with HKDF-SHA256 from a seed secret, a salt and a label.
+type Generator struct{}
+
+const (
+	valueKey = "value"
+
+	defaultLength = 32
+	// maxLength is the maximum output of HKDF-SHA256 (255 * hash size).
+	maxLength = 255 * sha256.Size
+	// minSeedLength rejects seeds that are too short to be a key.
+	minSeedLength = 16
+
+	errNoSpec        = "no config spec provided"
+	errParseSpec     = "unable to parse spec: %w"
+	errNoSalt        = "salt must not be empty"
+	errInvalidLength = "length must be between 1 and %d, got %d"
+	errInvalidEncode = "unsupported encoding %q"
+	errGetSeed       = "unable to get seed secret %s: %w"
+	errSeedLength    = "seed must be at least %d bytes long, got %d"
+	errDerive        = "unable to derive value: %w"
+)
+
+func (g *Generator) Generate(ctx context.Context, jsonSpec *apiextensions.JSON, kube client.Client, namespace string) (map[string][]byte, error) {
+	if jsonSpec == nil {
+		return nil, errors.New(errNoSpec)
+	}
+	res, err := parseSpec(jsonSpec.Raw)
+	if err != nil {
+		return nil, fmt.Errorf(errParseSpec, err)
+	}
+	seed, err := getSeed(ctx, kube, namespace, &res.Spec)
+	if err != nil {
+		return nil, err
+	}
+	value, err := derive(seed, &res.Spec)
+	if err != nil {
+		return nil, err
+	}
+	return map[string][]byte{valueKey: value}, nil
+}
+
+func getSeed(ctx context.Context, kube client.Client, namespace string, spec *genv1alpha1.DeterministicSecretSpec) ([]byte, error) {
+	ref := spec.SeedSecretRef
+	seed, err := resolvers.SecretKeyRef(ctx, kube, resolvers.EmptyStoreKind, namespace, &ref)
+	if err != nil {
+		return nil, fmt.Errorf(errGetSeed, ref.Name, err)
+	}
+	return []byte(seed), nil
+}
+
+// derive returns the encoded HKDF output for the seed and the spec.
+// The same seed, salt, label and length always result in the same value.
+func derive(seed []byte, spec *genv1alpha1.DeterministicSecretSpec) ([]byte, error) {
+	if len(seed) < minSeedLength {
+		return nil, fmt.Errorf(errSeedLength, minSeedLength, len(seed))
+	}
+	if spec.Salt == "" {
+		return nil, errors.New(errNoSalt)
+	}
+	length := spec.Length
+	if length == 0 {
+		length = defaultLength
+	}
+	if length < 0 || length > maxLength {
+		return nil, fmt.Errorf(errInvalidLength, maxLength, length)
+	}
+	out := make([]byte, length)
+	if _, err := io.ReadFull(hkdf.New(sha256.New, seed, []byte(spec.Salt), []byte(spec.Label)), out); err != nil {
+		return nil, fmt.Errorf(errDerive, err)
+	}
+	switch spec.Encoding {
+	case "", genv1alpha1.DeterministicSecretEncodingRaw:
+		return out, nil
+	case genv1alpha1.DeterministicSecretEncodingBase64:
+		return []byte(base64.StdEncoding.EncodeToString(out)), nil
+	case genv1alpha1.DeterministicSecretEncodingHex:
+		return []byte(hex.EncodeToString(out)), nil
+	default:
+		return nil, fmt.Errorf(errInvalidEncode, spec.Encoding)
+	}
+}
+
+func parseSpec(data []byte) (*genv1alpha1.DeterministicSecret, error) {
+	var spec genv1alpha1.DeterministicSecret
+	err := yaml.Unmarshal(data, &spec)
+	return &spec, err
+}
+
+func init() {
+	genv1alpha1.Register(genv1alpha1.DeterministicSecretKind, &Generator{})
+}
@@ -0,0 +1,163 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deterministic
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	apiextensions "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clientfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	genv1alpha1 "github.com/external-secrets/external-secrets/apis/generators/v1alpha1"
+)
+
+const testSeed = "0123456789abcdef0123456789abcdef"
+
+func spec(salt, extra string) *apiextensions.JSON {
+	return &apiextensions.JSON{
+		Raw: []byte(`apiVersion: generators.external-secrets.io/v1alpha1
+kind: DeterministicSecret
+spec:
+  seedSecretRef:
+    name: seed
+    key: key
+  salt: "` + salt + `"
+` + extra),
+	}
+}
+
+func generate(t *testing.T, jsonSpec *apiextensions.JSON, seed string) (map[string][]byte, error) {
+	t.Helper()
+	kube := clientfake.NewClientBuilder().WithObjects(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "seed", Namespace: "default"},
+		Data:       map[string][]byte{"key": []byte(seed)},
+	}).Build()
+	return (&Generator{}).Generate(context.Background(), jsonSpec, kube, "default")
+}
+
+func TestGenerateIsDeterministic(t *testing.T) {
+	first, err := generate(t, spec("db-password", ""), testSeed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := generate(t, spec("db-password", ""), testSeed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(first[valueKey]) != defaultLength {
+		t.Errorf("unexpected length %d, want %d", len(first[valueKey]), defaultLength)
+	}
+	if !bytes.Equal(first[valueKey], second[valueKey]) {
+		t.Errorf("same seed and salt resulted in different values")
+	}
+
+	for name, other := range map[string]*apiextensions.JSON{
+		"salt":  spec("db-password-v2", ""),
+		"label": spec("db-password", "  label: other\n"),
+	} {
+		got, err := generate(t, other, testSeed)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if bytes.Equal(first[valueKey], got[valueKey]) {
+			t.Errorf("a different %s resulted in the same value", name)
+		}
+	}
+	got, err := generate(t, spec("db-password", ""), strings.Repeat("x", 32))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Equal(first[valueKey], got[valueKey]) {
+		t.Errorf("a different seed resulted in the same value")
+	}
+}
+
+func TestDeriveKnownValue(t *testing.T) {
+	// RFC 5869 test case 1
+	seed, _ := hex.DecodeString("0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b")
+	salt, _ := hex.DecodeString("000102030405060708090a0b0c")
+	label, _ := hex.DecodeString("f0f1f2f3f4f5f6f7f8f9")
+	got, err := derive(seed, &genv1alpha1.DeterministicSecretSpec{
+		Salt:     string(salt),
+		Label:    string(label),
+		Length:   42,
+		Encoding: genv1alpha1.DeterministicSecretEncodingHex,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "3cb25f25faacd57a90434f64d0362f2a2d2d0a90cf1a5a4c5db02d56ecc4c5bf34007208d5b887185865"
+	if string(got) != want {
+		t.Errorf("got %s, want %s", got, want)
+	}
+}
+
+func TestGenerateErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		jsonSpec *apiextensions.JSON
+		seed     string
+		wantErr  string
+	}{
+		{
+			name:    "nil spec",
+			wantErr: errNoSpec,
+		},
+		{
+			name:     "short seed",
+			jsonSpec: spec("salt", ""),
+			seed:     "short",
+			wantErr:  "seed must be at least 16 bytes long",
+		},
+		{
+			name:     "empty salt",
+			jsonSpec: spec("", ""),
+			seed:     testSeed,
+			wantErr:  errNoSalt,
+		},
+		{
+			name:     "invalid length",
+			jsonSpec: spec("salt", "  length: 9000\n"),
+			seed:     testSeed,
+			wantErr:  "length must be between 1 and 8160",
+		},
+		{
+			name:     "invalid encoding",
+			jsonSpec: spec("salt", "  encoding: Base32\n"),
+			seed:     testSeed,
+			wantErr:  "unsupported encoding",
+		},
+		{
+			name:     "missing seed key",
+			jsonSpec: &apiextensions.JSON{Raw: []byte("spec:\n  seedSecretRef:\n    name: seed\n    key: other\n  salt: s\n")},
+			seed:     testSeed,
+			wantErr:  `unable to get seed secret seed: cannot find secret data for key: "other"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := generate(t, tt.jsonSpec, tt.seed)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Generate() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,178 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gcpkms
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+
+	"golang.org/x/oauth2"
+	cloudkms "google.golang.org/api/cloudkms/v1"
+	"google.golang.org/api/option"
+	apiextensions "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	genv1alpha1 "github.com/external-secrets/external-secrets/apis/generators/v1alpha1"
+	"github.com/external-secrets/external-secrets/pkg/provider/gcp/secretmanager"
+	"github.com/external-secrets/external-secrets/pkg/utils/resolvers"
+)
+
+// Generator decrypts a ciphertext with Cloud KMS.
+// The plaintext is kept in memory per spec and namespace, so Cloud KMS
+// is only called again when the ciphertext (or any other field of the spec) changes.
+type Generator struct {
+	mu    sync.Mutex
+	cache map[string][]byte
+}
+
+const (
+	// maxCacheEntries bounds the memory used by the plaintext cache,
+	// the cache is cleared once it is full.
+	maxCacheEntries = 1024
+
+	plaintextKey = "plaintext"
+
+	errNoSpec          = "no config spec provided"
+	errParseSpec       = "unable to parse spec: %w"
+	errInvalidBase64   = "%s is not valid base64: %w"
+	errKMSClient       = "unable to create Cloud KMS client: %w"
+	errDecrypt         = "unable to decrypt ciphertext with key %s: %w"
+	errDecodePlaintext = "unable to decode plaintext returned by Cloud KMS: %w"
+)
+
+func (g *Generator) Generate(ctx context.Context, jsonSpec *apiextensions.JSON, kube client.Client, namespace string) (map[string][]byte, error) {
+	return g.generate(
+		ctx,
+		jsonSpec,
+		kube,
+		namespace,
+		secretmanager.NewTokenSource,
+		decrypt,
+	)
+}
+
+func (g *Generator) generate(
+	ctx context.Context,
+	jsonSpec *apiextensions.JSON,
+	kube client.Client,
+	namespace string,
+	tokenSource tokenSourceFunc,
+	decrypt decryptFunc) (map[string][]byte, error) {
+	if jsonSpec == nil {
+		return nil, errors.New(errNoSpec)
+	}
+	res, err := parseSpec(jsonSpec.Raw)
+	if err != nil {
+		return nil, fmt.Errorf(errParseSpec, err)
+	}
+	if _, err := base64.StdEncoding.DecodeString(res.Spec.Ciphertext); err != nil {
+		return nil, fmt.Errorf(errInvalidBase64, "ciphertext", err)
+	}
+	if _, err := base64.StdEncoding.DecodeString(res.Spec.AdditionalAuthenticatedData); err != nil {
+		return nil, fmt.Errorf(errInvalidBase64, "additionalAuthenticatedData", err)
+	}
+	key, err := cacheKey(namespace, &res.Spec)
+	if err != nil {
+		return nil, err
+	}
+	if plaintext, ok := g.get(key); ok {
+		return map[string][]byte{plaintextKey: plaintext}, nil
+	}
+	ts, err := tokenSource(ctx, esv1beta1.GCPSMAuth{
+		SecretRef:        (*esv1beta1.GCPSMAuthSecretRef)(res.Spec.Auth.SecretRef),
+		WorkloadIdentity: (*esv1beta1.GCPWorkloadIdentity)(res.Spec.Auth.WorkloadIdentity),
+	}, res.Spec.ProjectID, resolvers.EmptyStoreKind, kube, namespace)
+	if err != nil {
+		return nil, err
+	}
+	plaintext, err := decrypt(ctx, ts, &cloudkms.DecryptRequest{
+		Ciphertext:                  res.Spec.Ciphertext,
+		AdditionalAuthenticatedData: res.Spec.AdditionalAuthenticatedData,
+	}, res.Spec.KeyName)
+	if err != nil {
+		// do not wrap the response, it must never contain the plaintext
+		return nil, fmt.Errorf(errDecrypt, res.Spec.KeyName, err)
+	}
+	g.set(key, plaintext)
+	return map[string][]byte{plaintextKey: plaintext}, nil
+}
+
+func (g *Generator) get(key string) ([]byte, bool) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	plaintext, ok := g.cache[key]
+	return plaintext, ok
+}
+
+func (g *Generator) set(key string, plaintext []byte) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.cache == nil || len(g.cache) >= maxCacheEntries {
+		g.cache = make(map[string][]byte)
+	}
+	g.cache[key] = plaintext
+}
+
+// cacheKey identifies a decryption by the namespace and the whole spec,
+// so a different auth configuration never reuses the plaintext of another.
+func cacheKey(namespace string, spec *genv1alpha1.GCPKMSDecryptSpec) (string, error) {
+	data, err := json.Marshal(spec)
+	if err != nil {
+		return "", err
+	}
+	h := sha256.New()
+	h.Write([]byte(namespace))
+	h.Write([]byte{0})
+	h.Write(data)
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+type tokenSourceFunc func(ctx context.Context, auth esv1beta1.GCPSMAuth, projectID string, storeKind string, kube client.Client, namespace string) (oauth2.TokenSource, error)
+
+type decryptFunc func(ctx context.Context, ts oauth2.TokenSource, req *cloudkms.DecryptRequest, keyName string) ([]byte, error)
+
+func decrypt(ctx context.Context, ts oauth2.TokenSource, req *cloudkms.DecryptRequest, keyName string) ([]byte, error) {
+	svc, err := cloudkms.NewService(ctx, option.WithTokenSource(ts))
+	if err != nil {
+		return nil, fmt.Errorf(errKMSClient, err)
+	}
+	resp, err := svc.Projects.Locations.KeyRings.CryptoKeys.Decrypt(keyName, req).Context(ctx).Do()
+	if err != nil {
+		return nil, err
+	}
+	plaintext, err := base64.StdEncoding.DecodeString(resp.Plaintext)
+	if err != nil {
+		return nil, fmt.Errorf(errDecodePlaintext, err)
+	}
+	return plaintext, nil
+}
+
+func parseSpec(data []byte) (*genv1alpha1.GCPKMSDecrypt, error) {
+	var spec genv1alpha1.GCPKMSDecrypt
+	err := yaml.Unmarshal(data, &spec)
+	return &spec, err
+}
+
+func init() {
+	genv1alpha1.Register(genv1alpha1.GCPKMSDecryptKind, &Generator{})
+}
@@ -0,0 +1,157 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gcpkms
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"golang.org/x/oauth2"
+	cloudkms "google.golang.org/api/cloudkms/v1"
+	apiextensions "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	clientfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+)
+
+const (
+	testKeyName = "projects/foo/locations/global/keyRings/bar/cryptoKeys/baz"
+	// base64 of "ciphertext"
+	testCiphertext = "Y2lwaGVydGV4dA=="
+)
+
+func specWithCiphertext(ciphertext string) *apiextensions.JSON {
+	return &apiextensions.JSON{
+		Raw: []byte(`apiVersion: generators.external-secrets.io/v1alpha1
+kind: GCPKMSDecrypt
+spec:
+  projectID: "foo"
+  keyName: "` + testKeyName + `"
+  ciphertext: "` + ciphertext + `"
+  auth:
+    secretRef:
+      secretAccessKeySecretRef:
+        name: "example"
+        key: "foo"
+`),
+	}
+}
+
+func fakeTokenSource(ctx context.Context, auth v1beta1.GCPSMAuth, projectID string, storeKind string, kube client.Client, namespace string) (oauth2.TokenSource, error) {
+	return oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "1234"}), nil
+}
+
+func TestGenerate(t *testing.T) {
+	tests := []struct {
+		name     string
+		jsonSpec *apiextensions.JSON
+		decrypt  decryptFunc
+		want     map[string][]byte
+		wantErr  string
+	}{
+		{
+			name:    "nil spec",
+			wantErr: errNoSpec,
+		},
+		{
+			name:     "invalid ciphertext",
+			jsonSpec: specWithCiphertext("not base64!"),
+			wantErr:  "ciphertext is not valid base64",
+		},
+		{
+			name:     "decrypt error",
+			jsonSpec: specWithCiphertext(testCiphertext),
+			decrypt: func(ctx context.Context, ts oauth2.TokenSource, req *cloudkms.DecryptRequest, keyName string) ([]byte, error) {
+				return nil, errors.New("permission denied")
+			},
+			wantErr: "unable to decrypt ciphertext with key " + testKeyName + ": permission denied",
+		},
+		{
+			name:     "full spec",
+			jsonSpec: specWithCiphertext(testCiphertext),
+			decrypt: func(ctx context.Context, ts oauth2.TokenSource, req *cloudkms.DecryptRequest, keyName string) ([]byte, error) {
+				if keyName != testKeyName || req.Ciphertext != testCiphertext {
+					return nil, errors.New("unexpected request")
+				}
+				return []byte("plaintext"), nil
+			},
+			want: map[string][]byte{
+				"plaintext": []byte("plaintext"),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := &Generator{}
+			got, err := g.generate(
+				context.Background(),
+				tt.jsonSpec,
+				clientfake.NewClientBuilder().Build(),
+				"foobar",
+				fakeTokenSource,
+				tt.decrypt)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("Generator.Generate() error = %v, wantErr %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Generator.Generate() unexpected error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Generator.Generate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGenerateCache(t *testing.T) {
+	calls := 0
+	decrypt := func(ctx context.Context, ts oauth2.TokenSource, req *cloudkms.DecryptRequest, keyName string) ([]byte, error) {
+		calls++
+		return []byte(req.Ciphertext), nil
+	}
+	g := &Generator{}
+	kube := clientfake.NewClientBuilder().Build()
+	generate := func(namespace, ciphertext string) string {
+		got, err := g.generate(context.Background(), specWithCiphertext(ciphertext), kube, namespace, fakeTokenSource, decrypt)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return string(got[plaintextKey])
+	}
+
+	if got := generate("foo", testCiphertext); got != testCiphertext {
+		t.Errorf("unexpected plaintext %q", got)
+	}
+	generate("foo", testCiphertext)
+	if calls != 1 {
+		t.Errorf("expected the plaintext to be cached, decrypt was called %d times", calls)
+	}
+
+	// a new ciphertext or namespace must be decrypted again
+	if got := generate("foo", "YmF6"); got != "YmF6" {
+		t.Errorf("unexpected plaintext %q", got)
+	}
+	generate("bar", testCiphertext)
+	if calls != 3 {
+		t.Errorf("expected decrypt to be called 3 times, got %d", calls)
+	}
+}
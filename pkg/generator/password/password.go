@@ -25,6 +25,7 @@ import (
 	"sigs.k8s.io/yaml"
 
 	genv1alpha1 "github.com/external-secrets/external-secrets/apis/generators/v1alpha1"
+	"github.com/external-secrets/external-secrets/pkg/utils/valuepolicy"
 )
 
 type Generator struct{}
@@ -34,10 +35,14 @@ const (
 	defaultSymbolChars = "~!@#$%^&*()_+`-={}|[]\\:\"<>?,./"
 	digitFactor        = 0.25
 	symbolFactor       = 0.25
+	// policyAttempts is the number of passwords generated until one complies with the policy.
+	// A random password can miss a character class, e.g. uppercase letters are not guaranteed.
+	policyAttempts = 5
 
 	errNoSpec    = "no config spec provided"
 	errParseSpec = "unable to parse spec: %w"
 	errGetToken  = "unable to get authorization token: %w"
+	errPolicy    = "generated password does not comply with the policy after %d attempts: %w"
 )
 
 type generateFunc func(
@@ -80,20 +85,27 @@ func (g *Generator) generate(jsonSpec *apiextensions.JSON, passGen generateFunc)
 	if res.Spec.Symbols != nil {
 		symbols = *res.Spec.Symbols
 	}
-	pass, err := passGen(
-		passLen,
-		symbols,
-		symbolCharacters,
-		digits,
-		res.Spec.NoUpper,
-		res.Spec.AllowRepeat,
-	)
-	if err != nil {
-		return nil, err
+	var policyErr error
+	for range policyAttempts {
+		pass, err := passGen(
+			passLen,
+			symbols,
+			symbolCharacters,
+			digits,
+			res.Spec.NoUpper,
+			res.Spec.AllowRepeat,
+		)
+		if err != nil {
+			return nil, err
+		}
+		policyErr = valuepolicy.Check(res.Spec.Policy, []byte(pass))
+		if policyErr == nil {
+			return map[string][]byte{
+				"password": []byte(pass),
+			}, nil
+		}
 	}
-	return map[string][]byte{
-		"password": []byte(pass),
-	}, nil
+	return nil, fmt.Errorf(errPolicy, policyAttempts, policyErr)
 }
 
 func generateSafePassword(
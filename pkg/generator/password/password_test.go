@@ -108,6 +108,40 @@ func TestGenerate(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "password is generated again until it complies with the policy",
+			args: args{
+				jsonSpec: &apiextensions.JSON{
+					Raw: []byte(`{"spec":{"policy":{"requiredClasses":["Uppercase","Digit"]}}}`),
+				},
+				passGen: sequenceGen("foobar", "Foobar", "Foobar1"),
+			},
+			want: map[string][]byte{
+				"password": []byte(`Foobar1`),
+			},
+		},
+		{
+			name: "compliant password is returned unchanged",
+			args: args{
+				jsonSpec: &apiextensions.JSON{
+					Raw: []byte(`{"spec":{"policy":{"minLength":6}}}`),
+				},
+				passGen: sequenceGen("foobar"),
+			},
+			want: map[string][]byte{
+				"password": []byte(`foobar`),
+			},
+		},
+		{
+			name: "policy violation should be returned after all attempts",
+			args: args{
+				jsonSpec: &apiextensions.JSON{
+					Raw: []byte(`{"spec":{"policy":{"minLength":12}}}`),
+				},
+				passGen: sequenceGen("foobar"),
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -123,3 +157,13 @@ func TestGenerate(t *testing.T) {
 		})
 	}
 }
+
+// sequenceGen returns a generateFunc that returns the passwords in order and repeats the last one.
+func sequenceGen(passwords ...string) generateFunc {
+	i := 0
+	return func(int, int, string, int, bool, bool) (string, error) {
+		pass := passwords[min(i, len(passwords)-1)]
+		i++
+		return pass, nil
+	}
+}
@@ -0,0 +1,114 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package serviceaccounttoken
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	authv1 "k8s.io/api/authentication/v1"
+	corev1 "k8s.io/api/core/v1"
+	apiextensions "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	genv1alpha1 "github.com/external-secrets/external-secrets/apis/generators/v1alpha1"
+)
+
+type Generator struct{}
+
+var _ genv1alpha1.ExpiringGenerator = &Generator{}
+
+const (
+	defaultExpirationSeconds = int64(3600)
+	annotationValueAllowed   = "true"
+
+	errNoSpec            = "no config spec provided"
+	errParseSpec         = "unable to parse spec: %w"
+	errNoServiceAccount  = "serviceAccountName must be set"
+	errGetServiceAccount = "unable to get service account %s: %w"
+	errNotAllowed        = "service account %s does not allow token generation, it must be annotated with %s: \"true\""
+	errCreateToken       = "unable to create token for service account %s: %w"
+	errNoToken           = "token request for service account %s returned no token"
+)
+
+func (g *Generator) Generate(ctx context.Context, jsonSpec *apiextensions.JSON, kube client.Client, namespace string) (map[string][]byte, error) {
+	data, _, err := g.GenerateWithExpiry(ctx, jsonSpec, kube, namespace)
+	return data, err
+}
+
+// GenerateWithExpiry returns the token and the time it expires,
+// so the ExternalSecret requests a new token before it expires.
+func (g *Generator) GenerateWithExpiry(ctx context.Context, jsonSpec *apiextensions.JSON, kube client.Client, namespace string) (map[string][]byte, time.Time, error) {
+	if jsonSpec == nil {
+		return nil, time.Time{}, errors.New(errNoSpec)
+	}
+	res, err := parseSpec(jsonSpec.Raw)
+	if err != nil {
+		return nil, time.Time{}, fmt.Errorf(errParseSpec, err)
+	}
+	if res.Spec.ServiceAccountName == "" {
+		return nil, time.Time{}, errors.New(errNoServiceAccount)
+	}
+
+	// The controller is allowed to request tokens for every service account in the cluster.
+	// Tokens are only requested for service accounts in the namespace of the ExternalSecret
+	// that explicitly opted in, so creating a generator does not grant access to arbitrary identities.
+	sa := &corev1.ServiceAccount{}
+	err = kube.Get(ctx, client.ObjectKey{Namespace: namespace, Name: res.Spec.ServiceAccountName}, sa)
+	if err != nil {
+		return nil, time.Time{}, fmt.Errorf(errGetServiceAccount, res.Spec.ServiceAccountName, err)
+	}
+	if sa.Annotations[genv1alpha1.AnnotationAllowServiceAccountToken] != annotationValueAllowed {
+		return nil, time.Time{}, fmt.Errorf(errNotAllowed, res.Spec.ServiceAccountName, genv1alpha1.AnnotationAllowServiceAccountToken)
+	}
+
+	expirationSeconds := defaultExpirationSeconds
+	if res.Spec.ExpirationSeconds != nil {
+		expirationSeconds = *res.Spec.ExpirationSeconds
+	}
+	tokenRequest := &authv1.TokenRequest{
+		Spec: authv1.TokenRequestSpec{
+			Audiences:         res.Spec.Audiences,
+			ExpirationSeconds: &expirationSeconds,
+		},
+	}
+	err = kube.SubResource("token").Create(ctx, sa, tokenRequest)
+	if err != nil {
+		return nil, time.Time{}, fmt.Errorf(errCreateToken, res.Spec.ServiceAccountName, err)
+	}
+	if tokenRequest.Status.Token == "" {
+		return nil, time.Time{}, fmt.Errorf(errNoToken, res.Spec.ServiceAccountName)
+	}
+
+	expiry := tokenRequest.Status.ExpirationTimestamp.UTC()
+	return map[string][]byte{
+		"token":  []byte(tokenRequest.Status.Token),
+		"expiry": []byte(strconv.FormatInt(expiry.Unix(), 10)),
+	}, expiry, nil
+}
+
+func parseSpec(data []byte) (*genv1alpha1.ServiceAccountToken, error) {
+	var spec genv1alpha1.ServiceAccountToken
+	err := yaml.Unmarshal(data, &spec)
+	return &spec, err
+}
+
+func init() {
+	genv1alpha1.Register(genv1alpha1.ServiceAccountTokenKind, &Generator{})
+}
@@ -0,0 +1,198 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package serviceaccounttoken
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	authv1 "k8s.io/api/authentication/v1"
+	corev1 "k8s.io/api/core/v1"
+	apiextensions "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	clientfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	genv1alpha1 "github.com/external-secrets/external-secrets/apis/generators/v1alpha1"
+)
+
+const (
+	testNamespace = "foobar"
+	testSA        = "my-sa"
+)
+
+func newServiceAccount(namespace string, annotations map[string]string) *corev1.ServiceAccount {
+	return &corev1.ServiceAccount{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        testSA,
+			Namespace:   namespace,
+			Annotations: annotations,
+		},
+	}
+}
+
+// fakeTokenRequest answers token requests and records the last request.
+func fakeTokenRequest(got *authv1.TokenRequest, err error) interceptor.Funcs {
+	return interceptor.Funcs{
+		SubResourceCreate: func(ctx context.Context, c client.Client, subResourceName string, obj, subResource client.Object, opts ...client.SubResourceCreateOption) error {
+			if err != nil {
+				return err
+			}
+			tr, ok := subResource.(*authv1.TokenRequest)
+			if subResourceName != "token" || !ok {
+				return errors.New("unexpected sub resource")
+			}
+			tr.Spec.DeepCopyInto(&got.Spec)
+			got.Name = obj.GetName()
+			got.Namespace = obj.GetNamespace()
+			tr.Status = authv1.TokenRequestStatus{
+				Token:               "token-" + obj.GetName(),
+				ExpirationTimestamp: metav1.NewTime(time.Unix(5555, 0)),
+			}
+			return nil
+		},
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	allowed := map[string]string{genv1alpha1.AnnotationAllowServiceAccountToken: "true"}
+	fullSpec := []byte(`apiVersion: generators.external-secrets.io/v1alpha1
+kind: ServiceAccountToken
+spec:
+  serviceAccountName: "my-sa"
+  audiences: ["https://example.com"]
+  expirationSeconds: 600
+`)
+	tests := []struct {
+		name        string
+		spec        []byte
+		objects     []client.Object
+		createErr   error
+		want        map[string][]byte
+		wantRequest *authv1.TokenRequestSpec
+		wantErr     bool
+	}{
+		{
+			name:    "nil spec",
+			wantErr: true,
+		},
+		{
+			name: "full spec",
+			spec: fullSpec,
+			objects: []client.Object{
+				newServiceAccount(testNamespace, allowed),
+			},
+			want: map[string][]byte{
+				"token":  []byte("token-my-sa"),
+				"expiry": []byte("5555"),
+			},
+			wantRequest: &authv1.TokenRequestSpec{
+				Audiences:         []string{"https://example.com"},
+				ExpirationSeconds: ptr.To(int64(600)),
+			},
+		},
+		{
+			name: "default expiration",
+			spec: []byte(`spec: {serviceAccountName: "my-sa"}`),
+			objects: []client.Object{
+				newServiceAccount(testNamespace, allowed),
+			},
+			want: map[string][]byte{
+				"token":  []byte("token-my-sa"),
+				"expiry": []byte("5555"),
+			},
+			wantRequest: &authv1.TokenRequestSpec{
+				ExpirationSeconds: ptr.To(defaultExpirationSeconds),
+			},
+		},
+		{
+			name:    "missing service account name",
+			spec:    []byte(`spec: {}`),
+			wantErr: true,
+		},
+		{
+			name: "service account without annotation",
+			spec: fullSpec,
+			objects: []client.Object{
+				newServiceAccount(testNamespace, nil),
+			},
+			wantErr: true,
+		},
+		{
+			name: "service account with disabled annotation",
+			spec: fullSpec,
+			objects: []client.Object{
+				newServiceAccount(testNamespace, map[string]string{genv1alpha1.AnnotationAllowServiceAccountToken: "false"}),
+			},
+			wantErr: true,
+		},
+		{
+			name: "service account in other namespace",
+			spec: fullSpec,
+			objects: []client.Object{
+				newServiceAccount("other", allowed),
+			},
+			wantErr: true,
+		},
+		{
+			name: "token request fails",
+			spec: fullSpec,
+			objects: []client.Object{
+				newServiceAccount(testNamespace, allowed),
+			},
+			createErr: errors.New("forbidden"),
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := &authv1.TokenRequest{}
+			kube := clientfake.NewClientBuilder().
+				WithObjects(tt.objects...).
+				WithInterceptorFuncs(fakeTokenRequest(got, tt.createErr)).
+				Build()
+			var jsonSpec *apiextensions.JSON
+			if tt.spec != nil {
+				jsonSpec = &apiextensions.JSON{Raw: tt.spec}
+			}
+			g := &Generator{}
+			res, expiry, err := g.GenerateWithExpiry(context.Background(), jsonSpec, kube, testNamespace)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Generator.GenerateWithExpiry() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(res, tt.want) {
+				t.Errorf("Generator.GenerateWithExpiry() = %v, want %v", res, tt.want)
+			}
+			if tt.want != nil && !expiry.Equal(time.Unix(5555, 0)) {
+				t.Errorf("Generator.GenerateWithExpiry() expiry = %v, want %v", expiry, time.Unix(5555, 0))
+			}
+			if tt.wantRequest == nil {
+				return
+			}
+			if got.Name != testSA || got.Namespace != testNamespace {
+				t.Errorf("token requested for %s/%s, want %s/%s", got.Namespace, got.Name, testNamespace, testSA)
+			}
+			if !reflect.DeepEqual(got.Spec, *tt.wantRequest) {
+				t.Errorf("token request = %v, want %v", got.Spec, *tt.wantRequest)
+			}
+		})
+	}
+}
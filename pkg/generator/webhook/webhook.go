@@ -24,6 +24,7 @@ import (
 
 	genv1alpha1 "github.com/external-secrets/external-secrets/apis/generators/v1alpha1"
 	"github.com/external-secrets/external-secrets/pkg/common/webhook"
+	"github.com/external-secrets/external-secrets/pkg/utils/clockskew"
 )
 
 type Webhook struct {
@@ -42,6 +43,7 @@ func (w *Webhook) Generate(ctx context.Context, jsonSpec *apiextensions.JSON, kc
 	w.wh.Namespace = ns
 	w.url = provider.URL
 	w.wh.Kube = kclient
+	w.wh.ClockSkew = clockskew.Default()
 	w.wh.HTTP, err = w.wh.GetHTTPClient(ctx, provider)
 	if err != nil {
 		return nil, fmt.Errorf("failed to prepare provider http client: %w", err)
@@ -24,6 +24,7 @@ import (
 const (
 	ExternalSecretSubsystem = "externalsecret"
 	providerAPICalls        = "provider_api_calls_count"
+	providerConnections     = "provider_open_connections"
 )
 
 var (
@@ -32,12 +33,28 @@ var (
 		Name:      providerAPICalls,
 		Help:      "Number of API calls towards the secret provider",
 	}, []string{"provider", "call", "status"})
+
+	openConnections = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Subsystem: ExternalSecretSubsystem,
+		Name:      providerConnections,
+		Help:      "Number of open connections towards the secret provider",
+	}, []string{"provider"})
 )
 
 func ObserveAPICall(provider, call string, err error) {
 	syncCallsTotal.WithLabelValues(provider, call, deriveStatus(err)).Inc()
 }
 
+// ObserveConnectionOpened increments the number of open connections of the provider.
+func ObserveConnectionOpened(provider string) {
+	openConnections.WithLabelValues(provider).Inc()
+}
+
+// ObserveConnectionClosed decrements the number of open connections of the provider.
+func ObserveConnectionClosed(provider string) {
+	openConnections.WithLabelValues(provider).Dec()
+}
+
 func deriveStatus(err error) string {
 	if err != nil {
 		return constants.StatusError
@@ -46,5 +63,5 @@ func deriveStatus(err error) string {
 }
 
 func init() {
-	metrics.Registry.MustRegister(syncCallsTotal)
+	metrics.Registry.MustRegister(syncCallsTotal, openConnections)
 }
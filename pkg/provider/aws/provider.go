@@ -17,6 +17,7 @@ package aws
 import (
 	"context"
 	"fmt"
+	"net/url"
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
@@ -25,6 +26,7 @@ import (
 	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/aws/aws-sdk-go/aws/session"
 	awssm "github.com/aws/aws-sdk-go/service/secretsmanager"
+	"github.com/aws/aws-sdk-go/service/sqs"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
@@ -38,6 +40,8 @@ import (
 
 // https://github.com/external-secrets/external-secrets/issues/644
 var _ esv1beta1.Provider = &Provider{}
+var _ esv1beta1.ErrorClassifier = &Provider{}
+var _ esv1beta1.SecretWatcher = &Provider{}
 
 // Provider satisfies the provider interface.
 type Provider struct{}
@@ -48,6 +52,7 @@ const (
 	errRegionNotFound         = "region not found: %s"
 	errInitAWSProvider        = "unable to initialize aws provider: %s"
 	errInvalidSecretsManager  = "invalid SecretsManager settings: %s"
+	errInvalidQueueURL        = "invalid SecretsManager.ChangeNotifications.QueueURL: %q must be an https URL"
 )
 
 // Capabilities return the provider supported capabilities (ReadOnly, WriteOnly, ReadWrite).
@@ -55,11 +60,37 @@ func (p *Provider) Capabilities() esv1beta1.SecretStoreCapabilities {
 	return esv1beta1.SecretStoreReadWrite
 }
 
+// ClassifyError maps the errors of the AWS APIs to provider error reasons.
+func (p *Provider) ClassifyError(err error) esv1beta1.ProviderErrorReason {
+	return util.ClassifyError(err)
+}
+
 // NewClient constructs a new secrets client based on the provided store.
 func (p *Provider) NewClient(ctx context.Context, store esv1beta1.GenericStore, kube client.Client, namespace string) (esv1beta1.SecretsClient, error) {
 	return newClient(ctx, store, kube, namespace, awsauth.DefaultSTSProvider)
 }
 
+// WatchSecrets receives the change events of the secrets from the SQS queue
+// of spec.provider.aws.secretsManager.changeNotifications.
+func (p *Provider) WatchSecrets(ctx context.Context, store esv1beta1.GenericStore, kube client.Client, namespace string, notify func(key string)) error {
+	prov, err := util.GetAWSProvider(store)
+	if err != nil {
+		return err
+	}
+	if prov.Service != esv1beta1.AWSServiceSecretsManager || prov.SecretsManager == nil || prov.SecretsManager.ChangeNotifications == nil {
+		return esv1beta1.ErrWatchSecretsNotSupported
+	}
+	// the credentials of a referent ClusterSecretStore depend on the namespace of the ExternalSecret
+	if util.IsReferentSpec(prov.Auth) && namespace == "" {
+		return fmt.Errorf("%w: the store uses referent authentication", esv1beta1.ErrWatchSecretsNotSupported)
+	}
+	sess, err := awsauth.New(ctx, store, kube, namespace, awsauth.DefaultSTSProvider, awsauth.DefaultJWTProvider)
+	if err != nil {
+		return fmt.Errorf(errUnableCreateSession, err)
+	}
+	return secretsmanager.Watch(ctx, sqs.New(sess), prov.SecretsManager.ChangeNotifications.QueueURL, notify)
+}
+
 func (p *Provider) ValidateStore(store esv1beta1.GenericStore) (admission.Warnings, error) {
 	prov, err := util.GetAWSProvider(store)
 	if err != nil {
@@ -129,6 +160,12 @@ func validateSecretsManagerConfig(prov *esv1beta1.AWSProvider) error {
 	if prov.SecretsManager == nil {
 		return nil
 	}
+	if notifications := prov.SecretsManager.ChangeNotifications; notifications != nil {
+		u, err := url.Parse(notifications.QueueURL)
+		if err != nil || u.Scheme != "https" || u.Host == "" {
+			return fmt.Errorf(errInvalidQueueURL, notifications.QueueURL)
+		}
+	}
 	return util.ValidateDeleteSecretInput(awssm.DeleteSecretInput{
 		ForceDeleteWithoutRecovery: &prov.SecretsManager.ForceDeleteWithoutRecovery,
 		RecoveryWindowInDays:       &prov.SecretsManager.RecoveryWindowInDays,
@@ -0,0 +1,121 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secretsmanager
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/aws/aws-sdk-go/aws"
+	awssm "github.com/aws/aws-sdk-go/service/secretsmanager"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	"github.com/external-secrets/external-secrets/pkg/constants"
+	"github.com/external-secrets/external-secrets/pkg/find"
+	"github.com/external-secrets/external-secrets/pkg/metrics"
+)
+
+const errDeleteAllSecret = "could not delete secret %s: %w"
+
+var _ esv1beta1.SecretBulkDeleter = &SecretsManager{}
+
+// DeleteAllSecrets deletes all secrets matching ref that carry the managed-by tag.
+// Secrets that are already scheduled for deletion are skipped.
+func (sm *SecretsManager) DeleteAllSecrets(ctx context.Context, ref esv1beta1.ExternalSecretFind, dryRun bool) ([]string, error) {
+	var matcher *find.Matcher
+	if ref.Name != nil {
+		var err error
+		matcher, err = find.New(*ref.Name)
+		if err != nil {
+			return nil, err
+		}
+	} else if len(ref.Tags) == 0 {
+		return nil, errors.New(errUnexpectedFindOperator)
+	}
+
+	// the filters only narrow down the list, every secret is checked for
+	// the managed-by tag before it is deleted.
+	filters := []*awssm.Filter{
+		{Key: aws.String(awssm.FilterNameStringTypeTagKey), Values: []*string{aws.String(managedBy)}},
+		{Key: aws.String(awssm.FilterNameStringTypeTagValue), Values: []*string{aws.String(externalSecrets)}},
+	}
+	if ref.Path != nil {
+		filters = append(filters, &awssm.Filter{
+			Key:    aws.String(awssm.FilterNameStringTypeName),
+			Values: []*string{ref.Path},
+		})
+	}
+
+	var names []string
+	var nextToken *string
+	for {
+		out, err := sm.client.ListSecrets(&awssm.ListSecretsInput{
+			Filters:   filters,
+			NextToken: nextToken,
+		})
+		metrics.ObserveAPICall(constants.ProviderAWSSM, constants.CallAWSSMListSecrets, err)
+		if err != nil {
+			return nil, err
+		}
+		for _, secret := range out.SecretList {
+			if secret.DeletedDate != nil || !hasTags(secret.Tags, map[string]string{managedBy: externalSecrets}) {
+				continue
+			}
+			if matcher != nil && !matcher.MatchName(aws.StringValue(secret.Name)) {
+				continue
+			}
+			if !hasTags(secret.Tags, ref.Tags) {
+				continue
+			}
+			names = append(names, aws.StringValue(secret.Name))
+		}
+		nextToken = out.NextToken
+		if nextToken == nil {
+			break
+		}
+	}
+	slices.Sort(names)
+	if dryRun {
+		return names, nil
+	}
+
+	deleted := make([]string, 0, len(names))
+	for _, name := range names {
+		if err := sm.deleteSecret(ctx, aws.String(name)); err != nil {
+			return deleted, fmt.Errorf(errDeleteAllSecret, name, err)
+		}
+		deleted = append(deleted, name)
+	}
+	return deleted, nil
+}
+
+// hasTags returns true if all wanted tags are set with the same value.
+func hasTags(tags []*awssm.Tag, want map[string]string) bool {
+	for k, v := range want {
+		found := false
+		for _, tag := range tags {
+			if aws.StringValue(tag.Key) == k && aws.StringValue(tag.Value) == v {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
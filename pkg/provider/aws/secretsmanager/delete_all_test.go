@@ -0,0 +1,131 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secretsmanager
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	awssm "github.com/aws/aws-sdk-go/service/secretsmanager"
+	"github.com/google/go-cmp/cmp"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	fakesm "github.com/external-secrets/external-secrets/pkg/provider/aws/secretsmanager/fake"
+)
+
+func TestDeleteAllSecrets(t *testing.T) {
+	managed := &awssm.Tag{Key: aws.String(managedBy), Value: aws.String(externalSecrets)}
+	team := &awssm.Tag{Key: aws.String("team"), Value: aws.String("a")}
+	deletedAt := time.Now()
+	// the filters of ListSecrets are not trusted, the fake returns unmanaged secrets as well
+	pages := map[string]*awssm.ListSecretsOutput{
+		"": {
+			SecretList: []*awssm.SecretListEntry{
+				{Name: aws.String("app/db"), Tags: []*awssm.Tag{managed, team}},
+				{Name: aws.String("app/unmanaged"), Tags: []*awssm.Tag{team}},
+				{Name: aws.String("app/other-manager"), Tags: []*awssm.Tag{{Key: aws.String(managedBy), Value: aws.String("someone-else")}}},
+			},
+			NextToken: aws.String("page2"),
+		},
+		"page2": {
+			SecretList: []*awssm.SecretListEntry{
+				{Name: aws.String("app/api"), Tags: []*awssm.Tag{managed}},
+				{Name: aws.String("app/scheduled"), Tags: []*awssm.Tag{managed, team}, DeletedDate: &deletedAt},
+				{Name: aws.String("other/db"), Tags: []*awssm.Tag{managed, team}},
+			},
+		},
+	}
+	errBoom := errors.New("boom")
+
+	tests := []struct {
+		name        string
+		ref         esv1beta1.ExternalSecretFind
+		dryRun      bool
+		deleteErr   error
+		want        []string
+		wantDeleted []string
+		wantErr     error
+	}{
+		{
+			name:        "deletes only managed secrets matching the name",
+			ref:         esv1beta1.ExternalSecretFind{Name: &esv1beta1.FindName{RegExp: "^app/"}},
+			want:        []string{"app/api", "app/db"},
+			wantDeleted: []string{"app/api", "app/db"},
+		},
+		{
+			name:        "name and tags must match",
+			ref:         esv1beta1.ExternalSecretFind{Name: &esv1beta1.FindName{RegExp: "^app/"}, Tags: map[string]string{"team": "a"}},
+			want:        []string{"app/db"},
+			wantDeleted: []string{"app/db"},
+		},
+		{
+			name:        "tags only",
+			ref:         esv1beta1.ExternalSecretFind{Tags: map[string]string{"team": "a"}},
+			want:        []string{"app/db", "other/db"},
+			wantDeleted: []string{"app/db", "other/db"},
+		},
+		{
+			name:   "dry run does not delete",
+			ref:    esv1beta1.ExternalSecretFind{Name: &esv1beta1.FindName{RegExp: "^app/"}},
+			dryRun: true,
+			want:   []string{"app/api", "app/db"},
+		},
+		{
+			name:        "returns the deleted secrets on error",
+			ref:         esv1beta1.ExternalSecretFind{Name: &esv1beta1.FindName{RegExp: "^app/"}},
+			deleteErr:   errBoom,
+			want:        []string{},
+			wantDeleted: []string{"app/api"},
+			wantErr:     errBoom,
+		},
+		{
+			name:    "name or tags are required",
+			ref:     esv1beta1.ExternalSecretFind{Path: aws.String("app/")},
+			wantErr: errors.New(errUnexpectedFindOperator),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var deleted []string
+			client := fakesm.NewClient()
+			client.ListSecretsFn = func(_ aws.Context, in *awssm.ListSecretsInput, _ ...request.Option) (*awssm.ListSecretsOutput, error) {
+				return pages[aws.StringValue(in.NextToken)], nil
+			}
+			client.DeleteSecretWithContextFn = func(_ aws.Context, in *awssm.DeleteSecretInput, _ ...request.Option) (*awssm.DeleteSecretOutput, error) {
+				deleted = append(deleted, aws.StringValue(in.SecretId))
+				return &awssm.DeleteSecretOutput{}, tt.deleteErr
+			}
+			sm := &SecretsManager{client: client}
+			got, err := sm.DeleteAllSecrets(context.Background(), tt.ref, tt.dryRun)
+			if tt.wantErr != nil {
+				if err == nil || !errors.Is(err, tt.wantErr) && err.Error() != tt.wantErr.Error() {
+					t.Fatalf("DeleteAllSecrets() error = %v, want %v", err, tt.wantErr)
+				}
+			} else if err != nil {
+				t.Fatalf("DeleteAllSecrets() unexpected error = %v", err)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("DeleteAllSecrets() mismatch (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tt.wantDeleted, deleted); diff != "" {
+				t.Errorf("deleted secrets mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
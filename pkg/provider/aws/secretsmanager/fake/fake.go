@@ -28,15 +28,19 @@ import (
 
 // Client implements the aws secretsmanager interface.
 type Client struct {
-	ExecutionCounter                 int
-	valFn                            map[string]func(*awssm.GetSecretValueInput) (*awssm.GetSecretValueOutput, error)
-	CreateSecretWithContextFn        CreateSecretWithContextFn
-	GetSecretValueWithContextFn      GetSecretValueWithContextFn
-	PutSecretValueWithContextFn      PutSecretValueWithContextFn
-	DescribeSecretWithContextFn      DescribeSecretWithContextFn
-	DeleteSecretWithContextFn        DeleteSecretWithContextFn
-	ListSecretsFn                    ListSecretsFn
-	BatchGetSecretValueWithContextFn BatchGetSecretValueWithContextFn
+	ExecutionCounter                  int
+	valFn                             map[string]func(*awssm.GetSecretValueInput) (*awssm.GetSecretValueOutput, error)
+	CreateSecretWithContextFn         CreateSecretWithContextFn
+	GetSecretValueWithContextFn       GetSecretValueWithContextFn
+	PutSecretValueWithContextFn       PutSecretValueWithContextFn
+	DescribeSecretWithContextFn       DescribeSecretWithContextFn
+	DeleteSecretWithContextFn         DeleteSecretWithContextFn
+	ListSecretsFn                     ListSecretsFn
+	BatchGetSecretValueWithContextFn  BatchGetSecretValueWithContextFn
+	ListSecretVersionIdsWithContextFn ListSecretVersionIdsWithContextFn
+	UpdateSecretWithContextFn         UpdateSecretWithContextFn
+	TagResourceWithContextFn          TagResourceWithContextFn
+	RotateSecretWithContextFn         RotateSecretWithContextFn
 }
 
 type CreateSecretWithContextFn func(aws.Context, *awssm.CreateSecretInput, ...request.Option) (*awssm.CreateSecretOutput, error)
@@ -46,6 +50,10 @@ type DescribeSecretWithContextFn func(aws.Context, *awssm.DescribeSecretInput, .
 type DeleteSecretWithContextFn func(ctx aws.Context, input *awssm.DeleteSecretInput, opts ...request.Option) (*awssm.DeleteSecretOutput, error)
 type ListSecretsFn func(ctx aws.Context, input *awssm.ListSecretsInput, opts ...request.Option) (*awssm.ListSecretsOutput, error)
 type BatchGetSecretValueWithContextFn func(aws.Context, *awssm.BatchGetSecretValueInput, ...request.Option) (*awssm.BatchGetSecretValueOutput, error)
+type ListSecretVersionIdsWithContextFn func(aws.Context, *awssm.ListSecretVersionIdsInput, ...request.Option) (*awssm.ListSecretVersionIdsOutput, error)
+type UpdateSecretWithContextFn func(aws.Context, *awssm.UpdateSecretInput, ...request.Option) (*awssm.UpdateSecretOutput, error)
+type TagResourceWithContextFn func(aws.Context, *awssm.TagResourceInput, ...request.Option) (*awssm.TagResourceOutput, error)
+type RotateSecretWithContextFn func(aws.Context, *awssm.RotateSecretInput, ...request.Option) (*awssm.RotateSecretOutput, error)
 
 func (sm Client) CreateSecretWithContext(ctx aws.Context, input *awssm.CreateSecretInput, options ...request.Option) (*awssm.CreateSecretOutput, error) {
 	return sm.CreateSecretWithContextFn(ctx, input, options...)
@@ -147,6 +155,18 @@ func NewDescribeSecretWithContextFn(output *awssm.DescribeSecretOutput, err erro
 	}
 }
 
+func (sm Client) UpdateSecretWithContext(ctx aws.Context, input *awssm.UpdateSecretInput, options ...request.Option) (*awssm.UpdateSecretOutput, error) {
+	return sm.UpdateSecretWithContextFn(ctx, input, options...)
+}
+
+func (sm Client) TagResourceWithContext(ctx aws.Context, input *awssm.TagResourceInput, options ...request.Option) (*awssm.TagResourceOutput, error) {
+	return sm.TagResourceWithContextFn(ctx, input, options...)
+}
+
+func (sm Client) RotateSecretWithContext(ctx aws.Context, input *awssm.RotateSecretInput, options ...request.Option) (*awssm.RotateSecretOutput, error) {
+	return sm.RotateSecretWithContextFn(ctx, input, options...)
+}
+
 // NewClient init a new fake client.
 func NewClient() *Client {
 	return &Client{
@@ -170,6 +190,10 @@ func (sm *Client) BatchGetSecretValueWithContext(_ aws.Context, in *awssm.BatchG
 	return sm.BatchGetSecretValueWithContextFn(nil, in)
 }
 
+func (sm *Client) ListSecretVersionIdsWithContext(ctx aws.Context, in *awssm.ListSecretVersionIdsInput, opts ...request.Option) (*awssm.ListSecretVersionIdsOutput, error) {
+	return sm.ListSecretVersionIdsWithContextFn(ctx, in, opts...)
+}
+
 func (sm *Client) cacheKeyForInput(in *awssm.GetSecretValueInput) string {
 	var secretID, versionID string
 	if in.SecretId != nil {
@@ -0,0 +1,59 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secretsmanager
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	awssm "github.com/aws/aws-sdk-go/service/secretsmanager"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	"github.com/external-secrets/external-secrets/pkg/constants"
+	"github.com/external-secrets/external-secrets/pkg/metrics"
+)
+
+var _ esv1beta1.SecretRotator = &SecretsManager{}
+
+var errRotationNotConfigured = errors.New("rotation is not configured for the secret")
+
+const errRotateNotManaged = "secret %q is not managed by external-secrets"
+
+// RotateSecret starts the rotation of a secret with the rotation lambda configured
+// in AWS Secrets Manager. Only secrets managed by external-secrets are rotated.
+func (sm *SecretsManager) RotateSecret(ctx context.Context, remoteRef esv1beta1.PushSecretRemoteRef) error {
+	secretName := remoteRef.GetRemoteKey()
+	data, err := sm.client.DescribeSecretWithContext(ctx, &awssm.DescribeSecretInput{
+		SecretId: &secretName,
+	})
+	metrics.ObserveAPICall(constants.ProviderAWSSM, constants.CallAWSSMDescribeSecret, err)
+	if err != nil {
+		return err
+	}
+	if !isManagedByESO(data) {
+		return fmt.Errorf(errRotateNotManaged, secretName)
+	}
+	if aws.StringValue(data.RotationLambdaARN) == "" {
+		return errRotationNotConfigured
+	}
+	_, err = sm.client.RotateSecretWithContext(ctx, &awssm.RotateSecretInput{
+		SecretId:          data.ARN,
+		RotateImmediately: aws.Bool(true),
+	})
+	metrics.ObserveAPICall(constants.ProviderAWSSM, constants.CallAWSSMRotateSecret, err)
+	return err
+}
@@ -0,0 +1,97 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secretsmanager
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	awssm "github.com/aws/aws-sdk-go/service/secretsmanager"
+
+	fakesm "github.com/external-secrets/external-secrets/pkg/provider/aws/secretsmanager/fake"
+	testingfake "github.com/external-secrets/external-secrets/pkg/provider/testing/fake"
+)
+
+func TestRotateSecret(t *testing.T) {
+	arn := "arn:aws:secretsmanager:eu-west-1:123456789012:secret:db-creds-AbCdEf"
+	lambda := "arn:aws:lambda:eu-west-1:123456789012:function:rotate"
+	managed := []*awssm.Tag{{Key: aws.String(managedBy), Value: aws.String(externalSecrets)}}
+	errBoom := errors.New("boom")
+
+	tests := []struct {
+		name        string
+		describe    *awssm.DescribeSecretOutput
+		describeErr error
+		rotateErr   error
+		wantRotate  bool
+		wantErr     string
+	}{
+		{
+			name:       "rotates a managed secret with the configured lambda",
+			describe:   &awssm.DescribeSecretOutput{ARN: &arn, Tags: managed, RotationLambdaARN: &lambda},
+			wantRotate: true,
+		},
+		{
+			name:     "fails if the secret is not managed",
+			describe: &awssm.DescribeSecretOutput{ARN: &arn, RotationLambdaARN: &lambda},
+			wantErr:  `secret "db-creds" is not managed by external-secrets`,
+		},
+		{
+			name:     "fails if rotation is not configured",
+			describe: &awssm.DescribeSecretOutput{ARN: &arn, Tags: managed},
+			wantErr:  errRotationNotConfigured.Error(),
+		},
+		{
+			name:        "returns the describe error",
+			describeErr: errBoom,
+			wantErr:     errBoom.Error(),
+		},
+		{
+			name:       "returns the rotate error",
+			describe:   &awssm.DescribeSecretOutput{ARN: &arn, Tags: managed, RotationLambdaARN: &lambda},
+			rotateErr:  errBoom,
+			wantRotate: true,
+			wantErr:    errBoom.Error(),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rotated := false
+			client := fakesm.NewClient()
+			client.DescribeSecretWithContextFn = fakesm.NewDescribeSecretWithContextFn(tt.describe, tt.describeErr)
+			client.RotateSecretWithContextFn = func(_ aws.Context, in *awssm.RotateSecretInput, _ ...request.Option) (*awssm.RotateSecretOutput, error) {
+				if aws.StringValue(in.SecretId) != arn || in.RotationLambdaARN != nil || !aws.BoolValue(in.RotateImmediately) {
+					t.Errorf("unexpected input %v", in)
+				}
+				rotated = true
+				return &awssm.RotateSecretOutput{}, tt.rotateErr
+			}
+			sm := &SecretsManager{client: client}
+			err := sm.RotateSecret(context.Background(), testingfake.PushSecretData{RemoteKey: "db-creds"})
+			if tt.wantErr == "" && err != nil {
+				t.Fatalf("RotateSecret() unexpected error = %v", err)
+			}
+			if tt.wantErr != "" && (err == nil || err.Error() != tt.wantErr) {
+				t.Fatalf("RotateSecret() error = %v, want %v", err, tt.wantErr)
+			}
+			if rotated != tt.wantRotate {
+				t.Errorf("RotateSecret() rotated = %v, want %v", rotated, tt.wantRotate)
+			}
+		})
+	}
+}
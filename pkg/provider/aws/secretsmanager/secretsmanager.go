@@ -20,7 +20,10 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"maps"
 	"math/big"
+	"regexp"
+	"slices"
 	"strings"
 
 	"github.com/aws/aws-sdk-go/aws"
@@ -48,8 +51,19 @@ const (
 	SecretPushFormatKey    = "secretPushFormat"
 	SecretPushFormatString = "string"
 	SecretPushFormatBinary = "binary"
+	// SecretDescriptionKey sets the description of the pushed secret.
+	SecretDescriptionKey = "description"
+	// SecretTagsKey sets custom tags on the pushed secret, the managed-by tag is always kept.
+	SecretTagsKey = "tags"
+	// SecretKMSKeyIDKey sets the KMS key that encrypts the pushed secret, it is only applied when the secret is created.
+	SecretKMSKeyIDKey = "kmsKeyID"
+	// SecretReplicaRegionsKey sets the regions the pushed secret is replicated to, it is only applied when the secret is created.
+	SecretReplicaRegionsKey = "replicaRegions"
 )
 
+// regionPattern matches the names of AWS regions, e.g. us-east-1 or us-gov-west-1.
+var regionPattern = regexp.MustCompile(`^[a-z]{2}(-[a-z]+)+-[0-9]+$`)
+
 // https://github.com/external-secrets/external-secrets/issues/644
 var _ esv1beta1.SecretsClient = &SecretsManager{}
 
@@ -73,6 +87,10 @@ type SMInterface interface {
 	PutSecretValueWithContext(aws.Context, *awssm.PutSecretValueInput, ...request.Option) (*awssm.PutSecretValueOutput, error)
 	DescribeSecretWithContext(aws.Context, *awssm.DescribeSecretInput, ...request.Option) (*awssm.DescribeSecretOutput, error)
 	DeleteSecretWithContext(ctx aws.Context, input *awssm.DeleteSecretInput, opts ...request.Option) (*awssm.DeleteSecretOutput, error)
+	ListSecretVersionIdsWithContext(aws.Context, *awssm.ListSecretVersionIdsInput, ...request.Option) (*awssm.ListSecretVersionIdsOutput, error)
+	UpdateSecretWithContext(aws.Context, *awssm.UpdateSecretInput, ...request.Option) (*awssm.UpdateSecretOutput, error)
+	TagResourceWithContext(aws.Context, *awssm.TagResourceInput, ...request.Option) (*awssm.TagResourceOutput, error)
+	RotateSecretWithContext(aws.Context, *awssm.RotateSecretInput, ...request.Option) (*awssm.RotateSecretOutput, error)
 }
 
 const (
@@ -151,8 +169,13 @@ func (sm *SecretsManager) DeleteSecret(ctx context.Context, remoteRef esv1beta1.
 	if !isManagedByESO(data) {
 		return nil
 	}
+	return sm.deleteSecret(ctx, awsSecret.ARN)
+}
+
+// deleteSecret deletes the secret id with the recovery settings of the store.
+func (sm *SecretsManager) deleteSecret(ctx context.Context, id *string) error {
 	deleteInput := &awssm.DeleteSecretInput{
-		SecretId: awsSecret.ARN,
+		SecretId: id,
 	}
 	if sm.config != nil && sm.config.ForceDeleteWithoutRecovery {
 		deleteInput.ForceDeleteWithoutRecovery = &sm.config.ForceDeleteWithoutRecovery
@@ -160,7 +183,7 @@ func (sm *SecretsManager) DeleteSecret(ctx context.Context, remoteRef esv1beta1.
 	if sm.config != nil && sm.config.RecoveryWindowInDays > 0 {
 		deleteInput.RecoveryWindowInDays = &sm.config.RecoveryWindowInDays
 	}
-	err = util.ValidateDeleteSecretInput(*deleteInput)
+	err := util.ValidateDeleteSecretInput(*deleteInput)
 	if err != nil {
 		return err
 	}
@@ -197,6 +220,11 @@ func (sm *SecretsManager) PushSecret(ctx context.Context, secret *corev1.Secret,
 		return errors.New("pushing the whole secret is not yet implemented")
 	}
 
+	// the create-only parameters are validated on every push, so invalid metadata is reported even if the secret exists
+	if _, _, err := pushSecretCreateParameters(psd); err != nil {
+		return err
+	}
+
 	secretName := psd.GetRemoteKey()
 	value := secret.Data[psd.GetSecretKey()]
 	secretValue := awssm.GetSecretValueInput{
@@ -493,17 +521,27 @@ func (sm *SecretsManager) createSecretWithContext(ctx context.Context, secretNam
 	if err != nil {
 		return fmt.Errorf("failed to parse metadata: %w", err)
 	}
+	description, tags, err := pushSecretDescriptionAndTags(psd)
+	if err != nil {
+		return err
+	}
+	kmsKeyID, replicas, err := pushSecretCreateParameters(psd)
+	if err != nil {
+		return err
+	}
 
 	input := &awssm.CreateSecretInput{
-		Name:         &secretName,
-		SecretBinary: value,
-		Tags: []*awssm.Tag{
-			{
-				Key:   utilpointer.To(managedBy),
-				Value: utilpointer.To(externalSecrets),
-			},
-		},
+		Name:               &secretName,
+		SecretBinary:       value,
+		Tags:               tags,
 		ClientRequestToken: utilpointer.To(initialVersion),
+		AddReplicaRegions:  replicas,
+	}
+	if description != "" {
+		input.Description = &description
+	}
+	if kmsKeyID != "" {
+		input.KmsKeyId = &kmsKeyID
 	}
 	if secretPushFormat == SecretPushFormatString {
 		input.SetSecretBinary(nil).SetSecretString(string(value))
@@ -524,6 +562,9 @@ func (sm *SecretsManager) putSecretValueWithContext(ctx context.Context, secretI
 	if !isManagedByESO(data) {
 		return errors.New("secret not managed by external-secrets")
 	}
+	if err := sm.updateSecretDescriptionAndTags(ctx, data, psd); err != nil {
+		return err
+	}
 	if awsSecret != nil && bytes.Equal(awsSecret.SecretBinary, value) || utils.CompareStringAndByteSlices(awsSecret.SecretString, value) {
 		return nil
 	}
@@ -551,6 +592,144 @@ func (sm *SecretsManager) putSecretValueWithContext(ctx context.Context, secretI
 	return err
 }
 
+// updateSecretDescriptionAndTags sets the description and the custom tags of the
+// PushSecret metadata on the existing secret if they differ. Other tags are kept.
+func (sm *SecretsManager) updateSecretDescriptionAndTags(ctx context.Context, data *awssm.DescribeSecretOutput, psd esv1beta1.PushSecretData) error {
+	description, tags, err := pushSecretDescriptionAndTags(psd)
+	if err != nil {
+		return err
+	}
+	if description != "" && aws.StringValue(data.Description) != description {
+		_, err = sm.client.UpdateSecretWithContext(ctx, &awssm.UpdateSecretInput{
+			SecretId:    data.ARN,
+			Description: &description,
+		})
+		metrics.ObserveAPICall(constants.ProviderAWSSM, constants.CallAWSSMUpdateSecret, err)
+		if err != nil {
+			return fmt.Errorf("failed to update the secret description: %w", err)
+		}
+	}
+
+	existing := make(map[string]string, len(data.Tags))
+	for _, tag := range data.Tags {
+		existing[aws.StringValue(tag.Key)] = aws.StringValue(tag.Value)
+	}
+	var changed []*awssm.Tag
+	for _, tag := range tags {
+		if v, ok := existing[*tag.Key]; !ok || v != *tag.Value {
+			changed = append(changed, tag)
+		}
+	}
+	if len(changed) == 0 {
+		return nil
+	}
+	_, err = sm.client.TagResourceWithContext(ctx, &awssm.TagResourceInput{
+		SecretId: data.ARN,
+		Tags:     changed,
+	})
+	metrics.ObserveAPICall(constants.ProviderAWSSM, constants.CallAWSSMTagResource, err)
+	if err != nil {
+		return fmt.Errorf("failed to tag the secret: %w", err)
+	}
+	return nil
+}
+
+// pushSecretDescriptionAndTags returns the description and the tags of the PushSecret metadata.
+// The tags always contain the managed-by tag, which can't be overridden.
+func pushSecretDescriptionAndTags(psd esv1beta1.PushSecretData) (string, []*awssm.Tag, error) {
+	description, err := utils.FetchValueFromMetadata(SecretDescriptionKey, psd.GetMetadata(), "")
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to parse metadata: %w", err)
+	}
+	customTags, err := utils.FetchValueFromMetadata(SecretTagsKey, psd.GetMetadata(), map[string]any{})
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to parse metadata: %w", err)
+	}
+
+	keys := slices.Sorted(maps.Keys(customTags))
+	tags := make([]*awssm.Tag, 0, len(keys)+1)
+	for _, k := range keys {
+		if k == managedBy {
+			continue
+		}
+		v, ok := customTags[k].(string)
+		if !ok {
+			return "", nil, fmt.Errorf("invalid value of tag %q in metadata, expected a string", k)
+		}
+		tags = append(tags, &awssm.Tag{Key: utilpointer.To(k), Value: utilpointer.To(v)})
+	}
+	tags = append(tags, &awssm.Tag{Key: utilpointer.To(managedBy), Value: utilpointer.To(externalSecrets)})
+	return description, tags, nil
+}
+
+// pushSecretCreateParameters returns the KMS key and the replica regions of the PushSecret metadata.
+// They can't be changed by a push to an existing secret, so they are only applied when the secret is created.
+// A replica region is either the name of the region or an object with the region and the KMS key of the replica.
+func pushSecretCreateParameters(psd esv1beta1.PushSecretData) (string, []*awssm.ReplicaRegionType, error) {
+	rawKMSKeyID, err := utils.FetchValueFromMetadata[any](SecretKMSKeyIDKey, psd.GetMetadata(), "")
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to parse metadata: %w", err)
+	}
+	kmsKeyID, ok := rawKMSKeyID.(string)
+	if !ok {
+		return "", nil, fmt.Errorf("invalid value of %s in metadata, expected a string", SecretKMSKeyIDKey)
+	}
+	rawRegions, err := utils.FetchValueFromMetadata[any](SecretReplicaRegionsKey, psd.GetMetadata(), []any{})
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to parse metadata: %w", err)
+	}
+	regions, ok := rawRegions.([]any)
+	if !ok {
+		return "", nil, fmt.Errorf("invalid value of %s in metadata, expected a list", SecretReplicaRegionsKey)
+	}
+
+	replicas := make([]*awssm.ReplicaRegionType, 0, len(regions))
+	seen := make(map[string]bool, len(regions))
+	for i, r := range regions {
+		replica, err := replicaRegion(r)
+		if err != nil {
+			return "", nil, fmt.Errorf("invalid value of %s[%d] in metadata: %w", SecretReplicaRegionsKey, i, err)
+		}
+		if seen[*replica.Region] {
+			return "", nil, fmt.Errorf("invalid value of %s[%d] in metadata: duplicate region %q", SecretReplicaRegionsKey, i, *replica.Region)
+		}
+		seen[*replica.Region] = true
+		replicas = append(replicas, replica)
+	}
+	if len(replicas) == 0 {
+		replicas = nil
+	}
+	return kmsKeyID, replicas, nil
+}
+
+func replicaRegion(value any) (*awssm.ReplicaRegionType, error) {
+	var region, kmsKeyID string
+	switch v := value.(type) {
+	case string:
+		region = v
+	case map[string]any:
+		var ok bool
+		if region, ok = v["region"].(string); !ok {
+			return nil, errors.New("expected a region")
+		}
+		if key, exists := v[SecretKMSKeyIDKey]; exists {
+			if kmsKeyID, ok = key.(string); !ok {
+				return nil, fmt.Errorf("invalid %s, expected a string", SecretKMSKeyIDKey)
+			}
+		}
+	default:
+		return nil, errors.New("expected a region or an object with a region")
+	}
+	if !regionPattern.MatchString(region) {
+		return nil, fmt.Errorf("%q is not a valid AWS region", region)
+	}
+	replica := &awssm.ReplicaRegionType{Region: utilpointer.To(region)}
+	if kmsKeyID != "" {
+		replica.KmsKeyId = utilpointer.To(kmsKeyID)
+	}
+	return replica, nil
+}
+
 func (sm *SecretsManager) fetchWithBatch(ctx context.Context, filters []*awssm.Filter, matcher *find.Matcher) (map[string][]byte, error) {
 	data := make(map[string][]byte)
 	var nextToken *string
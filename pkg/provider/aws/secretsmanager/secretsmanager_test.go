@@ -797,6 +797,181 @@ func TestSetSecret(t *testing.T) {
 	}
 }
 
+func TestPushSecretDescriptionAndTags(t *testing.T) {
+	arn := "arn:aws:secretsmanager:us-east-1:702902267788:secret:foo-bar5-Robbgh"
+	secret := &corev1.Secret{Data: map[string][]byte{"key": []byte("value")}}
+	psd := fake.PushSecretData{SecretKey: "key", RemoteKey: fakeKey, Metadata: &apiextensionsv1.JSON{
+		Raw: []byte(`{"description": "database password", "tags": {"team": "payments", "env": "prod", "managed-by": "someone-else"}}`),
+	}}
+	wantTags := []*awssm.Tag{
+		{Key: ptr.To("env"), Value: ptr.To("prod")},
+		{Key: ptr.To("team"), Value: ptr.To("payments")},
+		{Key: ptr.To(managedBy), Value: ptr.To(externalSecrets)},
+	}
+
+	t.Run("new secret", func(t *testing.T) {
+		var created *awssm.CreateSecretInput
+		sm := SecretsManager{client: &fakesm.Client{
+			GetSecretValueWithContextFn: fakesm.NewGetSecretValueWithContextFn(nil, &awssm.ResourceNotFoundException{}),
+			CreateSecretWithContextFn: func(_ aws.Context, input *awssm.CreateSecretInput, _ ...request.Option) (*awssm.CreateSecretOutput, error) {
+				created = input
+				return &awssm.CreateSecretOutput{ARN: &arn}, nil
+			},
+		}}
+		if err := sm.PushSecret(context.Background(), secret, psd); err != nil {
+			t.Fatalf("PushSecret() error = %v", err)
+		}
+		assert.Equal(t, "database password", aws.StringValue(created.Description))
+		assert.Equal(t, wantTags, created.Tags)
+	})
+
+	t.Run("existing secret", func(t *testing.T) {
+		var updated *awssm.UpdateSecretInput
+		var tagged *awssm.TagResourceInput
+		sm := SecretsManager{client: &fakesm.Client{
+			GetSecretValueWithContextFn: fakesm.NewGetSecretValueWithContextFn(&awssm.GetSecretValueOutput{
+				ARN:          &arn,
+				SecretBinary: []byte("value"),
+				VersionId:    ptr.To("00000000-0000-0000-0000-000000000002"),
+			}, nil),
+			DescribeSecretWithContextFn: fakesm.NewDescribeSecretWithContextFn(&awssm.DescribeSecretOutput{
+				ARN:         &arn,
+				Description: ptr.To("old description"),
+				Tags: []*awssm.Tag{
+					{Key: ptr.To(managedBy), Value: ptr.To(externalSecrets)},
+					{Key: ptr.To("team"), Value: ptr.To("billing")},
+					{Key: ptr.To("env"), Value: ptr.To("prod")},
+					{Key: ptr.To("owner"), Value: ptr.To("alice")},
+				},
+			}, nil),
+			UpdateSecretWithContextFn: func(_ aws.Context, input *awssm.UpdateSecretInput, _ ...request.Option) (*awssm.UpdateSecretOutput, error) {
+				updated = input
+				return &awssm.UpdateSecretOutput{ARN: &arn}, nil
+			},
+			TagResourceWithContextFn: func(_ aws.Context, input *awssm.TagResourceInput, _ ...request.Option) (*awssm.TagResourceOutput, error) {
+				tagged = input
+				return &awssm.TagResourceOutput{}, nil
+			},
+		}}
+		if err := sm.PushSecret(context.Background(), secret, psd); err != nil {
+			t.Fatalf("PushSecret() error = %v", err)
+		}
+		assert.Equal(t, "database password", aws.StringValue(updated.Description))
+		// only the changed tag is set, the managed-by and the other tags are kept
+		assert.Equal(t, []*awssm.Tag{{Key: ptr.To("team"), Value: ptr.To("payments")}}, tagged.Tags)
+	})
+
+	t.Run("invalid tag value", func(t *testing.T) {
+		sm := SecretsManager{client: &fakesm.Client{
+			GetSecretValueWithContextFn: fakesm.NewGetSecretValueWithContextFn(nil, &awssm.ResourceNotFoundException{}),
+		}}
+		err := sm.PushSecret(context.Background(), secret, fake.PushSecretData{SecretKey: "key", RemoteKey: fakeKey, Metadata: &apiextensionsv1.JSON{
+			Raw: []byte(`{"tags": {"replicas": 3}}`),
+		}})
+		assert.ErrorContains(t, err, `invalid value of tag "replicas"`)
+	})
+}
+
+func TestPushSecretCreateParameters(t *testing.T) {
+	arn := "arn:aws:secretsmanager:us-east-1:702902267788:secret:foo-bar5-Robbgh"
+	secret := &corev1.Secret{Data: map[string][]byte{"key": []byte("value")}}
+	psd := fake.PushSecretData{SecretKey: "key", RemoteKey: fakeKey, Metadata: &apiextensionsv1.JSON{
+		Raw: []byte(`{"kmsKeyID": "alias/payments", "replicaRegions": ["eu-west-1", {"region": "us-gov-west-1", "kmsKeyID": "alias/replica"}]}`),
+	}}
+
+	t.Run("new secret", func(t *testing.T) {
+		var created *awssm.CreateSecretInput
+		sm := SecretsManager{client: &fakesm.Client{
+			GetSecretValueWithContextFn: fakesm.NewGetSecretValueWithContextFn(nil, &awssm.ResourceNotFoundException{}),
+			CreateSecretWithContextFn: func(_ aws.Context, input *awssm.CreateSecretInput, _ ...request.Option) (*awssm.CreateSecretOutput, error) {
+				created = input
+				return &awssm.CreateSecretOutput{ARN: &arn}, nil
+			},
+		}}
+		if err := sm.PushSecret(context.Background(), secret, psd); err != nil {
+			t.Fatalf("PushSecret() error = %v", err)
+		}
+		assert.Equal(t, "alias/payments", aws.StringValue(created.KmsKeyId))
+		assert.Equal(t, []*awssm.ReplicaRegionType{
+			{Region: ptr.To("eu-west-1")},
+			{Region: ptr.To("us-gov-west-1"), KmsKeyId: ptr.To("alias/replica")},
+		}, created.AddReplicaRegions)
+	})
+
+	t.Run("existing secret", func(t *testing.T) {
+		var updated *awssm.UpdateSecretInput
+		var put *awssm.PutSecretValueInput
+		sm := SecretsManager{client: &fakesm.Client{
+			GetSecretValueWithContextFn: fakesm.NewGetSecretValueWithContextFn(&awssm.GetSecretValueOutput{
+				ARN:          &arn,
+				SecretBinary: []byte("old value"),
+				VersionId:    ptr.To("00000000-0000-0000-0000-000000000002"),
+			}, nil),
+			DescribeSecretWithContextFn: fakesm.NewDescribeSecretWithContextFn(&awssm.DescribeSecretOutput{
+				ARN:      &arn,
+				KmsKeyId: ptr.To("alias/other"),
+				Tags:     []*awssm.Tag{{Key: ptr.To(managedBy), Value: ptr.To(externalSecrets)}},
+			}, nil),
+			UpdateSecretWithContextFn: func(_ aws.Context, input *awssm.UpdateSecretInput, _ ...request.Option) (*awssm.UpdateSecretOutput, error) {
+				updated = input
+				return &awssm.UpdateSecretOutput{ARN: &arn}, nil
+			},
+			PutSecretValueWithContextFn: func(_ aws.Context, input *awssm.PutSecretValueInput, _ ...request.Option) (*awssm.PutSecretValueOutput, error) {
+				put = input
+				return &awssm.PutSecretValueOutput{ARN: &arn}, nil
+			},
+		}}
+		if err := sm.PushSecret(context.Background(), secret, psd); err != nil {
+			t.Fatalf("PushSecret() error = %v", err)
+		}
+		// the KMS key and the replicas of an existing secret are not changed, only the value is written
+		assert.Nil(t, updated)
+		assert.Equal(t, []byte("value"), put.SecretBinary)
+	})
+
+	tests := []struct {
+		name     string
+		metadata string
+		wantErr  string
+	}{
+		{
+			name:     "invalid region",
+			metadata: `{"replicaRegions": ["eu-west-1", "Frankfurt"]}`,
+			wantErr:  `invalid value of replicaRegions[1] in metadata: "Frankfurt" is not a valid AWS region`,
+		},
+		{
+			name:     "duplicate region",
+			metadata: `{"replicaRegions": ["eu-west-1", {"region": "eu-west-1"}]}`,
+			wantErr:  `duplicate region "eu-west-1"`,
+		},
+		{
+			name:     "replica without region",
+			metadata: `{"replicaRegions": [{"kmsKeyID": "alias/replica"}]}`,
+			wantErr:  "expected a region",
+		},
+		{
+			name:     "regions are not a list",
+			metadata: `{"replicaRegions": "eu-west-1"}`,
+			wantErr:  "invalid value of replicaRegions in metadata, expected a list",
+		},
+		{
+			name:     "kms key is not a string",
+			metadata: `{"kmsKeyID": 42}`,
+			wantErr:  "invalid value of kmsKeyID in metadata, expected a string",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// the parameters are validated before any call to the API, also for existing secrets
+			sm := SecretsManager{client: &fakesm.Client{}}
+			err := sm.PushSecret(context.Background(), secret, fake.PushSecretData{SecretKey: "key", RemoteKey: fakeKey, Metadata: &apiextensionsv1.JSON{
+				Raw: []byte(tt.metadata),
+			}})
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
 func TestDeleteSecret(t *testing.T) {
 	fakeClient := fakesm.Client{}
 	managed := managedBy
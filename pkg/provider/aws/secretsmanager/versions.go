@@ -0,0 +1,82 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secretsmanager
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/aws/aws-sdk-go/aws"
+	awssm "github.com/aws/aws-sdk-go/service/secretsmanager"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	"github.com/external-secrets/external-secrets/pkg/constants"
+	"github.com/external-secrets/external-secrets/pkg/metrics"
+)
+
+var _ esv1beta1.SecretVersionLister = &SecretsManager{}
+
+// ListSecretVersions lists the versions of a secret including deprecated versions,
+// the version stages (e.g. AWSCURRENT) are returned as attributes.
+func (sm *SecretsManager) ListSecretVersions(ctx context.Context, ref esv1beta1.ExternalSecretDataRemoteRef) ([]esv1beta1.SecretVersion, error) {
+	var versions []esv1beta1.SecretVersion
+	var nextToken *string
+	for {
+		out, err := sm.client.ListSecretVersionIdsWithContext(ctx, &awssm.ListSecretVersionIdsInput{
+			SecretId:          &ref.Key,
+			IncludeDeprecated: aws.Bool(true),
+			NextToken:         nextToken,
+		})
+		metrics.ObserveAPICall(constants.ProviderAWSSM, constants.CallAWSSMListSecretVersions, err)
+		if err != nil {
+			return nil, err
+		}
+		for _, v := range out.Versions {
+			versions = append(versions, esv1beta1.SecretVersion{
+				Version:    aws.StringValue(v.VersionId),
+				CreatedAt:  aws.TimeValue(v.CreatedDate),
+				Attributes: aws.StringValueSlice(v.VersionStages),
+			})
+		}
+		nextToken = out.NextToken
+		if nextToken == nil {
+			break
+		}
+	}
+	slices.SortStableFunc(versions, func(a, b esv1beta1.SecretVersion) int {
+		return a.CreatedAt.Compare(b.CreatedAt)
+	})
+	return versions, nil
+}
+
+var _ esv1beta1.SecretVersionAliasResolver = &SecretsManager{}
+
+// versionStages maps the version aliases to the staging labels of Secrets Manager.
+var versionStages = map[string]string{
+	esv1beta1.VersionAliasLatest:   "AWSCURRENT",
+	esv1beta1.VersionAliasCurrent:  "AWSCURRENT",
+	esv1beta1.VersionAliasPrevious: "AWSPREVIOUS",
+	esv1beta1.VersionAliasPending:  "AWSPENDING",
+}
+
+// ResolveVersionAlias maps a version alias to its staging label.
+func (sm *SecretsManager) ResolveVersionAlias(_ context.Context, ref esv1beta1.ExternalSecretDataRemoteRef) (string, error) {
+	stage, ok := versionStages[ref.Version]
+	if !ok {
+		return "", fmt.Errorf("%w %s", esv1beta1.ErrVersionAliasNotSupported, ref.Version)
+	}
+	return stage, nil
+}
@@ -0,0 +1,113 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secretsmanager
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	awssm "github.com/aws/aws-sdk-go/service/secretsmanager"
+	"github.com/google/go-cmp/cmp"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	fakesm "github.com/external-secrets/external-secrets/pkg/provider/aws/secretsmanager/fake"
+)
+
+func TestListSecretVersions(t *testing.T) {
+	older := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	newer := older.Add(time.Hour)
+	pages := map[string]*awssm.ListSecretVersionIdsOutput{
+		"": {
+			Versions: []*awssm.SecretVersionsListEntry{
+				{VersionId: aws.String("v2"), CreatedDate: &newer, VersionStages: aws.StringSlice([]string{"AWSCURRENT"})},
+			},
+			NextToken: aws.String("page2"),
+		},
+		"page2": {
+			Versions: []*awssm.SecretVersionsListEntry{
+				{VersionId: aws.String("v1"), CreatedDate: &older, VersionStages: aws.StringSlice([]string{"AWSPREVIOUS"})},
+			},
+		},
+	}
+	errBoom := errors.New("boom")
+
+	tests := []struct {
+		name    string
+		fn      fakesm.ListSecretVersionIdsWithContextFn
+		want    []esv1beta1.SecretVersion
+		wantErr error
+	}{
+		{
+			name: "lists all pages sorted by creation time",
+			fn: func(_ aws.Context, in *awssm.ListSecretVersionIdsInput, _ ...request.Option) (*awssm.ListSecretVersionIdsOutput, error) {
+				if aws.StringValue(in.SecretId) != "db-creds" || !aws.BoolValue(in.IncludeDeprecated) {
+					return nil, errors.New("unexpected input")
+				}
+				return pages[aws.StringValue(in.NextToken)], nil
+			},
+			want: []esv1beta1.SecretVersion{
+				{Version: "v1", CreatedAt: older, Attributes: []string{"AWSPREVIOUS"}},
+				{Version: "v2", CreatedAt: newer, Attributes: []string{"AWSCURRENT"}},
+			},
+		},
+		{
+			name: "returns the api error",
+			fn: func(aws.Context, *awssm.ListSecretVersionIdsInput, ...request.Option) (*awssm.ListSecretVersionIdsOutput, error) {
+				return nil, errBoom
+			},
+			wantErr: errBoom,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := fakesm.NewClient()
+			client.ListSecretVersionIdsWithContextFn = tt.fn
+			sm := &SecretsManager{client: client}
+			got, err := sm.ListSecretVersions(context.Background(), esv1beta1.ExternalSecretDataRemoteRef{Key: "db-creds"})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ListSecretVersions() error = %v, want %v", err, tt.wantErr)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("ListSecretVersions() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestResolveVersionAlias(t *testing.T) {
+	tests := map[string]string{
+		esv1beta1.VersionAliasLatest:   "AWSCURRENT",
+		esv1beta1.VersionAliasCurrent:  "AWSCURRENT",
+		esv1beta1.VersionAliasPrevious: "AWSPREVIOUS",
+		esv1beta1.VersionAliasPending:  "AWSPENDING",
+	}
+	sm := &SecretsManager{}
+	for alias, want := range tests {
+		got, err := sm.ResolveVersionAlias(context.Background(), esv1beta1.ExternalSecretDataRemoteRef{Key: "db", Version: alias})
+		if err != nil {
+			t.Fatalf("ResolveVersionAlias(%s) returned an error: %v", alias, err)
+		}
+		if got != want {
+			t.Errorf("ResolveVersionAlias(%s) = %s, want %s", alias, got, want)
+		}
+	}
+	if _, err := sm.ResolveVersionAlias(context.Background(), esv1beta1.ExternalSecretDataRemoteRef{Version: "oldest"}); !errors.Is(err, esv1beta1.ErrVersionAliasNotSupported) {
+		t.Errorf("ResolveVersionAlias(oldest) error = %v, want %v", err, esv1beta1.ErrVersionAliasNotSupported)
+	}
+}
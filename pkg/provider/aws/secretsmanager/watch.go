@@ -0,0 +1,144 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secretsmanager
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/sqs"
+	"github.com/aws/aws-sdk-go/service/sqs/sqsiface"
+
+	"github.com/external-secrets/external-secrets/pkg/constants"
+	"github.com/external-secrets/external-secrets/pkg/metrics"
+)
+
+const (
+	errReceiveChangeEvents = "unable to receive change events: %w"
+	errDeleteChangeEvents  = "unable to delete change events: %w"
+
+	eventSourceSecretsManager = "aws.secretsmanager"
+
+	// the suffix of 6 random characters that Secrets Manager appends to the name in the ARN.
+	arnSuffixLength = 7
+)
+
+// changeEvent is the part of an EventBridge event of Secrets Manager that identifies the changed secret.
+// Depending on the API call the secret is referenced in a different field.
+type changeEvent struct {
+	Source string `json:"source"`
+	Detail struct {
+		RequestParameters struct {
+			SecretID string `json:"secretId"`
+			Name     string `json:"name"`
+		} `json:"requestParameters"`
+		AdditionalEventData struct {
+			SecretID string `json:"SecretId"`
+		} `json:"additionalEventData"`
+		ResponseElements struct {
+			ARN string `json:"arn"`
+		} `json:"responseElements"`
+	} `json:"detail"`
+}
+
+// Watch receives the Secrets Manager events of an EventBridge rule from an SQS queue
+// and calls notify with the keys of every changed secret until ctx is done.
+// Every message is deleted once it was processed.
+func Watch(ctx context.Context, queue sqsiface.SQSAPI, queueURL string, notify func(key string)) error {
+	for ctx.Err() == nil {
+		out, err := queue.ReceiveMessageWithContext(ctx, &sqs.ReceiveMessageInput{
+			QueueUrl:            &queueURL,
+			MaxNumberOfMessages: aws.Int64(10),
+			WaitTimeSeconds:     aws.Int64(20),
+		})
+		metrics.ObserveAPICall(constants.ProviderAWSSM, constants.CallAWSSMReceiveMessage, err)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return fmt.Errorf(errReceiveChangeEvents, err)
+		}
+		if len(out.Messages) == 0 {
+			continue
+		}
+
+		entries := make([]*sqs.DeleteMessageBatchRequestEntry, 0, len(out.Messages))
+		for i, msg := range out.Messages {
+			for _, key := range changedSecretKeys(aws.StringValue(msg.Body)) {
+				notify(key)
+			}
+			entries = append(entries, &sqs.DeleteMessageBatchRequestEntry{
+				Id:            aws.String(strconv.Itoa(i)),
+				ReceiptHandle: msg.ReceiptHandle,
+			})
+		}
+		_, err = queue.DeleteMessageBatchWithContext(ctx, &sqs.DeleteMessageBatchInput{
+			QueueUrl: &queueURL,
+			Entries:  entries,
+		})
+		metrics.ObserveAPICall(constants.ProviderAWSSM, constants.CallAWSSMDeleteMessageBatch, err)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return fmt.Errorf(errDeleteChangeEvents, err)
+		}
+	}
+	return nil
+}
+
+// changedSecretKeys returns the keys of the secret changed by the event, both the ARN and the name if an ARN is given.
+// It returns an empty key if the secret is unknown, so all ExternalSecrets are refreshed,
+// and no key if the event is not an event of Secrets Manager.
+func changedSecretKeys(body string) []string {
+	var event changeEvent
+	if err := json.Unmarshal([]byte(body), &event); err != nil || event.Source != eventSourceSecretsManager {
+		return nil
+	}
+	var keys []string
+	for _, id := range []string{
+		event.Detail.RequestParameters.SecretID,
+		event.Detail.RequestParameters.Name,
+		event.Detail.AdditionalEventData.SecretID,
+		event.Detail.ResponseElements.ARN,
+	} {
+		for _, key := range []string{id, nameFromARN(id)} {
+			if key != "" && !slices.Contains(keys, key) {
+				keys = append(keys, key)
+			}
+		}
+	}
+	if len(keys) == 0 {
+		return []string{""}
+	}
+	return keys
+}
+
+// nameFromARN returns the name of the secret of an ARN, or an empty string if id is not an ARN.
+func nameFromARN(id string) string {
+	_, name, ok := strings.Cut(id, ":secret:")
+	if !ok || !strings.HasPrefix(id, "arn:") {
+		return ""
+	}
+	if len(name) > arnSuffixLength && name[len(name)-arnSuffixLength] == '-' {
+		name = name[:len(name)-arnSuffixLength]
+	}
+	return name
+}
@@ -0,0 +1,125 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secretsmanager
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/sqs"
+	"github.com/aws/aws-sdk-go/service/sqs/sqsiface"
+	"github.com/google/go-cmp/cmp"
+)
+
+type fakeQueue struct {
+	sqsiface.SQSAPI
+	batches [][]string
+	deleted []string
+	cancel  context.CancelFunc
+	err     error
+}
+
+func (q *fakeQueue) ReceiveMessageWithContext(_ aws.Context, _ *sqs.ReceiveMessageInput, _ ...request.Option) (*sqs.ReceiveMessageOutput, error) {
+	if q.err != nil {
+		return nil, q.err
+	}
+	if len(q.batches) == 0 {
+		q.cancel()
+		return nil, context.Canceled
+	}
+	out := &sqs.ReceiveMessageOutput{}
+	for _, body := range q.batches[0] {
+		out.Messages = append(out.Messages, &sqs.Message{Body: aws.String(body), ReceiptHandle: aws.String("handle-" + body)})
+	}
+	q.batches = q.batches[1:]
+	return out, nil
+}
+
+func (q *fakeQueue) DeleteMessageBatchWithContext(_ aws.Context, in *sqs.DeleteMessageBatchInput, _ ...request.Option) (*sqs.DeleteMessageBatchOutput, error) {
+	for _, entry := range in.Entries {
+		q.deleted = append(q.deleted, aws.StringValue(entry.ReceiptHandle))
+	}
+	return &sqs.DeleteMessageBatchOutput{}, nil
+}
+
+func TestWatch(t *testing.T) {
+	putSecretValue := `{"source":"aws.secretsmanager","detail":{"eventName":"PutSecretValue","requestParameters":{"secretId":"arn:aws:secretsmanager:eu-west-1:123456789012:secret:db-creds-AbCdEf"}}}`
+	rotation := `{"source":"aws.secretsmanager","detail":{"eventName":"RotationSucceeded","additionalEventData":{"SecretId":"app/token"}}}`
+	other := `{"source":"aws.ssm","detail":{"requestParameters":{"name":"param"}}}`
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	queue := &fakeQueue{batches: [][]string{{putSecretValue, other}, {}, {rotation}}, cancel: cancel}
+	var keys []string
+	if err := Watch(ctx, queue, "https://sqs.eu-west-1.amazonaws.com/123456789012/changes", func(key string) {
+		keys = append(keys, key)
+	}); err != nil {
+		t.Fatalf("Watch() error = %v", err)
+	}
+
+	wantKeys := []string{"arn:aws:secretsmanager:eu-west-1:123456789012:secret:db-creds-AbCdEf", "db-creds", "app/token"}
+	if diff := cmp.Diff(wantKeys, keys); diff != "" {
+		t.Errorf("unexpected keys (-want +got):\n%s", diff)
+	}
+	wantDeleted := []string{"handle-" + putSecretValue, "handle-" + other, "handle-" + rotation}
+	if diff := cmp.Diff(wantDeleted, queue.deleted); diff != "" {
+		t.Errorf("unexpected deleted messages (-want +got):\n%s", diff)
+	}
+}
+
+func TestWatchError(t *testing.T) {
+	queue := &fakeQueue{err: errors.New("access denied")}
+	err := Watch(context.Background(), queue, "https://sqs.eu-west-1.amazonaws.com/123456789012/changes", func(string) {})
+	if err == nil || err.Error() != "unable to receive change events: access denied" {
+		t.Errorf("Watch() error = %v", err)
+	}
+}
+
+func TestChangedSecretKeys(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want []string
+	}{
+		{
+			name: "created secret",
+			body: `{"source":"aws.secretsmanager","detail":{"requestParameters":{"name":"db-creds"},"responseElements":{"arn":"arn:aws:secretsmanager:eu-west-1:123456789012:secret:db-creds-AbCdEf"}}}`,
+			want: []string{"db-creds", "arn:aws:secretsmanager:eu-west-1:123456789012:secret:db-creds-AbCdEf"},
+		},
+		{
+			name: "unknown secret refreshes all",
+			body: `{"source":"aws.secretsmanager","detail":{}}`,
+			want: []string{""},
+		},
+		{
+			name: "other source",
+			body: `{"source":"aws.ssm","detail":{"requestParameters":{"name":"db-creds"}}}`,
+		},
+		{
+			name: "invalid message",
+			body: `not json`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if diff := cmp.Diff(tt.want, changedSecretKeys(tt.body)); diff != "" {
+				t.Errorf("unexpected keys (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
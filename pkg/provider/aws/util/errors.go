@@ -17,6 +17,10 @@ package util
 import (
 	"errors"
 	"regexp"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
 )
 
 var regexReqIDs = []*regexp.Regexp{
@@ -25,10 +29,50 @@ var regexReqIDs = []*regexp.Regexp{
 }
 
 // SanitizeErr sanitizes the error string.
+// The original error is kept in the chain, so its type can still be checked with errors.As.
 func SanitizeErr(err error) error {
 	msg := err.Error()
 	for _, regex := range regexReqIDs {
 		msg = string(regex.ReplaceAll([]byte(msg), nil))
 	}
-	return errors.New(msg)
+	return &sanitizedError{msg: msg, err: err}
+}
+
+type sanitizedError struct {
+	msg string
+	err error
+}
+
+func (e *sanitizedError) Error() string {
+	return e.msg
+}
+
+func (e *sanitizedError) Unwrap() error {
+	return e.err
+}
+
+// ClassifyError maps the error codes of the AWS APIs to provider error reasons.
+func ClassifyError(err error) esv1beta1.ProviderErrorReason {
+	var aerr awserr.Error
+	if !errors.As(err, &aerr) {
+		return ""
+	}
+	switch aerr.Code() {
+	case "AccessDenied", "AccessDeniedException":
+		return esv1beta1.ProviderErrorPermissionDenied
+	case "ResourceNotFoundException", "ParameterNotFound", "ParameterVersionNotFound":
+		return esv1beta1.ProviderErrorNotFound
+	case "Throttling", "ThrottlingException", "TooManyRequestsException", "RequestLimitExceeded":
+		return esv1beta1.ProviderErrorRateLimited
+	case "UnrecognizedClientException", "InvalidClientTokenId", "ExpiredToken", "ExpiredTokenException",
+		"InvalidSignatureException", "IncompleteSignature", "SignatureDoesNotMatch":
+		return esv1beta1.ProviderErrorUnauthenticated
+	case "ServiceUnavailable", "ServiceUnavailableException", "InternalFailure", "InternalServiceError", "RequestError":
+		return esv1beta1.ProviderErrorUnavailable
+	}
+	var reqErr awserr.RequestFailure
+	if errors.As(err, &reqErr) {
+		return esv1beta1.ProviderErrorReasonForHTTPStatus(reqErr.StatusCode())
+	}
+	return ""
 }
@@ -16,9 +16,13 @@ package util
 
 import (
 	"errors"
+	"fmt"
 	"testing"
 
+	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/stretchr/testify/assert"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
 )
 
 func TestSanitize(t *testing.T) {
@@ -45,3 +49,46 @@ func TestSanitize(t *testing.T) {
 		assert.Equal(t, c.expected, out.Error())
 	}
 }
+
+func TestClassifyError(t *testing.T) {
+	tbl := []struct {
+		name     string
+		err      error
+		expected esv1beta1.ProviderErrorReason
+	}{
+		{
+			name:     "access denied",
+			err:      awserr.New("AccessDeniedException", "not authorized", nil),
+			expected: esv1beta1.ProviderErrorPermissionDenied,
+		},
+		{
+			name:     "sanitized and wrapped",
+			err:      fmt.Errorf("reading secret: %w", SanitizeErr(awserr.New("ThrottlingException", "rate exceeded", nil))),
+			expected: esv1beta1.ProviderErrorRateLimited,
+		},
+		{
+			name:     "expired token",
+			err:      awserr.New("ExpiredTokenException", "token expired", nil),
+			expected: esv1beta1.ProviderErrorUnauthenticated,
+		},
+		{
+			name:     "unknown code with status",
+			err:      awserr.NewRequestFailure(awserr.New("SomethingBroke", "bad gateway", nil), 502, "id"),
+			expected: esv1beta1.ProviderErrorUnavailable,
+		},
+		{
+			name: "unknown code",
+			err:  awserr.New("ValidationException", "invalid", nil),
+		},
+		{
+			name: "not an AWS error",
+			err:  errors.New("some generic error"),
+		},
+	}
+
+	for _, c := range tbl {
+		t.Run(c.name, func(t *testing.T) {
+			assert.Equal(t, c.expected, ClassifyError(c.err))
+		})
+	}
+}
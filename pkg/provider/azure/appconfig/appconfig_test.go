@@ -0,0 +1,415 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package appconfig
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"slices"
+	"strings"
+	"testing"
+
+	kvsdk "github.com/Azure/azure-sdk-for-go/services/keyvault/v7.0/keyvault"
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+)
+
+const contentTypeKeyVaultRefUTF8 = contentTypeKeyVaultRef + ";charset=utf-8"
+
+// fakeAppConfig implements the key-value endpoints of the App Configuration REST API,
+// lists return one key-value per page to exercise the next links.
+type fakeAppConfig struct {
+	kvs []keyValue
+}
+
+func (f *fakeAppConfig) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	if query.Get("api-version") != apiVersion {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	label := query.Get("label")
+	if key, ok := strings.CutPrefix(r.URL.Path, "/kv/"); ok {
+		for _, kv := range f.kvs {
+			if kv.Key == key && labelOf(kv) == label {
+				_ = json.NewEncoder(w).Encode(kv)
+				return
+			}
+		}
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	var matches []keyValue
+	for _, kv := range f.kvs {
+		prefix, hasFilter := strings.CutSuffix(query.Get("key"), "*")
+		if labelOf(kv) == label && (!hasFilter || strings.HasPrefix(kv.Key, prefix)) {
+			matches = append(matches, kv)
+		}
+	}
+	after := 0
+	if a := query.Get("after"); a != "" {
+		after = slices.IndexFunc(matches, func(kv keyValue) bool { return kv.Key == a }) + 1
+	}
+	page := keyValueList{Items: matches[after:min(after+1, len(matches))]}
+	if after+1 < len(matches) {
+		next := url.Values{"api-version": {apiVersion}, "label": {label}, "after": {matches[after].Key}}
+		if key := query.Get("key"); key != "" {
+			next.Set("key", key)
+		}
+		page.NextLink = "/kv?" + next.Encode()
+	}
+	_ = json.NewEncoder(w).Encode(page)
+}
+
+func labelOf(kv keyValue) string {
+	if kv.Label == nil {
+		return nullLabel
+	}
+	return *kv.Label
+}
+
+type fakeKeyVault struct {
+	secrets map[string]string
+	calls   []string
+}
+
+func (f *fakeKeyVault) GetSecret(_ context.Context, vaultBaseURL, secretName, secretVersion string) (kvsdk.SecretBundle, error) {
+	id := vaultBaseURL + "/" + secretName + "/" + secretVersion
+	f.calls = append(f.calls, id)
+	value, ok := f.secrets[id]
+	if !ok {
+		return kvsdk.SecretBundle{}, errors.New("secret not found")
+	}
+	return kvsdk.SecretBundle{Value: &value}, nil
+}
+
+func newTestClient(t *testing.T, label string, kvs ...keyValue) *Client {
+	t.Helper()
+	srv := httptest.NewServer(&fakeAppConfig{kvs: kvs})
+	t.Cleanup(srv.Close)
+	endpoint, err := url.Parse(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &Client{
+		endpoint:   endpoint,
+		label:      label,
+		httpClient: srv.Client(),
+	}
+}
+
+var testKeyValues = []keyValue{
+	{Key: "app/db-host", Value: ptr.To("db.default")},
+	{Key: "app/db-host", Label: ptr.To("prod"), Value: ptr.To("db.prod")},
+	{Key: "app/settings", ContentType: "application/json", Value: ptr.To(`{"user":"admin","port":5432,"tls":{"enabled":true}}`)},
+	{Key: "app/settings", Label: ptr.To("prod"), ContentType: "application/json;charset=utf-8", Value: ptr.To(`{"user":"prod-admin"}`)},
+	{Key: "app/banner", ContentType: "text/plain", Value: ptr.To(`{"looks":"like json"}`)},
+	{Key: "app/untyped", Value: ptr.To(`{"user":"untyped"}`)},
+	{Key: "app/password", ContentType: contentTypeKeyVaultRefUTF8, Value: ptr.To(`{"uri":"https://my-vault.vault.azure.net/secrets/db-password"}`)},
+	{Key: "other/flag", Value: ptr.To("on"), Tags: map[string]string{"team": "web"}},
+}
+
+func TestGetSecret(t *testing.T) {
+	tests := []struct {
+		name     string
+		label    string
+		ref      esv1beta1.ExternalSecretDataRemoteRef
+		want     string
+		wantErr  string
+		notFound bool
+	}{
+		{
+			name: "key without a label",
+			ref:  esv1beta1.ExternalSecretDataRemoteRef{Key: "app/db-host"},
+			want: "db.default",
+		},
+		{
+			name:  "label of the store",
+			label: "prod",
+			ref:   esv1beta1.ExternalSecretDataRemoteRef{Key: "app/db-host"},
+			want:  "db.prod",
+		},
+		{
+			name: "version selects the label",
+			ref:  esv1beta1.ExternalSecretDataRemoteRef{Key: "app/db-host", Version: "prod"},
+			want: "db.prod",
+		},
+		{
+			name:     "missing label",
+			ref:      esv1beta1.ExternalSecretDataRemoteRef{Key: "app/db-host", Version: "staging"},
+			notFound: true,
+		},
+		{
+			name:     "missing key",
+			ref:      esv1beta1.ExternalSecretDataRemoteRef{Key: "app/missing"},
+			notFound: true,
+		},
+		{
+			name: "property of a JSON value",
+			ref:  esv1beta1.ExternalSecretDataRemoteRef{Key: "app/settings", Property: "tls.enabled"},
+			want: "true",
+		},
+		{
+			name: "property of a JSON value with charset",
+			ref:  esv1beta1.ExternalSecretDataRemoteRef{Key: "app/settings", Version: "prod", Property: "user"},
+			want: "prod-admin",
+		},
+		{
+			name: "property of a value without content type",
+			ref:  esv1beta1.ExternalSecretDataRemoteRef{Key: "app/untyped", Property: "user"},
+			want: "untyped",
+		},
+		{
+			name:     "missing property",
+			ref:      esv1beta1.ExternalSecretDataRemoteRef{Key: "app/settings", Property: "missing"},
+			notFound: true,
+		},
+		{
+			name:    "property of a text value",
+			ref:     esv1beta1.ExternalSecretDataRemoteRef{Key: "app/banner", Property: "looks"},
+			wantErr: `value of key app/banner is not JSON, content type: "text/plain"`,
+		},
+		{
+			name: "key vault reference is not resolved by default",
+			ref:  esv1beta1.ExternalSecretDataRemoteRef{Key: "app/password"},
+			want: `{"uri":"https://my-vault.vault.azure.net/secrets/db-password"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newTestClient(t, tt.label, testKeyValues...)
+			got, err := c.GetSecret(context.Background(), tt.ref)
+			switch {
+			case tt.notFound:
+				if !errors.Is(err, esv1beta1.NoSecretErr) {
+					t.Fatalf("GetSecret() error = %v, want NoSecretErr", err)
+				}
+				return
+			case tt.wantErr != "":
+				if err == nil || err.Error() != tt.wantErr {
+					t.Fatalf("GetSecret() error = %v, want %s", err, tt.wantErr)
+				}
+				return
+			case err != nil:
+				t.Fatalf("GetSecret() error = %v", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("GetSecret() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetSecretMap(t *testing.T) {
+	tests := []struct {
+		name    string
+		ref     esv1beta1.ExternalSecretDataRemoteRef
+		want    map[string]string
+		wantErr string
+	}{
+		{
+			name: "JSON content type",
+			ref:  esv1beta1.ExternalSecretDataRemoteRef{Key: "app/settings"},
+			want: map[string]string{"user": "admin", "port": "5432", "tls": `{"enabled":true}`},
+		},
+		{
+			name: "JSON field selected by the property",
+			ref:  esv1beta1.ExternalSecretDataRemoteRef{Key: "app/settings", Property: "tls"},
+			want: map[string]string{"enabled": "true"},
+		},
+		{
+			name: "labeled JSON value",
+			ref:  esv1beta1.ExternalSecretDataRemoteRef{Key: "app/settings", Version: "prod"},
+			want: map[string]string{"user": "prod-admin"},
+		},
+		{
+			name: "JSON value without content type",
+			ref:  esv1beta1.ExternalSecretDataRemoteRef{Key: "app/untyped"},
+			want: map[string]string{"user": "untyped"},
+		},
+		{
+			name:    "text content type",
+			ref:     esv1beta1.ExternalSecretDataRemoteRef{Key: "app/banner"},
+			wantErr: `value of key app/banner is not JSON, content type: "text/plain"`,
+		},
+		{
+			name:    "plain value without content type",
+			ref:     esv1beta1.ExternalSecretDataRemoteRef{Key: "app/db-host"},
+			wantErr: `value of key app/db-host is not JSON, content type: ""`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newTestClient(t, "", testKeyValues...)
+			got, err := c.GetSecretMap(context.Background(), tt.ref)
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Fatalf("GetSecretMap() error = %v, want %s", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("GetSecretMap() error = %v", err)
+			}
+			if diff := cmp.Diff(tt.want, toStrings(got)); diff != "" {
+				t.Errorf("GetSecretMap() (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestGetAllSecrets(t *testing.T) {
+	tests := []struct {
+		name  string
+		label string
+		find  esv1beta1.ExternalSecretFind
+		want  map[string]string
+	}{
+		{
+			name: "key prefix across pages",
+			find: esv1beta1.ExternalSecretFind{Path: ptr.To("app/")},
+			want: map[string]string{
+				"app/db-host":  "db.default",
+				"app/settings": `{"user":"admin","port":5432,"tls":{"enabled":true}}`,
+				"app/banner":   `{"looks":"like json"}`,
+				"app/untyped":  `{"user":"untyped"}`,
+				"app/password": `{"uri":"https://my-vault.vault.azure.net/secrets/db-password"}`,
+			},
+		},
+		{
+			name:  "key prefix with the label of the store",
+			label: "prod",
+			find:  esv1beta1.ExternalSecretFind{Path: ptr.To("app/")},
+			want: map[string]string{
+				"app/db-host":  "db.prod",
+				"app/settings": `{"user":"prod-admin"}`,
+			},
+		},
+		{
+			name: "name",
+			find: esv1beta1.ExternalSecretFind{Name: &esv1beta1.FindName{RegExp: "^app/db"}},
+			want: map[string]string{"app/db-host": "db.default"},
+		},
+		{
+			name: "tags",
+			find: esv1beta1.ExternalSecretFind{Tags: map[string]string{"team": "web"}},
+			want: map[string]string{"other/flag": "on"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newTestClient(t, tt.label, testKeyValues...)
+			got, err := c.GetAllSecrets(context.Background(), tt.find)
+			if err != nil {
+				t.Fatalf("GetAllSecrets() error = %v", err)
+			}
+			if diff := cmp.Diff(tt.want, toStrings(got)); diff != "" {
+				t.Errorf("GetAllSecrets() (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestResolveKeyVaultReferences(t *testing.T) {
+	kv := &fakeKeyVault{secrets: map[string]string{
+		"https://my-vault.vault.azure.net/db-password/":   "s3cr3t",
+		"https://my-vault.vault.azure.net/db-password/v2": "old",
+	}}
+	c := newTestClient(t, "",
+		keyValue{Key: "password", ContentType: contentTypeKeyVaultRefUTF8, Value: ptr.To(`{"uri":"https://my-vault.vault.azure.net/secrets/db-password"}`)},
+		keyValue{Key: "password", Label: ptr.To("v2"), ContentType: contentTypeKeyVaultRefUTF8, Value: ptr.To(`{"uri":"https://my-vault.vault.azure.net/secrets/db-password/v2"}`)},
+		keyValue{Key: "invalid", ContentType: contentTypeKeyVaultRefUTF8, Value: ptr.To(`{"uri":"https://my-vault.vault.azure.net/keys/db-key"}`)},
+	)
+	c.keyVault = kv
+
+	got, err := c.GetSecret(context.Background(), esv1beta1.ExternalSecretDataRemoteRef{Key: "password"})
+	if err != nil || string(got) != "s3cr3t" {
+		t.Errorf("GetSecret() = %q, %v, want the secret of the vault", got, err)
+	}
+	got, err = c.GetSecret(context.Background(), esv1beta1.ExternalSecretDataRemoteRef{Key: "password", Version: "v2"})
+	if err != nil || string(got) != "old" {
+		t.Errorf("GetSecret() = %q, %v, want the version of the reference", got, err)
+	}
+	all, err := c.GetAllSecrets(context.Background(), esv1beta1.ExternalSecretFind{Name: &esv1beta1.FindName{RegExp: "^password$"}})
+	if err != nil {
+		t.Fatalf("GetAllSecrets() error = %v", err)
+	}
+	if diff := cmp.Diff(map[string]string{"password": "s3cr3t"}, toStrings(all)); diff != "" {
+		t.Errorf("GetAllSecrets() (-want +got):\n%s", diff)
+	}
+	if _, err := c.GetSecret(context.Background(), esv1beta1.ExternalSecretDataRemoteRef{Key: "invalid"}); err == nil ||
+		!strings.Contains(err.Error(), "invalid Key Vault secret URI") {
+		t.Errorf("GetSecret() error = %v, want an invalid URI error", err)
+	}
+}
+
+func TestValidateStore(t *testing.T) {
+	tests := []struct {
+		name    string
+		prov    *esv1beta1.AzureAppConfigProvider
+		wantErr string
+	}{
+		{
+			name: "valid",
+			prov: &esv1beta1.AzureAppConfigProvider{Endpoint: "https://my-store.azconfig.io"},
+		},
+		{
+			name:    "missing endpoint",
+			prov:    &esv1beta1.AzureAppConfigProvider{},
+			wantErr: "invalid store: endpoint is required",
+		},
+		{
+			name:    "http endpoint",
+			prov:    &esv1beta1.AzureAppConfigProvider{Endpoint: "http://my-store.azconfig.io"},
+			wantErr: `invalid endpoint "http://my-store.azconfig.io": scheme must be https`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := &esv1beta1.SecretStore{
+				ObjectMeta: metav1.ObjectMeta{Name: "store", Namespace: "default"},
+				Spec: esv1beta1.SecretStoreSpec{
+					Provider: &esv1beta1.SecretStoreProvider{AzureAppConfig: tt.prov},
+				},
+			}
+			_, err := (&Provider{}).ValidateStore(store)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("ValidateStore() error = %v", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.wantErr {
+				t.Fatalf("ValidateStore() error = %v, want %s", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func toStrings(data map[string][]byte) map[string]string {
+	out := make(map[string]string, len(data))
+	for k, v := range data {
+		out[k] = string(v)
+	}
+	return out
+}
@@ -0,0 +1,373 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package appconfig
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"strings"
+
+	kvsdk "github.com/Azure/azure-sdk-for-go/services/keyvault/v7.0/keyvault"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/tidwall/gjson"
+	corev1 "k8s.io/api/core/v1"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	"github.com/external-secrets/external-secrets/pkg/find"
+	"github.com/external-secrets/external-secrets/pkg/utils"
+)
+
+const (
+	apiVersion = "1.0"
+
+	// nullLabel selects the key-values without a label.
+	nullLabel = "\x00"
+
+	contentTypeKeyVaultRef = "application/vnd.microsoft.appconfig.keyvaultref+json"
+
+	errGetKey          = "unable to get key %s: %w"
+	errListKeys        = "unable to list keys: %w"
+	errRequest         = "request failed with status %d: %s"
+	errNotJSON         = "value of key %s is not JSON, content type: %q"
+	errUnmarshalJSON   = "unable to parse the value of key %s as JSON object: %w"
+	errKeyVaultRef     = "unable to resolve the Key Vault reference of key %s: %w"
+	errInvalidRefURI   = "invalid Key Vault secret URI %q"
+	errUnsupportedFind = "unsupported find operator: %#v"
+)
+
+var errNotImplemented = errors.New("not implemented")
+
+// secretGetter reads a secret from Key Vault, it is implemented by the Key Vault client.
+type secretGetter interface {
+	GetSecret(ctx context.Context, vaultBaseURL, secretName, secretVersion string) (kvsdk.SecretBundle, error)
+}
+
+// Client reads key-values from an App Configuration store.
+type Client struct {
+	endpoint   *url.URL
+	label      string
+	httpClient *http.Client
+	authorizer autorest.Authorizer
+	// keyVault resolves Key Vault references, they are returned as they are if it is nil.
+	keyVault secretGetter
+	// referent is set if the client was created without the namespace of a referent ClusterSecretStore.
+	referent bool
+}
+
+// keyValue is a key-value of the App Configuration REST API.
+type keyValue struct {
+	Key         string            `json:"key"`
+	Label       *string           `json:"label"`
+	ContentType string            `json:"content_type"`
+	Value       *string           `json:"value"`
+	Tags        map[string]string `json:"tags"`
+}
+
+type keyValueList struct {
+	Items    []keyValue `json:"items"`
+	NextLink string     `json:"@nextLink"`
+}
+
+// GetSecret returns the value of the key, the version selects its label.
+// The property selects a field of JSON values.
+func (c *Client) GetSecret(ctx context.Context, ref esv1beta1.ExternalSecretDataRemoteRef) ([]byte, error) {
+	value, _, err := c.getValue(ctx, ref)
+	return value, err
+}
+
+// getValue returns the value of the key or the field selected by the property, and the content type of the key.
+func (c *Client) getValue(ctx context.Context, ref esv1beta1.ExternalSecretDataRemoteRef) ([]byte, string, error) {
+	kv, err := c.getKeyValue(ctx, ref.Key, c.labelFor(ref))
+	if err != nil {
+		return nil, "", err
+	}
+	value, contentType, err := c.resolve(ctx, kv)
+	if err != nil {
+		return nil, "", err
+	}
+	if ref.Property == "" {
+		return value, contentType, nil
+	}
+	if !isJSON(contentType, value) {
+		return nil, "", fmt.Errorf(errNotJSON, ref.Key, contentType)
+	}
+	val := gjson.GetBytes(value, ref.Property)
+	if !val.Exists() {
+		return nil, "", esv1beta1.NoSecretErr
+	}
+	return []byte(val.String()), contentType, nil
+}
+
+// GetSecretMap decomposes a JSON value, or the JSON field selected by the property, into its fields.
+func (c *Client) GetSecretMap(ctx context.Context, ref esv1beta1.ExternalSecretDataRemoteRef) (map[string][]byte, error) {
+	value, contentType, err := c.getValue(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+	if ref.Property == "" && !isJSON(contentType, value) {
+		return nil, fmt.Errorf(errNotJSON, ref.Key, contentType)
+	}
+	fields := make(map[string]json.RawMessage)
+	if err := json.Unmarshal(value, &fields); err != nil {
+		return nil, fmt.Errorf(errUnmarshalJSON, ref.Key, err)
+	}
+	secretData := make(map[string][]byte, len(fields))
+	for k, v := range fields {
+		var str string
+		if err := json.Unmarshal(v, &str); err == nil {
+			secretData[k] = []byte(str)
+		} else {
+			secretData[k] = v
+		}
+	}
+	return secretData, nil
+}
+
+// GetAllSecrets returns the key-values with the label of the store whose key starts with find.path,
+// matches find.name and has all find.tags.
+func (c *Client) GetAllSecrets(ctx context.Context, ref esv1beta1.ExternalSecretFind) (map[string][]byte, error) {
+	if ref.Name == nil && ref.Path == nil && len(ref.Tags) == 0 {
+		return nil, fmt.Errorf(errUnsupportedFind, ref)
+	}
+	var matcher *find.Matcher
+	if ref.Name != nil {
+		var err error
+		matcher, err = find.New(*ref.Name)
+		if err != nil {
+			return nil, err
+		}
+	}
+	var prefix string
+	if ref.Path != nil {
+		prefix = *ref.Path
+	}
+	kvs, err := c.listKeyValues(ctx, prefix, c.storeLabel())
+	if err != nil {
+		return nil, err
+	}
+
+	data := make(map[string][]byte)
+	for _, kv := range kvs {
+		if matcher != nil && !matcher.MatchName(kv.Key) {
+			continue
+		}
+		if !hasTags(kv.Tags, ref.Tags) {
+			continue
+		}
+		value, _, err := c.resolve(ctx, &kv)
+		if err != nil {
+			return nil, err
+		}
+		data[kv.Key] = value
+	}
+	return utils.ConvertKeys(ref.ConversionStrategy, data)
+}
+
+func hasTags(tags, want map[string]string) bool {
+	for k, v := range want {
+		if tag, ok := tags[k]; !ok || tag != v {
+			return false
+		}
+	}
+	return true
+}
+
+// resolve returns the value of the key-value and its content type.
+// Key Vault references are resolved if enabled, the secret of Key Vault has no content type.
+func (c *Client) resolve(ctx context.Context, kv *keyValue) ([]byte, string, error) {
+	var value string
+	if kv.Value != nil {
+		value = *kv.Value
+	}
+	if c.keyVault == nil || !isKeyVaultRef(kv.ContentType) {
+		return []byte(value), kv.ContentType, nil
+	}
+	secret, err := c.getKeyVaultSecret(ctx, value)
+	if err != nil {
+		return nil, "", fmt.Errorf(errKeyVaultRef, kv.Key, err)
+	}
+	return secret, "", nil
+}
+
+// getKeyVaultSecret reads the secret of a Key Vault reference,
+// e.g. {"uri":"https://my-vault.vault.azure.net/secrets/my-secret"}.
+func (c *Client) getKeyVaultSecret(ctx context.Context, reference string) ([]byte, error) {
+	var ref struct {
+		URI string `json:"uri"`
+	}
+	if err := json.Unmarshal([]byte(reference), &ref); err != nil {
+		return nil, err
+	}
+	u, err := url.Parse(ref.URI)
+	if err != nil || u.Host == "" {
+		return nil, fmt.Errorf(errInvalidRefURI, ref.URI)
+	}
+	// the path is /secrets/<name>[/<version>]
+	parts := strings.Split(strings.Trim(u.Path, "/"), "/")
+	if len(parts) < 2 || len(parts) > 3 || parts[0] != "secrets" || parts[1] == "" {
+		return nil, fmt.Errorf(errInvalidRefURI, ref.URI)
+	}
+	var version string
+	if len(parts) == 3 {
+		version = parts[2]
+	}
+	secret, err := c.keyVault.GetSecret(ctx, u.Scheme+"://"+u.Host, parts[1], version)
+	if err != nil {
+		var detailed autorest.DetailedError
+		if errors.As(err, &detailed) && detailed.StatusCode == http.StatusNotFound {
+			return nil, esv1beta1.NoSecretErr
+		}
+		return nil, err
+	}
+	if secret.Value == nil {
+		return nil, nil
+	}
+	return []byte(*secret.Value), nil
+}
+
+// labelFor returns the label of a key, remoteRef.version overrides the label of the store.
+func (c *Client) labelFor(ref esv1beta1.ExternalSecretDataRemoteRef) string {
+	if ref.Version != "" {
+		return ref.Version
+	}
+	return c.storeLabel()
+}
+
+func (c *Client) storeLabel() string {
+	if c.label == "" {
+		return nullLabel
+	}
+	return c.label
+}
+
+func (c *Client) getKeyValue(ctx context.Context, key, label string) (*keyValue, error) {
+	query := url.Values{"api-version": {apiVersion}, "label": {label}}
+	var kv keyValue
+	if err := c.get(ctx, "/kv/"+url.PathEscape(key), query, &kv); err != nil {
+		if errors.Is(err, esv1beta1.NoSecretErr) {
+			return nil, err
+		}
+		return nil, fmt.Errorf(errGetKey, key, err)
+	}
+	return &kv, nil
+}
+
+// listKeyValues returns all key-values with the label whose key starts with prefix.
+func (c *Client) listKeyValues(ctx context.Context, prefix, label string) ([]keyValue, error) {
+	query := url.Values{"api-version": {apiVersion}, "label": {label}}
+	if prefix != "" {
+		query.Set("key", escapeFilter(prefix)+"*")
+	}
+	var kvs []keyValue
+	path := "/kv"
+	for {
+		var page keyValueList
+		if err := c.get(ctx, path, query, &page); err != nil {
+			return nil, fmt.Errorf(errListKeys, err)
+		}
+		kvs = append(kvs, page.Items...)
+		if page.NextLink == "" {
+			return kvs, nil
+		}
+		// the next link holds the path and the whole query
+		next, err := url.Parse(page.NextLink)
+		if err != nil {
+			return nil, fmt.Errorf(errListKeys, err)
+		}
+		path, query = next.Path, next.Query()
+	}
+}
+
+// escapeFilter escapes the characters with a special meaning in key filters.
+func escapeFilter(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `*`, `\*`, `,`, `\,`).Replace(s)
+}
+
+func (c *Client) get(ctx context.Context, path string, query url.Values, out any) error {
+	u := c.endpoint.JoinPath(path)
+	u.RawQuery = query.Encode()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), http.NoBody)
+	if err != nil {
+		return err
+	}
+	if c.authorizer != nil {
+		req, err = autorest.Prepare(req, c.authorizer.WithAuthorization())
+		if err != nil {
+			return err
+		}
+	}
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		return esv1beta1.NoSecretErr
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf(errRequest, resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+	return json.Unmarshal(body, out)
+}
+
+func isKeyVaultRef(contentType string) bool {
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	return mediaType == contentTypeKeyVaultRef
+}
+
+// isJSON returns true for JSON content types, values without a content type are JSON if they can be parsed.
+func isJSON(contentType string, value []byte) bool {
+	if contentType == "" {
+		return json.Valid(value)
+	}
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	return mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")
+}
+
+func (c *Client) PushSecret(_ context.Context, _ *corev1.Secret, _ esv1beta1.PushSecretData) error {
+	return errNotImplemented
+}
+
+func (c *Client) DeleteSecret(_ context.Context, _ esv1beta1.PushSecretRemoteRef) error {
+	return errNotImplemented
+}
+
+func (c *Client) SecretExists(_ context.Context, _ esv1beta1.PushSecretRemoteRef) (bool, error) {
+	return false, errNotImplemented
+}
+
+func (c *Client) Validate() (esv1beta1.ValidationResult, error) {
+	if c.referent {
+		return esv1beta1.ValidationResultUnknown, nil
+	}
+	return esv1beta1.ValidationResultReady, nil
+}
+
+func (c *Client) Close(_ context.Context) error {
+	return nil
+}
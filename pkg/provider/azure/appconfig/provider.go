@@ -0,0 +1,190 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package appconfig implements a provider that reads key-values from Azure App Configuration.
+package appconfig
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	kvsdk "github.com/Azure/azure-sdk-for-go/services/keyvault/v7.0/keyvault"
+	"github.com/Azure/go-autorest/autorest"
+	kclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	esmeta "github.com/external-secrets/external-secrets/apis/meta/v1"
+	"github.com/external-secrets/external-secrets/pkg/provider/azure/keyvault"
+	"github.com/external-secrets/external-secrets/pkg/utils"
+)
+
+const (
+	errInvalidStore     = "invalid store: %w"
+	errInvalidEndpoint  = "invalid endpoint %q: %w"
+	errInvalidSecretRef = "invalid authSecretRef.%s: %w"
+	errInvalidSARef     = "invalid serviceAccountRef: %w"
+	errAuthorizer       = "unable to authenticate with Azure: %w"
+)
+
+var (
+	errMissingStore        = errors.New("missing store provider")
+	errMissingAppConfig    = errors.New("missing store provider azureappconfig")
+	errMissingEndpoint     = errors.New("endpoint is required")
+	errUnsupportedScheme   = errors.New("scheme must be https")
+	errMissingEndpointHost = errors.New("host must not be empty")
+)
+
+// Provider is an Azure App Configuration provider implementing NewClient and ValidateStore for the esv1beta1.Provider interface.
+type Provider struct{}
+
+var _ esv1beta1.SecretsClient = &Client{}
+var _ esv1beta1.Provider = &Provider{}
+
+func init() {
+	esv1beta1.Register(&Provider{}, &esv1beta1.SecretStoreProvider{
+		AzureAppConfig: &esv1beta1.AzureAppConfigProvider{},
+	})
+}
+
+// Capabilities return the provider supported capabilities (ReadOnly, WriteOnly, ReadWrite).
+func (p *Provider) Capabilities() esv1beta1.SecretStoreCapabilities {
+	return esv1beta1.SecretStoreReadOnly
+}
+
+func (p *Provider) NewClient(ctx context.Context, store esv1beta1.GenericStore, kube kclient.Client, namespace string) (esv1beta1.SecretsClient, error) {
+	prov, err := getProvider(store)
+	if err != nil {
+		return nil, err
+	}
+	endpoint, err := parseEndpoint(prov.Endpoint)
+	if err != nil {
+		return nil, err
+	}
+	httpClient := &http.Client{}
+	if prov.Proxy != nil {
+		httpClient.Transport = utils.ProxyTransport(prov.Proxy)
+	}
+	c := &Client{
+		endpoint:   endpoint,
+		label:      prov.Label,
+		httpClient: httpClient,
+	}
+
+	// allow SecretStore controller validation to pass
+	// when using referent namespace.
+	auth := authProvider(prov)
+	if store.GetKind() == esv1beta1.ClusterSecretStoreKind && namespace == "" && keyvault.IsReferentSpec(auth) {
+		c.referent = true
+		return c, nil
+	}
+
+	// the tokens of App Configuration are issued for the endpoint of the store
+	c.authorizer, err = keyvault.NewAuthorizer(ctx, store, auth, kube, namespace, endpoint.Scheme+"://"+endpoint.Host)
+	if err != nil {
+		return nil, fmt.Errorf(errAuthorizer, err)
+	}
+	if prov.ResolveKeyVaultReferences {
+		// an empty resource issues tokens for Key Vault
+		kvAuthorizer, err := keyvault.NewAuthorizer(ctx, store, auth, kube, namespace, "")
+		if err != nil {
+			return nil, fmt.Errorf(errAuthorizer, err)
+		}
+		kv := kvsdk.New()
+		kv.Authorizer = kvAuthorizer
+		kv.Sender = autorest.Sender(httpClient)
+		c.keyVault = &kv
+	}
+	return c, nil
+}
+
+// authProvider returns the auth fields of the store in the form of the Key Vault provider,
+// so the auth of Key Vault can be reused.
+func authProvider(prov *esv1beta1.AzureAppConfigProvider) *esv1beta1.AzureKVProvider {
+	return &esv1beta1.AzureKVProvider{
+		AuthType:          prov.AuthType,
+		TenantID:          prov.TenantID,
+		EnvironmentType:   prov.EnvironmentType,
+		AuthSecretRef:     prov.AuthSecretRef,
+		ServiceAccountRef: prov.ServiceAccountRef,
+		IdentityID:        prov.IdentityID,
+		Proxy:             prov.Proxy,
+	}
+}
+
+func parseEndpoint(endpoint string) (*url.URL, error) {
+	if endpoint == "" {
+		return nil, fmt.Errorf(errInvalidStore, errMissingEndpoint)
+	}
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, fmt.Errorf(errInvalidEndpoint, endpoint, err)
+	}
+	if u.Scheme != "https" {
+		return nil, fmt.Errorf(errInvalidEndpoint, endpoint, errUnsupportedScheme)
+	}
+	if u.Host == "" {
+		return nil, fmt.Errorf(errInvalidEndpoint, endpoint, errMissingEndpointHost)
+	}
+	return u, nil
+}
+
+func getProvider(store esv1beta1.GenericStore) (*esv1beta1.AzureAppConfigProvider, error) {
+	if store == nil {
+		return nil, errMissingStore
+	}
+	spc := store.GetSpec()
+	if spc == nil || spc.Provider == nil || spc.Provider.AzureAppConfig == nil {
+		return nil, errMissingAppConfig
+	}
+	return spc.Provider.AzureAppConfig, nil
+}
+
+func (p *Provider) ValidateStore(store esv1beta1.GenericStore) (admission.Warnings, error) {
+	prov, err := getProvider(store)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := parseEndpoint(prov.Endpoint); err != nil {
+		return nil, err
+	}
+	if ref := prov.AuthSecretRef; ref != nil {
+		selectors := []struct {
+			field string
+			ref   *esmeta.SecretKeySelector
+		}{
+			{"clientId", ref.ClientID},
+			{"clientSecret", ref.ClientSecret},
+			{"tenantId", ref.TenantID},
+			{"clientCertificate", ref.ClientCertificate},
+		}
+		for _, sel := range selectors {
+			if sel.ref == nil {
+				continue
+			}
+			if err := utils.ValidateReferentSecretSelector(store, *sel.ref); err != nil {
+				return nil, fmt.Errorf(errInvalidSecretRef, sel.field, err)
+			}
+		}
+	}
+	if prov.ServiceAccountRef != nil {
+		if err := utils.ValidateReferentServiceAccountSelector(store, *prov.ServiceAccountRef); err != nil {
+			return nil, fmt.Errorf(errInvalidSARef, err)
+		}
+	}
+	return nil, nil
+}
@@ -121,6 +121,13 @@ func (mc *AzureMockClient) WithSetSecret(output keyvault.SecretBundle, err error
 	}
 }
 
+// WithSetSecretFn sets a func called with the parameters of SetSecret.
+func (mc *AzureMockClient) WithSetSecretFn(fn func(ctx context.Context, vaultBaseURL, secretName string, parameters keyvault.SecretSetParameters) (keyvault.SecretBundle, error)) {
+	if mc != nil {
+		mc.setSecret = fn
+	}
+}
+
 func (mc *AzureMockClient) WithDeleteSecret(output keyvault.DeletedSecretBundle, err error) {
 	if mc != nil {
 		mc.deleteSecret = func(_ context.Context, _, _ string) (keyvault.DeletedSecretBundle, error) {
@@ -22,6 +22,8 @@ import (
 	"encoding/pem"
 	"errors"
 	"fmt"
+	"maps"
+	"net/http"
 	"os"
 	"path"
 	"regexp"
@@ -99,6 +101,7 @@ const (
 // https://github.com/external-secrets/external-secrets/issues/644
 var _ esv1beta1.SecretsClient = &Azure{}
 var _ esv1beta1.Provider = &Azure{}
+var _ esv1beta1.ErrorClassifier = &Azure{}
 
 // interface to keyvault.BaseClient.
 type SecretClient interface {
@@ -121,10 +124,15 @@ type Azure struct {
 	provider   *esv1beta1.AzureKVProvider
 	baseClient SecretClient
 	namespace  string
+	// resource the tokens are issued for, the Key Vault resource of the environment if empty.
+	resource string
 }
 
 type PushSecretMetadataSpec struct {
 	ExpirationDate string `json:"expirationDate,omitempty"`
+	// Tags are merged into the tags of the pushed secret, key or certificate,
+	// the managed-by tag can't be overridden.
+	Tags map[string]string `json:"tags,omitempty"`
 }
 
 func init() {
@@ -168,29 +176,70 @@ func newClient(ctx context.Context, store esv1beta1.GenericStore, kube client.Cl
 	// when using referent namespace.
 	if store.GetKind() == esv1beta1.ClusterSecretStoreKind &&
 		namespace == "" &&
-		isReferentSpec(provider) {
+		IsReferentSpec(provider) {
 		return az, nil
 	}
 
-	var authorizer autorest.Authorizer
-	switch *provider.AuthType {
-	case esv1beta1.AzureManagedIdentity:
-		authorizer, err = az.authorizerForManagedIdentity()
-	case esv1beta1.AzureServicePrincipal:
-		authorizer, err = az.authorizerForServicePrincipal(ctx)
-	case esv1beta1.AzureWorkloadIdentity:
-		authorizer, err = az.authorizerForWorkloadIdentity(ctx, NewTokenProvider)
-	default:
-		err = errors.New(errMissingAuthType)
-	}
+	authorizer, err := az.authorizer(ctx)
 
 	cl := keyvault.New()
 	cl.Authorizer = authorizer
+	if provider.Proxy != nil {
+		cl.Sender = &http.Client{Transport: utils.ProxyTransport(provider.Proxy)}
+	}
 	az.baseClient = &cl
 
 	return az, err
 }
 
+// NewAuthorizer returns an authorizer for the auth configuration of provider, the tokens are issued for resource.
+// It lets other Azure services authenticate in the same way as Key Vault, only the auth fields of provider are used.
+func NewAuthorizer(ctx context.Context, store esv1beta1.GenericStore, provider *esv1beta1.AzureKVProvider, kube client.Client, namespace, resource string) (autorest.Authorizer, error) {
+	cfg, err := ctrlcfg.GetConfig()
+	if err != nil {
+		return nil, err
+	}
+	kubeClient, err := kubernetes.NewForConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+	az := &Azure{
+		crClient:   kube,
+		kubeClient: kubeClient.CoreV1(),
+		store:      store,
+		namespace:  namespace,
+		provider:   provider,
+		resource:   resource,
+	}
+	return az.authorizer(ctx)
+}
+
+// authorizer returns the authorizer of the configured auth type.
+func (a *Azure) authorizer(ctx context.Context) (autorest.Authorizer, error) {
+	authType := esv1beta1.AzureServicePrincipal
+	if a.provider.AuthType != nil {
+		authType = *a.provider.AuthType
+	}
+	switch authType {
+	case esv1beta1.AzureManagedIdentity:
+		return a.authorizerForManagedIdentity()
+	case esv1beta1.AzureServicePrincipal:
+		return a.authorizerForServicePrincipal(ctx)
+	case esv1beta1.AzureWorkloadIdentity:
+		return a.authorizerForWorkloadIdentity(ctx, NewTokenProvider)
+	default:
+		return nil, errors.New(errMissingAuthType)
+	}
+}
+
+// tokenResource returns the resource the tokens are issued for.
+func (a *Azure) tokenResource() string {
+	if a.resource != "" {
+		return a.resource
+	}
+	return kvResourceForProviderConfig(a.provider.EnvironmentType)
+}
+
 func getProvider(store esv1beta1.GenericStore) (*esv1beta1.AzureKVProvider, error) {
 	spc := store.GetSpec()
 	if spc == nil || spc.Provider.AzureKV == nil {
@@ -419,7 +468,28 @@ func canCreate(tags map[string]*string, err error) (bool, error) {
 	return true, nil
 }
 
-func (a *Azure) setKeyVaultSecret(ctx context.Context, secretName string, value []byte, expires *date.UnixTime) error {
+// mergeTags returns the existing tags with the tags of the PushSecret metadata and the
+// managed-by tag, which can't be overridden. changed reports if existing lacks any of them.
+func mergeTags(existing map[string]*string, tags map[string]string) (merged map[string]*string, changed bool) {
+	merged = make(map[string]*string, len(existing)+len(tags)+1)
+	maps.Copy(merged, existing)
+	for k, v := range tags {
+		if k == managedBy {
+			continue
+		}
+		if pointer.Deref(merged[k], "") != v {
+			merged[k] = pointer.To(v)
+			changed = true
+		}
+	}
+	if pointer.Deref(merged[managedBy], "") != managerLabel {
+		merged[managedBy] = pointer.To(managerLabel)
+		changed = true
+	}
+	return merged, changed
+}
+
+func (a *Azure) setKeyVaultSecret(ctx context.Context, secretName string, value []byte, expires *date.UnixTime, customTags map[string]string) error {
 	secret, err := a.baseClient.GetSecret(ctx, *a.provider.VaultURL, secretName, "")
 	metrics.ObserveAPICall(constants.ProviderAzureKV, constants.CallAzureKVGetSecret, err)
 	ok, err := canCreate(secret.Tags, err)
@@ -429,8 +499,9 @@ func (a *Azure) setKeyVaultSecret(ctx context.Context, secretName string, value
 	if !ok {
 		return nil
 	}
+	tags, tagsChanged := mergeTags(secret.Tags, customTags)
 	val := string(value)
-	if secret.Value != nil && val == *secret.Value {
+	if secret.Value != nil && val == *secret.Value && !tagsChanged {
 		if secret.Attributes != nil {
 			if (secret.Attributes.Expires == nil && expires == nil) ||
 				(secret.Attributes.Expires != nil && expires != nil && *secret.Attributes.Expires == *expires) {
@@ -441,9 +512,7 @@ func (a *Azure) setKeyVaultSecret(ctx context.Context, secretName string, value
 
 	secretParams := keyvault.SecretSetParameters{
 		Value: &val,
-		Tags: map[string]*string{
-			managedBy: pointer.To(managerLabel),
-		},
+		Tags:  tags,
 		SecretAttributes: &keyvault.SecretAttributes{
 			Enabled: pointer.To(true),
 		},
@@ -461,7 +530,7 @@ func (a *Azure) setKeyVaultSecret(ctx context.Context, secretName string, value
 	return nil
 }
 
-func (a *Azure) setKeyVaultCertificate(ctx context.Context, secretName string, value []byte) error {
+func (a *Azure) setKeyVaultCertificate(ctx context.Context, secretName string, value []byte, customTags map[string]string) error {
 	val := b64.StdEncoding.EncodeToString(value)
 	localCert, err := getCertificateFromValue(value)
 	if err != nil {
@@ -477,14 +546,13 @@ func (a *Azure) setKeyVaultCertificate(ctx context.Context, secretName string, v
 		return nil
 	}
 	b512 := sha3.Sum512(localCert.Raw)
-	if cert.Cer != nil && b512 == sha3.Sum512(*cert.Cer) {
+	tags, tagsChanged := mergeTags(cert.Tags, customTags)
+	if cert.Cer != nil && b512 == sha3.Sum512(*cert.Cer) && !tagsChanged {
 		return nil
 	}
 	params := keyvault.CertificateImportParameters{
 		Base64EncodedCertificate: &val,
-		Tags: map[string]*string{
-			managedBy: pointer.To(managerLabel),
-		},
+		Tags:                     tags,
 	}
 	_, err = a.baseClient.ImportCertificate(ctx, *a.provider.VaultURL, secretName, params)
 	metrics.ObserveAPICall(constants.ProviderAzureKV, constants.CallAzureKVImportCertificate, err)
@@ -505,7 +573,7 @@ func equalKeys(newKey, oldKey keyvault.JSONWebKey) bool {
 
 	return newKey.Kty == oldKey.Kty && (rsaCheck || symmetricCheck)
 }
-func (a *Azure) setKeyVaultKey(ctx context.Context, secretName string, value []byte) error {
+func (a *Azure) setKeyVaultKey(ctx context.Context, secretName string, value []byte, customTags map[string]string) error {
 	key, err := getKeyFromValue(value)
 	if err != nil {
 		return fmt.Errorf("could not load private key %v: %w", secretName, err)
@@ -532,15 +600,14 @@ func (a *Azure) setKeyVaultKey(ctx context.Context, secretName string, value []b
 	if !ok {
 		return nil
 	}
-	if keyFromVault.Key != nil && equalKeys(azkey, *keyFromVault.Key) {
+	tags, tagsChanged := mergeTags(keyFromVault.Tags, customTags)
+	if keyFromVault.Key != nil && equalKeys(azkey, *keyFromVault.Key) && !tagsChanged {
 		return nil
 	}
 	params := keyvault.KeyImportParameters{
 		Key:           &azkey,
 		KeyAttributes: &keyvault.KeyAttributes{},
-		Tags: map[string]*string{
-			managedBy: pointer.To(managerLabel),
-		},
+		Tags:          tags,
 	}
 	_, err = a.baseClient.ImportKey(ctx, *a.provider.VaultURL, secretName, params)
 	metrics.ObserveAPICall(constants.ProviderAzureKV, constants.CallAzureKVImportKey, err)
@@ -576,6 +643,11 @@ func (a *Azure) PushSecret(ctx context.Context, secret *corev1.Secret, data esv1
 		return fmt.Errorf("failed to parse push secret metadata: %w", err)
 	}
 
+	var tags map[string]string
+	if metadata != nil {
+		tags = metadata.Spec.Tags
+	}
+
 	if metadata != nil && metadata.Spec.ExpirationDate != "" {
 		t, err := time.Parse(time.RFC3339, metadata.Spec.ExpirationDate)
 		if err != nil {
@@ -588,11 +660,11 @@ func (a *Azure) PushSecret(ctx context.Context, secret *corev1.Secret, data esv1
 	objectType, secretName := getObjType(esv1beta1.ExternalSecretDataRemoteRef{Key: data.GetRemoteKey()})
 	switch objectType {
 	case defaultObjType:
-		return a.setKeyVaultSecret(ctx, secretName, value, expires)
+		return a.setKeyVaultSecret(ctx, secretName, value, expires, tags)
 	case objectTypeCert:
-		return a.setKeyVaultCertificate(ctx, secretName, value)
+		return a.setKeyVaultCertificate(ctx, secretName, value, tags)
 	case objectTypeKey:
-		return a.setKeyVaultKey(ctx, secretName, value)
+		return a.setKeyVaultKey(ctx, secretName, value, tags)
 	default:
 		return fmt.Errorf("secret type %v not supported", objectType)
 	}
@@ -699,6 +771,23 @@ func parseError(err error) error {
 	return err
 }
 
+// ClassifyError maps the status codes of the Key Vault API to provider error reasons.
+// Errors while acquiring a token are reported as Unauthenticated.
+func (a *Azure) ClassifyError(err error) esv1beta1.ProviderErrorReason {
+	var tokenErr adal.TokenRefreshError
+	if errors.As(err, &tokenErr) {
+		return esv1beta1.ProviderErrorUnauthenticated
+	}
+	aerr := autorest.DetailedError{}
+	if !errors.As(err, &aerr) {
+		return ""
+	}
+	if code, ok := aerr.StatusCode.(int); ok {
+		return esv1beta1.ProviderErrorReasonForHTTPStatus(code)
+	}
+	return ""
+}
+
 // GetSecret implements store.Client.GetSecret Interface.
 // Retrieves a secret/Key/Certificate/Tag with the secret name defined in ref.Name
 // The Object Type is defined as a prefix in the ref.Name , if no prefix is defined , we assume a secret is required.
@@ -846,7 +935,7 @@ func getSecretMapProperties(tags map[string]*string, key, property string) map[s
 
 func (a *Azure) authorizerForWorkloadIdentity(ctx context.Context, tokenProvider tokenProviderFunc) (autorest.Authorizer, error) {
 	aadEndpoint := AadEndpointForType(a.provider.EnvironmentType)
-	kvResource := kvResourceForProviderConfig(a.provider.EnvironmentType)
+	kvResource := a.tokenResource()
 	// If no serviceAccountRef was provided
 	// we expect certain env vars to be present.
 	// They are set by the azure workload identity webhook
@@ -1016,7 +1105,7 @@ func (t *tokenProvider) OAuthToken() string {
 
 func (a *Azure) authorizerForManagedIdentity() (autorest.Authorizer, error) {
 	msiConfig := kvauth.NewMSIConfig()
-	msiConfig.Resource = kvResourceForProviderConfig(a.provider.EnvironmentType)
+	msiConfig.Resource = a.tokenResource()
 	if a.provider.IdentityID != nil {
 		msiConfig.ClientID = *a.provider.IdentityID
 	}
@@ -1069,6 +1158,7 @@ func (a *Azure) getAuthorizerFromCredentials(ctx context.Context) (autorest.Auth
 			clientSecret,
 			*a.provider.TenantID,
 			a.provider.EnvironmentType,
+			a.tokenResource(),
 		)
 	} else {
 		clientCertificate, err := resolvers.SecretKeyRef(
@@ -1087,20 +1177,21 @@ func (a *Azure) getAuthorizerFromCredentials(ctx context.Context) (autorest.Auth
 			[]byte(clientCertificate),
 			*a.provider.TenantID,
 			a.provider.EnvironmentType,
+			a.tokenResource(),
 		)
 	}
 }
 
-func getAuthorizerForClientSecret(clientID, clientSecret, tenantID string, environmentType esv1beta1.AzureEnvironmentType) (autorest.Authorizer, error) {
+func getAuthorizerForClientSecret(clientID, clientSecret, tenantID string, environmentType esv1beta1.AzureEnvironmentType, resource string) (autorest.Authorizer, error) {
 	clientCredentialsConfig := kvauth.NewClientCredentialsConfig(clientID, clientSecret, tenantID)
-	clientCredentialsConfig.Resource = kvResourceForProviderConfig(environmentType)
+	clientCredentialsConfig.Resource = resource
 	clientCredentialsConfig.AADEndpoint = AadEndpointForType(environmentType)
 	return clientCredentialsConfig.Authorizer()
 }
 
-func getAuthorizerForClientCertificate(clientID string, certificateBytes []byte, tenantID string, environmentType esv1beta1.AzureEnvironmentType) (autorest.Authorizer, error) {
+func getAuthorizerForClientCertificate(clientID string, certificateBytes []byte, tenantID string, environmentType esv1beta1.AzureEnvironmentType, resource string) (autorest.Authorizer, error) {
 	clientCertificateConfig := NewClientInMemoryCertificateConfig(clientID, certificateBytes, tenantID)
-	clientCertificateConfig.Resource = kvResourceForProviderConfig(environmentType)
+	clientCertificateConfig.Resource = resource
 	clientCertificateConfig.AADEndpoint = AadEndpointForType(environmentType)
 	return clientCertificateConfig.Authorizer()
 }
@@ -1110,13 +1201,14 @@ func (a *Azure) Close(_ context.Context) error {
 }
 
 func (a *Azure) Validate() (esv1beta1.ValidationResult, error) {
-	if a.store.GetKind() == esv1beta1.ClusterSecretStoreKind && isReferentSpec(a.provider) {
+	if a.store.GetKind() == esv1beta1.ClusterSecretStoreKind && IsReferentSpec(a.provider) {
 		return esv1beta1.ValidationResultUnknown, nil
 	}
 	return esv1beta1.ValidationResultReady, nil
 }
 
-func isReferentSpec(prov *esv1beta1.AzureKVProvider) bool {
+// IsReferentSpec returns true if the credentials are read from the namespace of the ExternalSecret.
+func IsReferentSpec(prov *esv1beta1.AzureKVProvider) bool {
 	if prov.AuthSecretRef != nil &&
 		((prov.AuthSecretRef.ClientID != nil &&
 			prov.AuthSecretRef.ClientID.Namespace == nil) ||
@@ -907,6 +907,93 @@ func TestAzureKeyVaultPushSecret(t *testing.T) {
 	}
 }
 
+func TestAzureKeyVaultPushSecretTags(t *testing.T) {
+	secretKey := "secret-key"
+	value := "value"
+	pushData := testingfake.PushSecretData{
+		SecretKey: secretKey,
+		RemoteKey: secretName,
+		Metadata: &apiextensionsv1.JSON{
+			Raw: []byte(`{"apiVersion":"kubernetes.external-secrets.io/v1alpha1","kind":"PushSecretMetadata","spec":{"tags":{"team":"payments","managed-by":"someone-else"}}}`),
+		},
+	}
+	tests := map[string]struct {
+		existing  keyvault.SecretBundle
+		wantTags  map[string]*string
+		wantWrite bool
+	}{
+		"new secret": {
+			wantTags: map[string]*string{
+				"team":    pointer.To("payments"),
+				managedBy: pointer.To(externalSecrets),
+			},
+			wantWrite: true,
+		},
+		"changed tag on unchanged secret": {
+			existing: keyvault.SecretBundle{
+				Value: pointer.To(value),
+				Tags: map[string]*string{
+					managedBy: pointer.To(externalSecrets),
+					"team":    pointer.To("billing"),
+					"owner":   pointer.To("alice"),
+				},
+			},
+			wantTags: map[string]*string{
+				"team":    pointer.To("payments"),
+				"owner":   pointer.To("alice"),
+				managedBy: pointer.To(externalSecrets),
+			},
+			wantWrite: true,
+		},
+		"unchanged tags on unchanged secret": {
+			existing: keyvault.SecretBundle{
+				Value:      pointer.To(value),
+				Attributes: &keyvault.SecretAttributes{},
+				Tags: map[string]*string{
+					managedBy: pointer.To(externalSecrets),
+					"team":    pointer.To("payments"),
+					"owner":   pointer.To("alice"),
+				},
+			},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			var apiErr error
+			if tc.existing.Value == nil {
+				apiErr = autorest.DetailedError{StatusCode: 404, Method: "GET", Message: notFoundMessage}
+			}
+			client := &fake.AzureMockClient{}
+			client.WithValue("", secretName, "", tc.existing, apiErr)
+			var written *keyvault.SecretSetParameters
+			client.WithSetSecretFn(func(_ context.Context, _, _ string, params keyvault.SecretSetParameters) (keyvault.SecretBundle, error) {
+				written = &params
+				return keyvault.SecretBundle{}, nil
+			})
+			sm := Azure{
+				provider:   &esv1beta1.AzureKVProvider{VaultURL: pointer.To(fakeURL)},
+				baseClient: client,
+			}
+			secret := &corev1.Secret{Data: map[string][]byte{secretKey: []byte(value)}}
+			if err := sm.PushSecret(context.Background(), secret, pushData); err != nil {
+				t.Fatalf("PushSecret() error = %v", err)
+			}
+			if !tc.wantWrite {
+				if written != nil {
+					t.Errorf("PushSecret() set the secret, want no write")
+				}
+				return
+			}
+			if written == nil {
+				t.Fatalf("PushSecret() did not set the secret")
+			}
+			if !reflect.DeepEqual(tc.wantTags, written.Tags) {
+				t.Errorf("PushSecret() set tags %v, want %v", written.Tags, tc.wantTags)
+			}
+		})
+	}
+}
+
 // test the sm<->azurekv interface
 // make sure correct values are passed and errors are handled accordingly.
 func TestAzureKeyVaultSecretManagerGetSecret(t *testing.T) {
@@ -1326,6 +1413,11 @@ func TestAzureKeyVaultSecretManagerGetSecretMap(t *testing.T) {
 		smtc.apiErr = errors.New(smtc.expectError)
 	}
 
+	secretNotFound := func(smtc *secretManagerTestCase) {
+		smtc.apiErr = autorest.DetailedError{StatusCode: 404}
+		smtc.expectError = esv1beta1.NoSecretError{}.Error()
+	}
+
 	badPubRSAKey := func(smtc *secretManagerTestCase) {
 		smtc.secretName = keyName
 		smtc.expectedSecret = jwkPubRSA
@@ -1416,6 +1508,7 @@ func TestAzureKeyVaultSecretManagerGetSecretMap(t *testing.T) {
 		makeValidSecretManagerTestCaseCustom(setSecretJSON),
 		makeValidSecretManagerTestCaseCustom(setSecretJSONWithProperty),
 		makeValidSecretManagerTestCaseCustom(badSecretWithProperty),
+		makeValidSecretManagerTestCaseCustom(secretNotFound),
 		makeValidSecretManagerTestCaseCustom(badPubRSAKey),
 		makeValidSecretManagerTestCaseCustom(badCertificate),
 		makeValidSecretManagerTestCaseCustom(badSecretType),
@@ -1793,3 +1886,38 @@ func TestAzureKeyVaultSecretExists(t *testing.T) {
 		}
 	}
 }
+
+func TestAzureKeyVaultClassifyError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want esv1beta1.ProviderErrorReason
+	}{
+		{
+			name: "forbidden",
+			err:  fmt.Errorf("keyvault.BaseClient#GetSecret: %w", autorest.DetailedError{StatusCode: 403, Message: "Failure responding to request"}),
+			want: esv1beta1.ProviderErrorPermissionDenied,
+		},
+		{
+			name: "throttled",
+			err:  autorest.DetailedError{StatusCode: 429},
+			want: esv1beta1.ProviderErrorRateLimited,
+		},
+		{
+			name: "no status code",
+			err:  autorest.DetailedError{Message: "connection reset"},
+		},
+		{
+			name: "not an Azure error",
+			err:  errors.New("boom"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := &Azure{}
+			if got := a.ClassifyError(tt.err); got != tt.want {
+				t.Errorf("ClassifyError() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
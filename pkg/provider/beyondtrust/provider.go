@@ -35,6 +35,7 @@ import (
 
 	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
 	esoClient "github.com/external-secrets/external-secrets/pkg/utils"
+	"github.com/external-secrets/external-secrets/pkg/utils/resolvers"
 )
 
 const (
@@ -124,12 +125,12 @@ func (p *Provider) NewClient(ctx context.Context, store esv1beta1.GenericStore,
 	config := store.GetSpec().Provider.Beyondtrust
 	logger := logging.NewLogrLogger(&ESOLogger)
 
-	clientID, clientSecret, apiKey, err := loadCredentialsFromConfig(ctx, config, kube, namespace)
+	clientID, clientSecret, apiKey, err := loadCredentialsFromConfig(ctx, config, kube, store.GetKind(), namespace)
 	if err != nil {
 		return nil, fmt.Errorf("error loading credentials: %w", err)
 	}
 
-	certificate, certificateKey, err := loadCertificateFromConfig(ctx, config, kube, namespace)
+	certificate, certificateKey, err := loadCertificateFromConfig(ctx, config, kube, store.GetKind(), namespace)
 	if err != nil {
 		return nil, fmt.Errorf("error loading certificate: %w", err)
 	}
@@ -193,22 +194,22 @@ func (p *Provider) NewClient(ctx context.Context, store esv1beta1.GenericStore,
 	}, nil
 }
 
-func loadCredentialsFromConfig(ctx context.Context, config *esv1beta1.BeyondtrustProvider, kube client.Client, namespace string) (string, string, string, error) {
+func loadCredentialsFromConfig(ctx context.Context, config *esv1beta1.BeyondtrustProvider, kube client.Client, storeKind, namespace string) (string, string, string, error) {
 	var clientID, clientSecret, apiKey string
 	var err error
 
 	if config.Auth.APIKey != nil {
-		apiKey, err = loadConfigSecret(ctx, config.Auth.APIKey, kube, namespace)
+		apiKey, err = loadConfigSecret(ctx, config.Auth.APIKey, kube, storeKind, namespace)
 		if err != nil {
 			return "", "", "", fmt.Errorf("error loading apiKey: %w", err)
 		}
 	} else {
-		clientID, err = loadConfigSecret(ctx, config.Auth.ClientID, kube, namespace)
+		clientID, err = loadConfigSecret(ctx, config.Auth.ClientID, kube, storeKind, namespace)
 		if err != nil {
 			return "", "", "", fmt.Errorf("error loading clientID: %w", err)
 		}
 
-		clientSecret, err = loadConfigSecret(ctx, config.Auth.ClientSecret, kube, namespace)
+		clientSecret, err = loadConfigSecret(ctx, config.Auth.ClientSecret, kube, storeKind, namespace)
 		if err != nil {
 			return "", "", "", fmt.Errorf("error loading clientSecret: %w", err)
 		}
@@ -217,17 +218,17 @@ func loadCredentialsFromConfig(ctx context.Context, config *esv1beta1.Beyondtrus
 	return clientID, clientSecret, apiKey, nil
 }
 
-func loadCertificateFromConfig(ctx context.Context, config *esv1beta1.BeyondtrustProvider, kube client.Client, namespace string) (string, string, error) {
+func loadCertificateFromConfig(ctx context.Context, config *esv1beta1.BeyondtrustProvider, kube client.Client, storeKind, namespace string) (string, string, error) {
 	var certificate, certificateKey string
 	var err error
 
 	if config.Auth.Certificate != nil && config.Auth.CertificateKey != nil {
-		certificate, err = loadConfigSecret(ctx, config.Auth.Certificate, kube, namespace)
+		certificate, err = loadConfigSecret(ctx, config.Auth.Certificate, kube, storeKind, namespace)
 		if err != nil {
 			return "", "", fmt.Errorf("error loading Certificate: %w", err)
 		}
 
-		certificateKey, err = loadConfigSecret(ctx, config.Auth.CertificateKey, kube, namespace)
+		certificateKey, err = loadConfigSecret(ctx, config.Auth.CertificateKey, kube, storeKind, namespace)
 		if err != nil {
 			return "", "", fmt.Errorf("error loading Certificate Key: %w", err)
 		}
@@ -273,7 +274,7 @@ func getAuthenticator(input AuthenticatorInput) (*auth.AuthenticationObj, error)
 	return auth.Authenticate(input.HTTPClientObj, input.BackoffDefinition, input.APIURL, input.ClientID, input.ClientSecret, input.Logger, input.RetryMaxElapsedTimeMinutes)
 }
 
-func loadConfigSecret(ctx context.Context, ref *esv1beta1.BeyondTrustProviderSecretRef, kube client.Client, defaultNamespace string) (string, error) {
+func loadConfigSecret(ctx context.Context, ref *esv1beta1.BeyondTrustProviderSecretRef, kube client.Client, storeKind, defaultNamespace string) (string, error) {
 	if ref.SecretRef == nil {
 		return ref.Value, nil
 	}
@@ -282,6 +283,10 @@ func loadConfigSecret(ctx context.Context, ref *esv1beta1.BeyondTrustProviderSec
 		return "", err
 	}
 
+	if ref.SecretRef.StoreRef != nil {
+		return resolvers.SecretKeyRef(ctx, kube, storeKind, defaultNamespace, ref.SecretRef)
+	}
+
 	namespace := defaultNamespace
 	if ref.SecretRef.Namespace != nil {
 		namespace = *ref.SecretRef.Namespace
@@ -308,6 +313,10 @@ func validateSecretRef(ref *esv1beta1.BeyondTrustProviderSecretRef) error {
 		if ref.Value != "" {
 			return errSecretRefAndValueConflict
 		}
+		// the name and key are not used when the value is read from a store
+		if ref.SecretRef.StoreRef != nil {
+			return nil
+		}
 		if ref.SecretRef.Name == "" {
 			return errMissingSecretName
 		}
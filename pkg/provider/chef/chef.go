@@ -27,7 +27,6 @@ import (
 	"github.com/go-logr/logr"
 	"github.com/tidwall/gjson"
 	corev1 "k8s.io/api/core/v1"
-	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
 	kclient "sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
@@ -35,6 +34,7 @@ import (
 	"github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
 	"github.com/external-secrets/external-secrets/pkg/metrics"
 	"github.com/external-secrets/external-secrets/pkg/utils"
+	"github.com/external-secrets/external-secrets/pkg/utils/resolvers"
 )
 
 const (
@@ -102,31 +102,23 @@ func (providerchef *Providerchef) NewClient(ctx context.Context, store v1beta1.G
 		return nil, fmt.Errorf(errChefProvider, err)
 	}
 
-	credentialsSecret := &corev1.Secret{}
-	objectKey := types.NamespacedName{
-		Name:      chefProvider.Auth.SecretRef.SecretKey.Name,
-		Namespace: namespace,
+	storeKind := store.GetObjectKind().GroupVersionKind().Kind
+	secretRef := chefProvider.Auth.SecretRef.SecretKey
+	if storeKind == v1beta1.ClusterSecretStoreKind && secretRef.StoreRef == nil && secretRef.Namespace == nil {
+		return nil, errors.New(errInvalidClusterStoreMissingPKNamespace)
 	}
 
-	if store.GetObjectKind().GroupVersionKind().Kind == v1beta1.ClusterSecretStoreKind {
-		if chefProvider.Auth.SecretRef.SecretKey.Namespace == nil {
-			return nil, errors.New(errInvalidClusterStoreMissingPKNamespace)
-		}
-		objectKey.Namespace = *chefProvider.Auth.SecretRef.SecretKey.Namespace
-	}
-
-	if err := kube.Get(ctx, objectKey, credentialsSecret); err != nil {
+	secretKey, err := resolvers.SecretKeyRef(ctx, kube, storeKind, namespace, &secretRef)
+	if err != nil {
 		return nil, fmt.Errorf(errFetchK8sSecret, err)
 	}
-
-	secretKey := credentialsSecret.Data[chefProvider.Auth.SecretRef.SecretKey.Key]
-	if len(secretKey) == 0 {
+	if secretKey == "" {
 		return nil, errors.New(errMissingSecretKey)
 	}
 
 	client, err := chef.NewClient(&chef.Config{
 		Name:    chefProvider.UserName,
-		Key:     string(secretKey),
+		Key:     secretKey,
 		BaseURL: chefProvider.ServerURL,
 	})
 	if err != nil {
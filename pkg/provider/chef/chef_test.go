@@ -349,7 +349,7 @@ func TestNewClient(t *testing.T) {
 		},
 	}
 
-	expected := fmt.Sprintf("could not fetch SecretKey Secret: secrets %q not found", authName)
+	expected := fmt.Sprintf("could not fetch SecretKey Secret: cannot get Kubernetes secret %q: secrets %q not found", authName, authName)
 	expectedMissingStore := "missing or invalid spec: missing store"
 
 	ctx := context.TODO()
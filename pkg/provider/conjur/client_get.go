@@ -17,10 +17,13 @@ package conjur
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"net/http"
 	"strings"
 
 	"github.com/cyberark/conjur-api-go/conjurapi"
+	"github.com/cyberark/conjur-api-go/conjurapi/response"
 	"github.com/tidwall/gjson"
 
 	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
@@ -37,11 +40,15 @@ type resourceFilterFunc func(candidate conjurResource) (name string, err error)
 
 // GetSecret returns a single secret from the provider.
 func (c *Client) GetSecret(ctx context.Context, ref esv1beta1.ExternalSecretDataRemoteRef) ([]byte, error) {
-	conjurClient, getConjurClientError := c.GetConjurClient(ctx)
-	if getConjurClientError != nil {
-		return nil, getConjurClientError
+	var secretValue []byte
+	err := c.withReauth(ctx, func(conjurClient SecretsClient) error {
+		var err error
+		secretValue, err = conjurClient.RetrieveSecret(ref.Key)
+		return err
+	})
+	if isConjurStatus(err, http.StatusNotFound) {
+		return nil, esv1beta1.NoSecretError{}
 	}
-	secretValue, err := conjurClient.RetrieveSecret(ref.Key)
 	if err != nil {
 		return nil, err
 	}
@@ -85,14 +92,19 @@ func (c *Client) GetSecretMap(ctx context.Context, ref esv1beta1.ExternalSecretD
 // GetAllSecrets gets multiple secrets from the provider and loads into a kubernetes secret.
 // First load all secrets from secretStore path configuration
 // Then, gets secrets from a matching name or matching custom_metadata.
+// If a path is given, only variables whose id starts with the path are considered.
 func (c *Client) GetAllSecrets(ctx context.Context, ref esv1beta1.ExternalSecretFind) (map[string][]byte, error) {
+	path := ""
+	if ref.Path != nil {
+		path = *ref.Path
+	}
 	if ref.Name != nil {
-		return c.findSecretsFromName(ctx, *ref.Name)
+		return c.findSecretsFromName(ctx, path, *ref.Name)
 	}
-	return c.findSecretsFromTags(ctx, ref.Tags)
+	return c.findSecretsFromTags(ctx, path, ref.Tags)
 }
 
-func (c *Client) findSecretsFromName(ctx context.Context, ref esv1beta1.FindName) (map[string][]byte, error) {
+func (c *Client) findSecretsFromName(ctx context.Context, path string, ref esv1beta1.FindName) (map[string][]byte, error) {
 	matcher, err := find.New(ref)
 	if err != nil {
 		return nil, err
@@ -107,10 +119,10 @@ func (c *Client) findSecretsFromName(ctx context.Context, ref esv1beta1.FindName
 		return name, nil
 	}
 
-	return c.listSecrets(ctx, resourceFilterFunc)
+	return c.listSecrets(ctx, path, resourceFilterFunc)
 }
 
-func (c *Client) findSecretsFromTags(ctx context.Context, tags map[string]string) (map[string][]byte, error) {
+func (c *Client) findSecretsFromTags(ctx context.Context, path string, tags map[string]string) (map[string][]byte, error) {
 	var resourceFilterFunc = func(candidate conjurResource) (string, error) {
 		name := trimConjurResourceName(candidate["id"].(string))
 		annotations, ok := candidate["annotations"].([]interface{})
@@ -135,15 +147,25 @@ func (c *Client) findSecretsFromTags(ctx context.Context, tags map[string]string
 		return name, nil
 	}
 
-	return c.listSecrets(ctx, resourceFilterFunc)
+	return c.listSecrets(ctx, path, resourceFilterFunc)
 }
 
-func (c *Client) listSecrets(ctx context.Context, filterFunc resourceFilterFunc) (map[string][]byte, error) {
-	conjurClient, getConjurClientError := c.GetConjurClient(ctx)
-	if getConjurClientError != nil {
-		return nil, getConjurClientError
+func (c *Client) listSecrets(ctx context.Context, path string, filterFunc resourceFilterFunc) (map[string][]byte, error) {
+	var filteredResources map[string][]byte
+	err := c.withReauth(ctx, func(conjurClient SecretsClient) error {
+		var err error
+		filteredResources, err = listSecrets(conjurClient, path, filterFunc)
+		return err
+	})
+	if err != nil {
+		return nil, err
 	}
 
+	// Trim the resource names to just the last part of the ID
+	return trimConjurResourceNames(filteredResources), nil
+}
+
+func listSecrets(conjurClient SecretsClient, path string, filterFunc resourceFilterFunc) (map[string][]byte, error) {
 	filteredResourceNames := []string{}
 
 	// Loop through all secrets in the Conjur account.
@@ -164,6 +186,9 @@ func (c *Client) listSecrets(ctx context.Context, filterFunc resourceFilterFunc)
 		}
 
 		for _, candidate := range resources {
+			if !strings.HasPrefix(trimConjurResourceName(candidate["id"].(string)), path) {
+				continue
+			}
 			name, err := filterFunc(candidate)
 			if err != nil {
 				return nil, err
@@ -179,13 +204,33 @@ func (c *Client) listSecrets(ctx context.Context, filterFunc resourceFilterFunc)
 		}
 	}
 
-	filteredResources, err := c.client.RetrieveBatchSecrets(filteredResourceNames)
+	return conjurClient.RetrieveBatchSecrets(filteredResourceNames)
+}
+
+// withReauth calls fn with the Conjur client. If Conjur rejects the credentials,
+// e.g. because the JWT the client authenticated with has expired, the client is
+// created again with fresh credentials and fn is retried once.
+func (c *Client) withReauth(ctx context.Context, fn func(conjurClient SecretsClient) error) error {
+	conjurClient, err := c.GetConjurClient(ctx)
 	if err != nil {
-		return nil, err
+		return err
+	}
+	err = fn(conjurClient)
+	if !isConjurStatus(err, http.StatusUnauthorized) {
+		return err
+	}
+	c.client = nil
+	conjurClient, err = c.GetConjurClient(ctx)
+	if err != nil {
+		return err
 	}
+	return fn(conjurClient)
+}
 
-	// Trim the resource names to just the last part of the ID
-	return trimConjurResourceNames(filteredResources), nil
+// isConjurStatus returns true if err is a response of the Conjur API with the given status code.
+func isConjurStatus(err error, code int) bool {
+	var conjurErr *response.ConjurError
+	return errors.As(err, &conjurErr) && conjurErr.Code == code
 }
 
 // trimConjurResourceNames trims the Conjur resource names to the last part of the ID.
@@ -20,12 +20,25 @@ import (
 	"math/rand"
 
 	"github.com/cyberark/conjur-api-go/conjurapi"
+	"github.com/cyberark/conjur-api-go/conjurapi/response"
 )
 
 type ConjurMockClient struct {
+	// Unauthorized makes every request fail as if the access token was rejected.
+	Unauthorized bool
+}
+
+func unauthorizedError() error {
+	return &response.ConjurError{Code: 401, Message: "Unauthorized"}
 }
 
 func (mc *ConjurMockClient) RetrieveSecret(secret string) (result []byte, err error) {
+	if mc.Unauthorized {
+		return nil, unauthorizedError()
+	}
+	if secret == "not_found" {
+		return nil, &response.ConjurError{Code: 404, Message: "Variable 'not_found' not found"}
+	}
 	if secret == "error" {
 		err = errors.New("error")
 		return nil, err
@@ -40,6 +53,9 @@ func (mc *ConjurMockClient) RetrieveSecret(secret string) (result []byte, err er
 }
 
 func (mc *ConjurMockClient) RetrieveBatchSecrets(variableIDs []string) (map[string][]byte, error) {
+	if mc.Unauthorized {
+		return nil, unauthorizedError()
+	}
 	secrets := make(map[string][]byte)
 	for _, id := range variableIDs {
 		if id == "error" {
@@ -52,6 +68,9 @@ func (mc *ConjurMockClient) RetrieveBatchSecrets(variableIDs []string) (map[stri
 }
 
 func (mc *ConjurMockClient) Resources(filter *conjurapi.ResourceFilter) (resources []map[string]interface{}, err error) {
+	if mc.Unauthorized {
+		return nil, unauthorizedError()
+	}
 	policyID := "conjur:policy:root"
 	if filter.Offset == 0 {
 		// First "page" of secrets: 2 static ones and 98 random ones
@@ -17,7 +17,9 @@ package conjur
 
 import (
 	"context"
+	"errors"
 
+	"github.com/cyberark/conjur-api-go/conjurapi/response"
 	"k8s.io/client-go/kubernetes"
 	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -51,6 +53,15 @@ func (p *Provider) Capabilities() esv1beta1.SecretStoreCapabilities {
 	return esv1beta1.SecretStoreReadOnly
 }
 
+// ClassifyError maps the status codes of the Conjur API to provider error reasons.
+func (p *Provider) ClassifyError(err error) esv1beta1.ProviderErrorReason {
+	var conjurErr *response.ConjurError
+	if !errors.As(err, &conjurErr) {
+		return ""
+	}
+	return esv1beta1.ProviderErrorReasonForHTTPStatus(conjurErr.Code)
+}
+
 func newConjurProvider(_ context.Context, store esv1beta1.GenericStore, kube client.Client, namespace string, corev1 typedcorev1.CoreV1Interface, clientAPI SecretsClientFactory) (esv1beta1.SecretsClient, error) {
 	return &Client{
 		StoreKind: store.GetObjectKind().GroupVersionKind().Kind,
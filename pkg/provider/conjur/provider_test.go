@@ -18,12 +18,14 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/http"
 	"reflect"
 	"testing"
 	"time"
 
 	"github.com/cyberark/conjur-api-go/conjurapi"
 	"github.com/cyberark/conjur-api-go/conjurapi/authn"
+	"github.com/cyberark/conjur-api-go/conjurapi/response"
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/google/go-cmp/cmp"
 	corev1 "k8s.io/api/core/v1"
@@ -117,6 +119,20 @@ func TestGetSecret(t *testing.T) {
 				value: "",
 			},
 		},
+		"ReadSecretNotFound": {
+			reason: "Should map a 404 response to a NoSecretError.",
+			args: args{
+				store: makeAPIKeySecretStore(svcURL, "conjur-hostid", "conjur-apikey", "myconjuraccount"),
+				kube: clientfake.NewClientBuilder().
+					WithObjects(makeFakeAPIKeySecrets()...).Build(),
+				namespace:  "default",
+				secretPath: "not_found",
+			},
+			want: want{
+				err:   esv1beta1.NoSecretError{},
+				value: "",
+			},
+		},
 		"JwtWithServiceAccountRefReadSecretSuccess": {
 			reason: "Should read a secret successfully using a JWT auth secret store that references a k8s service account.",
 			args: args{
@@ -212,6 +228,7 @@ func TestGetAllSecrets(t *testing.T) {
 		kube      kclient.Client
 		corev1    typedcorev1.CoreV1Interface
 		namespace string
+		path      string
 		search    string
 		tags      map[string]string
 	}
@@ -289,6 +306,24 @@ func TestGetAllSecrets(t *testing.T) {
 				values: map[string][]byte{},
 			},
 		},
+		"PathSearchSuccess": {
+			reason: "Should only return the secrets below the path.",
+			args: args{
+				store: makeAPIKeySecretStore(svcURL, "conjur-hostid", "conjur-apikey", "myconjuraccount"),
+				kube: clientfake.NewClientBuilder().
+					WithObjects(makeFakeAPIKeySecrets()...).Build(),
+				namespace: "default",
+				path:      "secret",
+				search:    ".*",
+			},
+			want: want{
+				err: nil,
+				values: map[string][]byte{
+					"secret1": []byte("secret"),
+					"secret2": []byte("secret"),
+				},
+			},
+		},
 		"TagSearchSingleResultSuccess": {
 			reason: "Should search for secrets successfully using a tag.",
 			args: args{
@@ -312,6 +347,9 @@ func TestGetAllSecrets(t *testing.T) {
 	runTest := func(t *testing.T, _ string, tc testCase) {
 		provider, _ := newConjurProvider(context.Background(), tc.args.store, tc.args.kube, tc.args.namespace, tc.args.corev1, &ConjurMockAPIClient{})
 		ref := makeValidFindRef(tc.args.search, tc.args.tags)
+		if tc.args.path != "" {
+			ref.Path = &tc.args.path
+		}
 		secrets, err := provider.GetAllSecrets(context.Background(), *ref)
 		if diff := cmp.Diff(tc.want.err, err, EquateErrors()); diff != "" {
 			t.Errorf("\n%s\nconjur.GetAllSecrets(...): -want error, +got error:\n%s", tc.reason, diff)
@@ -713,6 +751,105 @@ func (c *ConjurMockAPIClient) NewClientFromJWT(_ conjurapi.Config) (SecretsClien
 	return &fake.ConjurMockClient{}, nil
 }
 
+// ConjurExpiringMockAPIClient returns a client whose access token is rejected first,
+// and a working client once it is created again.
+type ConjurExpiringMockAPIClient struct {
+	created int
+}
+
+func (c *ConjurExpiringMockAPIClient) NewClientFromKey(_ conjurapi.Config, _ authn.LoginPair) (SecretsClient, error) {
+	c.created++
+	return &fake.ConjurMockClient{Unauthorized: c.created == 1}, nil
+}
+
+func (c *ConjurExpiringMockAPIClient) NewClientFromJWT(_ conjurapi.Config) (SecretsClient, error) {
+	c.created++
+	return &fake.ConjurMockClient{Unauthorized: c.created == 1}, nil
+}
+
+func TestReauthenticate(t *testing.T) {
+	stores := map[string]esv1beta1.GenericStore{
+		"ApiKey":         makeAPIKeySecretStore(svcURL, "conjur-hostid", "conjur-apikey", "myconjuraccount"),
+		"JwtSecretRef":   makeJWTSecretStore(svcURL, "", jwtSecretName, jwtAuthenticator, "", "myconjuraccount"),
+		"JwtServiceAcct": makeJWTSecretStore(svcURL, svcAccount, "", jwtAuthenticator, "", "myconjuraccount"),
+	}
+	objects := append(makeFakeAPIKeySecrets(), &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: jwtSecretName, Namespace: "default"},
+		Data:       map[string][]byte{"token": []byte(createFakeJwtToken(true))},
+	})
+	for name, store := range stores {
+		t.Run(name, func(t *testing.T) {
+			kube := clientfake.NewClientBuilder().WithObjects(objects...).Build()
+			tokens := utilfake.NewCreateTokenMock().WithToken(createFakeJwtToken(true))
+
+			api := &ConjurExpiringMockAPIClient{}
+			provider, _ := newConjurProvider(context.Background(), store, kube, "default", tokens, api)
+			secret, err := provider.GetSecret(context.Background(), *makeValidRef("path/to/secret"))
+			if err != nil {
+				t.Fatalf("GetSecret() error = %v", err)
+			}
+			if string(secret) != "secret" {
+				t.Errorf("GetSecret() = %q, want %q", secret, "secret")
+			}
+			if api.created != 2 {
+				t.Errorf("created %d clients, want the client to be created again after the 401", api.created)
+			}
+
+			api = &ConjurExpiringMockAPIClient{}
+			provider, _ = newConjurProvider(context.Background(), store, kube, "default", tokens, api)
+			secrets, err := provider.GetAllSecrets(context.Background(), *makeValidFindRef("^secret1$", nil))
+			if err != nil {
+				t.Fatalf("GetAllSecrets() error = %v", err)
+			}
+			if diff := cmp.Diff(map[string][]byte{"secret1": []byte("secret")}, secrets); diff != "" {
+				t.Errorf("GetAllSecrets() -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestReauthenticateOnce(t *testing.T) {
+	store := makeAPIKeySecretStore(svcURL, "conjur-hostid", "conjur-apikey", "myconjuraccount")
+	kube := clientfake.NewClientBuilder().WithObjects(makeFakeAPIKeySecrets()...).Build()
+	provider, _ := newConjurProvider(context.Background(), store, kube, "default", nil, &ConjurUnauthorizedMockAPIClient{})
+	_, err := provider.GetSecret(context.Background(), *makeValidRef("path/to/secret"))
+	if !isConjurStatus(err, http.StatusUnauthorized) {
+		t.Errorf("GetSecret() error = %v, want the 401 of the second attempt", err)
+	}
+}
+
+// ConjurUnauthorizedMockAPIClient returns clients whose access tokens are always rejected.
+type ConjurUnauthorizedMockAPIClient struct{}
+
+func (c *ConjurUnauthorizedMockAPIClient) NewClientFromKey(_ conjurapi.Config, _ authn.LoginPair) (SecretsClient, error) {
+	return &fake.ConjurMockClient{Unauthorized: true}, nil
+}
+
+func (c *ConjurUnauthorizedMockAPIClient) NewClientFromJWT(_ conjurapi.Config) (SecretsClient, error) {
+	return &fake.ConjurMockClient{Unauthorized: true}, nil
+}
+
+func TestClassifyError(t *testing.T) {
+	p := &Provider{}
+	tests := []struct {
+		name string
+		err  error
+		want esv1beta1.ProviderErrorReason
+	}{
+		{name: "unauthorized", err: &response.ConjurError{Code: http.StatusUnauthorized}, want: esv1beta1.ProviderErrorUnauthenticated},
+		{name: "forbidden", err: &response.ConjurError{Code: http.StatusForbidden}, want: esv1beta1.ProviderErrorPermissionDenied},
+		{name: "wrapped not found", err: fmt.Errorf("error getting secret foo: %w", &response.ConjurError{Code: http.StatusNotFound}), want: esv1beta1.ProviderErrorNotFound},
+		{name: "unknown", err: errors.New("error"), want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := p.ClassifyError(tt.err); got != tt.want {
+				t.Errorf("ClassifyError() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
 // EquateErrors returns true if the supplied errors are of the same type and
 // produce identical strings. This mirrors the error comparison behavior of
 // https://github.com/go-test/deep, which most Crossplane tests targeted before
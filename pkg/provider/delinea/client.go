@@ -18,6 +18,9 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"net/http"
+	"strconv"
+	"strings"
 
 	"github.com/DelineaXPM/dsv-sdk-go/v2/vault"
 	"github.com/tidwall/gjson"
@@ -110,5 +113,15 @@ func (c *client) getSecret(_ context.Context, ref esv1beta1.ExternalSecretDataRe
 	if ref.Version != "" {
 		return nil, errors.New("specifying a version is not yet supported")
 	}
-	return c.api.Secret(ref.Key)
+	secret, err := c.api.Secret(ref.Key)
+	if isNotFoundError(err) {
+		return nil, esv1beta1.NoSecretError{}
+	}
+	return secret, err
+}
+
+// isNotFoundError reports whether err is a 404 response.
+// The SDK only exposes the HTTP status as the prefix of the error message.
+func isNotFoundError(err error) bool {
+	return err != nil && strings.HasPrefix(err.Error(), strconv.Itoa(http.StatusNotFound)+" ")
 }
@@ -46,7 +46,8 @@ func (f *fakeAPI) Secret(path string) (*vault.Secret, error) {
 			return s, nil
 		}
 	}
-	return nil, errors.New("not found")
+	// the SDK reports the HTTP status as the prefix of the error
+	return nil, errors.New("404 Not Found: secret not found")
 }
 
 func newTestClient() esv1beta1.SecretsClient {
@@ -94,6 +95,12 @@ func TestGetSecret(t *testing.T) {
 			},
 			want: []byte(`baz`),
 		},
+		"querying for non-existing key returns noSecretError": {
+			ref: esv1beta1.ExternalSecretDataRemoteRef{
+				Key: "d",
+			},
+			err: esv1beta1.NoSecretErr,
+		},
 		"querying for existent key and non-existing propery": {
 			ref: esv1beta1.ExternalSecretDataRemoteRef{
 				Key:      "c",
@@ -116,3 +123,41 @@ func TestGetSecret(t *testing.T) {
 		})
 	}
 }
+
+func TestGetSecretMap(t *testing.T) {
+	ctx := context.Background()
+	c := newTestClient()
+
+	testCases := map[string]struct {
+		ref  esv1beta1.ExternalSecretDataRemoteRef
+		want map[string][]byte
+		err  error
+	}{
+		"querying for the key returns the map": {
+			ref: esv1beta1.ExternalSecretDataRemoteRef{
+				Key: "b",
+			},
+			want: map[string][]byte{
+				"hello": []byte("world"),
+			},
+		},
+		"querying for non-existing key returns noSecretError": {
+			ref: esv1beta1.ExternalSecretDataRemoteRef{
+				Key: "d",
+			},
+			err: esv1beta1.NoSecretErr,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got, err := c.GetSecretMap(ctx, tc.ref)
+			if tc.err == nil {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.want, got)
+			} else {
+				assert.Nil(t, got)
+				assert.ErrorIs(t, err, tc.err)
+			}
+		})
+	}
+}
@@ -47,8 +47,13 @@ type Device42 struct {
 	client Client
 }
 
-func (p *Device42) ValidateStore(esv1beta1.GenericStore) (admission.Warnings, error) {
-	return nil, nil
+func (p *Device42) ValidateStore(store esv1beta1.GenericStore) (admission.Warnings, error) {
+	storeSpec := store.GetSpec()
+	if storeSpec == nil || storeSpec.Provider == nil || storeSpec.Provider.Device42 == nil {
+		return nil, nil
+	}
+	// username and password are read from the same secret
+	return nil, utils.ValidateNoStoreRef(storeSpec.Provider.Device42.Auth.SecretRef.Credentials)
 }
 
 func (p *Device42) Capabilities() esv1beta1.SecretStoreCapabilities {
@@ -65,6 +70,9 @@ type device42Client struct {
 type Provider struct{}
 
 func (c *device42Client) getAuth(ctx context.Context) (string, string, error) {
+	if err := utils.ValidateNoStoreRef(c.store.Auth.SecretRef.Credentials); err != nil {
+		return "", "", err
+	}
 	credentialsSecret := &corev1.Secret{}
 	credentialsSecretName := c.store.Auth.SecretRef.Credentials.Name
 	if credentialsSecretName == "" {
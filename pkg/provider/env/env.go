@@ -0,0 +1,216 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package env implements a provider that reads secrets from the environment
+// variables of the controller process. It is intended for local development and testing only.
+package env
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/spf13/pflag"
+	"github.com/tidwall/gjson"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	"github.com/external-secrets/external-secrets/pkg/feature"
+	"github.com/external-secrets/external-secrets/pkg/find"
+	"github.com/external-secrets/external-secrets/pkg/utils"
+)
+
+var (
+	// enableEnvProvider and allowedVariables are set with the controller flags,
+	// no variable can be read unless the provider is enabled and the variable is allowed.
+	enableEnvProvider bool
+	allowedVariables  []string
+)
+
+var (
+	errDisabled           = errors.New("the env provider is disabled, it must be enabled with --enable-env-provider")
+	errVariableNotAllowed = errors.New("environment variable is not listed in --env-provider-allowed-variables")
+	errMissingStore       = errors.New("missing store provider")
+	errMissingEnvProvider = errors.New("missing store provider env")
+	errMissingPrefix      = errors.New("prefix must not be empty")
+	errVersionUnsupported = errors.New("versions are not supported by the env provider")
+	errNotImplemented     = errors.New("not implemented")
+	errUnmarshalSecret    = "unable to unmarshal secret %s: %w"
+	errUnsupportedFind    = "unsupported find operator: %#v"
+)
+
+// Provider reads secrets from environment variables.
+type Provider struct {
+	prefix string
+}
+
+// Capabilities return the provider supported capabilities (ReadOnly, WriteOnly, ReadWrite).
+func (p *Provider) Capabilities() esv1beta1.SecretStoreCapabilities {
+	return esv1beta1.SecretStoreReadOnly
+}
+
+func (p *Provider) NewClient(_ context.Context, store esv1beta1.GenericStore, _ client.Client, _ string) (esv1beta1.SecretsClient, error) {
+	if !enableEnvProvider {
+		return nil, errDisabled
+	}
+	c, err := getProvider(store)
+	if err != nil {
+		return nil, err
+	}
+	if c.Prefix == "" {
+		return nil, errMissingPrefix
+	}
+	return &Provider{
+		prefix: c.Prefix,
+	}, nil
+}
+
+func getProvider(store esv1beta1.GenericStore) (*esv1beta1.EnvProvider, error) {
+	if store == nil {
+		return nil, errMissingStore
+	}
+	spc := store.GetSpec()
+	if spc == nil || spc.Provider == nil || spc.Provider.Env == nil {
+		return nil, errMissingEnvProvider
+	}
+	return spc.Provider.Env, nil
+}
+
+func (p *Provider) DeleteSecret(_ context.Context, _ esv1beta1.PushSecretRemoteRef) error {
+	return errNotImplemented
+}
+
+func (p *Provider) SecretExists(_ context.Context, _ esv1beta1.PushSecretRemoteRef) (bool, error) {
+	return false, errNotImplemented
+}
+
+func (p *Provider) PushSecret(_ context.Context, _ *corev1.Secret, _ esv1beta1.PushSecretData) error {
+	return errNotImplemented
+}
+
+// GetAllSecrets returns all environment variables with the store prefix whose name
+// (without the prefix) matches the given ExternalSecretFind.
+// Currently, only the Name operator is supported.
+func (p *Provider) GetAllSecrets(_ context.Context, ref esv1beta1.ExternalSecretFind) (map[string][]byte, error) {
+	if ref.Name == nil || ref.Path != nil || len(ref.Tags) > 0 {
+		return nil, fmt.Errorf(errUnsupportedFind, ref)
+	}
+	matcher, err := find.New(*ref.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	dataMap := make(map[string][]byte)
+	for _, env := range os.Environ() {
+		name, value, _ := strings.Cut(env, "=")
+		key, ok := strings.CutPrefix(name, p.prefix)
+		if !ok || key == "" || !isAllowed(name) || !matcher.MatchName(key) {
+			continue
+		}
+		dataMap[key] = []byte(value)
+	}
+	return utils.ConvertKeys(ref.ConversionStrategy, dataMap)
+}
+
+// GetSecret returns the value of the environment variable `<prefix><key>`.
+func (p *Provider) GetSecret(_ context.Context, ref esv1beta1.ExternalSecretDataRemoteRef) ([]byte, error) {
+	if ref.Version != "" {
+		return nil, errVersionUnsupported
+	}
+	name := p.prefix + ref.Key
+	if !isAllowed(name) {
+		return nil, fmt.Errorf("%w: %s", errVariableNotAllowed, name)
+	}
+	value, ok := os.LookupEnv(name)
+	if !ok {
+		return nil, esv1beta1.NoSecretErr
+	}
+
+	if ref.Property != "" {
+		val := gjson.Get(value, ref.Property)
+		if !val.Exists() {
+			return nil, esv1beta1.NoSecretErr
+		}
+		return []byte(val.String()), nil
+	}
+
+	return []byte(value), nil
+}
+
+// GetSecretMap returns the k/v pairs of an environment variable containing a JSON object.
+func (p *Provider) GetSecretMap(ctx context.Context, ref esv1beta1.ExternalSecretDataRemoteRef) (map[string][]byte, error) {
+	data, err := p.GetSecret(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+
+	kv := make(map[string]json.RawMessage)
+	if err := json.Unmarshal(data, &kv); err != nil {
+		return nil, fmt.Errorf(errUnmarshalSecret, ref.Key, err)
+	}
+
+	secretData := make(map[string][]byte, len(kv))
+	for k, v := range kv {
+		var strVal string
+		if err := json.Unmarshal(v, &strVal); err == nil {
+			secretData[k] = []byte(strVal)
+		} else {
+			secretData[k] = v
+		}
+	}
+	return secretData, nil
+}
+
+// isAllowed returns true if the variable is listed in --env-provider-allowed-variables.
+func isAllowed(name string) bool {
+	return slices.Contains(allowedVariables, name)
+}
+
+func (p *Provider) Close(_ context.Context) error {
+	return nil
+}
+
+func (p *Provider) Validate() (esv1beta1.ValidationResult, error) {
+	return esv1beta1.ValidationResultReady, nil
+}
+
+func (p *Provider) ValidateStore(store esv1beta1.GenericStore) (admission.Warnings, error) {
+	prov, err := getProvider(store)
+	if err != nil {
+		return nil, err
+	}
+	if prov.Prefix == "" {
+		return nil, errMissingPrefix
+	}
+	return admission.Warnings{"the env provider is intended for local development and testing only"}, nil
+}
+
+func init() {
+	fs := pflag.NewFlagSet("env", pflag.ExitOnError)
+	fs.BoolVar(&enableEnvProvider, "enable-env-provider", false, "Enable the env provider, which reads secrets from the environment variables of the controller. Intended for local development and testing only.")
+	fs.StringSliceVar(&allowedVariables, "env-provider-allowed-variables", nil, "Comma separated names of the environment variables the env provider may read, including the prefix of the store. All other variables are denied.")
+	feature.Register(feature.Feature{
+		Flags: fs,
+	})
+
+	esv1beta1.Register(&Provider{}, &esv1beta1.SecretStoreProvider{
+		Env: &esv1beta1.EnvProvider{},
+	})
+}
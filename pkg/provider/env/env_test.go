@@ -0,0 +1,194 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package env
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+)
+
+const testPrefix = "ESO_ENV_TEST_"
+
+// allowVariables enables the env provider and allows the given variables for the test.
+func allowVariables(t *testing.T, names ...string) {
+	t.Helper()
+	enabled, allowed := enableEnvProvider, allowedVariables
+	enableEnvProvider, allowedVariables = true, names
+	t.Cleanup(func() {
+		enableEnvProvider, allowedVariables = enabled, allowed
+	})
+}
+
+func newTestClient(t *testing.T) esv1beta1.SecretsClient {
+	t.Helper()
+	allowVariables(t, testPrefix+"USERNAME", testPrefix+"CONFIG", testPrefix+"MISSING", "ESO_ENV_OTHER_PASSWORD")
+	t.Setenv(testPrefix+"UNLISTED_PASSWORD", "must-not-be-visible")
+	t.Setenv(testPrefix+"USERNAME", "admin")
+	t.Setenv(testPrefix+"CONFIG", `{"host":"db.example.com","port":5432}`)
+	t.Setenv("ESO_ENV_OTHER_PASSWORD", "must-not-be-visible")
+
+	store := &esv1beta1.SecretStore{
+		Spec: esv1beta1.SecretStoreSpec{
+			Provider: &esv1beta1.SecretStoreProvider{
+				Env: &esv1beta1.EnvProvider{Prefix: testPrefix},
+			},
+		},
+	}
+	c, err := (&Provider{}).NewClient(context.Background(), store, nil, "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return c
+}
+
+func TestGetSecret(t *testing.T) {
+	c := newTestClient(t)
+	tests := []struct {
+		name    string
+		ref     esv1beta1.ExternalSecretDataRemoteRef
+		want    string
+		wantErr error
+	}{
+		{
+			name: "read variable",
+			ref:  esv1beta1.ExternalSecretDataRemoteRef{Key: "USERNAME"},
+			want: "admin",
+		},
+		{
+			name: "read property",
+			ref:  esv1beta1.ExternalSecretDataRemoteRef{Key: "CONFIG", Property: "host"},
+			want: "db.example.com",
+		},
+		{
+			name:    "missing variable",
+			ref:     esv1beta1.ExternalSecretDataRemoteRef{Key: "MISSING"},
+			wantErr: esv1beta1.NoSecretErr,
+		},
+		{
+			name:    "missing property",
+			ref:     esv1beta1.ExternalSecretDataRemoteRef{Key: "CONFIG", Property: "user"},
+			wantErr: esv1beta1.NoSecretErr,
+		},
+		{
+			name:    "variable without prefix is not visible",
+			ref:     esv1beta1.ExternalSecretDataRemoteRef{Key: "../OTHER_PASSWORD"},
+			wantErr: errVariableNotAllowed,
+		},
+		{
+			name:    "variable that is not allowed is not visible",
+			ref:     esv1beta1.ExternalSecretDataRemoteRef{Key: "UNLISTED_PASSWORD"},
+			wantErr: errVariableNotAllowed,
+		},
+		{
+			name:    "versions are not supported",
+			ref:     esv1beta1.ExternalSecretDataRemoteRef{Key: "USERNAME", Version: "1"},
+			wantErr: errVersionUnsupported,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := c.GetSecret(context.Background(), tt.ref)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestGetSecretMap(t *testing.T) {
+	c := newTestClient(t)
+	got, err := c.GetSecretMap(context.Background(), esv1beta1.ExternalSecretDataRemoteRef{Key: "CONFIG"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := map[string][]byte{
+		"host": []byte("db.example.com"),
+		"port": []byte("5432"),
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("unexpected result (-want +got):\n%s", diff)
+	}
+
+	if _, err := c.GetSecretMap(context.Background(), esv1beta1.ExternalSecretDataRemoteRef{Key: "USERNAME"}); err == nil {
+		t.Errorf("expected error for non-JSON value")
+	}
+}
+
+func TestGetAllSecrets(t *testing.T) {
+	c := newTestClient(t)
+	got, err := c.GetAllSecrets(context.Background(), esv1beta1.ExternalSecretFind{
+		Name: &esv1beta1.FindName{RegExp: ".*NAME|.*PASSWORD"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := map[string][]byte{
+		"USERNAME": []byte("admin"),
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("unexpected result (-want +got):\n%s", diff)
+	}
+
+	_, err = c.GetAllSecrets(context.Background(), esv1beta1.ExternalSecretFind{
+		Tags: map[string]string{"foo": "bar"},
+	})
+	if err == nil {
+		t.Errorf("expected error for unsupported find operator")
+	}
+}
+
+func TestNewClientDisabled(t *testing.T) {
+	store := &esv1beta1.SecretStore{
+		Spec: esv1beta1.SecretStoreSpec{
+			Provider: &esv1beta1.SecretStoreProvider{
+				Env: &esv1beta1.EnvProvider{Prefix: testPrefix},
+			},
+		},
+	}
+	if _, err := (&Provider{}).NewClient(context.Background(), store, nil, ""); !errors.Is(err, errDisabled) {
+		t.Errorf("expected disabled error, got %v", err)
+	}
+}
+
+func TestValidateStore(t *testing.T) {
+	p := &Provider{}
+	store := &esv1beta1.SecretStore{
+		Spec: esv1beta1.SecretStoreSpec{
+			Provider: &esv1beta1.SecretStoreProvider{
+				Env: &esv1beta1.EnvProvider{},
+			},
+		},
+	}
+	if _, err := p.ValidateStore(store); !errors.Is(err, errMissingPrefix) {
+		t.Errorf("expected missing prefix error, got %v", err)
+	}
+
+	store.Spec.Provider.Env.Prefix = testPrefix
+	warnings, err := p.ValidateStore(store)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(warnings) != 1 {
+		t.Errorf("expected a dev-only warning, got %v", warnings)
+	}
+}
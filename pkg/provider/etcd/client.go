@@ -0,0 +1,183 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package etcd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/tidwall/gjson"
+	clientv3 "go.etcd.io/etcd/client/v3"
+	corev1 "k8s.io/api/core/v1"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	"github.com/external-secrets/external-secrets/pkg/find"
+	"github.com/external-secrets/external-secrets/pkg/utils"
+)
+
+const (
+	errGetKey          = "unable to get key %s: %w"
+	errListPrefix      = "unable to list keys with prefix %s: %w"
+	errInvalidRevision = "invalid version %q, the version must be a revision of etcd: %w"
+	errUnsupportedFind = "unsupported find operator: %#v"
+	errStatus          = "unable to get the status of endpoint %s: %w"
+)
+
+// validateTimeout limits the status requests of Validate.
+var validateTimeout = 5 * time.Second
+
+var (
+	errNotImplemented      = errors.New("not implemented")
+	errPropertyUnsupported = errors.New("remoteRef.property is not supported when fetching all keys of a prefix")
+)
+
+// Client reads keys from etcd.
+type Client struct {
+	etcd *clientv3.Client
+	kv   clientv3.KV
+}
+
+// GetSecret returns the value of the key, the version selects a revision of etcd.
+func (c *Client) GetSecret(ctx context.Context, ref esv1beta1.ExternalSecretDataRemoteRef) ([]byte, error) {
+	var opts []clientv3.OpOption
+	if ref.Version != "" {
+		rev, err := strconv.ParseInt(ref.Version, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf(errInvalidRevision, ref.Version, err)
+		}
+		opts = append(opts, clientv3.WithRev(rev))
+	}
+	resp, err := c.kv.Get(ctx, ref.Key, opts...)
+	if err != nil {
+		return nil, fmt.Errorf(errGetKey, ref.Key, err)
+	}
+	if len(resp.Kvs) == 0 {
+		return nil, esv1beta1.NoSecretErr
+	}
+	value := resp.Kvs[0].Value
+
+	if ref.Property != "" {
+		val := gjson.GetBytes(value, ref.Property)
+		if !val.Exists() {
+			return nil, esv1beta1.NoSecretErr
+		}
+		return []byte(val.String()), nil
+	}
+	return value, nil
+}
+
+// GetSecretMap returns all keys below the key as prefix, e.g. with the key /config/app
+// the key /config/app/db/host is returned as db/host.
+func (c *Client) GetSecretMap(ctx context.Context, ref esv1beta1.ExternalSecretDataRemoteRef) (map[string][]byte, error) {
+	if ref.Property != "" {
+		return nil, errPropertyUnsupported
+	}
+	prefix := ref.Key
+	if !strings.HasSuffix(prefix, "/") {
+		prefix += "/"
+	}
+	data, err := c.listPrefix(ctx, prefix)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) == 0 {
+		return nil, esv1beta1.NoSecretErr
+	}
+
+	secretData := make(map[string][]byte, len(data))
+	for key, value := range data {
+		if rel := strings.TrimPrefix(key, prefix); rel != "" {
+			secretData[rel] = value
+		}
+	}
+	return secretData, nil
+}
+
+// GetAllSecrets returns all keys below find.path whose full name matches find.name.
+// Tags are not supported.
+func (c *Client) GetAllSecrets(ctx context.Context, ref esv1beta1.ExternalSecretFind) (map[string][]byte, error) {
+	if len(ref.Tags) > 0 || (ref.Name == nil && ref.Path == nil) {
+		return nil, fmt.Errorf(errUnsupportedFind, ref)
+	}
+	var prefix string
+	if ref.Path != nil {
+		prefix = *ref.Path
+	}
+	data, err := c.listPrefix(ctx, prefix)
+	if err != nil {
+		return nil, err
+	}
+	if ref.Name != nil {
+		matcher, err := find.New(*ref.Name)
+		if err != nil {
+			return nil, err
+		}
+		for key := range data {
+			if !matcher.MatchName(key) {
+				delete(data, key)
+			}
+		}
+	}
+	return utils.ConvertKeys(ref.ConversionStrategy, data)
+}
+
+func (c *Client) listPrefix(ctx context.Context, prefix string) (map[string][]byte, error) {
+	// an empty key with WithPrefix selects all keys
+	resp, err := c.kv.Get(ctx, prefix, clientv3.WithPrefix())
+	if err != nil {
+		return nil, fmt.Errorf(errListPrefix, prefix, err)
+	}
+	data := make(map[string][]byte, len(resp.Kvs))
+	for _, kv := range resp.Kvs {
+		data[string(kv.Key)] = kv.Value
+	}
+	return data, nil
+}
+
+func (c *Client) PushSecret(_ context.Context, _ *corev1.Secret, _ esv1beta1.PushSecretData) error {
+	return errNotImplemented
+}
+
+func (c *Client) DeleteSecret(_ context.Context, _ esv1beta1.PushSecretRemoteRef) error {
+	return errNotImplemented
+}
+
+func (c *Client) SecretExists(_ context.Context, _ esv1beta1.PushSecretRemoteRef) (bool, error) {
+	return false, errNotImplemented
+}
+
+// Validate checks that an endpoint of etcd can be reached with the configured credentials.
+// The status of the endpoint is requested, it does not require read access to any key.
+func (c *Client) Validate() (esv1beta1.ValidationResult, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), validateTimeout)
+	defer cancel()
+	var errs error
+	for _, endpoint := range c.etcd.Endpoints() {
+		if _, err := c.etcd.Status(ctx, endpoint); err != nil {
+			errs = errors.Join(errs, fmt.Errorf(errStatus, endpoint, err))
+			continue
+		}
+		return esv1beta1.ValidationResultReady, nil
+	}
+	return esv1beta1.ValidationResultError, errs
+}
+
+func (c *Client) Close(_ context.Context) error {
+	return c.etcd.Close()
+}
@@ -0,0 +1,525 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package etcd
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"net"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
+	"go.etcd.io/etcd/api/v3/mvccpb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	kclient "sigs.k8s.io/controller-runtime/pkg/client"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	esmeta "github.com/external-secrets/external-secrets/apis/meta/v1"
+)
+
+// fakeEtcd implements the KV and Maintenance services of etcd on top of a map.
+type fakeEtcd struct {
+	pb.UnimplementedKVServer
+	pb.UnimplementedMaintenanceServer
+
+	mu           sync.Mutex
+	data         map[string]string
+	lastRevision int64
+}
+
+func (f *fakeEtcd) Range(_ context.Context, req *pb.RangeRequest) (*pb.RangeResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.lastRevision = req.Revision
+	resp := &pb.RangeResponse{Header: &pb.ResponseHeader{}}
+	keys := make([]string, 0, len(f.data))
+	for key := range f.data {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	for _, key := range keys {
+		k := []byte(key)
+		inRange := bytes.Equal(k, req.Key)
+		if len(req.RangeEnd) > 0 {
+			// a range end of \0 selects all keys >= key
+			inRange = bytes.Compare(k, req.Key) >= 0 &&
+				(bytes.Equal(req.RangeEnd, []byte{0}) || bytes.Compare(k, req.RangeEnd) < 0)
+		}
+		if inRange {
+			resp.Kvs = append(resp.Kvs, &mvccpb.KeyValue{Key: k, Value: []byte(f.data[key])})
+		}
+	}
+	resp.Count = int64(len(resp.Kvs))
+	return resp, nil
+}
+
+func (f *fakeEtcd) Status(context.Context, *pb.StatusRequest) (*pb.StatusResponse, error) {
+	return &pb.StatusResponse{Header: &pb.ResponseHeader{}, Version: "3.5.16"}, nil
+}
+
+type testPKI struct {
+	caPEM     []byte
+	server    tls.Certificate
+	clientPEM []byte
+	keyPEM    []byte
+}
+
+func newTestPKI(t *testing.T) *testPKI {
+	t.Helper()
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	caTmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "etcd-ca"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}
+	caDER, err := x509.CreateCertificate(rand.Reader, caTmpl, caTmpl, &caKey.PublicKey, caKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ca, err := x509.ParseCertificate(caDER)
+	if err != nil {
+		t.Fatal(err)
+	}
+	issue := func(serial int64, usage x509.ExtKeyUsage) ([]byte, []byte) {
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		tmpl := &x509.Certificate{
+			SerialNumber: big.NewInt(serial),
+			Subject:      pkix.Name{CommonName: "etcd"},
+			NotBefore:    time.Now().Add(-time.Hour),
+			NotAfter:     time.Now().Add(time.Hour),
+			KeyUsage:     x509.KeyUsageDigitalSignature,
+			ExtKeyUsage:  []x509.ExtKeyUsage{usage},
+			IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		}
+		der, err := x509.CreateCertificate(rand.Reader, tmpl, ca, &key.PublicKey, caKey)
+		if err != nil {
+			t.Fatal(err)
+		}
+		keyDER, err := x509.MarshalECPrivateKey(key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+			pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+	}
+
+	serverCert, serverKey := issue(2, x509.ExtKeyUsageServerAuth)
+	server, err := tls.X509KeyPair(serverCert, serverKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	clientCert, clientKey := issue(3, x509.ExtKeyUsageClientAuth)
+	return &testPKI{
+		caPEM:     pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: caDER}),
+		server:    server,
+		clientPEM: clientCert,
+		keyPEM:    clientKey,
+	}
+}
+
+// startFakeEtcd serves the fake etcd over TLS and requires a client certificate signed by the CA.
+func startFakeEtcd(t *testing.T, pki *testPKI, data map[string]string) (*fakeEtcd, string) {
+	t.Helper()
+	pool := x509.NewCertPool()
+	pool.AppendCertsFromPEM(pki.caPEM)
+	creds := credentials.NewTLS(&tls.Config{
+		Certificates: []tls.Certificate{pki.server},
+		ClientCAs:    pool,
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		MinVersion:   tls.VersionTLS12,
+	})
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv := grpc.NewServer(grpc.Creds(creds))
+	etcd := &fakeEtcd{data: data}
+	pb.RegisterKVServer(srv, etcd)
+	pb.RegisterMaintenanceServer(srv, etcd)
+	go func() {
+		_ = srv.Serve(lis)
+	}()
+	t.Cleanup(srv.Stop)
+	return etcd, "https://" + lis.Addr().String()
+}
+
+func newStore(endpoint string, pki *testPKI, auth *esv1beta1.EtcdAuth) *esv1beta1.SecretStore {
+	return &esv1beta1.SecretStore{
+		ObjectMeta: metav1.ObjectMeta{Name: "etcd", Namespace: "default"},
+		Spec: esv1beta1.SecretStoreSpec{
+			Provider: &esv1beta1.SecretStoreProvider{
+				Etcd: &esv1beta1.EtcdProvider{
+					Endpoints: []string{endpoint},
+					CABundle:  pki.caPEM,
+					Auth:      auth,
+				},
+			},
+		},
+	}
+}
+
+func clientCertAuth() *esv1beta1.EtcdAuth {
+	return &esv1beta1.EtcdAuth{
+		ClientCert: &esv1beta1.EtcdClientCertAuth{
+			Certificate: esmeta.SecretKeySelector{Name: "etcd-client", Key: "tls.crt"},
+			PrivateKey:  esmeta.SecretKeySelector{Name: "etcd-client", Key: "tls.key"},
+		},
+	}
+}
+
+func newKube(pki *testPKI) kclient.Client {
+	return fakeclient.NewClientBuilder().WithObjects(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "etcd-client", Namespace: "default"},
+		Data: map[string][]byte{
+			"tls.crt": pki.clientPEM,
+			"tls.key": pki.keyPEM,
+		},
+	}).Build()
+}
+
+func newTestClient(t *testing.T, data map[string]string) (*fakeEtcd, esv1beta1.SecretsClient) {
+	t.Helper()
+	pki := newTestPKI(t)
+	etcd, endpoint := startFakeEtcd(t, pki, data)
+	client, err := (&Provider{}).NewClient(context.Background(), newStore(endpoint, pki, clientCertAuth()), newKube(pki), "default")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		_ = client.Close(context.Background())
+	})
+	return etcd, client
+}
+
+func TestGetSecret(t *testing.T) {
+	etcd, client := newTestClient(t, map[string]string{
+		"/config/app/token": "s3cr3t",
+		"/config/app/db":    `{"host":"db","port":5432}`,
+	})
+
+	tests := []struct {
+		name         string
+		ref          esv1beta1.ExternalSecretDataRemoteRef
+		want         string
+		wantErr      error
+		wantRevision int64
+	}{
+		{
+			name: "key",
+			ref:  esv1beta1.ExternalSecretDataRemoteRef{Key: "/config/app/token"},
+			want: "s3cr3t",
+		},
+		{
+			name: "property of a JSON value",
+			ref:  esv1beta1.ExternalSecretDataRemoteRef{Key: "/config/app/db", Property: "host"},
+			want: "db",
+		},
+		{
+			name:         "revision",
+			ref:          esv1beta1.ExternalSecretDataRemoteRef{Key: "/config/app/token", Version: "42"},
+			want:         "s3cr3t",
+			wantRevision: 42,
+		},
+		{
+			name:    "missing key",
+			ref:     esv1beta1.ExternalSecretDataRemoteRef{Key: "/config/app/missing"},
+			wantErr: esv1beta1.NoSecretErr,
+		},
+		{
+			name:    "missing property",
+			ref:     esv1beta1.ExternalSecretDataRemoteRef{Key: "/config/app/db", Property: "user"},
+			wantErr: esv1beta1.NoSecretErr,
+		},
+		{
+			name: "prefix of a key is not a key",
+			ref:  esv1beta1.ExternalSecretDataRemoteRef{Key: "/config/app"},
+			// only the exact key is read
+			wantErr: esv1beta1.NoSecretErr,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := client.GetSecret(context.Background(), tt.ref)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("GetSecret() error = %v, want %v", err, tt.wantErr)
+			}
+			if string(got) != tt.want {
+				t.Errorf("GetSecret() = %q, want %q", got, tt.want)
+			}
+			if etcd.lastRevision != tt.wantRevision {
+				t.Errorf("revision = %d, want %d", etcd.lastRevision, tt.wantRevision)
+			}
+		})
+	}
+
+	if _, err := client.GetSecret(context.Background(), esv1beta1.ExternalSecretDataRemoteRef{Key: "/config/app/token", Version: "latest"}); err == nil {
+		t.Errorf("GetSecret() accepted a version that is not a revision")
+	}
+}
+
+func TestGetSecretMap(t *testing.T) {
+	_, client := newTestClient(t, map[string]string{
+		"/config/app/host":    "db",
+		"/config/app/db/user": "admin",
+		"/config/application": "not below the prefix",
+		"/config/other/host":  "other",
+	})
+
+	got, err := client.GetSecretMap(context.Background(), esv1beta1.ExternalSecretDataRemoteRef{Key: "/config/app"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]string{"host": "db", "db/user": "admin"}
+	if diff := cmp.Diff(want, stringMap(got)); diff != "" {
+		t.Errorf("GetSecretMap() (-want +got):\n%s", diff)
+	}
+
+	if _, err := client.GetSecretMap(context.Background(), esv1beta1.ExternalSecretDataRemoteRef{Key: "/config/missing/"}); !errors.Is(err, esv1beta1.NoSecretErr) {
+		t.Errorf("GetSecretMap() error = %v, want %v", err, esv1beta1.NoSecretErr)
+	}
+	if _, err := client.GetSecretMap(context.Background(), esv1beta1.ExternalSecretDataRemoteRef{Key: "/config/app", Property: "host"}); !errors.Is(err, errPropertyUnsupported) {
+		t.Errorf("GetSecretMap() error = %v, want %v", err, errPropertyUnsupported)
+	}
+}
+
+func TestGetAllSecrets(t *testing.T) {
+	_, client := newTestClient(t, map[string]string{
+		"/config/app/token":    "s3cr3t",
+		"/config/app/password": "pa55",
+		"/config/other/token":  "other",
+	})
+
+	tests := []struct {
+		name    string
+		ref     esv1beta1.ExternalSecretFind
+		want    map[string]string
+		wantErr bool
+	}{
+		{
+			name: "prefix",
+			ref: esv1beta1.ExternalSecretFind{
+				Path:               ptr.To("/config/app/"),
+				ConversionStrategy: esv1beta1.ExternalSecretConversionDefault,
+			},
+			want: map[string]string{"_config_app_token": "s3cr3t", "_config_app_password": "pa55"},
+		},
+		{
+			name: "prefix and name",
+			ref: esv1beta1.ExternalSecretFind{
+				Path:               ptr.To("/config/"),
+				Name:               &esv1beta1.FindName{RegExp: "token$"},
+				ConversionStrategy: esv1beta1.ExternalSecretConversionDefault,
+			},
+			want: map[string]string{"_config_app_token": "s3cr3t", "_config_other_token": "other"},
+		},
+		{
+			name: "no match",
+			ref:  esv1beta1.ExternalSecretFind{Path: ptr.To("/secrets/")},
+			want: map[string]string{},
+		},
+		{
+			name:    "tags are not supported",
+			ref:     esv1beta1.ExternalSecretFind{Tags: map[string]string{"env": "prod"}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := client.GetAllSecrets(context.Background(), tt.ref)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GetAllSecrets() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if diff := cmp.Diff(tt.want, stringMap(got)); diff != "" {
+				t.Errorf("GetAllSecrets() (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestTLSAuth(t *testing.T) {
+	defer func(timeout time.Duration) { validateTimeout = timeout }(validateTimeout)
+	validateTimeout = time.Second
+
+	pki := newTestPKI(t)
+	_, endpoint := startFakeEtcd(t, pki, map[string]string{"key": "value"})
+	otherPKI := newTestPKI(t)
+
+	tests := []struct {
+		name    string
+		store   *esv1beta1.SecretStore
+		kube    kclient.Client
+		wantErr bool
+	}{
+		{
+			name:  "client certificate",
+			store: newStore(endpoint, pki, clientCertAuth()),
+			kube:  newKube(pki),
+		},
+		{
+			name:    "without client certificate",
+			store:   newStore(endpoint, pki, nil),
+			kube:    newKube(pki),
+			wantErr: true,
+		},
+		{
+			name:    "client certificate of another CA",
+			store:   newStore(endpoint, pki, clientCertAuth()),
+			kube:    newKube(otherPKI),
+			wantErr: true,
+		},
+		{
+			name:    "server certificate of another CA",
+			store:   newStore(endpoint, otherPKI, clientCertAuth()),
+			kube:    newKube(pki),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, err := (&Provider{}).NewClient(context.Background(), tt.store, tt.kube, "default")
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer client.Close(context.Background())
+
+			ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+			defer cancel()
+			got, err := client.GetSecret(ctx, esv1beta1.ExternalSecretDataRemoteRef{Key: "key"})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GetSecret() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && string(got) != "value" {
+				t.Errorf("GetSecret() = %q, want %q", got, "value")
+			}
+			result, err := client.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			wantResult := esv1beta1.ValidationResultReady
+			if tt.wantErr {
+				wantResult = esv1beta1.ValidationResultError
+			}
+			if result != wantResult {
+				t.Errorf("Validate() = %v, want %v", result, wantResult)
+			}
+		})
+	}
+
+	t.Run("missing client certificate secret", func(t *testing.T) {
+		kube := fakeclient.NewClientBuilder().Build()
+		if _, err := (&Provider{}).NewClient(context.Background(), newStore(endpoint, pki, clientCertAuth()), kube, "default"); err == nil {
+			t.Errorf("NewClient() did not fail without the client certificate")
+		}
+	})
+}
+
+func TestValidateStore(t *testing.T) {
+	tests := []struct {
+		name    string
+		store   esv1beta1.GenericStore
+		wantErr bool
+	}{
+		{
+			name: "valid",
+			store: &esv1beta1.SecretStore{Spec: esv1beta1.SecretStoreSpec{Provider: &esv1beta1.SecretStoreProvider{
+				Etcd: &esv1beta1.EtcdProvider{Endpoints: []string{"https://etcd:2379"}, Auth: clientCertAuth()},
+			}}},
+		},
+		{
+			name: "missing endpoints",
+			store: &esv1beta1.SecretStore{Spec: esv1beta1.SecretStoreSpec{Provider: &esv1beta1.SecretStoreProvider{
+				Etcd: &esv1beta1.EtcdProvider{},
+			}}},
+			wantErr: true,
+		},
+		{
+			name: "unsupported scheme",
+			store: &esv1beta1.SecretStore{Spec: esv1beta1.SecretStoreSpec{Provider: &esv1beta1.SecretStoreProvider{
+				Etcd: &esv1beta1.EtcdProvider{Endpoints: []string{"unix://etcd.sock"}},
+			}}},
+			wantErr: true,
+		},
+		{
+			name: "missing key of the client certificate",
+			store: &esv1beta1.SecretStore{Spec: esv1beta1.SecretStoreSpec{Provider: &esv1beta1.SecretStoreProvider{
+				Etcd: &esv1beta1.EtcdProvider{
+					Endpoints: []string{"https://etcd:2379"},
+					Auth: &esv1beta1.EtcdAuth{ClientCert: &esv1beta1.EtcdClientCertAuth{
+						Certificate: esmeta.SecretKeySelector{Name: "etcd-client"},
+						PrivateKey:  esmeta.SecretKeySelector{Name: "etcd-client", Key: "tls.key"},
+					}},
+				},
+			}}},
+			wantErr: true,
+		},
+		{
+			name: "cluster store without namespace of the client certificate",
+			store: &esv1beta1.ClusterSecretStore{
+				TypeMeta: metav1.TypeMeta{Kind: esv1beta1.ClusterSecretStoreKind},
+				Spec: esv1beta1.SecretStoreSpec{Provider: &esv1beta1.SecretStoreProvider{
+					Etcd: &esv1beta1.EtcdProvider{Endpoints: []string{"https://etcd:2379"}, Auth: clientCertAuth()},
+				}},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := (&Provider{}).ValidateStore(tt.store)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateStore() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func stringMap(in map[string][]byte) map[string]string {
+	out := make(map[string]string, len(in))
+	for k, v := range in {
+		out[k] = string(v)
+	}
+	return out
+}
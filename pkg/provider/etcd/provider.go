@@ -0,0 +1,196 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package etcd implements a provider that reads secrets from the keys of an etcd v3 cluster.
+package etcd
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"go.uber.org/zap"
+	kclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	esmeta "github.com/external-secrets/external-secrets/apis/meta/v1"
+	"github.com/external-secrets/external-secrets/pkg/utils"
+	"github.com/external-secrets/external-secrets/pkg/utils/resolvers"
+)
+
+const (
+	dialTimeout = 5 * time.Second
+
+	errNewClient       = "unable to create etcd client: %w"
+	errFetchCA         = "unable to fetch the CA certificate: %w"
+	errInvalidCA       = "unable to parse the CA certificate"
+	errClientCert      = "unable to read the client certificate: %w"
+	errInvalidCert     = "unable to parse the client certificate: %w"
+	errInvalidStore    = "invalid store: %w"
+	errInvalidEndpoint = "invalid endpoint %q: %w"
+)
+
+var (
+	errMissingStore        = errors.New("missing store provider")
+	errMissingEtcdProvider = errors.New("missing store provider etcd")
+	errMissingEndpoints    = errors.New("at least one endpoint is required")
+	errUnsupportedScheme   = errors.New("scheme must be http or https")
+	errCANamespace         = errors.New("caProvider.namespace must not be empty with ClusterSecretStore")
+	errMissingCertRef      = errors.New("name and key of the client certificate and private key must not be empty")
+)
+
+// Provider is an etcd provider implementing NewClient and ValidateStore for the esv1beta1.Provider interface.
+type Provider struct{}
+
+var _ esv1beta1.SecretsClient = &Client{}
+var _ esv1beta1.Provider = &Provider{}
+
+func init() {
+	esv1beta1.Register(&Provider{}, &esv1beta1.SecretStoreProvider{
+		Etcd: &esv1beta1.EtcdProvider{},
+	})
+}
+
+// Capabilities return the provider supported capabilities (ReadOnly, WriteOnly, ReadWrite).
+func (p *Provider) Capabilities() esv1beta1.SecretStoreCapabilities {
+	return esv1beta1.SecretStoreReadOnly
+}
+
+func (p *Provider) NewClient(ctx context.Context, store esv1beta1.GenericStore, kube kclient.Client, namespace string) (esv1beta1.SecretsClient, error) {
+	prov, err := getProvider(store)
+	if err != nil {
+		return nil, err
+	}
+	tlsConfig, err := newTLSConfig(ctx, prov, kube, store.GetKind(), namespace)
+	if err != nil {
+		return nil, err
+	}
+	etcd, err := clientv3.New(clientv3.Config{
+		Endpoints:   prov.Endpoints,
+		TLS:         tlsConfig,
+		DialTimeout: dialTimeout,
+		Logger:      zap.NewNop(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf(errNewClient, err)
+	}
+	return &Client{
+		etcd: etcd,
+		kv:   etcd.KV,
+	}, nil
+}
+
+// newTLSConfig returns the TLS config for the connection to etcd,
+// it is nil if neither https endpoints nor TLS options are used.
+func newTLSConfig(ctx context.Context, prov *esv1beta1.EtcdProvider, kube kclient.Client, storeKind, namespace string) (*tls.Config, error) {
+	clientCert := prov.Auth != nil && prov.Auth.ClientCert != nil
+	if !usesHTTPS(prov.Endpoints) && len(prov.CABundle) == 0 && prov.CAProvider == nil && !clientCert {
+		return nil, nil
+	}
+
+	cfg := &tls.Config{MinVersion: tls.VersionTLS12}
+	ca, err := utils.FetchCACertFromSource(ctx, utils.CreateCertOpts{
+		CABundle:   prov.CABundle,
+		CAProvider: prov.CAProvider,
+		StoreKind:  storeKind,
+		Namespace:  namespace,
+		Client:     kube,
+	})
+	if err != nil {
+		return nil, fmt.Errorf(errFetchCA, err)
+	}
+	if len(ca) > 0 {
+		cfg.RootCAs = x509.NewCertPool()
+		if !cfg.RootCAs.AppendCertsFromPEM(ca) {
+			return nil, errors.New(errInvalidCA)
+		}
+	}
+
+	if clientCert {
+		certPEM, err := resolvers.SecretKeyRef(ctx, kube, storeKind, namespace, &prov.Auth.ClientCert.Certificate)
+		if err != nil {
+			return nil, fmt.Errorf(errClientCert, err)
+		}
+		keyPEM, err := resolvers.SecretKeyRef(ctx, kube, storeKind, namespace, &prov.Auth.ClientCert.PrivateKey)
+		if err != nil {
+			return nil, fmt.Errorf(errClientCert, err)
+		}
+		cert, err := tls.X509KeyPair([]byte(certPEM), []byte(keyPEM))
+		if err != nil {
+			return nil, fmt.Errorf(errInvalidCert, err)
+		}
+		cfg.Certificates = []tls.Certificate{cert}
+	}
+	return cfg, nil
+}
+
+func usesHTTPS(endpoints []string) bool {
+	for _, endpoint := range endpoints {
+		if u, err := url.Parse(endpoint); err == nil && u.Scheme == "https" {
+			return true
+		}
+	}
+	return false
+}
+
+func getProvider(store esv1beta1.GenericStore) (*esv1beta1.EtcdProvider, error) {
+	if store == nil {
+		return nil, errMissingStore
+	}
+	spc := store.GetSpec()
+	if spc == nil || spc.Provider == nil || spc.Provider.Etcd == nil {
+		return nil, errMissingEtcdProvider
+	}
+	return spc.Provider.Etcd, nil
+}
+
+func (p *Provider) ValidateStore(store esv1beta1.GenericStore) (admission.Warnings, error) {
+	prov, err := getProvider(store)
+	if err != nil {
+		return nil, err
+	}
+	if len(prov.Endpoints) == 0 {
+		return nil, fmt.Errorf(errInvalidStore, errMissingEndpoints)
+	}
+	for _, endpoint := range prov.Endpoints {
+		u, err := url.Parse(endpoint)
+		if err != nil {
+			return nil, fmt.Errorf(errInvalidEndpoint, endpoint, err)
+		}
+		if u.Scheme != "http" && u.Scheme != "https" {
+			return nil, fmt.Errorf(errInvalidEndpoint, endpoint, errUnsupportedScheme)
+		}
+	}
+	if store.GetKind() == esv1beta1.ClusterSecretStoreKind &&
+		prov.CAProvider != nil && prov.CAProvider.Namespace == nil {
+		return nil, fmt.Errorf(errInvalidStore, errCANamespace)
+	}
+	if prov.Auth != nil && prov.Auth.ClientCert != nil {
+		for _, ref := range []esmeta.SecretKeySelector{prov.Auth.ClientCert.Certificate, prov.Auth.ClientCert.PrivateKey} {
+			if ref.Name == "" || ref.Key == "" {
+				return nil, fmt.Errorf(errInvalidStore, errMissingCertRef)
+			}
+			if err := utils.ValidateSecretSelector(store, ref); err != nil {
+				return nil, fmt.Errorf(errInvalidStore, err)
+			}
+		}
+	}
+	return nil, nil
+}
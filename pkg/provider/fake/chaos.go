@@ -0,0 +1,129 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fake
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"reflect"
+	"sync"
+	"time"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+)
+
+// chaos injects the latency and errors of spec.provider.fake.chaos into the requests of a store.
+// It is shared by all clients of the store, so the sequence of decisions continues across reconciles.
+type chaos struct {
+	spec esv1beta1.FakeProviderChaos
+
+	mu  sync.Mutex
+	rng *rand.Rand
+}
+
+// chaosError is an injected error.
+type chaosError struct {
+	reason esv1beta1.ProviderErrorReason
+}
+
+func (e *chaosError) Error() string {
+	return fmt.Sprintf("fake provider: injected %s error", e.reason)
+}
+
+// Unwrap returns NoSecretErr for NotFound, so the deletionPolicy applies.
+func (e *chaosError) Unwrap() error {
+	if e.reason == esv1beta1.ProviderErrorNotFound {
+		return esv1beta1.NoSecretErr
+	}
+	return nil
+}
+
+func newChaos(spec esv1beta1.FakeProviderChaos) *chaos {
+	// the second word of the seed is fixed, so the sequence only depends on spec.seed
+	return &chaos{
+		spec: spec,
+		rng:  rand.New(rand.NewPCG(uint64(spec.Seed), 0)), //nolint:gosec // deterministic on purpose
+	}
+}
+
+// chaosFor returns the chaos of the store, it is kept as long as the configuration does not change.
+func (p *Provider) chaosFor(storeName string, spec *esv1beta1.FakeProviderChaos) *chaos {
+	p.chaosMu.Lock()
+	defer p.chaosMu.Unlock()
+	if spec == nil {
+		delete(p.chaosByStore, storeName)
+		return nil
+	}
+	if p.chaosByStore == nil {
+		p.chaosByStore = make(map[string]*chaos)
+	}
+	if c, ok := p.chaosByStore[storeName]; ok && reflect.DeepEqual(c.spec, *spec) {
+		return c
+	}
+	c := newChaos(*spec)
+	p.chaosByStore[storeName] = c
+	return c
+}
+
+// next decides the latency and the error of a request.
+func (c *chaos) next() (time.Duration, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var latency time.Duration
+	if c.spec.Latency != nil {
+		latency = c.spec.Latency.Duration
+	}
+	if c.spec.LatencyJitter != nil && c.spec.LatencyJitter.Duration > 0 {
+		latency += time.Duration(c.rng.Int64N(int64(c.spec.LatencyJitter.Duration)))
+	}
+	if c.rng.IntN(100) >= c.spec.ErrorRate {
+		return latency, nil
+	}
+	reason := esv1beta1.ProviderErrorUnavailable
+	if len(c.spec.ErrorReasons) > 0 {
+		reason = c.spec.ErrorReasons[c.rng.IntN(len(c.spec.ErrorReasons))]
+	}
+	return latency, &chaosError{reason: reason}
+}
+
+// inject waits for the latency of the request and returns its error.
+// It returns early with the error of ctx if ctx is done, e.g. with spec.providerTimeout.
+func (c *chaos) inject(ctx context.Context) error {
+	if c == nil {
+		return nil
+	}
+	latency, err := c.next()
+	if latency > 0 {
+		timer := time.NewTimer(latency)
+		defer timer.Stop()
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+	return err
+}
+
+// ClassifyError reports injected errors with their reason.
+func (p *Provider) ClassifyError(err error) esv1beta1.ProviderErrorReason {
+	var chaosErr *chaosError
+	if errors.As(err, &chaosErr) {
+		return chaosErr.reason
+	}
+	return ""
+}
@@ -0,0 +1,151 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fake
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+)
+
+func chaosStore(name string, c *esv1beta1.FakeProviderChaos) *esv1beta1.SecretStore {
+	return &esv1beta1.SecretStore{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: esv1beta1.SecretStoreSpec{
+			Provider: &esv1beta1.SecretStoreProvider{
+				Fake: &esv1beta1.FakeProvider{
+					Data:  []esv1beta1.FakeProviderData{{Key: "foo", Value: "bar"}},
+					Chaos: c,
+				},
+			},
+		},
+	}
+}
+
+// outcomes returns the reasons of n reads, an empty reason for a successful read.
+func outcomes(t *testing.T, p *Provider, store *esv1beta1.SecretStore, n int) []esv1beta1.ProviderErrorReason {
+	t.Helper()
+	cl, err := p.NewClient(context.Background(), store, nil, "")
+	gomega.Expect(err).ToNot(gomega.HaveOccurred())
+	out := make([]esv1beta1.ProviderErrorReason, n)
+	for i := range out {
+		_, err := cl.GetSecret(context.Background(), esv1beta1.ExternalSecretDataRemoteRef{Key: "foo"})
+		if err != nil {
+			out[i] = p.ClassifyError(err)
+			gomega.Expect(out[i]).ToNot(gomega.BeEmpty(), "unexpected error: %v", err)
+		}
+	}
+	return out
+}
+
+func TestChaosDeterministic(t *testing.T) {
+	gomega.RegisterTestingT(t)
+	spec := &esv1beta1.FakeProviderChaos{
+		ErrorRate:    50,
+		ErrorReasons: []esv1beta1.ProviderErrorReason{esv1beta1.ProviderErrorRateLimited, esv1beta1.ProviderErrorUnavailable},
+		Seed:         42,
+	}
+
+	first := outcomes(t, &Provider{}, chaosStore("chaos", spec), 100)
+	second := outcomes(t, &Provider{}, chaosStore("chaos", spec), 100)
+	gomega.Expect(second).To(gomega.Equal(first))
+
+	var failed int
+	reasons := map[esv1beta1.ProviderErrorReason]bool{}
+	for _, reason := range first {
+		if reason != "" {
+			failed++
+			reasons[reason] = true
+		}
+	}
+	gomega.Expect(failed).To(gomega.BeNumerically("~", 50, 15))
+	gomega.Expect(reasons).To(gomega.HaveLen(2))
+
+	otherSeed := *spec
+	otherSeed.Seed = 7
+	gomega.Expect(outcomes(t, &Provider{}, chaosStore("chaos", &otherSeed), 100)).ToNot(gomega.Equal(first))
+}
+
+func TestChaosSequenceContinuesAcrossClients(t *testing.T) {
+	gomega.RegisterTestingT(t)
+	spec := &esv1beta1.FakeProviderChaos{ErrorRate: 50, Seed: 1}
+
+	all := outcomes(t, &Provider{}, chaosStore("chaos", spec), 20)
+
+	p := &Provider{}
+	split := append(outcomes(t, p, chaosStore("chaos", spec), 10), outcomes(t, p, chaosStore("chaos", spec), 10)...)
+	gomega.Expect(split).To(gomega.Equal(all))
+
+	// a changed configuration starts a new sequence
+	changed := *spec
+	changed.ErrorRate = 100
+	gomega.Expect(outcomes(t, p, chaosStore("chaos", &changed), 3)).To(gomega.Equal([]esv1beta1.ProviderErrorReason{
+		esv1beta1.ProviderErrorUnavailable, esv1beta1.ProviderErrorUnavailable, esv1beta1.ProviderErrorUnavailable,
+	}))
+}
+
+func TestChaosErrors(t *testing.T) {
+	gomega.RegisterTestingT(t)
+	p := &Provider{}
+	cl, err := p.NewClient(context.Background(), chaosStore("not-found", &esv1beta1.FakeProviderChaos{
+		ErrorRate:    100,
+		ErrorReasons: []esv1beta1.ProviderErrorReason{esv1beta1.ProviderErrorNotFound},
+	}), nil, "")
+	gomega.Expect(err).ToNot(gomega.HaveOccurred())
+
+	_, err = cl.GetSecretMap(context.Background(), esv1beta1.ExternalSecretDataRemoteRef{Key: "foo"})
+	gomega.Expect(errors.Is(err, esv1beta1.NoSecretErr)).To(gomega.BeTrue())
+	_, err = cl.GetAllSecrets(context.Background(), esv1beta1.ExternalSecretFind{Name: &esv1beta1.FindName{RegExp: ".*"}})
+	gomega.Expect(err).To(gomega.HaveOccurred())
+
+	classified := esv1beta1.ClassifyError(p, err)
+	var providerErr *esv1beta1.ProviderError
+	gomega.Expect(errors.As(classified, &providerErr)).To(gomega.BeTrue())
+	gomega.Expect(providerErr.Reason).To(gomega.Equal(esv1beta1.ProviderErrorNotFound))
+}
+
+func TestChaosLatency(t *testing.T) {
+	gomega.RegisterTestingT(t)
+	p := &Provider{}
+	cl, err := p.NewClient(context.Background(), chaosStore("slow", &esv1beta1.FakeProviderChaos{
+		Latency: &metav1.Duration{Duration: 50 * time.Millisecond},
+	}), nil, "")
+	gomega.Expect(err).ToNot(gomega.HaveOccurred())
+
+	start := time.Now()
+	got, err := cl.GetSecret(context.Background(), esv1beta1.ExternalSecretDataRemoteRef{Key: "foo"})
+	gomega.Expect(err).ToNot(gomega.HaveOccurred())
+	gomega.Expect(string(got)).To(gomega.Equal("bar"))
+	gomega.Expect(time.Since(start)).To(gomega.BeNumerically(">=", 50*time.Millisecond))
+
+	// the latency is cut short by the deadline of the request
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Millisecond)
+	defer cancel()
+	_, err = cl.GetSecret(ctx, esv1beta1.ExternalSecretDataRemoteRef{Key: "foo"})
+	gomega.Expect(errors.Is(err, context.DeadlineExceeded)).To(gomega.BeTrue())
+}
+
+func TestValidateStoreChaos(t *testing.T) {
+	gomega.RegisterTestingT(t)
+	store := chaosStore("chaos", &esv1beta1.FakeProviderChaos{Latency: &metav1.Duration{Duration: -time.Second}})
+	_, err := (&Provider{}).ValidateStore(store)
+	gomega.Expect(err).To(gomega.MatchError(errNegativeLatency))
+}
@@ -16,10 +16,14 @@ package fake
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/tidwall/gjson"
 	corev1 "k8s.io/api/core/v1"
@@ -36,6 +40,7 @@ var (
 	errMissingFakeProvider = errors.New("missing store provider fake")
 	errMissingKeyField     = "key must be set in data %v"
 	errMissingValueField   = "at least one of value or valueMap must be set in data %v"
+	errNegativeLatency     = errors.New("chaos latency and latencyJitter must not be negative")
 )
 
 type SourceOrigin string
@@ -43,6 +48,8 @@ type SourceOrigin string
 const (
 	FakeSecretStore SourceOrigin = "SecretStore"
 	FakeSetSecret   SourceOrigin = "SetSecret"
+
+	rotatedValueLength = 16
 )
 
 type Data struct {
@@ -55,6 +62,11 @@ type Config map[string]*Data
 type Provider struct {
 	config   Config
 	database map[string]Config
+
+	// chaos of the store of a client, chaosByStore of all stores of the provider
+	chaos        *chaos
+	chaosMu      sync.Mutex
+	chaosByStore map[string]*chaos
 }
 
 // Capabilities return the provider supported capabilities (ReadOnly, WriteOnly, ReadWrite).
@@ -95,9 +107,15 @@ func (p *Provider) NewClient(_ context.Context, store esv1beta1.GenericStore, _
 	p.database[store.GetName()] = cfg
 	return &Provider{
 		config: cfg,
+		chaos:  p.chaosFor(store.GetName(), c.Chaos),
 	}, nil
 }
 
+var (
+	_ esv1beta1.SecretRotator   = &Provider{}
+	_ esv1beta1.ErrorClassifier = &Provider{}
+)
+
 func getProvider(store esv1beta1.GenericStore) (*esv1beta1.FakeProvider, error) {
 	if store == nil {
 		return nil, errMissingStore
@@ -118,7 +136,10 @@ func (p *Provider) SecretExists(_ context.Context, ref esv1beta1.PushSecretRemot
 	return ok, nil
 }
 
-func (p *Provider) PushSecret(_ context.Context, secret *corev1.Secret, data esv1beta1.PushSecretData) error {
+func (p *Provider) PushSecret(ctx context.Context, secret *corev1.Secret, data esv1beta1.PushSecretData) error {
+	if err := p.chaos.inject(ctx); err != nil {
+		return err
+	}
 	value := secret.Data[data.GetSecretKey()]
 	currentData, ok := p.config[data.GetRemoteKey()]
 	if !ok {
@@ -137,9 +158,30 @@ func (p *Provider) PushSecret(_ context.Context, secret *corev1.Secret, data esv
 	return nil
 }
 
+// RotateSecret simulates a native rotation by replacing
+// the value of a key set with PushSecret with a random value.
+func (p *Provider) RotateSecret(_ context.Context, remoteRef esv1beta1.PushSecretRemoteRef) error {
+	currentData, ok := p.config[remoteRef.GetRemoteKey()]
+	if !ok {
+		return esv1beta1.NoSecretErr
+	}
+	if currentData.Origin != FakeSetSecret {
+		return errors.New("key is defined in the store")
+	}
+	value := make([]byte, rotatedValueLength)
+	if _, err := rand.Read(value); err != nil {
+		return err
+	}
+	currentData.Value = hex.EncodeToString(value)
+	return nil
+}
+
 // GetAllSecrets returns multiple secrets from the given ExternalSecretFind
 // Currently, only the Name operator is supported.
-func (p *Provider) GetAllSecrets(_ context.Context, ref esv1beta1.ExternalSecretFind) (map[string][]byte, error) {
+func (p *Provider) GetAllSecrets(ctx context.Context, ref esv1beta1.ExternalSecretFind) (map[string][]byte, error) {
+	if err := p.chaos.inject(ctx); err != nil {
+		return nil, err
+	}
 	if ref.Name != nil {
 		matcher, err := find.New(*ref.Name)
 		if err != nil {
@@ -173,11 +215,19 @@ func (p *Provider) GetAllSecrets(_ context.Context, ref esv1beta1.ExternalSecret
 }
 
 // GetSecret returns a single secret from the provider.
-func (p *Provider) GetSecret(_ context.Context, ref esv1beta1.ExternalSecretDataRemoteRef) ([]byte, error) {
+func (p *Provider) GetSecret(ctx context.Context, ref esv1beta1.ExternalSecretDataRemoteRef) ([]byte, error) {
+	if err := p.chaos.inject(ctx); err != nil {
+		return nil, err
+	}
+	return p.getSecret(ctx, ref)
+}
+
+func (p *Provider) getSecret(ctx context.Context, ref esv1beta1.ExternalSecretDataRemoteRef) ([]byte, error) {
 	data, ok := p.config[mapKey(ref.Key, ref.Version)]
 	if !ok || data.Version != ref.Version {
 		return nil, esv1beta1.NoSecretErr
 	}
+	reportMetadata(ctx, data)
 
 	if ref.Property != "" {
 		val := gjson.Get(data.Value, ref.Property)
@@ -193,6 +243,9 @@ func (p *Provider) GetSecret(_ context.Context, ref esv1beta1.ExternalSecretData
 
 // GetSecretMap returns multiple k/v pairs from the provider.
 func (p *Provider) GetSecretMap(ctx context.Context, ref esv1beta1.ExternalSecretDataRemoteRef) (map[string][]byte, error) {
+	if err := p.chaos.inject(ctx); err != nil {
+		return nil, err
+	}
 	ddata, ok := p.config[mapKey(ref.Key, ref.Version)]
 	if !ok || ddata.Version != ref.Version {
 		return nil, esv1beta1.NoSecretErr
@@ -200,10 +253,11 @@ func (p *Provider) GetSecretMap(ctx context.Context, ref esv1beta1.ExternalSecre
 
 	// Due to backward compatibility valueMap will still be returned for now
 	if ddata.ValueMap != nil {
+		reportMetadata(ctx, ddata)
 		return convertMap(ddata.ValueMap), nil
 	}
 
-	data, err := p.GetSecret(ctx, ref)
+	data, err := p.getSecret(ctx, ref)
 	if err != nil {
 		return nil, err
 	}
@@ -228,6 +282,20 @@ func (p *Provider) GetSecretMap(ctx context.Context, ref esv1beta1.ExternalSecre
 	return secretData, nil
 }
 
+// reportMetadata reports where and when the secret was read,
+// it can be written to the annotations of the Secret with target.metadataAnnotations.
+func reportMetadata(ctx context.Context, data *Data) {
+	metadata := map[string]string{
+		"source":       "fake",
+		"origin":       string(data.Origin),
+		"retrieved_at": time.Now().UTC().Format(time.RFC3339),
+	}
+	if data.Version != "" {
+		metadata["version"] = data.Version
+	}
+	esv1beta1.ReportProviderMetadata(ctx, metadata)
+}
+
 func convertMap(in map[string]string) map[string][]byte {
 	m := make(map[string][]byte)
 	for k, v := range in {
@@ -257,6 +325,11 @@ func (p *Provider) ValidateStore(store esv1beta1.GenericStore) (admission.Warnin
 			return nil, fmt.Errorf(errMissingValueField, pos)
 		}
 	}
+	if c := prov.Chaos; c != nil {
+		if (c.Latency != nil && c.Latency.Duration < 0) || (c.LatencyJitter != nil && c.LatencyJitter.Duration < 0) {
+			return nil, errNegativeLatency
+		}
+	}
 	return nil, nil
 }
 
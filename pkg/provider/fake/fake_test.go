@@ -324,6 +324,35 @@ func TestGetSecret(t *testing.T) {
 	}
 }
 
+func TestGetSecretMetadata(t *testing.T) {
+	gomega.RegisterTestingT(t)
+	p := &Provider{}
+	cl, err := p.NewClient(context.Background(), &esv1beta1.SecretStore{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "secret-store-metadata",
+		},
+		Spec: esv1beta1.SecretStoreSpec{
+			Provider: &esv1beta1.SecretStoreProvider{
+				Fake: &esv1beta1.FakeProvider{
+					Data: []esv1beta1.FakeProviderData{
+						{Key: "/foo", Value: "bar", Version: "v1"},
+					},
+				},
+			},
+		},
+	}, nil, "")
+	gomega.Expect(err).ToNot(gomega.HaveOccurred())
+
+	ctx, metadata := esv1beta1.ContextWithProviderMetadata(context.Background())
+	_, err = cl.GetSecret(ctx, esv1beta1.ExternalSecretDataRemoteRef{Key: "/foo", Version: "v1"})
+	gomega.Expect(err).ToNot(gomega.HaveOccurred())
+	got := metadata.Get()
+	gomega.Expect(got).To(gomega.HaveKeyWithValue("source", "fake"))
+	gomega.Expect(got).To(gomega.HaveKeyWithValue("origin", string(FakeSecretStore)))
+	gomega.Expect(got).To(gomega.HaveKeyWithValue("version", "v1"))
+	gomega.Expect(got).To(gomega.HaveKey("retrieved_at"))
+}
+
 type setSecretTestCase struct {
 	name       string
 	input      []esv1beta1.FakeProviderData
@@ -450,6 +479,45 @@ func TestSecretExists(t *testing.T) {
 	}
 }
 
+func TestRotateSecret(t *testing.T) {
+	gomega.RegisterTestingT(t)
+	p := &Provider{}
+	cl, err := p.NewClient(context.Background(), &esv1beta1.SecretStore{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "secret-store-rotate",
+		},
+		Spec: esv1beta1.SecretStoreSpec{
+			Provider: &esv1beta1.SecretStoreProvider{
+				Fake: &esv1beta1.FakeProvider{
+					Data: []esv1beta1.FakeProviderData{
+						{
+							Key:   "/store",
+							Value: "bar",
+						},
+					},
+				},
+			},
+		},
+	}, nil, "")
+	gomega.Expect(err).ToNot(gomega.HaveOccurred())
+	err = cl.PushSecret(context.TODO(), &corev1.Secret{
+		Data: map[string][]byte{"key": []byte("initial")},
+	}, testingfake.PushSecretData{SecretKey: "key", RemoteKey: "/pushed"})
+	gomega.Expect(err).ToNot(gomega.HaveOccurred())
+
+	err = esv1beta1.RotateSecret(context.TODO(), cl, esv1alpha1.PushSecretRemoteRef{RemoteKey: "/pushed"})
+	gomega.Expect(err).ToNot(gomega.HaveOccurred())
+	out, err := cl.GetSecret(context.TODO(), esv1beta1.ExternalSecretDataRemoteRef{Key: "/pushed"})
+	gomega.Expect(err).ToNot(gomega.HaveOccurred())
+	gomega.Expect(string(out)).ToNot(gomega.Equal("initial"))
+	gomega.Expect(out).To(gomega.HaveLen(2 * rotatedValueLength))
+
+	err = esv1beta1.RotateSecret(context.TODO(), cl, esv1alpha1.PushSecretRemoteRef{RemoteKey: "/store"})
+	gomega.Expect(err).To(gomega.MatchError("key is defined in the store"))
+	err = esv1beta1.RotateSecret(context.TODO(), cl, esv1alpha1.PushSecretRemoteRef{RemoteKey: "/missing"})
+	gomega.Expect(err).To(gomega.MatchError(esv1beta1.NoSecretErr))
+}
+
 type testMapCase struct {
 	name     string
 	input    []esv1beta1.FakeProviderData
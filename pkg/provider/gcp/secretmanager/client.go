@@ -20,6 +20,7 @@ import (
 	"errors"
 	"fmt"
 	"maps"
+	"regexp"
 	"slices"
 	"strconv"
 	"strings"
@@ -65,6 +66,8 @@ const (
 	errInvalidAuthSecretRef   = "invalid auth secret data: %w"
 	errInvalidWISARef         = "invalid workload identity service account reference: %w"
 	errUnexpectedFindOperator = "unexpected find operator"
+	errAtomicPushRef          = "all properties pushed atomically must set a property of remote key %s"
+	errAtomicPushKey          = "secret key %s does not exist"
 
 	managedByKey   = "managed-by"
 	managedByValue = "external-secrets"
@@ -88,6 +91,7 @@ type GoogleSecretManagerClient interface {
 	DeleteSecret(ctx context.Context, req *secretmanagerpb.DeleteSecretRequest, opts ...gax.CallOption) error
 	AccessSecretVersion(ctx context.Context, req *secretmanagerpb.AccessSecretVersionRequest, opts ...gax.CallOption) (*secretmanagerpb.AccessSecretVersionResponse, error)
 	ListSecrets(ctx context.Context, req *secretmanagerpb.ListSecretsRequest, opts ...gax.CallOption) *secretmanager.SecretIterator
+	ListSecretVersions(ctx context.Context, req *secretmanagerpb.ListSecretVersionsRequest, opts ...gax.CallOption) *secretmanager.SecretVersionIterator
 	AddSecretVersion(ctx context.Context, req *secretmanagerpb.AddSecretVersionRequest, opts ...gax.CallOption) (*secretmanagerpb.SecretVersion, error)
 	CreateSecret(ctx context.Context, req *secretmanagerpb.CreateSecretRequest, opts ...gax.CallOption) (*secretmanagerpb.Secret, error)
 	Close() error
@@ -97,6 +101,10 @@ type GoogleSecretManagerClient interface {
 
 var log = ctrl.Log.WithName("provider").WithName("gcp").WithName("secretsmanager")
 
+// plainFilterValue matches the values that can be used unquoted in a filter,
+// it covers the characters allowed in label values and secret names.
+var plainFilterValue = regexp.MustCompile(`^[\p{L}\p{N}_-]+$`)
+
 func (c *Client) DeleteSecret(ctx context.Context, remoteRef esv1beta1.PushSecretRemoteRef) error {
 	gcpSecret, err := c.smClient.GetSecret(ctx, &secretmanagerpb.GetSecretRequest{
 		Name: fmt.Sprintf("projects/%s/secrets/%s", c.store.ProjectID, remoteRef.GetRemoteKey()),
@@ -166,6 +174,41 @@ func (c *Client) PushSecret(ctx context.Context, secret *corev1.Secret, pushSecr
 	} else {
 		payload = secret.Data[pushSecretData.GetSecretKey()]
 	}
+	builder, err := newPushSecretBuilder(payload, pushSecretData)
+	if err != nil {
+		return err
+	}
+	return c.pushSecretVersion(ctx, pushSecretData, builder)
+}
+
+// PushSecrets writes all properties of one remote secret in a single new secret version,
+// so a failure never leaves a version with only some of the properties updated.
+func (c *Client) PushSecrets(ctx context.Context, secret *corev1.Secret, data []esv1beta1.PushSecretData) error {
+	if len(data) == 0 {
+		return nil
+	}
+	remoteKey := data[0].GetRemoteKey()
+	builder := &batchPSBuilder{}
+	for _, d := range data {
+		if d.GetRemoteKey() != remoteKey || d.GetProperty() == "" {
+			return fmt.Errorf(errAtomicPushRef, remoteKey)
+		}
+		payload, ok := secret.Data[d.GetSecretKey()]
+		if !ok {
+			return fmt.Errorf(errAtomicPushKey, d.GetSecretKey())
+		}
+		b, err := newPushSecretBuilder(payload, d)
+		if err != nil {
+			return err
+		}
+		builder.builders = append(builder.builders, b)
+	}
+	return c.pushSecretVersion(ctx, data[0], builder)
+}
+
+// pushSecretVersion creates the remote secret if needed, updates its metadata
+// and adds a new version with the data of the builder if it changed.
+func (c *Client) pushSecretVersion(ctx context.Context, pushSecretData esv1beta1.PushSecretData, builder pushSecretBuilder) error {
 	secretName := fmt.Sprintf("projects/%s/secrets/%s", c.store.ProjectID, pushSecretData.GetRemoteKey())
 	gcpSecret, err := c.smClient.GetSecret(ctx, &secretmanagerpb.GetSecretRequest{
 		Name: secretName,
@@ -246,11 +289,6 @@ func (c *Client) PushSecret(ctx context.Context, secret *corev1.Secret, pushSecr
 		}
 	}
 
-	builder, err := newPushSecretBuilder(payload, pushSecretData)
-	if err != nil {
-		return err
-	}
-
 	annotations, labels, topics, err := builder.buildMetadata(gcpSecret.Annotations, gcpSecret.Labels, gcpSecret.Topics)
 	if err != nil {
 		return err
@@ -340,54 +378,42 @@ func (c *Client) PushSecret(ctx context.Context, secret *corev1.Secret, pushSecr
 }
 
 // GetAllSecrets syncs multiple secrets from gcp provider into a single Kubernetes Secret.
+// The tags and the path are sent as a filter to the API, only the name regexp
+// and the prefix match of the path are evaluated on the listed secrets.
 func (c *Client) GetAllSecrets(ctx context.Context, ref esv1beta1.ExternalSecretFind) (map[string][]byte, error) {
+	var matcher *find.Matcher
 	if ref.Name != nil {
-		return c.findByName(ctx, ref)
-	}
-	if len(ref.Tags) > 0 {
-		return c.findByTags(ctx, ref)
+		var err error
+		matcher, err = find.New(*ref.Name)
+		if err != nil {
+			return nil, err
+		}
+	} else if len(ref.Tags) == 0 {
+		return nil, errors.New(errUnexpectedFindOperator)
 	}
 
-	return nil, errors.New(errUnexpectedFindOperator)
-}
-
-func (c *Client) findByName(ctx context.Context, ref esv1beta1.ExternalSecretFind) (map[string][]byte, error) {
-	// regex matcher
-	matcher, err := find.New(*ref.Name)
-	if err != nil {
-		return nil, err
-	}
 	req := &secretmanagerpb.ListSecretsRequest{
 		Parent: fmt.Sprintf("projects/%s", c.store.ProjectID),
+		Filter: findFilter(ref),
 	}
-	if ref.Path != nil {
-		req.Filter = fmt.Sprintf("name:%s", *ref.Path)
-	}
-	// Call the API.
+	log.V(1).Info("gcp sm find", "filter", req.Filter)
 	it := c.smClient.ListSecrets(ctx, req)
 	secretMap := make(map[string][]byte)
-	var resp *secretmanagerpb.Secret
-	defer metrics.ObserveAPICall(constants.ProviderGCPSM, constants.CallGCPSMListSecrets, err)
 	for {
-		resp, err = it.Next()
+		resp, err := it.Next()
 		if errors.Is(err, iterator.Done) {
+			metrics.ObserveAPICall(constants.ProviderGCPSM, constants.CallGCPSMListSecrets, nil)
 			break
 		}
 		if err != nil {
+			metrics.ObserveAPICall(constants.ProviderGCPSM, constants.CallGCPSMListSecrets, err)
 			return nil, fmt.Errorf("failed to list secrets: %w", err)
 		}
-		log.V(1).Info("gcp sm findByName found", "secrets", strconv.Itoa(it.PageInfo().Remaining()))
 		key := c.trimName(resp.Name)
-		// If we don't match we skip.
-		// Also, if we have path, and it is not at the beguining we skip.
-		// We have to check if path is at the beguining of the key because
-		// there is no way to create a `name:%s*` (starts with) filter
-		// At https://cloud.google.com/secret-manager/docs/filtering you can use `*`
-		// but not like that it seems.
-		if !matcher.MatchName(key) || (ref.Path != nil && !strings.HasPrefix(key, *ref.Path)) {
+		if !matchesFind(key, ref, matcher) {
 			continue
 		}
-		log.V(1).Info("gcp sm findByName matches", "name", resp.Name)
+		log.V(1).Info("gcp sm find matches", "name", resp.Name)
 		secretMap[key], err = c.getData(ctx, key)
 		if err != nil {
 			return nil, err
@@ -397,6 +423,43 @@ func (c *Client) findByName(ctx context.Context, ref esv1beta1.ExternalSecretFin
 	return utils.ConvertKeys(ref.ConversionStrategy, secretMap)
 }
 
+// findFilter translates the tags and the path of ref into a filter of ListSecrets,
+// see https://cloud.google.com/secret-manager/docs/filtering.
+// The labels are sorted, so the filter is stable.
+func findFilter(ref esv1beta1.ExternalSecretFind) string {
+	filters := make([]string, 0, len(ref.Tags)+1)
+	for _, k := range slices.Sorted(maps.Keys(ref.Tags)) {
+		filters = append(filters, labelFilter(k, ref.Tags[k]))
+	}
+	if ref.Path != nil {
+		// name:<path> matches the path anywhere in the name,
+		// the prefix is checked by matchesFind
+		filters = append(filters, "name:"+filterValue(*ref.Path))
+	}
+	return strings.Join(filters, " ")
+}
+
+func labelFilter(key, value string) string {
+	return fmt.Sprintf("labels.%s=%s", key, filterValue(value))
+}
+
+// filterValue quotes values that are not a plain word of the filter grammar.
+func filterValue(value string) string {
+	if plainFilterValue.MatchString(value) {
+		return value
+	}
+	return strconv.Quote(value)
+}
+
+// matchesFind evaluates the parts of ref that the filter can not express:
+// the name regexp and the prefix match of the path.
+func matchesFind(key string, ref esv1beta1.ExternalSecretFind, matcher *find.Matcher) bool {
+	if ref.Path != nil && !strings.HasPrefix(key, *ref.Path) {
+		return false
+	}
+	return matcher == nil || matcher.MatchName(key)
+}
+
 func (c *Client) getData(ctx context.Context, key string) ([]byte, error) {
 	dataRef := esv1beta1.ExternalSecretDataRemoteRef{
 		Key: key,
@@ -408,48 +471,6 @@ func (c *Client) getData(ctx context.Context, key string) ([]byte, error) {
 	return data, nil
 }
 
-func (c *Client) findByTags(ctx context.Context, ref esv1beta1.ExternalSecretFind) (map[string][]byte, error) {
-	var tagFilter string
-	for k, v := range ref.Tags {
-		tagFilter = fmt.Sprintf("%slabels.%s=%s ", tagFilter, k, v)
-	}
-	tagFilter = strings.TrimSuffix(tagFilter, " ")
-	if ref.Path != nil {
-		tagFilter = fmt.Sprintf("%s name:%s", tagFilter, *ref.Path)
-	}
-	req := &secretmanagerpb.ListSecretsRequest{
-		Parent: fmt.Sprintf("projects/%s", c.store.ProjectID),
-	}
-	log.V(1).Info("gcp sm findByTags", "tagFilter", tagFilter)
-	req.Filter = tagFilter
-	// Call the API.
-	it := c.smClient.ListSecrets(ctx, req)
-	var resp *secretmanagerpb.Secret
-	var err error
-	defer metrics.ObserveAPICall(constants.ProviderGCPSM, constants.CallGCPSMListSecrets, err)
-	secretMap := make(map[string][]byte)
-	for {
-		resp, err = it.Next()
-		if errors.Is(err, iterator.Done) {
-			break
-		}
-		if err != nil {
-			return nil, fmt.Errorf("failed to list secrets: %w", err)
-		}
-		key := c.trimName(resp.Name)
-		if ref.Path != nil && !strings.HasPrefix(key, *ref.Path) {
-			continue
-		}
-		log.V(1).Info("gcp sm findByTags matches tags", "name", resp.Name)
-		secretMap[key], err = c.getData(ctx, key)
-		if err != nil {
-			return nil, err
-		}
-	}
-
-	return utils.ConvertKeys(ref.ConversionStrategy, secretMap)
-}
-
 func (c *Client) trimName(name string) string {
 	projectIDNumuber := c.extractProjectIDNumber(name)
 	key := strings.TrimPrefix(name, fmt.Sprintf("projects/%s/secrets/", projectIDNumuber))
@@ -36,6 +36,7 @@ import (
 	"github.com/external-secrets/external-secrets/apis/externalsecrets/v1alpha1"
 	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
 	v1 "github.com/external-secrets/external-secrets/apis/meta/v1"
+	"github.com/external-secrets/external-secrets/pkg/find"
 	fakesm "github.com/external-secrets/external-secrets/pkg/provider/gcp/secretmanager/fake"
 	testingfake "github.com/external-secrets/external-secrets/pkg/provider/testing/fake"
 )
@@ -1217,6 +1218,100 @@ func TestPushSecretProperty(t *testing.T) {
 	}
 }
 
+func TestPushSecrets(t *testing.T) {
+	existing := `{"a":"1","b":"1"}`
+	secret := &corev1.Secret{Data: map[string][]byte{"one": []byte("1"), "b": []byte("2"), "c": []byte("3")}}
+	tests := []struct {
+		desc            string
+		data            []esv1beta1.PushSecretData
+		addErr          error
+		expectedPayload string
+		expectedAdds    int
+		expectedErr     string
+	}{
+		{
+			desc: "all properties are written in one version",
+			data: []esv1beta1.PushSecretData{
+				testingfake.PushSecretData{SecretKey: "b", RemoteKey: "db", Property: "b"},
+				testingfake.PushSecretData{SecretKey: "c", RemoteKey: "db", Property: "c"},
+			},
+			expectedPayload: `{"a":"1","b":"2","c":"3"}`,
+			expectedAdds:    1,
+		},
+		{
+			desc: "no version is added if nothing changed",
+			data: []esv1beta1.PushSecretData{
+				testingfake.PushSecretData{SecretKey: "one", RemoteKey: "db", Property: "a"},
+				testingfake.PushSecretData{SecretKey: "one", RemoteKey: "db", Property: "b"},
+			},
+			expectedAdds: 0,
+		},
+		{
+			desc: "failed write adds no version",
+			data: []esv1beta1.PushSecretData{
+				testingfake.PushSecretData{SecretKey: "b", RemoteKey: "db", Property: "b"},
+				testingfake.PushSecretData{SecretKey: "c", RemoteKey: "db", Property: "c"},
+			},
+			addErr:          errors.New("permission denied"),
+			expectedPayload: `{"a":"1","b":"2","c":"3"}`,
+			expectedAdds:    1,
+			expectedErr:     "permission denied",
+		},
+		{
+			desc: "entries without property are rejected",
+			data: []esv1beta1.PushSecretData{
+				testingfake.PushSecretData{SecretKey: "b", RemoteKey: "db", Property: "b"},
+				testingfake.PushSecretData{SecretKey: "c", RemoteKey: "db"},
+			},
+			expectedErr: "must set a property of remote key db",
+		},
+		{
+			desc: "missing secret keys are rejected",
+			data: []esv1beta1.PushSecretData{
+				testingfake.PushSecretData{SecretKey: "d", RemoteKey: "db", Property: "d"},
+			},
+			expectedErr: "secret key d does not exist",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			adds := 0
+			smClient := &fakesm.MockSMClient{
+				AddSecretFn: func(_ context.Context, req *secretmanagerpb.AddSecretVersionRequest, _ ...gax.CallOption) (*secretmanagerpb.SecretVersion, error) {
+					adds++
+					if got := string(req.Payload.Data); got != tc.expectedPayload {
+						t.Errorf("payload does not match: got %s, expected: %s", got, tc.expectedPayload)
+					}
+					return nil, tc.addErr
+				},
+			}
+			smClient.NewGetSecretFn(fakesm.SecretMockReturn{
+				Secret: &secretmanagerpb.Secret{Labels: map[string]string{managedByKey: managedByValue}},
+			})
+			smClient.NewUpdateSecretFn(fakesm.SecretMockReturn{})
+			smClient.NewAccessSecretVersionFn(fakesm.AccessSecretVersionMockReturn{
+				Res: &secretmanagerpb.AccessSecretVersionResponse{
+					Payload: &secretmanagerpb.SecretPayload{Data: []byte(existing)},
+				},
+			})
+			client := Client{
+				smClient: smClient,
+				store:    &esv1beta1.GCPSMProvider{},
+			}
+			err := client.PushSecrets(context.Background(), secret, tc.data)
+			if tc.expectedErr == "" && err != nil {
+				t.Fatalf("PushSecrets returns unexpected error: %v", err)
+			}
+			if tc.expectedErr != "" && (err == nil || !strings.Contains(err.Error(), tc.expectedErr)) {
+				t.Fatalf("PushSecrets returns unexpected error: %v, expected %s", err, tc.expectedErr)
+			}
+			if adds != tc.expectedAdds {
+				t.Errorf("PushSecrets added %d versions, expected %d", adds, tc.expectedAdds)
+			}
+		})
+	}
+}
+
 func TestGetSecretMap(t *testing.T) {
 	// good case: default version & deserialization
 	setDeserialization := func(smtc *secretManagerTestCase) {
@@ -1330,3 +1425,93 @@ func TestValidateStore(t *testing.T) {
 		})
 	}
 }
+
+func TestClassifyError(t *testing.T) {
+	apiErr, _ := apierror.FromError(status.Error(codes.PermissionDenied, "Permission 'secretmanager.versions.access' denied"))
+	tests := []struct {
+		name string
+		err  error
+		want esv1beta1.ProviderErrorReason
+	}{
+		{
+			name: "wrapped API error",
+			err:  fmt.Errorf("unable to access Secret from SecretManager Client: %w", apiErr),
+			want: esv1beta1.ProviderErrorPermissionDenied,
+		},
+		{
+			name: "rate limited",
+			err:  status.Error(codes.ResourceExhausted, "quota exceeded"),
+			want: esv1beta1.ProviderErrorRateLimited,
+		},
+		{
+			name: "unauthenticated",
+			err:  status.Error(codes.Unauthenticated, "invalid credentials"),
+			want: esv1beta1.ProviderErrorUnauthenticated,
+		},
+		{
+			name: "invalid argument",
+			err:  status.Error(codes.InvalidArgument, "invalid name"),
+		},
+		{
+			name: "not a gRPC error",
+			err:  errors.New("boom"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &Provider{}
+			assert.Equal(t, tt.want, p.ClassifyError(tt.err))
+		})
+	}
+}
+
+func TestFindFilter(t *testing.T) {
+	tests := []struct {
+		name string
+		ref  esv1beta1.ExternalSecretFind
+		want string
+	}{
+		{
+			name: "name only",
+			ref:  esv1beta1.ExternalSecretFind{Name: &esv1beta1.FindName{RegExp: "^db-"}},
+			want: "",
+		},
+		{
+			name: "single tag",
+			ref:  esv1beta1.ExternalSecretFind{Tags: map[string]string{"env": "prod"}},
+			want: "labels.env=prod",
+		},
+		{
+			name: "tags are sorted",
+			ref:  esv1beta1.ExternalSecretFind{Tags: map[string]string{"team": "payments", "env": "prod", "app": "api_v2"}},
+			want: "labels.app=api_v2 labels.env=prod labels.team=payments",
+		},
+		{
+			name: "tags and path",
+			ref:  esv1beta1.ExternalSecretFind{Path: pointer.To("db"), Tags: map[string]string{"env": "prod"}},
+			want: "labels.env=prod name:db",
+		},
+		{
+			name: "values are quoted if needed",
+			ref:  esv1beta1.ExternalSecretFind{Path: pointer.To("a b"), Tags: map[string]string{"env": "", "owner": `x"y`}},
+			want: `labels.env="" labels.owner="x\"y" name:"a b"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, findFilter(tt.ref))
+		})
+	}
+}
+
+func TestMatchesFind(t *testing.T) {
+	matcher, err := find.New(esv1beta1.FindName{RegExp: "-db$"})
+	require.NoError(t, err)
+	ref := esv1beta1.ExternalSecretFind{Path: pointer.To("app")}
+	// the name filter matches the path anywhere in the name
+	assert.True(t, matchesFind("app-db", ref, matcher))
+	assert.False(t, matchesFind("other-app-db", ref, matcher))
+	assert.False(t, matchesFind("app-api", ref, matcher))
+	assert.True(t, matchesFind("app-api", ref, nil))
+	assert.True(t, matchesFind("anything", esv1beta1.ExternalSecretFind{}, nil))
+}
@@ -0,0 +1,144 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secretmanager
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"cloud.google.com/go/secretmanager/apiv1/secretmanagerpb"
+	"google.golang.org/api/iterator"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	"github.com/external-secrets/external-secrets/pkg/constants"
+	"github.com/external-secrets/external-secrets/pkg/find"
+	"github.com/external-secrets/external-secrets/pkg/metrics"
+	"github.com/external-secrets/external-secrets/pkg/utils"
+)
+
+var _ esv1beta1.SecretBulkDeleter = &Client{}
+
+// remoteSecret is a secret that is deleted with the etag it was listed with,
+// so a secret that changed in the meantime (e.g. its labels) is not deleted.
+type remoteSecret struct {
+	key  string
+	etag string
+}
+
+// DeleteAllSecrets deletes all secrets matching ref that have the managed-by label.
+func (c *Client) DeleteAllSecrets(ctx context.Context, ref esv1beta1.ExternalSecretFind, dryRun bool) ([]string, error) {
+	if utils.IsNil(c.smClient) || c.store.ProjectID == "" {
+		return nil, errors.New(errUninitalizedGCPProvider)
+	}
+	var matcher *find.Matcher
+	if ref.Name != nil {
+		var err error
+		matcher, err = find.New(*ref.Name)
+		if err != nil {
+			return nil, err
+		}
+	} else if len(ref.Tags) == 0 {
+		return nil, errors.New(errUnexpectedFindOperator)
+	}
+
+	it := c.smClient.ListSecrets(ctx, &secretmanagerpb.ListSecretsRequest{
+		Parent: fmt.Sprintf("projects/%s", c.store.ProjectID),
+		Filter: deleteAllFilter(ref),
+	})
+	var listed []*secretmanagerpb.Secret
+	for {
+		resp, err := it.Next()
+		if errors.Is(err, iterator.Done) {
+			metrics.ObserveAPICall(constants.ProviderGCPSM, constants.CallGCPSMListSecrets, nil)
+			break
+		}
+		if err != nil {
+			metrics.ObserveAPICall(constants.ProviderGCPSM, constants.CallGCPSMListSecrets, err)
+			return nil, fmt.Errorf("failed to list secrets: %w", err)
+		}
+		listed = append(listed, resp)
+	}
+
+	secrets := c.selectManagedSecrets(listed, ref, matcher)
+	keys := make([]string, 0, len(secrets))
+	for _, secret := range secrets {
+		keys = append(keys, secret.key)
+	}
+	if dryRun {
+		return keys, nil
+	}
+	return c.deleteSecrets(ctx, secrets)
+}
+
+// deleteAllFilter narrows down the listed secrets,
+// the labels are checked again by selectManagedSecrets.
+func deleteAllFilter(ref esv1beta1.ExternalSecretFind) string {
+	filter := labelFilter(managedByKey, managedByValue)
+	if f := findFilter(ref); f != "" {
+		filter += " " + f
+	}
+	return filter
+}
+
+// selectManagedSecrets returns the secrets with the managed-by label that match ref, sorted by key.
+func (c *Client) selectManagedSecrets(listed []*secretmanagerpb.Secret, ref esv1beta1.ExternalSecretFind, matcher *find.Matcher) []remoteSecret {
+	var secrets []remoteSecret
+	for _, secret := range listed {
+		labels := secret.GetLabels()
+		if manager, ok := labels[managedByKey]; !ok || manager != managedByValue {
+			continue
+		}
+		key := c.trimName(secret.GetName())
+		if ref.Path != nil && !strings.HasPrefix(key, *ref.Path) {
+			continue
+		}
+		if matcher != nil && !matcher.MatchName(key) {
+			continue
+		}
+		matches := true
+		for k, v := range ref.Tags {
+			if p, ok := labels[k]; !ok || p != v {
+				matches = false
+				break
+			}
+		}
+		if matches {
+			secrets = append(secrets, remoteSecret{key: key, etag: secret.GetEtag()})
+		}
+	}
+	slices.SortFunc(secrets, func(a, b remoteSecret) int {
+		return strings.Compare(a.key, b.key)
+	})
+	return secrets
+}
+
+func (c *Client) deleteSecrets(ctx context.Context, secrets []remoteSecret) ([]string, error) {
+	deleted := make([]string, 0, len(secrets))
+	for _, secret := range secrets {
+		err := c.smClient.DeleteSecret(ctx, &secretmanagerpb.DeleteSecretRequest{
+			Name: fmt.Sprintf("projects/%s/secrets/%s", c.store.ProjectID, secret.key),
+			Etag: secret.etag,
+		})
+		metrics.ObserveAPICall(constants.ProviderGCPSM, constants.CallGCPSMDeleteSecret, err)
+		if err != nil {
+			return deleted, fmt.Errorf("could not delete secret %s: %w", secret.key, err)
+		}
+		deleted = append(deleted, secret.key)
+	}
+	return deleted, nil
+}
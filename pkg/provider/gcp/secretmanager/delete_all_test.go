@@ -0,0 +1,124 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secretmanager
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"cloud.google.com/go/secretmanager/apiv1/secretmanagerpb"
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/gax-go/v2"
+	"k8s.io/utils/ptr"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	"github.com/external-secrets/external-secrets/pkg/find"
+	fakesm "github.com/external-secrets/external-secrets/pkg/provider/gcp/secretmanager/fake"
+)
+
+func TestDeleteAllFilter(t *testing.T) {
+	got := deleteAllFilter(esv1beta1.ExternalSecretFind{
+		Path: ptr.To("app"),
+		Tags: map[string]string{"team": "a", "env": "dev"},
+	})
+	if want := "labels.managed-by=external-secrets labels.env=dev labels.team=a name:app"; got != want {
+		t.Errorf("deleteAllFilter() = %q, want %q", got, want)
+	}
+}
+
+func TestSelectManagedSecrets(t *testing.T) {
+	managed := map[string]string{managedByKey: managedByValue, "team": "a"}
+	// the filter of ListSecrets is not trusted, unmanaged secrets must never be selected
+	listed := []*secretmanagerpb.Secret{
+		{Name: "projects/123/secrets/app-db", Labels: managed, Etag: "1"},
+		{Name: "projects/123/secrets/app-unmanaged", Labels: map[string]string{"team": "a"}},
+		{Name: "projects/123/secrets/app-other-manager", Labels: map[string]string{managedByKey: "someone-else", "team": "a"}},
+		{Name: "projects/123/secrets/app-api", Labels: map[string]string{managedByKey: managedByValue}, Etag: "2"},
+		{Name: "projects/123/secrets/other-db", Labels: managed, Etag: "3"},
+	}
+	matcher, err := find.New(esv1beta1.FindName{RegExp: "^app-"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		ref     esv1beta1.ExternalSecretFind
+		matcher *find.Matcher
+		want    []remoteSecret
+	}{
+		{
+			name:    "name",
+			matcher: matcher,
+			want:    []remoteSecret{{key: "app-api", etag: "2"}, {key: "app-db", etag: "1"}},
+		},
+		{
+			name:    "name and tags",
+			ref:     esv1beta1.ExternalSecretFind{Tags: map[string]string{"team": "a"}},
+			matcher: matcher,
+			want:    []remoteSecret{{key: "app-db", etag: "1"}},
+		},
+		{
+			name: "tags and path",
+			ref:  esv1beta1.ExternalSecretFind{Path: ptr.To("other"), Tags: map[string]string{"team": "a"}},
+			want: []remoteSecret{{key: "other-db", etag: "3"}},
+		},
+	}
+	c := &Client{store: &esv1beta1.GCPSMProvider{ProjectID: "foo"}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := c.selectManagedSecrets(listed, tt.ref, tt.matcher)
+			if diff := cmp.Diff(tt.want, got, cmp.AllowUnexported(remoteSecret{})); diff != "" {
+				t.Errorf("selectManagedSecrets() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDeleteSecrets(t *testing.T) {
+	errBoom := errors.New("boom")
+	var requests []*secretmanagerpb.DeleteSecretRequest
+	c := &Client{
+		store: &esv1beta1.GCPSMProvider{ProjectID: "foo"},
+		smClient: &fakesm.MockSMClient{
+			DeleteSecretFn: func(_ context.Context, req *secretmanagerpb.DeleteSecretRequest, _ ...gax.CallOption) error {
+				requests = append(requests, req)
+				if req.Name == "projects/foo/secrets/b" {
+					return errBoom
+				}
+				return nil
+			},
+		},
+	}
+	deleted, err := c.deleteSecrets(context.Background(), []remoteSecret{{key: "a", etag: "1"}, {key: "b", etag: "2"}, {key: "c", etag: "3"}})
+	if !errors.Is(err, errBoom) {
+		t.Fatalf("deleteSecrets() error = %v, want %v", err, errBoom)
+	}
+	if diff := cmp.Diff([]string{"a"}, deleted); diff != "" {
+		t.Errorf("deleteSecrets() mismatch (-want +got):\n%s", diff)
+	}
+	if len(requests) != 2 || requests[0].Name != "projects/foo/secrets/a" || requests[0].Etag != "1" {
+		t.Errorf("unexpected delete requests %v", requests)
+	}
+}
+
+func TestDeleteAllSecretsRequiresFind(t *testing.T) {
+	c := &Client{store: &esv1beta1.GCPSMProvider{ProjectID: "foo"}, smClient: &fakesm.MockSMClient{}}
+	_, err := c.DeleteAllSecrets(context.Background(), esv1beta1.ExternalSecretFind{Path: ptr.To("app")}, false)
+	if err == nil || err.Error() != errUnexpectedFindOperator {
+		t.Errorf("DeleteAllSecrets() error = %v, want %v", err, errUnexpectedFindOperator)
+	}
+}
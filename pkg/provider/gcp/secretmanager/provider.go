@@ -23,6 +23,9 @@ import (
 	secretmanager "cloud.google.com/go/secretmanager/apiv1"
 	"golang.org/x/oauth2"
 	"google.golang.org/api/option"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	kclient "sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
@@ -37,6 +40,8 @@ type Provider struct{}
 // https://github.com/external-secrets/external-secrets/issues/644
 var _ esv1beta1.SecretsClient = &Client{}
 var _ esv1beta1.Provider = &Provider{}
+var _ esv1beta1.ErrorClassifier = &Provider{}
+var _ esv1beta1.AtomicPushSecretClient = &Client{}
 
 func init() {
 	esv1beta1.Register(&Provider{}, &esv1beta1.SecretStoreProvider{
@@ -57,6 +62,27 @@ func (p *Provider) Capabilities() esv1beta1.SecretStoreCapabilities {
 	return esv1beta1.SecretStoreReadWrite
 }
 
+// ClassifyError maps the gRPC status codes of the Secret Manager API to provider error reasons.
+func (p *Provider) ClassifyError(err error) esv1beta1.ProviderErrorReason {
+	s, ok := status.FromError(err)
+	if !ok {
+		return ""
+	}
+	switch s.Code() {
+	case codes.PermissionDenied:
+		return esv1beta1.ProviderErrorPermissionDenied
+	case codes.NotFound:
+		return esv1beta1.ProviderErrorNotFound
+	case codes.ResourceExhausted:
+		return esv1beta1.ProviderErrorRateLimited
+	case codes.Unauthenticated:
+		return esv1beta1.ProviderErrorUnauthenticated
+	case codes.Unavailable, codes.DeadlineExceeded:
+		return esv1beta1.ProviderErrorUnavailable
+	}
+	return ""
+}
+
 // NewClient constructs a GCP Provider.
 func (p *Provider) NewClient(ctx context.Context, store esv1beta1.GenericStore, kube kclient.Client, namespace string) (esv1beta1.SecretsClient, error) {
 	storeSpec := store.GetSpec()
@@ -104,7 +130,12 @@ func (p *Provider) NewClient(ctx context.Context, store esv1beta1.GenericStore,
 		return nil, fmt.Errorf(errUnableGetCredentials, err)
 	}
 
-	clientGCPSM, err := secretmanager.NewClient(ctx, option.WithTokenSource(ts))
+	opts := []option.ClientOption{option.WithTokenSource(ts)}
+	if gcpStore.Proxy != nil {
+		// the connection to Secret Manager is tunneled through the proxy of the store
+		opts = append(opts, option.WithGRPCDialOption(grpc.WithContextDialer(utils.ProxyDialer(gcpStore.Proxy))))
+	}
+	clientGCPSM, err := secretmanager.NewClient(ctx, opts...)
 	if err != nil {
 		return nil, fmt.Errorf(errUnableCreateGCPSMClient, err)
 	}
@@ -91,11 +91,12 @@ func (b *psBuilder) buildMetadata(_, labels map[string]string, _ []*secretmanage
 	}
 
 	newLabels := map[string]string{}
-	maps.Copy(newLabels, spec.Labels)
 	if spec.MergePolicy == PushSecretMetadataMergePolicyMerge {
-		// Keep labels from the existing GCP Secret Manager Secret
+		// Keep labels from the existing GCP Secret Manager Secret,
+		// the labels of the PushSecret take precedence
 		maps.Copy(newLabels, labels)
 	}
+	maps.Copy(newLabels, spec.Labels)
 	newLabels[managedByKey] = managedByValue
 
 	return spec.Annotations, newLabels, spec.Topics, nil
@@ -154,3 +155,35 @@ func (b *propertyPSBuilder) buildData(original []byte) ([]byte, error) {
 	}
 	return sjson.SetBytes(base, b.pushSecretData.GetProperty(), b.payload)
 }
+
+// batchPSBuilder combines the property builders of one remote secret,
+// so all properties are written in a single secret version.
+type batchPSBuilder struct {
+	builders []pushSecretBuilder
+}
+
+func (b *batchPSBuilder) buildMetadata(annotations, labels map[string]string, topics []*secretmanagerpb.Topic) (map[string]string, map[string]string, []string, error) {
+	// property builders keep the existing metadata, so the first one is representative
+	return b.builders[0].buildMetadata(annotations, labels, topics)
+}
+
+func (b *batchPSBuilder) needUpdate(original []byte) bool {
+	for _, builder := range b.builders {
+		if builder.needUpdate(original) {
+			return true
+		}
+	}
+	return false
+}
+
+func (b *batchPSBuilder) buildData(original []byte) ([]byte, error) {
+	data := original
+	for _, builder := range b.builders {
+		var err error
+		data, err = builder.buildData(data)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return data, nil
+}
@@ -94,6 +94,30 @@ func TestBuildMetadata(t *testing.T) {
 			},
 			expectedTopics: nil,
 		},
+		{
+			name: "merged labels are updated and keep the managed-by label",
+			labels: map[string]string{
+				managedByKey:  managedByValue,
+				"existingKey": "existingValue",
+				"team":        "billing",
+			},
+			metadata: &apiextensionsv1.JSON{
+				Raw: []byte(`{
+					"apiVersion": "kubernetes.external-secrets.io/v1alpha1",
+					"kind": "PushSecretMetadata",
+					"spec": {
+						"labels": {"team":"payments","managed-by":"someone-else"},
+						"mergePolicy": "Merge"
+					}
+				}`),
+			},
+			expectedError: false,
+			expectedLabels: map[string]string{
+				managedByKey:  managedByValue,
+				"existingKey": "existingValue",
+				"team":        "payments",
+			},
+		},
 		{
 			name: "metadata with CMEK key name",
 			labels: map[string]string{
@@ -0,0 +1,101 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secretmanager
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"slices"
+
+	"cloud.google.com/go/secretmanager/apiv1/secretmanagerpb"
+	"google.golang.org/api/iterator"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	"github.com/external-secrets/external-secrets/pkg/constants"
+	"github.com/external-secrets/external-secrets/pkg/metrics"
+	"github.com/external-secrets/external-secrets/pkg/utils"
+)
+
+var _ esv1beta1.SecretVersionLister = &Client{}
+
+// ListSecretVersions lists all versions of a secret,
+// the state of a version (e.g. ENABLED) is returned as attribute.
+func (c *Client) ListSecretVersions(ctx context.Context, ref esv1beta1.ExternalSecretDataRemoteRef) ([]esv1beta1.SecretVersion, error) {
+	if utils.IsNil(c.smClient) || c.store.ProjectID == "" {
+		return nil, errors.New(errUninitalizedGCPProvider)
+	}
+	it := c.smClient.ListSecretVersions(ctx, &secretmanagerpb.ListSecretVersionsRequest{
+		Parent: fmt.Sprintf("projects/%s/secrets/%s", c.store.ProjectID, ref.Key),
+	})
+	var versions []esv1beta1.SecretVersion
+	for {
+		resp, err := it.Next()
+		if errors.Is(err, iterator.Done) {
+			metrics.ObserveAPICall(constants.ProviderGCPSM, constants.CallGCPSMListSecretVersions, nil)
+			break
+		}
+		if err != nil {
+			metrics.ObserveAPICall(constants.ProviderGCPSM, constants.CallGCPSMListSecretVersions, err)
+			return nil, fmt.Errorf("failed to list secret versions: %w", parseError(err))
+		}
+		versions = append(versions, secretVersionFromGCP(resp))
+	}
+	slices.SortStableFunc(versions, func(a, b esv1beta1.SecretVersion) int {
+		return a.CreatedAt.Compare(b.CreatedAt)
+	})
+	return versions, nil
+}
+
+// secretVersionFromGCP converts a version, the name
+// projects/<project>/secrets/<secret>/versions/<version> is trimmed to the version.
+func secretVersionFromGCP(v *secretmanagerpb.SecretVersion) esv1beta1.SecretVersion {
+	version := esv1beta1.SecretVersion{
+		Version:    path.Base(v.GetName()),
+		Attributes: []string{v.GetState().String()},
+	}
+	if v.GetCreateTime() != nil {
+		version.CreatedAt = v.GetCreateTime().AsTime()
+	}
+	return version
+}
+
+var _ esv1beta1.SecretVersionAliasResolver = &Client{}
+
+// ResolveVersionAlias maps a version alias to a version of the secret,
+// previous is the version that was created before the newest one.
+func (c *Client) ResolveVersionAlias(ctx context.Context, ref esv1beta1.ExternalSecretDataRemoteRef) (string, error) {
+	switch ref.Version {
+	case esv1beta1.VersionAliasLatest, esv1beta1.VersionAliasCurrent:
+		return defaultVersion, nil
+	case esv1beta1.VersionAliasPrevious:
+		versions, err := c.ListSecretVersions(ctx, ref)
+		if err != nil {
+			return "", err
+		}
+		return previousVersion(versions)
+	default:
+		return "", fmt.Errorf("%w %s", esv1beta1.ErrVersionAliasNotSupported, ref.Version)
+	}
+}
+
+// previousVersion returns the second newest of the versions sorted from oldest to newest.
+func previousVersion(versions []esv1beta1.SecretVersion) (string, error) {
+	if len(versions) < 2 {
+		return "", errors.New("the secret has no previous version")
+	}
+	return versions[len(versions)-2].Version, nil
+}
@@ -0,0 +1,85 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secretmanager
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/secretmanager/apiv1/secretmanagerpb"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+)
+
+func TestSecretVersionFromGCP(t *testing.T) {
+	created := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name string
+		in   *secretmanagerpb.SecretVersion
+		want esv1beta1.SecretVersion
+	}{
+		{
+			name: "enabled version",
+			in: &secretmanagerpb.SecretVersion{
+				Name:       "projects/123/secrets/db/versions/3",
+				CreateTime: timestamppb.New(created),
+				State:      secretmanagerpb.SecretVersion_ENABLED,
+			},
+			want: esv1beta1.SecretVersion{Version: "3", CreatedAt: created, Attributes: []string{"ENABLED"}},
+		},
+		{
+			name: "destroyed version without create time",
+			in: &secretmanagerpb.SecretVersion{
+				Name:  "projects/123/secrets/db/versions/1",
+				State: secretmanagerpb.SecretVersion_DESTROYED,
+			},
+			want: esv1beta1.SecretVersion{Version: "1", Attributes: []string{"DESTROYED"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if diff := cmp.Diff(tt.want, secretVersionFromGCP(tt.in)); diff != "" {
+				t.Errorf("secretVersionFromGCP() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestResolveVersionAlias(t *testing.T) {
+	c := &Client{store: &esv1beta1.GCPSMProvider{ProjectID: "foo"}}
+	for _, alias := range []string{esv1beta1.VersionAliasLatest, esv1beta1.VersionAliasCurrent} {
+		got, err := c.ResolveVersionAlias(context.Background(), esv1beta1.ExternalSecretDataRemoteRef{Key: "db", Version: alias})
+		if err != nil || got != "latest" {
+			t.Errorf("ResolveVersionAlias(%s) = %q, %v, want latest", alias, got, err)
+		}
+	}
+	if _, err := c.ResolveVersionAlias(context.Background(), esv1beta1.ExternalSecretDataRemoteRef{Key: "db", Version: esv1beta1.VersionAliasPending}); !errors.Is(err, esv1beta1.ErrVersionAliasNotSupported) {
+		t.Errorf("ResolveVersionAlias(pending) error = %v, want %v", err, esv1beta1.ErrVersionAliasNotSupported)
+	}
+}
+
+func TestPreviousVersion(t *testing.T) {
+	got, err := previousVersion([]esv1beta1.SecretVersion{{Version: "1"}, {Version: "2"}, {Version: "5"}})
+	if err != nil || got != "2" {
+		t.Errorf("previousVersion() = %q, %v, want 2", got, err)
+	}
+	if _, err := previousVersion([]esv1beta1.SecretVersion{{Version: "1"}}); err == nil {
+		t.Errorf("previousVersion() returned no error for a single version")
+	}
+}
@@ -16,13 +16,19 @@ package kubernetes
 
 import (
 	"context"
+	"crypto/tls"
 	"errors"
 	"fmt"
+	"net/http"
+	"time"
 
+	"golang.org/x/oauth2"
 	authenticationv1 "k8s.io/api/authentication/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilnet "k8s.io/apimachinery/pkg/util/net"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/client-go/transport"
 
 	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
 	esmeta "github.com/external-secrets/external-secrets/apis/meta/v1"
@@ -32,6 +38,12 @@ import (
 
 const (
 	errUnableCreateToken = "cannot create service account token: %q"
+
+	// tokenExpirationSeconds is the requested lifetime of service account tokens.
+	tokenExpirationSeconds = int64(3600)
+	// tokenEarlyExpiry is the time before the expiry of a token with audiences
+	// at which a new token is requested.
+	tokenEarlyExpiry = 5 * time.Minute
 )
 
 func (c *Client) getAuth(ctx context.Context) (*rest.Config, error) {
@@ -64,8 +76,9 @@ func (c *Client) getAuth(ctx context.Context) (*rest.Config, error) {
 	}
 
 	cfg.TLSClientConfig = rest.TLSClientConfig{
-		Insecure: false,
-		CAData:   ca,
+		Insecure:   false,
+		CAData:     ca,
+		ServerName: c.store.Server.ServerName,
 	}
 
 	switch {
@@ -76,6 +89,13 @@ func (c *Client) getAuth(ctx context.Context) (*rest.Config, error) {
 		}
 
 		cfg.BearerToken = string(token)
+	case c.store.Auth.ServiceAccount != nil && len(c.store.Auth.ServiceAccount.Audiences) > 0:
+		ts, err := c.serviceAccountTokenSource(ctx, c.store.Auth.ServiceAccount)
+		if err != nil {
+			return nil, fmt.Errorf("could not fetch Auth.ServiceAccount: %w", err)
+		}
+
+		cfg.WrapTransport = transport.TokenSourceWrapTransport(ts)
 	case c.store.Auth.ServiceAccount != nil:
 		token, err := c.serviceAccountToken(ctx, c.store.Auth.ServiceAccount)
 		if err != nil {
@@ -98,6 +118,37 @@ func (c *Client) getAuth(ctx context.Context) (*rest.Config, error) {
 	return cfg, nil
 }
 
+// pinTLS replaces the transport of cfg with one that refuses the connection
+// unless the certificate chain of the API server contains one of server.tlsPinnedKeys.
+// client-go does not expose the verification of its transport, so the TLS config
+// is built from cfg and the TLS options of cfg are cleared.
+func (c *Client) pinTLS(cfg *rest.Config) error {
+	pins := c.store.Server.TLSPinnedKeys
+	if len(pins) == 0 {
+		return nil
+	}
+	tlsCfg, err := rest.TLSConfigFor(cfg)
+	if err != nil {
+		return err
+	}
+	if tlsCfg == nil {
+		tlsCfg = &tls.Config{MinVersion: tls.VersionTLS12}
+	}
+	if err := utils.PinTLSConfig(tlsCfg, pins); err != nil {
+		return err
+	}
+	proxy := cfg.Proxy
+	if proxy == nil {
+		proxy = http.ProxyFromEnvironment
+	}
+	cfg.Transport = utilnet.SetTransportDefaults(&http.Transport{
+		Proxy:           proxy,
+		TLSClientConfig: tlsCfg,
+	})
+	cfg.TLSClientConfig = rest.TLSClientConfig{}
+	return nil
+}
+
 func (c *Client) getClientKeyAndCert(ctx context.Context) ([]byte, []byte, error) {
 	var err error
 	cert, err := c.fetchSecretKey(ctx, c.store.Auth.Cert.ClientCert)
@@ -112,12 +163,45 @@ func (c *Client) getClientKeyAndCert(ctx context.Context) ([]byte, []byte, error
 }
 
 func (c *Client) serviceAccountToken(ctx context.Context, serviceAccountRef *esmeta.ServiceAccountSelector) ([]byte, error) {
+	token, err := c.createServiceAccountToken(ctx, serviceAccountRef)
+	if err != nil {
+		return nil, err
+	}
+	return []byte(token.AccessToken), nil
+}
+
+// serviceAccountTokenSource returns a token source for a service account with audiences.
+// The first token is requested immediately, a new token is requested
+// tokenEarlyExpiry plus the clock skew of the store before the current token expires.
+func (c *Client) serviceAccountTokenSource(ctx context.Context, serviceAccountRef *esmeta.ServiceAccountSelector) (oauth2.TokenSource, error) {
+	token, err := c.createServiceAccountToken(ctx, serviceAccountRef)
+	if err != nil {
+		return nil, err
+	}
+	return oauth2.ReuseTokenSourceWithExpiry(token, &serviceAccountTokenSource{
+		client:            c,
+		serviceAccountRef: serviceAccountRef,
+	}, tokenEarlyExpiry+c.clockSkew), nil
+}
+
+// serviceAccountTokenSource requests a new service account token on every call.
+type serviceAccountTokenSource struct {
+	client            *Client
+	serviceAccountRef *esmeta.ServiceAccountSelector
+}
+
+func (s *serviceAccountTokenSource) Token() (*oauth2.Token, error) {
+	// the token source outlives the context of the client creation
+	return s.client.createServiceAccountToken(context.Background(), s.serviceAccountRef)
+}
+
+func (c *Client) createServiceAccountToken(ctx context.Context, serviceAccountRef *esmeta.ServiceAccountSelector) (*oauth2.Token, error) {
 	namespace := c.namespace
 	if (c.storeKind == esv1beta1.ClusterSecretStoreKind) &&
 		(serviceAccountRef.Namespace != nil) {
 		namespace = *serviceAccountRef.Namespace
 	}
-	expirationSeconds := int64(3600)
+	expirationSeconds := tokenExpirationSeconds
 	tr, err := c.ctrlClientset.ServiceAccounts(namespace).CreateToken(ctx, serviceAccountRef.Name, &authenticationv1.TokenRequest{
 		Spec: authenticationv1.TokenRequestSpec{
 			Audiences:         serviceAccountRef.Audiences,
@@ -127,7 +211,10 @@ func (c *Client) serviceAccountToken(ctx context.Context, serviceAccountRef *esm
 	if err != nil {
 		return nil, fmt.Errorf(errUnableCreateToken, err)
 	}
-	return []byte(tr.Status.Token), nil
+	return &oauth2.Token{
+		AccessToken: tr.Status.Token,
+		Expiry:      tr.Status.ExpirationTimestamp.Time,
+	}, nil
 }
 
 func (c *Client) fetchSecretKey(ctx context.Context, ref esmeta.SecretKeySelector) ([]byte, error) {
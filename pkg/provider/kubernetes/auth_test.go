@@ -16,7 +16,11 @@ package kubernetes
 
 import (
 	"context"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
@@ -30,6 +34,7 @@ import (
 	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
 	v1 "github.com/external-secrets/external-secrets/apis/meta/v1"
 	utilfake "github.com/external-secrets/external-secrets/pkg/provider/util/fake"
+	"github.com/external-secrets/external-secrets/pkg/utils"
 )
 
 const (
@@ -388,3 +393,200 @@ func TestSetAuth(t *testing.T) {
 		})
 	}
 }
+
+func TestServiceAccountTokenWithAudiences(t *testing.T) {
+	tests := []struct {
+		name          string
+		expiration    time.Duration
+		clockSkew     time.Duration
+		wantRefreshes int
+	}{
+		{
+			name:          "token is reused until shortly before expiry",
+			expiration:    time.Hour,
+			wantRefreshes: 0,
+		},
+		{
+			name:          "token is refreshed before expiry",
+			expiration:    tokenEarlyExpiry - time.Minute,
+			wantRefreshes: 1,
+		},
+		{
+			name:          "token is refreshed earlier with clock skew",
+			expiration:    tokenEarlyExpiry + time.Minute,
+			clockSkew:     2 * time.Minute,
+			wantRefreshes: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clientset := utilfake.NewCreateTokenMock().WithToken("my-sa-token").WithExpiration(tt.expiration)
+			k := &Client{
+				ctrlClientset: clientset,
+				namespace:     "default",
+				clockSkew:     tt.clockSkew,
+				store: &esv1beta1.KubernetesProvider{
+					Server: esv1beta1.KubernetesServer{
+						URL:      "https://my.test.tld",
+						CABundle: []byte(caCert),
+					},
+					Auth: esv1beta1.KubernetesAuth{
+						ServiceAccount: &v1.ServiceAccountSelector{
+							Name:      "my-sa",
+							Audiences: []string{"api.my.test.tld", "vault"},
+						},
+					},
+				},
+			}
+			cfg, err := k.getAuth(context.Background())
+			assert.NoError(t, err)
+			assert.Empty(t, cfg.BearerToken)
+			assert.NotNil(t, cfg.WrapTransport)
+
+			assert.Len(t, clientset.Requests, 1)
+			assert.Equal(t, []string{"api.my.test.tld", "vault"}, clientset.Requests[0].Spec.Audiences)
+
+			// the token source of the config is not exposed, use a new one
+			ts, err := k.serviceAccountTokenSource(context.Background(), k.store.Auth.ServiceAccount)
+			assert.NoError(t, err)
+			clientset.Requests = nil
+			token, err := ts.Token()
+			assert.NoError(t, err)
+			assert.Equal(t, "my-sa-token", token.AccessToken)
+			assert.Len(t, clientset.Requests, tt.wantRefreshes)
+			for _, req := range clientset.Requests {
+				assert.Equal(t, []string{"api.my.test.tld", "vault"}, req.Spec.Audiences)
+			}
+		})
+	}
+}
+
+func TestServerName(t *testing.T) {
+	// the test certificate is valid for 127.0.0.1 and example.com, but not for kubernetes.internal
+	var serverName string
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		serverName = req.TLS.ServerName
+	}))
+	defer ts.Close()
+	ca := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: ts.Certificate().Raw})
+
+	tests := []struct {
+		name       string
+		serverName string
+		wantErr    string
+	}{
+		{
+			name:       "trusts the custom CA and sends the server name",
+			serverName: "example.com",
+		},
+		{
+			name: "trusts the custom CA without server name",
+		},
+		{
+			name:       "verifies the certificate against the server name",
+			serverName: "kubernetes.internal",
+			wantErr:    "certificate is valid for",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			serverName = ""
+			k := &Client{
+				namespace: "default",
+				ctrlClient: fclient.NewClientBuilder().WithObjects(&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Name: "foobar", Namespace: "default"},
+					Data:       map[string][]byte{"token": []byte("mytoken")},
+				}).Build(),
+				store: &esv1beta1.KubernetesProvider{
+					Server: esv1beta1.KubernetesServer{
+						URL:        ts.URL,
+						CABundle:   ca,
+						ServerName: tt.serverName,
+					},
+					Auth: esv1beta1.KubernetesAuth{
+						Token: &esv1beta1.TokenAuth{
+							BearerToken: v1.SecretKeySelector{Name: "foobar", Key: "token"},
+						},
+					},
+				},
+			}
+			cfg, err := k.getAuth(context.Background())
+			assert.NoError(t, err)
+			assert.Equal(t, tt.serverName, cfg.TLSClientConfig.ServerName)
+
+			httpClient, err := rest.HTTPClientFor(cfg)
+			assert.NoError(t, err)
+			resp, err := httpClient.Get(ts.URL)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			resp.Body.Close()
+			assert.Equal(t, tt.serverName, serverName)
+		})
+	}
+}
+
+func TestPinTLS(t *testing.T) {
+	var authorization string
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(_ http.ResponseWriter, req *http.Request) {
+		authorization = req.Header.Get("Authorization")
+	}))
+	defer ts.Close()
+	ca := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: ts.Certificate().Raw})
+
+	tests := []struct {
+		name    string
+		pins    []string
+		wantErr error
+	}{
+		{
+			name: "accepts the pinned key",
+			pins: []string{utils.TLSKeyPin(ts.Certificate())},
+		},
+		{
+			name:    "refuses a certificate of a trusted CA with another key",
+			pins:    []string{"sha256/AAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA="},
+			wantErr: esv1beta1.ErrTLSPinMismatch,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			authorization = ""
+			k := &Client{
+				namespace: "default",
+				ctrlClient: fclient.NewClientBuilder().WithObjects(&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Name: "foobar", Namespace: "default"},
+					Data:       map[string][]byte{"token": []byte("mytoken")},
+				}).Build(),
+				store: &esv1beta1.KubernetesProvider{
+					Server: esv1beta1.KubernetesServer{
+						URL:           ts.URL,
+						CABundle:      ca,
+						TLSPinnedKeys: tt.pins,
+					},
+					Auth: esv1beta1.KubernetesAuth{
+						Token: &esv1beta1.TokenAuth{
+							BearerToken: v1.SecretKeySelector{Name: "foobar", Key: "token"},
+						},
+					},
+				},
+			}
+			cfg, err := k.getAuth(context.Background())
+			assert.NoError(t, err)
+			assert.NoError(t, k.pinTLS(cfg))
+
+			httpClient, err := rest.HTTPClientFor(cfg)
+			assert.NoError(t, err)
+			resp, err := httpClient.Get(ts.URL)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			resp.Body.Close()
+			assert.Equal(t, "Bearer mytoken", authorization)
+		})
+	}
+}
@@ -278,7 +278,7 @@ func TestGetSecret(t *testing.T) {
 				Key:      "mysec",
 				Property: "token",
 			},
-			wantErr: `Secret "secret" not found`,
+			wantErr: esv1beta1.NoSecretErr.Error(),
 		},
 		{
 			desc: "secret data with wrong property",
@@ -354,8 +354,9 @@ func TestGetSecretMap(t *testing.T) {
 		fields fields
 		ref    esv1beta1.ExternalSecretDataRemoteRef
 
-		want    map[string][]byte
-		wantErr bool
+		want      map[string][]byte
+		wantErr   bool
+		wantErrIs error
 	}{
 		{
 			name: "successful case metadata without property",
@@ -425,6 +426,21 @@ func TestGetSecretMap(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "error case secret not found",
+			fields: fields{
+				Client: &fakeClient{
+					t:         t,
+					secretMap: map[string]*v1.Secret{},
+				},
+				Namespace: "default",
+			},
+			ref: esv1beta1.ExternalSecretDataRemoteRef{
+				Key: "mysec",
+			},
+			wantErr:   true,
+			wantErrIs: esv1beta1.NoSecretErr,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -434,6 +450,9 @@ func TestGetSecretMap(t *testing.T) {
 				namespace:        tt.fields.Namespace,
 			}
 			got, err := p.GetSecretMap(context.Background(), tt.ref)
+			if tt.wantErrIs != nil && !errors.Is(err, tt.wantErrIs) {
+				t.Errorf("ProviderKubernetes.GetSecretMap() error = %v, want %v", err, tt.wantErrIs)
+			}
 			if (err != nil) != tt.wantErr {
 				t.Errorf("ProviderKubernetes.GetSecretMap() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -18,9 +18,11 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	authv1 "k8s.io/api/authorization/v1"
 	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
 	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
@@ -28,11 +30,13 @@ import (
 	ctrlcfg "sigs.k8s.io/controller-runtime/pkg/client/config"
 
 	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	"github.com/external-secrets/external-secrets/pkg/utils/clockskew"
 )
 
 // https://github.com/external-secrets/external-secrets/issues/644
 var _ esv1beta1.SecretsClient = &Client{}
 var _ esv1beta1.Provider = &Provider{}
+var _ esv1beta1.ErrorClassifier = &Provider{}
 
 type KClient interface {
 	Get(ctx context.Context, name string, opts metav1.GetOptions) (*v1.Secret, error)
@@ -70,6 +74,8 @@ type Client struct {
 	// which contains the configuration for this provider.
 	store     *esv1beta1.KubernetesProvider
 	storeKind string
+	// clockSkew is the tolerated difference to the clock of the token issuer.
+	clockSkew time.Duration
 
 	// namespace is the namespace of the
 	// ExternalSecret referencing this provider.
@@ -86,6 +92,23 @@ func (p *Provider) Capabilities() esv1beta1.SecretStoreCapabilities {
 	return esv1beta1.SecretStoreReadWrite
 }
 
+// ClassifyError maps the errors of the Kubernetes API to provider error reasons.
+func (p *Provider) ClassifyError(err error) esv1beta1.ProviderErrorReason {
+	switch {
+	case apierrors.IsForbidden(err):
+		return esv1beta1.ProviderErrorPermissionDenied
+	case apierrors.IsNotFound(err):
+		return esv1beta1.ProviderErrorNotFound
+	case apierrors.IsTooManyRequests(err):
+		return esv1beta1.ProviderErrorRateLimited
+	case apierrors.IsUnauthorized(err):
+		return esv1beta1.ProviderErrorUnauthenticated
+	case apierrors.IsServiceUnavailable(err), apierrors.IsTimeout(err), apierrors.IsServerTimeout(err):
+		return esv1beta1.ProviderErrorUnavailable
+	}
+	return ""
+}
+
 // NewClient constructs a Kubernetes Provider.
 func (p *Provider) NewClient(ctx context.Context, store esv1beta1.GenericStore, kube kclient.Client, namespace string) (esv1beta1.SecretsClient, error) {
 	restCfg, err := ctrlcfg.GetConfig()
@@ -111,6 +134,7 @@ func (p *Provider) newClient(ctx context.Context, store esv1beta1.GenericStore,
 		store:         storeSpecKubernetes,
 		namespace:     namespace,
 		storeKind:     store.GetObjectKind().GroupVersionKind().Kind,
+		clockSkew:     clockskew.ForStore(store),
 	}
 
 	// allow SecretStore controller validation to pass
@@ -123,6 +147,9 @@ func (p *Provider) newClient(ctx context.Context, store esv1beta1.GenericStore,
 	if err != nil {
 		return nil, fmt.Errorf("failed to prepare auth: %w", err)
 	}
+	if err := client.pinTLS(cfg); err != nil {
+		return nil, fmt.Errorf("failed to pin TLS keys: %w", err)
+	}
 
 	userClientset, err := kubernetes.NewForConfig(cfg)
 	if err != nil {
@@ -16,10 +16,13 @@ package kubernetes
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
 	clientgofake "k8s.io/client-go/kubernetes/fake"
@@ -267,3 +270,42 @@ func TestNewClient(t *testing.T) {
 		})
 	}
 }
+
+func TestClassifyError(t *testing.T) {
+	secrets := corev1.Resource("secrets")
+	tests := []struct {
+		name string
+		err  error
+		want esv1beta1.ProviderErrorReason
+	}{
+		{
+			name: "forbidden",
+			err:  fmt.Errorf("reading secret: %w", apierrors.NewForbidden(secrets, "foo", errors.New("no RBAC"))),
+			want: esv1beta1.ProviderErrorPermissionDenied,
+		},
+		{
+			name: "unauthorized",
+			err:  apierrors.NewUnauthorized("token expired"),
+			want: esv1beta1.ProviderErrorUnauthenticated,
+		},
+		{
+			name: "too many requests",
+			err:  apierrors.NewTooManyRequests("slow down", 1),
+			want: esv1beta1.ProviderErrorRateLimited,
+		},
+		{
+			name: "invalid",
+			err:  apierrors.NewBadRequest("invalid"),
+		},
+		{
+			name: "not an API error",
+			err:  errors.New("boom"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &Provider{}
+			assert.Equal(t, tt.want, p.ClassifyError(tt.err))
+		})
+	}
+}
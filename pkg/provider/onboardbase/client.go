@@ -69,6 +69,13 @@ type SecretsClientInterface interface {
 }
 
 func (c *Client) setAuth(ctx context.Context) error {
+	// the API key and the passcode are read from the same secret
+	if err := utils.ValidateNoStoreRef(c.store.Auth.OnboardbaseAPIKeyRef); err != nil {
+		return err
+	}
+	if err := utils.ValidateNoStoreRef(c.store.Auth.OnboardbasePasscodeRef); err != nil {
+		return err
+	}
 	credentialsSecret := &corev1.Secret{}
 	credentialsSecretName := c.store.Auth.OnboardbaseAPIKeyRef.Name
 	if credentialsSecretName == "" {
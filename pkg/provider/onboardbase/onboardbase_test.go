@@ -331,6 +331,14 @@ func TestValidateStore(t *testing.T) {
 			store: makeSecretStore(withAuth(secretName, "", &namespace, "passcode")),
 			err:   errors.New("invalid store: namespace should either be empty or match the namespace of the SecretStore for a namespaced SecretStore"),
 		},
+		{
+			label: "invalid store storeRef not supported",
+			store: makeSecretStore(withAuth(secretName, "", nil, "passcode"), func(store *esv1beta1.SecretStore) *esv1beta1.SecretStore {
+				store.Spec.Provider.Onboardbase.Auth.OnboardbaseAPIKeyRef.StoreRef = &v1.StoreSecretRef{Name: "bootstrap", RemoteKey: "onboardbase"}
+				return store
+			}),
+			err: errors.New("invalid store: storeRef is not supported by this provider, the credentials must be read from a Kubernetes Secret"),
+		},
 		{
 			label: "valid provide optional onboardbaseAPIKey.key",
 			store: makeSecretStore(withAuth(secretName, "customSecretKey", nil, "passcode")),
@@ -89,6 +89,9 @@ func (p *Provider) ValidateStore(store esv1beta1.GenericStore) (admission.Warnin
 	if err := utils.ValidateSecretSelector(store, onboardbaseAPIKeySecretRef); err != nil {
 		return nil, fmt.Errorf(errInvalidStore, err)
 	}
+	if err := utils.ValidateNoStoreRef(onboardbaseAPIKeySecretRef); err != nil {
+		return nil, fmt.Errorf(errInvalidStore, err)
+	}
 
 	if onboardbaseAPIKeySecretRef.Name == "" {
 		return nil, fmt.Errorf(errInvalidStore, "onboardbaseAPIKey.name cannot be empty")
@@ -98,6 +101,9 @@ func (p *Provider) ValidateStore(store esv1beta1.GenericStore) (admission.Warnin
 	if err := utils.ValidateSecretSelector(store, onboardbasePasscodeKeySecretRef); err != nil {
 		return nil, fmt.Errorf(errInvalidStore, err)
 	}
+	if err := utils.ValidateNoStoreRef(onboardbasePasscodeKeySecretRef); err != nil {
+		return nil, fmt.Errorf(errInvalidStore, err)
+	}
 
 	if onboardbasePasscodeKeySecretRef.Name == "" {
 		return nil, fmt.Errorf(errInvalidStore, "onboardbasePasscode.name cannot be empty")
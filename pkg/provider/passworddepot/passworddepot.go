@@ -48,8 +48,13 @@ type PasswordDepot struct {
 	database string
 }
 
-func (p *PasswordDepot) ValidateStore(esv1beta1.GenericStore) (admission.Warnings, error) {
-	return nil, nil
+func (p *PasswordDepot) ValidateStore(store esv1beta1.GenericStore) (admission.Warnings, error) {
+	storeSpec := store.GetSpec()
+	if storeSpec == nil || storeSpec.Provider == nil || storeSpec.Provider.PasswordDepot == nil {
+		return nil, nil
+	}
+	// username and password are read from the same secret
+	return nil, utils.ValidateNoStoreRef(storeSpec.Provider.PasswordDepot.Auth.SecretRef.Credentials)
 }
 
 func (p *PasswordDepot) Capabilities() esv1beta1.SecretStoreCapabilities {
@@ -66,6 +71,9 @@ type passwordDepotClient struct {
 type Provider struct{}
 
 func (c *passwordDepotClient) getAuth(ctx context.Context) (string, string, error) {
+	if err := utils.ValidateNoStoreRef(c.store.Auth.SecretRef.Credentials); err != nil {
+		return "", "", err
+	}
 	credentialsSecret := &corev1.Secret{}
 	credentialsSecretName := c.store.Auth.SecretRef.Credentials.Name
 	if credentialsSecretName == "" {
@@ -0,0 +1,267 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	corev1 "k8s.io/api/core/v1"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	"github.com/external-secrets/external-secrets/pkg/find"
+	"github.com/external-secrets/external-secrets/pkg/utils"
+)
+
+const (
+	errQuery           = "unable to query table %s: %w"
+	errMultipleRows    = "key %q matches more than one row of table %s, keyColumn must be unique"
+	errUnsupportedFind = "unsupported find operator: %#v"
+	errPing            = "unable to connect to the database: %w"
+)
+
+// validateTimeout limits the connection check of Validate.
+var validateTimeout = 5 * time.Second
+
+var (
+	errNotImplemented        = errors.New("not implemented")
+	errVersionUnsupported    = errors.New("remoteRef.version is not supported")
+	errPropertyUnsupported   = errors.New("remoteRef.property is not supported when fetching all columns of a row")
+	errUnsupportedColumnType = errors.New("unsupported column type")
+)
+
+// Client reads the rows of a PostgreSQL table.
+// Every query is parameterized, the remote key is never part of the SQL statement.
+type Client struct {
+	db        *sql.DB
+	table     string
+	keyColumn string
+	// selectRow and selectLike select the key column followed by the secret columns
+	selectRow  string
+	selectLike string
+}
+
+func newClient(db *sql.DB, prov *esv1beta1.PostgreSQLProvider) *Client {
+	// identifiers are quoted, so they can not be used to inject SQL either
+	table, _ := tableIdentifier(prov.Table)
+	keyColumn := pgx.Identifier{prov.KeyColumn}.Sanitize()
+	columns := "*"
+	if len(prov.Columns) > 0 {
+		quoted := make([]string, 0, len(prov.Columns))
+		for _, column := range prov.Columns {
+			quoted = append(quoted, pgx.Identifier{column}.Sanitize())
+		}
+		columns = strings.Join(quoted, ", ")
+	}
+	selectFrom := fmt.Sprintf("SELECT %s, %s FROM %s", keyColumn, columns, table.Sanitize())
+	return &Client{
+		db:         db,
+		table:      prov.Table,
+		keyColumn:  prov.KeyColumn,
+		selectRow:  selectFrom + fmt.Sprintf(" WHERE %s = $1 LIMIT 2", keyColumn),
+		selectLike: selectFrom + fmt.Sprintf(` WHERE %s LIKE $1 ESCAPE '\'`, keyColumn),
+	}
+}
+
+// GetSecret returns the row of the key as JSON object of its columns, or the column of remoteRef.property.
+func (c *Client) GetSecret(ctx context.Context, ref esv1beta1.ExternalSecretDataRemoteRef) ([]byte, error) {
+	row, err := c.getRow(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+	if ref.Property != "" {
+		value, ok := row[ref.Property]
+		if !ok {
+			return nil, esv1beta1.NoSecretErr
+		}
+		return value, nil
+	}
+	return rowJSON(row)
+}
+
+// GetSecretMap returns the columns of the row of the key as secret keys.
+func (c *Client) GetSecretMap(ctx context.Context, ref esv1beta1.ExternalSecretDataRemoteRef) (map[string][]byte, error) {
+	if ref.Property != "" {
+		return nil, errPropertyUnsupported
+	}
+	return c.getRow(ctx, ref)
+}
+
+func (c *Client) getRow(ctx context.Context, ref esv1beta1.ExternalSecretDataRemoteRef) (map[string][]byte, error) {
+	if ref.Version != "" {
+		return nil, errVersionUnsupported
+	}
+	rows, err := c.query(ctx, c.selectRow, ref.Key)
+	if err != nil {
+		return nil, err
+	}
+	switch len(rows) {
+	case 0:
+		return nil, esv1beta1.NoSecretErr
+	case 1:
+		return rows[0].columns, nil
+	default:
+		return nil, fmt.Errorf(errMultipleRows, ref.Key, c.table)
+	}
+}
+
+// GetAllSecrets returns the rows whose key starts with find.path and matches find.name,
+// each row as JSON object of its columns. Tags are not supported.
+func (c *Client) GetAllSecrets(ctx context.Context, ref esv1beta1.ExternalSecretFind) (map[string][]byte, error) {
+	if len(ref.Tags) > 0 || (ref.Name == nil && ref.Path == nil) {
+		return nil, fmt.Errorf(errUnsupportedFind, ref)
+	}
+	var prefix string
+	if ref.Path != nil {
+		prefix = *ref.Path
+	}
+	var matcher *find.Matcher
+	if ref.Name != nil {
+		var err error
+		matcher, err = find.New(*ref.Name)
+		if err != nil {
+			return nil, err
+		}
+	}
+	rows, err := c.query(ctx, c.selectLike, escapeLike(prefix)+"%")
+	if err != nil {
+		return nil, err
+	}
+	data := make(map[string][]byte, len(rows))
+	for _, r := range rows {
+		if matcher != nil && !matcher.MatchName(r.key) {
+			continue
+		}
+		value, err := rowJSON(r.columns)
+		if err != nil {
+			return nil, err
+		}
+		data[r.key] = value
+	}
+	return utils.ConvertKeys(ref.ConversionStrategy, data)
+}
+
+// escapeLike escapes the wildcards of LIKE, so the prefix is matched literally.
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
+
+type row struct {
+	key     string
+	columns map[string][]byte
+}
+
+// query runs the statement with the argument and returns the key and the non-NULL columns of every row.
+func (c *Client) query(ctx context.Context, statement, arg string) ([]row, error) {
+	rows, err := c.db.QueryContext(ctx, statement, arg)
+	if err != nil {
+		return nil, fmt.Errorf(errQuery, c.table, err)
+	}
+	defer rows.Close()
+	names, err := rows.Columns()
+	if err != nil {
+		return nil, fmt.Errorf(errQuery, c.table, err)
+	}
+
+	var out []row
+	for rows.Next() {
+		values := make([]any, len(names))
+		dest := make([]any, len(names))
+		for i := range values {
+			dest[i] = &values[i]
+		}
+		if err := rows.Scan(dest...); err != nil {
+			return nil, fmt.Errorf(errQuery, c.table, err)
+		}
+		key, err := columnValue(values[0])
+		if err != nil {
+			return nil, fmt.Errorf(errQuery, c.table, err)
+		}
+		r := row{key: string(key), columns: make(map[string][]byte, len(names)-1)}
+		for i := 1; i < len(names); i++ {
+			// with all columns the key column is selected twice
+			if names[i] == c.keyColumn || values[i] == nil {
+				continue
+			}
+			value, err := columnValue(values[i])
+			if err != nil {
+				return nil, fmt.Errorf("%s column %s: %w", c.table, names[i], err)
+			}
+			r.columns[names[i]] = value
+		}
+		out = append(out, r)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf(errQuery, c.table, err)
+	}
+	return out, nil
+}
+
+// columnValue converts a value scanned by database/sql to bytes.
+func columnValue(value any) ([]byte, error) {
+	switch v := value.(type) {
+	case []byte:
+		return v, nil
+	case string:
+		return []byte(v), nil
+	case time.Time:
+		return []byte(v.Format(time.RFC3339Nano)), nil
+	case int64, float64, bool:
+		return []byte(fmt.Sprint(v)), nil
+	case nil:
+		return nil, nil
+	}
+	return nil, fmt.Errorf("%w %T", errUnsupportedColumnType, value)
+}
+
+func rowJSON(columns map[string][]byte) ([]byte, error) {
+	obj := make(map[string]string, len(columns))
+	for name, value := range columns {
+		obj[name] = string(value)
+	}
+	return json.Marshal(obj)
+}
+
+func (c *Client) PushSecret(_ context.Context, _ *corev1.Secret, _ esv1beta1.PushSecretData) error {
+	return errNotImplemented
+}
+
+func (c *Client) DeleteSecret(_ context.Context, _ esv1beta1.PushSecretRemoteRef) error {
+	return errNotImplemented
+}
+
+func (c *Client) SecretExists(_ context.Context, _ esv1beta1.PushSecretRemoteRef) (bool, error) {
+	return false, errNotImplemented
+}
+
+// Validate checks that the database can be reached with the configured credentials.
+func (c *Client) Validate() (esv1beta1.ValidationResult, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), validateTimeout)
+	defer cancel()
+	if err := c.db.PingContext(ctx); err != nil {
+		return esv1beta1.ValidationResultError, fmt.Errorf(errPing, err)
+	}
+	return esv1beta1.ValidationResultReady, nil
+}
+
+func (c *Client) Close(_ context.Context) error {
+	return c.db.Close()
+}
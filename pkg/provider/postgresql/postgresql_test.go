@@ -0,0 +1,474 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package postgresql
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	esmeta "github.com/external-secrets/external-secrets/apis/meta/v1"
+)
+
+const (
+	selectRow  = `SELECT "name", "username", "password" FROM "app"."credentials" WHERE "name" = $1 LIMIT 2`
+	selectLike = `SELECT "name", "username", "password" FROM "app"."credentials" WHERE "name" LIKE $1 ESCAPE '\'`
+)
+
+func testProvider() *esv1beta1.PostgreSQLProvider {
+	return &esv1beta1.PostgreSQLProvider{
+		Host:     "db.example.com",
+		Database: "app",
+		Auth: esv1beta1.PostgreSQLAuth{
+			Username:          "eso",
+			PasswordSecretRef: esmeta.SecretKeySelector{Name: "db-credentials", Key: "password"},
+		},
+		Table:     "app.credentials",
+		KeyColumn: "name",
+		Columns:   []string{"username", "password"},
+	}
+}
+
+func newMockClient(t *testing.T, prov *esv1beta1.PostgreSQLProvider) (*Client, sqlmock.Sqlmock) {
+	t.Helper()
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("unmet expectations: %v", err)
+		}
+		db.Close()
+	})
+	return newClient(db, prov), mock
+}
+
+func credentialRows() *sqlmock.Rows {
+	return sqlmock.NewRows([]string{"name", "username", "password"})
+}
+
+func TestNewClientQueries(t *testing.T) {
+	c, _ := newMockClient(t, testProvider())
+	if c.selectRow != selectRow {
+		t.Errorf("unexpected row query: %s", c.selectRow)
+	}
+	if c.selectLike != selectLike {
+		t.Errorf("unexpected LIKE query: %s", c.selectLike)
+	}
+
+	// identifiers are quoted, so they can not inject SQL
+	prov := testProvider()
+	prov.Table = `creds"; DROP TABLE users; --`
+	prov.Columns = nil
+	c, _ = newMockClient(t, prov)
+	want := `SELECT "name", * FROM "creds""; DROP TABLE users; --" WHERE "name" = $1 LIMIT 2`
+	if c.selectRow != want {
+		t.Errorf("unexpected row query with all columns: %s", c.selectRow)
+	}
+}
+
+func TestGetSecret(t *testing.T) {
+	tests := []struct {
+		name    string
+		ref     esv1beta1.ExternalSecretDataRemoteRef
+		rows    *sqlmock.Rows
+		want    string
+		wantErr error
+	}{
+		{
+			name: "row as JSON",
+			ref:  esv1beta1.ExternalSecretDataRemoteRef{Key: "billing"},
+			rows: credentialRows().AddRow("billing", "admin", "s3cr3t"),
+			want: `{"password":"s3cr3t","username":"admin"}`,
+		},
+		{
+			name: "property selects a column",
+			ref:  esv1beta1.ExternalSecretDataRemoteRef{Key: "billing", Property: "password"},
+			rows: credentialRows().AddRow("billing", "admin", "s3cr3t"),
+			want: "s3cr3t",
+		},
+		{
+			name:    "NULL column is missing",
+			ref:     esv1beta1.ExternalSecretDataRemoteRef{Key: "billing", Property: "password"},
+			rows:    credentialRows().AddRow("billing", "admin", nil),
+			wantErr: esv1beta1.NoSecretErr,
+		},
+		{
+			name:    "missing row",
+			ref:     esv1beta1.ExternalSecretDataRemoteRef{Key: "billing"},
+			rows:    credentialRows(),
+			wantErr: esv1beta1.NoSecretErr,
+		},
+		{
+			name: "key is passed as parameter",
+			ref:  esv1beta1.ExternalSecretDataRemoteRef{Key: "x' OR '1'='1", Property: "username"},
+			rows: credentialRows(),
+			// the injected condition is compared as value, so no row matches
+			wantErr: esv1beta1.NoSecretErr,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, mock := newMockClient(t, testProvider())
+			mock.ExpectQuery(selectRow).WithArgs(tt.ref.Key).WillReturnRows(tt.rows)
+			got, err := c.GetSecret(context.Background(), tt.ref)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("GetSecret() error = %v, want %v", err, tt.wantErr)
+			}
+			if string(got) != tt.want {
+				t.Errorf("GetSecret() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetSecretErrors(t *testing.T) {
+	c, mock := newMockClient(t, testProvider())
+	mock.ExpectQuery(selectRow).WithArgs("billing").
+		WillReturnRows(credentialRows().AddRow("billing", "a", "b").AddRow("billing", "c", "d"))
+	if _, err := c.GetSecret(context.Background(), esv1beta1.ExternalSecretDataRemoteRef{Key: "billing"}); err == nil {
+		t.Error("expected an error for a key that matches several rows")
+	}
+
+	mock.ExpectQuery(selectRow).WithArgs("billing").WillReturnError(errors.New("connection refused"))
+	if _, err := c.GetSecret(context.Background(), esv1beta1.ExternalSecretDataRemoteRef{Key: "billing"}); err == nil || errors.Is(err, esv1beta1.NoSecretErr) {
+		t.Errorf("expected the query error, got %v", err)
+	}
+
+	if _, err := c.GetSecret(context.Background(), esv1beta1.ExternalSecretDataRemoteRef{Key: "billing", Version: "1"}); !errors.Is(err, errVersionUnsupported) {
+		t.Errorf("expected errVersionUnsupported, got %v", err)
+	}
+}
+
+func TestGetSecretMap(t *testing.T) {
+	prov := testProvider()
+	prov.Columns = nil
+	c, mock := newMockClient(t, prov)
+	// with all columns the key column is selected twice and only returned as key
+	mock.ExpectQuery(`SELECT "name", * FROM "app"."credentials" WHERE "name" = $1 LIMIT 2`).WithArgs("billing").
+		WillReturnRows(sqlmock.NewRows([]string{"name", "name", "username", "port", "rotated_at", "comment"}).
+			AddRow("billing", "billing", "admin", int64(5432), time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC), nil))
+	got, err := c.GetSecretMap(context.Background(), esv1beta1.ExternalSecretDataRemoteRef{Key: "billing"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := map[string]string{"username": "admin", "port": "5432", "rotated_at": "2024-01-02T03:04:05Z"}
+	if diff := cmp.Diff(want, stringMap(got)); diff != "" {
+		t.Errorf("unexpected secret map (-want +got):\n%s", diff)
+	}
+
+	mock.ExpectQuery(`SELECT "name", * FROM "app"."credentials" WHERE "name" = $1 LIMIT 2`).WithArgs("missing").
+		WillReturnRows(sqlmock.NewRows([]string{"name"}))
+	if _, err := c.GetSecretMap(context.Background(), esv1beta1.ExternalSecretDataRemoteRef{Key: "missing"}); !errors.Is(err, esv1beta1.NoSecretErr) {
+		t.Errorf("expected NoSecretErr, got %v", err)
+	}
+
+	if _, err := c.GetSecretMap(context.Background(), esv1beta1.ExternalSecretDataRemoteRef{Key: "billing", Property: "username"}); !errors.Is(err, errPropertyUnsupported) {
+		t.Errorf("expected errPropertyUnsupported, got %v", err)
+	}
+}
+
+func TestGetAllSecrets(t *testing.T) {
+	tests := []struct {
+		name    string
+		find    esv1beta1.ExternalSecretFind
+		pattern string
+		want    map[string]string
+	}{
+		{
+			name:    "path is a literal prefix",
+			find:    esv1beta1.ExternalSecretFind{Path: ptr.To(`team_a%\`)},
+			pattern: `team\_a\%\\%`,
+			want: map[string]string{
+				`team_a%\db`:  `{"password":"p1","username":"u1"}`,
+				`team_a%\api`: `{"password":"p2","username":"u2"}`,
+			},
+		},
+		{
+			name:    "name filters the rows",
+			find:    esv1beta1.ExternalSecretFind{Name: &esv1beta1.FindName{RegExp: "db$"}},
+			pattern: "%",
+			want: map[string]string{
+				`team_a%\db`: `{"password":"p1","username":"u1"}`,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, mock := newMockClient(t, testProvider())
+			mock.ExpectQuery(selectLike).WithArgs(tt.pattern).
+				WillReturnRows(credentialRows().AddRow(`team_a%\db`, "u1", "p1").AddRow(`team_a%\api`, "u2", "p2"))
+			got, err := c.GetAllSecrets(context.Background(), tt.find)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tt.want, stringMap(got)); diff != "" {
+				t.Errorf("unexpected secrets (-want +got):\n%s", diff)
+			}
+		})
+	}
+
+	c, _ := newMockClient(t, testProvider())
+	if _, err := c.GetAllSecrets(context.Background(), esv1beta1.ExternalSecretFind{Tags: map[string]string{"a": "b"}}); err == nil {
+		t.Error("expected an error for find by tags")
+	}
+}
+
+func TestValidate(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.MonitorPingsOption(true))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	c := newClient(db, testProvider())
+
+	mock.ExpectPing()
+	if result, err := c.Validate(); err != nil || result != esv1beta1.ValidationResultReady {
+		t.Errorf("Validate() = %v, %v, want Ready", result, err)
+	}
+	mock.ExpectPing().WillReturnError(errors.New("password authentication failed"))
+	if result, err := c.Validate(); err == nil || result != esv1beta1.ValidationResultError {
+		t.Errorf("Validate() = %v, %v, want Error", result, err)
+	}
+}
+
+func TestValidateStore(t *testing.T) {
+	tests := []struct {
+		name    string
+		kind    string
+		modify  func(*esv1beta1.PostgreSQLProvider)
+		wantErr error
+	}{
+		{
+			name:   "valid store",
+			modify: func(*esv1beta1.PostgreSQLProvider) {},
+		},
+		{
+			name:    "missing host",
+			modify:  func(p *esv1beta1.PostgreSQLProvider) { p.Host = "" },
+			wantErr: errMissingHost,
+		},
+		{
+			name:    "invalid port",
+			modify:  func(p *esv1beta1.PostgreSQLProvider) { p.Port = 70000 },
+			wantErr: errInvalidPort,
+		},
+		{
+			name:    "invalid sslMode",
+			modify:  func(p *esv1beta1.PostgreSQLProvider) { p.SSLMode = "prefer" },
+			wantErr: errInvalidSSLMode,
+		},
+		{
+			name:    "missing password",
+			modify:  func(p *esv1beta1.PostgreSQLProvider) { p.Auth.PasswordSecretRef = esmeta.SecretKeySelector{} },
+			wantErr: errMissingPassword,
+		},
+		{
+			name:    "table with too many parts",
+			modify:  func(p *esv1beta1.PostgreSQLProvider) { p.Table = "db.app.credentials" },
+			wantErr: errInvalidTable,
+		},
+		{
+			name:    "empty schema",
+			modify:  func(p *esv1beta1.PostgreSQLProvider) { p.Table = ".credentials" },
+			wantErr: errInvalidTable,
+		},
+		{
+			name:    "missing key column",
+			modify:  func(p *esv1beta1.PostgreSQLProvider) { p.KeyColumn = "" },
+			wantErr: errMissingKeyColumn,
+		},
+		{
+			name:    "empty column",
+			modify:  func(p *esv1beta1.PostgreSQLProvider) { p.Columns = []string{"username", ""} },
+			wantErr: errEmptyColumn,
+		},
+		{
+			name:    "caProvider without namespace in ClusterSecretStore",
+			kind:    esv1beta1.ClusterSecretStoreKind,
+			modify:  func(p *esv1beta1.PostgreSQLProvider) { p.CAProvider = &esv1beta1.CAProvider{Name: "ca", Key: "ca.crt"} },
+			wantErr: errCANamespace,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prov := testProvider()
+			tt.modify(prov)
+			spec := esv1beta1.SecretStoreSpec{Provider: &esv1beta1.SecretStoreProvider{PostgreSQL: prov}}
+			var store esv1beta1.GenericStore = &esv1beta1.SecretStore{Spec: spec}
+			if tt.kind == esv1beta1.ClusterSecretStoreKind {
+				// a ClusterSecretStore must set the namespace of the password
+				prov.Auth.PasswordSecretRef.Namespace = ptr.To("default")
+				store = &esv1beta1.ClusterSecretStore{Spec: spec}
+			}
+			_, err := (&Provider{}).ValidateStore(store)
+			if tt.wantErr == nil && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("ValidateStore() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestNewClient(t *testing.T) {
+	kube := fakeclient.NewClientBuilder().WithObjects(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "db-credentials", Namespace: "default"},
+		Data:       map[string][]byte{"password": []byte("s3cr3t")},
+	}).Build()
+	store := &esv1beta1.SecretStore{
+		ObjectMeta: metav1.ObjectMeta{Name: "postgresql", Namespace: "default"},
+		Spec:       esv1beta1.SecretStoreSpec{Provider: &esv1beta1.SecretStoreProvider{PostgreSQL: testProvider()}},
+	}
+	// the connection is opened lazily, so the client is created without a server
+	client, err := (&Provider{}).NewClient(context.Background(), store, kube, "default")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := client.Close(context.Background()); err != nil {
+		t.Errorf("unexpected error on close: %v", err)
+	}
+
+	store.Spec.Provider.PostgreSQL.Auth.PasswordSecretRef.Name = "missing"
+	if _, err := (&Provider{}).NewClient(context.Background(), store, kube, "default"); err == nil {
+		t.Error("expected an error for a missing password secret")
+	}
+}
+
+func TestNewTLSConfig(t *testing.T) {
+	caPEM, serverDER := newTestCertificates(t)
+	tests := []struct {
+		name           string
+		mode           esv1beta1.PostgreSQLSSLMode
+		wantNil        bool
+		wantSkipVerify bool
+		wantServerName bool
+	}{
+		{name: "disable", mode: esv1beta1.PostgreSQLSSLModeDisable, wantNil: true},
+		{name: "require", mode: esv1beta1.PostgreSQLSSLModeRequire, wantSkipVerify: true},
+		{name: "verify-ca", mode: esv1beta1.PostgreSQLSSLModeVerifyCA, wantSkipVerify: true},
+		{name: "verify-full is the default", wantServerName: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prov := testProvider()
+			prov.SSLMode = tt.mode
+			prov.CABundle = caPEM
+			cfg, err := newTLSConfig(context.Background(), prov, nil, esv1beta1.SecretStoreKind, "default")
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tt.wantNil {
+				if cfg != nil {
+					t.Errorf("expected no TLS config, got %+v", cfg)
+				}
+				return
+			}
+			if cfg.InsecureSkipVerify != tt.wantSkipVerify {
+				t.Errorf("InsecureSkipVerify = %v, want %v", cfg.InsecureSkipVerify, tt.wantSkipVerify)
+			}
+			if tt.wantServerName && (cfg.ServerName != prov.Host || cfg.RootCAs == nil) {
+				t.Errorf("expected the host name to be verified with the CA, got %+v", cfg)
+			}
+		})
+	}
+
+	// verify-ca accepts a certificate of the CA for another host name, but no other certificate
+	prov := testProvider()
+	prov.SSLMode = esv1beta1.PostgreSQLSSLModeVerifyCA
+	prov.CABundle = caPEM
+	cfg, err := newTLSConfig(context.Background(), prov, nil, esv1beta1.SecretStoreKind, "default")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := cfg.VerifyPeerCertificate([][]byte{serverDER}, nil); err != nil {
+		t.Errorf("expected the certificate of the CA to be accepted: %v", err)
+	}
+	otherCA, _ := newTestCertificates(t)
+	prov.CABundle = otherCA
+	cfg, err = newTLSConfig(context.Background(), prov, nil, esv1beta1.SecretStoreKind, "default")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := cfg.VerifyPeerCertificate([][]byte{serverDER}, nil); err == nil {
+		t.Error("expected a certificate of another CA to be rejected")
+	}
+}
+
+// newTestCertificates returns a CA in PEM format and a server certificate of the CA for another host.
+func newTestCertificates(t *testing.T) ([]byte, []byte) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	caTmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "postgresql-ca"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}
+	caDER, err := x509.CreateCertificate(rand.Reader, caTmpl, caTmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ca, err := x509.ParseCertificate(caDER)
+	if err != nil {
+		t.Fatal(err)
+	}
+	serverTmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: "postgresql"},
+		DNSNames:     []string{"other-host"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	serverDER, err := x509.CreateCertificate(rand.Reader, serverTmpl, ca, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: caDER}), serverDER
+}
+
+func stringMap(data map[string][]byte) map[string]string {
+	out := make(map[string]string, len(data))
+	for k, v := range data {
+		out[k] = string(v)
+	}
+	return out
+}
@@ -0,0 +1,264 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package postgresql implements a provider that reads secrets from the rows of a PostgreSQL table.
+package postgresql
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/stdlib"
+	kclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	"github.com/external-secrets/external-secrets/pkg/utils"
+	"github.com/external-secrets/external-secrets/pkg/utils/resolvers"
+)
+
+const (
+	defaultPort    = 5432
+	connectTimeout = 10 * time.Second
+	// maxOpenConns limits the connections of a client, the queries of one ExternalSecret run sequentially.
+	maxOpenConns = 2
+
+	errConfig       = "unable to configure the connection: %w"
+	errFetchCA      = "unable to fetch the CA certificate: %w"
+	errInvalidCA    = "unable to parse the CA certificate"
+	errGetPassword  = "unable to read the password: %w"
+	errInvalidStore = "invalid store: %w"
+)
+
+var (
+	errMissingStore     = errors.New("missing store provider")
+	errMissingProvider  = errors.New("missing store provider postgresql")
+	errMissingHost      = errors.New("host must not be empty")
+	errMissingDatabase  = errors.New("database must not be empty")
+	errMissingUsername  = errors.New("auth.username must not be empty")
+	errMissingPassword  = errors.New("name and key of auth.passwordSecretRef must not be empty")
+	errInvalidPort      = errors.New("port must be between 1 and 65535")
+	errInvalidSSLMode   = errors.New("sslMode must be disable, require, verify-ca or verify-full")
+	errInvalidTable     = errors.New("table must be a table name, optionally qualified with its schema")
+	errMissingKeyColumn = errors.New("keyColumn must not be empty")
+	errEmptyColumn      = errors.New("columns must not contain empty names")
+	errCANamespace      = errors.New("caProvider.namespace must not be empty with ClusterSecretStore")
+)
+
+// Provider is a PostgreSQL provider implementing NewClient and ValidateStore for the esv1beta1.Provider interface.
+type Provider struct{}
+
+var _ esv1beta1.SecretsClient = &Client{}
+var _ esv1beta1.Provider = &Provider{}
+
+func init() {
+	esv1beta1.Register(&Provider{}, &esv1beta1.SecretStoreProvider{
+		PostgreSQL: &esv1beta1.PostgreSQLProvider{},
+	})
+}
+
+// Capabilities return the provider supported capabilities (ReadOnly, WriteOnly, ReadWrite).
+func (p *Provider) Capabilities() esv1beta1.SecretStoreCapabilities {
+	return esv1beta1.SecretStoreReadOnly
+}
+
+func (p *Provider) NewClient(ctx context.Context, store esv1beta1.GenericStore, kube kclient.Client, namespace string) (esv1beta1.SecretsClient, error) {
+	prov, err := getProvider(store)
+	if err != nil {
+		return nil, err
+	}
+	password, err := resolvers.SecretKeyRef(ctx, kube, store.GetKind(), namespace, &prov.Auth.PasswordSecretRef)
+	if err != nil {
+		return nil, fmt.Errorf(errGetPassword, err)
+	}
+	tlsConfig, err := newTLSConfig(ctx, prov, kube, store.GetKind(), namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	// the connection is configured from the store only, environment variables and files of libpq are ignored
+	cfg, err := pgx.ParseConfig("")
+	if err != nil {
+		return nil, fmt.Errorf(errConfig, err)
+	}
+	cfg.Host = prov.Host
+	cfg.Port = uint16(portOrDefault(prov.Port)) //nolint:gosec // the port is validated by ValidateStore
+	cfg.Database = prov.Database
+	cfg.User = prov.Auth.Username
+	cfg.Password = password
+	cfg.TLSConfig = tlsConfig
+	cfg.Fallbacks = nil
+	cfg.ConnectTimeout = connectTimeout
+	cfg.RuntimeParams = map[string]string{"application_name": "external-secrets"}
+
+	db := stdlib.OpenDB(*cfg)
+	db.SetMaxOpenConns(maxOpenConns)
+	return newClient(db, prov), nil
+}
+
+func portOrDefault(port int) int {
+	if port == 0 {
+		return defaultPort
+	}
+	return port
+}
+
+// newTLSConfig returns the TLS config for the sslMode of the store, it is nil with disable.
+// Like libpq, require encrypts the connection without verifying the server,
+// verify-ca verifies the certificate chain and verify-full the host name as well.
+func newTLSConfig(ctx context.Context, prov *esv1beta1.PostgreSQLProvider, kube kclient.Client, storeKind, namespace string) (*tls.Config, error) {
+	mode := prov.SSLMode
+	if mode == "" {
+		mode = esv1beta1.PostgreSQLSSLModeVerifyFull
+	}
+	if mode == esv1beta1.PostgreSQLSSLModeDisable {
+		return nil, nil
+	}
+
+	cfg := &tls.Config{MinVersion: tls.VersionTLS12, ServerName: prov.Host}
+	if mode == esv1beta1.PostgreSQLSSLModeRequire {
+		cfg.InsecureSkipVerify = true //nolint:gosec // sslMode require does not verify the server
+		return cfg, nil
+	}
+
+	ca, err := utils.FetchCACertFromSource(ctx, utils.CreateCertOpts{
+		CABundle:   prov.CABundle,
+		CAProvider: prov.CAProvider,
+		StoreKind:  storeKind,
+		Namespace:  namespace,
+		Client:     kube,
+	})
+	if err != nil {
+		return nil, fmt.Errorf(errFetchCA, err)
+	}
+	// without a CA the certificate is verified with the system roots
+	if len(ca) > 0 {
+		cfg.RootCAs = x509.NewCertPool()
+		if !cfg.RootCAs.AppendCertsFromPEM(ca) {
+			return nil, errors.New(errInvalidCA)
+		}
+	}
+	if mode == esv1beta1.PostgreSQLSSLModeVerifyCA {
+		// the chain is verified below, without the host name
+		cfg.InsecureSkipVerify = true //nolint:gosec // the chain is verified by VerifyPeerCertificate
+		cfg.VerifyPeerCertificate = verifyChain(cfg.RootCAs)
+	}
+	return cfg, nil
+}
+
+// verifyChain returns a function that verifies the certificate chain of the server against roots,
+// without verifying the host name.
+func verifyChain(roots *x509.CertPool) func([][]byte, [][]*x509.Certificate) error {
+	return func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+		if len(rawCerts) == 0 {
+			return errors.New("the server did not present a certificate")
+		}
+		certs := make([]*x509.Certificate, 0, len(rawCerts))
+		for _, raw := range rawCerts {
+			cert, err := x509.ParseCertificate(raw)
+			if err != nil {
+				return err
+			}
+			certs = append(certs, cert)
+		}
+		intermediates := x509.NewCertPool()
+		for _, cert := range certs[1:] {
+			intermediates.AddCert(cert)
+		}
+		_, err := certs[0].Verify(x509.VerifyOptions{Roots: roots, Intermediates: intermediates})
+		return err
+	}
+}
+
+func getProvider(store esv1beta1.GenericStore) (*esv1beta1.PostgreSQLProvider, error) {
+	if store == nil {
+		return nil, errMissingStore
+	}
+	spc := store.GetSpec()
+	if spc == nil || spc.Provider == nil || spc.Provider.PostgreSQL == nil {
+		return nil, errMissingProvider
+	}
+	return spc.Provider.PostgreSQL, nil
+}
+
+func (p *Provider) ValidateStore(store esv1beta1.GenericStore) (admission.Warnings, error) {
+	prov, err := getProvider(store)
+	if err != nil {
+		return nil, err
+	}
+	var errs error
+	if prov.Host == "" {
+		errs = errors.Join(errs, errMissingHost)
+	}
+	if prov.Port < 0 || prov.Port > 65535 {
+		errs = errors.Join(errs, errInvalidPort)
+	}
+	if prov.Database == "" {
+		errs = errors.Join(errs, errMissingDatabase)
+	}
+	switch prov.SSLMode {
+	case "", esv1beta1.PostgreSQLSSLModeDisable, esv1beta1.PostgreSQLSSLModeRequire,
+		esv1beta1.PostgreSQLSSLModeVerifyCA, esv1beta1.PostgreSQLSSLModeVerifyFull:
+	default:
+		errs = errors.Join(errs, errInvalidSSLMode)
+	}
+	if prov.Auth.Username == "" {
+		errs = errors.Join(errs, errMissingUsername)
+	}
+	if ref := prov.Auth.PasswordSecretRef; ref.StoreRef == nil && (ref.Name == "" || ref.Key == "") {
+		errs = errors.Join(errs, errMissingPassword)
+	} else if err := utils.ValidateSecretSelector(store, ref); err != nil {
+		errs = errors.Join(errs, err)
+	}
+	if _, err := tableIdentifier(prov.Table); err != nil {
+		errs = errors.Join(errs, err)
+	}
+	if prov.KeyColumn == "" {
+		errs = errors.Join(errs, errMissingKeyColumn)
+	}
+	for _, column := range prov.Columns {
+		if column == "" {
+			errs = errors.Join(errs, errEmptyColumn)
+			break
+		}
+	}
+	if store.GetKind() == esv1beta1.ClusterSecretStoreKind &&
+		prov.CAProvider != nil && prov.CAProvider.Namespace == nil {
+		errs = errors.Join(errs, errCANamespace)
+	}
+	if errs != nil {
+		return nil, fmt.Errorf(errInvalidStore, errs)
+	}
+	return nil, nil
+}
+
+// tableIdentifier splits the table into its schema and name, e.g. app.credentials.
+func tableIdentifier(table string) (pgx.Identifier, error) {
+	parts := strings.Split(table, ".")
+	if len(parts) > 2 {
+		return nil, errInvalidTable
+	}
+	for _, part := range parts {
+		if part == "" {
+			return nil, errInvalidTable
+		}
+	}
+	return pgx.Identifier(parts), nil
+}
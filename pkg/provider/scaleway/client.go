@@ -501,3 +501,17 @@ func splitNameAndPath(ref string) (name, path string, ok bool) {
 	ok = true
 	return
 }
+
+var _ esv1beta1.SecretVersionAliasResolver = &client{}
+
+// ResolveVersionAlias maps a version alias to a revision, previous revisions can not be addressed.
+func (c *client) ResolveVersionAlias(_ context.Context, ref esv1beta1.ExternalSecretDataRemoteRef) (string, error) {
+	switch ref.Version {
+	case esv1beta1.VersionAliasLatest:
+		return "latest", nil
+	case esv1beta1.VersionAliasCurrent:
+		return "latest_enabled", nil
+	default:
+		return "", fmt.Errorf("%w %s", esv1beta1.ErrVersionAliasNotSupported, ref.Version)
+	}
+}
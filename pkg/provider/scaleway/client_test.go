@@ -479,3 +479,18 @@ func TestSplitNameAndPath(t *testing.T) {
 		})
 	}
 }
+
+func TestResolveVersionAlias(t *testing.T) {
+	c := newTestClient()
+	tests := map[string]string{
+		esv1beta1.VersionAliasLatest:  "latest",
+		esv1beta1.VersionAliasCurrent: "latest_enabled",
+	}
+	for alias, want := range tests {
+		got, err := esv1beta1.ResolveVersionAlias(context.Background(), c, esv1beta1.ExternalSecretDataRemoteRef{Version: alias})
+		assert.NoError(t, err)
+		assert.Equal(t, want, got)
+	}
+	_, err := esv1beta1.ResolveVersionAlias(context.Background(), c, esv1beta1.ExternalSecretDataRemoteRef{Version: esv1beta1.VersionAliasPrevious})
+	assert.ErrorIs(t, err, esv1beta1.ErrVersionAliasNotSupported)
+}
@@ -18,7 +18,9 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/DelineaXPM/tss-sdk-go/v2/server"
 	"github.com/tidwall/gjson"
@@ -157,10 +159,20 @@ func (c *client) getSecret(_ context.Context, ref esv1beta1.ExternalSecretDataRe
 			return nil, err
 		}
 		if len(s) == 0 {
-			return nil, errors.New("unable to retrieve secret at this time")
+			return nil, esv1beta1.NoSecretError{}
 		}
 
 		return &s[0], nil
 	}
-	return c.api.Secret(id)
+	secret, err := c.api.Secret(id)
+	if isNotFoundError(err) {
+		return nil, esv1beta1.NoSecretError{}
+	}
+	return secret, err
+}
+
+// isNotFoundError reports whether err is a 404 response.
+// The SDK only exposes the HTTP status as the prefix of the error message.
+func isNotFoundError(err error) bool {
+	return err != nil && strings.HasPrefix(err.Error(), strconv.Itoa(http.StatusNotFound)+" ")
 }
@@ -28,7 +28,8 @@ import (
 )
 
 var (
-	errNotFound = errors.New("not found")
+	// errNotFound is formatted like the errors of the SDK.
+	errNotFound = errors.New("404 Not Found: secret not found")
 )
 
 type fakeAPI struct {
@@ -52,7 +53,7 @@ func (f *fakeAPI) Secrets(searchText, _ string) ([]server.Secret, error) {
 			return secret, nil
 		}
 	}
-	return nil, errNotFound
+	return []server.Secret{}, nil
 }
 
 // createSecret assembles a server.Secret from file test_data.json.
@@ -123,7 +124,7 @@ func TestGetSecretSecretServer(t *testing.T) {
 				Key: "0",
 			},
 			want: []byte(nil),
-			err:  errNotFound,
+			err:  esv1beta1.NoSecretError{},
 		},
 		"key = 'secret name' and user property returns a single value": {
 			ref: esv1beta1.ExternalSecretDataRemoteRef{
@@ -185,7 +186,7 @@ func TestGetSecretSecretServer(t *testing.T) {
 				Property: "password",
 			},
 			want: []byte(nil),
-			err:  errNotFound,
+			err:  esv1beta1.NoSecretError{},
 		},
 		"Secret from code: 'name' found and non-existent attribute slug returns noSecretError": {
 			ref: esv1beta1.ExternalSecretDataRemoteRef{
@@ -212,3 +213,50 @@ func TestGetSecretSecretServer(t *testing.T) {
 		})
 	}
 }
+
+func TestGetSecretMapSecretServer(t *testing.T) {
+	ctx := context.Background()
+	c := newTestClient()
+
+	testCases := map[string]struct {
+		ref  esv1beta1.ExternalSecretDataRemoteRef
+		want map[string][]byte
+		err  error
+	}{
+		"existent key returns the map": {
+			ref: esv1beta1.ExternalSecretDataRemoteRef{
+				Key: "1000",
+			},
+			want: map[string][]byte{
+				"user":     []byte("robertOppenheimer"),
+				"password": []byte("badPassword"),
+				"server":   []byte("192.168.1.50"),
+			},
+		},
+		"non-existing id returns noSecretError": {
+			ref: esv1beta1.ExternalSecretDataRemoteRef{
+				Key: "0",
+			},
+			err: esv1beta1.NoSecretError{},
+		},
+		"non-existing 'name' returns noSecretError": {
+			ref: esv1beta1.ExternalSecretDataRemoteRef{
+				Key: "Secretnameerror",
+			},
+			err: esv1beta1.NoSecretError{},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got, err := c.GetSecretMap(ctx, tc.ref)
+			if tc.err == nil {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.want, got)
+			} else {
+				assert.Nil(t, got)
+				assert.ErrorIs(t, err, tc.err)
+			}
+		})
+	}
+}
@@ -150,8 +150,10 @@ func (dsm *DSM) GetSecretMap(_ context.Context, ref esv1beta1.ExternalSecretData
 		return secretData, err
 	}
 
+	found := false
 	for _, v := range appSecrets.Application.Secrets {
 		if v.Identity == ref.Key {
+			found = true
 			for _, v2 := range v.Data {
 				for k, v3 := range v2 {
 					secretData[k] = []byte(v3)
@@ -159,6 +161,9 @@ func (dsm *DSM) GetSecretMap(_ context.Context, ref esv1beta1.ExternalSecretData
 			}
 		}
 	}
+	if !found {
+		return nil, esv1beta1.NoSecretErr
+	}
 	return secretData, nil
 }
 
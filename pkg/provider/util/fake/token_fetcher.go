@@ -16,6 +16,7 @@ package fake
 
 import (
 	"context"
+	"time"
 
 	authv1 "k8s.io/api/authentication/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -30,8 +31,12 @@ func NewCreateTokenMock() *MockK8sV1 {
 type MockK8sV1 struct {
 	k8sv1.CoreV1Interface
 
-	token string
-	err   error
+	token      string
+	expiration time.Duration
+	err        error
+
+	// Requests records the received token requests.
+	Requests []*authv1.TokenRequest
 }
 
 func (m *MockK8sV1) WithToken(token string) *MockK8sV1 {
@@ -39,6 +44,12 @@ func (m *MockK8sV1) WithToken(token string) *MockK8sV1 {
 	return m
 }
 
+// WithExpiration sets the lifetime of the returned tokens.
+func (m *MockK8sV1) WithExpiration(expiration time.Duration) *MockK8sV1 {
+	m.expiration = expiration
+	return m
+}
+
 func (m *MockK8sV1) WithError(err error) *MockK8sV1 {
 	m.err = err
 	return m
@@ -57,15 +68,20 @@ type MockK8sV1SA struct {
 func (ma *MockK8sV1SA) CreateToken(
 	_ context.Context,
 	_ string,
-	_ *authv1.TokenRequest,
+	tr *authv1.TokenRequest,
 	_ metav1.CreateOptions,
 ) (*authv1.TokenRequest, error) {
+	ma.v1mock.Requests = append(ma.v1mock.Requests, tr)
 	if ma.v1mock.err != nil {
 		return nil, ma.v1mock.err
 	}
+	status := authv1.TokenRequestStatus{
+		Token: ma.v1mock.token,
+	}
+	if ma.v1mock.expiration != 0 {
+		status.ExpirationTimestamp = metav1.NewTime(time.Now().Add(ma.v1mock.expiration))
+	}
 	return &authv1.TokenRequest{
-		Status: authv1.TokenRequestStatus{
-			Token: ma.v1mock.token,
-		},
+		Status: status,
 	}, nil
 }
@@ -35,6 +35,7 @@ import (
 )
 
 var _ esv1beta1.SecretsClient = &client{}
+var _ esv1beta1.AtomicPushSecretClient = &client{}
 
 type client struct {
 	kube      kclient.Client
@@ -47,15 +48,23 @@ type client struct {
 	token     util.Token
 	namespace string
 	storeKind string
+	// tlsVersion identifies the TLS material of the transport.
+	tlsVersion string
 }
 
 func (c *client) newConfig(ctx context.Context) (*vault.Config, error) {
 	cfg := vault.DefaultConfig()
 	cfg.Address = c.store.Server
+	configureTransport(cfg)
+	if transport, ok := cfg.HttpClient.Transport.(*http.Transport); ok && c.store.Proxy != nil {
+		transport.Proxy = utils.ProxyFunc(c.store.Proxy)
+	}
 
+	var ca []byte
 	if len(c.store.CABundle) != 0 || c.store.CAProvider != nil {
 		caCertPool := x509.NewCertPool()
-		ca, err := utils.FetchCACertFromSource(ctx, utils.CreateCertOpts{
+		var err error
+		ca, err = utils.FetchCACertFromSource(ctx, utils.CreateCertOpts{
 			CABundle:   c.store.CABundle,
 			CAProvider: c.store.CAProvider,
 			StoreKind:  c.storeKind,
@@ -74,11 +83,20 @@ func (c *client) newConfig(ctx context.Context) (*vault.Config, error) {
 			transport.TLSClientConfig.RootCAs = caCertPool
 		}
 	}
+	if transport, ok := cfg.HttpClient.Transport.(*http.Transport); ok && c.store.ServerName != "" {
+		transport.TLSClientConfig.ServerName = c.store.ServerName
+	}
+	if transport, ok := cfg.HttpClient.Transport.(*http.Transport); ok {
+		if err := utils.PinTLSConfig(transport.TLSClientConfig, c.store.TLSPinnedKeys); err != nil {
+			return nil, err
+		}
+	}
 
 	err := c.configureClientTLS(ctx, cfg)
 	if err != nil {
 		return nil, err
 	}
+	c.tlsVersion = tlsVersion(ca, cfg)
 
 	// If either read-after-write consistency feature is enabled, enable ReadYourWrites
 	cfg.ReadYourWrites = c.store.ReadYourWrites || c.store.ForwardInconsistent
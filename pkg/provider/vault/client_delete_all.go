@@ -0,0 +1,97 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vault
+
+import (
+	"context"
+	"errors"
+	"slices"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	"github.com/external-secrets/external-secrets/pkg/find"
+)
+
+const errDeleteAllFind = "find.name or find.tags must be set to delete secrets"
+
+var _ esv1beta1.SecretBulkDeleter = &client{}
+
+// DeleteAllSecrets deletes all secrets matching ref that have the managed-by custom_metadata.
+func (c *client) DeleteAllSecrets(ctx context.Context, ref esv1beta1.ExternalSecretFind, dryRun bool) ([]string, error) {
+	if c.store.Version == esv1beta1.VaultKVStoreV1 && ref.Tags != nil {
+		return nil, errors.New(errUnsupportedKvVersion)
+	}
+	var matcher *find.Matcher
+	if ref.Name != nil {
+		var err error
+		matcher, err = find.New(*ref.Name)
+		if err != nil {
+			return nil, err
+		}
+	} else if len(ref.Tags) == 0 {
+		return nil, errors.New(errDeleteAllFind)
+	}
+	searchPath := ""
+	if ref.Path != nil {
+		searchPath = *ref.Path + "/"
+	}
+	candidates, err := c.listSecrets(ctx, searchPath)
+	if errors.Is(err, esv1beta1.NoSecretError{}) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var keys []string
+	for _, name := range candidates {
+		if matcher != nil && !matcher.MatchName(name) {
+			continue
+		}
+		metadata, err := c.readSecretMetadata(ctx, name)
+		if err != nil {
+			return nil, err
+		}
+		if manager, ok := metadata[managedByKey]; !ok || manager != managedByValue {
+			continue
+		}
+		if !hasMetadata(metadata, ref.Tags) {
+			continue
+		}
+		keys = append(keys, name)
+	}
+	slices.Sort(keys)
+	if dryRun {
+		return keys, nil
+	}
+
+	deleted := make([]string, 0, len(keys))
+	for _, key := range keys {
+		if err := c.deleteSecret(ctx, key); err != nil {
+			return deleted, err
+		}
+		deleted = append(deleted, key)
+	}
+	return deleted, nil
+}
+
+// hasMetadata returns true if all tags are set in the custom_metadata with the same value.
+func hasMetadata(metadata, tags map[string]string) bool {
+	for k, v := range tags {
+		if p, ok := metadata[k]; !ok || p != v {
+			return false
+		}
+	}
+	return true
+}
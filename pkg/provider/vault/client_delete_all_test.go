@@ -0,0 +1,122 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vault
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	vault "github.com/hashicorp/vault/api"
+	"k8s.io/utils/ptr"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	"github.com/external-secrets/external-secrets/pkg/provider/vault/fake"
+)
+
+func TestDeleteAllSecrets(t *testing.T) {
+	lists := map[string][]any{
+		"secret/metadata/":     {"app/", "db"},
+		"secret/metadata/app/": {"api", "unmanaged", "other-manager"},
+	}
+	metadata := map[string]map[string]any{
+		"secret/metadata/db":                {managedByKey: managedByValue, "team": "a"},
+		"secret/metadata/app/api":           {managedByKey: managedByValue, "team": "a"},
+		"secret/metadata/app/unmanaged":     {"team": "a"},
+		"secret/metadata/app/other-manager": {managedByKey: "someone-else", "team": "a"},
+	}
+	errBoom := errors.New("boom")
+
+	tests := []struct {
+		name        string
+		ref         esv1beta1.ExternalSecretFind
+		dryRun      bool
+		deleteErr   error
+		want        []string
+		wantDeleted []string
+		wantErr     error
+	}{
+		{
+			name:        "deletes only managed secrets matching the name",
+			ref:         esv1beta1.ExternalSecretFind{Name: &esv1beta1.FindName{RegExp: ".*"}},
+			want:        []string{"app/api", "db"},
+			wantDeleted: []string{"secret/data/app/api", "secret/metadata/app/api", "secret/data/db", "secret/metadata/db"},
+		},
+		{
+			name:        "tags within a path",
+			ref:         esv1beta1.ExternalSecretFind{Path: ptr.To("app"), Tags: map[string]string{"team": "a"}},
+			want:        []string{"app/api"},
+			wantDeleted: []string{"secret/data/app/api", "secret/metadata/app/api"},
+		},
+		{
+			name:   "dry run does not delete",
+			ref:    esv1beta1.ExternalSecretFind{Tags: map[string]string{"team": "a"}},
+			dryRun: true,
+			want:   []string{"app/api", "db"},
+		},
+		{
+			name:        "returns the deleted secrets on error",
+			ref:         esv1beta1.ExternalSecretFind{Name: &esv1beta1.FindName{RegExp: ".*"}},
+			deleteErr:   errBoom,
+			want:        []string{},
+			wantDeleted: []string{"secret/data/app/api"},
+			wantErr:     errBoom,
+		},
+		{
+			name:    "name or tags are required",
+			ref:     esv1beta1.ExternalSecretFind{Path: ptr.To("app")},
+			wantErr: errors.New(errDeleteAllFind),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var deleted []string
+			c := &client{
+				store: makeValidSecretStoreWithVersion(esv1beta1.VaultKVStoreV2).Spec.Provider.Vault,
+				logical: &fake.Logical{
+					ListWithContextFn: func(_ context.Context, path string) (*vault.Secret, error) {
+						keys, ok := lists[path]
+						if !ok {
+							return nil, nil
+						}
+						return &vault.Secret{Data: map[string]any{"keys": keys}}, nil
+					},
+					ReadWithDataWithContextFn: func(_ context.Context, path string, _ map[string][]string) (*vault.Secret, error) {
+						return &vault.Secret{Data: map[string]any{"custom_metadata": metadata[path]}}, nil
+					},
+					DeleteWithContextFn: func(_ context.Context, path string) (*vault.Secret, error) {
+						deleted = append(deleted, path)
+						return nil, tt.deleteErr
+					},
+				},
+			}
+			got, err := c.DeleteAllSecrets(context.Background(), tt.ref, tt.dryRun)
+			if tt.wantErr != nil {
+				if err == nil || !errors.Is(err, tt.wantErr) && err.Error() != tt.wantErr.Error() {
+					t.Fatalf("DeleteAllSecrets() error = %v, want %v", err, tt.wantErr)
+				}
+			} else if err != nil {
+				t.Fatalf("DeleteAllSecrets() unexpected error = %v", err)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("DeleteAllSecrets() mismatch (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tt.wantDeleted, deleted); diff != "" {
+				t.Errorf("deleted paths mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
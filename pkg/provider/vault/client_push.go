@@ -28,8 +28,25 @@ import (
 	"github.com/external-secrets/external-secrets/pkg/constants"
 	"github.com/external-secrets/external-secrets/pkg/metrics"
 	"github.com/external-secrets/external-secrets/pkg/utils"
+	"github.com/external-secrets/external-secrets/pkg/utils/metadata"
 )
 
+const (
+	errAtomicPushRef      = "all properties pushed atomically must set a property of remote key %s"
+	errAtomicPushKey      = "secret key %s does not exist"
+	errPushSecretMetadata = "failed to parse PushSecret metadata: %w"
+
+	managedByKey   = "managed-by"
+	managedByValue = "external-secrets"
+)
+
+// PushSecretMetadataSpec is the spec of the PushSecret metadata for Vault.
+type PushSecretMetadataSpec struct {
+	// CustomMetadata is written to the custom_metadata of the secret,
+	// the managed-by key is always set by external-secrets.
+	CustomMetadata map[string]string `json:"customMetadata,omitempty"`
+}
+
 func (c *client) PushSecret(ctx context.Context, secret *corev1.Secret, data esv1beta1.PushSecretData) error {
 	var (
 		value []byte
@@ -49,39 +66,18 @@ func (c *client) PushSecret(ctx context.Context, secret *corev1.Secret, data esv
 	} else {
 		value = secret.Data[key]
 	}
-	label := map[string]any{
-		"custom_metadata": map[string]string{
-			"managed-by": "external-secrets",
-		},
-	}
 	secretVal := make(map[string]any)
-	path := c.buildPath(data.GetRemoteKey())
-	metaPath, err := c.buildMetadataPath(data.GetRemoteKey())
+	customMetadata, err := buildCustomMetadata(data)
 	if err != nil {
 		return err
 	}
 
 	// Retrieve the secret map from vault and convert the secret value in string form.
-	vaultSecret, err := c.readSecret(ctx, path, "")
-	// If error is not of type secret not found, we should error
-	if err != nil && !errors.Is(err, esv1beta1.NoSecretError{}) {
+	vaultSecret, vaultMetadata, err := c.readManagedSecret(ctx, data.GetRemoteKey())
+	if err != nil {
 		return err
 	}
-	// If the secret exists (err == nil), we should check if it is managed by external-secrets
-	if err == nil {
-		metadata, err := c.readSecretMetadata(ctx, data.GetRemoteKey())
-		if err != nil {
-			return err
-		}
-		manager, ok := metadata["managed-by"]
-		if !ok || manager != "external-secrets" {
-			return errors.New("secret not managed by external-secrets")
-		}
-	}
-	// Remove the metadata map to check the reconcile difference
-	if c.store.Version == esv1beta1.VaultKVStoreV1 {
-		delete(vaultSecret, "custom_metadata")
-	}
+	metadataChanged := !maps.Equal(vaultMetadata, customMetadata)
 	buf := &bytes.Buffer{}
 	enc := json.NewEncoder(buf)
 	enc.SetEscapeHTML(false)
@@ -90,21 +86,15 @@ func (c *client) PushSecret(ctx context.Context, secret *corev1.Secret, data esv
 		return fmt.Errorf("error encoding vault secret: %w", err)
 	}
 	vaultSecretValue := bytes.TrimSpace(buf.Bytes())
-	if err != nil {
-		return fmt.Errorf("error marshaling vault secret: %w", err)
-	}
-	if bytes.Equal(vaultSecretValue, value) {
+	if bytes.Equal(vaultSecretValue, value) && !metadataChanged {
 		return nil
 	}
 	// If a Push of a property only, we should merge and add/update the property
 	if data.GetProperty() != "" {
 		if _, ok := vaultSecret[data.GetProperty()]; ok {
 			d := vaultSecret[data.GetProperty()].(string)
-			if err != nil {
-				return fmt.Errorf("error marshaling vault secret: %w", err)
-			}
 			// If the property has the same value, don't update the secret
-			if bytes.Equal([]byte(d), value) {
+			if bytes.Equal([]byte(d), value) && !metadataChanged {
 				return nil
 			}
 		}
@@ -117,6 +107,112 @@ func (c *client) PushSecret(ctx context.Context, secret *corev1.Secret, data esv
 			return fmt.Errorf("error unmarshalling vault secret: %w", err)
 		}
 	}
+	return c.writeSecret(ctx, data.GetRemoteKey(), secretVal, customMetadata)
+}
+
+// PushSecrets writes multiple properties of the same remote secret with a single write,
+// so either all properties are updated or none.
+func (c *client) PushSecrets(ctx context.Context, secret *corev1.Secret, data []esv1beta1.PushSecretData) error {
+	if len(data) == 0 {
+		return nil
+	}
+	remoteKey := data[0].GetRemoteKey()
+
+	// assemble the desired properties and custom metadata before touching vault
+	values := make(map[string]string, len(data))
+	customMetadata := make(map[string]string)
+	for _, d := range data {
+		if d.GetRemoteKey() != remoteKey || d.GetProperty() == "" {
+			return fmt.Errorf(errAtomicPushRef, remoteKey)
+		}
+		value, ok := secret.Data[d.GetSecretKey()]
+		if !ok {
+			return fmt.Errorf(errAtomicPushKey, d.GetSecretKey())
+		}
+		values[d.GetProperty()] = string(value)
+		m, err := buildCustomMetadata(d)
+		if err != nil {
+			return err
+		}
+		maps.Copy(customMetadata, m)
+	}
+
+	vaultSecret, vaultMetadata, err := c.readManagedSecret(ctx, remoteKey)
+	if err != nil {
+		return err
+	}
+	secretVal := make(map[string]any, len(vaultSecret)+len(values))
+	maps.Insert(secretVal, maps.All(vaultSecret))
+	changed := !maps.Equal(vaultMetadata, customMetadata)
+	for property, value := range values {
+		if current, ok := secretVal[property].(string); !ok || current != value {
+			changed = true
+		}
+		secretVal[property] = value
+	}
+	if !changed {
+		return nil
+	}
+	return c.writeSecret(ctx, remoteKey, secretVal, customMetadata)
+}
+
+// buildCustomMetadata returns the custom metadata of the PushSecret metadata
+// together with the managed-by key, which can't be overridden.
+func buildCustomMetadata(data esv1beta1.PushSecretData) (map[string]string, error) {
+	meta, err := metadata.ParseMetadataParameters[PushSecretMetadataSpec](data.GetMetadata())
+	if err != nil {
+		return nil, fmt.Errorf(errPushSecretMetadata, err)
+	}
+	customMetadata := make(map[string]string)
+	if meta != nil {
+		maps.Copy(customMetadata, meta.Spec.CustomMetadata)
+	}
+	customMetadata[managedByKey] = managedByValue
+	return customMetadata, nil
+}
+
+// readManagedSecret reads the secret at remoteKey and its custom metadata separately.
+// It returns an error if the secret exists but is not managed by external-secrets.
+func (c *client) readManagedSecret(ctx context.Context, remoteKey string) (map[string]any, map[string]string, error) {
+	path := c.buildPath(remoteKey)
+	if _, err := c.buildMetadataPath(remoteKey); err != nil {
+		return nil, nil, err
+	}
+	vaultSecret, err := c.readSecret(ctx, path, "")
+	// If error is not of type secret not found, we should error
+	if err != nil && !errors.Is(err, esv1beta1.NoSecretError{}) {
+		return nil, nil, err
+	}
+	var vaultMetadata map[string]string
+	// If the secret exists (err == nil), we should check if it is managed by external-secrets
+	if err == nil {
+		vaultMetadata, err = c.readSecretMetadata(ctx, remoteKey)
+		if err != nil {
+			return nil, nil, err
+		}
+		manager, ok := vaultMetadata[managedByKey]
+		if !ok || manager != managedByValue {
+			return nil, nil, errors.New("secret not managed by external-secrets")
+		}
+	}
+	// Remove the metadata map to check the reconcile difference
+	if c.store.Version == esv1beta1.VaultKVStoreV1 {
+		delete(vaultSecret, "custom_metadata")
+	}
+	return vaultSecret, vaultMetadata, nil
+}
+
+// writeSecret creates or updates the secret at remoteKey with the custom metadata,
+// which marks it as managed by external-secrets.
+func (c *client) writeSecret(ctx context.Context, remoteKey string, secretVal map[string]any, customMetadata map[string]string) error {
+	label := map[string]any{
+		"custom_metadata": customMetadata,
+	}
+	path := c.buildPath(remoteKey)
+	metaPath, err := c.buildMetadataPath(remoteKey)
+	if err != nil {
+		return err
+	}
 	secretToPush := secretVal
 	// Adding custom_metadata to the secret for KV v1
 	if c.store.Version == esv1beta1.VaultKVStoreV1 {
@@ -127,9 +223,6 @@ func (c *client) PushSecret(ctx context.Context, secret *corev1.Secret, data esv
 			"data": secretVal,
 		}
 	}
-	if err != nil {
-		return fmt.Errorf("failed to convert value to a valid JSON: %w", err)
-	}
 	// Secret metadata should be pushed separately only for KV2
 	if c.store.Version == esv1beta1.VaultKVStoreV2 {
 		_, err = c.logical.WriteWithContext(ctx, metaPath, label)
@@ -146,8 +239,7 @@ func (c *client) PushSecret(ctx context.Context, secret *corev1.Secret, data esv
 
 func (c *client) DeleteSecret(ctx context.Context, remoteRef esv1beta1.PushSecretRemoteRef) error {
 	path := c.buildPath(remoteRef.GetRemoteKey())
-	metaPath, err := c.buildMetadataPath(remoteRef.GetRemoteKey())
-	if err != nil {
+	if _, err := c.buildMetadataPath(remoteRef.GetRemoteKey()); err != nil {
 		return err
 	}
 	// Retrieve the secret map from vault and convert the secret value in string form.
@@ -159,12 +251,12 @@ func (c *client) DeleteSecret(ctx context.Context, remoteRef esv1beta1.PushSecre
 	if err != nil {
 		return err
 	}
-	metadata, err := c.readSecretMetadata(ctx, remoteRef.GetRemoteKey())
+	vaultMetadata, err := c.readSecretMetadata(ctx, remoteRef.GetRemoteKey())
 	if err != nil {
 		return err
 	}
-	manager, ok := metadata["managed-by"]
-	if !ok || manager != "external-secrets" {
+	manager, ok := vaultMetadata[managedByKey]
+	if !ok || manager != managedByValue {
 		return nil
 	}
 	// If Push for a Property, we need to delete the property and update the secret
@@ -186,16 +278,25 @@ func (c *client) DeleteSecret(ctx context.Context, remoteRef esv1beta1.PushSecre
 			return err
 		}
 	}
-	_, err = c.logical.DeleteWithContext(ctx, path)
+	return c.deleteSecret(ctx, remoteRef.GetRemoteKey())
+}
+
+// deleteSecret deletes the secret key, for KV v2 including its metadata and all versions.
+func (c *client) deleteSecret(ctx context.Context, key string) error {
+	metaPath, err := c.buildMetadataPath(key)
+	if err != nil {
+		return err
+	}
+	_, err = c.logical.DeleteWithContext(ctx, c.buildPath(key))
 	metrics.ObserveAPICall(constants.ProviderHCVault, constants.CallHCVaultDeleteSecret, err)
 	if err != nil {
-		return fmt.Errorf("could not delete secret %v: %w", remoteRef.GetRemoteKey(), err)
+		return fmt.Errorf("could not delete secret %v: %w", key, err)
 	}
 	if c.store.Version == esv1beta1.VaultKVStoreV2 {
 		_, err = c.logical.DeleteWithContext(ctx, metaPath)
 		metrics.ObserveAPICall(constants.ProviderHCVault, constants.CallHCVaultDeleteSecret, err)
 		if err != nil {
-			return fmt.Errorf("could not delete secret metadata %v: %w", remoteRef.GetRemoteKey(), err)
+			return fmt.Errorf("could not delete secret metadata %v: %w", key, err)
 		}
 	}
 	return nil
@@ -18,10 +18,13 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"reflect"
 	"strings"
 	"testing"
 
+	vault "github.com/hashicorp/vault/api"
 	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 
 	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
 	testingfake "github.com/external-secrets/external-secrets/pkg/provider/testing/fake"
@@ -649,6 +652,64 @@ func TestPushSecret(t *testing.T) {
 				err: errors.New("secret not managed by external-secrets"),
 			},
 		},
+		"CustomMetadataKV2": {
+			reason: "custom metadata is merged with the managed-by key",
+			args: args{
+				store: makeValidSecretStoreWithVersion(esv1beta1.VaultKVStoreV2).Spec.Provider.Vault,
+				vLogical: &fake.Logical{
+					ReadWithDataWithContextFn: fake.NewReadWithContextFn(nil, nil),
+					WriteWithContextFn: fake.ExpectWriteMetadataWithContextValue(map[string]any{
+						"custom_metadata": map[string]string{"team": "payments", managedBy: managedByESO},
+					}),
+				},
+			},
+			data: &testingfake.PushSecretData{SecretKey: secretKey, RemoteKey: "secret", Metadata: &apiextensionsv1.JSON{
+				Raw: []byte(`{"apiVersion":"kubernetes.external-secrets.io/v1alpha1","kind":"PushSecretMetadata","spec":{"customMetadata":{"team":"payments","managed-by":"someone-else"}}}`),
+			}},
+			want: want{
+				err: nil,
+			},
+		},
+		"CustomMetadataUnchangedKV2": {
+			reason: "an unchanged secret with unchanged custom metadata is not written",
+			args: args{
+				store: makeValidSecretStoreWithVersion(esv1beta1.VaultKVStoreV2).Spec.Provider.Vault,
+				vLogical: &fake.Logical{
+					ReadWithDataWithContextFn: fake.NewReadWithContextFn(map[string]any{
+						"data": map[string]any{
+							fakeKey: fakeValue,
+						},
+						"custom_metadata": map[string]any{
+							managedBy: managedByESO,
+							"team":    "payments",
+						},
+					}, nil),
+					WriteWithContextFn: fake.ExpectWriteWithContextNoCall(),
+				},
+			},
+			data: &testingfake.PushSecretData{SecretKey: secretKey, RemoteKey: "secret", Metadata: &apiextensionsv1.JSON{
+				Raw: []byte(`{"apiVersion":"kubernetes.external-secrets.io/v1alpha1","kind":"PushSecretMetadata","spec":{"customMetadata":{"team":"payments"}}}`),
+			}},
+			want: want{
+				err: nil,
+			},
+		},
+		"InvalidMetadata": {
+			reason: "push secret fails with an invalid metadata",
+			args: args{
+				store: makeValidSecretStoreWithVersion(esv1beta1.VaultKVStoreV2).Spec.Provider.Vault,
+				vLogical: &fake.Logical{
+					ReadWithDataWithContextFn: fake.NewReadWithContextFn(nil, nil),
+					WriteWithContextFn:        fake.ExpectWriteWithContextNoCall(),
+				},
+			},
+			data: &testingfake.PushSecretData{SecretKey: secretKey, RemoteKey: "secret", Metadata: &apiextensionsv1.JSON{
+				Raw: []byte(`{"apiVersion":"kubernetes.external-secrets.io/v1alpha1","kind":"PushSecretMetadata","spec":{"tags":{"team":"payments"}}}`),
+			}},
+			want: want{
+				err: errors.New("failed to parse PushSecret metadata"),
+			},
+		},
 		"WholeSecretKV2": {
 			reason: "secret is successfully set, with no existing vault secret",
 			args: args{
@@ -700,3 +761,148 @@ func TestPushSecret(t *testing.T) {
 		})
 	}
 }
+
+func TestPushSecretCustomMetadataChanged(t *testing.T) {
+	var written []map[string]any
+	c := &client{
+		store: makeValidSecretStoreWithVersion(esv1beta1.VaultKVStoreV2).Spec.Provider.Vault,
+		logical: &fake.Logical{
+			ReadWithDataWithContextFn: fake.NewReadWithContextFn(map[string]any{
+				"data": map[string]any{
+					fakeKey: fakeValue,
+				},
+				"custom_metadata": map[string]any{
+					managedBy: managedByESO,
+					"team":    "billing",
+				},
+			}, nil),
+			WriteWithContextFn: func(_ context.Context, path string, data map[string]any) (*vault.Secret, error) {
+				if strings.Contains(path, "metadata") {
+					written = append(written, data)
+				}
+				return &vault.Secret{Data: data}, nil
+			},
+		},
+	}
+	data := testingfake.PushSecretData{SecretKey: "secret-key", RemoteKey: "secret", Metadata: &apiextensionsv1.JSON{
+		Raw: []byte(`{"apiVersion":"kubernetes.external-secrets.io/v1alpha1","kind":"PushSecretMetadata","spec":{"customMetadata":{"team":"payments"}}}`),
+	}}
+	secret := &corev1.Secret{Data: map[string][]byte{"secret-key": []byte(`{"fake-key":"fake-value"}`)}}
+	if err := c.PushSecret(context.Background(), secret, data); err != nil {
+		t.Fatalf("PushSecret() error = %v", err)
+	}
+	want := []map[string]any{{"custom_metadata": map[string]string{"team": "payments", managedBy: managedByESO}}}
+	if !reflect.DeepEqual(want, written) {
+		t.Errorf("PushSecret() wrote metadata %v, want %v", written, want)
+	}
+}
+
+func TestPushSecrets(t *testing.T) {
+	noPermission := errors.New("no permission")
+	managedSecret := map[string]any{
+		"data": map[string]any{
+			fakeKey: fakeValue,
+			"user":  "admin",
+		},
+		"custom_metadata": map[string]any{
+			managedBy: managedByESO,
+		},
+	}
+	secret := &corev1.Secret{Data: map[string][]byte{
+		"user":     []byte("admin"),
+		"password": []byte("s3cr3t"),
+	}}
+	data := []esv1beta1.PushSecretData{
+		&testingfake.PushSecretData{SecretKey: "user", RemoteKey: "secret", Property: "user"},
+		&testingfake.PushSecretData{SecretKey: "password", RemoteKey: "secret", Property: "password"},
+	}
+
+	tests := map[string]struct {
+		reason     string
+		readSecret map[string]any
+		writeErr   error
+		data       []esv1beta1.PushSecretData
+		wantWrites int
+		wantErr    string
+	}{
+		"WritesAllPropertiesOnce": {
+			reason:     "all properties are merged into the existing secret with a single write",
+			readSecret: managedSecret,
+			data:       data,
+			wantWrites: 1,
+		},
+		"NoChanges": {
+			reason:     "the secret is not written if all properties are up to date",
+			readSecret: managedSecret,
+			data:       data[:1],
+		},
+		"MissingSecretKey": {
+			reason:     "nothing is written if one of the secret keys is missing",
+			readSecret: managedSecret,
+			data: append([]esv1beta1.PushSecretData{
+				&testingfake.PushSecretData{SecretKey: "missing", RemoteKey: "secret", Property: "missing"},
+			}, data...),
+			wantErr: "secret key missing does not exist",
+		},
+		"DifferentRemoteKeys": {
+			reason:     "properties of different remote keys can not be written atomically",
+			readSecret: managedSecret,
+			data: append([]esv1beta1.PushSecretData{
+				&testingfake.PushSecretData{SecretKey: "user", RemoteKey: "other", Property: "user"},
+			}, data...),
+			wantErr: "must set a property of remote key other",
+		},
+		"NotManaged": {
+			reason: "nothing is written if the secret is not managed by external-secrets",
+			readSecret: map[string]any{
+				"data": map[string]any{fakeKey: fakeValue},
+			},
+			data:    data,
+			wantErr: "secret not managed by external-secrets",
+		},
+		"WriteError": {
+			reason:     "the write error is returned",
+			readSecret: managedSecret,
+			writeErr:   noPermission,
+			data:       data,
+			wantWrites: 1,
+			wantErr:    noPermission.Error(),
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			var written map[string]any
+			writes := 0
+			c := &client{
+				store: makeValidSecretStoreWithVersion(esv1beta1.VaultKVStoreV2).Spec.Provider.Vault,
+				logical: &fake.Logical{
+					ReadWithDataWithContextFn: fake.NewReadWithContextFn(tc.readSecret, nil),
+					WriteWithContextFn: func(_ context.Context, path string, data map[string]any) (*vault.Secret, error) {
+						if strings.Contains(path, "metadata") {
+							return nil, nil
+						}
+						writes++
+						written = data
+						return nil, tc.writeErr
+					},
+				},
+			}
+			err := c.PushSecrets(context.Background(), secret, tc.data)
+			if tc.wantErr == "" && err != nil {
+				t.Fatalf("%s: unexpected error: %v", tc.reason, err)
+			}
+			if tc.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tc.wantErr)) {
+				t.Fatalf("%s: expected error containing %q, got %v", tc.reason, tc.wantErr, err)
+			}
+			if writes != tc.wantWrites {
+				t.Fatalf("%s: expected %d writes, got %d", tc.reason, tc.wantWrites, writes)
+			}
+			if writes > 0 && tc.writeErr == nil {
+				want := map[string]any{"data": map[string]any{fakeKey: fakeValue, "user": "admin", "password": "s3cr3t"}}
+				if !reflect.DeepEqual(written, want) {
+					t.Errorf("%s: unexpected secret written: %v", tc.reason, written)
+				}
+			}
+		})
+	}
+}
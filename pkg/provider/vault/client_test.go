@@ -0,0 +1,144 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vault
+
+import (
+	"context"
+	"encoding/pem"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	"github.com/external-secrets/external-secrets/pkg/utils"
+)
+
+func TestNewConfigServerName(t *testing.T) {
+	// the test certificate is valid for 127.0.0.1 and example.com, but not for vault.internal
+	var serverName string
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		serverName = req.TLS.ServerName
+	}))
+	defer ts.Close()
+	ca := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: ts.Certificate().Raw})
+
+	tests := []struct {
+		name       string
+		caBundle   []byte
+		serverName string
+		wantErr    string
+	}{
+		{
+			name:       "trusts the custom CA and sends the server name",
+			caBundle:   ca,
+			serverName: "example.com",
+		},
+		{
+			name:     "trusts the custom CA without server name",
+			caBundle: ca,
+		},
+		{
+			name:       "verifies the certificate against the server name",
+			caBundle:   ca,
+			serverName: "vault.internal",
+			wantErr:    "certificate is valid for",
+		},
+		{
+			name:       "rejects the certificate without the custom CA",
+			serverName: "example.com",
+			wantErr:    "certificate signed by unknown authority",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			serverName = ""
+			c := &client{
+				store: &esv1beta1.VaultProvider{
+					Server:     ts.URL,
+					CABundle:   tt.caBundle,
+					ServerName: tt.serverName,
+				},
+			}
+			cfg, err := c.newConfig(context.Background())
+			if err != nil {
+				t.Fatalf("newConfig() returned an unexpected error: %v", err)
+			}
+			resp, err := cfg.HttpClient.Get(ts.URL)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			resp.Body.Close()
+			if serverName != tt.serverName {
+				t.Errorf("unexpected server name: %q (expected %q)", serverName, tt.serverName)
+			}
+		})
+	}
+}
+
+func TestNewConfigTLSPinnedKeys(t *testing.T) {
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+	defer ts.Close()
+	ca := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: ts.Certificate().Raw})
+
+	tests := []struct {
+		name    string
+		pins    []string
+		wantErr error
+	}{
+		{
+			name: "accepts the pinned key",
+			pins: []string{utils.TLSKeyPin(ts.Certificate())},
+		},
+		{
+			name:    "refuses a certificate of a trusted CA with another key",
+			pins:    []string{"sha256/AAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA="},
+			wantErr: esv1beta1.ErrTLSPinMismatch,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &client{
+				store: &esv1beta1.VaultProvider{
+					Server:        ts.URL,
+					CABundle:      ca,
+					TLSPinnedKeys: tt.pins,
+				},
+			}
+			cfg, err := c.newConfig(context.Background())
+			if err != nil {
+				t.Fatalf("newConfig() returned an unexpected error: %v", err)
+			}
+			resp, err := cfg.HttpClient.Get(ts.URL)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("expected %v, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			resp.Body.Close()
+		})
+	}
+}
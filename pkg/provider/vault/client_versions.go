@@ -0,0 +1,138 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vault
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"time"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	"github.com/external-secrets/external-secrets/pkg/constants"
+	"github.com/external-secrets/external-secrets/pkg/metrics"
+)
+
+const (
+	errVersionsKvV1      = "%w: kv version v1 does not keep versions"
+	errVersionsField     = "unexpected versions field in the metadata of the secret"
+	errVersionCreatedAt  = "unable to parse the created_time of version %s: %w"
+	errCurrentVersion    = "unexpected current_version field in the metadata of the secret"
+	errNoPreviousVersion = "the secret has no previous version"
+)
+
+var _ esv1beta1.SecretVersionLister = &client{}
+
+// ListSecretVersions lists the versions of a KV v2 secret from its metadata.
+// Deleted and destroyed versions are returned with a `deleted` or `destroyed` attribute.
+func (c *client) ListSecretVersions(ctx context.Context, ref esv1beta1.ExternalSecretDataRemoteRef) ([]esv1beta1.SecretVersion, error) {
+	if c.store.Version == esv1beta1.VaultKVStoreV1 {
+		return nil, fmt.Errorf(errVersionsKvV1, esv1beta1.ErrListSecretVersionsNotSupported)
+	}
+	url, err := c.buildMetadataPath(ref.Key)
+	if err != nil {
+		return nil, err
+	}
+	secret, err := c.logical.ReadWithDataWithContext(ctx, url, nil)
+	metrics.ObserveAPICall(constants.ProviderHCVault, constants.CallHCVaultReadSecretData, err)
+	if err != nil {
+		return nil, fmt.Errorf(errReadSecret, err)
+	}
+	if secret == nil {
+		return nil, esv1beta1.NoSecretError{}
+	}
+	rawVersions, ok := secret.Data["versions"].(map[string]any)
+	if !ok {
+		return nil, errors.New(errVersionsField)
+	}
+
+	versions := make([]esv1beta1.SecretVersion, 0, len(rawVersions))
+	for id, raw := range rawVersions {
+		meta, ok := raw.(map[string]any)
+		if !ok {
+			return nil, errors.New(errVersionsField)
+		}
+		version := esv1beta1.SecretVersion{Version: id}
+		if created, ok := meta["created_time"].(string); ok && created != "" {
+			version.CreatedAt, err = time.Parse(time.RFC3339Nano, created)
+			if err != nil {
+				return nil, fmt.Errorf(errVersionCreatedAt, id, err)
+			}
+		}
+		if destroyed, ok := meta["destroyed"].(bool); ok && destroyed {
+			version.Attributes = append(version.Attributes, "destroyed")
+		} else if deleted, ok := meta["deletion_time"].(string); ok && deleted != "" {
+			version.Attributes = append(version.Attributes, "deleted")
+		}
+		versions = append(versions, version)
+	}
+	slices.SortFunc(versions, func(a, b esv1beta1.SecretVersion) int {
+		// version ids of KV v2 are increasing integers
+		ia, _ := strconv.Atoi(a.Version)
+		ib, _ := strconv.Atoi(b.Version)
+		return ia - ib
+	})
+	return versions, nil
+}
+
+var _ esv1beta1.SecretVersionAliasResolver = &client{}
+
+// ResolveVersionAlias maps a version alias to a version of a KV v2 secret,
+// previous is the version before current_version.
+func (c *client) ResolveVersionAlias(ctx context.Context, ref esv1beta1.ExternalSecretDataRemoteRef) (string, error) {
+	if c.store.Version == esv1beta1.VaultKVStoreV1 {
+		return "", fmt.Errorf(errVersionsKvV1, esv1beta1.ErrVersionAliasNotSupported)
+	}
+	switch ref.Version {
+	case esv1beta1.VersionAliasLatest, esv1beta1.VersionAliasCurrent:
+		// without a version the current version is read
+		return "", nil
+	case esv1beta1.VersionAliasPrevious:
+		current, err := c.currentVersion(ctx, ref.Key)
+		if err != nil {
+			return "", err
+		}
+		if current <= 1 {
+			return "", errors.New(errNoPreviousVersion)
+		}
+		return strconv.Itoa(current - 1), nil
+	default:
+		return "", fmt.Errorf("%w %s", esv1beta1.ErrVersionAliasNotSupported, ref.Version)
+	}
+}
+
+// currentVersion reads current_version from the metadata of a KV v2 secret.
+func (c *client) currentVersion(ctx context.Context, key string) (int, error) {
+	url, err := c.buildMetadataPath(key)
+	if err != nil {
+		return 0, err
+	}
+	secret, err := c.logical.ReadWithDataWithContext(ctx, url, nil)
+	metrics.ObserveAPICall(constants.ProviderHCVault, constants.CallHCVaultReadSecretData, err)
+	if err != nil {
+		return 0, fmt.Errorf(errReadSecret, err)
+	}
+	if secret == nil {
+		return 0, esv1beta1.NoSecretError{}
+	}
+	// the field is a json.Number when read from Vault
+	current, err := strconv.Atoi(fmt.Sprint(secret.Data["current_version"]))
+	if err != nil {
+		return 0, errors.New(errCurrentVersion)
+	}
+	return current, nil
+}
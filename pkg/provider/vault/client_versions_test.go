@@ -0,0 +1,168 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vault
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	vault "github.com/hashicorp/vault/api"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	"github.com/external-secrets/external-secrets/pkg/provider/vault/fake"
+)
+
+func TestListSecretVersions(t *testing.T) {
+	metadata := map[string]any{
+		"current_version": 10,
+		"versions": map[string]any{
+			"10": map[string]any{"created_time": "2024-01-03T00:00:00.5Z", "deletion_time": "", "destroyed": false},
+			"2":  map[string]any{"created_time": "2024-01-02T00:00:00Z", "deletion_time": "2024-01-05T00:00:00Z", "destroyed": false},
+			"1":  map[string]any{"created_time": "2024-01-01T00:00:00Z", "deletion_time": "", "destroyed": true},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		version  esv1beta1.VaultKVStoreVersion
+		readFn   fake.ReadWithDataWithContextFn
+		wantPath string
+		want     []esv1beta1.SecretVersion
+		wantErr  error
+	}{
+		{
+			name:     "lists versions of kv v2",
+			version:  esv1beta1.VaultKVStoreV2,
+			readFn:   fake.NewReadWithContextFn(metadata, nil),
+			wantPath: "secret/metadata/db",
+			want: []esv1beta1.SecretVersion{
+				{Version: "1", CreatedAt: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), Attributes: []string{"destroyed"}},
+				{Version: "2", CreatedAt: time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC), Attributes: []string{"deleted"}},
+				{Version: "10", CreatedAt: time.Date(2024, 1, 3, 0, 0, 0, 5e8, time.UTC)},
+			},
+		},
+		{
+			name:    "secret not found",
+			version: esv1beta1.VaultKVStoreV2,
+			readFn:  fake.NewReadWithContextFn(nil, nil),
+			wantErr: esv1beta1.NoSecretError{},
+		},
+		{
+			name:    "kv v1 is not supported",
+			version: esv1beta1.VaultKVStoreV1,
+			wantErr: esv1beta1.ErrListSecretVersionsNotSupported,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotPath string
+			c := &client{
+				store: makeValidSecretStoreWithVersion(tt.version).Spec.Provider.Vault,
+				logical: &fake.Logical{
+					ReadWithDataWithContextFn: func(ctx context.Context, path string, data map[string][]string) (*vault.Secret, error) {
+						gotPath = path
+						return tt.readFn(ctx, path, data)
+					},
+				},
+			}
+			got, err := c.ListSecretVersions(context.Background(), esv1beta1.ExternalSecretDataRemoteRef{Key: "db"})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ListSecretVersions() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantPath != "" && gotPath != tt.wantPath {
+				t.Errorf("ListSecretVersions() read %q, want %q", gotPath, tt.wantPath)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("ListSecretVersions() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestResolveVersionAlias(t *testing.T) {
+	metadata := func(current any) fake.ReadWithDataWithContextFn {
+		return fake.NewReadWithContextFn(map[string]any{"current_version": current}, nil)
+	}
+	tests := []struct {
+		name    string
+		version esv1beta1.VaultKVStoreVersion
+		alias   string
+		readFn  fake.ReadWithDataWithContextFn
+		want    string
+		wantErr string
+	}{
+		{
+			name:    "latest is the current version",
+			version: esv1beta1.VaultKVStoreV2,
+			alias:   esv1beta1.VersionAliasLatest,
+		},
+		{
+			name:    "current",
+			version: esv1beta1.VaultKVStoreV2,
+			alias:   esv1beta1.VersionAliasCurrent,
+		},
+		{
+			name:    "previous is current_version minus one",
+			version: esv1beta1.VaultKVStoreV2,
+			alias:   esv1beta1.VersionAliasPrevious,
+			readFn:  metadata(json.Number("7")),
+			want:    "6",
+		},
+		{
+			name:    "no previous version",
+			version: esv1beta1.VaultKVStoreV2,
+			alias:   esv1beta1.VersionAliasPrevious,
+			readFn:  metadata(1),
+			wantErr: errNoPreviousVersion,
+		},
+		{
+			name:    "pending is not supported",
+			version: esv1beta1.VaultKVStoreV2,
+			alias:   esv1beta1.VersionAliasPending,
+			wantErr: "provider does not support the version alias pending",
+		},
+		{
+			name:    "kv v1 is not supported",
+			version: esv1beta1.VaultKVStoreV1,
+			alias:   esv1beta1.VersionAliasPrevious,
+			wantErr: "provider does not support the version alias: kv version v1 does not keep versions",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &client{
+				store:   makeValidSecretStoreWithVersion(tt.version).Spec.Provider.Vault,
+				logical: &fake.Logical{ReadWithDataWithContextFn: tt.readFn},
+			}
+			got, err := c.ResolveVersionAlias(context.Background(), esv1beta1.ExternalSecretDataRemoteRef{Key: "db", Version: tt.alias})
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Fatalf("ResolveVersionAlias() error = %v, want %s", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ResolveVersionAlias() returned an error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("ResolveVersionAlias() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
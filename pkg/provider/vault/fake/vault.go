@@ -103,6 +103,16 @@ func ExpectWriteWithContextValue(expected map[string]any) WriteWithContextFn {
 	}
 }
 
+// ExpectWriteMetadataWithContextValue fails writes to a metadata path with other data than expected.
+func ExpectWriteMetadataWithContextValue(expected map[string]any) WriteWithContextFn {
+	return func(ctx context.Context, path string, data map[string]any) (*vault.Secret, error) {
+		if strings.Contains(path, "metadata") && !reflect.DeepEqual(expected, data) {
+			return nil, fmt.Errorf("expected: %v, got: %v", expected, data)
+		}
+		return &vault.Secret{Data: data}, nil
+	}
+}
+
 func ExpectWriteWithContextNoCall() WriteWithContextFn {
 	return func(_ context.Context, path string, data map[string]any) (*vault.Secret, error) {
 		return nil, errors.New("fail")
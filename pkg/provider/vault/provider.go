@@ -18,6 +18,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/http"
+	"slices"
+	"strings"
 	"time"
 
 	vault "github.com/hashicorp/vault/api"
@@ -36,7 +39,8 @@ import (
 )
 
 var (
-	_           esv1beta1.Provider = &Provider{}
+	_           esv1beta1.Provider        = &Provider{}
+	_           esv1beta1.ErrorClassifier = &Provider{}
 	enableCache bool
 	logger      = ctrl.Log.WithName("provider").WithName("vault")
 	clientCache *cache.Cache[util.Client]
@@ -80,6 +84,21 @@ func (p *Provider) Capabilities() esv1beta1.SecretStoreCapabilities {
 	return esv1beta1.SecretStoreReadWrite
 }
 
+// ClassifyError maps the status codes of the Vault API to provider error reasons.
+// Vault responds with 403 to invalid tokens as well, they are reported as Unauthenticated.
+func (p *Provider) ClassifyError(err error) esv1beta1.ProviderErrorReason {
+	var respErr *vault.ResponseError
+	if !errors.As(err, &respErr) {
+		return ""
+	}
+	if respErr.StatusCode == http.StatusForbidden && slices.ContainsFunc(respErr.Errors, func(msg string) bool {
+		return strings.Contains(msg, "invalid token")
+	}) {
+		return esv1beta1.ProviderErrorUnauthenticated
+	}
+	return esv1beta1.ProviderErrorReasonForHTTPStatus(respErr.StatusCode)
+}
+
 // NewClient implements the Client interface.
 func (p *Provider) NewClient(ctx context.Context, store esv1beta1.GenericStore, kube kclient.Client, namespace string) (esv1beta1.SecretsClient, error) {
 	// controller-runtime/client does not support TokenRequest or other subresource APIs
@@ -134,6 +153,12 @@ func (p *Provider) newClient(ctx context.Context, store esv1beta1.GenericStore,
 		return nil, err
 	}
 
+	// reuse connections across clients of the same store.
+	// cert auth sets the client certificate on the transport during login, so it can not be shared.
+	if vaultSpec.Auth.Cert == nil {
+		cfg.HttpClient.Transport = pooledTransport(store, vStore.tlsVersion, cfg.HttpClient.Transport)
+	}
+
 	client, err := getVaultClient(p, store, cfg)
 	if err != nil {
 		return nil, fmt.Errorf(errVaultClient, err)
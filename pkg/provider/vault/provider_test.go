@@ -707,3 +707,48 @@ func vaultTest(t *testing.T, _ string, tc testCase) {
 		t.Errorf("\n%s\nvault.New(...): -want error, +got error:\n%s", tc.reason, diff)
 	}
 }
+
+func TestClassifyError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want esv1beta1.ProviderErrorReason
+	}{
+		{
+			name: "permission denied",
+			err:  fmt.Errorf("cannot read secret data from Vault: %w", &vault.ResponseError{StatusCode: 403, Errors: []string{"1 error occurred:\n\t* permission denied\n\n"}}),
+			want: esv1beta1.ProviderErrorPermissionDenied,
+		},
+		{
+			name: "invalid token",
+			err:  &vault.ResponseError{StatusCode: 403, Errors: []string{"2 errors occurred:\n\t* permission denied\n\t* invalid token\n\n"}},
+			want: esv1beta1.ProviderErrorUnauthenticated,
+		},
+		{
+			name: "sealed",
+			err:  &vault.ResponseError{StatusCode: 503, Errors: []string{"Vault is sealed"}},
+			want: esv1beta1.ProviderErrorUnavailable,
+		},
+		{
+			name: "rate limited",
+			err:  &vault.ResponseError{StatusCode: 429},
+			want: esv1beta1.ProviderErrorRateLimited,
+		},
+		{
+			name: "bad request",
+			err:  &vault.ResponseError{StatusCode: 400},
+		},
+		{
+			name: "not a Vault error",
+			err:  errors.New("boom"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &Provider{}
+			if got := p.ClassifyError(tt.err); got != tt.want {
+				t.Errorf("ClassifyError() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
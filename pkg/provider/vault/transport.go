@@ -0,0 +1,152 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vault
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"encoding/hex"
+	"net"
+	"net/http"
+	"slices"
+	"sync"
+	"time"
+
+	vault "github.com/hashicorp/vault/api"
+	"github.com/spf13/pflag"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	"github.com/external-secrets/external-secrets/pkg/cache"
+	"github.com/external-secrets/external-secrets/pkg/constants"
+	"github.com/external-secrets/external-secrets/pkg/feature"
+	"github.com/external-secrets/external-secrets/pkg/metrics"
+)
+
+const http2Proto = "h2"
+
+// the defaults match the transport of the Vault client and are used if the flags are not parsed.
+var (
+	transportMaxIdleConns        = 100
+	transportMaxIdleConnsPerHost = 0
+	transportIdleConnTimeout     = 90 * time.Second
+	transportEnableHTTP2         = true
+	transportCache               *cache.Cache[*http.Transport]
+)
+
+// configureTransport applies the transport tuning flags and counts the connections of the transport.
+func configureTransport(cfg *vault.Config) {
+	transport, ok := cfg.HttpClient.Transport.(*http.Transport)
+	if !ok {
+		return
+	}
+	transport.MaxIdleConns = transportMaxIdleConns
+	if transportMaxIdleConnsPerHost > 0 {
+		transport.MaxIdleConnsPerHost = transportMaxIdleConnsPerHost
+	}
+	transport.IdleConnTimeout = transportIdleConnTimeout
+	if !transportEnableHTTP2 {
+		// a non-nil empty map disables HTTP/2, the protocol must not be offered during the TLS handshake either
+		transport.TLSNextProto = map[string]func(string, *tls.Conn) http.RoundTripper{}
+		if transport.TLSClientConfig != nil {
+			transport.TLSClientConfig.NextProtos = slices.DeleteFunc(transport.TLSClientConfig.NextProtos, func(p string) bool {
+				return p == http2Proto
+			})
+		}
+	}
+	if transport.DialContext != nil {
+		transport.DialContext = countConnections(transport.DialContext)
+	}
+}
+
+// tlsVersion identifies the TLS material of a transport.
+// A pooled transport is replaced when the material changes.
+func tlsVersion(ca []byte, cfg *vault.Config) string {
+	h := sha256.New()
+	h.Write(ca)
+	if transport, ok := cfg.HttpClient.Transport.(*http.Transport); ok && transport.TLSClientConfig != nil {
+		for _, cert := range transport.TLSClientConfig.Certificates {
+			for _, der := range cert.Certificate {
+				h.Write(der)
+			}
+		}
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// pooledTransport returns the transport shared by all clients of the store,
+// so connections are reused across reconciles instead of being opened per client.
+func pooledTransport(store esv1beta1.GenericStore, tlsVersion string, transport http.RoundTripper) http.RoundTripper {
+	t, ok := transport.(*http.Transport)
+	if !ok || transportCache == nil {
+		return transport
+	}
+	key := cache.Key{
+		Name:      store.GetObjectMeta().Name,
+		Namespace: store.GetObjectMeta().Namespace,
+		Kind:      store.GetTypeMeta().Kind,
+	}
+	version := store.GetObjectMeta().ResourceVersion + "/" + tlsVersion
+	if pooled, ok := transportCache.Get(version, key); ok {
+		return pooled
+	}
+	transportCache.Add(version, key, t)
+	return t
+}
+
+func countConnections(dial func(ctx context.Context, network, addr string) (net.Conn, error)) func(ctx context.Context, network, addr string) (net.Conn, error) {
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		conn, err := dial(ctx, network, addr)
+		if err != nil {
+			return nil, err
+		}
+		metrics.ObserveConnectionOpened(constants.ProviderHCVault)
+		return &countedConn{Conn: conn}, nil
+	}
+}
+
+// countedConn decrements the open connections metric once it is closed.
+type countedConn struct {
+	net.Conn
+	once sync.Once
+}
+
+func (c *countedConn) Close() error {
+	c.once.Do(func() {
+		metrics.ObserveConnectionClosed(constants.ProviderHCVault)
+	})
+	return c.Conn.Close()
+}
+
+func init() {
+	var transportCacheSize int
+	fs := pflag.NewFlagSet("vault-transport", pflag.ExitOnError)
+	fs.IntVar(&transportMaxIdleConns, "vault-max-idle-conns", transportMaxIdleConns, "Maximum number of idle connections to Vault per SecretStore, 0 means no limit.")
+	fs.IntVar(&transportMaxIdleConnsPerHost, "vault-max-idle-conns-per-host", transportMaxIdleConnsPerHost, "Maximum number of idle connections to a Vault host per SecretStore. Defaults to the number of CPUs + 1.")
+	fs.DurationVar(&transportIdleConnTimeout, "vault-idle-conn-timeout", transportIdleConnTimeout, "Time an idle connection to Vault is kept open, 0 means no limit.")
+	fs.BoolVar(&transportEnableHTTP2, "vault-enable-http2", transportEnableHTTP2, "Use HTTP/2 for connections to Vault if the server supports it.")
+	fs.IntVar(&transportCacheSize, "vault-transport-cache-size", 1024, "Maximum number of SecretStores whose Vault connections are pooled, 0 disables pooling.")
+	feature.Register(feature.Feature{
+		Flags: fs,
+		Initialize: func() {
+			if transportCacheSize <= 0 {
+				return
+			}
+			transportCache = cache.Must(transportCacheSize, func(transport *http.Transport) {
+				transport.CloseIdleConnections()
+			})
+		},
+	})
+}
@@ -0,0 +1,147 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vault
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"slices"
+	"testing"
+	"time"
+
+	vault "github.com/hashicorp/vault/api"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	"github.com/external-secrets/external-secrets/pkg/cache"
+	"github.com/external-secrets/external-secrets/pkg/constants"
+)
+
+func TestConfigureTransport(t *testing.T) {
+	defer func(conns, perHost int, timeout time.Duration, http2 bool) {
+		transportMaxIdleConns, transportMaxIdleConnsPerHost, transportIdleConnTimeout, transportEnableHTTP2 = conns, perHost, timeout, http2
+	}(transportMaxIdleConns, transportMaxIdleConnsPerHost, transportIdleConnTimeout, transportEnableHTTP2)
+
+	cfg := vault.DefaultConfig()
+	configureTransport(cfg)
+	transport := cfg.HttpClient.Transport.(*http.Transport)
+	if !slices.Contains(transport.TLSClientConfig.NextProtos, http2Proto) {
+		t.Errorf("expected HTTP/2 to be enabled by default, got %v", transport.TLSClientConfig.NextProtos)
+	}
+
+	transportMaxIdleConns = 10
+	transportMaxIdleConnsPerHost = 5
+	transportIdleConnTimeout = time.Minute
+	transportEnableHTTP2 = false
+	cfg = vault.DefaultConfig()
+	configureTransport(cfg)
+	transport = cfg.HttpClient.Transport.(*http.Transport)
+	if transport.MaxIdleConns != 10 || transport.MaxIdleConnsPerHost != 5 || transport.IdleConnTimeout != time.Minute {
+		t.Errorf("unexpected tuning: maxIdleConns=%d maxIdleConnsPerHost=%d idleConnTimeout=%s",
+			transport.MaxIdleConns, transport.MaxIdleConnsPerHost, transport.IdleConnTimeout)
+	}
+	if slices.Contains(transport.TLSClientConfig.NextProtos, http2Proto) || transport.TLSNextProto == nil || len(transport.TLSNextProto) != 0 {
+		t.Errorf("expected HTTP/2 to be disabled, got %v", transport.TLSClientConfig.NextProtos)
+	}
+}
+
+func TestPooledTransport(t *testing.T) {
+	defer func(c *cache.Cache[*http.Transport]) {
+		transportCache = c
+	}(transportCache)
+	var closed []*http.Transport
+	transportCache = cache.Must(10, func(transport *http.Transport) {
+		closed = append(closed, transport)
+	})
+
+	store := makeValidSecretStoreWithVersion(esv1beta1.VaultKVStoreV2)
+	store.ResourceVersion = "1"
+	first := &http.Transport{}
+	if got := pooledTransport(store, "a", first); got != first {
+		t.Fatalf("expected the first transport to be pooled")
+	}
+	if got := pooledTransport(store, "a", &http.Transport{}); got != first {
+		t.Errorf("expected the pooled transport to be reused")
+	}
+
+	// changed TLS material replaces the pooled transport
+	second := &http.Transport{}
+	if got := pooledTransport(store, "b", second); got != second {
+		t.Errorf("expected a new transport for changed TLS material")
+	}
+	if len(closed) != 1 || closed[0] != first {
+		t.Errorf("expected the replaced transport to be cleaned up")
+	}
+
+	// a changed store replaces the pooled transport
+	store.ResourceVersion = "2"
+	third := &http.Transport{}
+	if got := pooledTransport(store, "b", third); got != third {
+		t.Errorf("expected a new transport for a changed store")
+	}
+
+	// other stores do not share transports
+	other := makeValidSecretStoreWithVersion(esv1beta1.VaultKVStoreV2)
+	other.Name = "other"
+	other.ResourceVersion = "2"
+	fourth := &http.Transport{}
+	if got := pooledTransport(other, "b", fourth); got != fourth {
+		t.Errorf("expected a separate transport per store")
+	}
+}
+
+// openConnections returns the value of the open connections metric for Vault.
+func openConnections(t *testing.T) float64 {
+	t.Helper()
+	families, err := ctrlmetrics.Registry.Gather()
+	if err != nil {
+		t.Fatalf("unable to gather metrics: %v", err)
+	}
+	for _, family := range families {
+		if family.GetName() != "externalsecret_provider_open_connections" {
+			continue
+		}
+		for _, m := range family.GetMetric() {
+			for _, label := range m.GetLabel() {
+				if label.GetName() == "provider" && label.GetValue() == constants.ProviderHCVault {
+					return m.GetGauge().GetValue()
+				}
+			}
+		}
+	}
+	return 0
+}
+
+func TestCountConnections(t *testing.T) {
+	server, client := net.Pipe()
+	defer server.Close()
+	dial := countConnections(func(_ context.Context, _, _ string) (net.Conn, error) {
+		return client, nil
+	})
+	before := openConnections(t)
+	conn, err := dial(context.Background(), "tcp", "vault.example.com:8200")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := openConnections(t) - before; got != 1 {
+		t.Errorf("expected one open connection, got %v", got)
+	}
+	_ = conn.Close()
+	_ = conn.Close()
+	if got := openConnections(t) - before; got != 0 {
+		t.Errorf("expected no open connection, got %v", got)
+	}
+}
@@ -30,10 +30,12 @@ import (
 	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
 	"github.com/external-secrets/external-secrets/pkg/common/webhook"
 	"github.com/external-secrets/external-secrets/pkg/utils"
+	"github.com/external-secrets/external-secrets/pkg/utils/clockskew"
 )
 
 const (
 	errNotImplemented = "not implemented"
+	errNoAudiences    = "auth.serviceAccountToken.serviceAccountRef must set at least one audience"
 )
 
 // https://github.com/external-secrets/external-secrets/issues/644
@@ -66,6 +68,7 @@ func (p *Provider) NewClient(ctx context.Context, store esv1beta1.GenericStore,
 		Kube:      kube,
 		Namespace: namespace,
 		StoreKind: store.GetObjectKind().GroupVersionKind().Kind,
+		ClockSkew: clockskew.ForStore(store),
 	}
 	whClient := &WebHook{
 		store:     store,
@@ -81,6 +84,9 @@ func (p *Provider) NewClient(ctx context.Context, store esv1beta1.GenericStore,
 		return nil, err
 	}
 	whClient.url = provider.URL
+	if err := whClient.wh.ConfigureAuth(provider); err != nil {
+		return nil, err
+	}
 
 	whClient.wh.HTTP, err = whClient.wh.GetHTTPClient(ctx, provider)
 	if err != nil {
@@ -89,7 +95,20 @@ func (p *Provider) NewClient(ctx context.Context, store esv1beta1.GenericStore,
 	return whClient, nil
 }
 
-func (p *Provider) ValidateStore(_ esv1beta1.GenericStore) (admission.Warnings, error) {
+func (p *Provider) ValidateStore(store esv1beta1.GenericStore) (admission.Warnings, error) {
+	provider, err := getProvider(store)
+	if err != nil {
+		return nil, err
+	}
+	if provider.Auth != nil && provider.Auth.ServiceAccountToken != nil {
+		ref := provider.Auth.ServiceAccountToken.ServiceAccountRef
+		if len(ref.Audiences) == 0 {
+			return nil, errors.New(errNoAudiences)
+		}
+		if err := utils.ValidateReferentServiceAccountSelector(store, ref); err != nil {
+			return nil, fmt.Errorf("invalid auth.serviceAccountToken.serviceAccountRef: %w", err)
+		}
+	}
 	return nil, nil
 }
 
@@ -17,7 +17,9 @@ package webhook
 import (
 	"bytes"
 	"context"
+	"encoding/pem"
 	"errors"
+	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
@@ -26,9 +28,15 @@ import (
 	"time"
 
 	"gopkg.in/yaml.v3"
+	authv1 "k8s.io/api/authentication/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
 
 	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	esmeta "github.com/external-secrets/external-secrets/apis/meta/v1"
+	"github.com/external-secrets/external-secrets/pkg/utils"
 )
 
 type testCase struct {
@@ -353,6 +361,207 @@ func TestWebhookGetSecret(t *testing.T) {
 	}
 }
 
+func TestWebhookServiceAccountTokenAuth(t *testing.T) {
+	const audience = "secrets.example.com"
+	const token = "service-account-token"
+	kube := fake.NewClientBuilder().WithInterceptorFuncs(interceptor.Funcs{
+		SubResourceCreate: func(_ context.Context, _ client.Client, _ string, obj client.Object, req client.Object, _ ...client.SubResourceCreateOption) error {
+			tokenRequest := req.(*authv1.TokenRequest)
+			if obj.GetNamespace() != "testnamespace" || obj.GetName() != "webhook" {
+				return fmt.Errorf("unexpected service account %s/%s", obj.GetNamespace(), obj.GetName())
+			}
+			if len(tokenRequest.Spec.Audiences) != 1 || tokenRequest.Spec.Audiences[0] != audience {
+				return fmt.Errorf("unexpected audiences %v", tokenRequest.Spec.Audiences)
+			}
+			tokenRequest.Status.Token = token
+			tokenRequest.Status.ExpirationTimestamp = metav1.NewTime(time.Now().Add(time.Hour))
+			return nil
+		},
+	}).Build()
+
+	ts := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if req.Header.Get("Authorization") != "Bearer "+token {
+			rw.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		rw.Write([]byte("secret-value"))
+	}))
+	defer ts.Close()
+
+	tests := []struct {
+		name      string
+		audiences []string
+		want      string
+		wantErr   string
+	}{
+		{
+			name:      "sends token as bearer header",
+			audiences: []string{audience},
+			want:      "secret-value",
+		},
+		{
+			name:      "fails on token request errors",
+			audiences: []string{"other.example.com"},
+			wantErr:   "failed to get auth token",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := makeClusterSecretStore(ts.URL, args{URL: "/api/getsecret"})
+			store.Spec.Provider.Webhook.Auth = &esv1beta1.WebhookAuth{
+				ServiceAccountToken: &esv1beta1.WebhookServiceAccountTokenAuth{
+					ServiceAccountRef: esmeta.ServiceAccountSelector{Name: "webhook", Audiences: tt.audiences},
+				},
+			}
+			client, err := (&Provider{}).NewClient(context.Background(), store, kube, "testnamespace")
+			if err != nil {
+				t.Fatalf("error creating client: %v", err)
+			}
+			got, err := client.GetSecret(context.Background(), esv1beta1.ExternalSecretDataRemoteRef{Key: "foo"})
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("unexpected response: %q (expected %q)", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateStoreServiceAccountTokenAuth(t *testing.T) {
+	otherNamespace := "other"
+	tests := []struct {
+		name    string
+		ref     esmeta.ServiceAccountSelector
+		wantErr string
+	}{
+		{
+			name: "valid ref",
+			ref:  esmeta.ServiceAccountSelector{Name: "webhook", Audiences: []string{"secrets.example.com"}},
+		},
+		{
+			name:    "missing audience",
+			ref:     esmeta.ServiceAccountSelector{Name: "webhook"},
+			wantErr: errNoAudiences,
+		},
+		{
+			name:    "namespace of a SecretStore ref",
+			ref:     esmeta.ServiceAccountSelector{Name: "webhook", Namespace: &otherNamespace, Audiences: []string{"secrets.example.com"}},
+			wantErr: "invalid auth.serviceAccountToken.serviceAccountRef",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := &esv1beta1.SecretStore{
+				ObjectMeta: metav1.ObjectMeta{Name: "webhook", Namespace: "default"},
+				Spec: esv1beta1.SecretStoreSpec{Provider: &esv1beta1.SecretStoreProvider{Webhook: &esv1beta1.WebhookProvider{
+					URL: "http://webhook.example.com",
+					Auth: &esv1beta1.WebhookAuth{
+						ServiceAccountToken: &esv1beta1.WebhookServiceAccountTokenAuth{ServiceAccountRef: tt.ref},
+					},
+				}}},
+			}
+			_, err := (&Provider{}).ValidateStore(store)
+			if tt.wantErr == "" && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)) {
+				t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestWebhookProviderOptions(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		// store headers take precedence over provider options
+		if req.Header.Get("X-SecretKey") != "foo" || req.Header.Get("X-Tenant") != "team-a" {
+			rw.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		rw.Write([]byte("secret-value"))
+	}))
+	defer ts.Close()
+
+	tests := []struct {
+		name    string
+		options map[string]string
+		wantErr string
+	}{
+		{
+			name:    "allowed headers are sent",
+			options: map[string]string{"x-tenant": "team-a"},
+		},
+		{
+			name:    "store headers are not overridden",
+			options: map[string]string{"X-Tenant": "team-a", "X-SecretKey": "overridden"},
+		},
+		{
+			name:    "other headers are rejected",
+			options: map[string]string{"X-Tenant": "team-a", "Host": "other.example.com"},
+			wantErr: "providerOption Host is not allowed by the store",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := makeClusterSecretStore(ts.URL, args{URL: "/api/getsecret"})
+			store.Spec.Provider.Webhook.AllowedProviderOptionHeaders = []string{"X-Tenant", "x-secretkey"}
+			client, err := (&Provider{}).NewClient(context.Background(), store, nil, "testnamespace")
+			if err != nil {
+				t.Fatalf("error creating client: %v", err)
+			}
+			ctx := esv1beta1.ContextWithProviderOptions(context.Background(), tt.options)
+			got, err := client.GetSecret(ctx, esv1beta1.ExternalSecretDataRemoteRef{Key: "foo"})
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if string(got) != "secret-value" {
+				t.Errorf("unexpected response: %q", got)
+			}
+		})
+	}
+}
+
+func TestWebhookProxy(t *testing.T) {
+	// the proxy receives the request for the webhook host, which is not resolvable
+	var proxied string
+	proxy := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		proxied = req.URL.String()
+		rw.Write([]byte("secret-value"))
+	}))
+	defer proxy.Close()
+	t.Setenv("HTTP_PROXY", "http://127.0.0.1:1")
+
+	store := makeClusterSecretStore("http://webhook.example.com", args{URL: "/api/getsecret"})
+	store.Spec.Provider.Webhook.Proxy = &esv1beta1.ProxyConfig{HTTPProxy: proxy.URL}
+	client, err := (&Provider{}).NewClient(context.Background(), store, nil, "testnamespace")
+	if err != nil {
+		t.Fatalf("error creating client: %v", err)
+	}
+	got, err := client.GetSecret(context.Background(), esv1beta1.ExternalSecretDataRemoteRef{Key: "foo"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(got) != "secret-value" {
+		t.Errorf("unexpected response: %q", got)
+	}
+	if proxied != "http://webhook.example.com/api/getsecret" {
+		t.Errorf("unexpected proxied request: %q", proxied)
+	}
+}
+
 func testCaseServer(tc testCase, t *testing.T) *httptest.Server {
 	// Start a new server for every test case because the server wants to check the expected api path
 	return httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
@@ -482,3 +691,114 @@ func makeClusterSecretStore(url string, args args) *esv1beta1.ClusterSecretStore
 	}
 	return store
 }
+
+func TestWebhookServerName(t *testing.T) {
+	// the test certificate is valid for 127.0.0.1 and example.com, but not for webhook.internal
+	var serverName string
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		serverName = req.TLS.ServerName
+		rw.Write([]byte("secret-value"))
+	}))
+	defer ts.Close()
+	ca := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: ts.Certificate().Raw})
+
+	tests := []struct {
+		name       string
+		caBundle   []byte
+		serverName string
+		wantErr    string
+	}{
+		{
+			name:       "trusts the custom CA and sends the server name",
+			caBundle:   ca,
+			serverName: "example.com",
+		},
+		{
+			name:       "verifies the certificate against the server name",
+			caBundle:   ca,
+			serverName: "webhook.internal",
+			wantErr:    "certificate is valid for",
+		},
+		{
+			name:       "rejects the certificate without the custom CA",
+			serverName: "example.com",
+			wantErr:    "certificate signed by unknown authority",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			serverName = ""
+			store := makeClusterSecretStore(ts.URL, args{URL: "/api/getsecret"})
+			store.Spec.Provider.Webhook.CABundle = tt.caBundle
+			store.Spec.Provider.Webhook.ServerName = tt.serverName
+			client, err := (&Provider{}).NewClient(context.Background(), store, nil, "testnamespace")
+			if err != nil {
+				t.Fatalf("error creating client: %v", err)
+			}
+			got, err := client.GetSecret(context.Background(), esv1beta1.ExternalSecretDataRemoteRef{Key: "foo"})
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if string(got) != "secret-value" {
+				t.Errorf("unexpected response: %q", got)
+			}
+			if serverName != tt.serverName {
+				t.Errorf("unexpected server name: %q (expected %q)", serverName, tt.serverName)
+			}
+		})
+	}
+}
+
+func TestWebhookTLSPinnedKeys(t *testing.T) {
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(rw http.ResponseWriter, _ *http.Request) {
+		rw.Write([]byte("secret-value"))
+	}))
+	defer ts.Close()
+	ca := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: ts.Certificate().Raw})
+
+	tests := []struct {
+		name    string
+		pins    []string
+		wantErr error
+	}{
+		{
+			name: "accepts the pinned key",
+			pins: []string{"sha256/AAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA=", utils.TLSKeyPin(ts.Certificate())},
+		},
+		{
+			name:    "refuses a certificate of a trusted CA with another key",
+			pins:    []string{"sha256/AAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA="},
+			wantErr: esv1beta1.ErrTLSPinMismatch,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := makeClusterSecretStore(ts.URL, args{URL: "/api/getsecret"})
+			store.Spec.Provider.Webhook.CABundle = ca
+			store.Spec.Provider.Webhook.TLSPinnedKeys = tt.pins
+			client, err := (&Provider{}).NewClient(context.Background(), store, nil, "testnamespace")
+			if err != nil {
+				t.Fatalf("error creating client: %v", err)
+			}
+			got, err := client.GetSecret(context.Background(), esv1beta1.ExternalSecretDataRemoteRef{Key: "foo"})
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("expected %v, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if string(got) != "secret-value" {
+				t.Errorf("unexpected response: %q", got)
+			}
+		})
+	}
+}
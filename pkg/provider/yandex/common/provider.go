@@ -31,6 +31,7 @@ import (
 	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
 	esmeta "github.com/external-secrets/external-secrets/apis/meta/v1"
 	clock2 "github.com/external-secrets/external-secrets/pkg/provider/yandex/common/clock"
+	"github.com/external-secrets/external-secrets/pkg/utils/clockskew"
 	"github.com/external-secrets/external-secrets/pkg/utils/resolvers"
 )
 
@@ -153,7 +154,7 @@ func (p *YandexCloudProvider) NewClient(ctx context.Context, store esv1beta1.Gen
 		return nil, fmt.Errorf("failed to create Yandex.Cloud client: %w", err)
 	}
 
-	iamToken, err := p.getOrCreateIamToken(ctx, input.APIEndpoint, &authorizedKey, caCertificateData)
+	iamToken, err := p.getOrCreateIamToken(ctx, input.APIEndpoint, &authorizedKey, caCertificateData, clockskew.ForStore(store))
 	if err != nil {
 		return nil, fmt.Errorf("failed to create IAM token: %w", err)
 	}
@@ -177,12 +178,12 @@ func (p *YandexCloudProvider) getOrCreateSecretGetter(ctx context.Context, apiEn
 	return p.secretGetteMap[apiEndpoint], nil
 }
 
-func (p *YandexCloudProvider) getOrCreateIamToken(ctx context.Context, apiEndpoint string, authorizedKey *iamkey.Key, caCertificate []byte) (*IamToken, error) {
+func (p *YandexCloudProvider) getOrCreateIamToken(ctx context.Context, apiEndpoint string, authorizedKey *iamkey.Key, caCertificate []byte, clockSkew time.Duration) (*IamToken, error) {
 	p.iamTokenMapMutex.Lock()
 	defer p.iamTokenMapMutex.Unlock()
 
 	iamTokenKey := buildIamTokenKey(authorizedKey)
-	if iamToken, ok := p.iamTokenMap[iamTokenKey]; !ok || !p.isIamTokenUsable(iamToken) {
+	if iamToken, ok := p.iamTokenMap[iamTokenKey]; !ok || !p.isIamTokenUsable(iamToken, clockSkew) {
 		p.logger.Info("creating IAM token", "authorizedKeyId", authorizedKey.Id)
 
 		iamToken, err := p.newIamTokenFunc(ctx, apiEndpoint, authorizedKey, caCertificate)
@@ -197,9 +198,11 @@ func (p *YandexCloudProvider) getOrCreateIamToken(ctx context.Context, apiEndpoi
 	return p.iamTokenMap[iamTokenKey], nil
 }
 
-func (p *YandexCloudProvider) isIamTokenUsable(iamToken *IamToken) bool {
+// isIamTokenUsable returns true if the token outlives the SecretsClient,
+// even if the clock of Yandex.Cloud is ahead by up to clockSkew.
+func (p *YandexCloudProvider) isIamTokenUsable(iamToken *IamToken, clockSkew time.Duration) bool {
 	now := p.clock.CurrentTime()
-	return now.Add(maxSecretsClientLifetime).Before(iamToken.ExpiresAt)
+	return now.Add(maxSecretsClientLifetime).Before(clockskew.RefreshBefore(iamToken.ExpiresAt, clockSkew))
 }
 
 func buildIamTokenKey(authorizedKey *iamkey.Key) iamTokenKey {
@@ -37,3 +37,20 @@ func EngineForVersion(version esapi.TemplateEngineVersion) (ExecFunc, error) {
 	}
 	return nil, fmt.Errorf("unsupported template engine version: %s", version)
 }
+
+// EngineWithPrevious returns the engine for the version like EngineForVersion.
+// The v2 engine exposes previous as `.Previous`, the v1 engine ignores it.
+func EngineWithPrevious(version esapi.TemplateEngineVersion, previous map[string][]byte) (ExecFunc, error) {
+	return EngineWithContext(version, v2.Context{Previous: previous})
+}
+
+// EngineWithContext returns the engine for the version like EngineForVersion.
+// The v2 engine exposes the fields of tplCtx, the v1 engine ignores it.
+func EngineWithContext(version esapi.TemplateEngineVersion, tplCtx v2.Context) (ExecFunc, error) {
+	if version != esapi.TemplateEngineV2 {
+		return EngineForVersion(version)
+	}
+	return func(tpl, data map[string][]byte, scope esapi.TemplateScope, target esapi.TemplateTarget, secret *corev1.Secret) error {
+		return v2.ExecuteWithContext(tpl, data, tplCtx, scope, target, secret)
+	}, nil
+}
@@ -0,0 +1,123 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package template
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	tpl "text/template"
+	"text/template/parse"
+
+	v1 "github.com/external-secrets/external-secrets/pkg/template/v1"
+)
+
+// v2Changes maps the functions of the v1 engine to how they behave differently with the v2 engine.
+var v2Changes = map[string]string{
+	"base64encode":   "is renamed to b64enc in v2",
+	"base64decode":   "is renamed to b64dec in v2, which ignores decoding errors",
+	"fromJSON":       "is renamed to fromJson in v2, which ignores unmarshalling errors",
+	"toJSON":         "is renamed to toJson in v2, which ignores marshalling errors",
+	"toBytes":        "is removed in v2, values are strings",
+	"pemPrivateKey":  "is removed in v2, pkcs12key and pkcs12keyPass return PEM",
+	"pemCertificate": "is removed in v2, pkcs12cert and pkcs12certPass return PEM",
+	"pkcs12key":      "returns all private keys as PEM in v2 instead of the first key as DER",
+	"pkcs12keyPass":  "returns all private keys as PEM in v2 instead of the first key as DER",
+	"pkcs12cert":     "returns all certificates as PEM in v2 instead of the first certificate as DER",
+	"pkcs12certPass": "returns all certificates as PEM in v2 instead of the first certificate as DER",
+}
+
+// Incompatibility is a function call of a v1 template that behaves differently with the v2 engine.
+type Incompatibility struct {
+	// Key is the key of the template.
+	Key string
+	// Function is the name of the v1 function.
+	Function string
+	// Change describes how the function behaves with the v2 engine.
+	Change string
+}
+
+func (i Incompatibility) String() string {
+	return fmt.Sprintf("%s: %s %s", i.Key, i.Function, i.Change)
+}
+
+// LintV1 returns the function calls of the v1 templates that behave differently with the v2 engine,
+// sorted by key and function. Templates that can not be parsed are skipped, the engine reports them.
+func LintV1(templates map[string]string) []Incompatibility {
+	var out []Incompatibility
+	for _, key := range slices.Sorted(maps.Keys(templates)) {
+		t, err := tpl.New(key).Funcs(v1.FuncMap()).Parse(templates[key])
+		if err != nil {
+			continue
+		}
+		functions := make(map[string]bool)
+		// the templates defined with {{ define }} are parsed into separate trees
+		for _, defined := range t.Templates() {
+			if defined.Tree != nil {
+				collectFunctions(defined.Tree.Root, functions)
+			}
+		}
+		for _, fn := range slices.Sorted(maps.Keys(functions)) {
+			if change, ok := v2Changes[fn]; ok {
+				out = append(out, Incompatibility{Key: key, Function: fn, Change: change})
+			}
+		}
+	}
+	return out
+}
+
+// collectFunctions adds the names of the functions called in the node and its children to functions.
+func collectFunctions(node parse.Node, functions map[string]bool) {
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return
+		}
+		for _, child := range n.Nodes {
+			collectFunctions(child, functions)
+		}
+	case *parse.ActionNode:
+		collectFunctions(n.Pipe, functions)
+	case *parse.PipeNode:
+		if n == nil {
+			return
+		}
+		for _, cmd := range n.Cmds {
+			collectFunctions(cmd, functions)
+		}
+	case *parse.CommandNode:
+		for _, arg := range n.Args {
+			collectFunctions(arg, functions)
+		}
+	case *parse.ChainNode:
+		collectFunctions(n.Node, functions)
+	case *parse.IdentifierNode:
+		functions[n.Ident] = true
+	case *parse.IfNode:
+		collectBranch(&n.BranchNode, functions)
+	case *parse.RangeNode:
+		collectBranch(&n.BranchNode, functions)
+	case *parse.WithNode:
+		collectBranch(&n.BranchNode, functions)
+	case *parse.TemplateNode:
+		collectFunctions(n.Pipe, functions)
+	}
+}
+
+func collectBranch(n *parse.BranchNode, functions map[string]bool) {
+	collectFunctions(n.Pipe, functions)
+	collectFunctions(n.List, functions)
+	collectFunctions(n.ElseList, functions)
+}
@@ -0,0 +1,74 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package template
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestLintV1(t *testing.T) {
+	tests := []struct {
+		name      string
+		templates map[string]string
+		want      []Incompatibility
+	}{
+		{
+			name: "compatible template",
+			templates: map[string]string{
+				"config": `user={{ .user | toString | upper }}`,
+			},
+		},
+		{
+			name: "renamed and removed functions",
+			templates: map[string]string{
+				"token": `{{ .token | base64decode | toString }}`,
+				"json":  `{{ (.config | fromJSON).name | toBytes }}`,
+			},
+			want: []Incompatibility{
+				{Key: "json", Function: "fromJSON", Change: v2Changes["fromJSON"]},
+				{Key: "json", Function: "toBytes", Change: v2Changes["toBytes"]},
+				{Key: "token", Function: "base64decode", Change: v2Changes["base64decode"]},
+			},
+		},
+		{
+			name: "functions in branches and defined templates",
+			templates: map[string]string{
+				"tls.key": `{{ define "key" }}{{ .p12 | pkcs12key | pemPrivateKey }}{{ end }}` +
+					`{{ if .p12 }}{{ template "key" . }}{{ else }}{{ .key | base64encode }}{{ end }}`,
+			},
+			want: []Incompatibility{
+				{Key: "tls.key", Function: "base64encode", Change: v2Changes["base64encode"]},
+				{Key: "tls.key", Function: "pemPrivateKey", Change: v2Changes["pemPrivateKey"]},
+				{Key: "tls.key", Function: "pkcs12key", Change: v2Changes["pkcs12key"]},
+			},
+		},
+		{
+			name: "template that can not be parsed is skipped",
+			templates: map[string]string{
+				"broken": `{{ .user | base64encode `,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := LintV1(tt.templates)
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("unexpected incompatibilities (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
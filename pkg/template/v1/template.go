@@ -21,6 +21,8 @@ import (
 	"encoding/json"
 	"encoding/pem"
 	"fmt"
+	"maps"
+	"slices"
 	"strings"
 	tpl "text/template"
 
@@ -88,8 +90,8 @@ func Execute(tpl, data map[string][]byte, scope esapi.TemplateScope, target esap
 	case esapi.TemplateTargetLabels:
 		// Labels are not supported in v1 templates
 	case esapi.TemplateTargetData, "":
-		for k, v := range tpl {
-			val, err := execute(k, string(v), data)
+		for _, k := range slices.Sorted(maps.Keys(tpl)) {
+			val, err := execute(k, string(tpl[k]), data)
 			if err != nil {
 				return fmt.Errorf(errExecute, k, err)
 			}
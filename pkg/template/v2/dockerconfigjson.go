@@ -0,0 +1,92 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package template
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+const (
+	errDockerConfigJSONArgs     = "dockerconfigjson expects registry, username and password for every registry, got %d arguments"
+	errDockerConfigJSONEmail    = "dockerconfigjsonEmail expects registry, username, password and email for every registry, got %d arguments"
+	errDockerConfigJSONRegistry = "registry %q is given more than once"
+	errDockerConfigJSONEmpty    = "registry must not be empty"
+)
+
+// dockerConfigJSON mirrors the .dockerconfigjson written by `kubectl create secret docker-registry`.
+type dockerConfigJSON struct {
+	Auths map[string]dockerConfigEntry `json:"auths"`
+}
+
+type dockerConfigEntry struct {
+	Username string `json:"username,omitempty"`
+	Password string `json:"password,omitempty"`
+	Email    string `json:"email,omitempty"`
+	Auth     string `json:"auth,omitempty"`
+}
+
+// dockerconfigjson returns the .dockerconfigjson of one or more registries,
+// given as registry, username and password triples.
+func dockerconfigjson(args ...string) (string, error) {
+	if len(args) == 0 || len(args)%3 != 0 {
+		return "", fmt.Errorf(errDockerConfigJSONArgs, len(args))
+	}
+	entries := make([][4]string, 0, len(args)/3)
+	for i := 0; i < len(args); i += 3 {
+		entries = append(entries, [4]string{args[i], args[i+1], args[i+2], ""})
+	}
+	return buildDockerConfigJSON(entries)
+}
+
+// dockerconfigjsonEmail is dockerconfigjson with the email of every registry,
+// given as registry, username, password and email quadruples.
+func dockerconfigjsonEmail(args ...string) (string, error) {
+	if len(args) == 0 || len(args)%4 != 0 {
+		return "", fmt.Errorf(errDockerConfigJSONEmail, len(args))
+	}
+	entries := make([][4]string, 0, len(args)/4)
+	for i := 0; i < len(args); i += 4 {
+		entries = append(entries, [4]string{args[i], args[i+1], args[i+2], args[i+3]})
+	}
+	return buildDockerConfigJSON(entries)
+}
+
+func buildDockerConfigJSON(entries [][4]string) (string, error) {
+	cfg := dockerConfigJSON{Auths: make(map[string]dockerConfigEntry, len(entries))}
+	for _, e := range entries {
+		registry, username, password, email := e[0], e[1], e[2], e[3]
+		if registry == "" {
+			return "", errors.New(errDockerConfigJSONEmpty)
+		}
+		if _, ok := cfg.Auths[registry]; ok {
+			return "", fmt.Errorf(errDockerConfigJSONRegistry, registry)
+		}
+		cfg.Auths[registry] = dockerConfigEntry{
+			Username: username,
+			Password: password,
+			Email:    email,
+			Auth:     base64.StdEncoding.EncodeToString([]byte(username + ":" + password)),
+		}
+	}
+	// json.Marshal sorts the registries and escapes like kubectl does
+	out, err := json.Marshal(cfg)
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
@@ -0,0 +1,102 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package template
+
+import (
+	"testing"
+)
+
+func TestDockerConfigJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		fn      func(args ...string) (string, error)
+		args    []string
+		want    string
+		wantErr bool
+	}{
+		{
+			// kubectl create secret docker-registry --docker-server=registry.example.com --docker-username=user --docker-password=pass
+			name: "single registry",
+			fn:   dockerconfigjson,
+			args: []string{"registry.example.com", "user", "pass"},
+			want: `{"auths":{"registry.example.com":{"username":"user","password":"pass","auth":"dXNlcjpwYXNz"}}}`,
+		},
+		{
+			// kubectl create secret docker-registry ... --docker-email=user@example.com
+			name: "single registry with email",
+			fn:   dockerconfigjsonEmail,
+			args: []string{"registry.example.com", "user", "pass", "user@example.com"},
+			want: `{"auths":{"registry.example.com":{"username":"user","password":"pass","email":"user@example.com","auth":"dXNlcjpwYXNz"}}}`,
+		},
+		{
+			name: "special characters are escaped like kubectl",
+			fn:   dockerconfigjson,
+			args: []string{"https://index.docker.io/v1/", "user", "p&ss<>"},
+			want: `{"auths":{"https://index.docker.io/v1/":{"username":"user","password":"p\u0026ss\u003c\u003e","auth":"dXNlcjpwJnNzPD4="}}}`,
+		},
+		{
+			name: "multiple registries",
+			fn:   dockerconfigjson,
+			args: []string{"registry.example.com", "user", "pass", "ghcr.io", "robot$ci", "tok"},
+			want: `{"auths":{"ghcr.io":{"username":"robot$ci","password":"tok","auth":"cm9ib3QkY2k6dG9r"},"registry.example.com":{"username":"user","password":"pass","auth":"dXNlcjpwYXNz"}}}`,
+		},
+		{
+			name: "multiple registries with email",
+			fn:   dockerconfigjsonEmail,
+			args: []string{"registry.example.com", "user", "pass", "user@example.com", "ghcr.io", "robot$ci", "tok", ""},
+			want: `{"auths":{"ghcr.io":{"username":"robot$ci","password":"tok","auth":"cm9ib3QkY2k6dG9r"},"registry.example.com":{"username":"user","password":"pass","email":"user@example.com","auth":"dXNlcjpwYXNz"}}}`,
+		},
+		{
+			name:    "missing password",
+			fn:      dockerconfigjson,
+			args:    []string{"registry.example.com", "user"},
+			wantErr: true,
+		},
+		{
+			name:    "missing email",
+			fn:      dockerconfigjsonEmail,
+			args:    []string{"registry.example.com", "user", "pass"},
+			wantErr: true,
+		},
+		{
+			name:    "no arguments",
+			fn:      dockerconfigjson,
+			wantErr: true,
+		},
+		{
+			name:    "empty registry",
+			fn:      dockerconfigjson,
+			args:    []string{"", "user", "pass"},
+			wantErr: true,
+		},
+		{
+			name:    "duplicate registry",
+			fn:      dockerconfigjson,
+			args:    []string{"ghcr.io", "user", "pass", "ghcr.io", "other", "pass"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.fn(tt.args...)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("got error %v, want error %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("got %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
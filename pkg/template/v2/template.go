@@ -17,6 +17,8 @@ package template
 import (
 	"bytes"
 	"fmt"
+	"maps"
+	"slices"
 	tpl "text/template"
 
 	"github.com/Masterminds/sprig/v3"
@@ -45,6 +47,9 @@ var tplFuncs = tpl.FuncMap{
 
 	"toYaml":   toYAML,
 	"fromYaml": fromYAML,
+
+	"dockerconfigjson":      dockerconfigjson,
+	"dockerconfigjsonEmail": dockerconfigjsonEmail,
 }
 
 // So other templating calls can use the same extra functions.
@@ -52,6 +57,23 @@ func FuncMap() tpl.FuncMap {
 	return tplFuncs
 }
 
+const (
+	// PreviousKey is the name of the previous data of the target secret in the template context.
+	PreviousKey = "Previous"
+	// NamespaceKey is the name of the namespace metadata of the target secret in the template context.
+	NamespaceKey = "Namespace"
+)
+
+// Context is the data available to a template besides the secret data.
+// Keys of the secret data take precedence over the context.
+type Context struct {
+	// Previous is the data of the target secret before the update, available as `.Previous`.
+	Previous map[string][]byte
+	// Namespace is the metadata of the namespace of the target secret, available as `.Namespace`
+	// with the keys name, labels and annotations. It is only set if not nil.
+	Namespace map[string]any
+}
+
 const (
 	errParse                = "unable to parse template at key %s: %s"
 	errExecute              = "unable to execute template at key %s: %s"
@@ -94,9 +116,9 @@ func applyToTarget(k, val string, target esapi.TemplateTarget, secret *corev1.Se
 	}
 }
 
-func valueScopeApply(tplMap, data map[string][]byte, target esapi.TemplateTarget, secret *corev1.Secret) error {
-	for k, v := range tplMap {
-		val, err := execute(k, string(v), data)
+func valueScopeApply(tplMap, data map[string][]byte, tplCtx Context, target esapi.TemplateTarget, secret *corev1.Secret) error {
+	for _, k := range slices.Sorted(maps.Keys(tplMap)) {
+		val, err := execute(k, string(tplMap[k]), data, tplCtx)
 		if err != nil {
 			return fmt.Errorf(errExecute, k, err)
 		}
@@ -105,8 +127,8 @@ func valueScopeApply(tplMap, data map[string][]byte, target esapi.TemplateTarget
 	return nil
 }
 
-func mapScopeApply(tpl string, data map[string][]byte, target esapi.TemplateTarget, secret *corev1.Secret) error {
-	val, err := execute(tpl, tpl, data)
+func mapScopeApply(tpl string, data map[string][]byte, tplCtx Context, target esapi.TemplateTarget, secret *corev1.Secret) error {
+	val, err := execute(tpl, tpl, data, tplCtx)
 	if err != nil {
 		return fmt.Errorf(errExecute, tpl, err)
 	}
@@ -115,27 +137,42 @@ func mapScopeApply(tpl string, data map[string][]byte, target esapi.TemplateTarg
 	if err != nil {
 		return fmt.Errorf("could not unmarshal template to 'map[string][]byte': %w", err)
 	}
-	for k, val := range src {
-		applyToTarget(k, val, target, secret)
+	for _, k := range slices.Sorted(maps.Keys(src)) {
+		applyToTarget(k, src[k], target, secret)
 	}
 	return nil
 }
 
 // Execute renders the secret data as template. If an error occurs processing is stopped immediately.
 func Execute(tpl, data map[string][]byte, scope esapi.TemplateScope, target esapi.TemplateTarget, secret *corev1.Secret) error {
+	return ExecuteWithPrevious(tpl, data, nil, scope, target, secret)
+}
+
+// ExecuteWithPrevious renders the secret data as template like Execute.
+// The previous data of the target secret is available as `.Previous`,
+// unless the secret data has a key with that name.
+func ExecuteWithPrevious(tpl, data, previous map[string][]byte, scope esapi.TemplateScope, target esapi.TemplateTarget, secret *corev1.Secret) error {
+	return ExecuteWithContext(tpl, data, Context{Previous: previous}, scope, target, secret)
+}
+
+// ExecuteWithContext renders the secret data as template like Execute,
+// with the fields of tplCtx available in the template.
+// Templates are executed in lexical order of their keys, so the result is the same
+// on every reconcile. With KeysAndValues the key of the last template wins on conflicts.
+func ExecuteWithContext(tpl, data map[string][]byte, tplCtx Context, scope esapi.TemplateScope, target esapi.TemplateTarget, secret *corev1.Secret) error {
 	if tpl == nil {
 		return nil
 	}
 	switch scope {
 	case esapi.TemplateScopeKeysAndValues:
-		for _, v := range tpl {
-			err := mapScopeApply(string(v), data, target, secret)
+		for _, k := range slices.Sorted(maps.Keys(tpl)) {
+			err := mapScopeApply(string(tpl[k]), data, tplCtx, target, secret)
 			if err != nil {
 				return err
 			}
 		}
 	case esapi.TemplateScopeValues:
-		err := valueScopeApply(tpl, data, target, secret)
+		err := valueScopeApply(tpl, data, tplCtx, target, secret)
 		if err != nil {
 			return err
 		}
@@ -145,11 +182,22 @@ func Execute(tpl, data map[string][]byte, scope esapi.TemplateScope, target esap
 	return nil
 }
 
-func execute(k, val string, data map[string][]byte) ([]byte, error) {
-	strValData := make(map[string]string, len(data))
+func execute(k, val string, data map[string][]byte, tplCtx Context) ([]byte, error) {
+	strValData := make(map[string]any, len(data)+2)
 	for k := range data {
 		strValData[k] = string(data[k])
 	}
+	if _, ok := strValData[PreviousKey]; !ok {
+		// always set, so templates can use `.Previous` on the first creation
+		prev := make(map[string]any, len(tplCtx.Previous))
+		for k := range tplCtx.Previous {
+			prev[k] = string(tplCtx.Previous[k])
+		}
+		strValData[PreviousKey] = prev
+	}
+	if _, ok := strValData[NamespaceKey]; !ok && tplCtx.Namespace != nil {
+		strValData[NamespaceKey] = tplCtx.Namespace
+	}
 
 	t, err := tpl.New(k).
 		Option("missingkey=error").
@@ -222,6 +222,19 @@ func TestExecute(t *testing.T) {
 				"foo": []byte(`{"foo":"bar"}`),
 			},
 		},
+		{
+			name: "dockerconfigjson func",
+			tpl: map[string][]byte{
+				".dockerconfigjson": []byte(`{{ dockerconfigjson "registry.example.com" .username .password }}`),
+			},
+			data: map[string][]byte{
+				"username": []byte(`user`),
+				"password": []byte(`pass`),
+			},
+			expectedData: map[string][]byte{
+				".dockerconfigjson": []byte(`{"auths":{"registry.example.com":{"username":"user","password":"pass","auth":"dXNlcjpwYXNz"}}}`),
+			},
+		},
 		{
 			name: "use sprig functions",
 			tpl: map[string][]byte{
@@ -609,6 +622,150 @@ func TestExecuteInvalidTemplateScope(t *testing.T) {
 	assert.ErrorContains(t, err, "expected 'Values' or 'KeysAndValues'")
 }
 
+func TestExecuteWithPrevious(t *testing.T) {
+	tbl := []struct {
+		name         string
+		tpl          map[string][]byte
+		data         map[string][]byte
+		previous     map[string][]byte
+		expectedData map[string][]byte
+		expErr       string
+	}{
+		{
+			name: "new key from previous key",
+			tpl: map[string][]byte{
+				"url": []byte(`{{ printf "postgres://%s@db" .Previous.user }}`),
+			},
+			previous: map[string][]byte{
+				"user": []byte("admin"),
+			},
+			expectedData: map[string][]byte{
+				"url": []byte("postgres://admin@db"),
+			},
+		},
+		{
+			name: "empty on first creation",
+			tpl: map[string][]byte{
+				"user": []byte(`{{ if hasKey .Previous "user" }}{{ .Previous.user }}{{ else }}{{ .user }}{{ end }}`),
+			},
+			data: map[string][]byte{
+				"user": []byte("new"),
+			},
+			expectedData: map[string][]byte{
+				"user": []byte("new"),
+			},
+		},
+		{
+			name: "removed key is missing",
+			tpl: map[string][]byte{
+				"user": []byte(`{{ .Previous.user }}`),
+			},
+			previous: map[string][]byte{},
+			expErr:   `map has no entry for key "user"`,
+		},
+		{
+			name: "data key takes precedence",
+			tpl: map[string][]byte{
+				"value": []byte(`{{ .Previous }}`),
+			},
+			data: map[string][]byte{
+				"Previous": []byte("from-provider"),
+			},
+			previous: map[string][]byte{
+				"value": []byte("old"),
+			},
+			expectedData: map[string][]byte{
+				"value": []byte("from-provider"),
+			},
+		},
+	}
+	for _, row := range tbl {
+		t.Run(row.name, func(t *testing.T) {
+			sec := &corev1.Secret{
+				Data: make(map[string][]byte),
+			}
+			err := ExecuteWithPrevious(row.tpl, row.data, row.previous, esapi.TemplateScopeValues, esapi.TemplateTargetData, sec)
+			if !ErrorContains(err, row.expErr) {
+				t.Fatalf("unexpected error: %s, expected: %s", err, row.expErr)
+			}
+			if row.expectedData != nil {
+				assert.EqualValues(t, row.expectedData, sec.Data)
+			}
+		})
+	}
+}
+
+func TestExecuteWithContextNamespace(t *testing.T) {
+	namespace := map[string]any{
+		"name":        "team-a",
+		"labels":      map[string]string{"tenant": "a"},
+		"annotations": map[string]string{},
+	}
+	tbl := []struct {
+		name         string
+		tpl          map[string][]byte
+		data         map[string][]byte
+		namespace    map[string]any
+		expectedData map[string][]byte
+		expErr       string
+	}{
+		{
+			name: "namespace labels",
+			tpl: map[string][]byte{
+				"url": []byte(`https://{{ .Namespace.labels.tenant }}.{{ .Namespace.name }}.example.com`),
+			},
+			namespace: namespace,
+			expectedData: map[string][]byte{
+				"url": []byte("https://a.team-a.example.com"),
+			},
+		},
+		{
+			name: "missing label",
+			tpl: map[string][]byte{
+				"region": []byte(`{{ index .Namespace.labels "region" | default "eu" }}`),
+			},
+			namespace: namespace,
+			expectedData: map[string][]byte{
+				"region": []byte("eu"),
+			},
+		},
+		{
+			name: "no namespace",
+			tpl: map[string][]byte{
+				"tenant": []byte(`{{ .Namespace.labels.tenant }}`),
+			},
+			expErr: `map has no entry for key "Namespace"`,
+		},
+		{
+			name: "data key takes precedence",
+			tpl: map[string][]byte{
+				"value": []byte(`{{ .Namespace }}`),
+			},
+			data: map[string][]byte{
+				"Namespace": []byte("from-provider"),
+			},
+			namespace: namespace,
+			expectedData: map[string][]byte{
+				"value": []byte("from-provider"),
+			},
+		},
+	}
+	for _, row := range tbl {
+		t.Run(row.name, func(t *testing.T) {
+			sec := &corev1.Secret{
+				Data: make(map[string][]byte),
+			}
+			err := ExecuteWithContext(row.tpl, row.data, Context{Namespace: row.namespace}, esapi.TemplateScopeValues, esapi.TemplateTargetData, sec)
+			if !ErrorContains(err, row.expErr) {
+				t.Fatalf("unexpected error: %s, expected: %s", err, row.expErr)
+			}
+			if row.expectedData != nil {
+				assert.EqualValues(t, row.expectedData, sec.Data)
+			}
+		})
+	}
+}
+
 func TestScopeKeysAndValues(t *testing.T) {
 	tbl := []struct {
 		name               string
@@ -691,6 +848,70 @@ func TestScopeKeysAndValues(t *testing.T) {
 		})
 	}
 }
+
+func TestExecuteDeterministic(t *testing.T) {
+	data := map[string][]byte{
+		"upstream": []byte("10.0.0.1"),
+		"port":     []byte("8080"),
+	}
+	render := func(tpl map[string][]byte, scope esapi.TemplateScope) (map[string][]byte, string) {
+		sec := &corev1.Secret{}
+		err := Execute(tpl, data, scope, esapi.TemplateTargetData, sec)
+		if err != nil {
+			return sec.Data, err.Error()
+		}
+		return sec.Data, ""
+	}
+
+	tbl := []struct {
+		name     string
+		tpl      map[string][]byte
+		scope    esapi.TemplateScope
+		expected map[string][]byte
+		expErr   string
+	}{
+		{
+			name: "keys and values with conflicting keys",
+			tpl: map[string][]byte{
+				"00-default":  []byte("nginx.conf: 'server {{ .upstream }}:80'"),
+				"10-override": []byte("nginx.conf: 'server {{ .upstream }}:{{ .port }}'"),
+				"20-extra":    []byte("upstream: '{{ .upstream }}'"),
+			},
+			scope: esapi.TemplateScopeKeysAndValues,
+			expected: map[string][]byte{
+				"nginx.conf": []byte("server 10.0.0.1:8080"),
+				"upstream":   []byte("10.0.0.1"),
+			},
+		},
+		{
+			name: "values with several invalid templates",
+			tpl: map[string][]byte{
+				"a": []byte("{{ .missing_a }}"),
+				"b": []byte("{{ .missing_b }}"),
+				"c": []byte("{{ .missing_c }}"),
+			},
+			scope:  esapi.TemplateScopeValues,
+			expErr: "unable to execute template at key a",
+		},
+	}
+	for _, row := range tbl {
+		t.Run(row.name, func(t *testing.T) {
+			first, firstErr := render(row.tpl, row.scope)
+			if !strings.Contains(firstErr, row.expErr) {
+				t.Fatalf("unexpected error: %s, expected: %s", firstErr, row.expErr)
+			}
+			if row.expected != nil {
+				assert.EqualValues(t, row.expected, first)
+			}
+			for range 50 {
+				got, gotErr := render(row.tpl, row.scope)
+				assert.Equal(t, first, got)
+				assert.Equal(t, firstErr, gotErr)
+			}
+		})
+	}
+}
+
 func ErrorContains(out error, want string) bool {
 	if out == nil {
 		return want == ""
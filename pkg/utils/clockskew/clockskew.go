@@ -0,0 +1,63 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package clockskew tolerates differences between the clock of the controller
+// and the clocks of providers when timestamps reported by a provider are compared.
+package clockskew
+
+import (
+	"time"
+
+	"github.com/spf13/pflag"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	"github.com/external-secrets/external-secrets/pkg/feature"
+)
+
+var defaultSkew time.Duration
+
+func init() {
+	fs := pflag.NewFlagSet("clock-skew", pflag.ExitOnError)
+	fs.DurationVar(&defaultSkew, "clock-skew", 0, "Tolerated difference between the clocks of the providers and the controller when expiry timestamps reported by a provider are compared. Can be overridden per store with spec.clockSkew.")
+	feature.Register(feature.Feature{
+		Flags: fs,
+	})
+}
+
+// Default returns the controller-wide tolerance set with --clock-skew.
+func Default() time.Duration {
+	return max(defaultSkew, 0)
+}
+
+// ForStore returns spec.clockSkew of the store, or the controller-wide tolerance if it is not set.
+func ForStore(store esv1beta1.GenericStore) time.Duration {
+	if store == nil || store.GetSpec() == nil || store.GetSpec().ClockSkew == nil {
+		return Default()
+	}
+	return max(store.GetSpec().ClockSkew.Duration, 0)
+}
+
+// Expired returns true if the expiry reported by a provider has passed,
+// even if the clock of the controller is ahead of the provider by up to skew.
+// Use it to decide whether a value is still valid.
+func Expired(expiresAt, now time.Time, skew time.Duration) bool {
+	return !now.Before(expiresAt.Add(skew))
+}
+
+// RefreshBefore returns the point in time at which a value that expires at expiresAt should be refreshed,
+// if the clock of the controller is behind the provider by up to skew.
+// Use it to schedule a refresh, so the value is replaced before the provider considers it expired.
+func RefreshBefore(expiresAt time.Time, skew time.Duration) time.Time {
+	return expiresAt.Add(-skew)
+}
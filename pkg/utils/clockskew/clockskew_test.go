@@ -0,0 +1,72 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clockskew
+
+import (
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+)
+
+func TestForStore(t *testing.T) {
+	defer func(skew time.Duration) { defaultSkew = skew }(defaultSkew)
+	defaultSkew = time.Minute
+
+	store := &esv1beta1.SecretStore{}
+	if got := ForStore(store); got != time.Minute {
+		t.Errorf("ForStore() = %v, want the controller-wide default", got)
+	}
+	store.Spec.ClockSkew = &metav1.Duration{Duration: 10 * time.Second}
+	if got := ForStore(store); got != 10*time.Second {
+		t.Errorf("ForStore() = %v, want the store override", got)
+	}
+	store.Spec.ClockSkew = &metav1.Duration{}
+	if got := ForStore(store); got != 0 {
+		t.Errorf("ForStore() = %v, want 0 to disable the tolerance", got)
+	}
+}
+
+func TestExpired(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name      string
+		expiresAt time.Time
+		skew      time.Duration
+		want      bool
+	}{
+		{name: "not expired", expiresAt: now.Add(time.Minute), want: false},
+		{name: "expired", expiresAt: now.Add(-time.Second), want: true},
+		{name: "expires now", expiresAt: now, want: true},
+		{name: "expired within skew", expiresAt: now.Add(-10 * time.Second), skew: 30 * time.Second, want: false},
+		{name: "expired beyond skew", expiresAt: now.Add(-time.Minute), skew: 30 * time.Second, want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Expired(tt.expiresAt, now, tt.skew); got != tt.want {
+				t.Errorf("Expired() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRefreshBefore(t *testing.T) {
+	expiresAt := time.Now()
+	if got := RefreshBefore(expiresAt, time.Minute); !got.Equal(expiresAt.Add(-time.Minute)) {
+		t.Errorf("RefreshBefore() = %v, want a minute before the expiry", got)
+	}
+}
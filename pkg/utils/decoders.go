@@ -0,0 +1,96 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+)
+
+// MaxDecompressedSize limits the output of the Gunzip and Zlib decoders,
+// it is larger than a Kubernetes Secret can ever be.
+const MaxDecompressedSize = 4 << 20
+
+var (
+	errInvalidHex         = errors.New("invalid hex data")
+	errDecompressedTooBig = fmt.Errorf("decompressed value exceeds %d bytes", MaxDecompressedSize)
+)
+
+// DecodeChain applies the decoders to in, in order.
+// The error names the step that failed and never contains the value.
+func DecodeChain(decoders []esv1beta1.ExternalSecretDecoder, in []byte) ([]byte, error) {
+	out := in
+	for i, decoder := range decoders {
+		var err error
+		out, err = decodeStep(decoder, out)
+		if err != nil {
+			return nil, fmt.Errorf("decoder %d (%s) failed: %w", i, decoder, err)
+		}
+	}
+	return out, nil
+}
+
+func decodeStep(decoder esv1beta1.ExternalSecretDecoder, in []byte) ([]byte, error) {
+	switch decoder {
+	case esv1beta1.ExternalSecretDecoderBase64:
+		return base64.StdEncoding.DecodeString(string(in))
+	case esv1beta1.ExternalSecretDecoderBase64URL:
+		return base64.URLEncoding.DecodeString(string(in))
+	case esv1beta1.ExternalSecretDecoderHex:
+		out, err := hex.DecodeString(string(in))
+		if err != nil {
+			// the hex error quotes the invalid character
+			return nil, errInvalidHex
+		}
+		return out, nil
+	case esv1beta1.ExternalSecretDecoderGunzip:
+		r, err := gzip.NewReader(bytes.NewReader(in))
+		if err != nil {
+			return nil, err
+		}
+		defer r.Close()
+		return readDecompressed(r)
+	case esv1beta1.ExternalSecretDecoderZlib:
+		r, err := zlib.NewReader(bytes.NewReader(in))
+		if err != nil {
+			return nil, err
+		}
+		defer r.Close()
+		return readDecompressed(r)
+	default:
+		return nil, fmt.Errorf("decoder %v is not supported", decoder)
+	}
+}
+
+// readDecompressed reads at most MaxDecompressedSize bytes,
+// so a small compressed value can not exhaust the memory of the controller.
+func readDecompressed(r io.Reader) ([]byte, error) {
+	out, err := io.ReadAll(io.LimitReader(r, MaxDecompressedSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(out) > MaxDecompressedSize {
+		return nil, errDecompressedTooBig
+	}
+	return out, nil
+}
@@ -0,0 +1,111 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"encoding/base64"
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+)
+
+func TestDecodeChain(t *testing.T) {
+	secret := []byte("s3cr3t-value")
+	var gz, zl bytes.Buffer
+	gw := gzip.NewWriter(&gz)
+	_, _ = gw.Write(secret)
+	_ = gw.Close()
+	zw := zlib.NewWriter(&zl)
+	_, _ = zw.Write(secret)
+	_ = zw.Close()
+
+	var bomb bytes.Buffer
+	bw := gzip.NewWriter(&bomb)
+	_, _ = bw.Write(make([]byte, MaxDecompressedSize+1))
+	_ = bw.Close()
+
+	tests := []struct {
+		name     string
+		decoders []esv1beta1.ExternalSecretDecoder
+		in       []byte
+		want     []byte
+		wantErr  string
+	}{
+		{
+			name: "no decoders",
+			in:   secret,
+			want: secret,
+		},
+		{
+			name:     "base64 then gunzip",
+			decoders: []esv1beta1.ExternalSecretDecoder{esv1beta1.ExternalSecretDecoderBase64, esv1beta1.ExternalSecretDecoderGunzip},
+			in:       []byte(base64.StdEncoding.EncodeToString(gz.Bytes())),
+			want:     secret,
+		},
+		{
+			name:     "hex then zlib",
+			decoders: []esv1beta1.ExternalSecretDecoder{esv1beta1.ExternalSecretDecoderHex, esv1beta1.ExternalSecretDecoderZlib},
+			in:       []byte(hex.EncodeToString(zl.Bytes())),
+			want:     secret,
+		},
+		{
+			name:     "base64url",
+			decoders: []esv1beta1.ExternalSecretDecoder{esv1beta1.ExternalSecretDecoderBase64URL},
+			in:       []byte(base64.URLEncoding.EncodeToString([]byte{0xfb, 0xff})),
+			want:     []byte{0xfb, 0xff},
+		},
+		{
+			name:     "names the failed step",
+			decoders: []esv1beta1.ExternalSecretDecoder{esv1beta1.ExternalSecretDecoderBase64, esv1beta1.ExternalSecretDecoderGunzip},
+			in:       []byte(base64.StdEncoding.EncodeToString(secret)),
+			wantErr:  "decoder 1 (Gunzip) failed: gzip: invalid header",
+		},
+		{
+			name:     "hex errors do not quote the value",
+			decoders: []esv1beta1.ExternalSecretDecoder{esv1beta1.ExternalSecretDecoderHex},
+			in:       secret,
+			wantErr:  "decoder 0 (Hex) failed: invalid hex data",
+		},
+		{
+			name:     "limits the decompressed size",
+			decoders: []esv1beta1.ExternalSecretDecoder{esv1beta1.ExternalSecretDecoderGunzip},
+			in:       bomb.Bytes(),
+			wantErr:  "decoder 0 (Gunzip) failed: decompressed value exceeds 4194304 bytes",
+		},
+		{
+			name:     "unknown decoder",
+			decoders: []esv1beta1.ExternalSecretDecoder{"Rot13"},
+			in:       secret,
+			wantErr:  "decoder 0 (Rot13) failed: decoder Rot13 is not supported",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DecodeChain(tt.decoders, tt.in)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
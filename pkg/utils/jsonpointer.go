@@ -0,0 +1,135 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+var ErrJSONPointer = errors.New("json pointer")
+
+// JSONPointer returns the value of the JSON document data selected by
+// the RFC 6901 JSON Pointer, e.g. `/keys/0/value`.
+// Strings are returned as is, all other values are JSON encoded.
+func JSONPointer(data []byte, pointer string) ([]byte, error) {
+	v, err := resolveJSONPointer(data, pointer)
+	if err != nil {
+		return nil, err
+	}
+	return GetByteValue(v)
+}
+
+// JSONPointerMap returns the members of the JSON object selected by the JSON Pointer.
+func JSONPointerMap(data []byte, pointer string) (map[string][]byte, error) {
+	v, err := resolveJSONPointer(data, pointer)
+	if err != nil {
+		return nil, err
+	}
+	obj, ok := v.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("%w %s: expected an object, got %s", ErrJSONPointer, pointer, jsonTypeName(v))
+	}
+	out := make(map[string][]byte, len(obj))
+	for k := range obj {
+		out[k], err = GetByteValueFromMap(obj, k)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return out, nil
+}
+
+func resolveJSONPointer(data []byte, pointer string) (any, error) {
+	if pointer != "" && !strings.HasPrefix(pointer, "/") {
+		return nil, fmt.Errorf("%w %s: must be empty or start with /", ErrJSONPointer, pointer)
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var doc any
+	if err := decoder.Decode(&doc); err != nil {
+		return nil, fmt.Errorf("%w %s: secret value is not valid JSON", ErrJSONPointer, pointer)
+	}
+	if pointer == "" {
+		return doc, nil
+	}
+
+	current := doc
+	path := ""
+	for _, token := range strings.Split(pointer[1:], "/") {
+		if strings.Contains(strings.NewReplacer("~0", "", "~1", "").Replace(token), "~") {
+			return nil, fmt.Errorf("%w %s: invalid escape sequence in %q", ErrJSONPointer, pointer, token)
+		}
+		path += "/" + token
+		token = strings.NewReplacer("~1", "/", "~0", "~").Replace(token)
+		switch v := current.(type) {
+		case map[string]any:
+			member, ok := v[token]
+			if !ok {
+				return nil, fmt.Errorf("%w %s: member %q does not exist", ErrJSONPointer, path, token)
+			}
+			current = member
+		case []any:
+			idx, err := parseArrayIndex(token)
+			if err != nil {
+				return nil, fmt.Errorf("%w %s: %w", ErrJSONPointer, path, err)
+			}
+			if idx >= len(v) {
+				return nil, fmt.Errorf("%w %s: index %d is out of range, the array has %d elements", ErrJSONPointer, path, idx, len(v))
+			}
+			current = v[idx]
+		default:
+			return nil, fmt.Errorf("%w %s: can not select %q from %s", ErrJSONPointer, path, token, jsonTypeName(v))
+		}
+	}
+	return current, nil
+}
+
+// parseArrayIndex parses an array index token, which must not have leading zeros.
+func parseArrayIndex(token string) (int, error) {
+	if token == "-" {
+		return 0, errors.New("index - refers to the element after the last one")
+	}
+	if token == "" || (len(token) > 1 && token[0] == '0') || strings.TrimLeft(token, "0123456789") != "" {
+		return 0, fmt.Errorf("%q is not a valid array index", token)
+	}
+	idx, err := strconv.Atoi(token)
+	if err != nil {
+		return 0, fmt.Errorf("%q is not a valid array index", token)
+	}
+	return idx, nil
+}
+
+func jsonTypeName(v any) string {
+	switch v.(type) {
+	case map[string]any:
+		return "an object"
+	case []any:
+		return "an array"
+	case string:
+		return "a string"
+	case json.Number:
+		return "a number"
+	case bool:
+		return "a boolean"
+	case nil:
+		return "null"
+	}
+	return fmt.Sprintf("%T", v)
+}
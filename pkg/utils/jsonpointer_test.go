@@ -0,0 +1,94 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const jsonPointerDoc = `{
+	"keys": [
+		{"name": "first", "value": "abc"},
+		{"name": "second", "value": "def", "port": 8080}
+	],
+	"matrix": [[1, 2], [3, 4.5]],
+	"a/b": "slash",
+	"m~n": "tilde",
+	"": "empty",
+	"enabled": true,
+	"nothing": null,
+	"html": "<a&b>"
+}`
+
+func TestJSONPointer(t *testing.T) {
+	tests := []struct {
+		name    string
+		pointer string
+		want    string
+		wantErr string
+	}{
+		{name: "array element", pointer: "/keys/0/value", want: "abc"},
+		{name: "array element number", pointer: "/keys/1/port", want: "8080"},
+		{name: "whole array element", pointer: "/keys/1", want: `{"name":"second","port":8080,"value":"def"}`},
+		{name: "nested array", pointer: "/matrix/1/1", want: "4.5"},
+		{name: "inner array", pointer: "/matrix/0", want: "[1,2]"},
+		{name: "escaped slash", pointer: "/a~1b", want: "slash"},
+		{name: "escaped tilde", pointer: "/m~0n", want: "tilde"},
+		{name: "empty member name", pointer: "/", want: "empty"},
+		{name: "boolean", pointer: "/enabled", want: "true"},
+		{name: "html is not escaped", pointer: "/html", want: "<a&b>"},
+		{name: "out of range", pointer: "/keys/2/value", wantErr: "json pointer /keys/2: index 2 is out of range, the array has 2 elements"},
+		{name: "end of array", pointer: "/keys/-", wantErr: "json pointer /keys/-: index - refers to the element after the last one"},
+		{name: "leading zero", pointer: "/keys/01", wantErr: `json pointer /keys/01: "01" is not a valid array index`},
+		{name: "index on object", pointer: "/keys/0/0", wantErr: `json pointer /keys/0/0: member "0" does not exist`},
+		{name: "member of array", pointer: "/keys/name", wantErr: `json pointer /keys/name: "name" is not a valid array index`},
+		{name: "member of string", pointer: "/keys/0/value/x", wantErr: `json pointer /keys/0/value/x: can not select "x" from a string`},
+		{name: "member of null", pointer: "/nothing/x", wantErr: `json pointer /nothing/x: can not select "x" from null`},
+		{name: "missing member", pointer: "/missing", wantErr: `json pointer /missing: member "missing" does not exist`},
+		{name: "invalid escape", pointer: "/m~2n", wantErr: `json pointer /m~2n: invalid escape sequence in "m~2n"`},
+		{name: "missing leading slash", pointer: "keys/0", wantErr: "json pointer keys/0: must be empty or start with /"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := JSONPointer([]byte(jsonPointerDoc), tt.pointer)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				assert.True(t, errors.Is(err, ErrJSONPointer))
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, string(got))
+		})
+	}
+
+	_, err := JSONPointer([]byte("not json"), "/keys")
+	assert.ErrorIs(t, err, ErrJSONPointer)
+}
+
+func TestJSONPointerMap(t *testing.T) {
+	got, err := JSONPointerMap([]byte(jsonPointerDoc), "/keys/1")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string][]byte{
+		"name":  []byte("second"),
+		"value": []byte("def"),
+		"port":  []byte("8080"),
+	}, got)
+
+	_, err = JSONPointerMap([]byte(jsonPointerDoc), "/keys")
+	assert.EqualError(t, err, "json pointer /keys: expected an object, got an array")
+}
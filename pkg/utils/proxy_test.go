@@ -0,0 +1,107 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+)
+
+func TestProxyTransport(t *testing.T) {
+	var proxied []string
+	proxy := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		proxied = append(proxied, req.URL.String())
+	}))
+	defer proxy.Close()
+	// the proxy of the store takes precedence over the environment
+	t.Setenv("HTTP_PROXY", "http://127.0.0.1:1")
+
+	client := &http.Client{Transport: ProxyTransport(&esv1beta1.ProxyConfig{
+		HTTPProxy: proxy.URL,
+		NoProxy:   "internal.example.com",
+	})}
+	resp, err := client.Get("http://secrets.example.com/v1/secret")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	resp.Body.Close()
+
+	// hosts of noProxy are not proxied and can not be resolved
+	if _, err := client.Get("http://internal.example.com/v1/secret"); err == nil {
+		t.Errorf("expected the request to bypass the proxy")
+	}
+
+	if len(proxied) != 1 || proxied[0] != "http://secrets.example.com/v1/secret" {
+		t.Errorf("unexpected proxied requests: %v", proxied)
+	}
+}
+
+func TestProxyDialer(t *testing.T) {
+	var connected string
+	proxy := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodConnect {
+			rw.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		connected = req.Host
+		conn, buf, err := rw.(http.Hijacker).Hijack()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		buf.WriteString("HTTP/1.1 200 Connection established\r\n\r\n")
+		buf.Flush()
+		// echo the tunneled data
+		io.Copy(conn, buf)
+	}))
+	defer proxy.Close()
+
+	dial := ProxyDialer(&esv1beta1.ProxyConfig{HTTPSProxy: proxy.URL})
+	conn, err := dial(context.Background(), "secretmanager.example.com:443")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer conn.Close()
+	if connected != "secretmanager.example.com:443" {
+		t.Errorf("unexpected CONNECT target: %q", connected)
+	}
+	if _, err := conn.Write([]byte("ping")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got := make([]byte, 4)
+	if _, err := io.ReadFull(conn, got); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(got) != "ping" {
+		t.Errorf("unexpected tunneled data: %q", got)
+	}
+}
+
+func TestProxyDialerRejected(t *testing.T) {
+	proxy := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.WriteHeader(http.StatusForbidden)
+	}))
+	defer proxy.Close()
+
+	dial := ProxyDialer(&esv1beta1.ProxyConfig{HTTPSProxy: proxy.URL})
+	if _, err := dial(context.Background(), "secretmanager.example.com:443"); err == nil {
+		t.Errorf("expected an error for a rejected CONNECT")
+	}
+}
@@ -16,9 +16,11 @@ package resolvers
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"reflect"
 
+	corev1 "k8s.io/api/core/v1"
 	apiextensions "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -35,6 +37,8 @@ import (
 var (
 	// ErrUnableToGetGenerator is returned when a generator reference cannot be resolved.
 	ErrUnableToGetGenerator = fmt.Errorf("unable to get generator")
+	// ErrGeneratorNotReady is returned when a generator reports a Ready condition that is not True.
+	ErrGeneratorNotReady = fmt.Errorf("generator is not ready")
 )
 
 // GeneratorRef resolves a generator reference to a generator implementation.
@@ -46,6 +50,30 @@ func GeneratorRef(ctx context.Context, cl client.Client, scheme *runtime.Scheme,
 	return generator, jsonObj, nil
 }
 
+// GeneratorReady returns ErrGeneratorNotReady if the resolved generator object has a Ready
+// status condition that is not True. Generators without a Ready condition are ready.
+func GeneratorReady(obj *apiextensions.JSON) error {
+	if obj == nil {
+		return nil
+	}
+	var gen struct {
+		Status genv1alpha1.GeneratorStatus `json:"status"`
+	}
+	if err := json.Unmarshal(obj.Raw, &gen); err != nil {
+		return fmt.Errorf("unable to parse generator status: %w", err)
+	}
+	for _, cond := range gen.Status.Conditions {
+		if cond.Type != genv1alpha1.GeneratorReady || cond.Status == corev1.ConditionTrue {
+			continue
+		}
+		if cond.Message != "" {
+			return fmt.Errorf("%w: %s", ErrGeneratorNotReady, cond.Message)
+		}
+		return ErrGeneratorNotReady
+	}
+	return nil
+}
+
 func getGenerator(ctx context.Context, cl client.Client, scheme *runtime.Scheme, namespace string, generatorRef *esv1beta1.GeneratorRef) (genv1alpha1.Generator, *apiextensions.JSON, error) {
 	// get a GVK from the generatorRef
 	gv, err := schema.ParseGroupVersion(generatorRef.APIVersion)
@@ -145,6 +173,13 @@ func clusterGeneratorToVirtual(gen *genv1alpha1.ClusterGenerator) (client.Object
 		return &genv1alpha1.ACRAccessToken{
 			Spec: *gen.Spec.Generator.ACRAccessTokenSpec,
 		}, nil
+	case genv1alpha1.GeneratorKindDeterministicSecret:
+		if gen.Spec.Generator.DeterministicSecretSpec == nil {
+			return nil, fmt.Errorf("when kind is %s, DeterministicSecretSpec must be set", gen.Spec.Kind)
+		}
+		return &genv1alpha1.DeterministicSecret{
+			Spec: *gen.Spec.Generator.DeterministicSecretSpec,
+		}, nil
 	case genv1alpha1.GeneratorKindECRAuthorizationToken:
 		if gen.Spec.Generator.ECRAuthorizationTokenSpec == nil {
 			return nil, fmt.Errorf("when kind is %s, ECRAuthorizationTokenSpec must be set", gen.Spec.Kind)
@@ -159,6 +194,13 @@ func clusterGeneratorToVirtual(gen *genv1alpha1.ClusterGenerator) (client.Object
 		return &genv1alpha1.Fake{
 			Spec: *gen.Spec.Generator.FakeSpec,
 		}, nil
+	case genv1alpha1.GeneratorKindGCPKMSDecrypt:
+		if gen.Spec.Generator.GCPKMSDecryptSpec == nil {
+			return nil, fmt.Errorf("when kind is %s, GCPKMSDecryptSpec must be set", gen.Spec.Kind)
+		}
+		return &genv1alpha1.GCPKMSDecrypt{
+			Spec: *gen.Spec.Generator.GCPKMSDecryptSpec,
+		}, nil
 	case genv1alpha1.GeneratorKindGCRAccessToken:
 		if gen.Spec.Generator.GCRAccessTokenSpec == nil {
 			return nil, fmt.Errorf("when kind is %s, GCRAccessTokenSpec must be set", gen.Spec.Kind)
@@ -180,6 +222,13 @@ func clusterGeneratorToVirtual(gen *genv1alpha1.ClusterGenerator) (client.Object
 		return &genv1alpha1.Password{
 			Spec: *gen.Spec.Generator.PasswordSpec,
 		}, nil
+	case genv1alpha1.GeneratorKindServiceAccountToken:
+		if gen.Spec.Generator.ServiceAccountTokenSpec == nil {
+			return nil, fmt.Errorf("when kind is %s, ServiceAccountTokenSpec must be set", gen.Spec.Kind)
+		}
+		return &genv1alpha1.ServiceAccountToken{
+			Spec: *gen.Spec.Generator.ServiceAccountTokenSpec,
+		}, nil
 	case genv1alpha1.GeneratorKindSTSSessionToken:
 		if gen.Spec.Generator.STSSessionTokenSpec == nil {
 			return nil, fmt.Errorf("when kind is %s, STSSessionTokenSpec must be set", gen.Spec.Kind)
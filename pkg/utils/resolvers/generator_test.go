@@ -0,0 +1,94 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resolvers
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	genv1alpha1 "github.com/external-secrets/external-secrets/apis/generators/v1alpha1"
+	_ "github.com/external-secrets/external-secrets/pkg/generator/fake"
+)
+
+func TestGeneratorReady(t *testing.T) {
+	tests := []struct {
+		name       string
+		conditions []genv1alpha1.GeneratorStatusCondition
+		wantErr    error
+	}{
+		{
+			name: "without status",
+		},
+		{
+			name: "ready",
+			conditions: []genv1alpha1.GeneratorStatusCondition{
+				{Type: genv1alpha1.GeneratorReady, Status: corev1.ConditionTrue},
+			},
+		},
+		{
+			name: "not ready",
+			conditions: []genv1alpha1.GeneratorStatusCondition{
+				{Type: genv1alpha1.GeneratorReady, Status: corev1.ConditionFalse, Message: "waiting for the backend"},
+			},
+			wantErr: ErrGeneratorNotReady,
+		},
+		{
+			name: "readiness unknown",
+			conditions: []genv1alpha1.GeneratorStatusCondition{
+				{Type: genv1alpha1.GeneratorReady, Status: corev1.ConditionUnknown},
+			},
+			wantErr: ErrGeneratorNotReady,
+		},
+		{
+			name: "other conditions are ignored",
+			conditions: []genv1alpha1.GeneratorStatusCondition{
+				{Type: "Degraded", Status: corev1.ConditionFalse},
+			},
+		},
+	}
+	scheme := runtime.NewScheme()
+	if err := genv1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gen := &genv1alpha1.Fake{
+				ObjectMeta: metav1.ObjectMeta{Name: "fake", Namespace: "default"},
+				Spec:       genv1alpha1.FakeSpec{Data: map[string]string{"foo": "bar"}},
+				Status:     genv1alpha1.GeneratorStatus{Conditions: tt.conditions},
+			}
+			cl := fake.NewClientBuilder().WithScheme(scheme).WithObjects(gen).Build()
+			_, obj, err := GeneratorRef(context.Background(), cl, scheme, "default", &esv1beta1.GeneratorRef{
+				APIVersion: genv1alpha1.Group + "/" + genv1alpha1.Version,
+				Kind:       genv1alpha1.FakeKind,
+				Name:       "fake",
+			})
+			if err != nil {
+				t.Fatalf("GeneratorRef() error = %v", err)
+			}
+			err = GeneratorReady(obj)
+			if !errors.Is(err, tt.wantErr) || (tt.wantErr == nil && err != nil) {
+				t.Errorf("GeneratorReady() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -50,6 +50,9 @@ func SecretKeyRef(
 	storeKind string,
 	esNamespace string,
 	ref *esmeta.SecretKeySelector) (string, error) {
+	if ref.StoreRef != nil {
+		return StoreSecretRef(ctx, c, storeKind, esNamespace, ref.StoreRef)
+	}
 	key := types.NamespacedName{
 		Namespace: esNamespace,
 		Name:      ref.Name,
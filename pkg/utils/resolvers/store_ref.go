@@ -0,0 +1,120 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resolvers
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	esmeta "github.com/external-secrets/external-secrets/apis/meta/v1"
+)
+
+// maxStoreChainDepth is the maximum number of stores that are chained
+// to resolve the credentials of a single store.
+const maxStoreChainDepth = 3
+
+const (
+	errStoreRefKind  = "a %s can not read credentials from a %s"
+	errStoreRefCycle = "cannot read credentials from %s %q: the stores refer to each other"
+	errStoreRefDepth = "cannot read credentials from %s %q: more than %d stores are chained"
+	errStoreRefGet   = "cannot get %s %q: %w"
+	errStoreRefDeny  = "using %s %q is not allowed from namespace %q: denied by spec.condition"
+	errStoreRefReady = "%s %q is not ready"
+	errStoreRefNew   = "cannot create client of %s %q: %w"
+	errStoreRefRead  = "cannot read %q from %s %q: %w"
+)
+
+// storeChainKey is the context key of the stores that are already resolving their credentials.
+type storeChainKey struct{}
+
+// StoreSecretRef resolves a metav1.StoreSecretRef and returns the value of the secret it points to.
+// The referenced store is built like the store of an ExternalSecret in esNamespace,
+// so its own credentials may refer to another store in turn.
+// The namespace must be allowed by the conditions of the store and the store must be ready.
+// Stores that refer to each other and chains of more than maxStoreChainDepth stores are rejected.
+func StoreSecretRef(
+	ctx context.Context,
+	c client.Client,
+	storeKind string,
+	esNamespace string,
+	ref *esmeta.StoreSecretRef) (string, error) {
+	kind := ref.Kind
+	if kind == "" {
+		kind = esv1beta1.SecretStoreKind
+	}
+	// a store can only use stores of its own scope, so a namespaced store can not read through a ClusterSecretStore
+	if (storeKind == esv1beta1.ClusterSecretStoreKind) != (kind == esv1beta1.ClusterSecretStoreKind) {
+		return "", fmt.Errorf(errStoreRefKind, storeKind, kind)
+	}
+
+	key := types.NamespacedName{Name: ref.Name}
+	if kind != esv1beta1.ClusterSecretStoreKind {
+		key.Namespace = esNamespace
+	}
+	link := kind + "/" + key.String()
+	chain, _ := ctx.Value(storeChainKey{}).([]string)
+	if slices.Contains(chain, link) {
+		return "", fmt.Errorf(errStoreRefCycle, kind, ref.Name)
+	}
+	if len(chain) >= maxStoreChainDepth {
+		return "", fmt.Errorf(errStoreRefDepth, kind, ref.Name, maxStoreChainDepth)
+	}
+	ctx = context.WithValue(ctx, storeChainKey{}, append(slices.Clone(chain), link))
+
+	var store esv1beta1.GenericStore = &esv1beta1.SecretStore{}
+	if kind == esv1beta1.ClusterSecretStoreKind {
+		store = &esv1beta1.ClusterSecretStore{}
+	}
+	if err := c.Get(ctx, key, store); err != nil {
+		return "", fmt.Errorf(errStoreRefGet, kind, ref.Name, err)
+	}
+	// the referenced store is used like the store of an ExternalSecret in esNamespace,
+	// so the namespace must be allowed by its conditions and the store must be ready
+	allowed, err := StoreAllowsNamespace(ctx, c, store, esNamespace)
+	if err != nil {
+		return "", fmt.Errorf(errStoreRefGet, kind, ref.Name, err)
+	}
+	if !allowed {
+		return "", fmt.Errorf(errStoreRefDeny, kind, ref.Name, esNamespace)
+	}
+	if !StoreIsReady(store) {
+		return "", fmt.Errorf(errStoreRefReady, kind, ref.Name)
+	}
+	provider, err := esv1beta1.GetProvider(store)
+	if err != nil {
+		return "", fmt.Errorf(errStoreRefNew, kind, ref.Name, err)
+	}
+	secretsClient, err := provider.NewClient(ctx, store, c, esNamespace)
+	if err != nil {
+		return "", fmt.Errorf(errStoreRefNew, kind, ref.Name, err)
+	}
+	defer func() {
+		_ = secretsClient.Close(ctx)
+	}()
+	val, err := secretsClient.GetSecret(ctx, esv1beta1.ExternalSecretDataRemoteRef{
+		Key:      ref.RemoteKey,
+		Property: ref.Property,
+		Version:  ref.Version,
+	})
+	if err != nil {
+		return "", fmt.Errorf(errStoreRefRead, ref.RemoteKey, kind, ref.Name, err)
+	}
+	return string(val), nil
+}
@@ -0,0 +1,215 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package resolvers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	esmeta "github.com/external-secrets/external-secrets/apis/meta/v1"
+	fakeprovider "github.com/external-secrets/external-secrets/pkg/provider/testing/fake"
+)
+
+const storeRefNamespace = "tenant"
+
+// readyStatus is the status of a store that passed its validation.
+var readyStatus = esv1beta1.SecretStoreStatus{
+	Conditions: []esv1beta1.SecretStoreStatusCondition{{Type: esv1beta1.SecretStoreReady, Status: corev1.ConditionTrue}},
+}
+
+// namespace returns a namespace with the label the API server sets on every namespace.
+func namespace(name string) *corev1.Namespace {
+	return &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+		Name:   name,
+		Labels: map[string]string{"kubernetes.io/metadata.name": name},
+	}}
+}
+
+// tokenStore returns a store of the chained test provider that authenticates with the given token.
+func tokenStore(name string, token esmeta.SecretKeySelector) *esv1beta1.SecretStore {
+	return &esv1beta1.SecretStore{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: storeRefNamespace},
+		Spec: esv1beta1.SecretStoreSpec{
+			Provider: &esv1beta1.SecretStoreProvider{
+				Kubernetes: &esv1beta1.KubernetesProvider{
+					Auth: esv1beta1.KubernetesAuth{Token: &esv1beta1.TokenAuth{BearerToken: token}},
+				},
+			},
+		},
+		Status: readyStatus,
+	}
+}
+
+// registerStoreRefProviders registers a provider that reads the secrets of its store
+// and a provider that resolves its token through SecretKeyRef before it can be used.
+// It returns the tokens the latter authenticated with.
+func registerStoreRefProviders(secrets map[string][]byte) *[]string {
+	bootstrap := fakeprovider.New()
+	bootstrap.GetSecretFn = func(_ context.Context, ref esv1beta1.ExternalSecretDataRemoteRef) ([]byte, error) {
+		val, ok := secrets[ref.Key]
+		if !ok {
+			return nil, esv1beta1.NoSecretError{}
+		}
+		return val, nil
+	}
+	bootstrap.RegisterAs(&esv1beta1.SecretStoreProvider{Fake: &esv1beta1.FakeProvider{}})
+
+	var tokens []string
+	chained := fakeprovider.New()
+	chained.WithNew(func(ctx context.Context, store esv1beta1.GenericStore, kube client.Client, namespace string) (esv1beta1.SecretsClient, error) {
+		token, err := SecretKeyRef(ctx, kube, store.GetKind(), namespace, &store.GetSpec().Provider.Kubernetes.Auth.Token.BearerToken)
+		if err != nil {
+			return nil, err
+		}
+		tokens = append(tokens, token)
+		return fakeprovider.New().WithGetSecret([]byte("from-"+store.GetName()), nil), nil
+	})
+	chained.RegisterAs(&esv1beta1.SecretStoreProvider{Kubernetes: &esv1beta1.KubernetesProvider{}})
+	return &tokens
+}
+
+func TestStoreSecretRef(t *testing.T) {
+	ctx := context.Background()
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, esv1beta1.AddToScheme(scheme))
+	tokens := registerStoreRefProviders(map[string][]byte{"store-a-token": []byte("s3cr3t")})
+
+	storeB := &esv1beta1.SecretStore{
+		ObjectMeta: metav1.ObjectMeta{Name: "store-b", Namespace: storeRefNamespace},
+		Spec: esv1beta1.SecretStoreSpec{
+			Provider: &esv1beta1.SecretStoreProvider{Fake: &esv1beta1.FakeProvider{}},
+		},
+		Status: readyStatus,
+	}
+	// store-pending has not been validated yet
+	storePending := &esv1beta1.SecretStore{
+		ObjectMeta: metav1.ObjectMeta{Name: "store-pending", Namespace: storeRefNamespace},
+		Spec: esv1beta1.SecretStoreSpec{
+			Provider: &esv1beta1.SecretStoreProvider{Fake: &esv1beta1.FakeProvider{}},
+		},
+	}
+	// cluster-b can only be used from the allowed namespace
+	clusterB := &esv1beta1.ClusterSecretStore{
+		ObjectMeta: metav1.ObjectMeta{Name: "cluster-b"},
+		Spec: esv1beta1.SecretStoreSpec{
+			Provider:   &esv1beta1.SecretStoreProvider{Fake: &esv1beta1.FakeProvider{}},
+			Conditions: []esv1beta1.ClusterSecretStoreCondition{{Namespaces: []string{"allowed"}}},
+		},
+		Status: readyStatus,
+	}
+	storeA := tokenStore("store-a", esmeta.SecretKeySelector{
+		StoreRef: &esmeta.StoreSecretRef{Name: "store-b", RemoteKey: "store-a-token"},
+	})
+	// store-c and store-d read their tokens from each other
+	storeC := tokenStore("store-c", esmeta.SecretKeySelector{
+		StoreRef: &esmeta.StoreSecretRef{Name: "store-d", RemoteKey: "token"},
+	})
+	storeD := tokenStore("store-d", esmeta.SecretKeySelector{
+		StoreRef: &esmeta.StoreSecretRef{Name: "store-c", RemoteKey: "token"},
+	})
+	// chain-1 reads its token from chain-2, which reads it from chain-3 and so on
+	objects := []client.Object{storeA, storeB, storeC, storeD, storePending, clusterB, namespace(storeRefNamespace), namespace("allowed")}
+	chain := []string{"chain-1", "chain-2", "chain-3", "chain-4", "chain-5"}
+	for i := range chain[:len(chain)-1] {
+		objects = append(objects, tokenStore(chain[i], esmeta.SecretKeySelector{
+			StoreRef: &esmeta.StoreSecretRef{Name: chain[i+1], RemoteKey: "token"},
+		}))
+	}
+	objects = append(objects, tokenStore("chain-5", esmeta.SecretKeySelector{
+		StoreRef: &esmeta.StoreSecretRef{Name: "store-b", RemoteKey: "store-a-token"},
+	}))
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build()
+
+	t.Run("store A authenticates with a token read from store B", func(t *testing.T) {
+		*tokens = nil
+		provider, err := esv1beta1.GetProvider(storeA)
+		require.NoError(t, err)
+		secretsClient, err := provider.NewClient(ctx, storeA, c, storeRefNamespace)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"s3cr3t"}, *tokens)
+
+		val, err := secretsClient.GetSecret(ctx, esv1beta1.ExternalSecretDataRemoteRef{Key: "foo"})
+		require.NoError(t, err)
+		assert.Equal(t, "from-store-a", string(val))
+	})
+
+	t.Run("stores that refer to each other are rejected", func(t *testing.T) {
+		provider, err := esv1beta1.GetProvider(storeC)
+		require.NoError(t, err)
+		_, err = provider.NewClient(ctx, storeC, c, storeRefNamespace)
+		assert.ErrorContains(t, err, "the stores refer to each other")
+	})
+
+	t.Run("chains longer than the maximum depth are rejected", func(t *testing.T) {
+		_, err := SecretKeyRef(ctx, c, esv1beta1.SecretStoreKind, storeRefNamespace, &esmeta.SecretKeySelector{
+			StoreRef: &esmeta.StoreSecretRef{Name: "chain-1", RemoteKey: "token"},
+		})
+		assert.ErrorContains(t, err, "more than 3 stores are chained")
+	})
+
+	t.Run("chains up to the maximum depth are resolved", func(t *testing.T) {
+		val, err := SecretKeyRef(ctx, c, esv1beta1.SecretStoreKind, storeRefNamespace, &esmeta.SecretKeySelector{
+			StoreRef: &esmeta.StoreSecretRef{Name: "chain-4", RemoteKey: "token"},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "from-chain-4", val)
+	})
+
+	t.Run("namespaced stores can not read through cluster stores", func(t *testing.T) {
+		_, err := SecretKeyRef(ctx, c, esv1beta1.SecretStoreKind, storeRefNamespace, &esmeta.SecretKeySelector{
+			StoreRef: &esmeta.StoreSecretRef{Name: "store-b", Kind: esv1beta1.ClusterSecretStoreKind, RemoteKey: "store-a-token"},
+		})
+		assert.ErrorContains(t, err, "a SecretStore can not read credentials from a ClusterSecretStore")
+	})
+
+	t.Run("stores of other namespaces are not found", func(t *testing.T) {
+		_, err := SecretKeyRef(ctx, c, esv1beta1.SecretStoreKind, "other", &esmeta.SecretKeySelector{
+			StoreRef: &esmeta.StoreSecretRef{Name: "store-b", RemoteKey: "store-a-token"},
+		})
+		assert.ErrorContains(t, err, "cannot get SecretStore \"store-b\"")
+	})
+
+	t.Run("stores that are not ready are rejected", func(t *testing.T) {
+		_, err := SecretKeyRef(ctx, c, esv1beta1.SecretStoreKind, storeRefNamespace, &esmeta.SecretKeySelector{
+			StoreRef: &esmeta.StoreSecretRef{Name: "store-pending", RemoteKey: "store-a-token"},
+		})
+		assert.ErrorContains(t, err, "SecretStore \"store-pending\" is not ready")
+	})
+
+	t.Run("cluster stores can not be used from namespaces excluded by their conditions", func(t *testing.T) {
+		_, err := SecretKeyRef(ctx, c, esv1beta1.ClusterSecretStoreKind, storeRefNamespace, &esmeta.SecretKeySelector{
+			StoreRef: &esmeta.StoreSecretRef{Name: "cluster-b", Kind: esv1beta1.ClusterSecretStoreKind, RemoteKey: "store-a-token"},
+		})
+		assert.ErrorContains(t, err, "using ClusterSecretStore \"cluster-b\" is not allowed from namespace \"tenant\"")
+	})
+
+	t.Run("cluster stores can be used from namespaces allowed by their conditions", func(t *testing.T) {
+		val, err := SecretKeyRef(ctx, c, esv1beta1.ClusterSecretStoreKind, "allowed", &esmeta.SecretKeySelector{
+			StoreRef: &esmeta.StoreSecretRef{Name: "cluster-b", Kind: esv1beta1.ClusterSecretStoreKind, RemoteKey: "store-a-token"},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "s3cr3t", val)
+	})
+}
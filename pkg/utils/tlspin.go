@@ -0,0 +1,98 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+)
+
+// TLSPinPrefix is the prefix of a pin in tlsPinnedKeys.
+const TLSPinPrefix = "sha256/"
+
+// TLSKeyPin returns the pin of the public key of cert:
+// the base64 encoded SHA-256 hash of its SubjectPublicKeyInfo, prefixed with TLSPinPrefix.
+func TLSKeyPin(cert *x509.Certificate) string {
+	sum := sha256.Sum256(cert.RawSubjectPublicKeyInfo)
+	return TLSPinPrefix + base64.StdEncoding.EncodeToString(sum[:])
+}
+
+// ValidateTLSPins checks that every pin has the format of TLSKeyPin.
+func ValidateTLSPins(pins []string) error {
+	for _, pin := range pins {
+		hash, ok := strings.CutPrefix(pin, TLSPinPrefix)
+		if !ok {
+			return fmt.Errorf("invalid TLS pin %q: expected prefix %q", pin, TLSPinPrefix)
+		}
+		decoded, err := base64.StdEncoding.DecodeString(hash)
+		if err != nil || len(decoded) != sha256.Size {
+			return fmt.Errorf("invalid TLS pin %q: expected a base64 encoded SHA-256 hash", pin)
+		}
+	}
+	return nil
+}
+
+// VerifyTLSPins returns a VerifyConnection function of a tls.Config that refuses the connection
+// with ErrTLSPinMismatch unless the verified certificate chain contains one of the pinned keys.
+// It runs after the regular certificate verification, which it does not replace.
+// Without pins it returns nil, so it can be assigned unconditionally.
+func VerifyTLSPins(pins []string) (func(tls.ConnectionState) error, error) {
+	if len(pins) == 0 {
+		return nil, nil
+	}
+	if err := ValidateTLSPins(pins); err != nil {
+		return nil, err
+	}
+	pinned := make(map[string]bool, len(pins))
+	for _, pin := range pins {
+		pinned[pin] = true
+	}
+	return func(cs tls.ConnectionState) error {
+		for _, chain := range cs.VerifiedChains {
+			for _, cert := range chain {
+				if pinned[TLSKeyPin(cert)] {
+					return nil
+				}
+			}
+		}
+		// without verified chains (InsecureSkipVerify) only the key of the leaf can be trusted
+		if len(cs.VerifiedChains) == 0 && len(cs.PeerCertificates) > 0 && pinned[TLSKeyPin(cs.PeerCertificates[0])] {
+			return nil
+		}
+		return fmt.Errorf("%w (server: %s)", esv1beta1.ErrTLSPinMismatch, cs.ServerName)
+	}, nil
+}
+
+// PinTLSConfig sets the VerifyConnection function of cfg to VerifyTLSPins.
+func PinTLSConfig(cfg *tls.Config, pins []string) error {
+	if cfg == nil {
+		return errors.New("no TLS config to pin")
+	}
+	verify, err := VerifyTLSPins(pins)
+	if err != nil {
+		return err
+	}
+	if verify != nil {
+		cfg.VerifyConnection = verify
+	}
+	return nil
+}
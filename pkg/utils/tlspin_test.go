@@ -0,0 +1,110 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+)
+
+const otherPin = "sha256/AAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA="
+
+func TestValidateTLSPins(t *testing.T) {
+	tests := []struct {
+		name    string
+		pins    []string
+		wantErr bool
+	}{
+		{name: "no pins"},
+		{name: "valid pin", pins: []string{otherPin}},
+		{name: "missing prefix", pins: []string{"AAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA="}, wantErr: true},
+		{name: "not base64", pins: []string{"sha256/not-base64"}, wantErr: true},
+		{name: "wrong hash size", pins: []string{"sha256/AAAA"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidateTLSPins(tt.pins); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateTLSPins() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestVerifyTLSPins(t *testing.T) {
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+	defer ts.Close()
+	leaf := ts.Certificate()
+	intermediate := &x509.Certificate{RawSubjectPublicKeyInfo: []byte("intermediate")}
+
+	tests := []struct {
+		name    string
+		pins    []string
+		state   tls.ConnectionState
+		wantErr bool
+	}{
+		{
+			name:  "pinned leaf",
+			pins:  []string{otherPin, TLSKeyPin(leaf)},
+			state: tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{leaf, intermediate}}},
+		},
+		{
+			name:  "pinned intermediate",
+			pins:  []string{TLSKeyPin(intermediate)},
+			state: tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{leaf, intermediate}}},
+		},
+		{
+			name:    "no pinned key in the chain",
+			pins:    []string{otherPin},
+			state:   tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{leaf, intermediate}}},
+			wantErr: true,
+		},
+		{
+			name:    "unverified intermediate is not trusted",
+			pins:    []string{TLSKeyPin(intermediate)},
+			state:   tls.ConnectionState{PeerCertificates: []*x509.Certificate{leaf, intermediate}},
+			wantErr: true,
+		},
+		{
+			name:  "unverified leaf",
+			pins:  []string{TLSKeyPin(leaf)},
+			state: tls.ConnectionState{PeerCertificates: []*x509.Certificate{leaf, intermediate}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			verify, err := VerifyTLSPins(tt.pins)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			err = verify(tt.state)
+			if tt.wantErr != errors.Is(err, esv1beta1.ErrTLSPinMismatch) {
+				t.Errorf("verify() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	if verify, err := VerifyTLSPins(nil); verify != nil || err != nil {
+		t.Errorf("expected no verification without pins, got %v", err)
+	}
+	if _, err := VerifyTLSPins([]string{"invalid"}); err == nil {
+		t.Errorf("expected an error for an invalid pin")
+	}
+}
@@ -0,0 +1,85 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"bytes"
+	"fmt"
+	"maps"
+	"slices"
+	"unicode/utf8"
+
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/charmap"
+	"golang.org/x/text/encoding/japanese"
+	"golang.org/x/text/encoding/korean"
+	"golang.org/x/text/encoding/simplifiedchinese"
+	"golang.org/x/text/encoding/traditionalchinese"
+	"golang.org/x/text/encoding/unicode"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+)
+
+var sourceEncodings = map[esv1beta1.ExternalSecretSourceEncoding]encoding.Encoding{
+	esv1beta1.ExternalSecretSourceEncodingLatin1:      charmap.ISO8859_1,
+	esv1beta1.ExternalSecretSourceEncodingLatin9:      charmap.ISO8859_15,
+	esv1beta1.ExternalSecretSourceEncodingWindows1250: charmap.Windows1250,
+	esv1beta1.ExternalSecretSourceEncodingWindows1251: charmap.Windows1251,
+	esv1beta1.ExternalSecretSourceEncodingWindows1252: charmap.Windows1252,
+	esv1beta1.ExternalSecretSourceEncodingShiftJIS:    japanese.ShiftJIS,
+	esv1beta1.ExternalSecretSourceEncodingEUCJP:       japanese.EUCJP,
+	esv1beta1.ExternalSecretSourceEncodingGBK:         simplifiedchinese.GBK,
+	esv1beta1.ExternalSecretSourceEncodingBig5:        traditionalchinese.Big5,
+	esv1beta1.ExternalSecretSourceEncodingEUCKR:       korean.EUCKR,
+	esv1beta1.ExternalSecretSourceEncodingUTF16LE:     unicode.UTF16(unicode.LittleEndian, unicode.UseBOM),
+	esv1beta1.ExternalSecretSourceEncodingUTF16BE:     unicode.UTF16(unicode.BigEndian, unicode.UseBOM),
+}
+
+// TranscodeMap converts the values of a secretMap to UTF-8.
+func TranscodeMap(sourceEncoding esv1beta1.ExternalSecretSourceEncoding, policy esv1beta1.ExternalSecretInvalidEncodingPolicy, in map[string][]byte) (map[string][]byte, error) {
+	if sourceEncoding == "" || sourceEncoding == esv1beta1.ExternalSecretSourceEncodingUTF8 {
+		return in, nil
+	}
+	out := make(map[string][]byte, len(in))
+	for _, k := range slices.Sorted(maps.Keys(in)) {
+		val, err := Transcode(sourceEncoding, policy, in[k])
+		if err != nil {
+			return nil, fmt.Errorf("failure converting key %v: %w", k, err)
+		}
+		out[k] = val
+	}
+	return out, nil
+}
+
+// Transcode converts in from the source encoding to UTF-8.
+// The decoders replace invalid byte sequences with U+FFFD, with the Fail policy
+// the conversion fails instead. The error never contains the value.
+func Transcode(sourceEncoding esv1beta1.ExternalSecretSourceEncoding, policy esv1beta1.ExternalSecretInvalidEncodingPolicy, in []byte) ([]byte, error) {
+	if sourceEncoding == "" || sourceEncoding == esv1beta1.ExternalSecretSourceEncodingUTF8 {
+		return in, nil
+	}
+	enc, ok := sourceEncodings[sourceEncoding]
+	if !ok {
+		return nil, fmt.Errorf("source encoding %v is not supported", sourceEncoding)
+	}
+	out, err := enc.NewDecoder().Bytes(in)
+	if err != nil {
+		return nil, fmt.Errorf("unable to convert from %v: %w", sourceEncoding, err)
+	}
+	if policy != esv1beta1.ExternalSecretInvalidEncodingReplace && bytes.ContainsRune(out, utf8.RuneError) {
+		return nil, fmt.Errorf("value contains byte sequences that are invalid in %v", sourceEncoding)
+	}
+	return out, nil
+}
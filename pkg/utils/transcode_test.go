@@ -0,0 +1,137 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/encoding/charmap"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+)
+
+func TestTranscode(t *testing.T) {
+	tests := []struct {
+		name     string
+		encoding esv1beta1.ExternalSecretSourceEncoding
+		policy   esv1beta1.ExternalSecretInvalidEncodingPolicy
+		in       []byte
+		want     string
+		wantErr  string
+	}{
+		{
+			name: "no encoding",
+			in:   []byte("p\xe4ss"),
+			want: "p\xe4ss",
+		},
+		{
+			name:     "utf8 is not converted",
+			encoding: esv1beta1.ExternalSecretSourceEncodingUTF8,
+			in:       []byte("pässwörd"),
+			want:     "pässwörd",
+		},
+		{
+			name:     "latin1",
+			encoding: esv1beta1.ExternalSecretSourceEncodingLatin1,
+			in:       []byte("p\xe4ssw\xf6rd \xa3"),
+			want:     "pässwörd £",
+		},
+		{
+			name:     "latin9 euro sign",
+			encoding: esv1beta1.ExternalSecretSourceEncodingLatin9,
+			in:       []byte("\xa4100"),
+			want:     "€100",
+		},
+		{
+			name:     "windows1252 euro sign",
+			encoding: esv1beta1.ExternalSecretSourceEncodingWindows1252,
+			in:       []byte("\x80100"),
+			want:     "€100",
+		},
+		{
+			name:     "shift jis",
+			encoding: esv1beta1.ExternalSecretSourceEncodingShiftJIS,
+			in:       []byte("\x83p\x83X\x83\x8f\x81[\x83h"),
+			want:     "パスワード",
+		},
+		{
+			name:     "utf16 little endian",
+			encoding: esv1beta1.ExternalSecretSourceEncodingUTF16LE,
+			in:       []byte("h\x00\xe9\x00"),
+			want:     "hé",
+		},
+		{
+			name:     "invalid sequence fails",
+			encoding: esv1beta1.ExternalSecretSourceEncodingShiftJIS,
+			in:       []byte("ok\x81"),
+			wantErr:  "value contains byte sequences that are invalid in ShiftJIS",
+		},
+		{
+			name:     "invalid sequence is replaced",
+			encoding: esv1beta1.ExternalSecretSourceEncodingShiftJIS,
+			policy:   esv1beta1.ExternalSecretInvalidEncodingReplace,
+			in:       []byte("ok\x81"),
+			want:     "ok�",
+		},
+		{
+			name:     "unsupported encoding",
+			encoding: "EBCDIC",
+			in:       []byte("value"),
+			wantErr:  "source encoding EBCDIC is not supported",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Transcode(tt.encoding, tt.policy, tt.in)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, string(got))
+		})
+	}
+}
+
+func TestTranscodeLatin1RoundTrip(t *testing.T) {
+	// every byte is a valid Latin-1 character
+	in := make([]byte, 256)
+	for i := range in {
+		in[i] = byte(i)
+	}
+	got, err := Transcode(esv1beta1.ExternalSecretSourceEncodingLatin1, "", in)
+	assert.NoError(t, err)
+	assert.Equal(t, 256, len([]rune(string(got))))
+
+	back, err := charmap.ISO8859_1.NewEncoder().Bytes(got)
+	assert.NoError(t, err)
+	assert.Equal(t, in, back)
+}
+
+func TestTranscodeMap(t *testing.T) {
+	got, err := TranscodeMap(esv1beta1.ExternalSecretSourceEncodingLatin1, "", map[string][]byte{
+		"user": []byte("j\xfcrgen"),
+		"pass": []byte("gr\xfc\xdf"),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string][]byte{"user": []byte("jürgen"), "pass": []byte("grüß")}, got)
+
+	_, err = TranscodeMap(esv1beta1.ExternalSecretSourceEncodingShiftJIS, "", map[string][]byte{"key": []byte("\x81")})
+	if err == nil || !strings.Contains(err.Error(), "failure converting key key") {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
@@ -18,6 +18,7 @@ import (
 	"bytes"
 	"context"
 	"crypto/md5" //nolint:gosec
+	"crypto/sha256"
 	"crypto/x509"
 	"encoding/base64"
 	"encoding/json"
@@ -203,7 +204,7 @@ func ValidateKeys(in map[string][]byte) error {
 			return fmt.Errorf("key has length %d but max is 253: (following is truncated): %s", keyLength, key[:253])
 		}
 		for _, c := range key {
-			if !unicode.IsLetter(c) && !unicode.IsNumber(c) && c != '-' && c != '.' && c != '_' {
+			if !isValidKeyChar(c) {
 				return fmt.Errorf("key has invalid character %c, only alphanumeric, '-', '.' and '_' are allowed: %s", c, key)
 			}
 		}
@@ -211,6 +212,50 @@ func ValidateKeys(in map[string][]byte) error {
 	return nil
 }
 
+// SanitizeKeys replaces every character that is not allowed in a Kubernetes secret key with `_`.
+// Valid keys are preserved, an error is returned if two keys are equal after sanitization.
+func SanitizeKeys(in map[string][]byte) (map[string][]byte, error) {
+	out := make(map[string][]byte, len(in))
+	for k, v := range in {
+		key := strings.Map(func(c rune) rune {
+			if isValidKeyChar(c) {
+				return c
+			}
+			return '_'
+		}, k)
+		if _, exists := out[key]; exists {
+			return nil, fmt.Errorf("secret key collision during sanitization: %s", key)
+		}
+		out[key] = v
+	}
+	return out, nil
+}
+
+// AffixKeys adds prefix and suffix to every key of the map.
+// An error is returned if a resulting key is not a valid secret key or collides with another key.
+func AffixKeys(prefix, suffix string, in map[string][]byte) (map[string][]byte, error) {
+	if prefix == "" && suffix == "" {
+		return in, nil
+	}
+	out := make(map[string][]byte, len(in))
+	for k, v := range in {
+		key := prefix + k + suffix
+		if _, exists := out[key]; exists {
+			return nil, fmt.Errorf("secret key collision after adding prefix/suffix: %s", key)
+		}
+		out[key] = v
+	}
+	if err := ValidateKeys(out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// isValidKeyChar reports whether c is allowed in a secret key, i.e. matches `[-._a-zA-Z0-9]`.
+func isValidKeyChar(c rune) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9') || c == '-' || c == '.' || c == '_'
+}
+
 // ConvertKeys converts a secret map into a valid key.
 // Replaces any non-alphanumeric characters depending on convert strategy.
 func ConvertKeys(strategy esv1beta1.ExternalSecretConversionStrategy, in map[string][]byte) (map[string][]byte, error) {
@@ -358,6 +403,11 @@ func ObjectHash(object any) string {
 	return fmt.Sprintf("%x", md5.Sum([]byte(textualVersion)))
 }
 
+// ValueDigest calculates the sha256 digest of a value in the form `sha256:<hex>`.
+func ValueDigest(value []byte) string {
+	return fmt.Sprintf("sha256:%x", sha256.Sum256(value))
+}
+
 func ErrorContains(out error, want string) bool {
 	if out == nil {
 		return want == ""
@@ -371,6 +421,8 @@ func ErrorContains(out error, want string) bool {
 var (
 	errNamespaceNotAllowed = errors.New("namespace should either be empty or match the namespace of the SecretStore for a namespaced SecretStore")
 	errRequireNamespace    = errors.New("cluster scope requires namespace")
+	errStoreRefKind        = errors.New("storeRef of a SecretStore must refer to a SecretStore, of a ClusterSecretStore to a ClusterSecretStore")
+	errStoreRefUnsupported = errors.New("storeRef is not supported by this provider, the credentials must be read from a Kubernetes Secret")
 )
 
 // ValidateSecretSelector just checks if the namespace field is present/absent
@@ -378,6 +430,9 @@ var (
 // We MUST NOT check the name or key property here. It MAY be defaulted by the provider.
 func ValidateSecretSelector(store esv1beta1.GenericStore, ref esmeta.SecretKeySelector) error {
 	clusterScope := store.GetObjectKind().GroupVersionKind().Kind == esv1beta1.ClusterSecretStoreKind
+	if ref.StoreRef != nil {
+		return validateStoreRef(clusterScope, ref.StoreRef)
+	}
 	if clusterScope && ref.Namespace == nil {
 		return errRequireNamespace
 	}
@@ -393,12 +448,32 @@ func ValidateSecretSelector(store esv1beta1.GenericStore, ref esmeta.SecretKeySe
 // support referent auth.
 func ValidateReferentSecretSelector(store esv1beta1.GenericStore, ref esmeta.SecretKeySelector) error {
 	clusterScope := store.GetObjectKind().GroupVersionKind().Kind == esv1beta1.ClusterSecretStoreKind
+	if ref.StoreRef != nil {
+		return validateStoreRef(clusterScope, ref.StoreRef)
+	}
 	if !clusterScope && ref.Namespace != nil && *ref.Namespace != store.GetNamespace() {
 		return errNamespaceNotAllowed
 	}
 	return nil
 }
 
+// validateStoreRef checks that a store only refers to stores of its own scope.
+func validateStoreRef(clusterScope bool, ref *esmeta.StoreSecretRef) error {
+	if clusterScope != (ref.Kind == esv1beta1.ClusterSecretStoreKind) {
+		return errStoreRefKind
+	}
+	return nil
+}
+
+// ValidateNoStoreRef rejects a storeRef for providers that read
+// more than a single key of the referenced Kubernetes Secret.
+func ValidateNoStoreRef(ref esmeta.SecretKeySelector) error {
+	if ref.StoreRef != nil {
+		return errStoreRefUnsupported
+	}
+	return nil
+}
+
 // ValidateServiceAccountSelector just checks if the namespace field is present/absent
 // depending on the secret store type.
 // We MUST NOT check the name or key property here. It MAY be defaulted by the provider.
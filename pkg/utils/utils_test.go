@@ -18,6 +18,7 @@ import (
 	"encoding/json"
 	"errors"
 	"reflect"
+	"strings"
 	"testing"
 	"time"
 
@@ -83,6 +84,32 @@ func TestObjectHash(t *testing.T) {
 	}
 }
 
+func TestValueDigest(t *testing.T) {
+	tests := []struct {
+		name  string
+		input []byte
+		want  string
+	}{
+		{
+			name:  "empty value",
+			input: nil,
+			want:  "sha256:e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855",
+		},
+		{
+			name:  "simple value",
+			input: []byte("hello there"),
+			want:  "sha256:12998c017066eb0d2a70b94e6ed3192985855ce390f321bbdb832022888bd251",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ValueDigest(tt.input); got != tt.want {
+				t.Errorf("ValueDigest() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestIsNil(t *testing.T) {
 	tbl := []struct {
 		name string
@@ -243,6 +270,142 @@ func TestConvertKeys(t *testing.T) {
 	}
 }
 
+func TestSanitizeKeys(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      map[string][]byte
+		want    map[string][]byte
+		wantErr bool
+	}{
+		{
+			name: "sanitize invalid characters",
+			in: map[string][]byte{
+				"/foo/bar baz": []byte(`noop`),
+				"foo:bar@baz":  []byte(`noop`),
+			},
+			want: map[string][]byte{
+				"_foo_bar_baz": []byte(`noop`),
+				"foo_bar_baz":  []byte(`noop`),
+			},
+		},
+		{
+			name: "preserve valid keys",
+			in: map[string][]byte{
+				"foo-bar.baz_bing": []byte(`noop`),
+				"FOO123":           []byte(`noop`),
+			},
+			want: map[string][]byte{
+				"foo-bar.baz_bing": []byte(`noop`),
+				"FOO123":           []byte(`noop`),
+			},
+		},
+		{
+			name: "sanitize non-ascii characters",
+			in: map[string][]byte{
+				"föö": []byte(`noop`),
+				"٣":   []byte(`noop`),
+			},
+			want: map[string][]byte{
+				"f__": []byte(`noop`),
+				"_":   []byte(`noop`),
+			},
+		},
+		{
+			name: "error on collision with sanitized key",
+			in: map[string][]byte{
+				"foo/bar": []byte(`noop`),
+				"foo$bar": []byte(`noop`),
+			},
+			wantErr: true,
+		},
+		{
+			name: "error on collision with valid key",
+			in: map[string][]byte{
+				"foo/bar": []byte(`noop`),
+				"foo_bar": []byte(`noop`),
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := SanitizeKeys(tt.in)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("SanitizeKeys() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("SanitizeKeys() = %v, want %v", got, tt.want)
+			}
+			if err := ValidateKeys(got); err != nil {
+				t.Errorf("ValidateKeys() error = %v", err)
+			}
+		})
+	}
+}
+
+func TestAffixKeys(t *testing.T) {
+	tests := []struct {
+		name    string
+		prefix  string
+		suffix  string
+		in      map[string][]byte
+		want    map[string][]byte
+		wantErr bool
+	}{
+		{
+			name: "no prefix and suffix",
+			in: map[string][]byte{
+				"foo": []byte(`noop`),
+			},
+			want: map[string][]byte{
+				"foo": []byte(`noop`),
+			},
+		},
+		{
+			name:   "prefix and suffix",
+			prefix: "DB_",
+			suffix: ".env",
+			in: map[string][]byte{
+				"user":     []byte(`noop`),
+				"password": []byte(`noop`),
+			},
+			want: map[string][]byte{
+				"DB_user.env":     []byte(`noop`),
+				"DB_password.env": []byte(`noop`),
+			},
+		},
+		{
+			name:   "error on too long key",
+			prefix: strings.Repeat("a", 250),
+			in: map[string][]byte{
+				"user": []byte(`noop`),
+			},
+			wantErr: true,
+		},
+		{
+			name:   "error on invalid key",
+			prefix: "db/",
+			in: map[string][]byte{
+				"user": []byte(`noop`),
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := AffixKeys(tt.prefix, tt.suffix, tt.in)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("AffixKeys() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("AffixKeys() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestReverseKeys(t *testing.T) {
 	type args struct {
 		encodingStrategy esv1beta1.ExternalSecretConversionStrategy
@@ -990,6 +1153,38 @@ func TestValidateSecretSelector(t *testing.T) {
 			},
 			expected: errNamespaceNotAllowed,
 		},
+		{
+			desc: "cluster secret store with cluster store reference",
+			store: &esv1beta1.ClusterSecretStore{
+				TypeMeta: metav1.TypeMeta{
+					Kind: esv1beta1.ClusterSecretStoreKind,
+				},
+			},
+			ref: esmetav1.SecretKeySelector{
+				StoreRef: &esmetav1.StoreSecretRef{
+					Name:      "bootstrap",
+					Kind:      esv1beta1.ClusterSecretStoreKind,
+					RemoteKey: "token",
+				},
+			},
+			expected: nil,
+		},
+		{
+			desc: "secret store with cluster store reference",
+			store: &esv1beta1.SecretStore{
+				TypeMeta: metav1.TypeMeta{
+					Kind: esv1beta1.SecretStoreKind,
+				},
+			},
+			ref: esmetav1.SecretKeySelector{
+				StoreRef: &esmetav1.StoreSecretRef{
+					Name:      "bootstrap",
+					Kind:      esv1beta1.ClusterSecretStoreKind,
+					RemoteKey: "token",
+				},
+			},
+			expected: errStoreRefKind,
+		},
 	}
 
 	for _, tt := range tests {
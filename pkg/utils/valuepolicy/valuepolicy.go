@@ -0,0 +1,79 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package valuepolicy checks secret values against the length and complexity rules of a SecretValuePolicy,
+// e.g. an organization's password policy.
+package valuepolicy
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	esmeta "github.com/external-secrets/external-secrets/apis/meta/v1"
+)
+
+// ErrViolation is wrapped by the errors of values that do not comply with the policy.
+var ErrViolation = errors.New("value does not comply with the policy")
+
+// Check returns an error that lists every rule of the policy the value does not comply with.
+// The error never contains the value. A nil policy accepts every value.
+func Check(policy *esmeta.SecretValuePolicy, value []byte) error {
+	if policy == nil {
+		return nil
+	}
+	var violations []string
+	length := utf8.RuneCount(value)
+	if length < policy.MinLength {
+		violations = append(violations, fmt.Sprintf("length %d is below the minimum of %d", length, policy.MinLength))
+	}
+	if policy.MaxLength != nil && length > *policy.MaxLength {
+		violations = append(violations, fmt.Sprintf("length %d is above the maximum of %d", length, *policy.MaxLength))
+	}
+	for _, class := range policy.RequiredClasses {
+		if !containsClass(value, class) {
+			violations = append(violations, fmt.Sprintf("no %s character", strings.ToLower(string(class))))
+		}
+	}
+	if len(violations) > 0 {
+		return fmt.Errorf("%w: %s", ErrViolation, strings.Join(violations, ", "))
+	}
+	return nil
+}
+
+// containsClass returns true if the value contains at least one character of the class.
+func containsClass(value []byte, class esmeta.CharacterClass) bool {
+	for _, r := range string(value) {
+		if inClass(r, class) {
+			return true
+		}
+	}
+	return false
+}
+
+func inClass(r rune, class esmeta.CharacterClass) bool {
+	switch class {
+	case esmeta.CharacterClassUppercase:
+		return unicode.IsUpper(r)
+	case esmeta.CharacterClassLowercase:
+		return unicode.IsLower(r)
+	case esmeta.CharacterClassDigit:
+		return unicode.IsDigit(r)
+	case esmeta.CharacterClassSymbol:
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && !unicode.IsSpace(r)
+	}
+	return false
+}
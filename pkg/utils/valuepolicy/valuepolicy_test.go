@@ -0,0 +1,126 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package valuepolicy
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"k8s.io/utils/ptr"
+
+	esmeta "github.com/external-secrets/external-secrets/apis/meta/v1"
+)
+
+func TestCheck(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy *esmeta.SecretValuePolicy
+		value  string
+		// wantViolations are the expected parts of the error, none if empty
+		wantViolations []string
+	}{
+		{
+			name:  "nil policy accepts every value",
+			value: "",
+		},
+		{
+			name:           "too short",
+			policy:         &esmeta.SecretValuePolicy{MinLength: 12},
+			value:          "s3cr3t",
+			wantViolations: []string{"length 6 is below the minimum of 12"},
+		},
+		{
+			name:   "length counts characters, not bytes",
+			policy: &esmeta.SecretValuePolicy{MinLength: 4, MaxLength: ptr.To(4)},
+			value:  "äöüß",
+		},
+		{
+			name:           "too long",
+			policy:         &esmeta.SecretValuePolicy{MaxLength: ptr.To(4)},
+			value:          "secret",
+			wantViolations: []string{"length 6 is above the maximum of 4"},
+		},
+		{
+			name:           "missing uppercase",
+			policy:         &esmeta.SecretValuePolicy{RequiredClasses: []esmeta.CharacterClass{esmeta.CharacterClassUppercase}},
+			value:          "secret",
+			wantViolations: []string{"no uppercase character"},
+		},
+		{
+			name:           "missing lowercase",
+			policy:         &esmeta.SecretValuePolicy{RequiredClasses: []esmeta.CharacterClass{esmeta.CharacterClassLowercase}},
+			value:          "SECRET",
+			wantViolations: []string{"no lowercase character"},
+		},
+		{
+			name:           "missing digit",
+			policy:         &esmeta.SecretValuePolicy{RequiredClasses: []esmeta.CharacterClass{esmeta.CharacterClassDigit}},
+			value:          "secret",
+			wantViolations: []string{"no digit character"},
+		},
+		{
+			name:           "whitespace is not a symbol",
+			policy:         &esmeta.SecretValuePolicy{RequiredClasses: []esmeta.CharacterClass{esmeta.CharacterClassSymbol}},
+			value:          "sec ret",
+			wantViolations: []string{"no symbol character"},
+		},
+		{
+			name: "every violation is listed",
+			policy: &esmeta.SecretValuePolicy{
+				MinLength:       8,
+				RequiredClasses: []esmeta.CharacterClass{esmeta.CharacterClassUppercase, esmeta.CharacterClassDigit},
+			},
+			value:          "abc",
+			wantViolations: []string{"length 3 is below the minimum of 8", "no uppercase character", "no digit character"},
+		},
+		{
+			name: "compliant value",
+			policy: &esmeta.SecretValuePolicy{
+				MinLength: 8,
+				MaxLength: ptr.To(16),
+				RequiredClasses: []esmeta.CharacterClass{
+					esmeta.CharacterClassUppercase,
+					esmeta.CharacterClassLowercase,
+					esmeta.CharacterClassDigit,
+					esmeta.CharacterClassSymbol,
+				},
+			},
+			value: "Sup3r-Secret",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Check(tt.policy, []byte(tt.value))
+			if len(tt.wantViolations) == 0 {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if !errors.Is(err, ErrViolation) {
+				t.Fatalf("expected ErrViolation, got %v", err)
+			}
+			for _, v := range tt.wantViolations {
+				if !strings.Contains(err.Error(), v) {
+					t.Errorf("expected %q in error %q", v, err.Error())
+				}
+			}
+			if tt.value != "" && strings.Contains(err.Error(), tt.value) {
+				t.Errorf("error must not contain the value: %q", err.Error())
+			}
+		})
+	}
+}
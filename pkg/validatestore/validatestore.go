@@ -0,0 +1,307 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package validatestore validates a SecretStore or ClusterSecretStore manifest
+// without a cluster, e.g. in a CI pipeline.
+package validatestore
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+
+	// Loading registered providers.
+	_ "github.com/external-secrets/external-secrets/pkg/provider/register"
+)
+
+const (
+	defaultNamespace = "default"
+
+	errReadFile        = "unable to read %s: %w"
+	errDecode          = "unable to decode %s: %w"
+	errUnexpectedKind  = "%s: expected a SecretStore or ClusterSecretStore, got %s"
+	errUnexpectedObj   = "%s: expected a Secret, got %s"
+	errInvalidEnvRef   = "invalid secret env reference %q, expected name/key=ENV_VAR"
+	errEnvNotSet       = "environment variable %s is not set"
+	errNoProvider      = "no provider configured"
+	errValidateFailed  = "validation failed"
+	errClientNotReady  = "client validation returned %s"
+	msgClientUnknown   = "the provider can not validate the client"
+	msgSkippedValidate = "skipped"
+)
+
+// ErrValidationFailed is returned by Run if any step failed.
+var ErrValidationFailed = errors.New(errValidateFailed)
+
+var scheme = runtime.NewScheme()
+
+func init() {
+	utilruntime.Must(corev1.AddToScheme(scheme))
+	utilruntime.Must(esv1beta1.AddToScheme(scheme))
+}
+
+// Options configures Run.
+type Options struct {
+	// StorePath is the path of the SecretStore or ClusterSecretStore manifest.
+	StorePath string
+	// SecretPaths are paths of Secret manifests referenced by the store.
+	// A file may contain multiple YAML documents.
+	SecretPaths []string
+	// SecretEnv creates Secret keys from environment variables, in the form name/key=ENV_VAR.
+	SecretEnv []string
+	// Namespace is used for Secrets without a namespace and as the namespace
+	// of the client. Defaults to the namespace of a SecretStore or `default`.
+	Namespace string
+	// SkipClientValidation skips the call of the provider API,
+	// only the store is validated and the client is created.
+	SkipClientValidation bool
+	// LookupEnv looks up environment variables, defaults to os.LookupEnv.
+	LookupEnv func(string) (string, bool)
+}
+
+// Run loads the store and the referenced Secrets, runs ValidateStore,
+// creates the provider client and validates it. The result of every step
+// is written to out. It returns ErrValidationFailed if a step failed.
+func Run(ctx context.Context, opts Options, out io.Writer) error {
+	r := &reporter{out: out}
+	secretsClient, err := newClient(ctx, opts, r)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = secretsClient.Close(ctx)
+	}()
+
+	if opts.SkipClientValidation {
+		r.ok("validate client", msgSkippedValidate)
+		return nil
+	}
+	result, err := secretsClient.Validate()
+	switch {
+	case err != nil:
+		r.fail("validate client", err)
+		return ErrValidationFailed
+	case result == esv1beta1.ValidationResultError:
+		r.fail("validate client", fmt.Errorf(errClientNotReady, result))
+		return ErrValidationFailed
+	case result == esv1beta1.ValidationResultUnknown:
+		r.ok("validate client", msgClientUnknown)
+	default:
+		r.ok("validate client", "")
+	}
+	return nil
+}
+
+// newClient loads the store and the referenced Secrets, runs ValidateStore
+// and creates the provider client. The caller must close the client.
+func newClient(ctx context.Context, opts Options, r *reporter) (esv1beta1.SecretsClient, error) {
+	store, err := loadStore(opts.StorePath)
+	if err != nil {
+		r.fail("load store", err)
+		return nil, ErrValidationFailed
+	}
+	namespace := opts.Namespace
+	if namespace == "" {
+		namespace = store.GetNamespace()
+	}
+	if namespace == "" {
+		namespace = defaultNamespace
+	}
+	if store.GetKind() == esv1beta1.SecretStoreKind && store.GetNamespace() == "" {
+		store.SetNamespace(namespace)
+	}
+	r.ok("load store", fmt.Sprintf("%s %s", store.GetKind(), client.ObjectKeyFromObject(store)))
+
+	provider, err := esv1beta1.GetProvider(store)
+	if err == nil && provider == nil {
+		err = errors.New(errNoProvider)
+	}
+	if err != nil {
+		r.fail("resolve provider", err)
+		return nil, ErrValidationFailed
+	}
+	r.ok("resolve provider", "")
+
+	warnings, err := provider.ValidateStore(store)
+	for _, w := range warnings {
+		r.warn("validate store", w)
+	}
+	if err != nil {
+		r.fail("validate store", err)
+		return nil, ErrValidationFailed
+	}
+	r.ok("validate store", "")
+
+	secrets, err := loadSecrets(opts, namespace)
+	if err != nil {
+		r.fail("load secrets", err)
+		return nil, ErrValidationFailed
+	}
+	r.ok("load secrets", fmt.Sprintf("%d secret(s)", len(secrets)))
+
+	kube := fake.NewClientBuilder().WithScheme(scheme).WithObjects(secrets...).Build()
+	secretsClient, err := provider.NewClient(ctx, store, kube, namespace)
+	if err != nil {
+		r.fail("create client", err)
+		return nil, ErrValidationFailed
+	}
+	r.ok("create client", "")
+	return secretsClient, nil
+}
+
+// loadStore reads a SecretStore or ClusterSecretStore manifest.
+func loadStore(path string) (esv1beta1.GenericStore, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf(errReadFile, path, err)
+	}
+	obj, _, err := serializer.NewCodecFactory(scheme).UniversalDeserializer().Decode(data, nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf(errDecode, path, err)
+	}
+	store, ok := obj.(esv1beta1.GenericStore)
+	if !ok {
+		return nil, fmt.Errorf(errUnexpectedKind, path, obj.GetObjectKind().GroupVersionKind().Kind)
+	}
+	return store, nil
+}
+
+// loadSecrets reads the Secret manifests and creates the Secrets from environment variables.
+func loadSecrets(opts Options, namespace string) ([]client.Object, error) {
+	secrets := make(map[client.ObjectKey]*corev1.Secret)
+	for _, path := range opts.SecretPaths {
+		fileSecrets, err := readSecrets(path)
+		if err != nil {
+			return nil, err
+		}
+		for _, s := range fileSecrets {
+			if s.Namespace == "" {
+				s.Namespace = namespace
+			}
+			// the API server merges stringData into data, the in-memory client does not
+			if s.Data == nil {
+				s.Data = make(map[string][]byte)
+			}
+			for k, v := range s.StringData {
+				s.Data[k] = []byte(v)
+			}
+			s.StringData = nil
+			secrets[client.ObjectKeyFromObject(s)] = s
+		}
+	}
+
+	lookupEnv := opts.LookupEnv
+	if lookupEnv == nil {
+		lookupEnv = os.LookupEnv
+	}
+	for _, ref := range opts.SecretEnv {
+		nameKey, envVar, ok := strings.Cut(ref, "=")
+		name, key, ok2 := strings.Cut(nameKey, "/")
+		if !ok || !ok2 || name == "" || key == "" || envVar == "" {
+			return nil, fmt.Errorf(errInvalidEnvRef, ref)
+		}
+		value, ok := lookupEnv(envVar)
+		if !ok {
+			return nil, fmt.Errorf(errEnvNotSet, envVar)
+		}
+		objKey := client.ObjectKey{Namespace: namespace, Name: name}
+		s, ok := secrets[objKey]
+		if !ok {
+			s = &corev1.Secret{}
+			s.Name = name
+			s.Namespace = namespace
+			s.Data = make(map[string][]byte)
+			secrets[objKey] = s
+		}
+		s.Data[key] = []byte(value)
+	}
+
+	out := make([]client.Object, 0, len(secrets))
+	for _, s := range secrets {
+		out = append(out, s)
+	}
+	return out, nil
+}
+
+// readSecrets reads all Secrets of a multi document YAML file.
+func readSecrets(path string) ([]*corev1.Secret, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf(errReadFile, path, err)
+	}
+	decoder := serializer.NewCodecFactory(scheme).UniversalDeserializer()
+	reader := utilyaml.NewYAMLReader(bufio.NewReader(bytes.NewReader(data)))
+	var secrets []*corev1.Secret
+	for {
+		doc, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return secrets, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf(errDecode, path, err)
+		}
+		if len(bytes.TrimSpace(doc)) == 0 {
+			continue
+		}
+		obj, _, err := decoder.Decode(doc, nil, nil)
+		if err != nil {
+			return nil, fmt.Errorf(errDecode, path, err)
+		}
+		secret, ok := obj.(*corev1.Secret)
+		if !ok {
+			return nil, fmt.Errorf(errUnexpectedObj, path, obj.GetObjectKind().GroupVersionKind().Kind)
+		}
+		secrets = append(secrets, secret)
+	}
+}
+
+// reporter writes the result of every step.
+type reporter struct {
+	out io.Writer
+}
+
+func (r *reporter) ok(step, msg string) {
+	r.print("OK", step, msg)
+}
+
+func (r *reporter) warn(step, msg string) {
+	r.print("WARN", step, msg)
+}
+
+func (r *reporter) fail(step string, err error) {
+	r.print("FAIL", step, err.Error())
+}
+
+func (r *reporter) print(status, step, msg string) {
+	line := fmt.Sprintf("%-6s %s", "["+status+"]", step)
+	if msg != "" {
+		line += ": " + msg
+	}
+	fmt.Fprintln(r.out, line)
+}
@@ -0,0 +1,221 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validatestore
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const fakeStore = `apiVersion: external-secrets.io/v1beta1
+kind: SecretStore
+metadata:
+  name: fake
+spec:
+  provider:
+    fake:
+      data:
+      - key: foo
+        value: bar
+`
+
+const invalidFakeStore = `apiVersion: external-secrets.io/v1beta1
+kind: ClusterSecretStore
+metadata:
+  name: fake
+spec:
+  provider:
+    fake:
+      data:
+      - key: foo
+`
+
+const dopplerStore = `apiVersion: external-secrets.io/v1beta1
+kind: ClusterSecretStore
+metadata:
+  name: doppler
+spec:
+  provider:
+    doppler:
+      auth:
+        secretRef:
+          dopplerToken:
+            name: doppler-token
+            namespace: ci
+            key: token
+`
+
+const tokenSecrets = `apiVersion: v1
+kind: Secret
+metadata:
+  name: other
+---
+apiVersion: v1
+kind: Secret
+metadata:
+  name: doppler-token
+  namespace: ci
+stringData:
+  token: dp.st.from-file
+`
+
+func writeFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestRun(t *testing.T) {
+	env := map[string]string{"DOPPLER_TOKEN": "dp.st.from-env"}
+	lookupEnv := func(k string) (string, bool) {
+		v, ok := env[k]
+		return v, ok
+	}
+
+	tests := []struct {
+		name    string
+		opts    Options
+		wantErr bool
+		wantOut []string
+		denyOut []string
+	}{
+		{
+			name: "valid store",
+			opts: Options{StorePath: writeFile(t, "store.yaml", fakeStore)},
+			wantOut: []string{
+				"[OK]   load store: SecretStore default/fake",
+				"[OK]   validate store",
+				"[OK]   create client",
+				"[OK]   validate client",
+			},
+		},
+		{
+			name:    "invalid store",
+			opts:    Options{StorePath: writeFile(t, "store.yaml", invalidFakeStore)},
+			wantErr: true,
+			wantOut: []string{"[FAIL] validate store: "},
+			denyOut: []string{"create client"},
+		},
+		{
+			name:    "missing file",
+			opts:    Options{StorePath: filepath.Join(t.TempDir(), "missing.yaml")},
+			wantErr: true,
+			wantOut: []string{"[FAIL] load store: unable to read"},
+		},
+		{
+			name:    "not a store",
+			opts:    Options{StorePath: writeFile(t, "store.yaml", tokenSecrets[:strings.Index(tokenSecrets, "---")])},
+			wantErr: true,
+			wantOut: []string{"[FAIL] load store: ", "expected a SecretStore or ClusterSecretStore, got Secret"},
+		},
+		{
+			name: "credentials from file",
+			opts: Options{
+				StorePath:            writeFile(t, "store.yaml", dopplerStore),
+				SecretPaths:          []string{writeFile(t, "secrets.yaml", tokenSecrets)},
+				SkipClientValidation: true,
+			},
+			wantOut: []string{
+				"[OK]   load secrets: 2 secret(s)",
+				"[OK]   create client",
+				"[OK]   validate client: skipped",
+			},
+		},
+		{
+			name: "credentials from env",
+			opts: Options{
+				StorePath:            writeFile(t, "store.yaml", dopplerStore),
+				SecretEnv:            []string{"doppler-token/token=DOPPLER_TOKEN"},
+				Namespace:            "ci",
+				SkipClientValidation: true,
+				LookupEnv:            lookupEnv,
+			},
+			wantOut: []string{"[OK]   create client"},
+		},
+		{
+			name: "missing credentials",
+			opts: Options{
+				StorePath:            writeFile(t, "store.yaml", dopplerStore),
+				SkipClientValidation: true,
+			},
+			wantErr: true,
+			wantOut: []string{"[FAIL] create client: "},
+		},
+		{
+			name: "unset env variable",
+			opts: Options{
+				StorePath: writeFile(t, "store.yaml", dopplerStore),
+				SecretEnv: []string{"doppler-token/token=UNSET"},
+				LookupEnv: lookupEnv,
+			},
+			wantErr: true,
+			wantOut: []string{"[FAIL] load secrets: environment variable UNSET is not set"},
+		},
+		{
+			name: "invalid env reference",
+			opts: Options{
+				StorePath: writeFile(t, "store.yaml", dopplerStore),
+				SecretEnv: []string{"doppler-token=DOPPLER_TOKEN"},
+				LookupEnv: lookupEnv,
+			},
+			wantErr: true,
+			wantOut: []string{`[FAIL] load secrets: invalid secret env reference "doppler-token=DOPPLER_TOKEN"`},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			err := Run(context.Background(), tt.opts, &out)
+			if tt.wantErr != errors.Is(err, ErrValidationFailed) {
+				t.Fatalf("unexpected error %v, output:\n%s", err, out.String())
+			}
+			for _, want := range tt.wantOut {
+				if !strings.Contains(out.String(), want) {
+					t.Errorf("expected output to contain %q, got:\n%s", want, out.String())
+				}
+			}
+			for _, deny := range tt.denyOut {
+				if strings.Contains(out.String(), deny) {
+					t.Errorf("expected output not to contain %q, got:\n%s", deny, out.String())
+				}
+			}
+			if strings.Contains(out.String(), "dp.st.") {
+				t.Errorf("output must not contain credentials:\n%s", out.String())
+			}
+		})
+	}
+}
+
+func TestListVersions(t *testing.T) {
+	var out, log bytes.Buffer
+	err := ListVersions(context.Background(), Options{StorePath: writeFile(t, "store.yaml", fakeStore)}, "foo", &out, &log)
+	if !errors.Is(err, ErrValidationFailed) {
+		t.Fatalf("unexpected error %v, output:\n%s", err, log.String())
+	}
+	if want := "[FAIL] list versions: provider does not support listing secret versions"; !strings.Contains(log.String(), want) {
+		t.Errorf("expected output to contain %q, got:\n%s", want, log.String())
+	}
+	if out.Len() != 0 {
+		t.Errorf("expected no versions, got:\n%s", out.String())
+	}
+}
@@ -0,0 +1,58 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validatestore
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+)
+
+// ListVersions creates the provider client like Run and lists the versions
+// of the remote secret key. The steps are written to log, the versions
+// are written to out as a table. It returns ErrValidationFailed if a step failed.
+func ListVersions(ctx context.Context, opts Options, key string, out, log io.Writer) error {
+	r := &reporter{out: log}
+	secretsClient, err := newClient(ctx, opts, r)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = secretsClient.Close(ctx)
+	}()
+
+	versions, err := esv1beta1.ListSecretVersions(ctx, secretsClient, esv1beta1.ExternalSecretDataRemoteRef{Key: key})
+	if err != nil {
+		r.fail("list versions", err)
+		return ErrValidationFailed
+	}
+	r.ok("list versions", fmt.Sprintf("%d version(s)", len(versions)))
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "VERSION\tCREATED\tATTRIBUTES")
+	for _, v := range versions {
+		created := ""
+		if !v.CreatedAt.IsZero() {
+			created = v.CreatedAt.UTC().Format(time.RFC3339)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\n", v.Version, created, strings.Join(v.Attributes, ","))
+	}
+	return w.Flush()
+}